@@ -79,6 +79,10 @@ func pullFlags(cmd *cobra.Command) {
 		certDirFlagName := "cert-dir"
 		flags.StringVar(&pullOptions.CertDirPath, certDirFlagName, "", "`Pathname` of a directory containing TLS certificates and keys")
 		_ = cmd.RegisterFlagCompletionFunc(certDirFlagName, completion.AutocompleteDefault)
+
+		signaturePolicyFlagName := "signature-policy"
+		flags.StringVar(&pullOptions.SignaturePolicyPath, signaturePolicyFlagName, "", "`Pathname` of signature policy file (not usually used)")
+		_ = flags.MarkHidden(signaturePolicyFlagName)
 	}
 }
 
@@ -0,0 +1,63 @@
+package artifact
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	refreshCmd = &cobra.Command{
+		Use:   "refresh [options] CONTAINER [CONTAINER...]",
+		Short: "Refresh the artifacts mounted into containers",
+		Long: `Refresh the artifact volumes mounted with the refresh option into running containers.
+
+  The content of a volume is atomically swapped when its artifact changed in local storage. Volumes mounted with refresh-pull pull their artifact first. The IDs of the containers whose artifacts were refreshed are printed.`,
+		RunE:              refresh,
+		Args:              checkAllAndContainers,
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman artifact refresh mywebapp
+  podman artifact refresh --all`,
+	}
+
+	refreshOptions = entities.ArtifactRefreshOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: refreshCmd,
+		Parent:  artifactCmd,
+	})
+	flags := refreshCmd.Flags()
+	flags.BoolVarP(&refreshOptions.All, "all", "a", false, "Refresh the artifacts of all running containers")
+}
+
+func refresh(_ *cobra.Command, args []string) error {
+	refreshOptions.Containers = args
+
+	reports, err := registry.ImageEngine().ArtifactRefresh(registry.Context(), refreshOptions)
+	if err != nil {
+		return err
+	}
+	for _, report := range reports {
+		if len(report.Refreshed) > 0 {
+			fmt.Println(report.Id)
+		}
+	}
+	return nil
+}
+
+func checkAllAndContainers(c *cobra.Command, args []string) error {
+	all, _ := c.Flags().GetBool("all")
+	if all && len(args) > 0 {
+		return errors.New("when using the --all switch, you may not pass any container names or IDs")
+	}
+	if !all && len(args) < 1 {
+		return errors.New("at least one container name or ID must be specified")
+	}
+	return nil
+}
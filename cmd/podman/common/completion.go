@@ -286,6 +286,50 @@ func getManifestListMembers(cmd *cobra.Command, list, toComplete string) ([]stri
 	return suggestions, cobra.ShellCompDirectiveNoFileComp
 }
 
+func getSessions(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	suggestions := []string{}
+
+	engine, err := setupContainerEngine(cmd)
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	sessions, err := engine.SessionList(registry.Context(), entities.SessionListOptions{})
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	for _, s := range sessions {
+		if strings.HasPrefix(s.ID, toComplete) {
+			suggestions = append(suggestions, s.ID[0:min(12, len(s.ID))])
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func getNetworkPolicies(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	suggestions := []string{}
+
+	engine, err := setupContainerEngine(cmd)
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	policies, err := engine.NetworkPolicyList(registry.Context())
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	for _, p := range policies {
+		if strings.HasPrefix(p.Name, toComplete) {
+			suggestions = append(suggestions, p.Name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
 func getSecrets(cmd *cobra.Command, toComplete string, cType completeType) ([]string, cobra.ShellCompDirective) {
 	suggestions := []string{}
 
@@ -738,6 +782,22 @@ func AutocompleteSecrets(cmd *cobra.Command, args []string, toComplete string) (
 	return getSecrets(cmd, toComplete, completeDefault)
 }
 
+// AutocompleteSessions - Autocomplete session recording IDs.
+func AutocompleteSessions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return getSessions(cmd, toComplete)
+}
+
+// AutocompleteNetworkPolicies - Autocomplete network policies.
+func AutocompleteNetworkPolicies(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return getNetworkPolicies(cmd, toComplete)
+}
+
 func AutocompleteSecretCreate(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
 	if len(args) == 1 {
 		return nil, cobra.ShellCompDirectiveDefault
@@ -745,6 +805,21 @@ func AutocompleteSecretCreate(_ *cobra.Command, args []string, _ string) ([]stri
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteSecretUpdate - Autocomplete secret update options.
+// -> secrets, then files
+func AutocompleteSecretUpdate(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	switch len(args) {
+	case 0:
+		return getSecrets(cmd, toComplete, completeDefault)
+	case 1:
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteImages - Autocomplete images.
 func AutocompleteImages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -958,6 +1033,21 @@ func AutocompleteContainerOneArg(cmd *cobra.Command, args []string, toComplete s
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteContainerAndFile - Autocomplete a container as first and a file
+// as second arg.
+func AutocompleteContainerAndFile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if len(args) == 0 {
+		return getContainers(cmd, toComplete, completeDefault)
+	}
+	if len(args) == 1 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteNetworkConnectCmd - Autocomplete podman network connect/disconnect command args.
 func AutocompleteNetworkConnectCmd(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if len(args) == 0 {
@@ -1183,6 +1273,13 @@ func AutocompletePullOption(_ *cobra.Command, _ []string, _ string) ([]string, c
 	return pullOptions, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteNetworkPolicyType - Autocomplete network policy types.
+// -> "ingress", "egress"
+func AutocompleteNetworkPolicyType(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	types := []string{"ingress", "egress"}
+	return types, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteRestartOption - Autocomplete restart options for create and run command.
 // -> "always", "no", "on-failure", "unless-stopped"
 func AutocompleteRestartOption(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
@@ -1350,6 +1447,15 @@ func AutocompleteNetworkFlag(cmd *cobra.Command, _ []string, toComplete string)
 	return append(networks, suggestions...), dir
 }
 
+// AutocompleteNetworkOpt - Autocomplete network-opt flag options.
+func AutocompleteNetworkOpt(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	kv := keyValueCompletion{
+		"ingress-rate=": nil,
+		"egress-rate=":  nil,
+	}
+	return completeKeyValues(toComplete, kv)
+}
+
 type formatSuggestion struct {
 	fieldname string
 	suffix    string
@@ -1599,7 +1705,7 @@ func AutocompleteEventFilter(cmd *cobra.Command, _ []string, toComplete string)
 	event := func(_ string) ([]string, cobra.ShellCompDirective) {
 		return []string{
 			events.Attach.String(), events.AutoUpdate.String(), events.Checkpoint.String(), events.Cleanup.String(),
-			events.Commit.String(), events.Create.String(), events.Exec.String(), events.ExecDied.String(),
+			events.Commit.String(), events.Create.String(), events.Debug.String(), events.Exec.String(), events.ExecDied.String(),
 			events.Exited.String(), events.Export.String(), events.Import.String(), events.Init.String(), events.Kill.String(),
 			events.LoadFromArchive.String(), events.Mount.String(), events.NetworkConnect.String(),
 			events.NetworkDisconnect.String(), events.Pause.String(), events.Prune.String(), events.Pull.String(),
@@ -1706,6 +1812,21 @@ func AutocompletePsSort(_ *cobra.Command, _ []string, _ string) ([]string, cobra
 	return sortBy, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteGraphFormat - Autocomplete podman graph --format options.
+func AutocompleteGraphFormat(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{"tree", "dot", "json"}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteTopSort - Autocomplete podman top --sort options.
+func AutocompleteTopSort(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{"pid", "cpu", "rss", "io", "fds"}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteTopFormat - Autocomplete podman top --format options.
+func AutocompleteTopFormat(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{"table", "json"}, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteImageSaveFormat - Autocomplete image save format options.
 func AutocompleteImageSaveFormat(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 	return ValidSaveFormats, cobra.ShellCompDirectiveNoFileComp
@@ -1722,6 +1843,27 @@ func AutocompleteWaitCondition(_ *cobra.Command, _ []string, _ string) ([]string
 	return states, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteRestoreConflict - Autocomplete system restore conflict policies.
+// -> "fail", "replace", "skip"
+func AutocompleteRestoreConflict(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	policies := []string{entities.RestoreConflictFail, entities.RestoreConflictReplace, entities.RestoreConflictSkip}
+	return policies, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteSBOMFormat - Autocomplete SBOM formats.
+// -> "cyclonedx", "spdx"
+func AutocompleteSBOMFormat(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	formats := []string{"cyclonedx", "spdx"}
+	return formats, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteSeverity - Autocomplete vulnerability severities.
+// -> "low", "medium", "high", "critical"
+func AutocompleteSeverity(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	severities := []string{"low", "medium", "high", "critical"}
+	return severities, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteCgroupManager - Autocomplete cgroup manager options.
 // -> "cgroupfs", "systemd"
 func AutocompleteCgroupManager(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
@@ -1825,6 +1967,9 @@ func AutocompletePsFilters(cmd *cobra.Command, _ []string, toComplete string) ([
 		},
 		"until=":  nil,
 		"volume=": func(s string) ([]string, cobra.ShellCompDirective) { return getVolumes(cmd, s) },
+		"vulnerable=": func(_ string) ([]string, cobra.ShellCompDirective) {
+			return []string{"true", "false", "low", "medium", "high", "critical"}, cobra.ShellCompDirectiveNoFileComp
+		},
 	}
 	return completeKeyValues(toComplete, kv)
 }
@@ -1949,6 +2094,19 @@ func AutocompleteSecretFilters(cmd *cobra.Command, _ []string, toComplete string
 	return completeKeyValues(toComplete, kv)
 }
 
+// AutocompleteSessionFilters - Autocomplete session ls --filter options.
+func AutocompleteSessionFilters(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	kv := keyValueCompletion{
+		"container=": func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeDefault) },
+		"exec=":      nil,
+		"type=": func(_ string) ([]string, cobra.ShellCompDirective) {
+			return []string{"attach", "exec"}, cobra.ShellCompDirectiveNoFileComp
+		},
+		"user=": nil,
+	}
+	return completeKeyValues(toComplete, kv)
+}
+
 // AutocompleteCheckpointCompressType - Autocomplete checkpoint compress type options.
 // -> "gzip", "none", "zstd"
 func AutocompleteCheckpointCompressType(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
@@ -88,6 +88,13 @@ func DefineNetFlags(cmd *cobra.Command) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(networkAliasFlagName, completion.AutocompleteNone)
 
+	networkOptFlagName := "network-opt"
+	netFlags.StringArray(
+		networkOptFlagName, nil,
+		"Limit the bandwidth of network attachments ([network:]ingress-rate=rate,egress-rate=rate)",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(networkOptFlagName, AutocompleteNetworkOpt)
+
 	publishFlagName := "publish"
 	netFlags.StringSliceP(
 		publishFlagName, "p", []string{},
@@ -223,6 +230,17 @@ func NetFlagsToNetOptions(opts *entities.NetOptions, flags pflag.FlagSet) (*enti
 		opts.Networks = networks
 	}
 
+	if flags.Changed("network-opt") {
+		networkOpts, err := flags.GetStringArray("network-opt")
+		if err != nil {
+			return nil, err
+		}
+		opts.NetworkRateLimits, err = specgen.ParseNetworkRateLimits(networkOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if flags.Changed("ip") || flags.Changed("ip6") || flags.Changed("mac-address") || flags.Changed("network-alias") {
 		// if there is no network we add the default
 		if len(opts.Networks) == 0 {
@@ -21,12 +21,14 @@ import (
 
 var composeCommand = &cobra.Command{
 	Use:   "compose [options]",
-	Short: "Run compose workloads via an external provider such as docker-compose or podman-compose",
-	Long: `This command is a thin wrapper around an external compose provider such as docker-compose or podman-compose.  This means that podman compose is executing another tool that implements the compose functionality but sets up the environment in a way to let the compose provider communicate transparently with the local Podman socket.  The specified options as well the command and argument are passed directly to the compose provider.
+	Short: "Run compose workloads",
+	Long: `Run compose workloads.  The up, down, ps, logs and config commands are implemented by Podman itself: it reads the compose files and creates the containers, pod, networks, volumes and secrets of the project directly.
+
+All other commands are passed to an external compose provider such as docker-compose or podman-compose.  This means that podman compose is executing another tool that implements the compose functionality but sets up the environment in a way to let the compose provider communicate transparently with the local Podman socket.  The specified options as well the command and argument are passed directly to the compose provider.
 
 The default compose providers are docker-compose and podman-compose.  If installed, docker-compose takes precedence since it is the original implementation of the Compose specification and is widely used on the supported platforms (i.e., Linux, Mac OS, Windows).
 
-If you want to change the default behavior or have a custom installation path for your provider of choice, please change the compose_providers field in containers.conf(5) to compose_providers = ["/path/to/provider"]. You may also set the PODMAN_COMPOSE_PROVIDER environment variable.`,
+If you want to change the default behavior or have a custom installation path for your provider of choice, please change the compose_providers field in containers.conf(5) to compose_providers = ["/path/to/provider"], or set the PODMAN_COMPOSE_PROVIDER environment variable.  If PODMAN_COMPOSE_PROVIDER is set, all commands are passed to the provider, including the ones implemented by Podman.`,
 	RunE:              composeMain,
 	ValidArgsFunction: composeCompletion,
 	Example: `podman compose -f nginx.yaml up --detach
@@ -36,21 +38,26 @@ If you want to change the default behavior or have a custom installation path fo
 }
 
 func init() {
-	// NOTE: we need to fully disable flag parsing and manually parse the
-	// flags in composeMain. cobra's FParseErrWhitelist will strip off
-	// unknown flags _before_ the first argument.  So `--unknown argument`
+	// NOTE: flag parsing is disabled unless the native compose engine
+	// registers its subcommands, and composeMain passes the arguments as
+	// given to the compose provider. cobra's FParseErrWhitelist will strip
+	// off unknown flags _before_ the first argument.  So `--unknown argument`
 	// will show as `argument`.
 
 	registry.Commands = append(registry.Commands, registry.CliCommand{Command: composeCommand})
 }
 
-func composeCompletion(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+func composeCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	var stdout strings.Builder
 
 	args = append(args, toComplete)
 	args = append([]string{"__complete"}, args...)
 	if err := composeProviderExec(args, &stdout, io.Discard, false); err != nil {
 		// Ignore errors since some providers may not expose a __complete command.
+		if cmd.HasSubCommands() {
+			// Keep the completion of the native commands.
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
 		return nil, cobra.ShellCompDirectiveError
 	}
 
@@ -95,6 +102,14 @@ func composeProvider() (string, error) {
 	return "", fmt.Errorf("looking up compose provider failed\n%v", errorhandling.JoinErrors(lookupErrors))
 }
 
+// composeProviderConfigured returns true if the compose provider was chosen
+// with PODMAN_COMPOSE_PROVIDER. All commands are passed to that provider then,
+// including the ones implemented by the native compose engine.
+func composeProviderConfigured() bool {
+	_, ok := os.LookupEnv("PODMAN_COMPOSE_PROVIDER")
+	return ok
+}
+
 // composeDockerHost returns the value to be set in the DOCKER_HOST environment
 // variable.
 func composeDockerHost() (string, error) {
@@ -240,6 +255,10 @@ func composeHelp(cmd *cobra.Command) error {
 	if err := tmpl.Execute(os.Stdout, cmd); err != nil {
 		return err
 	}
+	if cmd.HasSubCommands() {
+		fmt.Print("Other commands are passed to the external compose provider.\n")
+		return nil
+	}
 
 	shouldLog, err := composeShouldLogWarning()
 	if err != nil {
@@ -250,6 +269,16 @@ func composeHelp(cmd *cobra.Command) error {
 
 // composeMain is the main function of the compose command.
 func composeMain(cmd *cobra.Command, args []string) error {
+	if !cmd.DisableFlagParsing {
+		// cobra parsed the flags of the native compose engine and
+		// stripped unknown ones, get the arguments as given.
+		_, rawArgs, err := cmd.Root().Traverse(os.Args[1:])
+		if err != nil {
+			return err
+		}
+		args = rawArgs
+	}
+
 	// We have to manually parse the flags here to make sure all arguments
 	// after `podman compose [ARGS]` are passed to the compose provider.
 	// For now, we only look for the --help flag.
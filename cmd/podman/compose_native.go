@@ -0,0 +1,470 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/compose"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/util"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+)
+
+// composeNativeOptions are the global options of the native compose engine.
+type composeNativeOptions struct {
+	files      []string
+	project    string
+	projectDir string
+	envFiles   []string
+	profiles   []string
+}
+
+var (
+	composeOpts composeNativeOptions
+
+	composeConfigCommand = &cobra.Command{
+		Use:               "config [options] [SERVICE...]",
+		Short:             "Validate and print the resolved compose file",
+		Long:              "Validate the compose files of the project and print the resolved project, with variables substituted and all files merged.",
+		RunE:              composeConfig,
+		ValidArgsFunction: composeServiceCompletion,
+		Example: `podman compose config
+  podman compose -f compose.yaml config --services`,
+	}
+
+	composeDownCommand = &cobra.Command{
+		Use:               "down [options]",
+		Short:             "Stop and remove the containers, pod, networks and secrets of the project",
+		Long:              "Stop and remove the containers, pod, networks and secrets of the project.  Named volumes are kept unless --volumes is given.",
+		Args:              validate.NoArgs,
+		RunE:              composeDown,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman compose down
+  podman compose down --volumes`,
+	}
+
+	composeLogsCommand = &cobra.Command{
+		Use:               "logs [options] [SERVICE...]",
+		Short:             "Show the logs of the containers of the project",
+		Long:              "Show the logs of the containers of the project, or of the given services only.",
+		RunE:              composeLogs,
+		ValidArgsFunction: composeServiceCompletion,
+		Example: `podman compose logs
+  podman compose logs --follow web`,
+	}
+
+	composePsCommand = &cobra.Command{
+		Use:               "ps [options] [SERVICE...]",
+		Short:             "List the containers of the project",
+		Long:              "List the running containers of the project, or of the given services only.",
+		RunE:              composePs,
+		ValidArgsFunction: composeServiceCompletion,
+		Example: `podman compose ps
+  podman compose ps --all --format "{{.Name}} {{.Status}}"`,
+	}
+
+	composeUpCommand = &cobra.Command{
+		Use:               "up [options] [SERVICE...]",
+		Short:             "Create and start the containers of the project",
+		Long:              "Create the pod, networks, volumes and secrets of the project and create and start the containers of its services, or of the given services and their dependencies only.",
+		RunE:              composeUp,
+		ValidArgsFunction: composeServiceCompletion,
+		Example: `podman compose up --detach
+  podman compose -f compose.yaml up web`,
+	}
+)
+
+var (
+	composeConfigOpts struct {
+		quiet    bool
+		services bool
+		volumes  bool
+		profiles bool
+	}
+	composeDownOpts    compose.DownOptions
+	composeDownTimeout uint
+	composeLogsOpts    struct {
+		entities.ContainerLogsOptions
+		since string
+		until string
+	}
+	composePsOpts struct {
+		all      bool
+		format   string
+		quiet    bool
+		services bool
+	}
+	composeUpOpts    compose.UpOptions
+	composeUpDetach  bool
+	composeUpTimeout uint
+)
+
+func init() {
+	// A compose provider chosen with PODMAN_COMPOSE_PROVIDER runs all
+	// commands, including the ones implemented here, and the compose
+	// command parses no flags at all then.
+	if composeProviderConfigured() {
+		return
+	}
+	composeCommand.DisableFlagParsing = false
+	// Unknown flags are for the compose provider, composeMain passes the
+	// arguments as given to it.
+	composeCommand.FParseErrWhitelist.UnknownFlags = true
+	composeCommand.SetHelpFunc(composeHelpFunc)
+
+	// The global options are given before the command like with every
+	// compose provider, the flags following it belong to the command.
+	flags := composeCommand.Flags()
+	flags.SetInterspersed(false)
+
+	fileFlagName := "file"
+	flags.StringArrayVarP(&composeOpts.files, fileFlagName, "f", nil, "Compose configuration `file`s")
+	_ = composeCommand.RegisterFlagCompletionFunc(fileFlagName, completion.AutocompleteDefault)
+
+	projectNameFlagName := "project-name"
+	flags.StringVarP(&composeOpts.project, projectNameFlagName, "p", "", "Project `name`")
+	_ = composeCommand.RegisterFlagCompletionFunc(projectNameFlagName, completion.AutocompleteNone)
+
+	projectDirFlagName := "project-directory"
+	flags.StringVar(&composeOpts.projectDir, projectDirFlagName, "", "Working `directory` of the project, defaults to the directory of the first compose file")
+	_ = composeCommand.RegisterFlagCompletionFunc(projectDirFlagName, completion.AutocompleteDefault)
+
+	envFileFlagName := "env-file"
+	flags.StringArrayVar(&composeOpts.envFiles, envFileFlagName, nil, "Environment `file`s used for variable interpolation")
+	_ = composeCommand.RegisterFlagCompletionFunc(envFileFlagName, completion.AutocompleteDefault)
+
+	profileFlagName := "profile"
+	flags.StringArrayVar(&composeOpts.profiles, profileFlagName, nil, "Profiles to enable")
+	_ = composeCommand.RegisterFlagCompletionFunc(profileFlagName, completion.AutocompleteNone)
+
+	for _, cmd := range []*cobra.Command{composeConfigCommand, composeDownCommand, composeLogsCommand, composePsCommand, composeUpCommand} {
+		registry.Commands = append(registry.Commands, registry.CliCommand{
+			Command: cmd,
+			Parent:  composeCommand,
+		})
+	}
+
+	flags = composeConfigCommand.Flags()
+	flags.BoolVarP(&composeConfigOpts.quiet, "quiet", "q", false, "Only validate the configuration, do not print anything")
+	flags.BoolVar(&composeConfigOpts.services, "services", false, "Print the service names, one per line")
+	flags.BoolVar(&composeConfigOpts.volumes, "volumes", false, "Print the volume names, one per line")
+	flags.BoolVar(&composeConfigOpts.profiles, "profiles", false, "Print the profile names, one per line")
+
+	flags = composeDownCommand.Flags()
+	flags.BoolVarP(&composeDownOpts.Volumes, "volumes", "v", false, "Remove the named volumes of the project and the anonymous volumes of its containers")
+	flags.BoolVar(&composeDownOpts.RemoveOrphans, "remove-orphans", false, "Remove containers of services not defined in the compose file")
+	timeoutFlagName := "timeout"
+	flags.UintVarP(&composeDownTimeout, timeoutFlagName, "t", 0, "Seconds to wait for containers to stop before killing them")
+	_ = composeDownCommand.RegisterFlagCompletionFunc(timeoutFlagName, completion.AutocompleteNone)
+
+	flags = composeLogsCommand.Flags()
+	flags.BoolVarP(&composeLogsOpts.Follow, "follow", "f", false, "Follow log output")
+	tailFlagName := "tail"
+	flags.Int64Var(&composeLogsOpts.Tail, tailFlagName, -1, "Number of lines to show from the end of the logs, -1 shows all lines")
+	_ = composeLogsCommand.RegisterFlagCompletionFunc(tailFlagName, completion.AutocompleteNone)
+	flags.BoolVarP(&composeLogsOpts.Timestamps, "timestamps", "t", false, "Show timestamps")
+	sinceFlagName := "since"
+	flags.StringVar(&composeLogsOpts.since, sinceFlagName, "", "Show logs since TIMESTAMP")
+	_ = composeLogsCommand.RegisterFlagCompletionFunc(sinceFlagName, completion.AutocompleteNone)
+	untilFlagName := "until"
+	flags.StringVar(&composeLogsOpts.until, untilFlagName, "", "Show logs until TIMESTAMP")
+	_ = composeLogsCommand.RegisterFlagCompletionFunc(untilFlagName, completion.AutocompleteNone)
+
+	flags = composePsCommand.Flags()
+	flags.BoolVarP(&composePsOpts.all, "all", "a", false, "Show stopped containers as well")
+	formatFlagName := "format"
+	flags.StringVar(&composePsOpts.format, formatFlagName, "", "Pretty-print containers using a Go template")
+	_ = composePsCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&composePsReport{}))
+	flags.BoolVarP(&composePsOpts.quiet, "quiet", "q", false, "Only show container IDs")
+	flags.BoolVar(&composePsOpts.services, "services", false, "Only show the services")
+
+	flags = composeUpCommand.Flags()
+	flags.BoolVarP(&composeUpDetach, "detach", "d", false, "Run the containers in the background")
+	flags.BoolVar(&composeUpOpts.ForceRecreate, "force-recreate", false, "Recreate containers even if their configuration did not change")
+	flags.BoolVar(&composeUpOpts.NoRecreate, "no-recreate", false, "Do not recreate existing containers")
+	flags.BoolVar(&composeUpOpts.NoStart, "no-start", false, "Create the containers without starting them")
+	pullFlagName := "pull"
+	flags.StringVar(&composeUpOpts.Pull, pullFlagName, "", `Pull images before creating the containers ("always"|"missing"|"never"|"newer")`)
+	_ = composeUpCommand.RegisterFlagCompletionFunc(pullFlagName, common.AutocompletePullOption)
+	flags.BoolVar(&composeUpOpts.RemoveOrphans, "remove-orphans", false, "Remove containers of services not defined in the compose file")
+	flags.UintVarP(&composeUpTimeout, timeoutFlagName, "t", 0, "Seconds to wait for containers to stop when they are recreated or stopped")
+	_ = composeUpCommand.RegisterFlagCompletionFunc(timeoutFlagName, completion.AutocompleteNone)
+}
+
+// composeHelpFunc shows the help of the compose command with composeHelp,
+// and the help of its subcommands like for every other command.
+func composeHelpFunc(cmd *cobra.Command, args []string) {
+	if cmd != composeCommand {
+		cmd.Root().HelpFunc()(cmd, args)
+		return
+	}
+	if err := composeHelp(cmd); err != nil {
+		fmt.Fprintln(os.Stderr, formatError(err))
+	}
+}
+
+// composeServiceCompletion completes the services of the project.
+func composeServiceCompletion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	p, err := composeOpts.load(nil)
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return p.ServiceNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func (opts *composeNativeOptions) load(services []string) (*compose.Project, error) {
+	return compose.Load(compose.LoadOptions{
+		Files:       opts.files,
+		ProjectName: opts.project,
+		ProjectDir:  opts.projectDir,
+		EnvFiles:    opts.envFiles,
+		Profiles:    opts.profiles,
+		Services:    services,
+		Environ:     os.Environ(),
+	})
+}
+
+func composeEngine() *compose.Engine {
+	return &compose.Engine{
+		Containers: registry.ContainerEngine(),
+		Images:     registry.ImageEngine(),
+		Out:        os.Stderr,
+	}
+}
+
+// composeTimeout returns the value of a timeout flag if it was set.
+func composeTimeout(cmd *cobra.Command, timeout uint) *uint {
+	if !cmd.Flags().Changed("timeout") {
+		return nil
+	}
+	return &timeout
+}
+
+func composeUp(cmd *cobra.Command, args []string) error {
+	p, err := composeOpts.load(args)
+	if err != nil {
+		return err
+	}
+	composeUpOpts.Services = args
+	composeUpOpts.Timeout = composeTimeout(cmd, composeUpTimeout)
+	engine := composeEngine()
+	names, err := engine.Up(registry.Context(), p, composeUpOpts)
+	if err != nil || composeUpDetach || composeUpOpts.NoStart {
+		return err
+	}
+
+	// Attach to the logs of the containers until they exit or the
+	// user interrupts and stops them.
+	ctx, cancel := context.WithCancel(registry.Context())
+	defer cancel()
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	done := make(chan error, 1)
+	go func() {
+		done <- engine.Containers.ContainerLogs(ctx, names, entities.ContainerLogsOptions{
+			Follow:       true,
+			Names:        true,
+			Tail:         -1,
+			StdoutWriter: os.Stdout,
+			StderrWriter: os.Stderr,
+		})
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-sigChan:
+		fmt.Fprintln(os.Stderr, "Gracefully stopping... (press Ctrl+C again to force)")
+		go func() {
+			<-sigChan
+			os.Exit(130)
+		}()
+		cancel()
+		return engine.Stop(registry.Context(), p, composeUpOpts.Timeout)
+	}
+}
+
+func composeDown(cmd *cobra.Command, _ []string) error {
+	p, err := composeOpts.load(nil)
+	if err != nil {
+		return err
+	}
+	composeDownOpts.Timeout = composeTimeout(cmd, composeDownTimeout)
+	return composeEngine().Down(registry.Context(), p, composeDownOpts)
+}
+
+// composePsReport is a container of a compose project in the output of
+// podman compose ps.
+type composePsReport struct {
+	entities.ListContainer
+	Name    string
+	Service string
+}
+
+func (c composePsReport) CreatedHuman() string {
+	return units.HumanDuration(time.Since(c.Created)) + " ago"
+}
+
+func (c composePsReport) Status() string {
+	switch c.State {
+	case define.ContainerStateRunning.String():
+		return "Up " + units.HumanDuration(time.Since(time.Unix(c.StartedAt, 0)))
+	case define.ContainerStateStopped.String(), define.ContainerStateExited.String():
+		return fmt.Sprintf("Exited (%d) %s ago", c.ExitCode, units.HumanDuration(time.Since(time.Unix(c.ExitedAt, 0))))
+	default:
+		return c.State
+	}
+}
+
+func (c composePsReport) PortsString() string {
+	ports := make([]string, 0, len(c.Ports))
+	for _, port := range c.Ports {
+		hostIP := port.HostIP
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
+		}
+		for i := range max(port.Range, 1) {
+			ports = append(ports, fmt.Sprintf("%s:%d->%d/%s", hostIP, port.HostPort+i, port.ContainerPort+i, port.Protocol))
+		}
+	}
+	return strings.Join(ports, ", ")
+}
+
+func composePs(cmd *cobra.Command, args []string) error {
+	p, err := composeOpts.load(args)
+	if err != nil {
+		return err
+	}
+	ctrs, err := composeEngine().ListContainers(registry.Context(), p, args)
+	if err != nil {
+		return err
+	}
+	reports := make([]composePsReport, 0, len(ctrs))
+	for _, c := range ctrs {
+		if !composePsOpts.all && c.State != define.ContainerStateRunning.String() {
+			continue
+		}
+		reports = append(reports, composePsReport{ListContainer: c, Name: c.Names[0], Service: c.Labels[compose.ServiceLabel]})
+	}
+
+	switch {
+	case composePsOpts.quiet:
+		for _, r := range reports {
+			fmt.Println(r.ID)
+		}
+		return nil
+	case composePsOpts.services:
+		seen := make(map[string]bool)
+		for _, r := range reports {
+			if !seen[r.Service] {
+				seen[r.Service] = true
+				fmt.Println(r.Service)
+			}
+		}
+		return nil
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, composePsOpts.format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, "{{range .}}{{.Name}}\t{{.Image}}\t{{.Service}}\t{{.CreatedHuman}}\t{{.Status}}\t{{.PortsString}}\n{{end -}}")
+	}
+	if err != nil {
+		return err
+	}
+	if rpt.RenderHeaders {
+		headers := []map[string]string{{
+			"Name": "NAME", "Image": "IMAGE", "Service": "SERVICE",
+			"CreatedHuman": "CREATED", "Status": "STATUS", "PortsString": "PORTS",
+		}}
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reports)
+}
+
+func composeLogs(_ *cobra.Command, args []string) error {
+	var err error
+	if composeLogsOpts.since != "" {
+		if composeLogsOpts.Since, err = util.ParseInputTime(composeLogsOpts.since, true); err != nil {
+			return fmt.Errorf("parsing --since %q: %w", composeLogsOpts.since, err)
+		}
+	}
+	if composeLogsOpts.until != "" {
+		if composeLogsOpts.Until, err = util.ParseInputTime(composeLogsOpts.until, false); err != nil {
+			return fmt.Errorf("parsing --until %q: %w", composeLogsOpts.until, err)
+		}
+	}
+	p, err := composeOpts.load(args)
+	if err != nil {
+		return err
+	}
+	for _, name := range args {
+		if _, ok := p.Services[name]; !ok {
+			return fmt.Errorf("no such service: %s", name)
+		}
+	}
+	composeLogsOpts.StdoutWriter = os.Stdout
+	composeLogsOpts.StderrWriter = os.Stderr
+	return composeEngine().Logs(registry.Context(), p, args, composeLogsOpts.ContainerLogsOptions)
+}
+
+func composeConfig(_ *cobra.Command, args []string) error {
+	p, err := composeOpts.load(args)
+	if err != nil {
+		return err
+	}
+	switch {
+	case composeConfigOpts.quiet:
+		return nil
+	case composeConfigOpts.services:
+		for _, name := range p.ServiceNames() {
+			fmt.Println(name)
+		}
+	case composeConfigOpts.volumes:
+		for _, name := range slices.Sorted(maps.Keys(p.Volumes)) {
+			fmt.Println(name)
+		}
+	case composeConfigOpts.profiles:
+		set := make(map[string]bool)
+		for _, svc := range p.Services {
+			for _, profile := range svc.Profiles {
+				set[profile] = true
+			}
+		}
+		for _, svc := range p.DisabledServices {
+			for _, profile := range svc.Profiles {
+				set[profile] = true
+			}
+		}
+		for _, profile := range slices.Sorted(maps.Keys(set)) {
+			fmt.Println(profile)
+		}
+	default:
+		data, err := p.YAML()
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return nil
+}
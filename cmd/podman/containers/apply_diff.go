@@ -0,0 +1,52 @@
+package containers
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	applyDiffDescription = `Applies a layer tarball, as written by "podman diff --export", to the root filesystem of a container.
+
+  Files in the layer are added to or replace the files of the container, whiteouts remove files from the container.  If FILE is "-" or not given, the layer is read from stdin.`
+
+	applyDiffCommand = &cobra.Command{
+		Use:               "apply-diff CONTAINER [FILE]",
+		Short:             "Apply a layer tarball to the filesystem of a container",
+		Long:              applyDiffDescription,
+		RunE:              applyDiff,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: common.AutocompleteContainerAndFile,
+		Example: `podman diff --export hotfix.tar ctr1
+  podman container apply-diff ctr2 hotfix.tar
+  podman diff --export - ctr1 | podman container apply-diff ctr2`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: applyDiffCommand,
+		Parent:  containerCmd,
+	})
+}
+
+func applyDiff(_ *cobra.Command, args []string) error {
+	var reader io.Reader = os.Stdin
+	if len(args) > 1 && args[1] != "-" {
+		f, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		reader = f
+	} else if term.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.New("refusing to read the layer from a terminal. Specify a file or redirect stdin")
+	}
+	return registry.ContainerEngine().ContainerApplyDiff(registry.Context(), args[0], reader)
+}
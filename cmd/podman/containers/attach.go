@@ -50,6 +50,7 @@ func attachFlags(cmd *cobra.Command) {
 
 	flags.BoolVar(&attachOpts.NoStdin, "no-stdin", false, "Do not attach STDIN. The default is false")
 	flags.BoolVar(&attachOpts.SigProxy, "sig-proxy", true, "Proxy received signals to the process")
+	flags.BoolVar(&attachOpts.Record, "record", false, "Record the attach session to an asciicast file")
 }
 
 func init() {
@@ -19,6 +19,7 @@ import (
 	"github.com/containers/podman/v6/pkg/errorhandling"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
 	"go.podman.io/storage/pkg/archive"
 	"go.podman.io/storage/pkg/idtools"
 )
@@ -50,8 +51,10 @@ var (
 )
 
 var (
-	cpOpts entities.ContainerCpOptions
-	chown  bool
+	cpOpts     entities.ContainerCpOptions
+	chown      bool
+	syncOpts   cpSyncOptions
+	cpExcludes []string
 )
 
 func cpFlags(cmd *cobra.Command) {
@@ -59,6 +62,21 @@ func cpFlags(cmd *cobra.Command) {
 	flags.BoolVar(&cpOpts.OverwriteDirNonDir, "overwrite", false, "Allow to overwrite directories with non-directories and vice versa")
 	flags.BoolVarP(&chown, "archive", "a", true, `Chown copied files to the primary uid/gid of the destination container.`)
 
+	flags.BoolVar(&syncOpts.watch, "watch", false, "Keep watching the source path on the host and copy changed files to the container")
+	flags.BoolVar(&syncOpts.delete, "delete", false, "Remove files deleted on the host from the container when watching")
+
+	excludeFlagName := "exclude"
+	flags.StringArrayVar(&syncOpts.exclude, excludeFlagName, nil, "Exclude files matching the `pattern` when copying from the host")
+	_ = cmd.RegisterFlagCompletionFunc(excludeFlagName, completion.AutocompleteNone)
+
+	ignoreFileFlagName := "ignorefile"
+	flags.StringVar(&syncOpts.ignoreFile, ignoreFileFlagName, "", "Exclude files matching the patterns of the `file` when copying from the host")
+	_ = cmd.RegisterFlagCompletionFunc(ignoreFileFlagName, completion.AutocompleteDefault)
+
+	reloadFlagName := "reload"
+	flags.StringVar(&syncOpts.reload, reloadFlagName, "", "Run `command` in the container after changes were copied when watching")
+	_ = cmd.RegisterFlagCompletionFunc(reloadFlagName, completion.AutocompleteNone)
+
 	// Deprecated flags (both are NOPs): exist for backwards compat
 	flags.BoolVar(&cpOpts.Extract, "extract", false, "Deprecated...")
 	_ = flags.MarkHidden("extract")
@@ -86,12 +104,36 @@ func cp(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	if !syncOpts.watch {
+		switch {
+		case syncOpts.delete:
+			return errors.New("--delete requires --watch")
+		case syncOpts.reload != "":
+			return errors.New("--reload requires --watch")
+		}
+	}
+	if len(sourceContainerStr) > 0 {
+		switch {
+		case syncOpts.watch:
+			return errors.New("--watch is only supported when copying from the host to a container")
+		case len(syncOpts.exclude) > 0 || syncOpts.ignoreFile != "":
+			return errors.New("--exclude and --ignorefile are only supported when copying from the host")
+		}
+	}
+	cpExcludes, err = parseExcludes(syncOpts.exclude, syncOpts.ignoreFile)
+	if err != nil {
+		return err
+	}
+
 	if len(sourceContainerStr) > 0 && len(destContainerStr) > 0 {
 		return copyContainerToContainer(sourceContainerStr, sourcePath, destContainerStr, destPath)
 	} else if len(sourceContainerStr) > 0 {
 		return copyFromContainer(sourceContainerStr, sourcePath, destPath)
 	}
 
+	if syncOpts.watch {
+		return watchToContainer(destContainerStr, destPath, sourcePath, cpExcludes, syncOpts)
+	}
 	return copyToContainer(destContainerStr, destPath, sourcePath)
 }
 
@@ -428,6 +470,11 @@ func copyToContainer(container string, containerPath string, hostPath string) er
 			// rename accordingly.
 			getOptions.Rename = map[string]string{filepath.Base(hostTarget): containerBaseName}
 		}
+		if hostInfo.IsDir && len(cpExcludes) > 0 {
+			// The copier matches excludes against absolute paths
+			// while the patterns are relative to the source.
+			getOptions.Excludes = anchorExcludes(filepath.Clean(hostInfo.LinkTarget), cpExcludes)
+		}
 
 		// On Windows, the root path needs to be <drive>:\, while otherwise
 		// it needs to be /. Combining filepath.VolumeName() + string(os.PathSeparator)
@@ -0,0 +1,374 @@
+package containers
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/copy"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/fsnotify/fsnotify"
+	"github.com/openshift/imagebuilder"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/storage/pkg/archive"
+	"go.podman.io/storage/pkg/fileutils"
+)
+
+// cpWatchDelay is the time changes are collected for before they are
+// synced, so that a burst of changes, e.g. by a checkout, is synced at once.
+const cpWatchDelay = 250 * time.Millisecond
+
+// cpSyncOptions are the options of copying with --watch.
+type cpSyncOptions struct {
+	watch      bool
+	delete     bool
+	exclude    []string
+	ignoreFile string
+	reload     string
+}
+
+// parseExcludes returns the patterns of --exclude and the patterns read
+// from --ignorefile, both in the .containerignore syntax used by build.
+func parseExcludes(excludes []string, ignoreFile string) ([]string, error) {
+	patterns, err := imagebuilder.ParseIgnoreReader(strings.NewReader(strings.Join(excludes, "\n")))
+	if err != nil {
+		return nil, err
+	}
+	if ignoreFile != "" {
+		ignores, err := imagebuilder.ParseIgnore(ignoreFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading ignore file: %w", err)
+		}
+		patterns = append(patterns, ignores...)
+	}
+	return patterns, nil
+}
+
+// anchorExcludes makes relative patterns absolute by joining them to dir.
+func anchorExcludes(dir string, patterns []string) []string {
+	anchored := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(p, "!"); ok {
+			anchored = append(anchored, "!"+filepath.Join(dir, rest))
+			continue
+		}
+		anchored = append(anchored, filepath.Join(dir, p))
+	}
+	return anchored
+}
+
+// cpSyncer pushes the changes of a directory or file on the host to a
+// container.
+type cpSyncer struct {
+	ctx       context.Context
+	container string
+	// hostDir is the watched directory on the host.
+	hostDir string
+	// hostFile is the base name of the copied file if a single file is
+	// copied.
+	hostFile string
+	// containerDir is the directory in the container hostDir is copied
+	// to.  If a single file is copied, containerFile is its path.
+	containerDir  string
+	containerFile string
+	excludes      []string
+	matcher       *fileutils.PatternMatcher
+	options       cpSyncOptions
+}
+
+// watchToContainer copies hostPath to containerPath on the container and
+// then pushes all changes of hostPath until it is interrupted.
+func watchToContainer(container, containerPath, hostPath string, excludes []string, options cpSyncOptions) error {
+	if hostPath == "-" {
+		return errors.New("--watch cannot be used when copying from stdin")
+	}
+	hostInfo, err := copy.ResolveHostPath(hostPath)
+	if err != nil {
+		return fmt.Errorf("%q could not be found on the host: %w", hostPath, err)
+	}
+	_, statErr := registry.ContainerEngine().ContainerStat(registry.Context(), container, containerPath)
+	destExisted := statErr == nil
+
+	if err := copyToContainer(container, containerPath, hostPath); err != nil {
+		return err
+	}
+
+	// Resolve where the copy ended up in the container.
+	destInfo, err := registry.ContainerEngine().ContainerStat(registry.Context(), container, containerPath)
+	if err != nil {
+		return fmt.Errorf("%q could not be found on container %s after copying: %w", containerPath, container, err)
+	}
+	matcher, err := fileutils.NewPatternMatcher(excludes)
+	if err != nil {
+		return fmt.Errorf("parsing exclude patterns: %w", err)
+	}
+	ctx, stop := signal.NotifyContext(registry.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s := &cpSyncer{
+		ctx:       ctx,
+		container: container,
+		excludes:  excludes,
+		matcher:   matcher,
+		options:   options,
+	}
+	if hostInfo.IsDir {
+		s.hostDir = filepath.Clean(hostInfo.LinkTarget)
+		s.containerDir = destInfo.LinkTarget
+		if destExisted && destInfo.IsDir && filepath.Base(hostInfo.LinkTarget) != "." {
+			s.containerDir = path.Join(destInfo.LinkTarget, filepath.Base(s.hostDir))
+		}
+	} else {
+		s.hostDir, s.hostFile = filepath.Split(hostInfo.LinkTarget)
+		s.hostDir = filepath.Clean(s.hostDir)
+		s.containerFile = destInfo.LinkTarget
+		if destInfo.IsDir {
+			s.containerFile = path.Join(destInfo.LinkTarget, s.hostFile)
+		}
+	}
+	return s.run()
+}
+
+// excluded returns whether the path relative to the watched directory is
+// excluded.
+func (s *cpSyncer) excluded(rel string) bool {
+	if s.hostFile != "" {
+		return rel != s.hostFile
+	}
+	excluded, err := s.matcher.IsMatch(rel)
+	if err != nil {
+		logrus.Debugf("Matching %s against the exclude patterns: %v", rel, err)
+		return false
+	}
+	return excluded
+}
+
+// addWatches watches dir and all its subdirectories which are not excluded.
+func (s *cpSyncer) addWatches(watcher *fsnotify.Watcher, dir string) error {
+	if s.hostFile != "" {
+		return watcher.Add(dir)
+	}
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(s.hostDir, p)
+		if err != nil {
+			return err
+		}
+		// Exclusions can re-include content of an excluded directory.
+		if rel != "." && s.excluded(rel) && !s.matcher.Exclusions() {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(p); err != nil {
+			return fmt.Errorf("watching %s: %w", p, err)
+		}
+		return nil
+	})
+}
+
+func (s *cpSyncer) run() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating file watcher: %w", err)
+	}
+	defer watcher.Close()
+	if err := s.addWatches(watcher, s.hostDir); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Watching %s for changes, press Ctrl+C to stop\n", filepath.Join(s.hostDir, s.hostFile))
+
+	pending := make(map[string]struct{})
+	var flush <-chan time.Time
+	for {
+		select {
+		case <-s.ctx.Done():
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("watching %s: %w", s.hostDir, err)
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			rel, err := filepath.Rel(s.hostDir, event.Name)
+			if err != nil || rel == "." || s.excluded(rel) {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Lstat(event.Name); err == nil && info.IsDir() {
+					if err := s.addWatches(watcher, event.Name); err != nil {
+						logrus.Warnf("Unable to watch %s: %v", event.Name, err)
+					}
+				}
+			}
+			pending[rel] = struct{}{}
+			flush = time.After(cpWatchDelay)
+		case <-flush:
+			changed, deleted := classifyChanges(s.hostDir, pending)
+			pending = make(map[string]struct{})
+			flush = nil
+			if err := s.sync(changed, deleted); err != nil {
+				logrus.Errorf("Syncing changes to container %s: %v", s.container, err)
+			}
+		}
+	}
+}
+
+// classifyChanges splits the changed paths relative to dir into the ones
+// which exist and the ones which were deleted.  Paths whose parent directory
+// is in the same list are dropped, as they are copied or removed with it.
+func classifyChanges(dir string, paths map[string]struct{}) (changed, deleted []string) {
+	for rel := range paths {
+		if _, err := os.Lstat(filepath.Join(dir, rel)); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				deleted = append(deleted, rel)
+			}
+			continue
+		}
+		changed = append(changed, rel)
+	}
+	return dropChildren(changed), dropChildren(deleted)
+}
+
+// dropChildren sorts paths and removes the ones whose parent directory is
+// in paths.
+func dropChildren(paths []string) []string {
+	slices.Sort(paths)
+	result := paths[:0]
+	for _, p := range paths {
+		if len(result) > 0 {
+			last := result[len(result)-1]
+			if strings.HasPrefix(p, last+string(filepath.Separator)) {
+				continue
+			}
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+func (s *cpSyncer) sync(changed, deleted []string) error {
+	if !s.options.delete {
+		deleted = nil
+	}
+	if len(changed) == 0 && len(deleted) == 0 {
+		return nil
+	}
+	if err := s.push(changed, deleted); err != nil {
+		return err
+	}
+	for _, rel := range changed {
+		fmt.Printf("Updated %s\n", s.containerTarget(rel))
+	}
+	for _, rel := range deleted {
+		fmt.Printf("Deleted %s\n", s.containerTarget(rel))
+	}
+	if s.options.reload != "" {
+		if err := s.exec([]string{"/bin/sh", "-c", s.options.reload}); err != nil {
+			return fmt.Errorf("running reload command: %w", err)
+		}
+	}
+	return nil
+}
+
+// containerTarget returns the path in the container of the path relative to
+// the watched directory.
+func (s *cpSyncer) containerTarget(rel string) string {
+	if s.hostFile != "" {
+		return s.containerFile
+	}
+	return path.Join(s.containerDir, filepath.ToSlash(rel))
+}
+
+// push copies the changed paths relative to the watched directory to the
+// container and removes the deleted ones from it.
+func (s *cpSyncer) push(changed, deleted []string) error {
+	if s.hostFile != "" {
+		if len(changed) > 0 {
+			return copyToContainer(s.container, s.containerFile, filepath.Join(s.hostDir, s.hostFile))
+		}
+		return s.copy(path.Dir(s.containerFile), emptyArchive(), []string{path.Base(s.containerFile)})
+	}
+	remove := make([]string, 0, len(deleted))
+	for _, rel := range deleted {
+		remove = append(remove, filepath.ToSlash(rel))
+	}
+	if len(changed) == 0 {
+		// TarWithOptions archives everything without IncludeFiles.
+		return s.copy(s.containerDir, emptyArchive(), remove)
+	}
+	tarStream, err := archive.TarWithOptions(s.hostDir, &archive.TarOptions{
+		Compression:     archive.Uncompressed,
+		IncludeFiles:    changed,
+		ExcludePatterns: s.excludes,
+	})
+	if err != nil {
+		return err
+	}
+	defer tarStream.Close()
+	return s.copy(s.containerDir, tarStream, remove)
+}
+
+// copy extracts tarStream to dir in the container after removing the paths
+// in remove, relative to dir, through the copier of the container.
+func (s *cpSyncer) copy(dir string, tarStream io.Reader, remove []string) error {
+	copyFunc, err := registry.ContainerEngine().ContainerCopyFromArchive(s.ctx, s.container, dir, tarStream, entities.CopyOptions{Chown: chown, NoOverwriteDirNonDir: !cpOpts.OverwriteDirNonDir, Remove: remove})
+	if err != nil {
+		return err
+	}
+	if err := copyFunc(); err != nil {
+		return fmt.Errorf("copying to container: %w", err)
+	}
+	return nil
+}
+
+// emptyArchive returns a tar archive without entries.
+func emptyArchive() io.Reader {
+	var buf bytes.Buffer
+	_ = tar.NewWriter(&buf).Close()
+	return &buf
+}
+
+// exec runs a command in the container.
+func (s *cpSyncer) exec(command []string) error {
+	streams := define.AttachStreams{
+		OutputStream: os.Stdout,
+		ErrorStream:  os.Stderr,
+		AttachOutput: true,
+		AttachError:  true,
+	}
+	exitCode, err := registry.ContainerEngine().ContainerExec(s.ctx, s.container, entities.ExecOptions{Cmd: command}, streams)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("%s exited with code %d", command[0], exitCode)
+	}
+	return nil
+}
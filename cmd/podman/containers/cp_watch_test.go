@@ -0,0 +1,74 @@
+//go:build !windows
+
+package containers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseExcludes(t *testing.T) {
+	ignoreFile := filepath.Join(t.TempDir(), ".containerignore")
+	err := os.WriteFile(ignoreFile, []byte("# comment\n\nnode_modules\n/build/\n!build/keep\n*.swp\n"), 0o644)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		excludes   []string
+		ignoreFile string
+		want       []string
+	}{
+		{
+			name: "no excludes",
+		},
+		{
+			name:     "excludes use the ignore file syntax",
+			excludes: []string{"/.git", "tmp/../cache/", "", "# comment", "!/src/main.go"},
+			want:     []string{".git", "tmp/../cache", "!/src/main.go"},
+		},
+		{
+			name:       "ignore file",
+			excludes:   []string{".git"},
+			ignoreFile: ignoreFile,
+			want:       []string{".git", "node_modules", "build", "!build/keep", "*.swp"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseExcludes(tt.excludes, tt.ignoreFile)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err = parseExcludes(nil, filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func Test_anchorExcludes(t *testing.T) {
+	got := anchorExcludes("/src", []string{"node_modules", "!/build/keep", " *.swp ", " "})
+	assert.Equal(t, []string{"/src/node_modules", "!/src/build/keep", "/src/*.swp"}, got)
+}
+
+func Test_classifyChanges(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b", "c"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "d"), nil, 0o644))
+
+	changed, deleted := classifyChanges(dir, map[string]struct{}{
+		"a/b/c":   {},
+		"a":       {},
+		"d":       {},
+		"ab":      {},
+		"gone":    {},
+		"gone/x":  {},
+		"gone2/y": {},
+	})
+	assert.Equal(t, []string{"a", "d"}, changed)
+	assert.Equal(t, []string{"ab", "gone", "gone2/y"}, deleted)
+}
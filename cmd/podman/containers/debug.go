@@ -0,0 +1,154 @@
+package containers
+
+import (
+	"os"
+	"strings"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	envLib "github.com/containers/podman/v6/pkg/env"
+	"github.com/containers/podman/v6/pkg/specgen"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"golang.org/x/term"
+)
+
+// debugDefaultImage is the image of debug containers if --image is not set.
+const debugDefaultImage = "registry.fedoraproject.org/fedora-toolbox:latest"
+
+var (
+	debugDescription = `Runs a throwaway container with debugging tools in the namespaces of a running container.
+
+  The debug container joins the PID, network, IPC and UTS namespaces of the target container. The root file system of the target container is accessible at /proc/1/root, or at the path given with --mount-root. The debug container is removed when it exits.`
+	debugCommand = &cobra.Command{
+		Use:               "debug [options] CONTAINER [COMMAND [ARG...]]",
+		Short:             "Debug a running container with a throwaway container",
+		Long:              debugDescription,
+		RunE:              debug,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman debug ctrID
+  podman debug --image fedora-toolbox --mount-root /target ctrID
+  podman debug ctrID ls -l /proc/1/root/etc`,
+	}
+
+	containerDebugCommand = &cobra.Command{
+		Use:               debugCommand.Use,
+		Short:             debugCommand.Short,
+		Long:              debugCommand.Long,
+		RunE:              debugCommand.RunE,
+		Args:              debugCommand.Args,
+		ValidArgsFunction: debugCommand.ValidArgsFunction,
+		Example: `podman container debug ctrID
+  podman container debug --image fedora-toolbox --mount-root /target ctrID
+  podman container debug ctrID ls -l /proc/1/root/etc`,
+	}
+)
+
+var debugOpts = struct {
+	entities.ContainerDebugOptions
+	env         []string
+	image       string
+	interactive bool
+	name        string
+	privileged  bool
+	pull        string
+	quiet       bool
+	tty         bool
+	user        string
+}{}
+
+func debugFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.SetInterspersed(false)
+
+	detachKeysFlagName := "detach-keys"
+	flags.StringVar(&debugOpts.DetachKeys, detachKeysFlagName, containerConfig.DetachKeys(), "Override the key sequence for detaching the debug container. Format is a single character `[a-Z]` or a comma separated sequence of `ctrl-<value>`, where `<value>` is one of: `a-cf`, `@`, `^`, `[`, `\\`, `]`, `^` or `_`")
+	_ = cmd.RegisterFlagCompletionFunc(detachKeysFlagName, common.AutocompleteDetachKeys)
+
+	envFlagName := "env"
+	flags.StringArrayVarP(&debugOpts.env, envFlagName, "e", nil, "Set environment variables in the debug container")
+	_ = cmd.RegisterFlagCompletionFunc(envFlagName, completion.AutocompleteNone)
+
+	imageFlagName := "image"
+	flags.StringVar(&debugOpts.image, imageFlagName, debugDefaultImage, "Image of the debug container")
+	_ = cmd.RegisterFlagCompletionFunc(imageFlagName, common.AutocompleteImages)
+
+	flags.BoolVarP(&debugOpts.interactive, "interactive", "i", true, "Keep STDIN open")
+
+	mountRootFlagName := "mount-root"
+	flags.StringVar(&debugOpts.RootfsMount, mountRootFlagName, "", "Mount the root file system of the container at `PATH` in the debug container")
+	_ = cmd.RegisterFlagCompletionFunc(mountRootFlagName, completion.AutocompleteNone)
+
+	nameFlagName := "name"
+	flags.StringVar(&debugOpts.name, nameFlagName, "", "Assign a name to the debug container")
+	_ = cmd.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&debugOpts.privileged, "privileged", false, "Give extended privileges to the debug container")
+
+	pullFlagName := "pull"
+	flags.StringVar(&debugOpts.pull, pullFlagName, containerConfig.Engine.PullPolicy, `Pull the image of the debug container ("always"|"missing"|"never"|"newer")`)
+	_ = cmd.RegisterFlagCompletionFunc(pullFlagName, common.AutocompletePullOption)
+
+	flags.BoolVarP(&debugOpts.quiet, "quiet", "q", false, "Suppress output information when pulling images")
+	flags.BoolVar(&debugOpts.SigProxy, "sig-proxy", true, "Proxy received signals to the process")
+	flags.BoolVarP(&debugOpts.tty, "tty", "t", false, "Allocate a pseudo-TTY, the default is true if STDIN is a terminal")
+
+	userFlagName := "user"
+	flags.StringVarP(&debugOpts.user, userFlagName, "u", "", "Username or UID (format: <name|uid>[:<group|gid>])")
+	_ = cmd.RegisterFlagCompletionFunc(userFlagName, common.AutocompleteUserFlag)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: debugCommand,
+	})
+	debugFlags(debugCommand)
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: containerDebugCommand,
+		Parent:  containerCmd,
+	})
+	debugFlags(containerDebugCommand)
+}
+
+func debug(cmd *cobra.Command, args []string) error {
+	imageName, err := pullImage(cmd, debugOpts.image, &entities.ContainerCreateOptions{Pull: debugOpts.pull, Quiet: debugOpts.quiet})
+	if err != nil {
+		return err
+	}
+
+	s := specgen.NewSpecGenerator(imageName, false)
+	s.Name = debugOpts.name
+	s.Command = args[1:]
+	s.User = debugOpts.user
+	s.Privileged = &debugOpts.privileged
+	// Needed to access the root file system of the target at /proc/1/root
+	// and to trace its processes.
+	s.CapAdd = []string{"CAP_SYS_PTRACE"}
+	if s.Env, err = envLib.ParseSlice(debugOpts.env); err != nil {
+		return err
+	}
+
+	tty := debugOpts.tty
+	if !cmd.Flags().Changed("tty") {
+		tty = debugOpts.interactive && term.IsTerminal(int(os.Stdin.Fd()))
+	}
+	s.Terminal = &tty
+	s.Stdin = &debugOpts.interactive
+
+	debugOpts.Spec = s
+	debugOpts.OutputStream = os.Stdout
+	debugOpts.ErrorStream = os.Stderr
+	debugOpts.InputStream = nil
+	if debugOpts.interactive {
+		debugOpts.InputStream = os.Stdin
+	}
+
+	report, err := registry.ContainerEngine().ContainerDebug(registry.Context(), strings.TrimPrefix(args[0], "/"), debugOpts.ContainerDebugOptions)
+	if report != nil {
+		registry.SetExitCode(report.ExitCode)
+	}
+	return err
+}
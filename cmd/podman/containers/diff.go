@@ -22,7 +22,8 @@ var (
 		RunE:              diffRun,
 		ValidArgsFunction: common.AutocompleteContainers,
 		Example: `podman container diff myCtr
-  podman container diff -l --format json myCtr`,
+  podman container diff -l --format json myCtr
+  podman container diff --export hotfix.tar myCtr`,
 	}
 	diffOpts *entities.DiffOptions
 )
@@ -40,6 +41,7 @@ func init() {
 	flags.StringVar(&diffOpts.Format, formatFlagName, "", "Change the output format (json)")
 	_ = diffCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(nil))
 
+	diff.AddExportFlag(diffCmd, diffOpts)
 	validate.AddLatestFlag(diffCmd, &diffOpts.Latest)
 }
 
@@ -52,6 +52,7 @@ var (
 	execDetach        bool
 	execCidFile       string
 	execNoSession     bool
+	execRecord        bool
 )
 
 func execFlags(cmd *cobra.Command) {
@@ -101,6 +102,8 @@ func execFlags(cmd *cobra.Command) {
 	flags.Int32(waitFlagName, 0, "Total seconds to wait for container to start")
 	_ = flags.MarkHidden(waitFlagName)
 
+	flags.BoolVar(&execRecord, "record", false, "Record the exec session to an asciicast file")
+
 	if !registry.IsRemote() {
 		flags.BoolVar(&execNoSession, "no-session", false, "Do not create a database session for the exec process")
 	}
@@ -131,6 +134,9 @@ func exec(cmd *cobra.Command, args []string) error {
 			return errors.New("--no-session cannot be used with --detach or --detach-keys")
 		}
 	}
+	if execRecord && (execNoSession || execDetach) {
+		return errors.New("--record cannot be used with --detach or --no-session")
+	}
 
 	nameOrID, command, err := determineTargetCtrAndCmd(args, execOpts.Latest, execCidFile != "")
 	if err != nil {
@@ -188,6 +194,7 @@ func exec(cmd *cobra.Command, args []string) error {
 	}
 	streams.AttachOutput = true
 	streams.AttachError = true
+	streams.Record = execRecord
 
 	if execNoSession {
 		exitCode, err := registry.ContainerEngine().ContainerExecNoSession(registry.Context(), nameOrID, execOpts, streams)
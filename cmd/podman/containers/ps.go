@@ -106,6 +106,10 @@ func listFlagSet(cmd *cobra.Command) {
 	flags.Var(sort, sortFlagName, "Sort output by: "+sort.Choices())
 	_ = cmd.RegisterFlagCompletionFunc(sortFlagName, common.AutocompletePsSort)
 
+	whereFlagName := "where"
+	flags.StringVar(&listOpts.Where, whereFlagName, "", "Only list containers whose inspect data matches the `expression`")
+	_ = cmd.RegisterFlagCompletionFunc(whereFlagName, completion.AutocompleteNone)
+
 	flags.SetNormalizeFunc(utils.AliasFlags)
 }
 
@@ -129,6 +133,9 @@ func checkFlags(c *cobra.Command) error {
 	if listOpts.Watch > 0 && listOpts.Latest {
 		return errors.New("the watch and latest flags cannot be used together")
 	}
+	if listOpts.Where != "" && listOpts.External {
+		return errors.New("the where and external flags cannot be used together")
+	}
 	podmanConfig := registry.PodmanConfig()
 	if podmanConfig.ContainersConf.Engine.Namespace != "" {
 		if c.Flag("storage").Changed && listOpts.External {
@@ -5,15 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/containers/podman/v6/cmd/podman/common"
 	"github.com/containers/podman/v6/cmd/podman/registry"
 	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/pkg/domain/entities"
 	"github.com/containers/podman/v6/pkg/util"
+	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
-	"github.com/spf13/pflag"
+	"go.podman.io/common/pkg/completion"
 	"go.podman.io/common/pkg/report"
 )
 
@@ -21,9 +24,16 @@ var (
 	topDescription = `Display the running processes of a container.
 
   The top command extends the ps(1) compatible AIX descriptors with container-specific ones as shown below.  In the presence of ps(1) specific flags (e.g, -eo), Podman will execute ps(1) inside the container.
+
+  With --watch, --sort or --format, the CPU, memory, I/O and file descriptor usage of each process is read from the host instead, without running any tool in the container.
 `
 	topOptions = entities.TopOptions{}
 
+	// Options of the resource usage view.
+	topWatch  uint
+	topSort   = "pid"
+	topFormat = "table"
+
 	topCommand = &cobra.Command{
 		Use:               "top [options] CONTAINER [FORMAT-DESCRIPTORS|ARGS...]",
 		Short:             "Display the running processes of a container",
@@ -33,7 +43,8 @@ var (
 		ValidArgsFunction: common.AutocompleteTopCmd,
 		Example: `podman top ctrID
 podman top ctrID pid seccomp args %C
-podman top ctrID -eo user,pid,comm`,
+podman top ctrID -eo user,pid,comm
+podman top --watch 2 --sort cpu ctrID`,
 	}
 
 	containerTopCommand = &cobra.Command{
@@ -44,21 +55,35 @@ podman top ctrID -eo user,pid,comm`,
 		ValidArgsFunction: topCommand.ValidArgsFunction,
 		Example: `podman container top ctrID
 podman container top ctrID pid seccomp args %C
-podman container top ctrID -eo user,pid,comm`,
+podman container top ctrID -eo user,pid,comm
+podman container top --watch 2 --sort cpu ctrID`,
 	}
 )
 
-func topFlags(flags *pflag.FlagSet) {
+func topFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
 	flags.SetInterspersed(false)
 	flags.BoolVar(&topOptions.ListDescriptors, "list-descriptors", false, "")
 	_ = flags.MarkHidden("list-descriptors") // meant only for bash completion
+
+	watchFlagName := "watch"
+	flags.UintVarP(&topWatch, watchFlagName, "w", 0, "Refresh the resource usage of the processes on an interval in seconds")
+	_ = cmd.RegisterFlagCompletionFunc(watchFlagName, completion.AutocompleteNone)
+
+	sortFlagName := "sort"
+	flags.Var(validate.Value(&topSort, "pid", "cpu", "rss", "io", "fds"), sortFlagName, "Sort the processes by pid, cpu, rss, io or fds")
+	_ = cmd.RegisterFlagCompletionFunc(sortFlagName, common.AutocompleteTopSort)
+
+	formatFlagName := "format"
+	flags.Var(validate.Value(&topFormat, "table", "json"), formatFlagName, "Print the resource usage of the processes as a table or a stream of JSON objects")
+	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteTopFormat)
 }
 
 func init() {
 	registry.Commands = append(registry.Commands, registry.CliCommand{
 		Command: topCommand,
 	})
-	topFlags(topCommand.Flags())
+	topFlags(topCommand)
 	validate.AddLatestFlag(topCommand, &topOptions.Latest)
 
 	descriptors, err := util.GetContainerPidInformationDescriptors()
@@ -71,7 +96,7 @@ func init() {
 		Command: containerTopCommand,
 		Parent:  containerCmd,
 	})
-	topFlags(containerTopCommand.Flags())
+	topFlags(containerTopCommand)
 	validate.AddLatestFlag(containerTopCommand, &topOptions.Latest)
 }
 
@@ -96,6 +121,14 @@ func top(cmd *cobra.Command, args []string) error {
 		topOptions.Descriptors = args[1:]
 	}
 
+	flags := cmd.Flags()
+	if topWatch > 0 || flags.Changed("sort") || flags.Changed("format") {
+		if len(topOptions.Descriptors) > 0 {
+			return errors.New("format descriptors and ps(1) arguments cannot be used with --watch, --sort or --format")
+		}
+		return topStats()
+	}
+
 	topResponse, err := registry.ContainerEngine().ContainerTop(context.Background(), topOptions)
 	if err != nil {
 		return err
@@ -111,3 +144,65 @@ func top(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// topStats prints the resource usage of the processes of the container, every
+// topWatch seconds until the container stops if it is set.
+func topStats() error {
+	options := entities.ContainerTopStatsOptions{
+		Latest:   topOptions.Latest,
+		Stream:   topWatch > 0,
+		Interval: max(int(topWatch), 1),
+		Sort:     topSort,
+	}
+	statsChan, err := registry.ContainerEngine().ContainerTopStats(registry.Context(), topOptions.NameOrID, options)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	sampled := false
+	for stats := range statsChan {
+		if stats.Error != nil {
+			// Watching ends when the container stops.
+			if sampled && errors.Is(stats.Error, define.ErrCtrStopped) {
+				return nil
+			}
+			return stats.Error
+		}
+		sampled = true
+		if topFormat == "json" {
+			if err := enc.Encode(stats.Processes); err != nil {
+				return err
+			}
+			continue
+		}
+		if topWatch > 0 {
+			common.ClearScreen()
+		}
+		if err := printProcessStats(stats.Processes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printProcessStats(processes []define.ContainerProcessStats) error {
+	rpt := report.New(os.Stdout, "top").Init(os.Stdout, 8, 2, 2, ' ', 0)
+	defer rpt.Flush()
+
+	if _, err := fmt.Fprintln(rpt.Writer(), "PID\tPPID\tSTATE\t%CPU\tRSS\tREAD/S\tWRITE/S\tFDS\tCOMMAND"); err != nil {
+		return err
+	}
+	for _, p := range processes {
+		fds := "-"
+		if p.FDs >= 0 {
+			fds = strconv.Itoa(p.FDs)
+		}
+		if _, err := fmt.Fprintf(rpt.Writer(), "%d\t%d\t%s\t%.1f\t%s\t%s\t%s\t%s\t%s\n",
+			p.PID, p.PPID, p.State, p.CPU, units.HumanSize(float64(p.RSS)),
+			units.HumanSize(p.ReadRate), units.HumanSize(p.WriteRate), fds, p.Command); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/containers/podman/v6/libpod/shutdown"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/trace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	traceDescription = `Trace the file, exec and network activity of a running container.
+
+  The processes of the container are traced from the host with ptrace(2) until the container stops or podman trace is interrupted. The files they open, the binaries they execute and the connections they make are printed as JSON lines. With --generate-seccomp, a seccomp profile allowing only the system calls they used is written when the trace ends.`
+	traceCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "trace [options] CONTAINER",
+		Short:             "Trace the file, exec and network activity of a container",
+		Long:              traceDescription,
+		RunE:              traceContainer,
+		Args:              validate.IDOrLatestArgs,
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman trace ctrID
+  podman trace --generate-seccomp ./seccomp.json ctrID`,
+	}
+
+	containerTraceCommand = &cobra.Command{
+		Annotations:       traceCommand.Annotations,
+		Use:               traceCommand.Use,
+		Short:             traceCommand.Short,
+		Long:              traceCommand.Long,
+		RunE:              traceCommand.RunE,
+		Args:              traceCommand.Args,
+		ValidArgsFunction: traceCommand.ValidArgsFunction,
+		Example: `podman container trace ctrID
+  podman container trace --generate-seccomp ./seccomp.json ctrID`,
+	}
+)
+
+var (
+	traceOpts           entities.ContainerTraceOptions
+	traceSeccompProfile string
+	traceQuiet          bool
+)
+
+func traceFlags(cmd *cobra.Command, flags *pflag.FlagSet) {
+	seccompFlagName := "generate-seccomp"
+	flags.StringVar(&traceSeccompProfile, seccompFlagName, "", "Write a seccomp profile allowing the system calls used by the container to `file`")
+	_ = cmd.RegisterFlagCompletionFunc(seccompFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVarP(&traceQuiet, "quiet", "q", false, "Do not print the traced events")
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: traceCommand,
+	})
+	traceFlags(traceCommand, traceCommand.Flags())
+	validate.AddLatestFlag(traceCommand, &traceOpts.Latest)
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: containerTraceCommand,
+		Parent:  containerCmd,
+	})
+	traceFlags(containerTraceCommand, containerTraceCommand.Flags())
+	validate.AddLatestFlag(containerTraceCommand, &traceOpts.Latest)
+}
+
+func traceContainer(_ *cobra.Command, args []string) error {
+	var container string
+	if len(args) > 0 {
+		container = strings.TrimPrefix(args[0], "/")
+	}
+
+	// Stop the shutdown signal handler, an interrupt ends the trace and
+	// the seccomp profile is still written.
+	if err := shutdown.Stop(); err != nil && !errors.Is(err, shutdown.ErrNotStarted) {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(registry.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	traceOpts.EventChan = make(chan trace.Event, 64)
+	printed := make(chan error, 1)
+	go func() {
+		var err error
+		for ev := range traceOpts.EventChan {
+			if traceQuiet || err != nil {
+				continue
+			}
+			err = json.NewEncoder(os.Stdout).Encode(ev)
+		}
+		printed <- err
+	}()
+
+	report, err := registry.ContainerEngine().ContainerTrace(ctx, container, traceOpts)
+	if printErr := <-printed; err == nil {
+		err = printErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if traceSeccompProfile == "" {
+		return nil
+	}
+	profile, err := trace.GenerateSeccompProfile(report.Syscalls)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(traceSeccompProfile, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing seccomp profile: %w", err)
+	}
+	return nil
+}
@@ -51,6 +51,13 @@ var updateOptions ContainerUpdateOptions
 func updateFlags(cmd *cobra.Command) {
 	common.DefineCreateDefaults(&updateOptions.ContainerCreateOptions)
 	common.DefineCreateFlags(cmd, &updateOptions.ContainerCreateOptions, entities.UpdateMode)
+
+	networkOptFlagName := "network-opt"
+	cmd.Flags().StringArray(
+		networkOptFlagName, nil,
+		"Change the bandwidth limits of network attachments ([network:]ingress-rate=rate,egress-rate=rate)",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(networkOptFlagName, common.AutocompleteNetworkOpt)
 }
 
 func init() {
@@ -190,6 +197,17 @@ func update(cmd *cobra.Command, args []string) error {
 		opts.UnsetEnv = env
 	}
 
+	if cmd.Flags().Changed("network-opt") {
+		networkOpts, err := cmd.Flags().GetStringArray("network-opt")
+		if err != nil {
+			return err
+		}
+		opts.NetworkRateLimits, err = specgen.ParseNetworkRateLimits(networkOpts)
+		if err != nil {
+			return err
+		}
+	}
+
 	rep, err := registry.ContainerEngine().ContainerUpdate(context.Background(), opts)
 	if err != nil {
 		return err
@@ -24,7 +24,8 @@ var (
 		ValidArgsFunction: common.AutocompleteContainersAndImages,
 		Example: `podman diff imageID
   podman diff ctrID
-  podman diff --format json redis:alpine`,
+  podman diff --format json redis:alpine
+  podman diff --export hotfix.tar ctrID`,
 	}
 
 	diffOpts = entities.DiffOptions{}
@@ -40,6 +41,7 @@ func init() {
 	flags.StringVar(&diffOpts.Format, formatFlagName, "", "Change the output format (json)")
 	_ = diffCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(nil))
 
+	diff.AddExportFlag(diffCmd, &diffOpts)
 	validate.AddLatestFlag(diffCmd, &diffOpts.Latest)
 }
 
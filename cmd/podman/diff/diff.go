@@ -6,14 +6,24 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/containers/podman/v6/cmd/podman/parse"
 	"github.com/containers/podman/v6/cmd/podman/registry"
 	"github.com/containers/podman/v6/pkg/domain/entities"
 	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
 	"go.podman.io/common/pkg/report"
 	"go.podman.io/storage/pkg/archive"
+	"golang.org/x/term"
 )
 
 func Diff(_ *cobra.Command, args []string, options entities.DiffOptions) error {
+	if options.Export != "" {
+		if options.Format != "" {
+			return errors.New("--format and --export cannot be used together")
+		}
+		return export(args, options)
+	}
+
 	results, err := registry.ContainerEngine().Diff(registry.Context(), args, options)
 	if err != nil {
 		return err
@@ -29,6 +39,30 @@ func Diff(_ *cobra.Command, args []string, options entities.DiffOptions) error {
 	}
 }
 
+// export writes the changes as a layer tarball to the file of options.Export
+// or to stdout if it is "-".
+func export(args []string, options entities.DiffOptions) error {
+	if options.Export == "-" {
+		if term.IsTerminal(int(os.Stdout.Fd())) {
+			return errors.New("refusing to export to terminal. Use --export with a file or redirect stdout")
+		}
+		return registry.ContainerEngine().DiffExport(registry.Context(), args, os.Stdout, options)
+	}
+	if err := parse.ValidateFileName(options.Export); err != nil {
+		return err
+	}
+	f, err := os.Create(options.Export)
+	if err != nil {
+		return err
+	}
+	if err := registry.ContainerEngine().DiffExport(registry.Context(), args, f, options); err != nil {
+		f.Close()
+		os.Remove(options.Export)
+		return err
+	}
+	return f.Close()
+}
+
 type ChangesReportJSON struct {
 	Changed []string `json:"changed,omitempty"`
 	Added   []string `json:"added,omitempty"`
@@ -63,6 +97,13 @@ func changesToTable(diffs *entities.DiffReport) error {
 	return nil
 }
 
+// AddExportFlag adds the --export flag to the diff commands.
+func AddExportFlag(cmd *cobra.Command, options *entities.DiffOptions) {
+	exportFlagName := "export"
+	cmd.Flags().StringVar(&options.Export, exportFlagName, "", "Export the changes as a layer tarball to `file` (\"-\" for stdout)")
+	_ = cmd.RegisterFlagCompletionFunc(exportFlagName, completion.AutocompleteDefault)
+}
+
 // ValidateContainerDiffArgs used to validate a nameOrId was provided or the "--latest" flag
 func ValidateContainerDiffArgs(cmd *cobra.Command, args []string) error {
 	given, _ := cmd.Flags().GetBool("latest")
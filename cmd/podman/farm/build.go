@@ -4,15 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/containers/podman/v6/cmd/podman/common"
 	"github.com/containers/podman/v6/cmd/podman/registry"
 	"github.com/containers/podman/v6/cmd/podman/utils"
 	"github.com/containers/podman/v6/pkg/farm"
+	"github.com/docker/go-units"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
 )
 
 type buildOptions struct {
@@ -20,6 +23,7 @@ type buildOptions struct {
 	local        bool
 	platforms    []string
 	farm         string
+	dryRun       bool
 }
 
 var (
@@ -53,6 +57,9 @@ func init() {
 	flags.StringVar(&buildOpts.farm, farmFlagName, "", "Farm to use for builds")
 	_ = buildCommand.RegisterFlagCompletionFunc(farmFlagName, common.AutoCompleteFarms)
 
+	dryRunFlagName := "dry-run"
+	flags.BoolVar(&buildOpts.dryRun, dryRunFlagName, false, "Print on which farm nodes the images would be built, without building them")
+
 	localFlagName := "local"
 	// Default for local is true
 	flags.BoolVarP(&buildOpts.local, localFlagName, "l", true, "Build image on local machine as well as on farm nodes")
@@ -72,6 +79,14 @@ func build(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if buildOpts.dryRun {
+		_, schedule, err := scheduleBuilds()
+		if err != nil {
+			return err
+		}
+		return printSchedule(cmd, schedule)
+	}
+
 	if !cmd.Flags().Changed("tag") {
 		return errors.New("cannot create manifest list without a name, value for --tag is required")
 	}
@@ -121,26 +136,89 @@ func build(cmd *cobra.Command, args []string) error {
 		opts.SkipTLSVerify = &skipTLSVerify
 	}
 
-	localEngine := registry.ImageEngine()
-	ctx := registry.Context()
-	farm, err := farm.NewFarm(ctx, buildOpts.farm, localEngine, buildOpts.local)
+	farm, schedule, err := scheduleBuilds()
 	if err != nil {
-		return fmt.Errorf("initializing: %w", err)
+		return err
 	}
-
-	schedule, err := farm.Schedule(ctx, buildOpts.platforms)
-	if err != nil {
-		return fmt.Errorf("scheduling builds: %w", err)
+	if err := printSchedule(cmd, schedule); err != nil {
+		return err
 	}
-	logrus.Infof("schedule: %v", schedule)
 
 	manifestName := opts.Output
 	// Set Output to "" so that the images built on the farm nodes have no name
 	opts.Output = ""
-	if err = farm.Build(ctx, schedule, *opts, manifestName, localEngine); err != nil {
+	if err = farm.Build(registry.Context(), schedule, *opts, manifestName, registry.ImageEngine()); err != nil {
 		return fmt.Errorf("build: %w", err)
 	}
 	logrus.Infof("build: ok")
 
 	return nil
 }
+
+// scheduleBuilds connects to the nodes of the farm and assigns the builds of
+// the requested platforms to them.
+func scheduleBuilds() (*farm.Farm, farm.Schedule, error) {
+	ctx := registry.Context()
+	f, err := farm.NewFarm(ctx, buildOpts.farm, registry.ImageEngine(), buildOpts.local)
+	if err != nil {
+		return nil, farm.Schedule{}, fmt.Errorf("initializing: %w", err)
+	}
+
+	schedule, err := f.Schedule(ctx, buildOpts.platforms)
+	if err != nil {
+		return nil, farm.Schedule{}, fmt.Errorf("scheduling builds: %w", err)
+	}
+	return f, schedule, nil
+}
+
+// printSchedule prints on which nodes the images are built.
+// scheduleRow is a build of the schedule as printed by printSchedule.
+type scheduleRow struct {
+	Platform  string
+	Node      string
+	Native    string
+	CPUs      string
+	MemFree   string
+	Builds    string
+	Fallbacks string
+}
+
+func printSchedule(cmd *cobra.Command, schedule farm.Schedule) error {
+	headers := report.Headers(scheduleRow{}, map[string]string{
+		"MemFree": "FREE MEMORY",
+		"Builds":  "RUNNING BUILDS",
+	})
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	rpt, err := rpt.Parse(report.OriginPodman,
+		"{{range .}}{{.Platform}}\t{{.Node}}\t{{.Native}}\t{{.CPUs}}\t{{.MemFree}}\t{{.Builds}}\t{{.Fallbacks}}\n{{end -}}")
+	if err != nil {
+		return err
+	}
+
+	rows := make([]scheduleRow, 0, len(schedule.Builds()))
+	for _, build := range schedule.Builds() {
+		fallbacks := "-"
+		if len(build.Fallbacks) > 0 {
+			fallbacks = strings.Join(build.Fallbacks, ",")
+		}
+		rows = append(rows, scheduleRow{
+			Platform:  build.Platform,
+			Node:      build.Node,
+			Native:    strconv.FormatBool(build.Native),
+			CPUs:      strconv.Itoa(build.CPUs),
+			MemFree:   units.HumanSize(float64(build.MemFree)),
+			Builds:    strconv.Itoa(build.Builds),
+			Fallbacks: fallbacks,
+		})
+	}
+
+	if rpt.RenderHeaders {
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(rows)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/containers/podman/v6/cmd/podman/registry"
 	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/containers/podman/v6/pkg/farm"
 	"github.com/spf13/cobra"
 	"go.podman.io/common/pkg/completion"
 	"go.podman.io/common/pkg/config"
@@ -26,8 +27,13 @@ var (
 		PersistentPostRunE: validate.NoOp,
 		ValidArgsFunction:  completion.AutocompleteNone,
 		Example: `podman farm create myfarm connection1
-  podman farm create myfarm`,
+  podman farm create myfarm
+  podman farm create --cache-node connection1 myfarm connection1 connection2`,
 	}
+
+	createOpts = struct {
+		Cache farm.CacheConfig
+	}{}
 )
 
 func init() {
@@ -35,11 +41,15 @@ func init() {
 		Command: createCommand,
 		Parent:  farmCmd,
 	})
+	cacheFlags(createCommand, &createOpts.Cache)
 }
 
-func create(_ *cobra.Command, args []string) error {
+func create(cmd *cobra.Command, args []string) error {
 	farmName := args[0]
 	connections := args[1:]
+	if err := createOpts.Cache.Validate(); err != nil {
+		return err
+	}
 
 	err := config.EditConnectionConfig(func(cfg *config.ConnectionsFile) error {
 		if _, ok := cfg.Farm.List[farmName]; ok {
@@ -74,6 +84,9 @@ func create(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if _, err := setCacheConfig(cmd, farmName, createOpts.Cache); err != nil {
+		return err
+	}
 	fmt.Printf("Farm %q created\n", farmName)
 	return nil
 }
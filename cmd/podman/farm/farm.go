@@ -1,9 +1,14 @@
 package farm
 
 import (
+	"fmt"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
 	"github.com/containers/podman/v6/cmd/podman/registry"
 	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/containers/podman/v6/pkg/farm"
 	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
 )
 
 // Command: podman _farm_
@@ -19,3 +24,56 @@ func init() {
 		Command: farmCmd,
 	})
 }
+
+// cacheFlags adds the flags configuring where a farm shares its layer cache.
+func cacheFlags(cmd *cobra.Command, cache *farm.CacheConfig) {
+	flags := cmd.Flags()
+
+	cacheRepoFlagName := "cache-repo"
+	flags.StringVar(&cache.Repository, cacheRepoFlagName, "", "Share the layer cache of the farm nodes through the registry `repository`")
+	_ = cmd.RegisterFlagCompletionFunc(cacheRepoFlagName, completion.AutocompleteNone)
+
+	cacheNodeFlagName := "cache-node"
+	flags.StringVar(&cache.Node, cacheNodeFlagName, "", "Share the layer cache of the farm nodes through a registry run on the system `connection`")
+	_ = cmd.RegisterFlagCompletionFunc(cacheNodeFlagName, common.AutocompleteSystemConnections)
+
+	cachePortFlagName := "cache-port"
+	flags.Uint16Var(&cache.Port, cachePortFlagName, 0, fmt.Sprintf("Port of the registry on the cache node (default %d)", farm.DefaultCachePort))
+	_ = cmd.RegisterFlagCompletionFunc(cachePortFlagName, completion.AutocompleteNone)
+}
+
+// setCacheConfig applies the changed cache flags to the layer cache config of
+// the farm and returns whether any were changed.  Setting a repository or a
+// node replaces the other one, setting an empty one stops sharing the cache.
+func setCacheConfig(cmd *cobra.Command, farmName string, flagCache farm.CacheConfig) (bool, error) {
+	flags := cmd.Flags()
+	if !flags.Changed("cache-repo") && !flags.Changed("cache-node") && !flags.Changed("cache-port") {
+		return false, nil
+	}
+	cache, err := farm.GetCacheConfig(farmName)
+	if err != nil {
+		return false, err
+	}
+	if cache == nil {
+		cache = &farm.CacheConfig{}
+	}
+	if flags.Changed("cache-repo") {
+		*cache = farm.CacheConfig{Repository: flagCache.Repository}
+	}
+	if flags.Changed("cache-node") {
+		if flagCache.Node != "" {
+			con, err := registry.PodmanConfig().ContainersConfDefaultsRO.GetConnection(flagCache.Node, false)
+			if err != nil {
+				return false, fmt.Errorf("invalid cache node: %w", err)
+			}
+			if _, err := farm.CacheNodeHost(con); err != nil {
+				return false, fmt.Errorf("invalid cache node: %w", err)
+			}
+		}
+		*cache = farm.CacheConfig{Node: flagCache.Node}
+	}
+	if flags.Changed("cache-port") {
+		cache.Port = flagCache.Port
+	}
+	return true, farm.SetCacheConfig(farmName, cache)
+}
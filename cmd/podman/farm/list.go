@@ -8,6 +8,7 @@ import (
 	"github.com/containers/podman/v6/cmd/podman/common"
 	"github.com/containers/podman/v6/cmd/podman/registry"
 	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/containers/podman/v6/pkg/farm"
 	"github.com/spf13/cobra"
 	"go.podman.io/common/pkg/completion"
 	"go.podman.io/common/pkg/config"
@@ -46,7 +47,14 @@ func init() {
 
 	formatFlagName := "format"
 	flags.StringVar(&lsOpts.Format, formatFlagName, "", "Format farm output using Go template")
-	_ = lsCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&config.Farm{}))
+	_ = lsCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&listFarm{}))
+}
+
+// listFarm is a farm and where it shares its layer cache.
+type listFarm struct {
+	config.Farm
+	// Cache is the repository or node the layer cache is shared through.
+	Cache string
 }
 
 func list(cmd *cobra.Command, args []string) error {
@@ -63,12 +71,24 @@ func list(cmd *cobra.Command, args []string) error {
 	sort.Slice(farms, func(i, j int) bool {
 		return farms[i].Name < farms[j].Name
 	})
+	listFarms := make([]listFarm, 0, len(farms))
+	for _, f := range farms {
+		cache, err := farm.GetCacheConfig(f.Name)
+		if err != nil {
+			return err
+		}
+		lf := listFarm{Farm: f}
+		if cache != nil {
+			lf.Cache = cache.String()
+		}
+		listFarms = append(listFarms, lf)
+	}
 
 	rpt := report.New(os.Stdout, cmd.Name())
 	defer rpt.Flush()
 
 	if report.IsJSON(format) {
-		buf, err := registry.JSONLibrary().MarshalIndent(farms, "", "    ")
+		buf, err := registry.JSONLibrary().MarshalIndent(listFarms, "", "    ")
 		if err == nil {
 			fmt.Println(string(buf))
 		}
@@ -79,7 +99,7 @@ func list(cmd *cobra.Command, args []string) error {
 		rpt, err = rpt.Parse(report.OriginUser, format)
 	} else {
 		rpt, err = rpt.Parse(report.OriginPodman,
-			"{{range .}}{{.Name}}\t{{.Connections}}\t{{.Default}}\t{{.ReadWrite}}\n{{end -}}")
+			"{{range .}}{{.Name}}\t{{.Connections}}\t{{.Default}}\t{{.ReadWrite}}\t{{.Cache}}\n{{end -}}")
 	}
 	if err != nil {
 		return err
@@ -91,11 +111,12 @@ func list(cmd *cobra.Command, args []string) error {
 			"Connections": "Connections",
 			"Name":        "Name",
 			"ReadWrite":   "ReadWrite",
+			"Cache":       "Cache",
 		}})
 		if err != nil {
 			return err
 		}
 	}
 
-	return rpt.Execute(farms)
+	return rpt.Execute(listFarms)
 }
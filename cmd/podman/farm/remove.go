@@ -7,6 +7,7 @@ import (
 	"github.com/containers/podman/v6/cmd/podman/common"
 	"github.com/containers/podman/v6/cmd/podman/registry"
 	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/containers/podman/v6/pkg/farm"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"go.podman.io/common/pkg/config"
@@ -46,6 +47,9 @@ func rm(_ *cobra.Command, args []string) error {
 	deletedFarms := []string{}
 	err := config.EditConnectionConfig(func(cfg *config.ConnectionsFile) error {
 		if rmOpts.All {
+			for k := range cfg.Farm.List {
+				deletedFarms = append(deletedFarms, k)
+			}
 			cfg.Farm.List = make(map[string][]string)
 			cfg.Farm.Default = ""
 			return nil
@@ -88,6 +92,11 @@ func rm(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	for _, k := range deletedFarms {
+		if err := farm.SetCacheConfig(k, nil); err != nil {
+			logrus.Errorf("Removing layer cache config of farm %q: %v", k, err)
+		}
+	}
 	if rmOpts.All {
 		fmt.Println("All farms have been deleted")
 		return nil
@@ -8,6 +8,7 @@ import (
 	"github.com/containers/podman/v6/cmd/podman/common"
 	"github.com/containers/podman/v6/cmd/podman/registry"
 	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/containers/podman/v6/pkg/farm"
 	"github.com/spf13/cobra"
 	"go.podman.io/common/pkg/completion"
 	"go.podman.io/common/pkg/config"
@@ -26,7 +27,8 @@ var (
 		ValidArgsFunction:  common.AutoCompleteFarms,
 		Example: `podman farm update --add con1 farm1
 	podman farm update --remove con2 farm2
-	podman farm update --default farm3`,
+	podman farm update --default farm3
+	podman farm update --cache-repo registry.example.com/cache farm4`,
 	}
 
 	// Temporary struct to hold cli values.
@@ -34,6 +36,7 @@ var (
 		Add     []string
 		Remove  []string
 		Default bool
+		Cache   farm.CacheConfig
 	}{}
 )
 
@@ -52,15 +55,17 @@ func init() {
 	_ = updateCommand.RegisterFlagCompletionFunc(removeFlagName, completion.AutocompleteNone)
 	defaultFlagName := "default"
 	flags.BoolVarP(&updateOpts.Default, defaultFlagName, "d", false, "set the given farm as the default farm")
+	cacheFlags(updateCommand, &updateOpts.Cache)
 }
 
 func farmUpdate(cmd *cobra.Command, args []string) error {
 	farmName := args[0]
 
 	defChanged := cmd.Flags().Changed("default")
+	cacheChanged := cmd.Flags().Changed("cache-repo") || cmd.Flags().Changed("cache-node") || cmd.Flags().Changed("cache-port")
 
-	if len(updateOpts.Add) == 0 && len(updateOpts.Remove) == 0 && !defChanged {
-		return fmt.Errorf("nothing to update for farm %q, please use the --add, --remove, or --default flags to update a farm", farmName)
+	if len(updateOpts.Add) == 0 && len(updateOpts.Remove) == 0 && !defChanged && !cacheChanged {
+		return fmt.Errorf("nothing to update for farm %q, please use the --add, --remove, --default, or --cache-* flags to update a farm", farmName)
 	}
 
 	err := config.EditConnectionConfig(func(cfg *config.ConnectionsFile) error {
@@ -118,6 +123,9 @@ func farmUpdate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if _, err := setCacheConfig(cmd, farmName, updateOpts.Cache); err != nil {
+		return err
+	}
 	fmt.Printf("Farm %q updated\n", farmName)
 	return nil
 }
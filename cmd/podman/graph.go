@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	graphDescription = `Display the dependency graph of containers.
+
+  Containers are connected to the containers they depend on, through --requires or shared namespaces like the ones of the infra container of a pod, and to their networks and volumes. Each container is annotated with its state and health. For containers which are not running, the dependencies blocking them from starting are listed.`
+	graphCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "graph [options]",
+		Args:              validate.NoArgs,
+		Short:             "Display the dependency graph of containers",
+		Long:              graphDescription,
+		RunE:              graph,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman graph
+  podman graph --pod mypod
+  podman graph --format dot | dot -Tsvg -o graph.svg`,
+	}
+
+	graphOptions entities.GraphOptions
+	graphFormat  string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: graphCommand,
+	})
+	flags := graphCommand.Flags()
+
+	formatFlagName := "format"
+	graphFormat = "tree"
+	flags.Var(validate.Value(&graphFormat, "tree", "dot", "json"), formatFlagName, "Output format: tree, dot or json")
+	_ = graphCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteGraphFormat)
+
+	podFlagName := "pod"
+	flags.StringVar(&graphOptions.Pod, podFlagName, "", "Only show the containers of `pod` and their dependencies")
+	_ = graphCommand.RegisterFlagCompletionFunc(podFlagName, common.AutocompletePods)
+}
+
+func graph(_ *cobra.Command, _ []string) error {
+	report, err := registry.ContainerEngine().Graph(registry.Context(), graphOptions)
+	if err != nil {
+		return err
+	}
+	switch graphFormat {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		return enc.Encode(report)
+	case "dot":
+		return writeGraphDot(os.Stdout, report)
+	default:
+		return writeGraphTree(os.Stdout, report)
+	}
+}
+
+func isContainerNode(n *entities.GraphNode) bool {
+	return n.Kind == entities.GraphNodeContainer || n.Kind == entities.GraphNodeInfra
+}
+
+// graphStatus describes the state and health of a container.
+func graphStatus(n *entities.GraphNode) string {
+	status := n.State
+	if n.State == "exited" || n.State == "stopped" {
+		status += fmt.Sprintf(" (%d)", n.ExitCode)
+	}
+	if n.Health != "" {
+		status += ", " + n.Health
+	}
+	if n.Kind == entities.GraphNodeInfra {
+		status = "infra, " + status
+	}
+	return status
+}
+
+// blockedEdges returns the container edges on the paths to blocking
+// dependencies.
+func blockedEdges(report *entities.GraphReport) map[[2]string]bool {
+	edges := make(map[[2]string]bool)
+	for _, b := range report.Blockers {
+		path := append(append([]string{b.Container}, b.Path...), b.Dependency)
+		for i := 1; i < len(path); i++ {
+			edges[[2]string{path[i-1], path[i]}] = true
+		}
+	}
+	return edges
+}
+
+func writeGraphDot(w io.Writer, report *entities.GraphReport) error {
+	var sb strings.Builder
+	sb.WriteString("digraph containers {\n\trankdir=LR;\n\tnode [fontname=\"sans-serif\"];\n")
+
+	writeNode := func(indent string, n *entities.GraphNode) {
+		var attrs []string
+		switch n.Kind {
+		case entities.GraphNodeNetwork:
+			attrs = append(attrs, "shape=ellipse", "label="+strconv.Quote("network\n"+n.Name))
+		case entities.GraphNodeVolume:
+			attrs = append(attrs, "shape=cylinder", "label="+strconv.Quote("volume\n"+n.Name))
+		default:
+			color := "gray"
+			switch {
+			case n.State == "running" && n.Health != "unhealthy":
+				color = "darkgreen"
+			case n.State == "paused" || n.Health == "unhealthy" || (n.State == "exited" && n.ExitCode != 0):
+				color = "red"
+			}
+			attrs = append(attrs, "shape=box", "color="+color, "label="+strconv.Quote(n.Name+"\n"+graphStatus(n)))
+			if n.Kind == entities.GraphNodeInfra {
+				attrs = append(attrs, "style=dashed")
+			}
+		}
+		fmt.Fprintf(&sb, "%s%s [%s];\n", indent, strconv.Quote(n.ID), strings.Join(attrs, ", "))
+	}
+
+	// Group the containers of each pod in a cluster.
+	var pods []string
+	for i := range report.Nodes {
+		n := &report.Nodes[i]
+		if n.Pod == "" {
+			writeNode("\t", n)
+		} else if !slices.Contains(pods, n.Pod) {
+			pods = append(pods, n.Pod)
+		}
+	}
+	for i, pod := range pods {
+		fmt.Fprintf(&sb, "\tsubgraph cluster_%d {\n\t\tlabel=%s;\n", i, strconv.Quote("pod "+pod))
+		for j := range report.Nodes {
+			if report.Nodes[j].Pod == pod {
+				writeNode("\t\t", &report.Nodes[j])
+			}
+		}
+		sb.WriteString("\t}\n")
+	}
+
+	blocked := blockedEdges(report)
+	for _, e := range report.Edges {
+		attrs := []string{"label=" + strconv.Quote(strings.Join(e.Kinds, ","))}
+		if blocked[[2]string{e.From, e.To}] {
+			attrs = append(attrs, "color=red", "style=bold")
+		}
+		fmt.Fprintf(&sb, "\t%s -> %s [%s];\n", strconv.Quote(e.From), strconv.Quote(e.To), strings.Join(attrs, ", "))
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeGraphTree(w io.Writer, report *entities.GraphReport) error {
+	nodes := make(map[string]*entities.GraphNode, len(report.Nodes))
+	for i := range report.Nodes {
+		nodes[report.Nodes[i].ID] = &report.Nodes[i]
+	}
+	children := make(map[string][]entities.GraphEdge)
+	dependedOn := make(map[string]bool)
+	for _, e := range report.Edges {
+		children[e.From] = append(children[e.From], e)
+		if isContainerNode(nodes[e.To]) {
+			dependedOn[e.To] = true
+		}
+	}
+
+	var sb strings.Builder
+	var writeChildren func(id, prefix string)
+	writeChildren = func(id, prefix string) {
+		edges := children[id]
+		for i, e := range edges {
+			branch, next := "├── ", "│   "
+			if i == len(edges)-1 {
+				branch, next = "└── ", "    "
+			}
+			n := nodes[e.To]
+			if isContainerNode(n) {
+				fmt.Fprintf(&sb, "%s%s%s [%s] (%s)\n", prefix, branch, n.Name, graphStatus(n), strings.Join(e.Kinds, ", "))
+				writeChildren(e.To, prefix+next)
+			} else {
+				fmt.Fprintf(&sb, "%s%s%s %s\n", prefix, branch, n.Kind, n.Name)
+			}
+		}
+	}
+	for i := range report.Nodes {
+		n := &report.Nodes[i]
+		if !isContainerNode(n) || dependedOn[n.ID] {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s [%s]", n.Name, graphStatus(n))
+		if n.Pod != "" {
+			fmt.Fprintf(&sb, " in pod %s", n.Pod)
+		}
+		sb.WriteString("\n")
+		writeChildren(n.ID, "")
+	}
+
+	if len(report.Blockers) > 0 {
+		sb.WriteString("\nBlocked containers:\n")
+		for _, b := range report.Blockers {
+			fmt.Fprintf(&sb, "%s cannot start: %s %s", nodes[b.Container].Name, nodes[b.Dependency].Name, b.Reason)
+			if len(b.Path) > 0 {
+				names := make([]string, 0, len(b.Path))
+				for _, id := range b.Path {
+					names = append(names, nodes[id].Name)
+				}
+				fmt.Fprintf(&sb, " (via %s)", strings.Join(names, ", "))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
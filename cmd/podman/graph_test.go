@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGraphReport() *entities.GraphReport {
+	return &entities.GraphReport{
+		Nodes: []entities.GraphNode{
+			{ID: "a1", Name: "api", Kind: entities.GraphNodeContainer, Pod: "web", State: "created"},
+			{ID: "d1", Name: "db", Kind: entities.GraphNodeContainer, State: "exited", ExitCode: 1},
+			{ID: "i1", Name: "web-infra", Kind: entities.GraphNodeInfra, Pod: "web", State: "running"},
+			{ID: "p1", Name: "proxy", Kind: entities.GraphNodeContainer, State: "running", Health: "healthy"},
+			{ID: "network:podman", Name: "podman", Kind: entities.GraphNodeNetwork},
+			{ID: "volume:data", Name: "data", Kind: entities.GraphNodeVolume},
+		},
+		Edges: []entities.GraphEdge{
+			{From: "a1", To: "d1", Kinds: []string{"requires"}},
+			{From: "a1", To: "i1", Kinds: []string{"ipc", "net", "uts"}},
+			{From: "d1", To: "network:podman", Kinds: []string{"network"}},
+			{From: "d1", To: "volume:data", Kinds: []string{"volume"}},
+			{From: "i1", To: "network:podman", Kinds: []string{"network"}},
+		},
+		Blockers: []entities.GraphBlocker{
+			{Container: "a1", Dependency: "d1", Reason: "exited with code 1"},
+		},
+	}
+}
+
+func TestWriteGraphTree(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, writeGraphTree(&sb, testGraphReport()))
+	assert.Equal(t, `api [created] in pod web
+├── db [exited (1)] (requires)
+│   ├── network podman
+│   └── volume data
+└── web-infra [infra, running] (ipc, net, uts)
+    └── network podman
+proxy [running, healthy]
+
+Blocked containers:
+api cannot start: db exited with code 1
+`, sb.String())
+}
+
+func TestWriteGraphDot(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, writeGraphDot(&sb, testGraphReport()))
+	dot := sb.String()
+	assert.True(t, strings.HasPrefix(dot, "digraph containers {\n"))
+	assert.True(t, strings.HasSuffix(dot, "}\n"))
+	assert.Contains(t, dot, "\t\"d1\" [shape=box, color=red, label=\"db\\nexited (1)\"];\n")
+	assert.Contains(t, dot, "\tsubgraph cluster_0 {\n\t\tlabel=\"pod web\";\n\t\t\"a1\" [shape=box, color=gray, label=\"api\\ncreated\"];\n")
+	assert.Contains(t, dot, "\t\t\"i1\" [shape=box, color=darkgreen, label=\"web-infra\\ninfra, running\", style=dashed];\n")
+	assert.Contains(t, dot, "\t\"volume:data\" [shape=cylinder, label=\"volume\\ndata\"];\n")
+	assert.Contains(t, dot, "\t\"a1\" -> \"d1\" [label=\"requires\", color=red, style=bold];\n")
+	assert.Contains(t, dot, "\t\"a1\" -> \"i1\" [label=\"ipc,net,uts\"];\n")
+}
@@ -0,0 +1,182 @@
+package images
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/analyze"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	analyzeDescription = `Analyze the layers of a local image.
+
+  The files each layer adds, modifies and deletes are read from the local storage. Files stored in a layer but replaced or deleted by a later layer waste space; their size is reported along with the efficiency of the image, the percentage of the size of all layers which is visible in the image.`
+	analyzeCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "analyze [options] IMAGE",
+		Short:             "Analyze the layers of an image for wasted space",
+		Long:              analyzeDescription,
+		RunE:              analyzeImage,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteImages,
+		Example: `podman image analyze quay.io/myrepo/myimage:latest
+  podman image analyze --files myimage
+  podman image analyze --fail-under 95 --format json myimage`,
+	}
+)
+
+// analyzeWastedLimit is the number of wasteful paths listed without --files.
+const analyzeWastedLimit = 10
+
+var analyzeOptions = struct {
+	entities.ImageAnalyzeOptions
+	format    string
+	files     bool
+	failUnder float64
+}{}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: analyzeCommand,
+		Parent:  imageCmd,
+	})
+	flags := analyzeCommand.Flags()
+
+	flags.BoolVar(&analyzeOptions.files, "files", false, "List the changed files of each layer and all wasteful files")
+
+	failUnderFlagName := "fail-under"
+	flags.Float64Var(&analyzeOptions.failUnder, failUnderFlagName, 0, "Exit with an error if the efficiency of the image is below the `percentage`")
+	_ = analyzeCommand.RegisterFlagCompletionFunc(failUnderFlagName, completion.AutocompleteNone)
+
+	formatFlagName := "format"
+	flags.StringVar(&analyzeOptions.format, formatFlagName, "", "Change the output to JSON or a Go template")
+	_ = analyzeCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.ImageAnalyzeReport{}))
+}
+
+func analyzeImage(cmd *cobra.Command, args []string) error {
+	if analyzeOptions.failUnder < 0 || analyzeOptions.failUnder > 100 {
+		return fmt.Errorf("--fail-under must be a percentage between 0 and 100, not %g", analyzeOptions.failUnder)
+	}
+
+	result, err := registry.ImageEngine().Analyze(registry.Context(), args[0], analyzeOptions.ImageAnalyzeOptions)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case report.IsJSON(analyzeOptions.format):
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	case cmd.Flags().Changed("format"):
+		rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginUser, analyzeOptions.format)
+		if err != nil {
+			return err
+		}
+		if err := rpt.Execute(result); err != nil {
+			return err
+		}
+		if err := rpt.Flush(); err != nil {
+			return err
+		}
+	default:
+		if err := printAnalyze(os.Stdout, result, analyzeOptions.files); err != nil {
+			return err
+		}
+	}
+
+	if result.Efficiency < analyzeOptions.failUnder {
+		registry.SetExitCode(1)
+		return fmt.Errorf("efficiency of image %s is %.2f%%, below the threshold of %g%%", result.Name, result.Efficiency, analyzeOptions.failUnder)
+	}
+	return nil
+}
+
+func humanSize(size int64) string {
+	return units.HumanSizeWithPrecision(float64(size), 3)
+}
+
+func printAnalyze(w io.Writer, r *entities.ImageAnalyzeReport, files bool) error {
+	fmt.Fprintf(w, "Image:       %s\n", r.Name)
+	fmt.Fprintf(w, "ID:          %s\n", r.ID)
+	fmt.Fprintf(w, "Total size:  %s\n", humanSize(r.TotalBytes))
+	fmt.Fprintf(w, "Wasted:      %s\n", humanSize(r.WastedBytes))
+	fmt.Fprintf(w, "Efficiency:  %.2f%%\n\n", r.Efficiency)
+
+	tw, err := report.NewWriterDefault(w)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(tw, "LAYER\tSIZE\tADDED\tMODIFIED\tDELETED\tWASTED\tCREATED BY")
+	for _, l := range r.Layers {
+		fmt.Fprintf(tw, "%.12s\t%s\t%d\t%d\t%d\t%s\t%s\n", l.ID, humanSize(l.Size), l.Added, l.Modified, l.Deleted, humanSize(l.WastedBytes), createdBy(l.CreatedBy))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if files {
+		for _, l := range r.Layers {
+			fmt.Fprintf(w, "\nLayer %.12s:\n", l.ID)
+			for _, c := range l.Changes {
+				fmt.Fprintf(w, "%s %s (%s)\n", changeSymbol(c.Kind), c.Path, humanSize(c.Size))
+			}
+		}
+	}
+
+	if len(r.Wasted) == 0 {
+		return nil
+	}
+	wasted := r.Wasted
+	if !files && len(wasted) > analyzeWastedLimit {
+		wasted = wasted[:analyzeWastedLimit]
+	}
+	fmt.Fprintln(w)
+	tw, err = report.NewWriterDefault(w)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(tw, "COUNT\tWASTED\tPATH")
+	for _, f := range wasted {
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", f.Count, humanSize(f.WastedBytes), f.Path)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if n := len(r.Wasted) - len(wasted); n > 0 {
+		fmt.Fprintf(w, "... and %d more, use --files to list all\n", n)
+	}
+	return nil
+}
+
+// changeSymbol returns the symbol podman diff uses for the kind of change.
+func changeSymbol(kind analyze.ChangeKind) string {
+	switch kind {
+	case analyze.Added:
+		return "A"
+	case analyze.Deleted:
+		return "D"
+	default:
+		return "C"
+	}
+}
+
+// createdBy shortens the command which created a layer to one line.
+func createdBy(command string) string {
+	command = strings.Join(strings.Fields(strings.TrimPrefix(command, "/bin/sh -c #(nop) ")), " ")
+	if runes := []rune(command); len(runes) > 45 {
+		return string(runes[:42]) + "..."
+	}
+	return command
+}
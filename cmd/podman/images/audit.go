@@ -0,0 +1,169 @@
+package images
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	auditDescription = `Check the packages installed in images for known vulnerabilities.
+
+  The packages are matched against a local database of OSV advisories imported with --import-db, no network access is needed.`
+	auditCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "audit [options] [IMAGE...]",
+		Short:             "Check images for known vulnerabilities",
+		Long:              auditDescription,
+		RunE:              audit,
+		ValidArgsFunction: common.AutocompleteImages,
+		Example: `podman image audit --import-db ./all.zip
+  podman image audit quay.io/libpod/alpine
+  podman image audit --fail-on high quay.io/libpod/alpine`,
+	}
+)
+
+// auditSeverities ranks the severities reported by the image engine.
+var auditSeverities = []string{"unknown", "low", "medium", "high", "critical"}
+
+var auditOptions = struct {
+	importDB []string
+	failOn   string
+	format   string
+	noTrunc  bool
+}{}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: auditCommand,
+		Parent:  imageCmd,
+	})
+	flags := auditCommand.Flags()
+
+	importDBFlagName := "import-db"
+	flags.StringArrayVar(&auditOptions.importDB, importDBFlagName, nil, "Import OSV advisories from a JSON file, zip archive or directory into the vulnerability database")
+	_ = auditCommand.RegisterFlagCompletionFunc(importDBFlagName, completion.AutocompleteDefault)
+
+	failOnFlagName := "fail-on"
+	flags.StringVar(&auditOptions.failOn, failOnFlagName, "", "Exit with an error if a vulnerability of at least this `severity` is found (low, medium, high, critical)")
+	_ = auditCommand.RegisterFlagCompletionFunc(failOnFlagName, common.AutocompleteSeverity)
+
+	formatFlagName := "format"
+	flags.StringVar(&auditOptions.format, formatFlagName, "", "Change the output to JSON or a Go template")
+	_ = auditCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&auditReporter{}))
+
+	flags.BoolVar(&auditOptions.noTrunc, "no-trunc", false, "Do not truncate the output")
+}
+
+func audit(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && len(auditOptions.importDB) == 0 {
+		return errors.New("at least one image or --import-db must be specified")
+	}
+	threshold := 0
+	if cmd.Flags().Changed("fail-on") {
+		threshold = slices.Index(auditSeverities, strings.ToLower(auditOptions.failOn))
+		if threshold < 1 {
+			return fmt.Errorf("invalid severity %q, must be one of %s", auditOptions.failOn, strings.Join(auditSeverities[1:], ", "))
+		}
+	}
+
+	if len(auditOptions.importDB) > 0 {
+		imported, err := registry.ImageEngine().AuditImport(registry.Context(), auditOptions.importDB)
+		if err != nil {
+			return err
+		}
+		out := os.Stdout
+		if len(args) > 0 {
+			out = os.Stderr
+		}
+		fmt.Fprintf(out, "Imported %d advisories, the database holds %d advisories\n", imported.Imported, imported.Advisories)
+		if len(args) == 0 {
+			return nil
+		}
+	}
+
+	reports, err := registry.ImageEngine().Audit(registry.Context(), args, entities.ImageAuditOptions{})
+	if err != nil {
+		return err
+	}
+	if err := printAudit(cmd, reports); err != nil {
+		return err
+	}
+
+	if threshold > 0 {
+		count := 0
+		for _, r := range reports {
+			for _, v := range r.Vulnerabilities {
+				if slices.Index(auditSeverities, v.Severity) >= threshold {
+					count++
+				}
+			}
+		}
+		if count > 0 {
+			registry.SetExitCode(1)
+			return fmt.Errorf("found %d vulnerabilities with severity %s or higher", count, auditSeverities[threshold])
+		}
+	}
+	return nil
+}
+
+func printAudit(cmd *cobra.Command, reports []*entities.ImageAuditReport) error {
+	if report.IsJSON(auditOptions.format) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		return enc.Encode(reports)
+	}
+
+	rows := []auditReporter{}
+	for _, r := range reports {
+		for _, v := range r.Vulnerabilities {
+			rows = append(rows, auditReporter{ImageVulnerability: v, Image: r.Name})
+		}
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	var err error
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, auditOptions.format)
+	} else {
+		format := "{{range .}}{{.Image}}\t{{.Package}}\t{{.Version}}\t{{.ID}}\t{{.Severity}}\t{{.Fixed}}\n{{end -}}"
+		rpt, err = rpt.Parse(report.OriginPodman, format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders {
+		hdrs := report.Headers(auditReporter{}, map[string]string{
+			"ID":    "VULNERABILITY",
+			"Fixed": "FIXED IN",
+		})
+		if err := rpt.Execute(hdrs); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(rows)
+}
+
+type auditReporter struct {
+	entities.ImageVulnerability
+	Image string
+}
+
+func (a auditReporter) Version() string {
+	if !auditOptions.noTrunc && len(a.ImageVulnerability.Version) > 30 {
+		return a.ImageVulnerability.Version[:30-3] + "..."
+	}
+	return a.ImageVulnerability.Version
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/containers/podman/v6/pkg/domain/entities"
 	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
 	"go.podman.io/common/pkg/report"
 	"go.podman.io/image/v5/docker/reference"
 )
@@ -109,6 +110,10 @@ func imageListFlagSet(cmd *cobra.Command) {
 	_ = cmd.RegisterFlagCompletionFunc(sortFlagName, common.AutocompleteImageSort)
 
 	flags.BoolVarP(&listFlag.history, "history", "", false, "Display the image name history")
+
+	whereFlagName := "where"
+	flags.StringVar(&listOptions.Where, whereFlagName, "", "Only list images whose inspect data matches the `expression`")
+	_ = cmd.RegisterFlagCompletionFunc(whereFlagName, completion.AutocompleteNone)
 }
 
 func images(cmd *cobra.Command, args []string) error {
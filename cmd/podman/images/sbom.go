@@ -0,0 +1,116 @@
+package images
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/util"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/auth"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/image/v5/types"
+)
+
+var (
+	sbomDescription = `Generate a software bill of materials (SBOM) for an image.
+
+  The layers of the image are scanned for rpm, dpkg and apk package databases, Python and npm package manifests and Go binaries.`
+	sbomCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "sbom [options] IMAGE",
+		Short:             "Generate an SBOM for an image",
+		Long:              sbomDescription,
+		RunE:              sbom,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteImages,
+		Example: `podman image sbom quay.io/libpod/alpine
+  podman image sbom --format cyclonedx --output alpine.cdx.json quay.io/libpod/alpine
+  podman image sbom --attach quay.io/myrepo/myimage:latest`,
+	}
+)
+
+var sbomOptions = struct {
+	entities.ImageSBOMOptions
+	output         string
+	quiet          bool
+	tlsVerifyCLI   bool
+	credentialsCLI string
+}{}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: sbomCommand,
+		Parent:  imageCmd,
+	})
+	flags := sbomCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&sbomOptions.Format, formatFlagName, "spdx", "Format of the SBOM (spdx, cyclonedx)")
+	_ = sbomCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteSBOMFormat)
+
+	outputFlagName := "output"
+	flags.StringVarP(&sbomOptions.output, outputFlagName, "o", "", "Write the SBOM to the specified file instead of stdout")
+	_ = sbomCommand.RegisterFlagCompletionFunc(outputFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&sbomOptions.Attach, "attach", false, "Push the SBOM as an artifact to the repository of the image")
+	flags.BoolVarP(&sbomOptions.quiet, "quiet", "q", false, "Suppress output information when pushing the SBOM")
+
+	authfileFlagName := "authfile"
+	flags.StringVar(&sbomOptions.Authfile, authfileFlagName, auth.GetDefaultAuthFile(), "Path of the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
+	_ = sbomCommand.RegisterFlagCompletionFunc(authfileFlagName, completion.AutocompleteDefault)
+
+	certDirFlagName := "cert-dir"
+	flags.StringVar(&sbomOptions.CertDir, certDirFlagName, "", "`Pathname` of a directory containing TLS certificates and keys")
+	_ = sbomCommand.RegisterFlagCompletionFunc(certDirFlagName, completion.AutocompleteDefault)
+
+	credsFlagName := "creds"
+	flags.StringVar(&sbomOptions.credentialsCLI, credsFlagName, "", "`Credentials` (USERNAME:PASSWORD) to use for authenticating to a registry")
+	_ = sbomCommand.RegisterFlagCompletionFunc(credsFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&sbomOptions.tlsVerifyCLI, "tls-verify", true, "Require HTTPS and verify certificates when contacting registries")
+}
+
+func sbom(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("authfile") {
+		if err := auth.CheckAuthFile(sbomOptions.Authfile); err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("tls-verify") {
+		sbomOptions.SkipTLSVerify = types.NewOptionalBool(!sbomOptions.tlsVerifyCLI)
+	}
+	if sbomOptions.credentialsCLI != "" {
+		creds, err := util.ParseRegistryCreds(sbomOptions.credentialsCLI)
+		if err != nil {
+			return err
+		}
+		sbomOptions.Username = creds.Username
+		sbomOptions.Password = creds.Password
+	}
+	if !sbomOptions.quiet {
+		sbomOptions.Writer = os.Stderr
+	}
+
+	report, err := registry.ImageEngine().SBOM(registry.Context(), args[0], sbomOptions.ImageSBOMOptions)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case sbomOptions.output != "":
+		if err := os.WriteFile(sbomOptions.output, report.SBOM, 0o644); err != nil {
+			return err
+		}
+	case !sbomOptions.Attach:
+		if _, err := os.Stdout.Write(report.SBOM); err != nil {
+			return err
+		}
+	}
+	if sbomOptions.Attach {
+		fmt.Printf("%s@%s\n", report.Artifact, report.ArtifactDigest)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package images
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/util"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/auth"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+	"go.podman.io/image/v5/types"
+)
+
+var (
+	verifyDescription = `Verify the signatures of a local image against the signature policy.
+
+  The requirements of the policy that applies when pulling the image are evaluated against the image and the signatures stored with it. Each requirement, signature and, with --attestations, attestation attached to the image in its registry is reported.`
+	verifyCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "verify [options] IMAGE",
+		Short:             "Verify the signatures of an image",
+		Long:              verifyDescription,
+		RunE:              verify,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteImages,
+		Example: `podman image verify quay.io/myrepo/myimage:latest
+  podman image verify --format json quay.io/myrepo/myimage:latest
+  podman image verify --attestations quay.io/myrepo/myimage:latest`,
+	}
+)
+
+var verifyOptions = struct {
+	entities.ImageVerifyOptions
+	format         string
+	tlsVerifyCLI   bool
+	credentialsCLI string
+}{}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: verifyCommand,
+		Parent:  imageCmd,
+	})
+	flags := verifyCommand.Flags()
+
+	flags.BoolVar(&verifyOptions.Attestations, "attestations", false, "Fetch the attestations attached to the image from its registry and verify them")
+
+	formatFlagName := "format"
+	flags.StringVar(&verifyOptions.format, formatFlagName, "", "Change the output to JSON or a Go template")
+	_ = verifyCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.ImageVerifyReport{}))
+
+	policyPathFlagName := "policypath"
+	flags.StringVar(&verifyOptions.PolicyPath, policyPathFlagName, "", "Path of the signature policy file")
+	_ = verifyCommand.RegisterFlagCompletionFunc(policyPathFlagName, completion.AutocompleteDefault)
+
+	authfileFlagName := "authfile"
+	flags.StringVar(&verifyOptions.Authfile, authfileFlagName, auth.GetDefaultAuthFile(), "Path of the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
+	_ = verifyCommand.RegisterFlagCompletionFunc(authfileFlagName, completion.AutocompleteDefault)
+
+	certDirFlagName := "cert-dir"
+	flags.StringVar(&verifyOptions.CertDir, certDirFlagName, "", "`Pathname` of a directory containing TLS certificates and keys")
+	_ = verifyCommand.RegisterFlagCompletionFunc(certDirFlagName, completion.AutocompleteDefault)
+
+	credsFlagName := "creds"
+	flags.StringVar(&verifyOptions.credentialsCLI, credsFlagName, "", "`Credentials` (USERNAME:PASSWORD) to use for authenticating to a registry")
+	_ = verifyCommand.RegisterFlagCompletionFunc(credsFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&verifyOptions.tlsVerifyCLI, "tls-verify", true, "Require HTTPS and verify certificates when contacting registries")
+}
+
+func verify(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("authfile") {
+		if err := auth.CheckAuthFile(verifyOptions.Authfile); err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("tls-verify") {
+		verifyOptions.SkipTLSVerify = types.NewOptionalBool(!verifyOptions.tlsVerifyCLI)
+	}
+	if verifyOptions.credentialsCLI != "" {
+		creds, err := util.ParseRegistryCreds(verifyOptions.credentialsCLI)
+		if err != nil {
+			return err
+		}
+		verifyOptions.Username = creds.Username
+		verifyOptions.Password = creds.Password
+	}
+
+	result, err := registry.ImageEngine().Verify(registry.Context(), args[0], verifyOptions.ImageVerifyOptions)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case report.IsJSON(verifyOptions.format):
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	case cmd.Flags().Changed("format"):
+		rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginUser, verifyOptions.format)
+		if err != nil {
+			return err
+		}
+		if err := rpt.Execute(result); err != nil {
+			return err
+		}
+		if err := rpt.Flush(); err != nil {
+			return err
+		}
+	default:
+		printVerify(os.Stdout, result)
+	}
+
+	if !result.Allowed {
+		registry.SetExitCode(1)
+		return fmt.Errorf("image %s is rejected by the policy in %s", result.Name, result.PolicyPath)
+	}
+	for _, a := range result.Attestations {
+		if !a.Verified {
+			registry.SetExitCode(1)
+			return fmt.Errorf("attestation in %s could not be verified: %s", a.Reference, a.Error)
+		}
+	}
+	return nil
+}
+
+func printVerify(w io.Writer, r *entities.ImageVerifyReport) {
+	result := "rejected"
+	if r.Allowed {
+		result = "accepted"
+	}
+	fmt.Fprintf(w, "Image:   %s\n", r.Name)
+	fmt.Fprintf(w, "Digest:  %s\n", r.Digest)
+	fmt.Fprintf(w, "Policy:  %s (scope %s)\n", r.PolicyPath, r.Scope)
+	fmt.Fprintf(w, "Result:  %s\n", result)
+
+	fmt.Fprintln(w, "\nRequirements:")
+	if len(r.Requirements) == 0 {
+		fmt.Fprintln(w, "  none, the image is rejected")
+	}
+	for _, req := range r.Requirements {
+		switch {
+		case !req.Allowed:
+			fmt.Fprintf(w, "  %s: rejected: %s\n", req.Type, req.Error)
+		case len(req.MatchedKeys) > 0:
+			fmt.Fprintf(w, "  %s: satisfied by %s\n", req.Type, strings.Join(req.MatchedKeys, ", "))
+		case req.SubjectEmail != "" || req.OIDCIssuer != "":
+			fmt.Fprintf(w, "  %s: satisfied by Fulcio identity %s\n", req.Type, fulcioIdentity(req.SubjectEmail, req.OIDCIssuer))
+		default:
+			fmt.Fprintf(w, "  %s: satisfied\n", req.Type)
+		}
+	}
+
+	fmt.Fprintln(w, "\nSignatures:")
+	if len(r.Signatures) == 0 {
+		fmt.Fprintln(w, "  none")
+	}
+	for i, sig := range r.Signatures {
+		fmt.Fprintf(w, "  %d: %s", i+1, sig.Format)
+		if sig.DockerReference != "" {
+			fmt.Fprintf(w, " for %s", sig.DockerReference)
+		}
+		if sig.KeyID != "" {
+			fmt.Fprintf(w, ", key ID %s", sig.KeyID)
+		}
+		if sig.Identity != "" {
+			fmt.Fprintf(w, ", identity %s", fulcioIdentity(sig.Identity, sig.Issuer))
+		}
+		if sig.Rekor {
+			fmt.Fprint(w, ", Rekor")
+		}
+		fmt.Fprintln(w)
+		printVerification(w, sig.Verified, sig.Key, sig.Error)
+	}
+
+	if r.Attestations == nil {
+		return
+	}
+	fmt.Fprintln(w, "\nAttestations:")
+	if len(r.Attestations) == 0 {
+		fmt.Fprintln(w, "  none")
+	}
+	for i, att := range r.Attestations {
+		predicate := att.PredicateType
+		if predicate == "" {
+			predicate = "unknown predicate"
+		}
+		fmt.Fprintf(w, "  %d: %s in %s", i+1, predicate, att.Reference)
+		if att.Identity != "" {
+			fmt.Fprintf(w, ", identity %s", fulcioIdentity(att.Identity, att.Issuer))
+		}
+		fmt.Fprintln(w)
+		printVerification(w, att.Verified, att.Key, att.Error)
+	}
+}
+
+func printVerification(w io.Writer, verified bool, key, errMsg string) {
+	if verified {
+		fmt.Fprintf(w, "     verified by %s\n", key)
+		return
+	}
+	fmt.Fprintf(w, "     not verified: %s\n", errMsg)
+}
+
+func fulcioIdentity(subject, issuer string) string {
+	if issuer == "" {
+		return subject
+	}
+	return fmt.Sprintf("%s (issuer %s)", subject, issuer)
+}
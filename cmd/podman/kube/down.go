@@ -9,7 +9,8 @@ import (
 )
 
 type downKubeOptions struct {
-	Force bool
+	Force  bool
+	values valuesOptions
 }
 
 var (
@@ -45,10 +46,15 @@ func downFlags(cmd *cobra.Command) {
 	flags.SetNormalizeFunc(utils.AliasFlags)
 
 	flags.BoolVar(&downOptions.Force, "force", false, "remove volumes")
+	valuesFlags(cmd, &downOptions.values)
 }
 
 func down(_ *cobra.Command, args []string) error {
-	reader, err := readerFromArgs(args)
+	values, err := downOptions.values.load()
+	if err != nil {
+		return err
+	}
+	reader, err := readerFromArgs(args, values)
 	if err != nil {
 		return err
 	}
@@ -40,6 +40,8 @@ type playKubeOptionsWrapper struct {
 	BuildCLI       bool
 	annotations    []string
 	macs           []string
+	values         valuesOptions
+	render         bool
 }
 
 const yamlFileSeparator = "\n---\n"
@@ -160,6 +162,9 @@ func playFlags(cmd *cobra.Command) {
 	replaceFlagName := "replace"
 	flags.BoolVar(&playOptions.Replace, replaceFlagName, false, "Delete and recreate pods defined in the YAML file")
 
+	rollingFlagName := "rolling"
+	flags.BoolVar(&playOptions.Rolling, rollingFlagName, false, "Update the pods of running Deployments one revision at a time, keeping the previous revision for rolling back")
+
 	publishPortsFlagName := "publish"
 	flags.StringSliceVar(&playOptions.PublishPorts, publishPortsFlagName, []string{}, "Publish a container's port, or a range of ports, to the host")
 	_ = cmd.RegisterFlagCompletionFunc(publishPortsFlagName, completion.AutocompleteNone)
@@ -174,6 +179,9 @@ func playFlags(cmd *cobra.Command) {
 	flags.StringArrayVar(&playOptions.ConfigMaps, configmapFlagName, []string{}, "`Pathname` of a YAML file containing a kubernetes configmap")
 	_ = cmd.RegisterFlagCompletionFunc(configmapFlagName, completion.AutocompleteDefault)
 
+	valuesFlags(cmd, &playOptions.values)
+	flags.BoolVar(&playOptions.render, "render", false, "Print the Kubernetes YAML rendered with the values instead of playing it")
+
 	noTruncFlagName := "no-trunc"
 	flags.BoolVar(&playOptions.UseLongAnnotations, noTruncFlagName, false, "Use annotations that are not truncated to the Kubernetes maximum length of 63 characters")
 	_ = flags.MarkHidden(noTruncFlagName)
@@ -280,8 +288,27 @@ func play(cmd *cobra.Command, args []string) error {
 	if playOptions.Force && !playOptions.Down {
 		return errors.New("--force may be specified only with --down")
 	}
+	if playOptions.Rolling && (playOptions.Down || playOptions.Replace || playOptions.Wait) {
+		return errors.New("--rolling may not be combined with --down, --replace or --wait")
+	}
 
-	reader, err := readerFromArgs(args)
+	values, err := playOptions.values.load()
+	if err != nil {
+		return err
+	}
+	if playOptions.render {
+		if values == nil {
+			values = make(map[string]any)
+		}
+		reader, err := readerFromArgs(args, values)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(os.Stdout, reader)
+		return err
+	}
+
+	reader, err := readerFromArgs(args, values)
 	if err != nil {
 		return err
 	}
@@ -311,7 +338,7 @@ func play(cmd *cobra.Command, args []string) error {
 		playOptions.ServiceContainer = true
 
 		// Read the kube yaml file again so that a reader can be passed down to the teardown function
-		teardownReader, err = readerFromArgs(args)
+		teardownReader, err = readerFromArgs(args, values)
 		if err != nil {
 			return err
 		}
@@ -369,17 +396,24 @@ func playKube(cmd *cobra.Command, args []string) error {
 	return play(cmd, args)
 }
 
-func readerFromArgs(args []string) (*bytes.Reader, error) {
-	return readerFromArgsWithStdin(args, os.Stdin)
+// readerFromArgs reads the Kubernetes YAML files and, if values are given,
+// renders their template placeholders.
+func readerFromArgs(args []string, values map[string]any) (*bytes.Reader, error) {
+	return readerFromArgsWithStdin(args, os.Stdin, values)
 }
 
-func readerFromArgsWithStdin(args []string, stdin io.Reader) (*bytes.Reader, error) {
+func readerFromArgsWithStdin(args []string, stdin io.Reader, values map[string]any) (*bytes.Reader, error) {
 	// if user tried to pipe, shortcut the reading
 	if len(args) == 1 && args[0] == "-" {
 		data, err := io.ReadAll(stdin)
 		if err != nil {
 			return nil, err
 		}
+		if values != nil {
+			if data, err = renderKube("stdin", data, values); err != nil {
+				return nil, err
+			}
+		}
 		return bytes.NewReader(data), nil
 	}
 
@@ -391,11 +425,17 @@ func readerFromArgsWithStdin(args []string, stdin io.Reader) (*bytes.Reader, err
 			return nil, err
 		}
 
-		_, err = io.Copy(&combined, reader)
+		data, err := io.ReadAll(reader)
 		reader.Close()
 		if err != nil {
 			return nil, err
 		}
+		if values != nil {
+			if data, err = renderKube(arg, data, values); err != nil {
+				return nil, err
+			}
+		}
+		combined.Write(data)
 
 		if i < len(args)-1 {
 			// separate multiple files with YAML document separator
@@ -421,10 +461,11 @@ func readerFromArg(fileOrURL string) (io.ReadCloser, error) {
 
 func teardown(body io.Reader, options entities.PlayKubeDownOptions) error {
 	var (
-		podStopErrors utils.OutputErrors
-		podRmErrors   utils.OutputErrors
-		volRmErrors   utils.OutputErrors
-		secRmErrors   utils.OutputErrors
+		podStopErrors  utils.OutputErrors
+		podRmErrors    utils.OutputErrors
+		volRmErrors    utils.OutputErrors
+		secRmErrors    utils.OutputErrors
+		policyRmErrors utils.OutputErrors
 	)
 	reports, err := registry.ContainerEngine().PlayKubeDown(registry.Context(), body, options)
 	if err != nil {
@@ -478,6 +519,22 @@ func teardown(body io.Reader, options entities.PlayKubeDownOptions) error {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", lastSecretRmError)
 	}
 
+	// Output rm'd network policies
+	if len(reports.NetworkPolicyRmReport) > 0 {
+		fmt.Println("Network policies removed:")
+	}
+	for _, removed := range reports.NetworkPolicyRmReport {
+		switch {
+		case removed.Err != nil:
+			policyRmErrors = append(policyRmErrors, removed.Err)
+		default:
+			fmt.Println(removed.Name)
+		}
+	}
+	if lastPolicyRmError := policyRmErrors.PrintErrors(); lastPolicyRmError != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", lastPolicyRmError)
+	}
+
 	// Output rm'd volumes
 	fmt.Println("Volumes removed:")
 	for _, removed := range reports.VolumeRmReport {
@@ -533,6 +590,26 @@ func printPlayReport(report *entities.PlayKubeReport) error {
 		fmt.Println(secret.CreateReport.ID)
 	}
 
+	// Print network policies report
+	for i, name := range report.NetworkPolicies {
+		if i == 0 {
+			fmt.Println("Network policies:")
+		}
+		fmt.Println(name)
+	}
+
+	// Print rollouts report
+	for i, rollout := range report.Rollouts {
+		if i == 0 {
+			fmt.Println("Rollouts:")
+		}
+		if rollout.Unchanged {
+			fmt.Printf("%s: revision %d is up to date\n", rollout.Deployment, rollout.Revision)
+		} else {
+			fmt.Printf("%s: rolled out revision %d\n", rollout.Deployment, rollout.Revision)
+		}
+	}
+
 	// Print pods report
 	for _, pod := range report.Pods {
 		for _, l := range pod.Logs {
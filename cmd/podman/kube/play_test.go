@@ -110,7 +110,7 @@ func TestReaderFromArgs(t *testing.T) {
 				paths = append(paths, path)
 			}
 
-			reader, err := readerFromArgsWithStdin(paths, nil)
+			reader, err := readerFromArgsWithStdin(paths, nil, nil)
 			if err != nil {
 				t.Fatalf("readerFromArgsWithStdin failed: %v", err)
 			}
@@ -133,7 +133,7 @@ func TestReaderFromArgs(t *testing.T) {
 func TestReaderFromArgs_Stdin(t *testing.T) {
 	stdinReader := strings.NewReader(namespaceYAML)
 
-	reader, err := readerFromArgsWithStdin([]string{"-"}, stdinReader)
+	reader, err := readerFromArgsWithStdin([]string{"-"}, stdinReader, nil)
 	if err != nil {
 		t.Fatalf("readerFromArgsWithStdin failed: %v", err)
 	}
@@ -0,0 +1,53 @@
+package kube
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	rollbackDescription = `Roll a Deployment played with "podman kube play" back to a previous revision.
+
+  Starts the stopped pod of the previous revision and stops the pod of the running revision once the previous one is ready.`
+
+	rollbackCmd = &cobra.Command{
+		Use:               "rollback [options] DEPLOYMENT",
+		Short:             "Roll back a Deployment to a previous revision",
+		Long:              rollbackDescription,
+		RunE:              rollback,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman kube rollback web
+  podman kube rollback --to-revision 2 web`,
+	}
+
+	rollbackOptions = entities.KubeRollbackOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rollbackCmd,
+		Parent:  kubeCmd,
+	})
+
+	flags := rollbackCmd.Flags()
+	toRevisionFlagName := "to-revision"
+	flags.IntVar(&rollbackOptions.Revision, toRevisionFlagName, 0, "Revision to roll back to, the previously running one if 0")
+	_ = rollbackCmd.RegisterFlagCompletionFunc(toRevisionFlagName, completion.AutocompleteNone)
+}
+
+func rollback(_ *cobra.Command, args []string) error {
+	if rollbackOptions.Revision < 0 {
+		return fmt.Errorf("invalid revision %d", rollbackOptions.Revision)
+	}
+	report, err := registry.ContainerEngine().KubeRollback(registry.Context(), args[0], rollbackOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s: rolled back to revision %d\n", report.Deployment, report.Revision)
+	return nil
+}
@@ -0,0 +1,189 @@
+package kube
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"gopkg.in/yaml.v3"
+)
+
+// noValue is printed by text/template for missing values, it is removed from
+// the rendered YAML like Helm does.
+const noValue = "<no value>"
+
+// valuesOptions are the CLI options setting the values the Kubernetes YAML
+// is rendered with.
+type valuesOptions struct {
+	files []string
+	set   []string
+}
+
+// valuesFlags adds the flags setting the values to the command.
+func valuesFlags(cmd *cobra.Command, options *valuesOptions) {
+	flags := cmd.Flags()
+
+	valuesFlagName := "values"
+	flags.StringArrayVar(&options.files, valuesFlagName, nil, "`Pathname` of a YAML file with values to render the Kubernetes YAML with")
+	_ = cmd.RegisterFlagCompletionFunc(valuesFlagName, completion.AutocompleteDefault)
+
+	setFlagName := "set"
+	flags.StringArrayVar(&options.set, setFlagName, nil, "Set a value to render the Kubernetes YAML with (`KEY=VALUE`)")
+	_ = cmd.RegisterFlagCompletionFunc(setFlagName, completion.AutocompleteNone)
+}
+
+// load returns the values of the values files merged in order, with the
+// --set values on top.  It returns nil if no values are set.
+func (o *valuesOptions) load() (map[string]any, error) {
+	if len(o.files) == 0 && len(o.set) == 0 {
+		return nil, nil
+	}
+	values := make(map[string]any)
+	for _, file := range o.files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fileValues := make(map[string]any)
+		if err := yaml.Unmarshal(data, &fileValues); err != nil {
+			return nil, fmt.Errorf("parsing values file %q: %w", file, err)
+		}
+		mergeValues(values, fileValues)
+	}
+	for _, set := range o.set {
+		if err := setValue(values, set); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// mergeValues merges src into dst.  Maps are merged recursively, other values
+// of src replace the ones in dst.
+func mergeValues(dst, src map[string]any) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]any)
+		dstMap, dstIsMap := dst[key].(map[string]any)
+		if srcIsMap && dstIsMap {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// setValue sets a value given as KEY=VALUE on the command line.  Dots in KEY
+// separate the keys of nested maps.  Booleans and integers are typed, any
+// other value is a string.
+func setValue(values map[string]any, set string) error {
+	key, value, ok := strings.Cut(set, "=")
+	if !ok {
+		return fmt.Errorf("value %q must be of the form KEY=VALUE", set)
+	}
+	path := strings.Split(key, ".")
+	if slices.Contains(path, "") {
+		return fmt.Errorf("value %q has an empty key", set)
+	}
+	current := values
+	for _, k := range path[:len(path)-1] {
+		next, ok := current[k].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			current[k] = next
+		}
+		current = next
+	}
+	current[path[len(path)-1]] = typedValue(value)
+	return nil
+}
+
+func typedValue(value string) any {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	return value
+}
+
+// templateFuncs are the functions available in Kubernetes YAML templates, a
+// subset of the ones of Helm.
+var templateFuncs = template.FuncMap{
+	"default": func(def, value any) any {
+		if isEmpty(value) {
+			return def
+		}
+		return value
+	},
+	"required": func(msg string, value any) (any, error) {
+		if isEmpty(value) {
+			return nil, errors.New(msg)
+		}
+		return value, nil
+	},
+	"quote": func(value any) string {
+		if value == nil {
+			return `""`
+		}
+		return strconv.Quote(fmt.Sprint(value))
+	},
+	"toYaml": func(value any) (string, error) {
+		if value == nil {
+			return "", nil
+		}
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(string(data), "\n"), nil
+	},
+	"indent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+	},
+	"nindent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		return "\n" + pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+	},
+}
+
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// renderKube renders the Go template placeholders in the Kubernetes YAML
+// with the values, available as .Values like in Helm charts.
+func renderKube(name string, data []byte, values map[string]any) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]any{"Values": values}); err != nil {
+		return nil, err
+	}
+	return bytes.ReplaceAll(buf.Bytes(), []byte(noValue), nil), nil
+}
@@ -0,0 +1,132 @@
+package kube
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var templateYAML = strings.Join([]string{
+	"apiVersion: v1",
+	"kind: Pod",
+	"metadata:",
+	"  name: {{ .Values.name | default \"web\" }}",
+	"  labels: {{ toYaml .Values.labels | nindent 4 }}",
+	"spec:",
+	"  containers:",
+	"  - name: ctr",
+	"    image: {{ .Values.image }}",
+	"    env:",
+	"    - name: DEBUG",
+	"      value: {{ .Values.debug | quote }}",
+}, "\n")
+
+func TestValuesLoad(t *testing.T) {
+	base := createTempFile(t, "image: quay.io/podman/hello\nlabels:\n  app: web\n  tier: front\nreplicas: 1\n")
+	prod := createTempFile(t, "labels:\n  tier: back\nreplicas: 3\n")
+
+	options := valuesOptions{
+		files: []string{base, prod},
+		set:   []string{"labels.env=prod", "debug=true", "port=8080", "name=a=b"},
+	}
+	values, err := options.load()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	want := map[string]any{
+		"image":    "quay.io/podman/hello",
+		"labels":   map[string]any{"app": "web", "tier": "back", "env": "prod"},
+		"replicas": 3,
+		"debug":    true,
+		"port":     int64(8080),
+		"name":     "a=b",
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("unexpected values:\n--- got ---\n%v\n--- want ---\n%v", values, want)
+	}
+
+	values, err = (&valuesOptions{}).load()
+	if err != nil || values != nil {
+		t.Errorf("expected no values without options, got %v, %v", values, err)
+	}
+
+	for _, set := range []string{"noequal", "=value", "a..b=c", "a.=b"} {
+		if _, err := (&valuesOptions{set: []string{set}}).load(); err == nil {
+			t.Errorf("expected an error for --set %q", set)
+		}
+	}
+}
+
+func TestRenderKube(t *testing.T) {
+	values := map[string]any{
+		"image":  "quay.io/podman/hello",
+		"labels": map[string]any{"app": "web", "tier": "front"},
+	}
+	rendered, err := renderKube("pod.yaml", []byte(templateYAML), values)
+	if err != nil {
+		t.Fatalf("renderKube failed: %v", err)
+	}
+	want := strings.Join([]string{
+		"apiVersion: v1",
+		"kind: Pod",
+		"metadata:",
+		"  name: web",
+		"  labels: ",
+		"    app: web",
+		"    tier: front",
+		"spec:",
+		"  containers:",
+		"  - name: ctr",
+		"    image: quay.io/podman/hello",
+		"    env:",
+		"    - name: DEBUG",
+		"      value: \"\"",
+	}, "\n")
+	if string(rendered) != want {
+		t.Errorf("unexpected output:\n--- got ---\n%s\n--- want ---\n%s", rendered, want)
+	}
+
+	_, err = renderKube("pod.yaml", []byte(`image: {{ required "image is required" .Values.image }}`), map[string]any{})
+	if err == nil || !strings.Contains(err.Error(), "image is required") {
+		t.Errorf("expected the required error, got %v", err)
+	}
+
+	_, err = renderKube("pod.yaml", []byte("name: {{ .Values.name"), values)
+	if err == nil || !strings.Contains(err.Error(), "pod.yaml") {
+		t.Errorf("expected a parse error naming the file, got %v", err)
+	}
+}
+
+func TestReaderFromArgs_Values(t *testing.T) {
+	paths := []string{
+		createTempFile(t, "name: {{ .Values.first }}"),
+		createTempFile(t, "name: {{ .Values.second }}"),
+	}
+	values := map[string]any{"first": "one", "second": "two"}
+
+	reader, err := readerFromArgsWithStdin(paths, nil, values)
+	if err != nil {
+		t.Fatalf("readerFromArgsWithStdin failed: %v", err)
+	}
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to read result: %v", err)
+	}
+	if got, want := string(output), "name: one\n---\nname: two"; got != want {
+		t.Errorf("unexpected output:\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+
+	// Without values, the YAML is not treated as a template.
+	reader, err = readerFromArgsWithStdin([]string{"-"}, strings.NewReader("command: ['echo', '{{ x }}']"), nil)
+	if err != nil {
+		t.Fatalf("readerFromArgsWithStdin failed: %v", err)
+	}
+	output, err = io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to read result: %v", err)
+	}
+	if got, want := string(output), "command: ['echo', '{{ x }}']"; got != want {
+		t.Errorf("unexpected output:\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	_ "github.com/containers/podman/v6/cmd/podman/quadlet"
 	"github.com/containers/podman/v6/cmd/podman/registry"
 	_ "github.com/containers/podman/v6/cmd/podman/secrets"
+	_ "github.com/containers/podman/v6/cmd/podman/sessions"
 	_ "github.com/containers/podman/v6/cmd/podman/system"
 	_ "github.com/containers/podman/v6/cmd/podman/system/connection"
 	"github.com/containers/podman/v6/cmd/podman/validate"
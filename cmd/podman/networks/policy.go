@@ -0,0 +1,24 @@
+package network
+
+import (
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Command: podman network _policy_
+	policyCmd = &cobra.Command{
+		Use:   "policy",
+		Short: "Manage network policies",
+		Long:  "Manage network policies restricting the connections of containers and pods on networks",
+		RunE:  validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: policyCmd,
+		Parent:  networkCmd,
+	})
+}
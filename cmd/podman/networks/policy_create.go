@@ -0,0 +1,142 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/parse"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	policyCreateDescription = `Create a network policy restricting the connections of the containers and pods it selects on networks.
+
+  Once a policy with the ingress type selects a container, it only accepts the connections allowed by an --ingress rule of such a policy, the egress type restricts outgoing connections the same way.`
+	policyCreateCommand = &cobra.Command{
+		Use:               "create [options] NAME",
+		Short:             "Create a network policy",
+		Long:              policyCreateDescription,
+		RunE:              policyCreate,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman network policy create --network tenant --selector app=db --ingress selector=app=web,port=5432 db-access
+  podman network policy create --network tenant --policy-type egress --egress cidr=10.89.0.0/24 --egress port=53/udp no-internet`,
+	}
+)
+
+var (
+	policyCreateOptions entities.NetworkPolicyCreateOptions
+	policyNetworks      []string
+	policySelector      []string
+	policyTypes         []string
+	policyIngress       []string
+	policyEgress        []string
+	policyLabels        []string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: policyCreateCommand,
+		Parent:  policyCmd,
+	})
+	flags := policyCreateCommand.Flags()
+
+	networkFlagName := "network"
+	flags.StringArrayVar(&policyNetworks, networkFlagName, nil, "Networks the policy applies to")
+	_ = policyCreateCommand.RegisterFlagCompletionFunc(networkFlagName, common.AutocompleteNetworks)
+
+	selectorFlagName := "selector"
+	flags.StringArrayVar(&policySelector, selectorFlagName, nil, "Select the containers and pods with the `KEY=VALUE` label, all if not set")
+	_ = policyCreateCommand.RegisterFlagCompletionFunc(selectorFlagName, completion.AutocompleteNone)
+
+	policyTypeFlagName := "policy-type"
+	flags.StringSliceVar(&policyTypes, policyTypeFlagName, nil, "Isolate the selected containers for ingress or egress (default ingress, and egress if --egress is set)")
+	_ = policyCreateCommand.RegisterFlagCompletionFunc(policyTypeFlagName, common.AutocompleteNetworkPolicyType)
+
+	ingressFlagName := "ingress"
+	flags.StringArrayVar(&policyIngress, ingressFlagName, nil, "Allow incoming connections matching the `RULE`")
+	_ = policyCreateCommand.RegisterFlagCompletionFunc(ingressFlagName, completion.AutocompleteNone)
+
+	egressFlagName := "egress"
+	flags.StringArrayVar(&policyEgress, egressFlagName, nil, "Allow outgoing connections matching the `RULE`")
+	_ = policyCreateCommand.RegisterFlagCompletionFunc(egressFlagName, completion.AutocompleteNone)
+
+	labelFlagName := "label"
+	flags.StringArrayVar(&policyLabels, labelFlagName, nil, "Set metadata on the network policy")
+	_ = policyCreateCommand.RegisterFlagCompletionFunc(labelFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&policyCreateOptions.Replace, "replace", false, "Replace an existing network policy of the same name")
+}
+
+func policyCreate(cmd *cobra.Command, args []string) error {
+	if len(policyNetworks) == 0 {
+		return errors.New("at least one network must be given with --network")
+	}
+	policy := entities.NetworkPolicy{
+		Name:     args[0],
+		Networks: policyNetworks,
+	}
+
+	var err error
+	if cmd.Flags().Changed("selector") {
+		policy.PodSelector, err = parse.GetAllLabels([]string{}, policySelector)
+		if err != nil {
+			return fmt.Errorf("parsing selector: %w", err)
+		}
+	}
+	policy.Labels, err = parse.GetAllLabels([]string{}, policyLabels)
+	if err != nil {
+		return fmt.Errorf("parsing labels: %w", err)
+	}
+
+	for _, policyType := range policyTypes {
+		switch strings.ToLower(policyType) {
+		case "ingress":
+			policyType = define.NetworkPolicyTypeIngress
+		case "egress":
+			policyType = define.NetworkPolicyTypeEgress
+		default:
+			return fmt.Errorf("invalid policy type %q, must be ingress or egress", policyType)
+		}
+		if !slices.Contains(policy.PolicyTypes, policyType) {
+			policy.PolicyTypes = append(policy.PolicyTypes, policyType)
+		}
+	}
+	// like Kubernetes isolate for ingress, and for egress if there are
+	// egress rules, unless the types are given
+	if len(policy.PolicyTypes) == 0 {
+		policy.PolicyTypes = []string{define.NetworkPolicyTypeIngress}
+		if len(policyEgress) > 0 {
+			policy.PolicyTypes = append(policy.PolicyTypes, define.NetworkPolicyTypeEgress)
+		}
+	}
+
+	for _, rule := range policyIngress {
+		r, err := parse.NetworkPolicyRule(rule)
+		if err != nil {
+			return fmt.Errorf("parsing --ingress %q: %w", rule, err)
+		}
+		policy.Ingress = append(policy.Ingress, r)
+	}
+	for _, rule := range policyEgress {
+		r, err := parse.NetworkPolicyRule(rule)
+		if err != nil {
+			return fmt.Errorf("parsing --egress %q: %w", rule, err)
+		}
+		policy.Egress = append(policy.Egress, r)
+	}
+
+	report, err := registry.ContainerEngine().NetworkPolicyCreate(registry.Context(), policy, policyCreateOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Name)
+	return nil
+}
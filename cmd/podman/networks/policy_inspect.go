@@ -0,0 +1,72 @@
+package network
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	policyInspectCommand = &cobra.Command{
+		Use:               "inspect [options] POLICY [POLICY...]",
+		Short:             "Inspect network policies",
+		Long:              "Displays the rules of one or more network policies.",
+		RunE:              policyInspect,
+		Example:           `podman network policy inspect db-access`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: common.AutocompleteNetworkPolicies,
+	}
+	policyInspectFormat string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: policyInspectCommand,
+		Parent:  policyCmd,
+	})
+	flags := policyInspectCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVarP(&policyInspectFormat, formatFlagName, "f", "", "Format inspect output using Go template")
+	_ = policyInspectCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.NetworkPolicy{}))
+}
+
+func policyInspect(cmd *cobra.Command, args []string) error {
+	policies, errs, err := registry.ContainerEngine().NetworkPolicyInspect(registry.Context(), args)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("format") && !report.IsJSON(policyInspectFormat) {
+		rpt := report.New(os.Stdout, cmd.Name())
+		defer rpt.Flush()
+
+		rpt, err := rpt.Parse(report.OriginUser, policyInspectFormat)
+		if err != nil {
+			return err
+		}
+		if err := rpt.Execute(policies); err != nil {
+			return err
+		}
+	} else {
+		buf, err := json.MarshalIndent(policies, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(buf))
+	}
+
+	if len(errs) > 0 {
+		for _, err := range errs[1:] {
+			fmt.Fprintf(os.Stderr, "error inspecting network policy: %v\n", err)
+		}
+		registry.SetExitCode(1)
+		return errs[0]
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	policyListCommand = &cobra.Command{
+		Use:               "ls [options]",
+		Aliases:           []string{"list"},
+		Args:              validate.NoArgs,
+		Short:             "List network policies",
+		Long:              "List network policies",
+		RunE:              policyList,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           `podman network policy ls`,
+	}
+)
+
+var (
+	policyListFormat string
+	policyListQuiet  bool
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: policyListCommand,
+		Parent:  policyCmd,
+	})
+	flags := policyListCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&policyListFormat, formatFlagName, "", "Pretty-print network policies to JSON or using a Go template")
+	_ = policyListCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&policyListReport{}))
+
+	flags.BoolVarP(&policyListQuiet, "quiet", "q", false, "display only names")
+	flags.BoolP("noheading", "n", false, "Do not print headers")
+}
+
+func policyList(cmd *cobra.Command, _ []string) error {
+	policies, err := registry.ContainerEngine().NetworkPolicyList(registry.Context())
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case policyListQuiet:
+		for _, p := range policies {
+			fmt.Println(p.Name)
+		}
+		return nil
+	case report.IsJSON(policyListFormat):
+		prettyJSON, err := json.MarshalIndent(policies, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(prettyJSON))
+		return nil
+	}
+
+	reports := make([]policyListReport, 0, len(policies))
+	for _, p := range policies {
+		reports = append(reports, policyListReport{p})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flag("format").Changed {
+		rpt, err = rpt.Parse(report.OriginUser, policyListFormat)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, "{{range .}}{{.Name}}\t{{.Networks}}\t{{.Selector}}\t{{.Types}}\n{{end -}}")
+	}
+	if err != nil {
+		return err
+	}
+
+	noHeading, _ := cmd.Flags().GetBool("noheading")
+	if rpt.RenderHeaders && !noHeading {
+		headers := report.Headers(policyListReport{}, map[string]string{
+			"Name":     "name",
+			"Networks": "networks",
+			"Types":    "policy types",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reports)
+}
+
+type policyListReport struct {
+	*entities.NetworkPolicy
+}
+
+// Networks returns the networks the policy applies to
+func (p policyListReport) Networks() string {
+	return strings.Join(p.NetworkPolicy.Networks, ",")
+}
+
+// Selector returns the pod selector of the policy
+func (p policyListReport) Selector() string {
+	if len(p.PodSelector) == 0 {
+		return "<all>"
+	}
+	list := make([]string, 0, len(p.PodSelector))
+	for k, v := range p.PodSelector {
+		list = append(list, k+"="+v)
+	}
+	slices.Sort(list)
+	return strings.Join(list, ",")
+}
+
+// Types returns the policy types of the policy
+func (p policyListReport) Types() string {
+	return strings.Join(p.PolicyTypes, ",")
+}
@@ -0,0 +1,50 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/cmd/podman/utils"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	policyRmCommand = &cobra.Command{
+		Use:               "rm [options] POLICY [POLICY...]",
+		Aliases:           []string{"remove"},
+		Short:             "Remove network policies",
+		Long:              "Remove network policies, lifting the isolation of the containers they selected unless other policies select them",
+		RunE:              policyRm,
+		Example:           `podman network policy rm db-access`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: common.AutocompleteNetworkPolicies,
+	}
+	policyRmOptions entities.NetworkPolicyRmOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: policyRmCommand,
+		Parent:  policyCmd,
+	})
+	flags := policyRmCommand.Flags()
+	flags.BoolVarP(&policyRmOptions.Ignore, "ignore", "i", false, "Ignore errors when a specified network policy is missing")
+}
+
+func policyRm(_ *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	reports, err := registry.ContainerEngine().NetworkPolicyRm(registry.Context(), args, policyRmOptions)
+	if err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if r.Err == nil {
+			fmt.Println(r.Name)
+		} else {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
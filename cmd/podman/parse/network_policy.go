@@ -0,0 +1,93 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v6/libpod/define"
+)
+
+// NetworkPolicyRule parses an ingress or egress rule of a network policy,
+// a comma separated list of selector=KEY=VALUE, cidr=CIDR, except=CIDR and
+// port=PORT[-END][/PROTOCOL] fields. The selector fields form one peer
+// selecting the containers with all the labels, an empty selector selects
+// all containers. Every cidr field is a peer, except fields exclude ranges
+// from the preceding cidr. An empty rule allows all connections.
+func NetworkPolicyRule(rule string) (define.NetworkPolicyRule, error) {
+	var (
+		result   define.NetworkPolicyRule
+		selector map[string]string
+		cidrs    []define.NetworkPolicyPeer
+	)
+	if rule == "" {
+		return result, nil
+	}
+	for field := range strings.SplitSeq(rule, ",") {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return result, fmt.Errorf("invalid network policy rule field %q, must be KEY=VALUE", field)
+		}
+		switch key {
+		case "selector":
+			if selector == nil {
+				selector = make(map[string]string)
+			}
+			if value == "" {
+				continue
+			}
+			label, labelValue, _ := strings.Cut(value, "=")
+			if label == "" {
+				return result, fmt.Errorf("invalid network policy selector %q", value)
+			}
+			selector[label] = labelValue
+		case "cidr":
+			if value == "" {
+				return result, errors.New("network policy cidr must not be empty")
+			}
+			cidrs = append(cidrs, define.NetworkPolicyPeer{CIDR: value})
+		case "except":
+			if len(cidrs) == 0 {
+				return result, fmt.Errorf("network policy except %q must follow a cidr", value)
+			}
+			cidrs[len(cidrs)-1].Except = append(cidrs[len(cidrs)-1].Except, value)
+		case "port":
+			port, err := networkPolicyPort(value)
+			if err != nil {
+				return result, err
+			}
+			result.Ports = append(result.Ports, port)
+		default:
+			return result, fmt.Errorf("unknown network policy rule field %q", key)
+		}
+	}
+	if selector != nil {
+		result.Peers = append(result.Peers, define.NetworkPolicyPeer{PodSelector: selector})
+	}
+	result.Peers = append(result.Peers, cidrs...)
+	return result, nil
+}
+
+// networkPolicyPort parses PORT[-END][/PROTOCOL].
+func networkPolicyPort(value string) (define.NetworkPolicyPort, error) {
+	var port define.NetworkPolicyPort
+	ports, protocol, ok := strings.Cut(value, "/")
+	if ok {
+		port.Protocol = strings.ToLower(protocol)
+	}
+	start, end, isRange := strings.Cut(ports, "-")
+	p, err := strconv.ParseUint(start, 10, 16)
+	if err != nil || p == 0 {
+		return port, fmt.Errorf("invalid network policy port %q", value)
+	}
+	port.Port = uint16(p)
+	if isRange {
+		e, err := strconv.ParseUint(end, 10, 16)
+		if err != nil || e < p {
+			return port, fmt.Errorf("invalid network policy port range %q", value)
+		}
+		port.EndPort = uint16(e)
+	}
+	return port, nil
+}
@@ -0,0 +1,75 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkPolicyRule(t *testing.T) {
+	tests := []struct {
+		rule        string
+		expected    define.NetworkPolicyRule
+		expectedErr string
+	}{
+		{
+			rule: "",
+		},
+		{
+			rule: "selector=app=web,selector=tier=front,port=80,port=8000-8080/TCP",
+			expected: define.NetworkPolicyRule{
+				Peers: []define.NetworkPolicyPeer{{PodSelector: map[string]string{"app": "web", "tier": "front"}}},
+				Ports: []define.NetworkPolicyPort{{Port: 80}, {Protocol: "tcp", Port: 8000, EndPort: 8080}},
+			},
+		},
+		{
+			rule: "selector=",
+			expected: define.NetworkPolicyRule{
+				Peers: []define.NetworkPolicyPeer{{PodSelector: map[string]string{}}},
+			},
+		},
+		{
+			rule: "cidr=0.0.0.0/0,except=10.0.0.0/8,except=192.168.0.0/16,cidr=fd00::/8,port=53/udp",
+			expected: define.NetworkPolicyRule{
+				Peers: []define.NetworkPolicyPeer{
+					{CIDR: "0.0.0.0/0", Except: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+					{CIDR: "fd00::/8"},
+				},
+				Ports: []define.NetworkPolicyPort{{Protocol: "udp", Port: 53}},
+			},
+		},
+		{
+			rule:        "except=10.0.0.0/8",
+			expectedErr: `network policy except "10.0.0.0/8" must follow a cidr`,
+		},
+		{
+			rule:        "port=http",
+			expectedErr: `invalid network policy port "http"`,
+		},
+		{
+			rule:        "port=90-80",
+			expectedErr: `invalid network policy port range "90-80"`,
+		},
+		{
+			rule:        "host=example.com",
+			expectedErr: `unknown network policy rule field "host"`,
+		},
+		{
+			rule:        "selector",
+			expectedErr: `invalid network policy rule field "selector", must be KEY=VALUE`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.rule, func(t *testing.T) {
+			rule, err := NetworkPolicyRule(test.rule)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, rule)
+		})
+	}
+}
@@ -252,7 +252,8 @@ func persistentPreRunE(cmd *cobra.Command, args []string) error {
 
 	// Help, completion and commands with subcommands are special cases, no need for more setup
 	// Completion cmd is used to generate the shell scripts
-	if cmd.Name() == "help" || cmd.Name() == "completion" || cmd.HasSubCommands() {
+	// compose runs the commands not implemented by its subcommands itself.
+	if cmd.Name() == "help" || cmd.Name() == "completion" || (cmd.HasSubCommands() && cmd != composeCommand) {
 		requireCleanup = false
 		return nil
 	}
@@ -686,6 +687,8 @@ func rootFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig) {
 		_ = rootCmd.RegisterFlagCompletionFunc(runtimeflagFlagName, completion.AutocompleteNone)
 
 		pFlags.BoolVar(&podmanConfig.Syslog, "syslog", false, "Output podman-internal logs to syslog as well as the console (default false)")
+
+		pFlags.BoolVar(&podmanConfig.RecordSessions, "record-sessions", false, "Record all exec and attach sessions attached to STDIN (default false)")
 	}
 }
 
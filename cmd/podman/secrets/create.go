@@ -55,6 +55,8 @@ func init() {
 
 	flags.BoolVar(&createOpts.Replace, "replace", false, "If a secret with the same name exists, replace it")
 
+	keepVersionsFlag(createCmd, &createOpts.KeepVersions)
+
 	flags.BoolVar(&createOpts.Ignore, "ignore", false, "If a secret with the same name exists, ignore and do not create a new secret")
 
 	labelFlagName := "label"
@@ -70,34 +72,11 @@ func create(_ *cobra.Command, args []string) error {
 		return errors.New("cannot use --ignore and --replace flags together")
 	}
 
-	var err error
-	path := args[1]
-
-	var reader io.Reader
-	switch {
-	case env:
-		envValue := os.Getenv(path)
-		if envValue == "" {
-			return fmt.Errorf("cannot create store secret data: environment variable %s is not set", path)
-		}
-		reader = strings.NewReader(envValue)
-	case path == "-" || path == "/dev/stdin":
-		stat, err := os.Stdin.Stat()
-		if err != nil {
-			return err
-		}
-		if (stat.Mode() & os.ModeNamedPipe) == 0 {
-			return errors.New("if `-` is used, data must be passed into stdin")
-		}
-		reader = os.Stdin
-	default:
-		file, err := os.Open(path)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
-		reader = file
+	reader, err := secretData(args[1], env)
+	if err != nil {
+		return err
 	}
+	defer reader.Close()
 
 	createOpts.Labels, err = parse.GetAllLabels([]string{}, labels)
 	if err != nil {
@@ -111,3 +90,27 @@ func create(_ *cobra.Command, args []string) error {
 	fmt.Println(report.ID)
 	return nil
 }
+
+// secretData returns the reader of the secret data in the file, stdin for
+// "-", or the environment variable.
+func secretData(path string, fromEnv bool) (io.ReadCloser, error) {
+	switch {
+	case fromEnv:
+		envValue := os.Getenv(path)
+		if envValue == "" {
+			return nil, fmt.Errorf("cannot create store secret data: environment variable %s is not set", path)
+		}
+		return io.NopCloser(strings.NewReader(envValue)), nil
+	case path == "-" || path == "/dev/stdin":
+		stat, err := os.Stdin.Stat()
+		if err != nil {
+			return nil, err
+		}
+		if (stat.Mode() & os.ModeNamedPipe) == 0 {
+			return nil, errors.New("if `-` is used, data must be passed into stdin")
+		}
+		return io.NopCloser(os.Stdin), nil
+	default:
+		return os.Open(path)
+	}
+}
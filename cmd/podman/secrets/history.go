@@ -0,0 +1,110 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/report"
+)
+
+var historyCmd = &cobra.Command{
+	Use:               "history [options] SECRET",
+	Short:             "Show the versions of a secret",
+	Long:              "List the current and the kept previous versions of a secret, newest first.",
+	RunE:              history,
+	Args:              cobra.ExactArgs(1),
+	Example:           "podman secret history mysecret",
+	ValidArgsFunction: common.AutocompleteSecrets,
+}
+
+var historyFlag = struct {
+	format    string
+	noHeading bool
+	quiet     bool
+}{}
+
+type historyReporter struct {
+	*entities.SecretHistoryReport
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: historyCmd,
+		Parent:  secretCmd,
+	})
+
+	flags := historyCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&historyFlag.format, formatFlagName, "{{range .}}{{.Version}}\t{{.ID}}\t{{.Changed}}\t{{.ChangedBy}}\t{{.Comment}}\n{{end -}}", "Format secret history output using Go template")
+	_ = historyCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&historyReporter{}))
+
+	flags.BoolVarP(&historyFlag.noHeading, "noheading", "n", false, "Do not print headers")
+	flags.BoolVarP(&historyFlag.quiet, "quiet", "q", false, "Print versions only")
+}
+
+func history(cmd *cobra.Command, args []string) error {
+	responses, err := registry.ContainerEngine().SecretHistory(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	if historyFlag.quiet && !cmd.Flags().Changed("format") {
+		for _, response := range responses {
+			fmt.Println(response.Version)
+		}
+		return nil
+	}
+
+	reports := make([]historyReporter, 0, len(responses))
+	for _, response := range responses {
+		reports = append(reports, historyReporter{response})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, historyFlag.format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, historyFlag.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !historyFlag.noHeading {
+		headers := report.Headers(historyReporter{}, map[string]string{
+			"ChangedBy": "CHANGED BY",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reports)
+}
+
+// Changed returns when the version was set.
+func (h historyReporter) Changed() string {
+	return units.HumanDuration(time.Since(h.ChangedAt)) + " ago"
+}
+
+// Comment describes the version.
+func (h historyReporter) Comment() string {
+	var comments []string
+	if h.Current {
+		comments = append(comments, "current")
+	}
+	if h.RollbackTo > 0 {
+		comments = append(comments, fmt.Sprintf("rollback to v%d", h.RollbackTo))
+	}
+	return strings.Join(comments, ", ")
+}
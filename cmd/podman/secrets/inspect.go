@@ -38,7 +38,13 @@ Labels:
 {{- end }}{{ end }}
 Driver:            {{.Spec.Driver.Name}}
 Created at:        {{.CreatedAt}}
-Updated at:        {{.UpdatedAt}}`
+Updated at:        {{.UpdatedAt}}
+Version:           {{.Version}}
+{{- if .Containers }}
+Containers:
+{{- range .Containers }}
+ - {{ .Name }}{{ if .Stale }} (stale){{ end }}
+{{- end }}{{ end }}`
 )
 
 var inspectOpts = entities.SecretInspectOptions{}
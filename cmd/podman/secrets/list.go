@@ -31,6 +31,7 @@ var (
 )
 
 type listFlagType struct {
+	all       bool
 	format    string
 	noHeading bool
 	filter    []string
@@ -45,6 +46,8 @@ func init() {
 
 	flags := lsCmd.Flags()
 
+	flags.BoolVarP(&listFlag.all, "all", "a", false, "Show previous versions of secrets too")
+
 	formatFlagName := "format"
 	flags.StringVar(&listFlag.format, formatFlagName, "{{range .}}{{.ID}}\t{{.Name}}\t{{.Driver}}\t{{.CreatedAt}}\t{{.UpdatedAt}}\n{{end -}}", "Format secret output using Go template")
 	_ = lsCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.SecretListReport{}))
@@ -62,7 +65,7 @@ func init() {
 
 func ls(cmd *cobra.Command, _ []string) error {
 	var err error
-	lsOpts := entities.SecretListRequest{}
+	lsOpts := entities.SecretListRequest{All: listFlag.all}
 
 	lsOpts.Filters, err = parse.FilterArgumentsIntoFilters(listFlag.filter)
 	if err != nil {
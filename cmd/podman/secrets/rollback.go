@@ -0,0 +1,57 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:               "rollback [options] --to VERSION SECRET",
+	Short:             "Roll back a secret to a previous version",
+	Long:              "Restore the data of a previous version of a secret as its next version. The secret is updated in all containers it is mounted into, running containers can be reloaded with a signal or a command.",
+	RunE:              rollback,
+	Args:              cobra.ExactArgs(1),
+	Example:           "podman secret rollback --to v3 mysecret",
+	ValidArgsFunction: common.AutocompleteSecrets,
+}
+
+var (
+	rollbackOpts = entities.SecretRollbackOptions{}
+	rollbackTo   string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rollbackCmd,
+		Parent:  secretCmd,
+	})
+
+	toFlagName := "to"
+	rollbackCmd.Flags().StringVar(&rollbackTo, toFlagName, "", "Version to roll back to, e.g. 3 or v3")
+	_ = rollbackCmd.RegisterFlagCompletionFunc(toFlagName, completion.AutocompleteNone)
+	_ = rollbackCmd.MarkFlagRequired(toFlagName)
+
+	updateFlags(rollbackCmd, &rollbackOpts.SecretUpdateOptions)
+}
+
+func rollback(_ *cobra.Command, args []string) error {
+	version, err := strconv.Atoi(strings.TrimPrefix(rollbackTo, "v"))
+	if err != nil || version < 1 {
+		return fmt.Errorf("invalid secret version %q", rollbackTo)
+	}
+	rollbackOpts.Version = version
+
+	report, err := registry.ContainerEngine().SecretRollback(context.Background(), args[0], rollbackOpts)
+	if err != nil {
+		return err
+	}
+	return printUpdateReport(report)
+}
@@ -0,0 +1,89 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/cmd/podman/utils"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update [options] SECRET FILE|-",
+	Short: "Update the data of a secret",
+	Long:  "Replace the data of a secret and increment its version. The secret is updated in all containers it is mounted into, running containers can be reloaded with a signal or a command.",
+	RunE:  update,
+	Args:  cobra.ExactArgs(2),
+	Example: `podman secret update mysecret /path/to/secret
+  printf "secretdata" | podman secret update --signal SIGHUP mysecret -`,
+	ValidArgsFunction: common.AutocompleteSecretUpdate,
+}
+
+var (
+	updateOpts = entities.SecretUpdateOptions{}
+	updateEnv  = false
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: updateCmd,
+		Parent:  secretCmd,
+	})
+
+	flags := updateCmd.Flags()
+
+	flags.BoolVar(&updateEnv, "env", false, "Read secret data from environment variable")
+
+	updateFlags(updateCmd, &updateOpts)
+}
+
+// updateFlags adds the flags shared by update and rollback.
+func updateFlags(cmd *cobra.Command, opts *entities.SecretUpdateOptions) {
+	flags := cmd.Flags()
+
+	signalFlagName := "signal"
+	flags.StringVar(&opts.ReloadSignal, signalFlagName, "", "Signal to send to running containers using the secret")
+	_ = cmd.RegisterFlagCompletionFunc(signalFlagName, common.AutocompleteStopSignal)
+
+	execFlagName := "exec"
+	flags.StringVar(&opts.ReloadExec, execFlagName, "", "Command to execute in running containers using the secret")
+	_ = cmd.RegisterFlagCompletionFunc(execFlagName, completion.AutocompleteNone)
+
+	keepVersionsFlag(cmd, &opts.KeepVersions)
+}
+
+func keepVersionsFlag(cmd *cobra.Command, keep *int) {
+	keepFlagName := "keep-versions"
+	cmd.Flags().IntVar(keep, keepFlagName, entities.DefaultSecretKeepVersions, "Number of previous versions of the secret to keep")
+	_ = cmd.RegisterFlagCompletionFunc(keepFlagName, completion.AutocompleteNone)
+}
+
+func update(_ *cobra.Command, args []string) error {
+	reader, err := secretData(args[1], updateEnv)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	report, err := registry.ContainerEngine().SecretUpdate(context.Background(), args[0], reader, updateOpts)
+	if err != nil {
+		return err
+	}
+	return printUpdateReport(report)
+}
+
+func printUpdateReport(report *entities.SecretUpdateReport) error {
+	fmt.Println(report.ID)
+
+	var errs utils.OutputErrors
+	for _, ctr := range report.Containers {
+		if ctr.Error != "" {
+			errs = append(errs, fmt.Errorf("updating secret in container %s: %s", ctr.Name, ctr.Error))
+		}
+	}
+	return errs.PrintErrors()
+}
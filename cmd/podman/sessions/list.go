@@ -0,0 +1,135 @@
+package sessions
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/parse"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	lsCmd = &cobra.Command{
+		Use:               "ls [options]",
+		Aliases:           []string{"list"},
+		Short:             "List session recordings",
+		RunE:              ls,
+		Example:           "podman session ls --filter container=ctr1",
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+	}
+	listFlag = listFlagType{}
+)
+
+type listFlagType struct {
+	format    string
+	noHeading bool
+	filter    []string
+	quiet     bool
+}
+
+// sessionReporter formats a session recording for the output.
+type sessionReporter struct {
+	entities.SessionListReport
+}
+
+func (s sessionReporter) ID() string {
+	if len(s.SessionListReport.ID) > 12 {
+		return s.SessionListReport.ID[:12]
+	}
+	return s.SessionListReport.ID
+}
+
+func (s sessionReporter) Container() string {
+	return s.ContainerName
+}
+
+func (s sessionReporter) CreatedAt() string {
+	return units.HumanDuration(time.Since(s.Created)) + " ago"
+}
+
+func (s sessionReporter) Length() string {
+	return s.Duration.Round(time.Second).String()
+}
+
+func (s sessionReporter) Size() string {
+	return fmt.Sprintf("%dx%d", s.Width, s.Height)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: lsCmd,
+		Parent:  sessionCmd,
+	})
+
+	flags := lsCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&listFlag.format, formatFlagName, "{{range .}}{{.ID}}\t{{.Type}}\t{{.Container}}\t{{.Command}}\t{{.User}}\t{{.CreatedAt}}\t{{.Length}}\n{{end -}}", "Format session recording output using Go template")
+	_ = lsCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&sessionReporter{}))
+
+	filterFlagName := "filter"
+	flags.StringArrayVarP(&listFlag.filter, filterFlagName, "f", []string{}, "Filter session recording output")
+	_ = lsCmd.RegisterFlagCompletionFunc(filterFlagName, common.AutocompleteSessionFilters)
+
+	flags.BoolVarP(&listFlag.noHeading, "noheading", "n", false, "Do not print headers")
+	flags.BoolVarP(&listFlag.quiet, "quiet", "q", false, "Print session recording IDs only")
+}
+
+func ls(cmd *cobra.Command, _ []string) error {
+	filters, err := parse.FilterArgumentsIntoFilters(listFlag.filter)
+	if err != nil {
+		return err
+	}
+	responses, err := registry.ContainerEngine().SessionList(registry.Context(), entities.SessionListOptions{Filters: filters})
+	if err != nil {
+		return err
+	}
+
+	listed := make([]sessionReporter, 0, len(responses))
+	for _, response := range responses {
+		listed = append(listed, sessionReporter{*response})
+	}
+
+	if listFlag.quiet && !cmd.Flags().Changed("format") {
+		for _, s := range listed {
+			fmt.Println(s.ID())
+		}
+		return nil
+	}
+
+	headers := report.Headers(sessionReporter{}, map[string]string{
+		"Container": "CONTAINER",
+		"CreatedAt": "CREATED",
+		"Length":    "DURATION",
+		"Size":      "SIZE",
+	})
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	switch {
+	case cmd.Flag("format").Changed:
+		rpt, err = rpt.Parse(report.OriginUser, listFlag.format)
+	default:
+		rpt, err = rpt.Parse(report.OriginPodman, listFlag.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !listFlag.noHeading {
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(listed)
+}
@@ -0,0 +1,50 @@
+package sessions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/cmd/podman/utils"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var rmCmd = &cobra.Command{
+	Use:               "rm [options] RECORDING [RECORDING...]",
+	Short:             "Remove one or more session recordings",
+	RunE:              rm,
+	ValidArgsFunction: common.AutocompleteSessions,
+	Example:           "podman session rm 3c5a2f1e8d7b",
+}
+
+var rmOptions = entities.SessionRmOptions{}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rmCmd,
+		Parent:  sessionCmd,
+	})
+	flags := rmCmd.Flags()
+	flags.BoolVarP(&rmOptions.All, "all", "a", false, "Remove all session recordings")
+}
+
+func rm(_ *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	if (len(args) > 0 && rmOptions.All) || (len(args) < 1 && !rmOptions.All) {
+		return errors.New("`podman session rm` requires one argument, or the --all flag")
+	}
+	responses, err := registry.ContainerEngine().SessionRm(registry.Context(), args, rmOptions)
+	if err != nil {
+		return err
+	}
+	for _, r := range responses {
+		if r.Err == nil {
+			fmt.Println(r.ID)
+		} else {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
@@ -0,0 +1,21 @@
+package sessions
+
+import (
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+// Command: podman _session_
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Manage recordings of exec and attach sessions",
+	Long:  "List, replay and remove the recordings of exec and attach sessions created with --record or the --record-sessions option",
+	RunE:  validate.SubCommandExists,
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: sessionCmd,
+	})
+}
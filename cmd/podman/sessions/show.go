@@ -0,0 +1,50 @@
+package sessions
+
+import (
+	"os"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	showDescription = `Replays the output of a recorded exec or attach session with its original timing.
+
+  The recording is given by its ID, a unique prefix of the ID, or the path of an asciicast file.`
+	showCmd = &cobra.Command{
+		Use:               "show [options] RECORDING",
+		Aliases:           []string{"replay"},
+		Short:             "Replay a session recording",
+		Long:              showDescription,
+		RunE:              show,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteSessions,
+		Example: `podman session show 3c5a2f1e8d7b
+  podman session show --speed 4 --idle-limit 1s 3c5a2f1e8d7b`,
+	}
+	showOptions = entities.SessionReplayOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: showCmd,
+		Parent:  sessionCmd,
+	})
+	flags := showCmd.Flags()
+
+	speedFlagName := "speed"
+	flags.Float64Var(&showOptions.Speed, speedFlagName, 1, "Speed up the replay by the given factor, 0 replays without delays")
+	_ = showCmd.RegisterFlagCompletionFunc(speedFlagName, completion.AutocompleteNone)
+
+	idleLimitFlagName := "idle-limit"
+	flags.DurationVar(&showOptions.IdleLimit, idleLimitFlagName, 0, "Limit pauses of the replay to the given duration")
+	_ = showCmd.RegisterFlagCompletionFunc(idleLimitFlagName, completion.AutocompleteNone)
+}
+
+func show(_ *cobra.Command, args []string) error {
+	showOptions.OutputStream = os.Stdout
+	return registry.ContainerEngine().SessionReplay(registry.Context(), args[0], showOptions)
+}
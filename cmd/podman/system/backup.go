@@ -0,0 +1,56 @@
+//go:build !remote
+
+package system
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	backupDescription = `
+        podman system backup
+
+        Write the configuration of all containers, pods, networks, volumes and secrets
+        to an archive which can be restored on another host with podman system restore.
+`
+
+	backupCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "backup [options] ARCHIVE",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Back up the configuration of all containers, pods, networks and volumes",
+		Long:              backupDescription,
+		RunE:              backup,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman system backup backup.tar
+  podman system backup --volume-data backup.tar`,
+	}
+)
+
+var backupOptions entities.SystemBackupOptions
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: backupCommand,
+		Parent:  systemCmd,
+	})
+
+	flags := backupCommand.Flags()
+	flags.BoolVar(&backupOptions.VolumeData, "volume-data", false, "Include the contents of local volumes")
+}
+
+func backup(_ *cobra.Command, args []string) error {
+	backupOptions.Output = args[0]
+	report, err := registry.ContainerEngine().SystemBackup(registry.Context(), backupOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Backed up %d containers, %d pods, %d networks, %d volumes, %d secrets and %d image references to %s\n",
+		len(report.Containers), len(report.Pods), len(report.Networks), len(report.Volumes), len(report.Secrets), len(report.Images), backupOptions.Output)
+	return nil
+}
@@ -19,7 +19,7 @@ import (
 var (
 	eventsDescription = `Monitor podman system events.
 
-  By default, streaming mode is used, printing new events as they occur.  Previous events can be listed via --since and --until.`
+  By default, streaming mode is used, printing new events as they occur.  Previous events can be listed via --since and --until, or resumed after an event with --since-cursor.`
 	eventsCommand = &cobra.Command{
 		Use:               "events [options]",
 		Args:              validate.NoArgs,
@@ -30,7 +30,8 @@ var (
 		Example: `podman events
   podman events --filter event=create
   podman events --format {{.Image}}
-  podman events --since 1h30s`,
+  podman events --since 1h30s
+  podman events --since-cursor 1024`,
 	}
 
 	systemEventsCommand = &cobra.Command{
@@ -74,6 +75,8 @@ type Event struct {
 	HealthStatus string `json:"health_status,omitempty"`
 	// Error code for certain events involving errors.
 	Error string `json:",omitempty"`
+	// Cursor is the position of the event in the event log
+	Cursor string `json:"cursor,omitempty"`
 
 	events.Details
 }
@@ -92,6 +95,7 @@ func newEventFromLibpodEvent(e *events.Event) Event {
 		Details:           e.Details,
 		TimeNano:          e.Time.UnixNano(),
 		Error:             e.Error,
+		Cursor:            e.Cursor,
 	}
 }
 
@@ -129,6 +133,10 @@ func eventsFlags(cmd *cobra.Command) {
 	flags.StringVar(&eventOptions.Since, sinceFlagName, "", "show all events created since timestamp")
 	_ = cmd.RegisterFlagCompletionFunc(sinceFlagName, completion.AutocompleteNone)
 
+	sinceCursorFlagName := "since-cursor"
+	flags.StringVar(&eventOptions.SinceCursor, sinceCursorFlagName, "", "show all events after the event with the cursor")
+	_ = cmd.RegisterFlagCompletionFunc(sinceCursorFlagName, completion.AutocompleteNone)
+
 	flags.BoolVar(&noTrunc, "no-trunc", true, "do not truncate the output")
 
 	untilFlagName := "until"
@@ -137,7 +145,7 @@ func eventsFlags(cmd *cobra.Command) {
 }
 
 func eventsCmd(cmd *cobra.Command, _ []string) error {
-	if len(eventOptions.Since) > 0 || len(eventOptions.Until) > 0 {
+	if len(eventOptions.Since) > 0 || len(eventOptions.Until) > 0 || len(eventOptions.SinceCursor) > 0 {
 		eventOptions.FromStart = true
 	}
 	eventChannel := make(chan events.ReadResult, 1)
@@ -0,0 +1,72 @@
+//go:build !remote
+
+package system
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v6/cmd/podman/common"
+	"github.com/containers/podman/v6/cmd/podman/registry"
+	"github.com/containers/podman/v6/cmd/podman/utils"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	restoreDescription = `
+        podman system restore
+
+        Re-create the containers, pods, networks and volumes stored in an archive
+        written by podman system backup. Missing images are pulled.
+`
+
+	restoreCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "restore [options] ARCHIVE",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Restore the configuration written by podman system backup",
+		Long:              restoreDescription,
+		RunE:              restore,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman system restore backup.tar
+  podman system restore --keep-ids=false --keep-names=false backup.tar
+  podman system restore --on-conflict=skip backup.tar`,
+	}
+)
+
+var restoreOptions entities.SystemRestoreOptions
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: restoreCommand,
+		Parent:  systemCmd,
+	})
+
+	flags := restoreCommand.Flags()
+	flags.BoolVar(&restoreOptions.KeepIDs, "keep-ids", true, "Keep the container and pod IDs stored in the archive")
+	flags.BoolVar(&restoreOptions.KeepNames, "keep-names", true, "Keep the container and pod names stored in the archive")
+
+	onConflictFlagName := "on-conflict"
+	flags.StringVar(&restoreOptions.OnConflict, onConflictFlagName, entities.RestoreConflictFail, "How to handle objects which already exist: fail, skip or replace")
+	_ = restoreCommand.RegisterFlagCompletionFunc(onConflictFlagName, common.AutocompleteRestoreConflict)
+}
+
+func restore(_ *cobra.Command, args []string) error {
+	restoreOptions.Input = args[0]
+	report, err := registry.ContainerEngine().SystemRestore(registry.Context(), restoreOptions)
+	var errs utils.OutputErrors
+	if report != nil {
+		for _, entry := range report.Entries {
+			if entry.Err != nil {
+				errs = append(errs, entry.Err)
+				continue
+			}
+			fmt.Printf("%s %s: %s\n", entry.Kind, entry.Name, entry.Status)
+		}
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errs.PrintErrors()
+}
@@ -60,6 +60,10 @@ func init() {
 
 	flags.BoolP("noheading", "n", false, "Do not print headers")
 	flags.BoolVarP(&cliOpts.Quiet, "quiet", "q", false, "Print volume output in quiet mode")
+
+	whereFlagName := "where"
+	flags.StringVar(&lsOpts.Where, whereFlagName, "", "Only list volumes whose inspect data matches the `expression`")
+	_ = lsCommand.RegisterFlagCompletionFunc(whereFlagName, completion.AutocompleteNone)
 }
 
 func list(cmd *cobra.Command, _ []string) error {
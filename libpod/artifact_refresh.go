@@ -0,0 +1,312 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/libpod/events"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/libartifact/store"
+	libartTypes "go.podman.io/common/pkg/libartifact/types"
+	"go.podman.io/storage/pkg/fileutils"
+)
+
+// artifactDataDir is the symlink in the directory of a refreshing artifact
+// volume pointing to the directory holding the current version of the blobs.
+// The files of the volume are symlinks through it, so swapping it replaces
+// all files at once.
+const artifactDataDir = "..data"
+
+// artifactFileName returns the name of the file the blob of the artifact
+// volume is mounted as.  i is the index of the blob and n the number of blobs
+// mounted.
+func artifactFileName(artifactMount *ContainerArtifactVolume, path libartTypes.BlobMountPath, i, n int) string {
+	if artifactMount.Name == "" {
+		return path.Name
+	}
+	if n > 1 {
+		return artifactMount.Name + "-" + strconv.Itoa(i)
+	}
+	return artifactMount.Name
+}
+
+// artifactRefreshInterval returns the shortest refresh interval of the
+// artifact volumes of the container, or 0 if none of them is refreshed.
+func (c *Container) artifactRefreshInterval() time.Duration {
+	var interval time.Duration
+	for _, artifactMount := range c.config.ArtifactVolumes {
+		if artifactMount.RefreshInterval > 0 && (interval == 0 || artifactMount.RefreshInterval < interval) {
+			interval = artifactMount.RefreshInterval
+		}
+	}
+	return interval
+}
+
+// artifactRefreshDir is the directory the blobs of the refreshing artifact
+// volume with the given index are populated in.
+func (c *Container) artifactRefreshDir(i int) string {
+	return filepath.Join(c.config.StaticDir, "artifacts", strconv.Itoa(i))
+}
+
+// mountRefreshingArtifact populates the directory of a refreshing artifact
+// volume and mounts it into the container.
+func (c *Container) mountRefreshingArtifact(ctx context.Context, g *generate.Generator, artStore *store.ArtifactStore, i int, artifactMount *ContainerArtifactVolume) error {
+	destIsFile, err := containerPathIsFile(c.state.Mountpoint, artifactMount.Dest)
+	if err == nil && destIsFile {
+		return fmt.Errorf("refreshing artifact %q must be mounted on a directory but container path %q is a file", artifactMount.Source, artifactMount.Dest)
+	}
+
+	digest, err := artifactDigest(ctx, artStore, artifactMount)
+	if err != nil {
+		return err
+	}
+	dir := c.artifactRefreshDir(i)
+	if err := populateArtifactDir(ctx, artStore, dir, artifactMount, digest); err != nil {
+		return fmt.Errorf("populating artifact %q for container %s: %w", artifactMount.Source, c.ID(), err)
+	}
+	if c.state.ArtifactDigests == nil {
+		c.state.ArtifactDigests = make(map[string]string)
+	}
+	c.state.ArtifactDigests[artifactMount.Dest] = digest
+
+	logrus.Debugf("Mounting refreshing artifact %q in container %s, mount %q to %q", artifactMount.Source, c.ID(), dir, artifactMount.Dest)
+
+	g.AddMount(spec.Mount{
+		Destination: artifactMount.Dest,
+		Source:      dir,
+		Type:        define.TypeBind,
+		Options:     []string{define.TypeBind, "ro"},
+	})
+	return nil
+}
+
+// RefreshArtifacts checks the refreshing artifact volumes of the container for
+// new versions of their artifacts and swaps the mounted blobs of the changed
+// ones.  The container is signaled as configured by the refreshed volumes.  It
+// returns the new artifact digests keyed by the destinations of the refreshed
+// volumes.
+func (c *Container) RefreshArtifacts(ctx context.Context) (map[string]string, error) {
+	refreshed, signals, err := c.refreshArtifactVolumes(ctx)
+	if err != nil {
+		return refreshed, err
+	}
+	for _, signal := range signals {
+		if err := c.signalReload(signal); err != nil {
+			return refreshed, err
+		}
+	}
+	return refreshed, nil
+}
+
+// refreshArtifactVolumes swaps the blobs of the refreshing artifact volumes
+// whose artifacts changed and returns the new digests and the signals to send
+// to the container.
+func (c *Container) refreshArtifactVolumes(ctx context.Context) (map[string]string, []uint, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return nil, nil, err
+	}
+	// The volumes are populated when the container is started.
+	if !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return nil, nil, nil
+	}
+
+	artStore, err := c.runtime.ArtifactStore()
+	if err != nil {
+		return nil, nil, err
+	}
+	refreshed := make(map[string]string)
+	var signals []uint
+	for i, artifactMount := range c.config.ArtifactVolumes {
+		if artifactMount.RefreshInterval == 0 {
+			continue
+		}
+		digest, err := artifactDigest(ctx, artStore, artifactMount)
+		if err != nil {
+			return nil, nil, err
+		}
+		if digest == c.state.ArtifactDigests[artifactMount.Dest] {
+			continue
+		}
+		if err := c.runtime.verifyArtifact(ctx, artStore, artifactMount.Source); err != nil {
+			return nil, nil, fmt.Errorf("refreshing artifact %q of container %s: %w", artifactMount.Source, c.ID(), err)
+		}
+		if err := populateArtifactDir(ctx, artStore, c.artifactRefreshDir(i), artifactMount, digest); err != nil {
+			return nil, nil, fmt.Errorf("refreshing artifact %q of container %s: %w", artifactMount.Source, c.ID(), err)
+		}
+		logrus.Debugf("Refreshed artifact %q of container %s to %s", artifactMount.Source, c.ID(), digest)
+		if c.state.ArtifactDigests == nil {
+			c.state.ArtifactDigests = make(map[string]string)
+		}
+		c.state.ArtifactDigests[artifactMount.Dest] = digest
+		refreshed[artifactMount.Dest] = digest
+		if artifactMount.RefreshSignal != 0 && !slices.Contains(signals, artifactMount.RefreshSignal) {
+			signals = append(signals, artifactMount.RefreshSignal)
+		}
+	}
+	if len(refreshed) == 0 {
+		return nil, nil, nil
+	}
+	if err := c.save(); err != nil {
+		return nil, nil, err
+	}
+	c.newContainerEvent(events.ArtifactRefresh)
+	return refreshed, signals, nil
+}
+
+// artifactDigest returns the digest of the artifact the volume mounts.
+func artifactDigest(ctx context.Context, artStore *store.ArtifactStore, artifactMount *ContainerArtifactVolume) (string, error) {
+	asr, err := store.NewArtifactStorageReference(artifactMount.Source)
+	if err != nil {
+		return "", err
+	}
+	artifact, err := artStore.Inspect(ctx, asr)
+	if err != nil {
+		return "", err
+	}
+	digest, err := artifact.GetDigest()
+	if err != nil {
+		return "", err
+	}
+	return digest.String(), nil
+}
+
+// populateArtifactDir links the blobs of the artifact volume into a new
+// version directory in dir and atomically switches the files of dir to it.
+func populateArtifactDir(ctx context.Context, artStore *store.ArtifactStore, dir string, artifactMount *ContainerArtifactVolume, digest string) error {
+	asr, err := store.NewArtifactStorageReference(artifactMount.Source)
+	if err != nil {
+		return err
+	}
+	paths, err := artStore.BlobMountPaths(ctx, asr, &libartTypes.BlobMountPathOptions{
+		FilterBlobOptions: libartTypes.FilterBlobOptions{
+			Title:  artifactMount.Title,
+			Digest: artifactMount.Digest,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	files := make(map[string]string, len(paths))
+	for i, path := range paths {
+		files[artifactFileName(artifactMount, path, i, len(paths))] = path.SourcePath
+	}
+	_, encoded, _ := strings.Cut(digest, ":")
+	return swapArtifactDir(dir, encoded, files)
+}
+
+// swapArtifactDir replaces the files of dir with the given files, keyed by
+// their names, without readers ever seeing a mix of old and new files.  The
+// files are linked into a new version directory, the ..data symlink is
+// atomically replaced to point to it and every file of dir is a symlink into
+// ..data.
+func swapArtifactDir(dir, version string, files map[string]string) (retErr error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if len(version) > 12 {
+		version = version[:12]
+	}
+	versionDir, err := os.MkdirTemp(dir, ".."+version+"_")
+	if err != nil {
+		return err
+	}
+	switched := false
+	defer func() {
+		if retErr != nil && !switched {
+			if err := os.RemoveAll(versionDir); err != nil {
+				logrus.Errorf("Removing artifact version %q: %v", versionDir, err)
+			}
+		}
+	}()
+	if err := os.Chmod(versionDir, 0o755); err != nil {
+		return err
+	}
+	for name, source := range files {
+		if name == "" || strings.HasPrefix(name, "..") || strings.ContainsRune(name, os.PathSeparator) {
+			return fmt.Errorf("invalid artifact file name %q", name)
+		}
+		if err := linkOrCopy(source, filepath.Join(versionDir, name)); err != nil {
+			return err
+		}
+	}
+
+	dataLink := filepath.Join(dir, artifactDataDir)
+	oldVersion, err := os.Readlink(dataLink)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := replaceSymlink(filepath.Base(versionDir), dataLink); err != nil {
+		return err
+	}
+	switched = true
+
+	for name := range files {
+		target := filepath.Join(artifactDataDir, name)
+		if current, err := os.Readlink(filepath.Join(dir, name)); err == nil && current == target {
+			continue
+		}
+		if err := replaceSymlink(target, filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if _, ok := files[entry.Name()]; ok || strings.HasPrefix(entry.Name(), "..") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	if oldVersion != "" && oldVersion != filepath.Base(versionDir) {
+		if err := os.RemoveAll(filepath.Join(dir, oldVersion)); err != nil {
+			logrus.Warnf("Removing old artifact version %q: %v", oldVersion, err)
+		}
+	}
+	return nil
+}
+
+// replaceSymlink atomically replaces link with a symlink to target.
+func replaceSymlink(target, link string) error {
+	tmp := filepath.Join(filepath.Dir(link), "..tmp_"+filepath.Base(link))
+	if err := os.Remove(tmp); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Symlink(target, tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp, link)
+}
+
+// linkOrCopy hard links the blob to dest, the blobs are never changed in the
+// artifact store.  It falls back to copying the blob if the artifact store is
+// on another file system.
+func linkOrCopy(source, dest string) error {
+	if err := os.Link(source, dest); err == nil {
+		return nil
+	}
+	if _, err := fileutils.CopyFile(source, dest); err != nil {
+		return err
+	}
+	return os.Chmod(dest, 0o644)
+}
@@ -0,0 +1,51 @@
+//go:build !remote && systemd
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/sirupsen/logrus"
+	systemdCommon "go.podman.io/common/pkg/systemd"
+)
+
+// createArtifactRefreshTimer creates the systemd timer which periodically
+// refreshes the artifact volumes of the container.
+func (c *Container) createArtifactRefreshTimer() error {
+	interval := c.artifactRefreshInterval()
+	if interval == 0 || !systemdCommon.RunsOnSystemd() {
+		return nil
+	}
+	if c.state.ArtifactRefreshUnitName != "" {
+		if err := c.removeArtifactRefreshTimer(context.Background()); err != nil {
+			logrus.Errorf("Removing artifact refresh timer of container %s: %v", c.ID(), err)
+		}
+	}
+
+	unitName := fmt.Sprintf("%s-artifact-refresh-%x", c.ID(), rand.Int())
+	timerArgs := []string{"--on-active=" + interval.String(), "--on-unit-active=" + interval.String()}
+	if err := runTransientTimer(unitName, timerArgs, "artifact", "refresh", c.ID()); err != nil {
+		return err
+	}
+
+	c.state.ArtifactRefreshUnitName = unitName
+	if err := c.save(); err != nil {
+		return fmt.Errorf("saving container %s artifact refresh unit name: %w", c.ID(), err)
+	}
+	return nil
+}
+
+// removeArtifactRefreshTimer removes the systemd timer refreshing the artifact
+// volumes of the container.
+func (c *Container) removeArtifactRefreshTimer(ctx context.Context) error {
+	if c.state.ArtifactRefreshUnitName == "" {
+		return nil
+	}
+	if err := stopTransientUnits(ctx, c.state.ArtifactRefreshUnitName, "artifact refresh"); err != nil {
+		return err
+	}
+	c.state.ArtifactRefreshUnitName = ""
+	return nil
+}
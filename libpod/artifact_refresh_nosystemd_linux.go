@@ -0,0 +1,19 @@
+//go:build !remote && !systemd
+
+package libpod
+
+import (
+	"context"
+)
+
+// createArtifactRefreshTimer creates the systemd timer which periodically
+// refreshes the artifact volumes of the container.
+func (c *Container) createArtifactRefreshTimer() error {
+	return nil
+}
+
+// removeArtifactRefreshTimer removes the systemd timer refreshing the artifact
+// volumes of the container.
+func (c *Container) removeArtifactRefreshTimer(_ context.Context) error {
+	return nil
+}
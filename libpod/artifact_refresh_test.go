@@ -0,0 +1,59 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwapArtifactDir(t *testing.T) {
+	blobs := t.TempDir()
+	for name, content := range map[string]string{"v1": "model v1", "v2": "model v2", "config": "config"} {
+		require.NoError(t, os.WriteFile(filepath.Join(blobs, name), []byte(content), 0o644))
+	}
+	dir := filepath.Join(t.TempDir(), "artifacts", "0")
+
+	require.NoError(t, swapArtifactDir(dir, "1111111111111111", map[string]string{
+		"model":  filepath.Join(blobs, "v1"),
+		"config": filepath.Join(blobs, "config"),
+	}))
+	data, err := os.ReadFile(filepath.Join(dir, "model"))
+	require.NoError(t, err)
+	assert.Equal(t, "model v1", string(data))
+	oldVersion, err := os.Readlink(filepath.Join(dir, artifactDataDir))
+	require.NoError(t, err)
+
+	// The files are replaced by switching ..data, the symlinks of the files
+	// which still exist are kept and the ones of removed files are deleted.
+	require.NoError(t, swapArtifactDir(dir, "2222222222222222", map[string]string{
+		"model": filepath.Join(blobs, "v2"),
+	}))
+	data, err = os.ReadFile(filepath.Join(dir, "model"))
+	require.NoError(t, err)
+	assert.Equal(t, "model v2", string(data))
+	target, err := os.Readlink(filepath.Join(dir, "model"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(artifactDataDir, "model"), target)
+	assert.NoFileExists(t, filepath.Join(dir, "config"))
+	assert.NoDirExists(t, filepath.Join(dir, oldVersion))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Len(t, names, 3)
+	assert.Contains(t, names, "model")
+	assert.Contains(t, names, artifactDataDir)
+
+	assert.ErrorContains(t, swapArtifactDir(dir, "3333333333333333", map[string]string{"..data": filepath.Join(blobs, "v1")}), "invalid artifact file name")
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
@@ -0,0 +1,99 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/opencontainers/go-digest"
+	specV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.podman.io/image/v5/oci/layout"
+	"go.podman.io/storage/pkg/lockfile"
+)
+
+// artifactStorePath returns the directory of the artifact store, an OCI
+// layout.
+func (r *Runtime) artifactStorePath() string {
+	return filepath.Join(r.storageConfig.GraphRoot, "artifacts")
+}
+
+// SetArtifactSubject sets subject as the OCI subject of the manifest of the
+// artifact with the given name in the artifact store, which the store can not
+// add artifacts with.  Registries implementing the OCI referrers API list
+// the artifact as a referrer of the subject once it is pushed.  It returns
+// the new digest of the artifact.
+func (r *Runtime) SetArtifactSubject(ctx context.Context, name string, subject *specV1.Descriptor) (*digest.Digest, error) {
+	storePath := r.artifactStorePath()
+	// The lock of the artifact store, shared with the store by the lock
+	// file.
+	lock, err := lockfile.GetLockFile(filepath.Join(storePath, "index.lock"))
+	if err != nil {
+		return nil, err
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	ref, err := layout.NewReference(storePath, name)
+	if err != nil {
+		return nil, err
+	}
+	src, err := ref.NewImageSource(ctx, r.SystemContext())
+	if err != nil {
+		return nil, err
+	}
+	rawManifest, _, err := src.GetManifest(ctx, nil)
+	src.Close()
+	if err != nil {
+		return nil, err
+	}
+	var artifactManifest specV1.Manifest
+	if err := json.Unmarshal(rawManifest, &artifactManifest); err != nil {
+		return nil, err
+	}
+	oldDigest := digest.FromBytes(rawManifest)
+
+	// Marshal the manifest like the store does, it identifies artifacts by
+	// the digest of the manifest as it parsed it.
+	artifactManifest.Subject = subject
+	rawData, err := json.Marshal(artifactManifest)
+	if err != nil {
+		return nil, err
+	}
+	newDigest := digest.FromBytes(rawData)
+	if newDigest == oldDigest {
+		return &newDigest, nil
+	}
+
+	dest, err := ref.NewImageDestination(ctx, r.SystemContext())
+	if err != nil {
+		return nil, err
+	}
+	defer dest.Close()
+	if err := dest.PutManifest(ctx, rawData, nil); err != nil {
+		return nil, err
+	}
+	if err := dest.Commit(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	// The name moved to the new manifest, remove the old one like the
+	// store does when appending to an artifact.
+	lrs, err := layout.List(storePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lrs {
+		if l.ManifestDescriptor.Digest != oldDigest {
+			continue
+		}
+		if _, ok := l.ManifestDescriptor.Annotations[specV1.AnnotationRefName]; ok {
+			continue
+		}
+		if err := l.Reference.DeleteImage(ctx, r.SystemContext()); err != nil {
+			return nil, err
+		}
+		break
+	}
+	return &newDigest, nil
+}
@@ -0,0 +1,211 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/opencontainers/go-digest"
+	specV1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.podman.io/common/pkg/libartifact"
+	"go.podman.io/common/pkg/libartifact/store"
+	"go.podman.io/image/v5/directory"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/image"
+	"go.podman.io/image/v5/manifest"
+	"go.podman.io/image/v5/signature"
+	"go.podman.io/image/v5/types"
+)
+
+// artifactSignaturesDir returns the directory the manifest and signatures of
+// the pulled artifact with the given digest are stored in, in the format of
+// the dir transport.  The artifact store itself can not hold signatures.
+func (r *Runtime) artifactSignaturesDir(d digest.Digest) string {
+	return filepath.Join(r.storageConfig.GraphRoot, "artifact-signatures", d.Encoded())
+}
+
+// artifactManifestDigest returns the digest the artifact store identifies
+// the artifact with the given manifest by.  The store digests the manifest
+// as it parsed it, which may differ from the digest of the pulled manifest.
+func artifactManifestDigest(raw []byte) (digest.Digest, error) {
+	m, err := manifest.OCI1FromManifest(raw)
+	if err != nil {
+		return "", err
+	}
+	artifact := libartifact.Artifact{Manifest: m}
+	d, err := artifact.GetDigest()
+	if err != nil {
+		return "", err
+	}
+	return *d, nil
+}
+
+// SetArtifactSignatures stores the manifest and the signatures, in the format
+// stored by containers-storage, of an artifact pulled from a registry.  They
+// are verified against the signature policy whenever the artifact is mounted
+// into a container.
+func (r *Runtime) SetArtifactSignatures(ctx context.Context, rawManifest []byte, sigs [][]byte) error {
+	d, err := artifactManifestDigest(rawManifest)
+	if err != nil {
+		return err
+	}
+	if len(sigs) == 0 {
+		return r.RemoveArtifactSignatures(d)
+	}
+
+	dir := r.artifactSignaturesDir(d)
+	if err := os.MkdirAll(filepath.Dir(dir), 0o700); err != nil {
+		return err
+	}
+	// Write the signatures to a temporary directory first so a concurrent
+	// mount never sees a partial set.
+	tmpDir, err := os.MkdirTemp(filepath.Dir(dir), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	ref, err := directory.NewReference(tmpDir)
+	if err != nil {
+		return err
+	}
+	dest, err := ref.NewImageDestination(ctx, r.SystemContext())
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+	if err := dest.PutManifest(ctx, rawManifest, nil); err != nil {
+		return err
+	}
+	// The dir transport stores the blobs as they are, keeping the format
+	// of sigstore signatures.
+	if err := dest.PutSignatures(ctx, sigs, nil); err != nil {
+		return err
+	}
+	if err := dest.Commit(ctx, nil); err != nil {
+		return err
+	}
+	if err := r.RemoveArtifactSignatures(d); err != nil {
+		return err
+	}
+	return os.Rename(tmpDir, dir)
+}
+
+// RemoveArtifactSignatures removes the stored signatures of the artifact with
+// the given digest.
+func (r *Runtime) RemoveArtifactSignatures(d digest.Digest) error {
+	if err := os.RemoveAll(r.artifactSignaturesDir(d)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing signatures of artifact %s: %w", d, err)
+	}
+	return nil
+}
+
+// verifyArtifact checks the artifact against the signature policy for
+// pulling it from the registry in its name, with the signatures stored when
+// it was pulled.  Artifacts added locally have no signatures and are
+// rejected by scopes requiring them, like images.
+func (r *Runtime) verifyArtifact(ctx context.Context, artStore *store.ArtifactStore, nameOrDigest string) error {
+	asr, err := store.NewArtifactStorageReference(nameOrDigest)
+	if err != nil {
+		return err
+	}
+	artifact, err := artStore.Inspect(ctx, asr)
+	if err != nil {
+		return err
+	}
+	named, err := reference.ParseNormalizedNamed(artifact.Name)
+	if err != nil {
+		return fmt.Errorf("parsing name of artifact %q: %w", nameOrDigest, err)
+	}
+	ref, err := docker.NewReference(reference.TagNameOnly(named))
+	if err != nil {
+		return err
+	}
+
+	unparsed, closer, err := r.artifactUnparsedImage(ctx, artifact)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	policy, err := signature.DefaultPolicy(r.SystemContext())
+	if err != nil {
+		return fmt.Errorf("obtaining default signature policy: %w", err)
+	}
+	policyContext, err := signature.NewPolicyContext(policy)
+	if err != nil {
+		return fmt.Errorf("creating new signature policy context: %w", err)
+	}
+	defer func() { _ = policyContext.Destroy() }()
+
+	if allowed, err := policyContext.IsRunningImageAllowed(ctx, image.UnparsedInstanceWithReference(unparsed, ref)); !allowed || err != nil { // Be paranoid and fail if either return value indicates so.
+		return fmt.Errorf("artifact %q: Source image rejected: %w", artifact.Name, err)
+	}
+	return nil
+}
+
+// artifactUnparsedImage returns the manifest and stored signatures of the
+// artifact for evaluating the signature policy.
+func (r *Runtime) artifactUnparsedImage(ctx context.Context, artifact *libartifact.Artifact) (types.UnparsedImage, func(), error) {
+	d, err := artifact.GetDigest()
+	if err != nil {
+		return nil, nil, err
+	}
+	dir := r.artifactSignaturesDir(*d)
+	if _, err := os.Stat(dir); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, err
+		}
+		rawManifest, err := json.Marshal(artifact.Manifest)
+		if err != nil {
+			return nil, nil, err
+		}
+		return unsignedArtifact{manifest: rawManifest}, func() {}, nil
+	}
+
+	ref, err := directory.NewReference(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	src, err := ref.NewImageSource(ctx, r.SystemContext())
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading signatures of artifact %s: %w", artifact.Name, err)
+	}
+	closer := func() { src.Close() }
+	unparsed := image.UnparsedInstance(src, nil)
+	rawManifest, _, err := unparsed.Manifest(ctx)
+	if err != nil {
+		closer()
+		return nil, nil, err
+	}
+	if storedDigest, err := artifactManifestDigest(rawManifest); err != nil || storedDigest != *d {
+		closer()
+		return nil, nil, fmt.Errorf("stored signatures of artifact %s do not match digest %s", artifact.Name, d)
+	}
+	return unparsed, closer, nil
+}
+
+// unsignedArtifact is an artifact without stored signatures.  Its reference
+// is replaced by the docker reference of the artifact when evaluating the
+// policy.
+type unsignedArtifact struct {
+	manifest []byte
+}
+
+func (u unsignedArtifact) Reference() types.ImageReference {
+	return nil
+}
+
+func (u unsignedArtifact) Manifest(_ context.Context) ([]byte, string, error) {
+	return u.manifest, specV1.MediaTypeImageManifest, nil
+}
+
+func (u unsignedArtifact) Signatures(_ context.Context) ([][]byte, error) {
+	return nil, nil
+}
@@ -10,6 +10,7 @@ import (
 	"maps"
 	"net"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -212,6 +213,13 @@ type ContainerState struct {
 	// HCUnitName records the name of the healthcheck unit.
 	// Automatically generated when the healthcheck is started.
 	HCUnitName string `json:"hcUnitName,omitempty"`
+	// ArtifactDigests records the digests of the artifacts mounted into
+	// the container by refreshing artifact volumes, keyed by the
+	// destination of the volume.
+	ArtifactDigests map[string]string `json:"artifactDigests,omitempty"`
+	// ArtifactRefreshUnitName records the name of the systemd unit
+	// refreshing the artifact volumes.
+	ArtifactRefreshUnitName string `json:"artifactRefreshUnitName,omitempty"`
 
 	// ExtensionStageHooks holds hooks which will be executed by libpod
 	// and not delegated to the OCI runtime.
@@ -307,6 +315,17 @@ type ContainerArtifactVolume struct {
 	// "<name>-x" where x is a 0 indexed integer based on the layer order.
 	// Optional.
 	Name string `json:"name,omitempty"`
+	// RefreshInterval is the interval in which the artifact is checked for a
+	// new version.  When set, the blobs are mounted through a directory whose
+	// content is atomically swapped when the artifact changes.
+	// Optional.
+	RefreshInterval time.Duration `json:"refreshInterval,omitempty"`
+	// RefreshPull pulls the artifact from its registry before each check.
+	// Optional.
+	RefreshPull bool `json:"refreshPull,omitempty"`
+	// RefreshSignal is sent to the container after the artifact was refreshed.
+	// Optional.
+	RefreshSignal uint `json:"refreshSignal,omitempty"`
 }
 
 // ContainerSecret is a secret that is mounted in a container
@@ -549,6 +568,36 @@ func (c *Container) Dependencies() []string {
 	return depends
 }
 
+// DependencyKinds gets the containers this container depends upon along with
+// the kinds of each dependency: the namespaces shared with it ("ipc",
+// "mount", "net", "pid", "user", "uts" and "cgroup") and "requires" for
+// generic dependencies.
+func (c *Container) DependencyKinds() map[string][]string {
+	kinds := make(map[string][]string)
+	for _, ns := range []struct {
+		kind string
+		ctr  string
+	}{
+		{"ipc", c.config.IPCNsCtr},
+		{"mount", c.config.MountNsCtr},
+		{"net", c.config.NetNsCtr},
+		{"pid", c.config.PIDNsCtr},
+		{"user", c.config.UserNsCtr},
+		{"uts", c.config.UTSNsCtr},
+		{"cgroup", c.config.CgroupNsCtr},
+	} {
+		if ns.ctr != "" {
+			kinds[ns.ctr] = append(kinds[ns.ctr], ns.kind)
+		}
+	}
+	for _, id := range c.config.Dependencies {
+		if !slices.Contains(kinds[id], "requires") {
+			kinds[id] = append(kinds[id], "requires")
+		}
+	}
+	return kinds
+}
+
 // NewNetNS returns whether the container will create a new network namespace
 func (c *Container) NewNetNS() bool {
 	return c.config.CreateNetNS
@@ -14,9 +14,11 @@ import (
 	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/libpod/events"
 	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/rootless"
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/pkg/resize"
 	"go.podman.io/storage/pkg/archive"
+	"go.podman.io/storage/pkg/chrootarchive"
 	"golang.org/x/sys/unix"
 )
 
@@ -207,6 +209,23 @@ func (c *Container) Attach(ctx context.Context, streams *define.AttachStreams, k
 		}
 	}
 
+	recording := ""
+	var recorder *sessionRecorder
+	if streams != nil && c.runtime.shouldRecord(streams.Record, streams.AttachInput) {
+		var command []string
+		if c.config.Spec != nil && c.config.Spec.Process != nil {
+			command = c.config.Spec.Process.Args
+		}
+		r, err := c.newSessionRecorder(RecordingTypeAttach, "", command, nil)
+		if err != nil {
+			return nil, err
+		}
+		recorder = r
+		recording = recorder.path
+		streams = recorder.streams(streams)
+		resize = recorder.resizes(resize)
+	}
+
 	attachChan := make(chan error)
 
 	// We need to ensure that we don't return until start() fired in attach.
@@ -229,7 +248,13 @@ func (c *Container) Attach(ctx context.Context, streams *define.AttachStreams, k
 		// attach and start the container on a different thread.  waitForHealthy must
 		// be done later, as it requires to run on the same thread that holds the lock
 		// for the container.
-		if err := c.ociRuntime.Attach(c, opts); err != nil {
+		err := c.ociRuntime.Attach(c, opts)
+		// Finish the recording before the caller learns that the
+		// session ended.
+		if recorder != nil {
+			recorder.close()
+		}
+		if err != nil {
 			attachChan <- err
 		}
 		close(attachChan)
@@ -239,7 +264,7 @@ func (c *Container) Attach(ctx context.Context, streams *define.AttachStreams, k
 	case err := <-attachChan:
 		return nil, err
 	case <-startedChan:
-		c.newContainerEvent(events.Attach)
+		c.newSessionEvent(events.Attach, "", recording)
 	}
 
 	if start {
@@ -410,13 +435,34 @@ func (c *Container) HTTPAttach(r *http.Request, w http.ResponseWriter, streams *
 		return fmt.Errorf("must specify at least one of stream or logs: %w", define.ErrInvalidArg)
 	}
 
+	recording := ""
+	if streamAttach && c.runtime.shouldRecord(streams != nil && streams.Record, streams == nil || streams.Stdin) {
+		var command []string
+		if c.config.Spec != nil && c.config.Spec.Process != nil {
+			command = c.config.Spec.Process.Args
+		}
+		recorder, err := c.newSessionRecorder(RecordingTypeAttach, "", command, nil)
+		if err != nil {
+			return err
+		}
+		defer recorder.close()
+		recorder.register(c.ID())
+		recording = recorder.path
+		recorded := HTTPAttachStreams{Stdin: true, Stdout: true, Stderr: true}
+		if streams != nil {
+			recorded = *streams
+		}
+		recorded.recording = recorder.output()
+		streams = &recorded
+	}
+
 	// We are NOT holding the lock for the duration of the function.
 	locked = false
 	c.lock.Unlock()
 
 	logrus.Infof("Performing HTTP Hijack attach to container %s", c.ID())
 
-	c.newContainerEvent(events.Attach)
+	c.newSessionEvent(events.Attach, "", recording)
 	return c.ociRuntime.HTTPAttach(c, r, w, streams, detachKeys, cancel, hijackDone, streamAttach, streamLogs)
 }
 
@@ -438,7 +484,11 @@ func (c *Container) AttachResize(newSize resize.TerminalSize) error {
 
 	logrus.Infof("Resizing TTY of container %s", c.ID())
 
-	return c.ociRuntime.AttachResize(c, newSize)
+	if err := c.ociRuntime.AttachResize(c, newSize); err != nil {
+		return err
+	}
+	recordResize(c.ID(), newSize)
+	return nil
 }
 
 // Mount mounts a container's filesystem on the host
@@ -1116,8 +1166,9 @@ func (c *Container) ShouldStartOnBoot() bool {
 }
 
 // CopyFromArchive copies the contents from the specified tarStream to path
-// *inside* the container.
-func (c *Container) CopyFromArchive(_ context.Context, containerPath string, chown, noOverwriteDirNonDir bool, rename map[string]string, tarStream io.Reader) (func() error, error) {
+// *inside* the container.  The paths in remove, relative to path, are removed
+// before.
+func (c *Container) CopyFromArchive(_ context.Context, containerPath string, chown, noOverwriteDirNonDir bool, rename map[string]string, remove []string, tarStream io.Reader) (func() error, error) {
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -1127,7 +1178,7 @@ func (c *Container) CopyFromArchive(_ context.Context, containerPath string, cho
 		}
 	}
 
-	return c.copyFromArchive(containerPath, chown, noOverwriteDirNonDir, rename, tarStream)
+	return c.copyFromArchive(containerPath, chown, noOverwriteDirNonDir, rename, remove, tarStream)
 }
 
 // CopyToArchive copies the contents from the specified path *inside* the
@@ -1145,6 +1196,56 @@ func (c *Container) CopyToArchive(_ context.Context, containerPath string, tarSt
 	return c.copyToArchive(containerPath, tarStream)
 }
 
+// ApplyDiff applies a layer tarball, as written by Runtime.ExportDiff, to the
+// root filesystem of the container.  Whiteouts in the tarball remove the
+// respective paths from the container.  The tarball may be compressed.
+func (c *Container) ApplyDiff(_ context.Context, diff io.Reader) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.config.Rootfs != "" {
+		return fmt.Errorf("cannot apply a diff to container %s as it uses an external root filesystem: %w", c.ID(), define.ErrNotImplemented)
+	}
+
+	var mountPoint string
+	var err error
+	if c.state.Mounted {
+		mountPoint = c.state.Mountpoint
+	} else {
+		mountPoint, err = c.mount()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := c.unmount(false); err != nil {
+				logrus.Errorf("Unmounting container %s: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	layer, err := archive.DecompressStream(diff)
+	if err != nil {
+		return err
+	}
+	defer layer.Close()
+
+	options := &archive.TarOptions{
+		UIDMaps:  c.config.IDMappings.UIDMap,
+		GIDMaps:  c.config.IDMappings.GIDMap,
+		InUserNS: rootless.IsRootless(),
+	}
+	if _, err := chrootarchive.ApplyUncompressedLayer(mountPoint, layer, options); err != nil {
+		return fmt.Errorf("applying diff to container %s: %w", c.ID(), err)
+	}
+	return nil
+}
+
 // Stat the specified path *inside* the container and return a file info.
 func (c *Container) Stat(_ context.Context, containerPath string) (*define.FileInfo, error) {
 	if !c.batched {
@@ -312,6 +312,12 @@ type ContainerNetworkConfig struct {
 	NetMode namespaces.NetworkMode `json:"networkMode,omitempty"`
 	// NetworkOptions are additional options for each network
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// NetworkRateLimits limits the bandwidth of the network attachments
+	// of the container, keyed by network name. The limit with the empty
+	// name applies to all attachments without a limit of their own,
+	// including the interface of pasta and slirp4netns.
+	// These are not used unless CreateNetNS is true
+	NetworkRateLimits map[string]define.NetworkRateLimit `json:"networkRateLimits,omitempty"`
 }
 
 // ContainerImageConfig is an embedded sub-config providing image configuration
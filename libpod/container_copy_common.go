@@ -23,7 +23,13 @@ import (
 	"go.podman.io/storage/pkg/stringid"
 )
 
-func (c *Container) copyFromArchive(path string, chown, noOverwriteDirNonDir bool, rename map[string]string, reader io.Reader) (func() error, error) {
+func (c *Container) copyFromArchive(path string, chown, noOverwriteDirNonDir bool, rename map[string]string, remove []string, reader io.Reader) (func() error, error) {
+	for _, item := range remove {
+		if !filepath.IsLocal(item) {
+			return nil, fmt.Errorf("path %q to remove is not below %q: %w", item, path, define.ErrInvalidArg)
+		}
+	}
+
 	var (
 		mountPoint   string
 		resolvedRoot string
@@ -223,6 +229,11 @@ func (c *Container) copyFromArchive(path string, chown, noOverwriteDirNonDir boo
 
 		return c.joinMountAndExec(
 			func() error {
+				for _, item := range remove {
+					if err := buildahCopiah.Remove(resolvedRoot, filepath.Join(resolvedPath, item), buildahCopiah.RemoveOptions{All: true}); err != nil {
+						return err
+					}
+				}
 				return buildahCopiah.Put(resolvedRoot, resolvedPath, putOptions, decompressed)
 			},
 		)
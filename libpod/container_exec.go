@@ -316,7 +316,7 @@ func (c *Container) ExecStart(sessionID string) error {
 		return err
 	}
 
-	c.newContainerEvent(events.Exec)
+	c.newSessionEvent(events.Exec, session.ID(), "")
 	logrus.Debugf("Successfully started exec session %s in container %s", session.ID(), c.ID())
 
 	// Update and save session to reflect PID/running
@@ -329,7 +329,8 @@ func (c *Container) ExecStart(sessionID string) error {
 
 // execStartAndAttach starts and attaches to an exec session in a container.
 // newSize resizes the tty to this size before the process is started, must be nil if the exec session has no tty
-func (c *Container) execStartAndAttach(sessionID string, streams *define.AttachStreams, newSize *resize.TerminalSize, isHealthcheck bool) error {
+// recording is the path of the recording of the session, if it is recorded
+func (c *Container) execStartAndAttach(sessionID string, streams *define.AttachStreams, newSize *resize.TerminalSize, isHealthcheck bool, recording string) error {
 	unlock := true
 	if !c.batched {
 		c.lock.Lock()
@@ -371,7 +372,7 @@ func (c *Container) execStartAndAttach(sessionID string, streams *define.AttachS
 	}
 
 	if !isHealthcheck {
-		c.newContainerEvent(events.Exec)
+		c.newSessionEvent(events.Exec, session.ID(), recording)
 	}
 
 	logrus.Debugf("Successfully started exec session %s in container %s", session.ID(), c.ID())
@@ -536,6 +537,22 @@ func (c *Container) ExecHTTPStartAndAttach(sessionID string, r *http.Request, w
 		streams.Stderr = session.Config.AttachStderr
 	}
 
+	recording := ""
+	if c.runtime.shouldRecord(streams.Record, streams.Stdin) {
+		recorder, err := c.newSessionRecorder(RecordingTypeExec, session.ID(), session.Config.Command, newSize)
+		if err != nil {
+			return err
+		}
+		// Finish the recording before the caller learns that the
+		// session ended.
+		defer recorder.close()
+		recorder.register(session.ID())
+		recording = recorder.path
+		recorded := *streams
+		recorded.recording = recorder.output()
+		streams = &recorded
+	}
+
 	holdConnOpen := make(chan bool)
 
 	defer func() {
@@ -557,7 +574,7 @@ func (c *Container) ExecHTTPStartAndAttach(sessionID string, r *http.Request, w
 	// TODO: Investigate whether more of this can be made common with
 	// ExecStartAndAttach
 
-	c.newContainerEvent(events.Exec)
+	c.newSessionEvent(events.Exec, session.ID(), recording)
 	logrus.Debugf("Successfully started exec session %s in container %s", session.ID(), c.ID())
 
 	var lastErr error
@@ -818,7 +835,11 @@ func (c *Container) ExecResize(sessionID string, newSize resize.TerminalSize) er
 
 	// Make sure the exec session is still running.
 
-	return c.ociRuntime.ExecAttachResize(c, sessionID, newSize)
+	if err := c.ociRuntime.ExecAttachResize(c, sessionID, newSize); err != nil {
+		return err
+	}
+	recordResize(sessionID, newSize)
+	return nil
 }
 
 func (c *Container) healthCheckExec(config *ExecConfig, timeout time.Duration, streams *define.AttachStreams) (int, error) {
@@ -837,6 +858,18 @@ func (c *Container) exec(config *ExecConfig, streams *define.AttachStreams, resi
 	if err != nil {
 		return -1, err
 	}
+	var recorder *sessionRecorder
+	if !isHealthcheck && streams != nil && c.runtime.shouldRecord(streams.Record, streams.AttachInput) {
+		recorder, err = c.newSessionRecorder(RecordingTypeExec, sessionID, config.Command, nil)
+		if err != nil {
+			if rmErr := c.ExecRemove(sessionID, false); rmErr != nil {
+				logrus.Errorf("Removing exec session %s: %v", sessionID, rmErr)
+			}
+			return -1, err
+		}
+		defer recorder.close()
+		streams = recorder.streams(streams)
+	}
 	cleanup := true
 	defer func() {
 		if cleanup {
@@ -860,9 +893,15 @@ func (c *Container) exec(config *ExecConfig, streams *define.AttachStreams, resi
 	if resizeChan != nil {
 		s := <-resizeChan
 		size = &s
+		if recorder != nil {
+			recorder.resize(s)
+		}
 		go func() {
 			logrus.Debugf("Sending resize events to exec session %s", sessionID)
 			for resizeRequest := range resizeChan {
+				if recorder != nil {
+					recorder.resize(resizeRequest)
+				}
 				if err := c.ExecResize(sessionID, resizeRequest); err != nil {
 					if errors.Is(err, define.ErrExecSessionStateInvalid) {
 						// The exec session stopped
@@ -877,7 +916,11 @@ func (c *Container) exec(config *ExecConfig, streams *define.AttachStreams, resi
 		}()
 	}
 
-	if err := c.execStartAndAttach(sessionID, streams, size, isHealthcheck); err != nil {
+	recording := ""
+	if recorder != nil {
+		recording = recorder.path
+	}
+	if err := c.execStartAndAttach(sessionID, streams, size, isHealthcheck, recording); err != nil {
 		// user detached, there will be no exit just exit without reporting an error
 		if errors.Is(err, define.ErrDetach) {
 			cleanup = false
@@ -617,6 +617,7 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 	// Only populate if we are creating the network namespace to configure the network.
 	if c.config.CreateNetNS {
 		hostConfig.PortBindings = makeInspectPortBindings(c.config.PortMappings)
+		hostConfig.NetworkRateLimits = c.config.NetworkRateLimits
 	} else {
 		hostConfig.PortBindings = make(map[string][]define.InspectHostPort)
 	}
@@ -648,6 +648,8 @@ func resetContainerState(state *ContainerState) {
 	state.StartupHCSuccessCount = 0
 	state.StartupHCFailureCount = 0
 	state.HCUnitName = ""
+	state.ArtifactDigests = nil
+	state.ArtifactRefreshUnitName = ""
 	state.NetNS = ""
 	state.NetworkStatus = nil
 }
@@ -1322,6 +1324,10 @@ func (c *Container) start() error {
 		}
 	}
 
+	if err := c.createArtifactRefreshTimer(); err != nil {
+		return fmt.Errorf("create artifact refresh timer: %w", err)
+	}
+
 	c.newContainerEvent(events.Start)
 
 	return c.save()
@@ -2161,6 +2167,11 @@ func (c *Container) cleanup(ctx context.Context) error {
 		}
 	}
 
+	// Remove the timer refreshing the artifact volumes
+	if err := c.removeArtifactRefreshTimer(ctx); err != nil {
+		logrus.Errorf("Removing artifact refresh timer for container %s: %v", c.ID(), err)
+	}
+
 	// Clean up network namespace, if present
 	if err := c.cleanupNetwork(); err != nil {
 		lastError = fmt.Errorf("removing container %s network: %w", c.ID(), err)
@@ -2732,26 +2743,7 @@ func (c *Container) extractSecretToCtrStorage(secr *ContainerSecret) error {
 	if err != nil {
 		return err
 	}
-	secretFile := filepath.Join(c.config.SecretsPath, secr.Name)
-
-	hostUID, hostGID, err := butil.GetHostIDs(util.IDtoolsToRuntimeSpec(c.config.IDMappings.UIDMap), util.IDtoolsToRuntimeSpec(c.config.IDMappings.GIDMap), secr.UID, secr.GID)
-	if err != nil {
-		return fmt.Errorf("unable to extract secret: %w", err)
-	}
-	err = os.WriteFile(secretFile, data, 0o644)
-	if err != nil {
-		return fmt.Errorf("unable to create %s: %w", secretFile, err)
-	}
-	if err := idtools.SafeLchown(secretFile, int(hostUID), int(hostGID)); err != nil {
-		return err
-	}
-	if err := os.Chmod(secretFile, os.FileMode(secr.Mode)); err != nil {
-		return err
-	}
-	if err := c.relabel(secretFile, c.config.MountLabel, false); err != nil {
-		return err
-	}
-	return nil
+	return c.writeSecretFile(secr, data)
 }
 
 // Update a container's resources or restart policy after creation.
@@ -2772,6 +2764,35 @@ func (c *Container) update(updateOptions *entities.ContainerUpdateOptions) error
 	}
 	oldRestart := c.config.RestartPolicy
 	oldRetries := c.config.RestartRetries
+	oldRateLimits := c.config.NetworkRateLimits
+
+	var newRateLimits map[string]define.NetworkRateLimit
+	if len(updateOptions.NetworkRateLimits) != 0 {
+		if !c.config.CreateNetNS {
+			return fmt.Errorf("network rate limits can only be set for containers with their own network namespace: %w", define.ErrInvalidArg)
+		}
+		limits := maps.Clone(c.config.NetworkRateLimits)
+		if limits == nil {
+			limits = make(map[string]define.NetworkRateLimit)
+		}
+		for network, limit := range updateOptions.NetworkRateLimits {
+			if network != "" && !c.config.NetMode.IsBridge() {
+				return fmt.Errorf("network rate limits for network %q can only be set in bridge network mode: %w", network, define.ErrInvalidArg)
+			}
+			if err := define.ValidateNetworkRateLimit(limit); err != nil {
+				return err
+			}
+			if network == "default" {
+				network = c.runtime.config.Network.DefaultNetwork
+			}
+			if limit == (define.NetworkRateLimit{}) {
+				delete(limits, network)
+			} else {
+				limits[network] = limit
+			}
+		}
+		newRateLimits = limits
+	}
 
 	if updateOptions.RestartPolicy != nil {
 		if err := define.ValidateRestartPolicy(*updateOptions.RestartPolicy); err != nil {
@@ -2807,6 +2828,10 @@ func (c *Container) update(updateOptions *entities.ContainerUpdateOptions) error
 		updateOptions.Resources = c.config.Spec.Linux.Resources
 	}
 
+	if newRateLimits != nil {
+		c.config.NetworkRateLimits = newRateLimits
+	}
+
 	if len(updateOptions.Env) != 0 {
 		c.config.Spec.Process.Env = envLib.Slice(envLib.Join(envLib.Map(c.config.Spec.Process.Env), envLib.Map(updateOptions.Env)))
 	}
@@ -2824,9 +2849,16 @@ func (c *Container) update(updateOptions *entities.ContainerUpdateOptions) error
 		c.config.Spec.Linux.Resources = oldResources
 		c.config.RestartPolicy = oldRestart
 		c.config.RestartRetries = oldRetries
+		c.config.NetworkRateLimits = oldRateLimits
 		return err
 	}
 
+	if len(updateOptions.NetworkRateLimits) != 0 && c.state.NetNS != "" {
+		if err := c.setupNetworkRateLimits(c.state.NetNS, c.state.NetworkStatus); err != nil {
+			return fmt.Errorf("updating network rate limits: %w", err)
+		}
+	}
+
 	if c.ensureState(define.ContainerStateCreated, define.ContainerStateRunning, define.ContainerStatePaused) &&
 		(updateOptions.Resources != nil || updateOptions.Env != nil || updateOptions.UnsetEnv != nil) {
 		// So `podman inspect` on running containers sources its OCI spec from disk.
@@ -456,6 +456,9 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		if dstPath == "/dev/shm" && c.state.BindMounts["/dev/shm"] == c.config.ShmDir {
 			newMount.Options = append(newMount.Options, "nosuid", "noexec", "nodev")
 		}
+		if srcPath == c.config.SecretsPath && !c.IsReadOnly() {
+			newMount.Options = append(newMount.Options, "ro", "nosuid", "noexec", "nodev")
+		}
 		if !MountExists(g.Mounts(), dstPath) {
 			g.AddMount(newMount)
 		} else {
@@ -548,7 +551,17 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		if err != nil {
 			return nil, nil, err
 		}
-		for _, artifactMount := range c.config.ArtifactVolumes {
+		for i, artifactMount := range c.config.ArtifactVolumes {
+			if err := c.runtime.verifyArtifact(ctx, artStore, artifactMount.Source); err != nil {
+				return nil, nil, err
+			}
+			if artifactMount.RefreshInterval > 0 {
+				if err := c.mountRefreshingArtifact(ctx, &g, artStore, i, artifactMount); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+
 			asr, err := store.NewArtifactStorageReference(artifactMount.Source)
 			if err != nil {
 				return nil, nil, err
@@ -578,16 +591,7 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 				if destIsFile {
 					dest = artifactMount.Dest
 				} else {
-					var filename string
-					if artifactMount.Name != "" {
-						filename = artifactMount.Name
-						if len(paths) > 1 {
-							filename += "-" + strconv.Itoa(i)
-						}
-					} else {
-						filename = path.Name
-					}
-					dest = filepath.Join(artifactMount.Dest, filename)
+					dest = filepath.Join(artifactMount.Dest, artifactFileName(artifactMount, path, i, len(paths)))
 				}
 
 				logrus.Debugf("Mounting artifact %q in container %s, mount blob %q to %q", artifactMount.Source, c.ID(), path.SourcePath, dest)
@@ -1739,6 +1743,9 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 			if dstPath == "/dev/shm" && c.state.BindMounts["/dev/shm"] == c.config.ShmDir {
 				newMount.Options = append(newMount.Options, "nosuid", "noexec", "nodev")
 			}
+			if srcPath == c.config.SecretsPath && !c.IsReadOnly() {
+				newMount.Options = append(newMount.Options, "ro", "nosuid", "noexec", "nodev")
+			}
 			if !MountExists(g.Mounts(), dstPath) {
 				g.AddMount(newMount)
 			}
@@ -2144,9 +2151,15 @@ rootless=%d
 		if err := c.createSecretMountDir(runPath); err != nil {
 			return fmt.Errorf("creating secrets mount: %w", err)
 		}
+		// The secrets below /run/secrets are symlinks into the
+		// secrets directory, mounted at secretsDataDir, so that
+		// UpdateSecret can replace them in running containers.
+		secretsDir := filepath.Join(runPath, "secrets")
+		linkDir := c.secretLinkDir(secretsDir)
+		linked := false
 		for _, secret := range c.Secrets() {
 			secretFileName := secret.Name
-			base := filepath.Join(runPath, "secrets")
+			base := secretsDir
 			if secret.Target != "" {
 				secretFileName = secret.Target
 				// If absolute path for target given remove base.
@@ -2156,8 +2169,28 @@ rootless=%d
 			}
 			src := filepath.Join(c.config.SecretsPath, secret.Name)
 			dest := filepath.Join(base, secretFileName)
+			if linkDir != "" {
+				ok, err := linkSecret(linkDir, secretsDir, dest, secret.Name)
+				if err != nil {
+					return err
+				}
+				if ok {
+					delete(c.state.BindMounts, dest)
+					linked = true
+					continue
+				}
+			}
 			c.state.BindMounts[dest] = src
 		}
+		if linked {
+			if err := os.MkdirAll(filepath.Join(linkDir, secretsDataDir), 0o755); err != nil {
+				return err
+			}
+			if err := c.relabel(c.config.SecretsPath, c.config.MountLabel, false); err != nil {
+				return err
+			}
+			c.state.BindMounts[filepath.Join(secretsDir, secretsDataDir)] = c.config.SecretsPath
+		}
 	}
 
 	return c.makeHostnameBindMount()
@@ -0,0 +1,275 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	butil "github.com/containers/buildah/util"
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/libpod/events"
+	"github.com/containers/podman/v6/pkg/util"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/secrets"
+	"go.podman.io/storage/pkg/idtools"
+)
+
+// SecretReload configures how the processes of a running container are told
+// that a secret mounted into it was updated.
+type SecretReload struct {
+	// Signal is sent to the container if not 0.
+	Signal uint
+	// Command is executed in the container if not empty.
+	Command []string
+}
+
+// UsesSecret returns whether the secret is mounted into the container or set
+// as one of its environment variables.
+func (c *Container) UsesSecret(name string) bool {
+	for _, secret := range c.config.Secrets {
+		if secret.Name == name {
+			return true
+		}
+	}
+	for _, secret := range c.config.EnvSecrets {
+		if secret.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretStale returns whether the container uses an older version of the
+// secret than the given one.  Mounted secrets are stale until they are
+// updated with UpdateSecret.  Environment variables, and secrets mounted
+// outside of /run/secrets, are stale until the container is restarted.
+func (c *Container) SecretStale(secret *secrets.Secret) (bool, error) {
+	needsRestart := false
+	for _, s := range c.config.Secrets {
+		if s.Name != secret.Name {
+			continue
+		}
+		if s.ID != secret.ID {
+			return true, nil
+		}
+		needsRestart = true
+	}
+	for _, s := range c.config.EnvSecrets {
+		if s.Name == secret.Name {
+			needsRestart = true
+		}
+	}
+	if !needsRestart {
+		return false, nil
+	}
+
+	state, err := c.State()
+	if err != nil {
+		return false, err
+	}
+	if state != define.ContainerStateRunning && state != define.ContainerStatePaused {
+		return false, nil
+	}
+	started, err := c.StartedTime()
+	if err != nil {
+		return false, err
+	}
+	if !started.Before(secret.UpdatedAt) {
+		return false, nil
+	}
+	for _, s := range c.config.EnvSecrets {
+		if s.Name == secret.Name {
+			return true, nil
+		}
+	}
+	return c.secretFileMounted(secret.Name), nil
+}
+
+// UpdateSecret replaces the files of the secret mounted into the container with
+// the new data of the secret.  If the container is running, its processes are
+// reloaded as configured once the files are rewritten.  Environment variables
+// set from the secret are not changed.
+func (c *Container) UpdateSecret(secret *secrets.Secret, data []byte, reload SecretReload) error {
+	running, err := c.updateSecretFiles(secret, data)
+	if err != nil {
+		return err
+	}
+	if !running {
+		return nil
+	}
+
+	if reload.Signal != 0 {
+		if err := c.signalReload(reload.Signal); err != nil {
+			return err
+		}
+	}
+	if len(reload.Command) > 0 {
+		output := &bytes.Buffer{}
+		streams := &define.AttachStreams{
+			OutputStream: output,
+			ErrorStream:  output,
+			AttachOutput: true,
+			AttachError:  true,
+		}
+		exitCode, err := c.Exec(&ExecConfig{Command: reload.Command}, streams, nil)
+		if err != nil {
+			return fmt.Errorf("reloading container %s: %w", c.ID(), err)
+		}
+		if exitCode != 0 {
+			return fmt.Errorf("reloading container %s: %v exited with code %d: %s", c.ID(), reload.Command, exitCode, bytes.TrimSpace(output.Bytes()))
+		}
+	}
+	return nil
+}
+
+// updateSecretFiles replaces the mounted files of the secret and returns
+// whether the container is running and thus needs to be reloaded.
+func (c *Container) updateSecretFiles(secret *secrets.Secret, data []byte) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return false, err
+	}
+
+	updated := false
+	for _, s := range c.config.Secrets {
+		if s.Name != secret.Name {
+			continue
+		}
+		if err := c.writeSecretFile(s, data); err != nil {
+			return false, fmt.Errorf("updating secret %s of container %s: %w", s.Name, c.ID(), err)
+		}
+		newSecret := *secret
+		s.Secret = &newSecret
+		updated = true
+	}
+	if !updated {
+		return false, nil
+	}
+	// Only the secrets of the config were changed.
+	if err := c.runtime.state.RewriteContainerConfig(c, c.config); err != nil {
+		return false, fmt.Errorf("saving secrets of container %s: %w", c.ID(), err)
+	}
+	logrus.Debugf("Updated secret %s of container %s", secret.Name, c.ID())
+
+	return c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused), nil
+}
+
+// signalReload sends the signal to the container.  Unlike Kill, the container
+// is not considered stopped by the user.
+func (c *Container) signalReload(signal uint) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	if !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return nil
+	}
+	if err := c.ociRuntime.KillContainer(c, signal, false); err != nil {
+		return fmt.Errorf("reloading container %s: %w", c.ID(), err)
+	}
+	c.newContainerEvent(events.Kill)
+	return nil
+}
+
+// secretsDataDir is the directory below /run/secrets of the container the
+// secrets directory of the container is mounted at.  The secrets below
+// /run/secrets are symlinks into it, so their files can be replaced while the
+// container is running.
+const secretsDataDir = "..data"
+
+// writeSecretFile replaces the file of the secret in the secrets directory of
+// the container with data.  The data is written to a temporary file which is
+// renamed over the old file, so readers see either the old or the new data.
+func (c *Container) writeSecretFile(secr *ContainerSecret, data []byte) (retErr error) {
+	hostUID, hostGID, err := butil.GetHostIDs(util.IDtoolsToRuntimeSpec(c.config.IDMappings.UIDMap), util.IDtoolsToRuntimeSpec(c.config.IDMappings.GIDMap), secr.UID, secr.GID)
+	if err != nil {
+		return fmt.Errorf("unable to extract secret: %w", err)
+	}
+	f, err := os.CreateTemp(c.config.SecretsPath, "."+secr.Name+"-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			f.Close()
+			if err := os.Remove(f.Name()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				logrus.Errorf("Removing temporary secret file %s: %v", f.Name(), err)
+			}
+		}
+	}()
+	if _, err := f.Write(data); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := idtools.SafeLchown(f.Name(), int(hostUID), int(hostGID)); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), os.FileMode(secr.Mode)); err != nil {
+		return err
+	}
+	if err := c.relabel(f.Name(), c.config.MountLabel, false); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(c.config.SecretsPath, secr.Name))
+}
+
+// secretLinkDir returns the directory on the host mounted at secretsDir, the
+// /run/secrets directory of the container, or "" if the user mounted
+// something else there and the secrets must be mounted file by file.
+func (c *Container) secretLinkDir(secretsDir string) string {
+	for _, m := range c.config.Spec.Mounts {
+		if m.Destination == secretsDir {
+			return ""
+		}
+	}
+	return c.state.BindMounts[secretsDir]
+}
+
+// linkSecret makes dest a symlink to the secret name in the secretsDataDir
+// directory of secretsDir, whose directory on the host is linkDir.  It
+// returns false if dest is not below secretsDir.
+func linkSecret(linkDir, secretsDir, dest, name string) (bool, error) {
+	rel, err := filepath.Rel(secretsDir, dest)
+	if err != nil || !filepath.IsLocal(rel) || rel == secretsDataDir || strings.HasPrefix(rel, secretsDataDir+string(filepath.Separator)) {
+		return false, nil
+	}
+	link := filepath.Join(linkDir, rel)
+	if err := os.MkdirAll(filepath.Dir(link), 0o755); err != nil {
+		return false, err
+	}
+	target, err := filepath.Rel(filepath.Dir(link), filepath.Join(linkDir, secretsDataDir, name))
+	if err != nil {
+		return false, err
+	}
+	if err := replaceSymlink(target, link); err != nil {
+		return false, fmt.Errorf("linking secret %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// secretFileMounted returns whether the file of the secret is bind mounted
+// into the container, so the container sees new data only once restarted.
+func (c *Container) secretFileMounted(name string) bool {
+	src := filepath.Join(c.config.SecretsPath, name)
+	for _, mounted := range c.state.BindMounts {
+		if mounted == src {
+			return true
+		}
+	}
+	return false
+}
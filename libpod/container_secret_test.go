@@ -0,0 +1,81 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/common/pkg/secrets"
+)
+
+func TestWriteSecretFile(t *testing.T) {
+	c := &Container{config: &ContainerConfig{}}
+	c.config.SecretsPath = t.TempDir()
+	secret := &ContainerSecret{
+		Secret: &secrets.Secret{Name: "secret"},
+		UID:    uint32(os.Getuid()),
+		GID:    uint32(os.Getgid()),
+		Mode:   0o400,
+	}
+	path := filepath.Join(c.config.SecretsPath, "secret")
+
+	require.NoError(t, c.writeSecretFile(secret, []byte("a long secret")))
+	reader, err := os.Open(path)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	require.NoError(t, c.writeSecretFile(secret, []byte("short")))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "short", string(content))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o400), info.Mode())
+
+	// The file is replaced, open files keep the old data.
+	old := make([]byte, 64)
+	n, err := reader.Read(old)
+	require.NoError(t, err)
+	assert.Equal(t, "a long secret", string(old[:n]))
+
+	entries, err := os.ReadDir(c.config.SecretsPath)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLinkSecret(t *testing.T) {
+	linkDir := t.TempDir()
+	// A mount point of a secret mounted as file by an older version.
+	require.NoError(t, os.WriteFile(filepath.Join(linkDir, "foo"), nil, 0o644))
+
+	tests := []struct {
+		dest   string
+		linked bool
+		target string
+	}{
+		{dest: "/run/secrets/foo", linked: true, target: "..data/foo"},
+		{dest: "/run/secrets/sub/dir/foo", linked: true, target: "../../..data/foo"},
+		{dest: "/etc/foo"},
+		{dest: "/run/secrets/..data"},
+		{dest: "/run/secrets/..data/foo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dest, func(t *testing.T) {
+			linked, err := linkSecret(linkDir, "/run/secrets", tt.dest, "foo")
+			require.NoError(t, err)
+			assert.Equal(t, tt.linked, linked)
+			if !tt.linked {
+				return
+			}
+			rel, err := filepath.Rel("/run/secrets", tt.dest)
+			require.NoError(t, err)
+			target, err := os.Readlink(filepath.Join(linkDir, rel))
+			require.NoError(t, err)
+			assert.Equal(t, tt.target, target)
+		})
+	}
+}
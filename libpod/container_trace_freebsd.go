@@ -0,0 +1,15 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+
+	"github.com/containers/podman/v6/pkg/trace"
+)
+
+// Trace is not supported on FreeBSD.
+func (c *Container) Trace(_ context.Context, _ chan<- trace.Event) ([]string, error) {
+	return nil, errors.New("tracing containers is not supported on FreeBSD")
+}
@@ -0,0 +1,48 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/trace"
+)
+
+// Trace traces the file, exec and network activity of the processes of the
+// container with ptrace(2) until the container stops or the context is
+// cancelled.  Processes joining the cgroup of the container later, like exec
+// sessions, are traced as well.  The events are sent to the channel, which is
+// not closed.  It returns the names of the system calls used by the processes.
+func (c *Container) Trace(ctx context.Context, events chan<- trace.Event) ([]string, error) {
+	if c.config.NoCgroups {
+		return nil, fmt.Errorf("cannot trace container %s as it did not create a cgroup: %w", c.ID(), define.ErrNoCgroups)
+	}
+
+	cgroupPath, err := func() (string, error) {
+		if !c.batched {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			if err := c.syncContainer(); err != nil {
+				return "", err
+			}
+		}
+		if c.state.State != define.ContainerStateRunning {
+			return "", fmt.Errorf("can only trace running containers, %s is %s: %w", c.ID(), c.state.State, define.ErrCtrStateInvalid)
+		}
+		return c.cGroupPath()
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	// The container is not locked while tracing, the cgroup is read again
+	// to find new processes.
+	return trace.Trace(ctx, trace.Options{
+		Pids: func() ([]int, error) {
+			return cgroupPids(cgroupPath)
+		},
+		Events: events,
+	})
+}
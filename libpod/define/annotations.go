@@ -152,6 +152,12 @@ const (
 	// of the container
 	UserNsAnnotation = "io.podman.annotations.userns"
 
+	// NetworkOptAnnotation is used by kube play when playing a kube yaml to
+	// specify the bandwidth limits of the network attachments of the pod.
+	// It is expected to be a semicolon-separated list of network options
+	// as accepted by --network-opt.
+	NetworkOptAnnotation = "io.podman.annotations.network-opt"
+
 	// UlimitAnnotation is used by kube play when playing a kube yaml to specify the ulimits
 	// of the container
 	UlimitAnnotation = "io.podman.annotations.ulimit"
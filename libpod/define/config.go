@@ -65,6 +65,8 @@ type AttachStreams struct {
 	// AttachInput is whether to attach to STDIN
 	// If false, stdout will not be attached
 	AttachInput bool
+	// Record is whether to record the session to an asciicast file
+	Record bool
 }
 
 // JournaldLogging is the string conmon expects to specify journald logging
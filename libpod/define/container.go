@@ -2,6 +2,7 @@ package define
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -67,6 +68,38 @@ const (
 	K8sKindJob = "job"
 )
 
+// NetworkRateLimit limits the bandwidth of a network attachment of a
+// container. Rates are in bits per second, 0 means no limit.
+type NetworkRateLimit struct {
+	// IngressRate limits the traffic received by the container.
+	IngressRate uint64 `json:"ingressRate,omitempty"`
+	// EgressRate limits the traffic sent by the container.
+	EgressRate uint64 `json:"egressRate,omitempty"`
+}
+
+const (
+	// MinNetworkRate is the lowest rate limit in bits per second, below it
+	// the token bucket of the egress limit cannot be represented.
+	MinNetworkRate = 8000
+	// MaxIngressNetworkRate is the highest ingress rate limit in bits per
+	// second, the kernel policer takes the rate in bytes as 32 bit value.
+	MaxIngressNetworkRate = 8 * math.MaxUint32
+)
+
+// ValidateNetworkRateLimit checks that the rates of limit can be applied.
+func ValidateNetworkRateLimit(limit NetworkRateLimit) error {
+	if limit.IngressRate > MaxIngressNetworkRate {
+		return fmt.Errorf("ingress rate %dbit exceeds the maximum of %dbit: %w", limit.IngressRate, uint64(MaxIngressNetworkRate), ErrInvalidArg)
+	}
+	if limit.IngressRate != 0 && limit.IngressRate < MinNetworkRate {
+		return fmt.Errorf("ingress rate %dbit is below the minimum of %dbit: %w", limit.IngressRate, MinNetworkRate, ErrInvalidArg)
+	}
+	if limit.EgressRate != 0 && limit.EgressRate < MinNetworkRate {
+		return fmt.Errorf("egress rate %dbit is below the minimum of %dbit: %w", limit.EgressRate, MinNetworkRate, ErrInvalidArg)
+	}
+	return nil
+}
+
 type WeightDevice struct {
 	Path   string
 	Weight uint16
@@ -668,6 +668,10 @@ type InspectContainerHostConfig struct {
 	// IntelRdtClosID defines the Intel RDT CAT Class Of Service (COS) that
 	// all processes of the container should run in.
 	IntelRdtClosID string `json:"IntelRdtClosID,omitempty"`
+	// NetworkRateLimits are the bandwidth limits of the network
+	// attachments of the container, by network name. The limit without
+	// network name applies to all attachments.
+	NetworkRateLimits map[string]NetworkRateLimit `json:"NetworkRateLimits,omitempty"`
 }
 
 // Address represents an IP address.
@@ -160,3 +160,37 @@ type ContainerNetworkStats struct {
 	TxErrors  uint64
 	TxPackets uint64
 }
+
+// ContainerProcessStats contains the resource usage of a process running in a
+// container.
+type ContainerProcessStats struct {
+	// PID is the PID of the process on the host.
+	PID int
+	// PPID is the PID of the parent process on the host.
+	PPID int
+	// Command is the name of the executable of the process.
+	Command string
+	// State is the state of the process as shown by ps(1), e.g. R or S.
+	State string
+	// CPU is the CPU usage in percent since the previous sample, or since
+	// the start of the process for the first sample.
+	CPU float64
+	// CPUNano is the total CPU time used by the process.
+	CPUNano uint64
+	// RSS is the resident set size in bytes.
+	RSS uint64
+	// ReadBytes and WriteBytes are the bytes read from and written to
+	// storage by the process.  They are zero when the I/O accounting of
+	// the process cannot be read.
+	ReadBytes  uint64
+	WriteBytes uint64
+	// ReadRate and WriteRate are the bytes per second read and written
+	// since the previous sample.
+	ReadRate  float64
+	WriteRate float64
+	// FDs is the number of open file descriptors, or -1 when they cannot
+	// be counted.
+	FDs int
+	// SystemNano is the time of the sample.
+	SystemNano uint64
+}
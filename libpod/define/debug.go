@@ -0,0 +1,5 @@
+package define
+
+// DebugTargetLabel denotes the container label key recording the ID of the
+// container a debug container was started for.
+const DebugTargetLabel = "io.podman.debug.target"
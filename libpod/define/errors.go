@@ -25,6 +25,10 @@ var (
 	// not exist.
 	ErrNoSuchExecSession = errors.New("no such exec session")
 
+	// ErrNoSuchSessionRecording indicates that the requested session
+	// recording does not exist.
+	ErrNoSuchSessionRecording = errors.New("no such session recording")
+
 	// ErrNoSuchExitCode indicates that the requested container exit code
 	// does not exist.
 	ErrNoSuchExitCode = errors.New("no such exit code")
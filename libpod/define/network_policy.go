@@ -0,0 +1,91 @@
+package define
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+const (
+	// NetworkPolicyTypeIngress isolates the selected containers for
+	// incoming connections.
+	NetworkPolicyTypeIngress = "Ingress"
+	// NetworkPolicyTypeEgress isolates the selected containers for
+	// outgoing connections.
+	NetworkPolicyTypeEgress = "Egress"
+)
+
+var (
+	// ErrNoSuchNetworkPolicy indicates the requested network policy does
+	// not exist.
+	ErrNoSuchNetworkPolicy = errors.New("no such network policy")
+	// ErrNetworkPolicyExists indicates a network policy with the same name
+	// already exists.
+	ErrNetworkPolicyExists = errors.New("network policy already exists")
+)
+
+// NetworkPolicy restricts the connections of the containers and pods it
+// selects on a set of networks, like a Kubernetes NetworkPolicy restricts
+// them in a namespace. A container is isolated for a direction once any
+// policy selecting it lists that direction in PolicyTypes, it may then only
+// make or accept the connections allowed by a rule of those policies.
+type NetworkPolicy struct {
+	// Name of the policy.
+	Name string `json:"name"`
+	// Networks the policy applies to. Only containers attached to them
+	// are selected and only their addresses on them are matched by
+	// selector peers.
+	Networks []string `json:"networks"`
+	// PodSelector selects the containers the policy applies to by the
+	// labels of their pod, or their own labels when they are not in a pod.
+	// An empty selector selects all containers of the networks.
+	PodSelector map[string]string `json:"podSelector,omitempty"`
+	// PolicyTypes are the directions the selected containers are isolated
+	// for, NetworkPolicyTypeIngress and NetworkPolicyTypeEgress.
+	PolicyTypes []string `json:"policyTypes"`
+	// Ingress rules allow incoming connections.
+	Ingress []NetworkPolicyRule `json:"ingress,omitempty"`
+	// Egress rules allow outgoing connections.
+	Egress []NetworkPolicyRule `json:"egress,omitempty"`
+	// Labels of the policy.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Created is the time the policy was created.
+	Created time.Time `json:"created"`
+}
+
+// NetworkPolicyRule allows connections from or to any of its peers on any of
+// its ports. A rule without peers matches all peers and a rule without ports
+// matches all ports.
+type NetworkPolicyRule struct {
+	Peers []NetworkPolicyPeer `json:"peers,omitempty"`
+	Ports []NetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// NetworkPolicyPeer is either a set of containers selected by label or an
+// address range. Exactly one of PodSelector and CIDR is set.
+type NetworkPolicyPeer struct {
+	// PodSelector selects the containers on the networks of the policy by
+	// the labels of their pod, or their own labels when they are not in a
+	// pod. An empty, non-nil selector selects all containers.
+	PodSelector map[string]string `json:"podSelector"`
+	// CIDR is an IPv4 or IPv6 address range.
+	CIDR string `json:"cidr,omitempty"`
+	// Except are ranges within CIDR that are not matched.
+	Except []string `json:"except,omitempty"`
+}
+
+// NetworkPolicyPort is a port or range of ports.
+type NetworkPolicyPort struct {
+	// Protocol is tcp, udp or sctp, tcp if empty.
+	Protocol string `json:"protocol,omitempty"`
+	// Port is the port, all ports of the protocol if 0.
+	Port uint16 `json:"port,omitempty"`
+	// EndPort is the last port of the range starting at Port, if set.
+	EndPort uint16 `json:"endPort,omitempty"`
+}
+
+// HasPolicyType returns whether the policy isolates the selected containers
+// for the given direction.
+func (p *NetworkPolicy) HasPolicyType(policyType string) bool {
+	return slices.Contains(p.PolicyTypes, policyType)
+}
@@ -0,0 +1,15 @@
+package define
+
+// SecretHistoryLabel denotes the secret label key recording the name of the
+// secret a secret is a previous version of.  Previous versions are named
+// NAME@vVERSION.
+const SecretHistoryLabel = "io.podman.secret.history"
+
+// Metadata keys of secret versions.
+const (
+	// SecretVersionKey is the version of a secret.
+	SecretVersionKey = "version"
+	// SecretChangedAtKey is the time a previous version of a secret was
+	// set.
+	SecretChangedAtKey = "changedAt"
+)
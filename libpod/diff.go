@@ -3,10 +3,15 @@
 package libpod
 
 import (
+	"archive/tar"
+	"errors"
 	"fmt"
+	"io"
+	"path"
 
 	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/libpod/layers"
+	"go.podman.io/storage"
 	"go.podman.io/storage/pkg/archive"
 )
 
@@ -51,6 +56,65 @@ func (r *Runtime) GetDiff(from, to string, diffType define.DiffType) ([]archive.
 	return rchanges, err
 }
 
+// ExportDiff returns the differences between the two images, layers, or
+// containers as an uncompressed layer tarball with whiteouts for deleted
+// paths, as used by OCI images.  Paths Podman creates in every container are
+// not part of the tarball.
+func (r *Runtime) ExportDiff(from, to string, diffType define.DiffType) (io.ReadCloser, error) {
+	toLayer, err := r.getLayerID(to, diffType)
+	if err != nil {
+		return nil, err
+	}
+	fromLayer := ""
+	if from != "" {
+		fromLayer, err = r.getLayerID(from, diffType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	uncompressed := archive.Uncompressed
+	diff, err := r.store.Diff(fromLayer, toLayer, &storage.DiffOptions{Compression: &uncompressed})
+	if err != nil {
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		err := filterInitInodes(diff, writer)
+		if closeErr := diff.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		writer.CloseWithError(err)
+	}()
+	return reader, nil
+}
+
+// filterInitInodes copies the tarball read from r to w, omitting the paths
+// of initInodes.
+func filterInitInodes(r io.Reader, w io.Writer) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		if initInodes[path.Join("/", hdr.Name)] {
+			continue
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
 // GetLayerID gets a full layer id given a full or partial id
 // If the id matches a container or image, the id of the top layer is returned
 // If the id matches a layer, the top layer id is returned
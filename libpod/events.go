@@ -5,6 +5,7 @@ package libpod
 import (
 	"context"
 	"fmt"
+	"maps"
 	"path/filepath"
 
 	"github.com/containers/podman/v6/libpod/define"
@@ -135,6 +136,56 @@ func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
 	}
 }
 
+// newSessionEvent creates a new exec or attach event.  execID is the ID of
+// the exec session and recording the path of the session recording, if set.
+func (c *Container) newSessionEvent(status events.Status, execID, recording string) {
+	e := events.NewEvent(status)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+
+	attributes := make(map[string]string, len(c.Labels())+2)
+	maps.Copy(attributes, c.Labels())
+	if execID != "" {
+		attributes["execID"] = execID
+	}
+	if recording != "" {
+		attributes["recording"] = recording
+	}
+	e.Details = events.Details{
+		PodID:      c.PodID(),
+		Attributes: attributes,
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write %s event: %q", status, err)
+	}
+}
+
+// NewDebugEvent creates a new event for a debug container attached to the
+// container.
+func (c *Container) NewDebugEvent(debugCtr *Container) {
+	e := events.NewEvent(events.Debug)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+
+	attributes := make(map[string]string, len(c.Labels())+2)
+	maps.Copy(attributes, c.Labels())
+	attributes["debugContainerID"] = debugCtr.ID()
+	attributes["debugImage"] = debugCtr.config.RootfsImageName
+	e.Details = events.Details{
+		PodID:      c.PodID(),
+		Attributes: attributes,
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write debug event: %q", err)
+	}
+}
+
 // newNetworkEvent creates a new event based on a network create/remove
 func (r *Runtime) NewNetworkEvent(status events.Status, netName, netID, netDriver string) {
 	e := events.NewEvent(status)
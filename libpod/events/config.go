@@ -46,6 +46,10 @@ type Event struct {
 	HealthFailingStreak int `json:"health_failing_streak,omitempty"`
 	// Error code for certain events involving errors.
 	Error string `json:"error,omitempty"`
+	// Cursor is the position of the event in the event log, set by the
+	// eventer when reading.  Readers resume after an event by reading with
+	// its cursor as SinceCursor.
+	Cursor string `json:"-"`
 
 	Details
 }
@@ -101,6 +105,8 @@ type ReadOptions struct {
 	FromStart bool
 	// Since reads "since" the given time
 	Since string
+	// SinceCursor reads the events after the event with the given cursor
+	SinceCursor string
 	// Stream is follow
 	Stream bool
 	// Until reads "until" the given time
@@ -134,6 +140,9 @@ const (
 	// Secret - event is related to secrets
 	Secret Type = "secret"
 
+	// ArtifactRefresh indicates that the artifacts mounted into a container
+	// were refreshed with a new version.
+	ArtifactRefresh Status = "artifact-refresh"
 	// Attach ...
 	Attach Status = "attach"
 	// AutoUpdate ...
@@ -150,6 +159,8 @@ const (
 	Copy Status = "copy"
 	// Create ...
 	Create Status = "create"
+	// Debug indicates that a debug container was attached to a container.
+	Debug Status = "debug"
 	// Exec ...
 	Exec Status = "exec"
 	// ExecDied indicates that an exec session in a container died.
@@ -136,6 +136,8 @@ func StringToType(name string) (Type, error) {
 // StringToStatus converts a string to an Event Status
 func StringToStatus(name string) (Status, error) {
 	switch name {
+	case ArtifactRefresh.String():
+		return ArtifactRefresh, nil
 	case Attach.String():
 		return Attach, nil
 	case AutoUpdate.String():
@@ -150,6 +152,8 @@ func StringToStatus(name string) (Status, error) {
 		return Commit, nil
 	case Create.String():
 		return Create, nil
+	case Debug.String():
+		return Debug, nil
 	case Exec.String():
 		return Exec, nil
 	case ExecDied.String():
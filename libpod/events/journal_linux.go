@@ -161,7 +161,7 @@ func (e EventJournalD) Read(ctx context.Context, options ReadOptions) (retErr er
 		return fmt.Errorf("failed to add _UID journal filter for event log: %w", err)
 	}
 
-	if len(options.Since) == 0 && len(options.Until) == 0 && options.Stream {
+	if len(options.Since) == 0 && len(options.Until) == 0 && len(options.SinceCursor) == 0 && options.Stream {
 		if err := j.SeekTail(); err != nil {
 			return fmt.Errorf("failed to seek end of journal: %w", err)
 		}
@@ -171,6 +171,11 @@ func (e EventJournalD) Read(ctx context.Context, options ReadOptions) (retErr er
 		if _, err := j.Previous(); err != nil {
 			return fmt.Errorf("failed to move journal cursor to previous entry: %w", err)
 		}
+	} else if len(options.SinceCursor) > 0 {
+		// the entry of the cursor is read first and skipped below
+		if err := j.SeekCursor(options.SinceCursor); err != nil {
+			return fmt.Errorf("failed to seek event cursor %q: %w", options.SinceCursor, err)
+		}
 	} else if len(options.Since) > 0 {
 		since, err := util.ParseInputTime(options.Since, true)
 		if err != nil {
@@ -199,6 +204,9 @@ func (e EventJournalD) Read(ctx context.Context, options ReadOptions) (retErr er
 			if entry == nil {
 				break
 			}
+			if entry.Cursor == options.SinceCursor {
+				continue
+			}
 
 			newEvent, err := newEventFromJournalEntry(entry)
 			if err != nil {
@@ -236,6 +244,7 @@ func newEventFromJournalEntry(entry *sdjournal.JournalEntry) (*Event, error) {
 	newEvent.Time = eventTime
 	newEvent.Status = eventStatus
 	newEvent.Name = entry.Fields["PODMAN_NAME"]
+	newEvent.Cursor = entry.Cursor
 
 	switch eventType {
 	case Container, Pod:
@@ -11,6 +11,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/containers/podman/v6/pkg/util"
@@ -79,13 +81,50 @@ func writeToFile(s string, f *os.File) error {
 
 func (e EventLogFile) getTail(options ReadOptions) (*tail.Tail, error) {
 	seek := tail.SeekInfo{Offset: 0, Whence: io.SeekEnd}
-	if options.FromStart || !options.Stream {
+	if options.FromStart || !options.Stream || len(options.SinceCursor) > 0 {
 		seek.Whence = 0
 	}
 	stream := options.Stream
 	return tail.TailFile(e.options.LogFilePath, tail.Config{ReOpen: stream, Follow: stream, Location: &seek, Logger: tail.DiscardingLogger, Poll: true})
 }
 
+// logFileBase returns the position of the start of the log file in the event
+// log.  The event log grows across rotations, the begin event of a rotation
+// records where the log file continues in it.  Cursors are positions in the
+// event log, which keeps them valid when a rotation drops the start of the
+// log file.
+func logFileBase(logFilePath string) (base, size int64, err error) {
+	f, err := os.Open(logFilePath)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, 0, err
+	}
+	return rotateBase(line, int64(len(line))), info.Size(), nil
+}
+
+// rotateBase returns the position of the start of the log file in the event
+// log if line is the begin event of a rotation ending at lineEnd.  Log files
+// not starting with one, or rotated by older versions, start at 0.
+func rotateBase(line string, lineEnd int64) int64 {
+	event, err := newEventFromJSONString(strings.TrimSuffix(line, "\n"))
+	if err != nil || event.Status != Rotate || event.Details.Attributes[rotateEventAttribute] != rotateEventBegin {
+		return 0
+	}
+	offset, err := strconv.ParseInt(event.Details.Attributes[rotateEventOffsetAttribute], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return offset - lineEnd
+}
+
 func (e EventLogFile) readRotateEvent(event *Event) (begin bool, end bool, err error) {
 	if event.Status != Rotate {
 		return begin, end, err
@@ -113,6 +152,13 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 	if err != nil {
 		return fmt.Errorf("failed to parse event filters: %w", err)
 	}
+	var sinceCursor int64
+	if len(options.SinceCursor) > 0 {
+		sinceCursor, err = strconv.ParseInt(options.SinceCursor, 10, 64)
+		if err != nil || sinceCursor < 0 {
+			return fmt.Errorf("invalid event cursor %q", options.SinceCursor)
+		}
+	}
 	t, err := e.getTail(options)
 	if err != nil {
 		return err
@@ -134,6 +180,9 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 	// Get the time *before* starting to read.  Comparing the timestamps
 	// with events avoids returning events more than once after a log-file
 	// rotation.
+	// The position of the start of the log file in the event log is needed
+	// for the cursors of the events when not reading from the start.
+	var base int64
 	readTime, err := func() (time.Time, error) {
 		// We need to lock events file
 		lock, err := lockfile.GetLockFile(e.options.LogFilePath + ".lock")
@@ -142,6 +191,16 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 		}
 		lock.Lock()
 		defer lock.Unlock()
+		var size int64
+		base, size, err = logFileBase(e.options.LogFilePath)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if sinceCursor > base+size {
+			// The cursor is past the end of the event log, which
+			// was recreated since, e.g. after a reboot.
+			sinceCursor = 0
+		}
 		return time.Now(), nil
 	}()
 	if err != nil {
@@ -182,6 +241,11 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 					options.EventChannel <- ReadResult{Error: err}
 					continue
 				}
+				if begin {
+					// the log file was rotated and is read from
+					// its start again
+					base = rotateBase(line.Text, line.SeekInfo.Offset)
+				}
 				if begin && event.Time.After(readTime) {
 					// If the rotation event happened _after_ we
 					// started reading, we need to ignore/skip
@@ -201,6 +265,12 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 			if skipRotate {
 				continue
 			}
+			// The cursor is the end of the event in the event log.
+			cursor := base + line.SeekInfo.Offset
+			if cursor <= sinceCursor {
+				continue
+			}
+			event.Cursor = strconv.FormatInt(cursor, 10)
 			if applyFilters(event, filterMap) {
 				options.EventChannel <- ReadResult{Event: event}
 			}
@@ -218,15 +288,19 @@ const (
 	rotateEventAttribute = "io.podman.event.rotate"
 	rotateEventBegin     = "begin"
 	rotateEventEnd       = "end"
+	// rotateEventOffsetAttribute is the position in the event log of the
+	// end of the begin event of a rotation.
+	rotateEventOffsetAttribute = "io.podman.event.rotate.offset"
 )
 
-func writeRotateEvent(f *os.File, logFilePath string, begin bool) error {
+func writeRotateEvent(f *os.File, logFilePath string, begin bool, offset int64) error {
 	rEvent := NewEvent(Rotate)
 	rEvent.Type = System
 	rEvent.Name = logFilePath
 	rEvent.Attributes = make(map[string]string)
 	if begin {
 		rEvent.Attributes[rotateEventAttribute] = rotateEventBegin
+		rEvent.Attributes[rotateEventOffsetAttribute] = strconv.FormatInt(offset, 10)
 	} else {
 		rEvent.Attributes[rotateEventAttribute] = rotateEventEnd
 	}
@@ -298,24 +372,31 @@ func truncate(filePath string) error {
 	}
 	defer tmp.Close()
 
+	reader := bufio.NewReader(orig)
+	first, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	base := rotateBase(first, int64(len(first)))
+
 	// Jump directly to the threshold, drop the first line and copy the remainder
 	if _, err := orig.Seek(threshold, 0); err != nil {
 		return err
 	}
-	reader := bufio.NewReader(orig)
-	if _, err := reader.ReadString('\n'); err != nil {
-		if !errors.Is(err, io.EOF) {
-			return err
-		}
+	reader.Reset(orig)
+	dropped, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
 	}
 
-	if err := writeRotateEvent(tmp, filePath, true); err != nil {
+	// The remainder continues in the event log after the begin event.
+	if err := writeRotateEvent(tmp, filePath, true, base+threshold+int64(len(dropped))); err != nil {
 		return fmt.Errorf("writing rotation event begin marker: %w", err)
 	}
 	if _, err := reader.WriteTo(tmp); err != nil {
 		return fmt.Errorf("writing truncated contents: %w", err)
 	}
-	if err := writeRotateEvent(tmp, filePath, false); err != nil {
+	if err := writeRotateEvent(tmp, filePath, false, 0); err != nil {
 		return fmt.Errorf("writing rotation event end marker: %w", err)
 	}
 
@@ -3,9 +3,14 @@
 package events
 
 import (
+	"context"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -99,7 +104,7 @@ func TestTruncationOutput(t *testing.T) {
 	require.NotEqual(t, beforeTruncation, afterTruncation)
 	split := strings.Split(string(afterTruncation), "\n")
 	require.Len(t, split, 8) // 2 events + 5 rotated lines + last new line
-	require.Contains(t, split[0], "\"Attributes\":{\"io.podman.event.rotate\":\"begin\"}")
+	require.Contains(t, split[0], "\"Attributes\":{\"io.podman.event.rotate\":\"begin\",\"io.podman.event.rotate.offset\":\"12\"}")
 	require.Equal(t, split[1:6], []string{"6", "7", "8", "9", "10"})
 	require.Contains(t, split[6], "\"Attributes\":{\"io.podman.event.rotate\":\"end\"}")
 	require.Contains(t, split[7], "")
@@ -137,3 +142,89 @@ func TestRenameLog(t *testing.T) {
 	require.NoError(t, os.Remove(target.Name()))
 	require.Equal(t, beforeRename, afterRename)
 }
+
+func TestLogFileCursor(t *testing.T) {
+	eventer, err := newLogFileEventer(EventerOptions{
+		LogFilePath:    filepath.Join(t.TempDir(), "events.log"),
+		LogFileMaxSize: 2000,
+	})
+	require.NoError(t, err)
+
+	write := func(first, last int) {
+		for i := first; i <= last; i++ {
+			e := NewEvent(Create)
+			e.Type = Container
+			e.Name = strconv.Itoa(i)
+			require.NoError(t, eventer.Write(e))
+		}
+	}
+	read := func(sinceCursor string) (names, cursors []string) {
+		eventChannel := make(chan ReadResult)
+		err := eventer.Read(context.Background(), ReadOptions{
+			EventChannel: eventChannel,
+			Filters:      []string{"type=container"},
+			SinceCursor:  sinceCursor,
+		})
+		require.NoError(t, err)
+		for result := range eventChannel {
+			require.NoError(t, result.Error)
+			names = append(names, result.Event.Name)
+			cursors = append(cursors, result.Event.Cursor)
+		}
+		return names, cursors
+	}
+
+	write(1, 30)
+	names, cursors := read("")
+	require.NotContains(t, names, "1", "log file must have been rotated")
+	require.Equal(t, "30", names[len(names)-1])
+	var positions []int
+	for _, cursor := range cursors {
+		position, err := strconv.Atoi(cursor)
+		require.NoError(t, err)
+		positions = append(positions, position)
+	}
+	require.IsIncreasing(t, positions)
+
+	// rotating the log file again drops the first events, the cursors of
+	// the remaining ones must not change
+	rotatedNames, rotatedCursors := names, cursors
+	for i := 31; rotatedNames[0] == names[0]; i++ {
+		write(i, i)
+		rotatedNames, rotatedCursors = read("")
+	}
+	require.Contains(t, names, rotatedNames[0])
+	offset := slices.Index(names, rotatedNames[0])
+	require.Equal(t, cursors[offset:], rotatedCursors[:len(cursors)-offset])
+
+	since, _ := read(rotatedCursors[2])
+	require.Equal(t, rotatedNames[3:], since)
+	since, _ = read(rotatedCursors[len(rotatedCursors)-1])
+	require.Empty(t, since)
+
+	// streaming starts at the end of the rotated log file
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := make(chan ReadResult)
+	require.NoError(t, eventer.Read(ctx, ReadOptions{EventChannel: stream, Filters: []string{"type=container"}, Stream: true}))
+	// the log file may be tailed only after the first events are written
+	var result ReadResult
+	for i := 100; result.Event == nil; i++ {
+		write(i, i)
+		select {
+		case result = <-stream:
+			require.NoError(t, result.Error)
+		case <-time.After(time.Second):
+		}
+	}
+	cancel()
+	names, cursors = read("")
+	require.Equal(t, cursors[len(cursors)-1], result.Event.Cursor)
+
+	// a cursor past the end is from a log file which was recreated
+	since, _ = read("1000000")
+	require.Equal(t, names, since)
+
+	eventChannel := make(chan ReadResult)
+	require.Error(t, eventer.Read(context.Background(), ReadOptions{EventChannel: eventChannel, SinceCursor: "invalid"}))
+}
@@ -26,9 +26,24 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 
 	hcUnitName := c.hcUnitName(isStartup, false)
 
+	if err := runTransientTimer(hcUnitName, []string{fmt.Sprintf("--on-unit-inactive=%s", interval)}, "healthcheck", "run", c.ID()); err != nil {
+		return err
+	}
+
+	c.state.HCUnitName = hcUnitName
+	if err := c.save(); err != nil {
+		return fmt.Errorf("saving container %s healthcheck unit name: %w", c.ID(), err)
+	}
+
+	return nil
+}
+
+// runTransientTimer creates a systemd timer which runs podman with the given
+// arguments in the transient service of the same name.
+func runTransientTimer(unitName string, timerArgs []string, podmanArgs ...string) error {
 	podman, err := os.Executable()
 	if err != nil {
-		return fmt.Errorf("failed to get path for podman for a health check timer: %w", err)
+		return fmt.Errorf("failed to get path for podman for a systemd timer: %w", err)
 	}
 
 	cmd := []string{"--property", "LogLevelMax=notice"}
@@ -40,18 +55,20 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 		cmd = append(cmd, "--setenv=PATH="+path)
 	}
 
+	cmd = append(cmd, "--unit", unitName)
+	cmd = append(cmd, timerArgs...)
 	// StartLimitIntervalSec=0 so we don't hit the restart limit
-	cmd = append(cmd, "--unit", hcUnitName, fmt.Sprintf("--on-unit-inactive=%s", interval), "--timer-property=AccuracySec=1s", "--property=StartLimitIntervalSec=0", podman)
+	cmd = append(cmd, "--timer-property=AccuracySec=1s", "--property=StartLimitIntervalSec=0", podman)
 
 	if logrus.IsLevelEnabled(logrus.DebugLevel) {
 		cmd = append(cmd, "--log-level=debug", "--syslog")
 	}
 
-	cmd = append(cmd, "healthcheck", "run", c.ID())
+	cmd = append(cmd, podmanArgs...)
 
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
-		return fmt.Errorf("unable to get systemd connection to add healthchecks: %w", err)
+		return fmt.Errorf("unable to get systemd connection to add timer: %w", err)
 	}
 	conn.Close()
 	logrus.Debugf("creating systemd-transient files: %s %s", "systemd-run", cmd)
@@ -63,12 +80,6 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 		}
 		return fmt.Errorf("failed to execute systemd-run: %w", err)
 	}
-
-	c.state.HCUnitName = hcUnitName
-	if err := c.save(); err != nil {
-		return fmt.Errorf("saving container %s healthcheck unit name: %w", c.ID(), err)
-	}
-
 	return nil
 }
 
@@ -118,9 +129,18 @@ func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool, un
 	if c.disableHealthCheckSystemd(isStartup) {
 		return nil
 	}
+	if unitName == "" {
+		unitName = c.hcUnitName(isStartup, true)
+	}
+	return stopTransientUnits(ctx, unitName, "health-check")
+}
+
+// stopTransientUnits stops and removes the transient timer and service of
+// the unit.  The kind of the unit is used in error messages.
+func stopTransientUnits(ctx context.Context, unitName, kind string) error {
 	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
-		return fmt.Errorf("unable to get systemd connection to remove healthchecks: %w", err)
+		return fmt.Errorf("unable to get systemd connection to remove %s timer: %w", kind, err)
 	}
 	defer conn.Close()
 
@@ -128,29 +148,26 @@ func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool, un
 	// clean up as much as possible.
 	stopErrors := []error{}
 
-	if unitName == "" {
-		unitName = c.hcUnitName(isStartup, true)
-	}
 	// Stop the timer before the service to make sure the timer does not
 	// fire after the service is stopped.
 	timerChan := make(chan string)
 	timerFile := fmt.Sprintf("%s.timer", unitName)
 	if _, err := conn.StopUnitContext(ctx, timerFile, "ignore-dependencies", timerChan); err != nil {
 		if !strings.HasSuffix(err.Error(), ".timer not loaded.") {
-			stopErrors = append(stopErrors, fmt.Errorf("removing health-check timer %q: %w", timerFile, err))
+			stopErrors = append(stopErrors, fmt.Errorf("removing %s timer %q: %w", kind, timerFile, err))
 		}
 	} else if err := systemdOpSuccessful(timerChan); err != nil {
-		stopErrors = append(stopErrors, fmt.Errorf("stopping systemd health-check timer %q: %w", timerFile, err))
+		stopErrors = append(stopErrors, fmt.Errorf("stopping systemd %s timer %q: %w", kind, timerFile, err))
 	}
 
 	serviceChan := make(chan string)
 	serviceFile := fmt.Sprintf("%s.service", unitName)
 	if _, err := conn.StopUnitContext(ctx, serviceFile, "ignore-dependencies", serviceChan); err != nil {
 		if !strings.HasSuffix(err.Error(), ".service not loaded.") {
-			stopErrors = append(stopErrors, fmt.Errorf("removing health-check service %q: %w", serviceFile, err))
+			stopErrors = append(stopErrors, fmt.Errorf("removing %s service %q: %w", kind, serviceFile, err))
 		}
 	} else if err := systemdOpSuccessful(serviceChan); err != nil {
-		stopErrors = append(stopErrors, fmt.Errorf("stopping systemd health-check service %q: %w", serviceFile, err))
+		stopErrors = append(stopErrors, fmt.Errorf("stopping systemd %s service %q: %w", kind, serviceFile, err))
 	}
 	// Reset the service after stopping it to make sure it's being removed, systemd keep failed transient services
 	// around in its state. We do not care about the error and we need to ensure to reset the state so we do not
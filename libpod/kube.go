@@ -593,6 +593,9 @@ func (p *Pod) podWithContainers(ctx context.Context, containers []*Container, po
 			if v, found := ctr.config.Spec.Annotations[define.UserNsAnnotation]; found {
 				podAnnotations[define.UserNsAnnotation] = v
 			}
+			if len(ctr.config.NetworkRateLimits) > 0 {
+				podAnnotations[define.NetworkOptAnnotation] = networkRateLimitsToAnnotation(ctr.config.NetworkRateLimits)
+			}
 			_, _, infraDNS, _, err := containerToV1Container(ctx, ctr, getService)
 			if err != nil {
 				return nil, err
@@ -1471,3 +1474,18 @@ func getAutoUpdateAnnotations(ctrName string, ctrLabels map[string]string) map[s
 
 	return annotations
 }
+
+// networkRateLimitsToAnnotation formats network rate limits as the value of
+// the network-opt annotation understood by kube play.
+func networkRateLimitsToAnnotation(limits map[string]define.NetworkRateLimit) string {
+	opts := make([]string, 0, len(limits))
+	for _, network := range slices.Sorted(maps.Keys(limits)) {
+		limit := limits[network]
+		opt := fmt.Sprintf("ingress-rate=%dbit,egress-rate=%dbit", limit.IngressRate, limit.EgressRate)
+		if network != "" {
+			opt = network + ":" + opt
+		}
+		opts = append(opts, opt)
+	}
+	return strings.Join(opts, ";")
+}
@@ -0,0 +1,512 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libnetwork/types"
+	"go.podman.io/storage/pkg/lockfile"
+)
+
+const (
+	// networkPolicyTable is the name of the nftables tables holding the
+	// rules of the network policies, in the network namespace of the
+	// bridges of the networks.
+	networkPolicyTable = "podman_network_policy"
+)
+
+// policyEndpoint is a container, or the infra container of a pod, attached to
+// networks, which network policies select and match as peer.
+type policyEndpoint struct {
+	id     string
+	labels map[string]string
+	status map[string]types.StatusBlock
+}
+
+// networkPoliciesLock serializes the changes of the network policies and the
+// updates of their rules.
+func (r *Runtime) networkPoliciesLock() (*lockfile.LockFile, error) {
+	return lockfile.GetLockFile(filepath.Join(r.config.Engine.StaticDir, "network-policies.lock"))
+}
+
+// validateNetworkPolicy checks the policy and that its networks exist.
+func (r *Runtime) validateNetworkPolicy(policy *define.NetworkPolicy) error {
+	if !define.NameRegex.MatchString(policy.Name) {
+		return fmt.Errorf("network policy name %q: %w", policy.Name, define.RegexError)
+	}
+	if len(policy.Networks) == 0 {
+		return errors.New("network policy must apply to at least one network")
+	}
+	for i, name := range policy.Networks {
+		network, err := r.network.NetworkInspect(name)
+		if err != nil {
+			return err
+		}
+		if network.Driver != types.BridgeNetworkDriver {
+			return fmt.Errorf("network policies are only supported on bridge networks, network %s uses the %s driver", network.Name, network.Driver)
+		}
+		// the network status of containers uses the names of networks
+		policy.Networks[i] = network.Name
+	}
+	for _, policyType := range policy.PolicyTypes {
+		if policyType != define.NetworkPolicyTypeIngress && policyType != define.NetworkPolicyTypeEgress {
+			return fmt.Errorf("invalid network policy type %q, must be %s or %s", policyType, define.NetworkPolicyTypeIngress, define.NetworkPolicyTypeEgress)
+		}
+	}
+	for _, rule := range slices.Concat(policy.Ingress, policy.Egress) {
+		if err := validateNetworkPolicyRule(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateNetworkPolicyRule(rule define.NetworkPolicyRule) error {
+	for _, peer := range rule.Peers {
+		if peer.CIDR == "" {
+			if peer.PodSelector == nil {
+				return errors.New("network policy peer needs a pod selector or a CIDR")
+			}
+			if len(peer.Except) > 0 {
+				return errors.New("network policy peer excepts address ranges without CIDR")
+			}
+			continue
+		}
+		if peer.PodSelector != nil {
+			return errors.New("network policy peer may not have both a pod selector and a CIDR")
+		}
+		_, cidr, err := net.ParseCIDR(peer.CIDR)
+		if err != nil {
+			return fmt.Errorf("network policy peer: %w", err)
+		}
+		for _, except := range peer.Except {
+			ip, _, err := net.ParseCIDR(except)
+			if err != nil {
+				return fmt.Errorf("network policy peer: %w", err)
+			}
+			if !cidr.Contains(ip) {
+				return fmt.Errorf("network policy peer: except %s is not within %s", except, peer.CIDR)
+			}
+		}
+	}
+	for _, port := range rule.Ports {
+		switch strings.ToLower(port.Protocol) {
+		case "", "tcp", "udp", "sctp":
+		default:
+			return fmt.Errorf("invalid network policy protocol %q, must be tcp, udp or sctp", port.Protocol)
+		}
+		if port.EndPort != 0 && (port.Port == 0 || port.EndPort < port.Port) {
+			return fmt.Errorf("invalid network policy port range %d-%d", port.Port, port.EndPort)
+		}
+	}
+	return nil
+}
+
+// CreateNetworkPolicy stores a network policy and enforces it on the running
+// containers of its networks. An existing policy of the same name is
+// replaced if replace is set.
+func (r *Runtime) CreateNetworkPolicy(policy define.NetworkPolicy, replace bool) (*define.NetworkPolicy, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	if err := networkPoliciesSupported(); err != nil {
+		return nil, err
+	}
+	if err := r.validateNetworkPolicy(&policy); err != nil {
+		return nil, err
+	}
+	policy.Created = time.Now()
+
+	lock, err := r.networkPoliciesLock()
+	if err != nil {
+		return nil, err
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := r.state.AddNetworkPolicy(&policy, replace); err != nil {
+		return nil, err
+	}
+	policies, err := r.state.AllNetworkPolicies()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.enforceNetworkPolicies(policies, "", nil, nil); err != nil {
+		return nil, fmt.Errorf("enforcing network policy %s: %w", policy.Name, err)
+	}
+	return &policy, nil
+}
+
+// RemoveNetworkPolicy removes a network policy and lifts the isolation of
+// the containers it selected unless other policies select them.
+func (r *Runtime) RemoveNetworkPolicy(name string) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	lock, err := r.networkPoliciesLock()
+	if err != nil {
+		return err
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := r.state.RemoveNetworkPolicy(name); err != nil {
+		return err
+	}
+	policies, err := r.state.AllNetworkPolicies()
+	if err != nil {
+		return err
+	}
+	if err := r.enforceNetworkPolicies(policies, "", nil, nil); err != nil {
+		return fmt.Errorf("lifting network policy %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetNetworkPolicy returns the network policy with the given name.
+func (r *Runtime) GetNetworkPolicy(name string) (*define.NetworkPolicy, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.NetworkPolicy(name)
+}
+
+// GetNetworkPolicies returns all network policies sorted by name.
+func (r *Runtime) GetNetworkPolicies() ([]define.NetworkPolicy, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	return r.state.AllNetworkPolicies()
+}
+
+// updateNetworkPolicies enforces the network policies after the container
+// with the given ID was attached to the networks in attached, or before it is
+// detached from the networks in detached. Only containers attached to
+// networks with policies have rules to update.
+func (r *Runtime) updateNetworkPolicies(id string, attached map[string]types.StatusBlock, detached []string) error {
+	policies, err := r.state.AllNetworkPolicies()
+	if err != nil {
+		return err
+	}
+	networks := slices.Concat(slices.Collect(maps.Keys(attached)), detached)
+	if !slices.ContainsFunc(networks, func(network string) bool {
+		return slices.ContainsFunc(policies, func(p define.NetworkPolicy) bool {
+			return slices.Contains(p.Networks, network)
+		})
+	}) {
+		return nil
+	}
+
+	lock, err := r.networkPoliciesLock()
+	if err != nil {
+		return err
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	// the policies may have changed while waiting for the lock
+	if policies, err = r.state.AllNetworkPolicies(); err != nil {
+		return err
+	}
+	return r.enforceNetworkPolicies(policies, id, attached, detached)
+}
+
+// enforceNetworkPolicies replaces the rules of the network policies. The
+// network status of the container with the given ID is updated with the
+// networks in attached and without those in detached, as its state is not
+// saved yet. The rules are applied in the network namespace of the bridges,
+// out of reach of the containers. The caller must hold the network policies
+// lock.
+func (r *Runtime) enforceNetworkPolicies(policies []define.NetworkPolicy, id string, attached map[string]types.StatusBlock, detached []string) error {
+	endpoints, err := r.networkPolicyEndpoints(id, attached, detached)
+	if err != nil {
+		return err
+	}
+	if id == "" && len(endpoints) == 0 {
+		// without containers there are no rules, and for rootless users
+		// no network namespace to remove them from
+		return nil
+	}
+	ruleset := networkPolicyRuleset(policies, endpoints)
+	err = r.network.RunInRootlessNetns(func() error {
+		return applyNetworkPolicyRuleset(ruleset)
+	})
+	if errors.Is(err, types.ErrNotRootlessNetns) {
+		err = applyNetworkPolicyRuleset(ruleset)
+	}
+	if err != nil {
+		return fmt.Errorf("applying network policies: %w", err)
+	}
+	return nil
+}
+
+// networkPolicyEndpoints returns the containers attached to networks. The
+// network status of the container with the given ID is updated with the
+// networks in attached and without those in detached.
+func (r *Runtime) networkPolicyEndpoints(id string, attached map[string]types.StatusBlock, detached []string) ([]*policyEndpoint, error) {
+	ctrs, err := r.state.AllContainers(true)
+	if err != nil {
+		return nil, err
+	}
+	podLabels := make(map[string]map[string]string)
+	var endpoints []*policyEndpoint
+	for _, ctr := range ctrs {
+		status := ctr.state.NetworkStatus
+		if ctr.ID() == id {
+			status = maps.Clone(status)
+			if status == nil {
+				status = make(map[string]types.StatusBlock)
+			}
+			maps.Copy(status, attached)
+			for _, network := range detached {
+				delete(status, network)
+			}
+		} else if ctr.state.NetNS == "" {
+			continue
+		}
+		if len(status) == 0 {
+			continue
+		}
+		endpoints = append(endpoints, &policyEndpoint{
+			id:     ctr.ID(),
+			labels: ctr.networkPolicyLabels(podLabels),
+			status: status,
+		})
+	}
+	return endpoints, nil
+}
+
+// networkPolicyLabels returns the labels network policies select the
+// container by, the labels of its pod or its own labels when it is not in a
+// pod. podLabels caches the labels of pods if not nil.
+func (c *Container) networkPolicyLabels(podLabels map[string]map[string]string) map[string]string {
+	if c.config.Pod == "" {
+		return c.config.Labels
+	}
+	if labels, ok := podLabels[c.config.Pod]; ok {
+		return labels
+	}
+	pod, err := c.runtime.state.Pod(c.config.Pod)
+	if err != nil {
+		logrus.Debugf("Retrieving pod %s of container %s for network policies: %v", c.config.Pod, c.ID(), err)
+		return nil
+	}
+	labels := pod.Labels()
+	if podLabels != nil {
+		podLabels[c.config.Pod] = labels
+	}
+	return labels
+}
+
+// addresses returns the IPv4 and IPv6 addresses of the endpoint on the
+// network.
+func (e *policyEndpoint) addresses(network string) ([]string, []string) {
+	var ipv4, ipv6 []string
+	for _, netInt := range e.status[network].Interfaces {
+		for _, subnet := range netInt.Subnets {
+			if subnet.IPNet.IP.To4() != nil {
+				ipv4 = append(ipv4, subnet.IPNet.IP.String())
+			} else {
+				ipv6 = append(ipv6, subnet.IPNet.IP.String())
+			}
+		}
+	}
+	return ipv4, ipv6
+}
+
+func selectorMatches(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// networkPolicyRuleset returns the nftables tables isolating the endpoints
+// according to the policies selecting them, or an empty string if none does.
+// The bridge table filters the traffic between the containers of a network,
+// the inet table the routed traffic and the traffic with the host. Both
+// match the containers by their addresses and jump to a chain per container,
+// network and direction, which returns if a rule allows the traffic and drops
+// it otherwise, so the traffic between two isolated containers has to pass
+// the egress rules of the one and the ingress rules of the other.
+func networkPolicyRuleset(policies []define.NetworkPolicy, endpoints []*policyEndpoint) string {
+	var ingress, egress, chains strings.Builder
+	index := 0
+	for _, target := range endpoints {
+		for _, network := range slices.Sorted(maps.Keys(target.status)) {
+			var selecting []define.NetworkPolicy
+			for _, policy := range policies {
+				if slices.Contains(policy.Networks, network) && selectorMatches(policy.PodSelector, target.labels) {
+					selecting = append(selecting, policy)
+				}
+			}
+			ipv4, ipv6 := target.addresses(network)
+			if len(selecting) == 0 || len(ipv4)+len(ipv6) == 0 {
+				continue
+			}
+			slices.Sort(ipv4)
+			slices.Sort(ipv6)
+			for _, direction := range []string{define.NetworkPolicyTypeEgress, define.NetworkPolicyTypeIngress} {
+				var rules []define.NetworkPolicyRule
+				isolated := false
+				for _, policy := range selecting {
+					if !policy.HasPolicyType(direction) {
+						continue
+					}
+					isolated = true
+					if direction == define.NetworkPolicyTypeIngress {
+						rules = append(rules, policy.Ingress...)
+					} else {
+						rules = append(rules, policy.Egress...)
+					}
+				}
+				if !isolated {
+					continue
+				}
+
+				chain := fmt.Sprintf("%s_%d", strings.ToLower(direction), index)
+				dispatch, addr := &egress, "saddr"
+				if direction == define.NetworkPolicyTypeIngress {
+					dispatch, addr = &ingress, "daddr"
+				}
+				if len(ipv4) > 0 {
+					fmt.Fprintf(dispatch, "\t\tip %s { %s } jump %s\n", addr, strings.Join(ipv4, ", "), chain)
+				}
+				if len(ipv6) > 0 {
+					fmt.Fprintf(dispatch, "\t\tip6 %s { %s } jump %s\n", addr, strings.Join(ipv6, ", "), chain)
+				}
+				fmt.Fprintf(&chains, "\tchain %s {\n", chain)
+				for _, rule := range rules {
+					for _, match := range networkPolicyRuleMatches(rule, direction, network, endpoints) {
+						fmt.Fprintf(&chains, "\t\t%s\n", match)
+					}
+				}
+				chains.WriteString("\t\tdrop\n\t}\n")
+			}
+			index++
+		}
+	}
+	if ingress.Len() == 0 && egress.Len() == 0 {
+		return ""
+	}
+
+	// Replies to allowed connections are accepted, so is the neighbor
+	// discovery IPv6 cannot work without.
+	const accepted = "\t\tct state established,related accept\n\t\ticmpv6 type { nd-router-solicit, nd-router-advert, nd-neighbor-solicit, nd-neighbor-advert } accept\n"
+	baseChain := func(table *strings.Builder, hook string, dispatch ...*strings.Builder) {
+		fmt.Fprintf(table, "\tchain %s {\n\t\ttype filter hook %s priority filter; policy accept;\n%s", hook, hook, accepted)
+		for _, d := range dispatch {
+			table.WriteString(d.String())
+		}
+		table.WriteString("\t}\n")
+	}
+	var ruleset strings.Builder
+	fmt.Fprintf(&ruleset, "table bridge %s {\n", networkPolicyTable)
+	baseChain(&ruleset, "forward", &egress, &ingress)
+	ruleset.WriteString(chains.String())
+	ruleset.WriteString("}\n")
+	fmt.Fprintf(&ruleset, "table inet %s {\n", networkPolicyTable)
+	baseChain(&ruleset, "forward", &egress, &ingress)
+	baseChain(&ruleset, "input", &egress)
+	baseChain(&ruleset, "output", &ingress)
+	ruleset.WriteString(chains.String())
+	ruleset.WriteString("}\n")
+	return ruleset.String()
+}
+
+// networkPolicyRuleMatches returns the nftables rules returning from the chain
+// of a container for the connections the rule allows in the direction on the
+// network.
+func networkPolicyRuleMatches(rule define.NetworkPolicyRule, direction, network string, endpoints []*policyEndpoint) []string {
+	addr := "daddr"
+	if direction == define.NetworkPolicyTypeIngress {
+		addr = "saddr"
+	}
+
+	peers := []string{""}
+	if len(rule.Peers) > 0 {
+		peers = nil
+	}
+	for _, peer := range rule.Peers {
+		if peer.CIDR != "" {
+			family := ipFamily(peer.CIDR)
+			match := fmt.Sprintf("%s %s %s", family, addr, peer.CIDR)
+			var except []string
+			for _, e := range peer.Except {
+				if ipFamily(e) == family {
+					except = append(except, e)
+				}
+			}
+			if len(except) > 0 {
+				match += fmt.Sprintf(" %s %s != { %s }", family, addr, strings.Join(except, ", "))
+			}
+			peers = append(peers, match)
+			continue
+		}
+		var ipv4, ipv6 []string
+		for _, e := range endpoints {
+			if !selectorMatches(peer.PodSelector, e.labels) {
+				continue
+			}
+			v4, v6 := e.addresses(network)
+			ipv4 = append(ipv4, v4...)
+			ipv6 = append(ipv6, v6...)
+		}
+		if len(ipv4) > 0 {
+			slices.Sort(ipv4)
+			peers = append(peers, fmt.Sprintf("ip %s { %s }", addr, strings.Join(slices.Compact(ipv4), ", ")))
+		}
+		if len(ipv6) > 0 {
+			slices.Sort(ipv6)
+			peers = append(peers, fmt.Sprintf("ip6 %s { %s }", addr, strings.Join(slices.Compact(ipv6), ", ")))
+		}
+	}
+
+	ports := []string{""}
+	if len(rule.Ports) > 0 {
+		ports = nil
+	}
+	for _, port := range rule.Ports {
+		protocol := strings.ToLower(port.Protocol)
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		switch {
+		case port.Port == 0:
+			ports = append(ports, "meta l4proto "+protocol)
+		case port.EndPort > port.Port:
+			ports = append(ports, fmt.Sprintf("%s dport %d-%d", protocol, port.Port, port.EndPort))
+		default:
+			ports = append(ports, fmt.Sprintf("%s dport %d", protocol, port.Port))
+		}
+	}
+
+	var matches []string
+	for _, peer := range peers {
+		for _, port := range ports {
+			parts := slices.DeleteFunc([]string{peer, port, "return"}, func(part string) bool { return part == "" })
+			matches = append(matches, strings.Join(parts, " "))
+		}
+	}
+	return matches
+}
+
+func ipFamily(cidr string) string {
+	if strings.Contains(cidr, ":") {
+		return "ip6"
+	}
+	return "ip"
+}
@@ -0,0 +1,46 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+func networkPoliciesSupported() error {
+	return nil
+}
+
+// applyNetworkPolicyRuleset replaces the network policy tables in the current
+// network namespace with the tables of ruleset, an empty ruleset removes them.
+func applyNetworkPolicyRuleset(ruleset string) error {
+	nft, err := exec.LookPath("nft")
+	if err != nil {
+		if ruleset == "" {
+			// without nft no table can have been created
+			return nil
+		}
+		return fmt.Errorf("nft is required to enforce network policies: %w", err)
+	}
+
+	// Adding the tables before deleting them makes the deletion succeed if
+	// they do not exist yet, nft applies the whole script atomically.
+	var script strings.Builder
+	for _, family := range []string{"bridge", "inet"} {
+		fmt.Fprintf(&script, "table %s %s\ndelete table %s %s\n", family, networkPolicyTable, family, networkPolicyTable)
+	}
+	script.WriteString(ruleset)
+	cmd := exec.Command(nft, "-f", "-")
+	cmd.Stdin = strings.NewReader(script.String())
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("nft: %s", strings.TrimSpace(string(out)))
+		}
+		return fmt.Errorf("running nft: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"go.podman.io/common/libnetwork/types"
+)
+
+func testPolicyEndpoint(id, ip string, labels map[string]string) *policyEndpoint {
+	return &policyEndpoint{
+		id:     id,
+		labels: labels,
+		status: map[string]types.StatusBlock{
+			"tenant": {
+				Interfaces: map[string]types.NetInterface{
+					"eth0": {
+						Subnets: []types.NetAddress{{
+							IPNet: types.IPNet{IPNet: net.IPNet{IP: net.ParseIP(ip), Mask: net.CIDRMask(24, 32)}},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNetworkPolicyRuleset(t *testing.T) {
+	web := testPolicyEndpoint("web", "10.89.0.2", map[string]string{"app": "web"})
+	db := testPolicyEndpoint("db", "10.89.0.3", map[string]string{"app": "db"})
+	other := testPolicyEndpoint("other", "10.89.0.4", nil)
+	endpoints := []*policyEndpoint{web, db, other}
+
+	policies := []define.NetworkPolicy{
+		{
+			Name:        "db-access",
+			Networks:    []string{"tenant"},
+			PodSelector: map[string]string{"app": "db"},
+			PolicyTypes: []string{define.NetworkPolicyTypeIngress},
+			Ingress: []define.NetworkPolicyRule{{
+				Peers: []define.NetworkPolicyPeer{
+					{PodSelector: map[string]string{"app": "web"}},
+					{CIDR: "10.0.0.0/8", Except: []string{"10.89.0.0/16", "fd00::/8"}},
+				},
+				Ports: []define.NetworkPolicyPort{{Port: 5432}, {Protocol: "udp", Port: 8000, EndPort: 8100}},
+			}},
+		},
+		{
+			Name:        "web-egress",
+			Networks:    []string{"tenant"},
+			PodSelector: map[string]string{"app": "web"},
+			PolicyTypes: []string{define.NetworkPolicyTypeEgress},
+			Egress: []define.NetworkPolicyRule{
+				{Peers: []define.NetworkPolicyPeer{{PodSelector: map[string]string{"app": "db"}}}},
+				{Ports: []define.NetworkPolicyPort{{Protocol: "udp"}}},
+			},
+		},
+		{
+			Name:        "other-network",
+			Networks:    []string{"podman"},
+			PodSelector: map[string]string{},
+			PolicyTypes: []string{define.NetworkPolicyTypeIngress},
+		},
+	}
+
+	// the traffic from web to db passes the egress chain of web and the
+	// ingress chain of db
+	assert.Equal(t, `table bridge podman_network_policy {
+	chain forward {
+		type filter hook forward priority filter; policy accept;
+		ct state established,related accept
+		icmpv6 type { nd-router-solicit, nd-router-advert, nd-neighbor-solicit, nd-neighbor-advert } accept
+		ip saddr { 10.89.0.2 } jump egress_0
+		ip daddr { 10.89.0.3 } jump ingress_1
+	}
+	chain egress_0 {
+		ip daddr { 10.89.0.3 } return
+		meta l4proto udp return
+		drop
+	}
+	chain ingress_1 {
+		ip saddr { 10.89.0.2 } tcp dport 5432 return
+		ip saddr { 10.89.0.2 } udp dport 8000-8100 return
+		ip saddr 10.0.0.0/8 ip saddr != { 10.89.0.0/16 } tcp dport 5432 return
+		ip saddr 10.0.0.0/8 ip saddr != { 10.89.0.0/16 } udp dport 8000-8100 return
+		drop
+	}
+}
+table inet podman_network_policy {
+	chain forward {
+		type filter hook forward priority filter; policy accept;
+		ct state established,related accept
+		icmpv6 type { nd-router-solicit, nd-router-advert, nd-neighbor-solicit, nd-neighbor-advert } accept
+		ip saddr { 10.89.0.2 } jump egress_0
+		ip daddr { 10.89.0.3 } jump ingress_1
+	}
+	chain input {
+		type filter hook input priority filter; policy accept;
+		ct state established,related accept
+		icmpv6 type { nd-router-solicit, nd-router-advert, nd-neighbor-solicit, nd-neighbor-advert } accept
+		ip saddr { 10.89.0.2 } jump egress_0
+	}
+	chain output {
+		type filter hook output priority filter; policy accept;
+		ct state established,related accept
+		icmpv6 type { nd-router-solicit, nd-router-advert, nd-neighbor-solicit, nd-neighbor-advert } accept
+		ip daddr { 10.89.0.3 } jump ingress_1
+	}
+	chain egress_0 {
+		ip daddr { 10.89.0.3 } return
+		meta l4proto udp return
+		drop
+	}
+	chain ingress_1 {
+		ip saddr { 10.89.0.2 } tcp dport 5432 return
+		ip saddr { 10.89.0.2 } udp dport 8000-8100 return
+		ip saddr 10.0.0.0/8 ip saddr != { 10.89.0.0/16 } tcp dport 5432 return
+		ip saddr 10.0.0.0/8 ip saddr != { 10.89.0.0/16 } udp dport 8000-8100 return
+		drop
+	}
+}
+`, networkPolicyRuleset(policies, endpoints))
+
+	// no policy selects the containers on their networks
+	assert.Empty(t, networkPolicyRuleset(policies, []*policyEndpoint{other}))
+
+	// a selector peer without matching containers allows nothing
+	policies[1].Egress = policies[1].Egress[:1]
+	db.labels = nil
+	assert.Contains(t, networkPolicyRuleset(policies, endpoints), "\tchain egress_0 {\n\t\tdrop\n\t}\n")
+}
@@ -5,6 +5,7 @@ package libpod
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"regexp"
 	"slices"
@@ -74,8 +75,20 @@ func (c *Container) getNetworkOptions(networkOpts map[string]types.PerNetworkOpt
 
 // setUpNetwork will set up the networks, on error it will also tear down the cni
 // networks. If rootless it will join/create the rootless network namespace.
+// The network policies are enforced on the bridges of the new attachments
+// before the container can use them.
 func (r *Runtime) setUpNetwork(ns string, opts types.NetworkOptions) (map[string]types.StatusBlock, error) {
-	return r.network.Setup(ns, types.SetupOptions{NetworkOptions: opts})
+	status, err := r.network.Setup(ns, types.SetupOptions{NetworkOptions: opts})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.updateNetworkPolicies(opts.ContainerID, status, nil); err != nil {
+		if err := r.network.Teardown(ns, types.TeardownOptions{NetworkOptions: opts}); err != nil {
+			logrus.Warnf("failed to teardown network after failed network policy setup: %v", err)
+		}
+		return nil, err
+	}
+	return status, nil
 }
 
 // getNetworkPodName return the pod name (hostname) used by dns backend.
@@ -90,9 +103,23 @@ func getNetworkPodName(c *Container) string {
 	return c.Name()
 }
 
+// setupNetworkRateLimitsIfSet applies the network rate limits of the
+// container, without touching the network namespace if it has none.
+func (c *Container) setupNetworkRateLimitsIfSet(netNSPath string, status map[string]types.StatusBlock) error {
+	if len(c.config.NetworkRateLimits) == 0 {
+		return nil
+	}
+	return c.setupNetworkRateLimits(netNSPath, status)
+}
+
 // Tear down a container's network configuration and joins the
-// rootless net ns as rootless user
+// rootless net ns as rootless user. The addresses of the container are removed
+// from the rules of the network policies first, so they do not apply to
+// containers the addresses are reused by.
 func (r *Runtime) teardownNetworkBackend(ns string, opts types.NetworkOptions) error {
+	if err := r.updateNetworkPolicies(opts.ContainerID, nil, slices.Collect(maps.Keys(opts.Networks))); err != nil {
+		logrus.Errorf("Updating network policies before tearing down the network of container %s: %v", opts.ContainerID, err)
+	}
 	return r.network.Teardown(ns, types.TeardownOptions{NetworkOptions: opts})
 }
 
@@ -582,6 +609,10 @@ func (c *Container) NetworkConnect(nameOrID, netName string, netOpts types.PerNe
 	networkStatus[netName] = results[netName]
 	c.state.NetworkStatus = networkStatus
 
+	if err := c.setupNetworkRateLimitsIfSet(c.state.NetNS, results); err != nil {
+		return err
+	}
+
 	err = c.save()
 	if err != nil {
 		return err
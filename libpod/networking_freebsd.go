@@ -48,6 +48,23 @@ func getSlirp4netnsIP(_ *net.IPNet) (*net.IP, error) {
 	return nil, errors.New("not implemented GetSlirp4netnsIP")
 }
 
+func (c *Container) setupNetworkRateLimits(_ string, _ map[string]types.StatusBlock) error {
+	return errors.New("network rate limits are not supported on FreeBSD")
+}
+
+func networkPoliciesSupported() error {
+	return errors.New("network policies are not supported on FreeBSD")
+}
+
+// applyNetworkPolicyRuleset is never called on FreeBSD with a ruleset,
+// network policies cannot be created.
+func applyNetworkPolicyRuleset(ruleset string) error {
+	if ruleset != "" {
+		return networkPoliciesSupported()
+	}
+	return nil
+}
+
 // This is called after the container's jail is created but before its
 // started. We can use this to initialise the container's vnet when we don't
 // have a separate vnet jail (which is the case in FreeBSD 13.3 and later).
@@ -30,10 +30,16 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[strin
 		}
 	}()
 	if ctr.config.NetMode.IsSlirp4netns() {
-		return nil, r.setupSlirp4netns(ctr, ctrNS)
+		if err := r.setupSlirp4netns(ctr, ctrNS); err != nil {
+			return nil, err
+		}
+		return nil, ctr.setupNetworkRateLimitsIfSet(ctrNS, nil)
 	}
 	if ctr.config.NetMode.IsPasta() {
-		return nil, r.setupPasta(ctr, ctrNS)
+		if err := r.setupPasta(ctr, ctrNS); err != nil {
+			return nil, err
+		}
+		return nil, ctr.setupNetworkRateLimitsIfSet(ctrNS, nil)
 	}
 	networks, err := ctr.networks()
 	if err != nil {
@@ -59,6 +65,10 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[strin
 		}
 	}()
 
+	if err := ctr.setupNetworkRateLimitsIfSet(ctrNS, netStatus); err != nil {
+		return nil, err
+	}
+
 	// set up rootless port forwarder when rootless with ports and the network status is empty,
 	// if this is called from network reload the network status will not be empty and we should
 	// not set up port because they are still active
@@ -0,0 +1,169 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"go.podman.io/common/libnetwork/types"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// rateLimitBurstTime is the time of traffic at the limited rate that
+	// may be sent or received in a burst, in seconds.
+	rateLimitBurstTime = 0.1
+	// minRateLimitBurst is the minimum burst size in bytes, large enough
+	// for a few packets of the largest common MTU.
+	minRateLimitBurst = 64 << 10
+	// rateLimitLatency is the maximum time in seconds a packet may wait
+	// in the egress queue before it is dropped.
+	rateLimitLatency = 0.025
+)
+
+var ingressHandle = netlink.MakeHandle(0xffff, 0)
+
+// networkRateLimit returns the rate limit of the container for the network.
+func (c *Container) networkRateLimit(network string) define.NetworkRateLimit {
+	if limit, ok := c.config.NetworkRateLimits[network]; ok {
+		return limit
+	}
+	return c.config.NetworkRateLimits[""]
+}
+
+// setupNetworkRateLimits applies the network rate limits of the container to
+// the interfaces in its network namespace. For bridge networks status maps
+// the networks to their interfaces, for pasta and slirp4netns it is nil and
+// the limit without network name is applied to every interface.
+func (c *Container) setupNetworkRateLimits(netNSPath string, status map[string]types.StatusBlock) error {
+	var interfaceLimits map[string]define.NetworkRateLimit
+	if status != nil {
+		interfaceLimits = make(map[string]define.NetworkRateLimit)
+		for network, block := range status {
+			for name := range block.Interfaces {
+				interfaceLimits[name] = c.networkRateLimit(network)
+			}
+		}
+	}
+
+	return ns.WithNetNSPath(netNSPath, func(_ ns.NetNS) error {
+		links, err := netlink.LinkList()
+		if err != nil {
+			return fmt.Errorf("retrieving all network interfaces: %w", err)
+		}
+		for _, link := range links {
+			attrs := link.Attrs()
+			if attrs.Flags&net.FlagLoopback != 0 {
+				continue
+			}
+			limit := c.config.NetworkRateLimits[""]
+			if interfaceLimits != nil {
+				var ok bool
+				if limit, ok = interfaceLimits[attrs.Name]; !ok {
+					continue
+				}
+			}
+			logrus.Debugf("Setting rate limit of interface %s of container %s to ingress %d bit/s, egress %d bit/s", attrs.Name, c.ID(), limit.IngressRate, limit.EgressRate)
+			if err := setLinkRateLimit(link, limit); err != nil {
+				return fmt.Errorf("setting rate limit of interface %s: %w", attrs.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// rateLimitBurst returns the burst size in bytes for a rate in bits per
+// second.
+func rateLimitBurst(rate uint64) uint32 {
+	return uint32(max(float64(rate)/8*rateLimitBurstTime, minRateLimitBurst))
+}
+
+// setLinkRateLimit shapes the egress traffic of the link with a token bucket
+// filter and polices its ingress traffic, dropping what exceeds the rate.
+// Limits of 0 remove the qdiscs set up before.
+func setLinkRateLimit(link netlink.Link, limit define.NetworkRateLimit) error {
+	if err := define.ValidateNetworkRateLimit(limit); err != nil {
+		return err
+	}
+	index := link.Attrs().Index
+	if limit.EgressRate > 0 {
+		rate := limit.EgressRate / 8
+		burst := rateLimitBurst(limit.EgressRate)
+		// Same computation as tc(8) for the buffer in ticks and the queue limit.
+		buffer := uint32(float64(burst) * float64(netlink.TIME_UNITS_PER_SEC) / float64(rate) * netlink.TickInUsec())
+		tbf := &netlink.Tbf{
+			QdiscAttrs: netlink.QdiscAttrs{
+				LinkIndex: index,
+				Handle:    netlink.MakeHandle(1, 0),
+				Parent:    netlink.HANDLE_ROOT,
+			},
+			Rate:   rate,
+			Buffer: buffer,
+			Limit:  uint32(float64(rate)*rateLimitLatency) + burst,
+		}
+		if err := netlink.QdiscReplace(tbf); err != nil {
+			return fmt.Errorf("shaping egress traffic: %w", err)
+		}
+	} else if err := deleteQdisc(link, netlink.HANDLE_ROOT, "tbf"); err != nil {
+		return err
+	}
+
+	// Deleting the ingress qdisc removes the policer with it.
+	if err := deleteQdisc(link, netlink.HANDLE_INGRESS, "ingress"); err != nil {
+		return err
+	}
+	if limit.IngressRate == 0 {
+		return nil
+	}
+	ingress := &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: index,
+			Handle:    ingressHandle,
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+	if err := netlink.QdiscAdd(ingress); err != nil {
+		return fmt.Errorf("adding ingress qdisc: %w", err)
+	}
+	police := netlink.NewPoliceAction()
+	police.Rate = uint32(limit.IngressRate / 8)
+	police.Burst = rateLimitBurst(limit.IngressRate)
+	police.ExceedAction = netlink.TC_POLICE_SHOT
+	filter := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: index,
+			Parent:    ingressHandle,
+			Priority:  1,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		Actions: []netlink.Action{police},
+	}
+	if err := netlink.FilterAdd(filter); err != nil {
+		return fmt.Errorf("policing ingress traffic: %w", err)
+	}
+	return nil
+}
+
+// deleteQdisc deletes the qdisc of the given type attached to parent, if
+// there is one.
+func deleteQdisc(link netlink.Link, parent uint32, qdiscType string) error {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return fmt.Errorf("listing qdiscs: %w", err)
+	}
+	for _, q := range qdiscs {
+		if q.Attrs().Parent != parent || q.Type() != qdiscType {
+			continue
+		}
+		if err := netlink.QdiscDel(q); err != nil && !errors.Is(err, unix.ENOENT) {
+			return fmt.Errorf("removing %s qdisc: %w", qdiscType, err)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/containers/podman/v6/libpod/define"
@@ -239,4 +240,17 @@ type HTTPAttachStreams struct {
 	Stdin  bool
 	Stdout bool
 	Stderr bool
+	// Record requests a recording of the session.
+	Record bool
+	// recording is written the output of the session if it is recorded.
+	recording io.Writer
+}
+
+// recordingWriter returns the writer recording the output of the session,
+// or nil if it is not recorded.
+func (s *HTTPAttachStreams) recordingWriter() io.Writer {
+	if s == nil {
+		return nil
+	}
+	return s.recording
 }
@@ -644,11 +644,11 @@ func (r *ConmonOCIRuntime) HTTPAttach(ctr *Container, req *http.Request, w http.
 			// anything from here.
 			logrus.Debugf("Performing terminal HTTP attach for container %s", ctr.ID())
 			if attachStdout {
-				err = httpAttachTerminalCopy(conn, httpBuf, ctr.ID())
+				err = httpAttachTerminalCopy(conn, httpBuf, ctr.ID(), streams.recordingWriter())
 			}
 		} else {
 			logrus.Debugf("Performing non-terminal HTTP attach for container %s", ctr.ID())
-			err = httpAttachNonTerminalCopy(conn, httpBuf, ctr.ID(), attachStdin, attachStdout, attachStderr)
+			err = httpAttachNonTerminalCopy(conn, httpBuf, ctr.ID(), attachStdin, attachStdout, attachStderr, streams.recordingWriter())
 		}
 		stdoutChan <- err
 		logrus.Debugf("STDOUT/ERR copy completed")
@@ -1504,8 +1504,9 @@ func (r *ConmonOCIRuntime) getOCIRuntimeVersion() (string, error) {
 // Copy data from container to HTTP connection, for terminal attach.
 // Container is the container's attach socket connection, http is a buffer for
 // the HTTP connection. cid is the ID of the container the attach session is
-// running for (used solely for error messages).
-func httpAttachTerminalCopy(container *net.UnixConn, http *bufio.ReadWriter, cid string) error {
+// running for (used solely for error messages). The output is also written to
+// record, if not nil.
+func httpAttachTerminalCopy(container *net.UnixConn, http *bufio.ReadWriter, cid string, record io.Writer) error {
 	buf := make([]byte, bufferSize)
 	for {
 		numR, err := container.Read(buf)
@@ -1529,6 +1530,9 @@ func httpAttachTerminalCopy(container *net.UnixConn, http *bufio.ReadWriter, cid
 			} else if numW+1 != numR {
 				return io.ErrShortWrite
 			}
+			if record != nil {
+				_, _ = record.Write(buf[1:numR])
+			}
 			// We need to force the buffer to write immediately, so
 			// there isn't a delay on the terminal side.
 			if err2 := http.Flush(); err2 != nil {
@@ -1548,8 +1552,9 @@ func httpAttachTerminalCopy(container *net.UnixConn, http *bufio.ReadWriter, cid
 }
 
 // Copy data from a container to an HTTP connection, for non-terminal attach.
-// Appends a header to multiplex input.
-func httpAttachNonTerminalCopy(container *net.UnixConn, http *bufio.ReadWriter, cid string, stdin, stdout, stderr bool) error {
+// Appends a header to multiplex input. STDOUT and STDERR are also written to
+// record, if not nil.
+func httpAttachNonTerminalCopy(container *net.UnixConn, http *bufio.ReadWriter, cid string, stdin, stdout, stderr bool, record io.Writer) error {
 	buf := make([]byte, bufferSize)
 	for {
 		numR, err := container.Read(buf)
@@ -1615,6 +1620,9 @@ func httpAttachNonTerminalCopy(container *net.UnixConn, http *bufio.ReadWriter,
 
 				return io.ErrShortWrite
 			}
+			if record != nil && buf[0] != AttachPipeStdin {
+				_, _ = record.Write(buf[1:numR])
+			}
 			// We need to force the buffer to write immediately, so
 			// there isn't a delay on the terminal side.
 			if err2 := http.Flush(); err2 != nil {
@@ -642,11 +642,11 @@ func attachExecHTTP(c *Container, sessionID string, r *http.Request, w http.Resp
 			// anything from here.
 			logrus.Debugf("Performing terminal HTTP attach for container %s", c.ID())
 			if attachStdout {
-				err = httpAttachTerminalCopy(conn, httpBuf, c.ID())
+				err = httpAttachTerminalCopy(conn, httpBuf, c.ID(), streams.recordingWriter())
 			}
 		} else {
 			logrus.Debugf("Performing non-terminal HTTP attach for container %s", c.ID())
-			err = httpAttachNonTerminalCopy(conn, httpBuf, c.ID(), attachStdin, attachStdout, attachStderr)
+			err = httpAttachNonTerminalCopy(conn, httpBuf, c.ID(), attachStdin, attachStdout, attachStderr, streams.recordingWriter())
 		}
 		stdoutChan <- err
 		logrus.Debugf("STDOUT/ERR copy completed")
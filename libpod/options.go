@@ -451,6 +451,19 @@ func WithSyslog() RuntimeOption {
 	}
 }
 
+// WithRecordSessions sets a runtime option to record all exec and attach
+// sessions attached to STDIN.
+func WithRecordSessions() RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		rt.recordSessions = true
+		return nil
+	}
+}
+
 // WithRuntimeFlags adds the global runtime flags to the container config
 func WithRuntimeFlags(runtimeFlags []string) RuntimeOption {
 	return func(rt *Runtime) error {
@@ -994,6 +1007,31 @@ func WithNetworkOptions(options map[string][]string) CtrCreateOption {
 	}
 }
 
+// WithNetworkRateLimits limits the bandwidth of the network attachments of
+// the container. The container must create its own network namespace.
+func WithNetworkRateLimits(limits map[string]define.NetworkRateLimit) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if !ctr.config.CreateNetNS {
+			return fmt.Errorf("network rate limits can only be set for containers with their own network namespace: %w", define.ErrInvalidArg)
+		}
+		for network, limit := range limits {
+			if network != "" && !ctr.config.NetMode.IsBridge() {
+				return fmt.Errorf("network rate limits for network %q can only be set in bridge network mode: %w", network, define.ErrInvalidArg)
+			}
+			if err := define.ValidateNetworkRateLimit(limit); err != nil {
+				return err
+			}
+		}
+
+		ctr.config.NetworkRateLimits = limits
+
+		return nil
+	}
+}
+
 // WithLogDriver sets the log driver for the container
 func WithLogDriver(driver string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1801,12 +1839,8 @@ func WithEnvSecrets(envSecrets map[string]string) CtrCreateOption {
 		if ctr.valid {
 			return define.ErrCtrFinalized
 		}
-		manager, err := ctr.runtime.SecretsManager()
-		if err != nil {
-			return err
-		}
 		for target, src := range envSecrets {
-			secr, err := manager.Lookup(src)
+			secr, err := ctr.runtime.LookupSecret(src)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,33 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v6/libpod/define"
+)
+
+// GetProcessStats gets the resource usage of the processes running in the
+// container.  The previousStats are used to calculate the cpu percentages and
+// I/O rates since the previous sample.  You should pass nil for the first
+// sample, the usage is then averaged over the lifetime of each process.
+func (c *Container) GetProcessStats(previousStats []define.ContainerProcessStats) ([]define.ContainerProcessStats, error) {
+	if c.config.NoCgroups {
+		return nil, fmt.Errorf("cannot get the processes of container %s as it did not create a cgroup: %w", c.ID(), define.ErrNoCgroups)
+	}
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.state.State != define.ContainerStateRunning && c.state.State != define.ContainerStatePaused {
+		return nil, fmt.Errorf("cannot get the processes of container %s unless it is running: %w", c.ID(), define.ErrCtrStopped)
+	}
+
+	return c.getPlatformProcessStats(previousStats)
+}
@@ -0,0 +1,13 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+
+	"github.com/containers/podman/v6/libpod/define"
+)
+
+func (c *Container) getPlatformProcessStats(_ []define.ContainerProcessStats) ([]define.ContainerProcessStats, error) {
+	return nil, errors.New("process statistics are not supported on FreeBSD")
+}
@@ -0,0 +1,252 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"go.podman.io/common/pkg/cgroups"
+	"golang.org/x/sys/unix"
+)
+
+// clockTicks is USER_HZ, the unit of the times in /proc/PID/stat.  It is
+// 100 on all architectures supported by Linux.
+const clockTicks = 100
+
+// procStat is the subset of /proc/PID/stat used for process statistics.
+type procStat struct {
+	ppid     int
+	command  string
+	state    string
+	cpuTicks uint64
+	// startTicks is the start time of the process after boot.
+	startTicks uint64
+	rssPages   uint64
+}
+
+// getPlatformProcessStats reads the statistics of all processes in the
+// cgroup of the container from /proc.
+// NOTE: only call this when owning the container's lock.
+func (c *Container) getPlatformProcessStats(previousStats []define.ContainerProcessStats) ([]define.ContainerProcessStats, error) {
+	cgroupPath, err := c.cGroupPath()
+	if err != nil {
+		return nil, err
+	}
+	pids, err := cgroupPids(cgroupPath)
+	if err != nil {
+		return nil, err
+	}
+	uptime, err := readUptime()
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make(map[int]*define.ContainerProcessStats, len(previousStats))
+	for i := range previousStats {
+		previous[previousStats[i].PID] = &previousStats[i]
+	}
+
+	now := uint64(time.Now().UnixNano())
+	pageSize := uint64(os.Getpagesize())
+	processStats := make([]define.ContainerProcessStats, 0, len(pids))
+	for _, pid := range pids {
+		procDir := filepath.Join("/proc", strconv.Itoa(pid))
+		data, err := os.ReadFile(filepath.Join(procDir, "stat"))
+		if err != nil {
+			// The process exited since the cgroup was read.
+			if errors.Is(err, os.ErrNotExist) || errors.Is(err, unix.ESRCH) {
+				continue
+			}
+			return nil, err
+		}
+		stat, err := parseProcStat(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s/stat: %w", procDir, err)
+		}
+
+		stats := define.ContainerProcessStats{
+			PID:        pid,
+			PPID:       stat.ppid,
+			Command:    stat.command,
+			State:      stat.state,
+			CPUNano:    stat.cpuTicks * uint64(time.Second) / clockTicks,
+			RSS:        stat.rssPages * pageSize,
+			FDs:        -1,
+			SystemNano: now,
+		}
+		// The I/O accounting and file descriptors of processes owned by
+		// other users cannot be read, they are reported as unknown.
+		if data, err := os.ReadFile(filepath.Join(procDir, "io")); err == nil {
+			stats.ReadBytes, stats.WriteBytes = parseProcIO(string(data))
+		}
+		if fds, err := os.ReadDir(filepath.Join(procDir, "fd")); err == nil {
+			stats.FDs = len(fds)
+		}
+
+		lifetime := uptime - time.Duration(stat.startTicks*uint64(time.Second)/clockTicks)
+		calculateProcessUsage(&stats, previous[pid], lifetime)
+		processStats = append(processStats, stats)
+	}
+	return processStats, nil
+}
+
+// calculateProcessUsage sets the cpu percentage and I/O rates of the process
+// since the previous sample, or over the lifetime of the process if there is
+// no previous sample.
+func calculateProcessUsage(stats, previous *define.ContainerProcessStats, lifetime time.Duration) {
+	// The PID was reused by a new process if it used less cpu time.
+	if previous == nil || previous.CPUNano > stats.CPUNano || previous.SystemNano >= stats.SystemNano {
+		previous = &define.ContainerProcessStats{SystemNano: stats.SystemNano - uint64(max(lifetime, 0))}
+	}
+	elapsed := float64(stats.SystemNano - previous.SystemNano)
+	if elapsed <= 0 {
+		return
+	}
+	seconds := elapsed / float64(time.Second)
+	stats.CPU = float64(stats.CPUNano-previous.CPUNano) / elapsed * 100
+	if stats.ReadBytes >= previous.ReadBytes {
+		stats.ReadRate = float64(stats.ReadBytes-previous.ReadBytes) / seconds
+	}
+	if stats.WriteBytes >= previous.WriteBytes {
+		stats.WriteRate = float64(stats.WriteBytes-previous.WriteBytes) / seconds
+	}
+}
+
+// cgroupPids returns the PIDs of the processes in the cgroup and its
+// sub-cgroups, which are created by containers running systemd.
+func cgroupPids(cgroupPath string) ([]int, error) {
+	root := "/sys/fs/cgroup"
+	unified, err := cgroups.IsCgroup2UnifiedMode()
+	if err != nil {
+		return nil, err
+	}
+	if !unified {
+		root = filepath.Join(root, "pids")
+	}
+
+	var pids []int
+	err = filepath.WalkDir(filepath.Join(root, cgroupPath), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Sub-cgroups can be removed while walking.
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || d.Name() != "cgroup.procs" {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			pid, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", path, err)
+			}
+			pids = append(pids, pid)
+		}
+		return scanner.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading processes of cgroup %s: %w", cgroupPath, err)
+	}
+	slices.Sort(pids)
+	return slices.Compact(pids), nil
+}
+
+// readUptime returns the time since boot.
+func readUptime() (time.Duration, error) {
+	data, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, errors.New("parsing /proc/uptime: empty file")
+	}
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing /proc/uptime: %w", err)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// parseProcStat parses the content of /proc/PID/stat, see proc_pid_stat(5).
+func parseProcStat(data string) (*procStat, error) {
+	// The command may contain spaces and parentheses, it ends at the
+	// last parenthesis.
+	start := strings.IndexByte(data, '(')
+	end := strings.LastIndexByte(data, ')')
+	if start < 0 || end < start {
+		return nil, errors.New("missing command")
+	}
+	// fields[0] is the third field of the file.
+	fields := strings.Fields(data[end+1:])
+	if len(fields) < 22 {
+		return nil, fmt.Errorf("expected at least 24 fields but got %d", len(fields)+2)
+	}
+
+	stat := &procStat{command: data[start+1 : end], state: fields[0]}
+	var err error
+	if stat.ppid, err = strconv.Atoi(fields[1]); err != nil {
+		return nil, fmt.Errorf("parsing ppid: %w", err)
+	}
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parsing utime: %w", err)
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parsing stime: %w", err)
+	}
+	stat.cpuTicks = utime + stime
+	if stat.startTicks, err = strconv.ParseUint(fields[19], 10, 64); err != nil {
+		return nil, fmt.Errorf("parsing starttime: %w", err)
+	}
+	// rss is negative for kernel threads.
+	if rss, err := strconv.ParseInt(fields[21], 10, 64); err != nil {
+		return nil, fmt.Errorf("parsing rss: %w", err)
+	} else if rss > 0 {
+		stat.rssPages = uint64(rss)
+	}
+	return stat, nil
+}
+
+// parseProcIO returns the bytes read from and written to storage from the
+// content of /proc/PID/io.
+func parseProcIO(data string) (readBytes, writeBytes uint64) {
+	for line := range strings.SplitSeq(data, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "read_bytes":
+			readBytes = n
+		case "write_bytes":
+			writeBytes = n
+		}
+	}
+	return readBytes, writeBytes
+}
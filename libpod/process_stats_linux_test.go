@@ -0,0 +1,81 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProcStat(t *testing.T) {
+	data := "1234 (my (odd) cmd) S 1 1234 1234 0 -1 4194560 1051 0 0 0 250 50 0 0 20 0 1 0 9000 12345678 321 18446744073709551615 1 1 0 0 0 0 0 4096 0 0 0 0 17 3 0 0 0 0 0\n"
+	stat, err := parseProcStat(data)
+	require.NoError(t, err)
+	assert.Equal(t, "my (odd) cmd", stat.command)
+	assert.Equal(t, "S", stat.state)
+	assert.Equal(t, 1, stat.ppid)
+	assert.Equal(t, uint64(300), stat.cpuTicks)
+	assert.Equal(t, uint64(9000), stat.startTicks)
+	assert.Equal(t, uint64(321), stat.rssPages)
+
+	_, err = parseProcStat("1234 (cmd) S 1 2 3")
+	assert.ErrorContains(t, err, "expected at least 24 fields")
+	_, err = parseProcStat("1234 cmd")
+	assert.ErrorContains(t, err, "missing command")
+}
+
+func TestParseProcIO(t *testing.T) {
+	data := "rchar: 100\nwchar: 200\nsyscr: 3\nsyscw: 4\nread_bytes: 4096\nwrite_bytes: 8192\ncancelled_write_bytes: 0\n"
+	readBytes, writeBytes := parseProcIO(data)
+	assert.Equal(t, uint64(4096), readBytes)
+	assert.Equal(t, uint64(8192), writeBytes)
+}
+
+func TestCalculateProcessUsage(t *testing.T) {
+	now := uint64(100 * time.Second)
+	tests := []struct {
+		name      string
+		previous  *define.ContainerProcessStats
+		lifetime  time.Duration
+		cpu       float64
+		readRate  float64
+		writeRate float64
+	}{
+		{
+			name:      "first sample averages over the lifetime",
+			lifetime:  10 * time.Second,
+			cpu:       20,
+			readRate:  1000,
+			writeRate: 500,
+		},
+		{
+			name:      "delta since the previous sample",
+			previous:  &define.ContainerProcessStats{CPUNano: uint64(time.Second), ReadBytes: 5000, WriteBytes: 5000, SystemNano: now - uint64(2*time.Second)},
+			lifetime:  10 * time.Second,
+			cpu:       50,
+			readRate:  2500,
+			writeRate: 0,
+		},
+		{
+			name:      "reused pid",
+			previous:  &define.ContainerProcessStats{CPUNano: uint64(time.Hour), SystemNano: now - uint64(time.Second)},
+			lifetime:  4 * time.Second,
+			cpu:       50,
+			readRate:  2500,
+			writeRate: 1250,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := define.ContainerProcessStats{CPUNano: uint64(2 * time.Second), ReadBytes: 10000, WriteBytes: 5000, SystemNano: now}
+			calculateProcessUsage(&stats, tt.previous, tt.lifetime)
+			assert.InDelta(t, tt.cpu, stats.CPU, 0.001)
+			assert.InDelta(t, tt.readRate, stats.ReadRate, 0.001)
+			assert.InDelta(t, tt.writeRate, stats.WriteRate, 0.001)
+		})
+	}
+}
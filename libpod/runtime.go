@@ -94,6 +94,10 @@ type Runtime struct {
 	// This bool is just needed so that we can set it for netavark interface.
 	syslog bool
 
+	// recordSessions records all exec and attach sessions attached to
+	// STDIN, see shouldRecord.
+	recordSessions bool
+
 	// doReset indicates that the runtime will perform a system reset.
 	// A reset will remove all containers, pods, volumes, networks, etc.
 	// A number of validation checks are relaxed, or replaced with logic to
@@ -505,7 +509,7 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 
 		// Using sync once value to only init the store exactly once and only when it will be actually be used.
 		runtime.ArtifactStore = sync.OnceValues(func() (*artStore.ArtifactStore, error) {
-			return artStore.NewArtifactStore(filepath.Join(runtime.storageConfig.GraphRoot, "artifacts"), runtime.SystemContext())
+			return artStore.NewArtifactStore(runtime.artifactStorePath(), runtime.SystemContext())
 		})
 	}
 
@@ -90,13 +90,65 @@ func (r *Runtime) RestoreContainer(ctx context.Context, rSpec *spec.Spec, config
 		return nil, define.ErrRuntimeStopped
 	}
 
-	ctr, err := r.initContainerVariables(rSpec, config)
+	ctr, err := r.initImportedContainer(rSpec, config)
 	if err != nil {
-		return nil, fmt.Errorf("initializing container variables: %w", err)
+		return nil, err
 	}
 	// For an imported checkpoint no one has ever set the StartedTime. Set it now.
 	ctr.state.StartedTime = time.Now()
 
+	return r.setupContainer(ctx, ctr)
+}
+
+// RecreateContainer re-creates a container from a configuration that was
+// exported on another host (or an earlier installation of this one), e.g. by
+// podman system backup. Unlike RestoreContainer, the container is created in
+// the configured state and keeps its OCI runtime if it is available here.
+func (r *Runtime) RecreateContainer(ctx context.Context, config *ContainerConfig) (*Container, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	if config == nil || config.Spec == nil {
+		return nil, fmt.Errorf("must provide a valid container config to recreate a container: %w", define.ErrInvalidArg)
+	}
+
+	ctr, err := r.initImportedContainer(config.Spec, config)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.ociRuntimes[config.OCIRuntime]; ok {
+		ctr.config.OCIRuntime = config.OCIRuntime
+	}
+
+	// The default cgroup parents depend on the cgroup manager and on
+	// whether we run rootless, and a pod cgroup contains the pod ID.
+	// Reset them so they are computed again for this host.
+	switch ctr.config.CgroupParent {
+	case CgroupfsDefaultCgroupParent, SystemdDefaultCgroupParent, SystemdDefaultRootlessCgroupParent:
+		ctr.config.CgroupParent = ""
+	default:
+		if ctr.config.Pod != "" {
+			pod, err := r.state.Pod(ctr.config.Pod)
+			if err != nil {
+				return nil, fmt.Errorf("cannot add container %s to pod %s: %w", ctr.ID(), ctr.config.Pod, err)
+			}
+			if pod.config.UsePodCgroup {
+				ctr.config.CgroupParent = ""
+			}
+		}
+	}
+
+	return r.setupContainer(ctx, ctr)
+}
+
+// initImportedContainer prepares a container from a config that was created
+// by another runtime, resetting paths that are specific to that runtime.
+func (r *Runtime) initImportedContainer(rSpec *spec.Spec, config *ContainerConfig) (*Container, error) {
+	ctr, err := r.initContainerVariables(rSpec, config)
+	if err != nil {
+		return nil, fmt.Errorf("initializing container variables: %w", err)
+	}
+
 	// If the path to ConmonPidFile starts with the default value (RunRoot), then
 	// the user has not specified '--conmon-pidfile' during run or create (probably).
 	// In that case reset ConmonPidFile to be set to the default value later.
@@ -111,7 +163,7 @@ func (r *Runtime) RestoreContainer(ctx context.Context, rSpec *spec.Spec, config
 		ctr.config.PidFile = ""
 	}
 
-	return r.setupContainer(ctx, ctr)
+	return ctr, nil
 }
 
 // RenameContainer renames the given container.
@@ -6,6 +6,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
 
 	buildahDefine "github.com/containers/buildah/define"
 	"github.com/containers/buildah/imagebuildah"
@@ -14,6 +16,10 @@ import (
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/libimage"
 	"go.podman.io/image/v5/docker/reference"
+	is "go.podman.io/image/v5/storage"
+	"go.podman.io/image/v5/types"
+	"go.podman.io/storage"
+	"go.podman.io/storage/pkg/archive"
 )
 
 // Runtime API
@@ -127,3 +133,66 @@ func (r *Runtime) Build(ctx context.Context, options buildahDefine.BuildOptions,
 	r.newImageBuildCompleteEvent(id)
 	return id, ref, err
 }
+
+// ImageLayerIDs returns the IDs of the storage layers of the image, ordered
+// from the base layer to the top layer.
+func (r *Runtime) ImageLayerIDs(img *libimage.Image) ([]string, error) {
+	var layers []string
+	for id := img.TopLayer(); id != ""; {
+		layer, err := r.store.Layer(id)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer.ID)
+		id = layer.Parent
+	}
+	slices.Reverse(layers)
+	return layers, nil
+}
+
+// ImageReference returns a reference to img in the local storage carrying
+// the given name, which signatures of the image are verified against.
+func (r *Runtime) ImageReference(img *libimage.Image, named reference.Named) (types.ImageReference, error) {
+	return is.Transport.NewStoreReference(r.store, named, img.ID())
+}
+
+// ImageSignatures returns the signatures stored with img when it was
+// pulled, in the blob format of containers/image.
+func (r *Runtime) ImageSignatures(img *libimage.Image) ([][]byte, error) {
+	storeImage, err := r.store.Image(img.ID())
+	if err != nil {
+		return nil, err
+	}
+	// See storageImageMetadata in go.podman.io/image/v5/storage.
+	var metadata struct {
+		SignatureSizes []int `json:"signature-sizes,omitempty"`
+	}
+	if storeImage.Metadata != "" {
+		if err := json.Unmarshal([]byte(storeImage.Metadata), &metadata); err != nil {
+			return nil, fmt.Errorf("parsing metadata of image %s: %w", img.ID(), err)
+		}
+	}
+	if len(metadata.SignatureSizes) == 0 {
+		return nil, nil
+	}
+	data, err := r.store.ImageBigData(img.ID(), "signatures")
+	if err != nil {
+		return nil, fmt.Errorf("reading signatures of image %s: %w", img.ID(), err)
+	}
+	sigs := make([][]byte, 0, len(metadata.SignatureSizes))
+	for _, size := range metadata.SignatureSizes {
+		if size > len(data) {
+			return nil, fmt.Errorf("signatures of image %s are truncated", img.ID())
+		}
+		sigs = append(sigs, data[:size])
+		data = data[size:]
+	}
+	return sigs, nil
+}
+
+// LayerDiff returns an uncompressed tar stream of the changes the layer
+// makes to its parent. The caller must close the stream.
+func (r *Runtime) LayerDiff(layerID string) (io.ReadCloser, error) {
+	uncompressed := archive.Uncompressed
+	return r.store.Diff("", layerID, &storage.DiffOptions{Compression: &uncompressed})
+}
@@ -6,12 +6,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/libpod/events"
 	"github.com/containers/podman/v6/pkg/specgen"
 	"github.com/hashicorp/go-multierror"
 	"github.com/sirupsen/logrus"
+	"go.podman.io/storage/pkg/stringid"
 )
 
 // NewPod makes a new, empty pod
@@ -93,6 +95,80 @@ func (r *Runtime) NewPod(_ context.Context, p specgen.PodSpecGenerator, options
 	return nil, fmt.Errorf("adding pod to state: %w", addPodErr)
 }
 
+// RestorePod re-creates a pod from a configuration that was exported on
+// another host (or an earlier installation of this one), e.g. by podman system
+// backup. If the config has no ID or name, new ones are generated. The infra
+// container is not part of the pod config and must be recreated separately
+// and added to the pod with AddInfra().
+func (r *Runtime) RestorePod(_ context.Context, config *PodConfig) (_ *Pod, deferredErr error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	pod := newPod(r)
+	if err := JSONDeepCopy(config, pod.config); err != nil {
+		return nil, fmt.Errorf("copying pod config for restore: %w", err)
+	}
+	if pod.config.ID == "" {
+		pod.config.ID = stringid.GenerateRandomID()
+	}
+	if pod.config.Labels == nil {
+		pod.config.Labels = make(map[string]string)
+	}
+	// The service container is not restored together with the pod.
+	pod.config.ServiceContainerID = ""
+	pod.config.CreatedTime = time.Now()
+	if r.config.Engine.Namespace != "" {
+		pod.config.Namespace = r.config.Engine.Namespace
+	}
+
+	lock, err := r.lockManager.AllocateLock()
+	if err != nil {
+		return nil, fmt.Errorf("allocating lock for restored pod: %w", err)
+	}
+	pod.lock = lock
+	pod.config.LockID = pod.lock.ID()
+
+	defer func() {
+		if deferredErr != nil {
+			if err := pod.lock.Free(); err != nil {
+				logrus.Errorf("Freeing pod lock after failed restore: %v", err)
+			}
+		}
+	}()
+
+	pod.valid = true
+
+	// The default cgroup parent depends on the cgroup manager in use on
+	// this host, let platformMakePod pick it again.
+	switch pod.config.CgroupParent {
+	case CgroupfsDefaultCgroupParent, SystemdDefaultCgroupParent, SystemdDefaultRootlessCgroupParent:
+		pod.config.CgroupParent = ""
+	}
+	if _, err := r.platformMakePod(pod, &pod.config.ResourceLimits); err != nil {
+		return nil, err
+	}
+
+	generateName := pod.config.Name == ""
+	var addPodErr error
+	for {
+		if generateName {
+			name, err := r.generateName()
+			if err != nil {
+				return nil, err
+			}
+			pod.config.Name = name
+		}
+		if addPodErr = r.state.AddPod(pod); addPodErr == nil {
+			return pod, nil
+		}
+		if !generateName || (!errors.Is(addPodErr, define.ErrPodExists) && !errors.Is(addPodErr, define.ErrCtrExists)) {
+			break
+		}
+	}
+	return nil, fmt.Errorf("adding restored pod to state: %w", addPodErr)
+}
+
 // AddInfra adds the created infra container to the pod state
 func (r *Runtime) AddInfra(_ context.Context, pod *Pod, infraCtr *Container) (*Pod, error) {
 	if !r.valid {
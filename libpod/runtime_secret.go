@@ -0,0 +1,104 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"go.podman.io/common/pkg/secrets"
+)
+
+// secretVersionRegexp matches the name of a previous version of a secret.
+var secretVersionRegexp = regexp.MustCompile(`^(.+)@v([1-9][0-9]*)$`)
+
+// SecretVersion returns the version of the secret, secrets which were never
+// updated are at version 1.
+func SecretVersion(secret secrets.Secret) int {
+	version, err := strconv.Atoi(secret.Metadata[define.SecretVersionKey])
+	if err != nil || version < 1 {
+		return 1
+	}
+	return version
+}
+
+// SecretVersionName returns the name of a previous version of a secret.
+func SecretVersionName(name string, version int) string {
+	return fmt.Sprintf("%s@v%d", name, version)
+}
+
+// LookupSecret looks up a secret by name or ID.  NAME@vVERSION looks up a
+// previous version of a secret; if VERSION is the current version of the
+// secret, the current version is kept as NAME@vVERSION first so that it can
+// be pinned.
+func (r *Runtime) LookupSecret(nameOrID string) (*secrets.Secret, error) {
+	manager, err := r.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := manager.Lookup(nameOrID)
+	if err == nil || !errors.Is(err, secrets.ErrNoSuchSecret) {
+		return secret, err
+	}
+	match := secretVersionRegexp.FindStringSubmatch(nameOrID)
+	if match == nil {
+		return nil, err
+	}
+	current, lookupErr := manager.Lookup(match[1])
+	if lookupErr != nil || current.Name != match[1] || strconv.Itoa(SecretVersion(*current)) != match[2] {
+		return nil, err
+	}
+	if _, ok := current.Labels[define.SecretHistoryLabel]; ok {
+		return nil, err
+	}
+	if err := r.ArchiveSecret(current); err != nil {
+		return nil, err
+	}
+	return manager.Lookup(nameOrID)
+}
+
+// ArchiveSecret stores the current version of the secret as a previous
+// version named NAME@vVERSION, unless it was stored already.
+func (r *Runtime) ArchiveSecret(secret *secrets.Secret) error {
+	manager, err := r.SecretsManager()
+	if err != nil {
+		return err
+	}
+	version := SecretVersion(*secret)
+	name := SecretVersionName(secret.Name, version)
+	_, data, err := manager.LookupSecretData(secret.ID)
+	if err != nil {
+		return err
+	}
+	if archived, archivedData, err := manager.LookupSecretData(name); err == nil && archived.Labels[define.SecretHistoryLabel] == secret.Name && bytes.Equal(archivedData, data) {
+		return nil
+	}
+
+	labels := maps.Clone(secret.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[define.SecretHistoryLabel] = secret.Name
+	metadata := maps.Clone(secret.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	metadata[define.SecretVersionKey] = strconv.Itoa(version)
+	metadata[define.SecretChangedAtKey] = secret.UpdatedAt.Format(time.RFC3339Nano)
+	storeOpts := secrets.StoreOptions{
+		DriverOpts: secret.DriverOptions,
+		Labels:     labels,
+		Metadata:   metadata,
+		Replace:    true,
+	}
+	if _, err := manager.Store(name, data, secret.Driver, storeOpts); err != nil {
+		return fmt.Errorf("keeping version %d of secret %s: %w", version, secret.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/asciicast"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/resize"
+	"go.podman.io/storage/pkg/stringid"
+)
+
+const (
+	// RecordSessionsEnv is the environment variable overriding the
+	// --record-sessions option of podman.
+	RecordSessionsEnv = "PODMAN_RECORD_SESSIONS"
+
+	// RecordingTypeExec is the type of recordings of exec sessions.
+	RecordingTypeExec = "exec"
+	// RecordingTypeAttach is the type of recordings of attach sessions.
+	RecordingTypeAttach = "attach"
+)
+
+// SessionRecordingsDir returns the directory recordings of exec and attach
+// sessions are stored in.
+func (r *Runtime) SessionRecordingsDir() string {
+	return filepath.Join(r.config.Engine.StaticDir, "recordings")
+}
+
+// shouldRecord returns whether a session is recorded, either because it was
+// requested or because the runtime was created WithRecordSessions, or
+// RecordSessionsEnv is set, and the session is attached to STDIN.
+func (r *Runtime) shouldRecord(requested, interactive bool) bool {
+	if requested {
+		return true
+	}
+	if !interactive {
+		return false
+	}
+	if value, ok := os.LookupEnv(RecordSessionsEnv); ok {
+		record, err := strconv.ParseBool(value)
+		if err == nil {
+			return record
+		}
+		logrus.Warnf("Invalid value %q of %s, ignoring it: %v", value, RecordSessionsEnv, err)
+	}
+	return r.recordSessions
+}
+
+// activeRecorders holds the recorders of the running HTTP exec and attach
+// sessions, by exec session ID or container ID, so that the terminal size
+// changes requested by the resize endpoints are recorded.
+var activeRecorders sync.Map
+
+// sessionRecorder records the output and the terminal size changes of an
+// exec or attach session.
+type sessionRecorder struct {
+	writer *asciicast.Writer
+	path   string
+	// key is the key of the recorder in activeRecorders, if any.
+	key string
+	// failed logs the first error writing the recording, the session is
+	// not interrupted by it.
+	failed sync.Once
+}
+
+// newSessionRecorder creates a recording of a session of the container.
+// size is the initial size of the terminal, if known.
+func (c *Container) newSessionRecorder(sessionType, execID string, command []string, size *resize.TerminalSize) (*sessionRecorder, error) {
+	dir := c.runtime.SessionRecordingsDir()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating session recordings directory: %w", err)
+	}
+	id := stringid.GenerateRandomID()
+	path := filepath.Join(dir, id+".cast")
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("creating session recording: %w", err)
+	}
+
+	session := &asciicast.Session{
+		ID:            id,
+		Type:          sessionType,
+		ContainerID:   c.ID(),
+		ContainerName: c.Name(),
+		ExecID:        execID,
+		UID:           os.Getuid(),
+		SudoUser:      os.Getenv("SUDO_USER"),
+	}
+	if u, err := user.Current(); err == nil {
+		session.User = u.Username
+	}
+	header := asciicast.Header{
+		Command: strings.Join(command, " "),
+		Title:   fmt.Sprintf("podman %s %s", sessionType, c.Name()),
+		Session: session,
+	}
+	if term := os.Getenv("TERM"); term != "" {
+		header.Env = map[string]string{"TERM": term}
+	}
+	if size != nil {
+		header.Width = int(size.Width)
+		header.Height = int(size.Height)
+	}
+
+	logrus.Debugf("Recording %s session of container %s to %s", sessionType, c.ID(), path)
+	return &sessionRecorder{writer: asciicast.NewWriter(f, header), path: path}, nil
+}
+
+func (s *sessionRecorder) fail(err error) {
+	s.failed.Do(func() {
+		logrus.Errorf("Recording session to %s: %v", s.path, err)
+	})
+}
+
+// streams returns a copy of streams whose output is recorded.
+func (s *sessionRecorder) streams(streams *define.AttachStreams) *define.AttachStreams {
+	recorded := *streams
+	if streams.AttachOutput && streams.OutputStream != nil {
+		recorded.OutputStream = &recordingWriter{w: streams.OutputStream, recorder: s}
+	}
+	if streams.AttachError && streams.ErrorStream != nil {
+		recorded.ErrorStream = &recordingWriter{w: streams.ErrorStream, recorder: s}
+	}
+	return &recorded
+}
+
+// resize records a change of the terminal size.
+func (s *sessionRecorder) resize(size resize.TerminalSize) {
+	if err := s.writer.Resize(int(size.Width), int(size.Height)); err != nil {
+		s.fail(err)
+	}
+}
+
+// resizes returns a channel passing on the terminal size changes received
+// on in, after recording them.
+func (s *sessionRecorder) resizes(in <-chan resize.TerminalSize) <-chan resize.TerminalSize {
+	if in == nil {
+		return nil
+	}
+	out := make(chan resize.TerminalSize)
+	go func() {
+		defer close(out)
+		for size := range in {
+			s.resize(size)
+			out <- size
+		}
+	}()
+	return out
+}
+
+// register adds the recorder to activeRecorders with key.
+func (s *sessionRecorder) register(key string) {
+	s.key = key
+	activeRecorders.Store(key, s)
+}
+
+// recordResize records a change of the terminal size of the session with
+// key, if it is recorded.
+func recordResize(key string, size resize.TerminalSize) {
+	if s, ok := activeRecorders.Load(key); ok {
+		s.(*sessionRecorder).resize(size)
+	}
+}
+
+// close finishes the recording.
+func (s *sessionRecorder) close() {
+	if s.key != "" {
+		activeRecorders.CompareAndDelete(s.key, s)
+	}
+	if err := s.writer.Close(); err != nil {
+		s.fail(err)
+	}
+}
+
+// output returns a writer recording the output of the session, or nil if
+// s is nil.
+func (s *sessionRecorder) output() io.Writer {
+	if s == nil {
+		return nil
+	}
+	return &recordingWriter{w: io.Discard, recorder: s}
+}
+
+// recordingWriter records everything written to w.
+type recordingWriter struct {
+	w        io.Writer
+	recorder *sessionRecorder
+}
+
+func (r *recordingWriter) Write(p []byte) (int, error) {
+	n, err := r.w.Write(p)
+	if n > 0 {
+		if err := r.recorder.writer.WriteOutput(p[:n]); err != nil {
+			r.recorder.fail(err)
+		}
+	}
+	return n, err
+}
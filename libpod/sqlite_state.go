@@ -2322,3 +2322,140 @@ func (s *SQLiteState) ContainerIDIsVolume(id string) (bool, error) {
 
 	return true, nil
 }
+
+// AddNetworkPolicy adds the network policy to the state. An existing policy
+// of the same name is replaced if replace is set.
+func (s *SQLiteState) AddNetworkPolicy(policy *define.NetworkPolicy, replace bool) (defErr error) {
+	if policy.Name == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("marshalling network policy %s json: %w", policy.Name, err)
+	}
+
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning network policy create transaction: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to create network policy: %v", err)
+			}
+		}
+	}()
+
+	if !replace {
+		var check int
+		row := tx.QueryRow("SELECT 1 FROM NetworkPolicy WHERE Name=?;", policy.Name)
+		if err := row.Scan(&check); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("checking if network policy name %s exists in database: %w", policy.Name, err)
+			}
+		} else if check != 0 {
+			return fmt.Errorf("network policy %s: %w", policy.Name, define.ErrNetworkPolicyExists)
+		}
+	}
+
+	if _, err := tx.Exec("INSERT OR REPLACE INTO NetworkPolicy VALUES (?, ?);", policy.Name, policyJSON); err != nil {
+		return fmt.Errorf("adding network policy %s to database: %w", policy.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveNetworkPolicy removes the network policy with the given name from the
+// state.
+func (s *SQLiteState) RemoveNetworkPolicy(name string) error {
+	if name == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	result, err := s.conn.Exec("DELETE FROM NetworkPolicy WHERE Name=?;", name)
+	if err != nil {
+		return fmt.Errorf("removing network policy %s from database: %w", name, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("retrieving network policy %s delete rows affected: %w", name, err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("network policy %s: %w", name, define.ErrNoSuchNetworkPolicy)
+	}
+
+	return nil
+}
+
+// NetworkPolicy retrieves the network policy with the given name.
+func (s *SQLiteState) NetworkPolicy(name string) (*define.NetworkPolicy, error) {
+	if name == "" {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	row := s.conn.QueryRow("SELECT JSON FROM NetworkPolicy WHERE Name=?;", name)
+
+	var policyJSON string
+	if err := row.Scan(&policyJSON); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("network policy %s: %w", name, define.ErrNoSuchNetworkPolicy)
+		}
+		return nil, fmt.Errorf("querying network policy %s: %w", name, err)
+	}
+
+	policy := new(define.NetworkPolicy)
+	if err := json.Unmarshal([]byte(policyJSON), policy); err != nil {
+		return nil, fmt.Errorf("unmarshalling network policy %s JSON: %w", name, err)
+	}
+
+	return policy, nil
+}
+
+// AllNetworkPolicies returns all network policies in the state, sorted by
+// name.
+func (s *SQLiteState) AllNetworkPolicies() ([]define.NetworkPolicy, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	rows, err := s.conn.Query("SELECT JSON FROM NetworkPolicy ORDER BY Name;")
+	if err != nil {
+		return nil, fmt.Errorf("querying database for all network policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []define.NetworkPolicy
+	for rows.Next() {
+		var policyJSON string
+		if err := rows.Scan(&policyJSON); err != nil {
+			return nil, fmt.Errorf("scanning network policy from database: %w", err)
+		}
+		var policy define.NetworkPolicy
+		if err := json.Unmarshal([]byte(policyJSON), &policy); err != nil {
+			return nil, fmt.Errorf("unmarshalling network policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
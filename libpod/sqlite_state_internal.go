@@ -32,14 +32,13 @@ func initSQLiteDB(conn *sql.DB) (defErr error) {
 		}
 	}()
 
-	sameSchema, err := migrateSchemaIfNecessary(tx)
-	if err != nil {
+	if _, err := migrateSchemaIfNecessary(tx); err != nil {
 		return err
 	}
-	if !sameSchema {
-		if err := createSQLiteTables(tx); err != nil {
-			return err
-		}
+	// Tables such as NetworkPolicy were added without a schema change,
+	// create the missing ones for databases of the same schema as well.
+	if err := createSQLiteTables(tx); err != nil {
+		return err
 	}
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing transaction: %w", err)
@@ -206,6 +205,12 @@ func createSQLiteTables(tx *sql.Tx) error {
                 FOREIGN KEY (Name) REFERENCES VolumeConfig(Name) DEFERRABLE INITIALLY DEFERRED
         );`
 
+	const networkPolicy = `
+        CREATE TABLE IF NOT EXISTS NetworkPolicy(
+                Name TEXT PRIMARY KEY NOT NULL,
+                JSON TEXT NOT NULL
+        );`
+
 	tables := map[string]string{
 		"DBConfig":             dbConfig,
 		"IDNamespace":          idNamespace,
@@ -219,6 +224,7 @@ func createSQLiteTables(tx *sql.Tx) error {
 		"PodState":             podState,
 		"VolumeConfig":         volumeConfig,
 		"VolumeState":          volumeState,
+		"NetworkPolicy":        networkPolicy,
 	}
 
 	for tblName, cmd := range tables {
@@ -2,7 +2,10 @@
 
 package libpod
 
-import "go.podman.io/common/libnetwork/types"
+import (
+	"github.com/containers/podman/v6/libpod/define"
+	"go.podman.io/common/libnetwork/types"
+)
 
 // State is a storage backend for libpod's current state.
 // A State is only initialized once per instance of libpod.
@@ -220,4 +223,15 @@ type State interface { //nolint:interfacebloat
 	SaveVolume(volume *Volume) error
 	// AllVolumes returns all the volumes available in the state
 	AllVolumes() ([]*Volume, error)
+
+	// AddNetworkPolicy adds the network policy to the state. An existing
+	// policy of the same name is replaced if replace is set, otherwise
+	// adding it fails.
+	AddNetworkPolicy(policy *define.NetworkPolicy, replace bool) error
+	// RemoveNetworkPolicy removes the network policy with the given name.
+	RemoveNetworkPolicy(name string) error
+	// NetworkPolicy returns the network policy with the given name.
+	NetworkPolicy(name string) (*define.NetworkPolicy, error)
+	// AllNetworkPolicies returns all network policies, sorted by name.
+	AllNetworkPolicies() ([]define.NetworkPolicy, error)
 }
@@ -2457,3 +2457,85 @@ func TestGetContainerConfigNonExistentIDFails(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestAddAndGetNetworkPolicy(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, _ lock.Manager) {
+		policy := &define.NetworkPolicy{
+			Name:        "deny-all",
+			Networks:    []string{"podman"},
+			PodSelector: map[string]string{"app": "web"},
+			PolicyTypes: []string{define.NetworkPolicyTypeIngress},
+		}
+		err := state.AddNetworkPolicy(policy, false)
+		assert.NoError(t, err)
+
+		retrieved, err := state.NetworkPolicy(policy.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, policy, retrieved)
+
+		err = state.AddNetworkPolicy(policy, false)
+		assert.ErrorIs(t, err, define.ErrNetworkPolicyExists)
+
+		replaced := *policy
+		replaced.PolicyTypes = []string{define.NetworkPolicyTypeEgress}
+		err = state.AddNetworkPolicy(&replaced, true)
+		assert.NoError(t, err)
+
+		retrieved, err = state.NetworkPolicy(policy.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, &replaced, retrieved)
+	})
+}
+
+func TestGetNonexistentNetworkPolicyFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, _ lock.Manager) {
+		_, err := state.NetworkPolicy("does not exist")
+		assert.ErrorIs(t, err, define.ErrNoSuchNetworkPolicy)
+
+		err = state.RemoveNetworkPolicy("does not exist")
+		assert.ErrorIs(t, err, define.ErrNoSuchNetworkPolicy)
+	})
+}
+
+func TestAllNetworkPoliciesSortedByName(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, _ lock.Manager) {
+		policies, err := state.AllNetworkPolicies()
+		assert.NoError(t, err)
+		assert.Empty(t, policies)
+
+		for _, name := range []string{"web", "db", "cache"} {
+			err := state.AddNetworkPolicy(&define.NetworkPolicy{Name: name, Networks: []string{"podman"}}, false)
+			assert.NoError(t, err)
+		}
+		err = state.RemoveNetworkPolicy("db")
+		assert.NoError(t, err)
+
+		policies, err = state.AllNetworkPolicies()
+		assert.NoError(t, err)
+		require.Len(t, policies, 2)
+		assert.Equal(t, "cache", policies[0].Name)
+		assert.Equal(t, "web", policies[1].Name)
+	})
+}
+
+func TestNetworkPolicyTableCreatedInExistingDatabase(t *testing.T) {
+	state, path, _, err := getEmptySqliteState()
+	require.NoError(t, err)
+	defer os.RemoveAll(path)
+
+	// A database of the current schema created before the NetworkPolicy
+	// table was added.
+	sqliteState := state.(*SQLiteState)
+	_, err = sqliteState.conn.Exec("INSERT INTO DBConfig VALUES (1, ?, 'linux', '', '', '', '', '', '');", schemaVersion)
+	require.NoError(t, err)
+	_, err = sqliteState.conn.Exec("DROP TABLE NetworkPolicy;")
+	require.NoError(t, err)
+	require.NoError(t, state.Close())
+
+	state, err = NewSqliteState(sqliteState.runtime)
+	require.NoError(t, err)
+	defer state.Close()
+
+	err = state.AddNetworkPolicy(&define.NetworkPolicy{Name: "web", Networks: []string{"podman"}}, false)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,282 @@
+// Package analyze reports the contents of the layers of an image and the
+// space wasted by files which later layers replace or delete.
+package analyze
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = whiteoutPrefix + whiteoutPrefix + ".opq"
+)
+
+// ChangeKind is the kind of a change of a layer to a path.
+type ChangeKind string
+
+const (
+	// Added paths did not exist in the lower layers.
+	Added ChangeKind = "added"
+	// Modified paths replace a path of the lower layers.
+	Modified ChangeKind = "modified"
+	// Deleted paths of the lower layers are whited out by the layer.
+	Deleted ChangeKind = "deleted"
+)
+
+// Change is a change of a layer to a path.
+type Change struct {
+	// Path is the absolute path in the image.
+	Path string
+	// Kind of the change.
+	Kind ChangeKind
+	// Size of the file in the layer.  For deleted paths it is the size of
+	// the files removed from the lower layers.
+	Size int64
+}
+
+// Layer describes the changes of one layer.
+type Layer struct {
+	// ID of the layer in the local storage.
+	ID string
+	// CreatedBy is the command which created the layer, according to the
+	// image history.
+	CreatedBy string
+	// Size is the size of the files in the layer.
+	Size int64
+	// Added is the number of paths added by the layer.
+	Added int
+	// Modified is the number of paths of lower layers replaced by the
+	// layer.
+	Modified int
+	// Deleted is the number of paths of lower layers deleted by the layer.
+	Deleted int
+	// WastedBytes is the size of the files of lower layers replaced or
+	// deleted by the layer.
+	WastedBytes int64
+	// Changes made by the layer.  Directories which already exist in lower
+	// layers are not listed.
+	Changes []Change
+}
+
+// WastedFile is a path whose content is stored in more than one layer or
+// which is deleted by a later layer.
+type WastedFile struct {
+	// Path is the absolute path in the image.
+	Path string
+	// Count is the number of layers storing the path.
+	Count int
+	// WastedBytes is the size of the copies of the path which are not
+	// visible in the image.
+	WastedBytes int64
+}
+
+// Report is the result of analyzing the layers of an image.
+type Report struct {
+	// Layers of the image from the base to the top layer.
+	Layers []Layer
+	// TotalBytes is the size of the files of all layers.
+	TotalBytes int64
+	// WastedBytes is the size of the files of all layers which are
+	// replaced or deleted by a later layer.
+	WastedBytes int64
+	// Efficiency is the percentage of TotalBytes visible in the image.
+	Efficiency float64
+	// Wasted lists the paths wasting space, the most wasteful first.
+	Wasted []WastedFile
+}
+
+// node is a path in the file system resulting from the layers.
+type node struct {
+	children map[string]*node
+	dir      bool
+	size     int64
+}
+
+// Analyzer applies layers from the bottom up and records their changes.
+type Analyzer struct {
+	root   *node
+	report Report
+	wasted map[string]*WastedFile
+	// count is the number of layers storing a path.
+	count map[string]int
+}
+
+// NewAnalyzer returns an Analyzer without any layer.
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{
+		root:   &node{dir: true, children: make(map[string]*node)},
+		wasted: make(map[string]*WastedFile),
+		count:  make(map[string]int),
+	}
+}
+
+// lookup returns the node of the cleaned absolute path p and its parent.  If
+// create is set, missing parent directories are created.
+func (a *Analyzer) lookup(p string, create bool) (parent, n *node) {
+	parent = a.root
+	if p == "/" {
+		return nil, a.root
+	}
+	parts := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	for i, part := range parts {
+		child := parent.children[part]
+		if i == len(parts)-1 {
+			return parent, child
+		}
+		if child == nil || !child.dir {
+			if !create {
+				return nil, nil
+			}
+			child = &node{dir: true, children: make(map[string]*node)}
+			parent.children[part] = child
+		}
+		parent = child
+	}
+	return parent, nil
+}
+
+// waste records that the content of p stored in a lower layer is no longer
+// visible.
+func (a *Analyzer) waste(p string, size int64) {
+	w := a.wasted[p]
+	if w == nil {
+		w = &WastedFile{Path: p}
+		a.wasted[p] = w
+	}
+	w.Count = a.count[p]
+	w.WastedBytes += size
+}
+
+// removeTree records the files below and including n at p as wasted and
+// returns their size.
+func (a *Analyzer) removeTree(p string, n *node) int64 {
+	var size int64
+	if !n.dir && n.size > 0 {
+		a.waste(p, n.size)
+		size += n.size
+	}
+	for name, child := range n.children {
+		size += a.removeTree(path.Join(p, name), child)
+	}
+	return size
+}
+
+// AddLayer applies the uncompressed layer tarball read from r on top of the
+// previously added layers.
+func (a *Analyzer) AddLayer(id, createdBy string, r io.Reader) error {
+	layer := Layer{ID: id, CreatedBy: createdBy}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("reading layer %s: %w", id, err)
+		}
+		name := path.Clean("/" + hdr.Name)
+		dir, base := path.Split(name)
+		switch {
+		case base == whiteoutOpaque:
+			// The directory hides all content of lower layers.
+			dir = path.Clean(dir)
+			_, n := a.lookup(dir, false)
+			if n == nil || !n.dir {
+				continue
+			}
+			var size int64
+			for child, c := range n.children {
+				size += a.removeTree(path.Join(dir, child), c)
+			}
+			n.children = make(map[string]*node)
+			layer.Deleted++
+			layer.WastedBytes += size
+			layer.Changes = append(layer.Changes, Change{Path: dir, Kind: Deleted, Size: size})
+			continue
+		case strings.HasPrefix(base, whiteoutPrefix):
+			removed := path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix))
+			var size int64
+			if parent, n := a.lookup(removed, false); n != nil {
+				size = a.removeTree(removed, n)
+				delete(parent.children, path.Base(removed))
+			}
+			layer.Deleted++
+			layer.WastedBytes += size
+			layer.Changes = append(layer.Changes, Change{Path: removed, Kind: Deleted, Size: size})
+			continue
+		}
+
+		isDir := hdr.Typeflag == tar.TypeDir
+		var size int64
+		if hdr.Typeflag == tar.TypeReg {
+			size = hdr.Size
+		}
+		layer.Size += size
+
+		parent, n := a.lookup(name, true)
+		if parent == nil {
+			// The root directory itself.
+			continue
+		}
+		if !isDir {
+			a.count[name]++
+		}
+		switch {
+		case n == nil:
+			n = &node{dir: isDir, size: size}
+			if isDir {
+				n.children = make(map[string]*node)
+			}
+			parent.children[path.Base(name)] = n
+			layer.Added++
+			layer.Changes = append(layer.Changes, Change{Path: name, Kind: Added, Size: size})
+		case isDir && n.dir:
+			// Lower layers contain the directory already, only its
+			// metadata may change.
+			continue
+		default:
+			wasted := a.removeTree(name, n)
+			n.dir = isDir
+			n.size = size
+			n.children = nil
+			if isDir {
+				n.children = make(map[string]*node)
+			}
+			layer.Modified++
+			layer.WastedBytes += wasted
+			layer.Changes = append(layer.Changes, Change{Path: name, Kind: Modified, Size: size})
+		}
+	}
+
+	a.report.Layers = append(a.report.Layers, layer)
+	a.report.TotalBytes += layer.Size
+	a.report.WastedBytes += layer.WastedBytes
+	return nil
+}
+
+// Report returns the result of the layers added so far.
+func (a *Analyzer) Report() *Report {
+	report := a.report
+	report.Efficiency = 100
+	if report.TotalBytes > 0 {
+		report.Efficiency = float64(report.TotalBytes-report.WastedBytes) / float64(report.TotalBytes) * 100
+	}
+	report.Wasted = make([]WastedFile, 0, len(a.wasted))
+	for _, w := range a.wasted {
+		report.Wasted = append(report.Wasted, *w)
+	}
+	sort.Slice(report.Wasted, func(i, j int) bool {
+		if report.Wasted[i].WastedBytes != report.Wasted[j].WastedBytes {
+			return report.Wasted[i].WastedBytes > report.Wasted[j].WastedBytes
+		}
+		return report.Wasted[i].Path < report.Wasted[j].Path
+	})
+	return &report
+}
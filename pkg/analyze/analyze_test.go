@@ -0,0 +1,105 @@
+package analyze
+
+import (
+	"archive/tar"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type layerFile struct {
+	name    string
+	content string
+	dir     bool
+}
+
+func makeLayer(t *testing.T, files ...layerFile) *bytes.Buffer {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for _, f := range files {
+		hdr := &tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.content)), Typeflag: tar.TypeReg}
+		if f.dir {
+			hdr = &tar.Header{Name: f.name, Mode: 0o755, Typeflag: tar.TypeDir}
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		if !f.dir {
+			_, err := tw.Write([]byte(f.content))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	return buf
+}
+
+func TestAnalyzer(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.AddLayer("base", "ADD rootfs.tar /", makeLayer(t,
+		layerFile{name: "etc/", dir: true},
+		layerFile{name: "etc/config", content: "0123456789"},
+		layerFile{name: "var/cache/pkg/index", content: strings.Repeat("x", 50)},
+		layerFile{name: "var/cache/pkg/data", content: strings.Repeat("y", 30)},
+		layerFile{name: "bin/tool", content: strings.Repeat("z", 10)},
+	)))
+	require.NoError(t, a.AddLayer("update", "RUN update", makeLayer(t,
+		layerFile{name: "etc/", dir: true},
+		layerFile{name: "etc/config", content: "01234"},
+		layerFile{name: "var/cache/.wh.pkg"},
+		layerFile{name: "bin/.wh..wh..opq"},
+		layerFile{name: "bin/new", content: "new"},
+		layerFile{name: "etc/.wh.missing"},
+	)))
+
+	report := a.Report()
+	require.Len(t, report.Layers, 2)
+
+	base := report.Layers[0]
+	assert.Equal(t, "base", base.ID)
+	assert.Equal(t, "ADD rootfs.tar /", base.CreatedBy)
+	assert.Equal(t, int64(100), base.Size)
+	assert.Equal(t, 5, base.Added)
+	assert.Zero(t, base.Modified)
+	assert.Zero(t, base.Deleted)
+	assert.Zero(t, base.WastedBytes)
+	assert.Contains(t, base.Changes, Change{Path: "/etc", Kind: Added})
+	assert.Contains(t, base.Changes, Change{Path: "/var/cache/pkg/index", Kind: Added, Size: 50})
+
+	update := report.Layers[1]
+	assert.Equal(t, int64(8), update.Size)
+	assert.Equal(t, 1, update.Added)
+	assert.Equal(t, 1, update.Modified)
+	assert.Equal(t, 3, update.Deleted)
+	assert.Equal(t, int64(100), update.WastedBytes)
+	assert.Equal(t, []Change{
+		{Path: "/etc/config", Kind: Modified, Size: 5},
+		{Path: "/var/cache/pkg", Kind: Deleted, Size: 80},
+		{Path: "/bin", Kind: Deleted, Size: 10},
+		{Path: "/bin/new", Kind: Added, Size: 3},
+		{Path: "/etc/missing", Kind: Deleted},
+	}, update.Changes)
+
+	assert.Equal(t, int64(108), report.TotalBytes)
+	assert.Equal(t, int64(100), report.WastedBytes)
+	assert.InDelta(t, 100.0*8/108, report.Efficiency, 0.001)
+	assert.Equal(t, []WastedFile{
+		{Path: "/var/cache/pkg/index", Count: 1, WastedBytes: 50},
+		{Path: "/var/cache/pkg/data", Count: 1, WastedBytes: 30},
+		{Path: "/bin/tool", Count: 1, WastedBytes: 10},
+		{Path: "/etc/config", Count: 2, WastedBytes: 10},
+	}, report.Wasted)
+}
+
+func TestAnalyzerEmpty(t *testing.T) {
+	report := NewAnalyzer().Report()
+	assert.Empty(t, report.Layers)
+	assert.Empty(t, report.Wasted)
+	assert.InDelta(t, 100.0, report.Efficiency, 0)
+}
+
+func TestAnalyzerInvalidLayer(t *testing.T) {
+	err := NewAnalyzer().AddLayer("broken", "", strings.NewReader("not a tarball but long enough to not be EOF immediately, really"+strings.Repeat(" ", 512)))
+	assert.ErrorContains(t, err, "reading layer broken")
+}
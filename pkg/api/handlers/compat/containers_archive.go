@@ -98,10 +98,11 @@ func handleHeadAndGet(w http.ResponseWriter, r *http.Request, decoder *schema.De
 
 func handlePut(w http.ResponseWriter, r *http.Request, decoder *schema.Decoder, runtime *libpod.Runtime) {
 	query := struct {
-		Path                 string `schema:"path"`
-		Chown                bool   `schema:"copyUIDGID"`
-		Rename               string `schema:"rename"`
-		NoOverwriteDirNonDir bool   `schema:"noOverwriteDirNonDir"`
+		Path                 string   `schema:"path"`
+		Chown                bool     `schema:"copyUIDGID"`
+		Rename               string   `schema:"rename"`
+		Remove               []string `schema:"remove"`
+		NoOverwriteDirNonDir bool     `schema:"noOverwriteDirNonDir"`
 	}{
 		Chown: utils.IsLibpodRequest(r), // backward compatibility
 	}
@@ -128,6 +129,7 @@ func handlePut(w http.ResponseWriter, r *http.Request, decoder *schema.Decoder,
 			Chown:                query.Chown,
 			NoOverwriteDirNonDir: query.NoOverwriteDirNonDir,
 			Rename:               rename,
+			Remove:               query.Remove,
 		})
 	if err != nil {
 		switch {
@@ -135,7 +137,7 @@ func handlePut(w http.ResponseWriter, r *http.Request, decoder *schema.Decoder,
 			// 404 is returned for an absent container and path.  The
 			// clients must deal with it accordingly.
 			utils.Error(w, http.StatusNotFound, fmt.Errorf("the container does not exist: %w", err))
-		case strings.Contains(err.Error(), "copier: put: error creating file"):
+		case errors.Is(err, define.ErrInvalidArg) || strings.Contains(err.Error(), "copier: put: error creating file"):
 			// Not the best test but need to break this out for compatibility
 			// See vendor/github.com/containers/buildah/copier/copier.go:1585
 			utils.Error(w, http.StatusBadRequest, err)
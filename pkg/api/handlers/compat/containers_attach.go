@@ -24,6 +24,7 @@ func AttachContainer(w http.ResponseWriter, r *http.Request) {
 		Stdin      bool   `schema:"stdin"`
 		Stdout     bool   `schema:"stdout"`
 		Stderr     bool   `schema:"stderr"`
+		Record     bool   `schema:"record"`
 	}{
 		Stream: true,
 	}
@@ -56,6 +57,10 @@ func AttachContainer(w http.ResponseWriter, r *http.Request) {
 		streams.Stderr = query.Stderr
 		useStreams = true
 	}
+	if query.Record {
+		streams.Record = true
+		useStreams = true
+	}
 	if !useStreams {
 		streams = nil
 	}
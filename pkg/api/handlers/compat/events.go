@@ -28,9 +28,10 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 	// NOTE: the "filters" parameter is extracted separately for backwards
 	// compat via `filterFromRequest()`.
 	query := struct {
-		Since  string `schema:"since"`
-		Until  string `schema:"until"`
-		Stream bool   `schema:"stream"`
+		Since       string `schema:"since"`
+		SinceCursor string `schema:"sinceCursor"`
+		Until       string `schema:"until"`
+		Stream      bool   `schema:"stream"`
 	}{
 		Stream: true,
 	}
@@ -39,7 +40,7 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(query.Since) > 0 || len(query.Until) > 0 {
+	if len(query.Since) > 0 || len(query.Until) > 0 || len(query.SinceCursor) > 0 {
 		fromStart = true
 	}
 
@@ -56,6 +57,7 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 		Filters:      libpodFilters,
 		EventChannel: eventChannel,
 		Since:        query.Since,
+		SinceCursor:  query.SinceCursor,
 		Until:        query.Until,
 	}
 	err = runtime.Events(r.Context(), readOpts)
@@ -192,8 +192,23 @@ func ExecStartHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	var streams *libpod.HTTPAttachStreams
+	if bodyParams.Record {
+		session, err := sessionCtr.ExecSession(sessionID)
+		if err != nil {
+			utils.Error(w, http.StatusNotFound, err)
+			return
+		}
+		streams = &libpod.HTTPAttachStreams{
+			Stdin:  session.Config.AttachStdin,
+			Stdout: session.Config.AttachStdout,
+			Stderr: session.Config.AttachStderr,
+			Record: true,
+		}
+	}
+
 	hijackChan := make(chan bool, 1)
-	err = sessionCtr.ExecHTTPStartAndAttach(sessionID, r, w, nil, nil, nil, hijackChan, size)
+	err = sessionCtr.ExecHTTPStartAndAttach(sessionID, r, w, streams, nil, nil, hijackChan, size)
 
 	if <-hijackChan {
 		// If connection was Hijacked, we have to signal it's being closed
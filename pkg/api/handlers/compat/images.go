@@ -21,6 +21,7 @@ import (
 	"github.com/containers/podman/v6/pkg/domain/entities"
 	"github.com/containers/podman/v6/pkg/domain/infra/abi"
 	"github.com/containers/podman/v6/pkg/util"
+	"github.com/containers/podman/v6/pkg/where"
 	dockerContainer "github.com/docker/docker/api/types/container"
 	dockerImage "github.com/docker/docker/api/types/image"
 	dockerStorage "github.com/docker/docker/api/types/storage"
@@ -429,6 +430,7 @@ func GetImages(w http.ResponseWriter, r *http.Request) {
 		Digests    bool
 		Filter     string // Docker 1.24 compatibility
 		SharedSize bool   `schema:"shared-size"` // Docker 1.42 compatibility
+		Where      string `schema:"where"`       // libpod only
 	}{
 		// This is where you can override the golang default value for one of fields
 	}
@@ -468,6 +470,13 @@ func GetImages(w http.ResponseWriter, r *http.Request) {
 	imageEngine := abi.ImageEngine{Libpod: runtime}
 
 	listOptions := entities.ImageListOptions{All: query.All, Filter: filterList, ExtendedAttributes: utils.IsLibpodRequest(r)}
+	if utils.IsLibpodRequest(r) && query.Where != "" {
+		if _, err := where.Compile(query.Where); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		listOptions.Where = query.Where
+	}
 	summaries, err := imageEngine.List(r.Context(), listOptions)
 	if err != nil {
 		utils.Error(w, http.StatusInternalServerError, err)
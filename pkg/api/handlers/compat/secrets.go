@@ -20,15 +20,24 @@ import (
 )
 
 func ListSecrets(w http.ResponseWriter, r *http.Request) {
+	decoder := utils.GetDecoder(r)
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	filtersMap, err := util.PrepareFilters(r)
 	if err != nil {
 		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
 		return
 	}
+	query := struct {
+		All bool `schema:"all"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
 	ic := abi.ContainerEngine{Libpod: runtime}
 	listOptions := entities.SecretListRequest{
 		Filters: *filtersMap,
+		All:     query.All,
 	}
 	reports, err := ic.SecretList(r.Context(), listOptions)
 	if err != nil {
@@ -40,12 +49,11 @@ func ListSecrets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Docker compat expects a version field that increments when the secret is updated
-	// We currently can't update a secret, so we default the version to 1
 	compatReports := make([]entities.SecretInfoReportCompat, 0, len(reports))
 	for _, report := range reports {
 		compatRep := entities.SecretInfoReportCompat{
 			SecretInfoReport: *report,
-			Version:          entities.SecretVersion{Index: 1},
+			Version:          entities.SecretVersion{Index: report.Version},
 		}
 		compatReports = append(compatReports, compatRep)
 	}
@@ -89,10 +97,9 @@ func InspectSecret(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Docker compat expects a version field that increments when the secret is updated
-	// We currently can't update a secret, so we default the version to 1
 	compatReport := entities.SecretInfoReportCompat{
 		SecretInfoReport: *reports[0],
-		Version:          entities.SecretVersion{Index: 1},
+		Version:          entities.SecretVersion{Index: reports[0].Version},
 	}
 	utils.WriteResponse(w, http.StatusOK, compatReport)
 }
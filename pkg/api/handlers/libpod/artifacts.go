@@ -11,6 +11,7 @@ import (
 
 	"github.com/containers/podman/v6/internal/localapi"
 	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v6/pkg/api/types"
 	"github.com/containers/podman/v6/pkg/auth"
@@ -215,6 +216,50 @@ func BatchRemoveArtifact(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, artifacts)
 }
 
+func RefreshArtifacts(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+
+	query := struct {
+		All        bool     `schema:"all"`
+		Containers []string `schema:"containers"`
+	}{}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	if query.All && len(query.Containers) > 0 {
+		utils.Error(w, http.StatusBadRequest, errors.New("when setting all to true, you may not pass any container names or IDs"))
+		return
+	}
+
+	if !query.All && len(query.Containers) < 1 {
+		utils.Error(w, http.StatusBadRequest, errors.New("a container or all option must be specified"))
+		return
+	}
+
+	imageEngine := abi.ImageEngine{Libpod: runtime}
+
+	refreshOptions := entities.ArtifactRefreshOptions{
+		All:        query.All,
+		Containers: query.Containers,
+	}
+
+	reports, err := imageEngine.ArtifactRefresh(r.Context(), refreshOptions)
+	if err != nil {
+		if errors.Is(err, define.ErrNoSuchCtr) {
+			utils.ContainerNotFound(w, "", err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
 type artifactAddRequestQuery struct {
 	Name             string   `schema:"name"`
 	FileName         string   `schema:"fileName"`
@@ -20,6 +20,7 @@ import (
 	"github.com/containers/podman/v6/pkg/domain/infra/abi"
 	"github.com/containers/podman/v6/pkg/specgenutil"
 	"github.com/containers/podman/v6/pkg/util"
+	"github.com/containers/podman/v6/pkg/where"
 	"github.com/gorilla/schema"
 	"github.com/sirupsen/logrus"
 )
@@ -66,13 +67,14 @@ func ContainerExists(w http.ResponseWriter, r *http.Request) {
 func ListContainers(w http.ResponseWriter, r *http.Request) {
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 	query := struct {
-		All       bool `schema:"all"`
-		External  bool `schema:"external"`
-		Last      int  `schema:"last"` // alias for limit
-		Limit     int  `schema:"limit"`
-		Namespace bool `schema:"namespace"`
-		Size      bool `schema:"size"`
-		Sync      bool `schema:"sync"`
+		All       bool   `schema:"all"`
+		External  bool   `schema:"external"`
+		Last      int    `schema:"last"` // alias for limit
+		Limit     int    `schema:"limit"`
+		Namespace bool   `schema:"namespace"`
+		Size      bool   `schema:"size"`
+		Sync      bool   `schema:"sync"`
+		Where     string `schema:"where"`
 	}{
 		// override any golang type defaults
 	}
@@ -97,6 +99,12 @@ func ListContainers(w http.ResponseWriter, r *http.Request) {
 		logrus.Info("List containers: received `last` parameter - overwriting `limit`")
 		limit = query.Last
 	}
+	if query.Where != "" {
+		if _, err := where.Compile(query.Where); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
 
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	// Now use the ABI implementation to prevent us from having duplicate
@@ -110,9 +118,10 @@ func ListContainers(w http.ResponseWriter, r *http.Request) {
 		Namespace: query.Namespace,
 		// Always return Pod, should not be part of the API.
 		// https://github.com/containers/podman/pull/7223
-		Pod:  true,
-		Size: query.Size,
-		Sync: query.Sync,
+		Pod:   true,
+		Size:  query.Size,
+		Sync:  query.Sync,
+		Where: query.Where,
 	}
 	pss, err := containerEngine.ContainerList(r.Context(), opts)
 	if err != nil {
@@ -464,6 +473,7 @@ func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 		RestartRetries:                  restartRetries,
 		Env:                             options.Env,
 		UnsetEnv:                        options.UnsetEnv,
+		NetworkRateLimits:               options.NetworkRateLimits,
 	}
 
 	err = ctr.Update(updateOptions)
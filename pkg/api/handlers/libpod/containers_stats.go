@@ -4,10 +4,12 @@ package libpod
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v6/pkg/api/types"
 	"github.com/containers/podman/v6/pkg/domain/entities"
@@ -81,3 +83,73 @@ func StatsContainer(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+func TopStatsContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	name := utils.GetName(r)
+
+	query := struct {
+		Stream   bool   `schema:"stream"`
+		Interval int    `schema:"interval"`
+		Sort     string `schema:"sort"`
+	}{
+		Stream:   true,
+		Interval: 5,
+		Sort:     "pid",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	options := entities.ContainerTopStatsOptions{
+		Stream:   query.Stream,
+		Interval: query.Interval,
+		Sort:     query.Sort,
+	}
+
+	// Stats will stop if the connection is closed.
+	statsChan, err := containerEngine.ContainerTopStats(r.Context(), name, options)
+	if err != nil {
+		if errors.Is(err, define.ErrNoSuchCtr) {
+			utils.ContainerNotFound(w, name, err)
+			return
+		}
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	wroteContent := false
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+
+	for stats := range statsChan {
+		if stats.Error != nil {
+			if !wroteContent {
+				if errors.Is(stats.Error, define.ErrCtrStopped) {
+					utils.Error(w, http.StatusConflict, stats.Error)
+				} else {
+					utils.InternalServerError(w, stats.Error)
+				}
+			}
+			// The stream ends when the container stops.
+			logrus.Debugf("Container top stats stopped: %v", stats.Error)
+			return
+		}
+		if !wroteContent {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			wroteContent = true
+		}
+
+		if err := coder.Encode(stats); err != nil {
+			logrus.Errorf("Unable to encode top stats: %v", err)
+			return
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"go.podman.io/storage/pkg/archive"
 
 	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v6/pkg/api/types"
 	"github.com/containers/podman/v6/pkg/auth"
@@ -113,6 +114,7 @@ func KubePlay(w http.ResponseWriter, r *http.Request) {
 		NoHosts          bool              `schema:"noHosts"`
 		NoTrunc          bool              `schema:"noTrunc"`
 		Replace          bool              `schema:"replace"`
+		Rolling          bool              `schema:"rolling"`
 		PublishPorts     []string          `schema:"publishPorts"`
 		PublishAllPorts  bool              `schema:"publishAllPorts"`
 		ServiceContainer bool              `schema:"serviceContainer"`
@@ -191,6 +193,7 @@ func KubePlay(w http.ResponseWriter, r *http.Request) {
 		PublishAllPorts:    query.PublishAllPorts,
 		Quiet:              true,
 		Replace:            query.Replace,
+		Rolling:            query.Rolling,
 		ServiceContainer:   query.ServiceContainer,
 		StaticIPs:          staticIPs,
 		StaticMACs:         staticMACs,
@@ -241,6 +244,38 @@ func KubePlayDown(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, report)
 }
 
+func KubeRollback(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Revision int `schema:"revision"`
+	}{
+		// Defaults would go here.
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Revision < 0 {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("invalid revision %d", query.Revision))
+		return
+	}
+
+	name := utils.GetName(r)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	report, err := containerEngine.KubeRollback(r.Context(), name, entities.KubeRollbackOptions{Revision: query.Revision})
+	if err != nil {
+		if errors.Is(err, define.ErrNoSuchPod) {
+			utils.PodNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
 func KubeGenerate(w http.ResponseWriter, r *http.Request) {
 	GenerateKube(w, r)
 }
@@ -248,3 +248,94 @@ func Prune(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusOK, pruneReports)
 }
+
+// CreateNetworkPolicy creates a network policy
+func CreateNetworkPolicy(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	policy := entities.NetworkPolicy{}
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to decode request JSON payload: %w", err))
+		return
+	}
+
+	query := struct {
+		Replace bool `schema:"replace"`
+	}{}
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	ic := abi.ContainerEngine{Libpod: runtime}
+	report, err := ic.NetworkPolicyCreate(r.Context(), policy, entities.NetworkPolicyCreateOptions{Replace: query.Replace})
+	if err != nil {
+		switch {
+		case errors.Is(err, define.ErrNetworkPolicyExists):
+			utils.Error(w, http.StatusConflict, err)
+		case errors.Is(err, define.ErrNoSuchNetwork):
+			utils.Error(w, http.StatusNotFound, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// ListNetworkPolicies lists all network policies
+func ListNetworkPolicies(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	reports, err := ic.NetworkPolicyList(r.Context())
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
+// InspectNetworkPolicy reports on a network policy
+func InspectNetworkPolicy(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	reports, errs, err := ic.NetworkPolicyInspect(r.Context(), []string{utils.GetName(r)})
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if len(errs) > 0 {
+		utils.Error(w, http.StatusNotFound, errs[0])
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports[0])
+}
+
+// RemoveNetworkPolicy removes a network policy
+func RemoveNetworkPolicy(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Ignore bool `schema:"ignore"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	ic := abi.ContainerEngine{Libpod: runtime}
+	reports, err := ic.NetworkPolicyRm(r.Context(), []string{utils.GetName(r)}, entities.NetworkPolicyRmOptions{Ignore: query.Ignore})
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if len(reports) > 0 && reports[0].Err != nil {
+		if errors.Is(reports[0].Err, define.ErrNoSuchNetworkPolicy) {
+			utils.Error(w, http.StatusNotFound, reports[0].Err)
+			return
+		}
+		utils.InternalServerError(w, reports[0].Err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, nil)
+}
@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -11,6 +12,7 @@ import (
 	api "github.com/containers/podman/v6/pkg/api/types"
 	"github.com/containers/podman/v6/pkg/domain/entities"
 	"github.com/containers/podman/v6/pkg/domain/infra/abi"
+	"github.com/containers/podman/v6/pkg/signal"
 	"github.com/gorilla/schema"
 	"go.podman.io/common/pkg/secrets"
 )
@@ -22,14 +24,16 @@ func CreateSecret(w http.ResponseWriter, r *http.Request) {
 	)
 
 	query := struct {
-		Name       string            `schema:"name"`
-		Driver     string            `schema:"driver"`
-		DriverOpts map[string]string `schema:"driveropts"`
-		Labels     map[string]string `schema:"labels"`
-		Replace    bool              `schema:"replace"`
-		Ignore     bool              `schema:"ignore"`
+		Name         string            `schema:"name"`
+		Driver       string            `schema:"driver"`
+		DriverOpts   map[string]string `schema:"driveropts"`
+		Labels       map[string]string `schema:"labels"`
+		Replace      bool              `schema:"replace"`
+		Ignore       bool              `schema:"ignore"`
+		KeepVersions int               `schema:"keepversions"`
 	}{
 		// override any golang type defaults
+		KeepVersions: entities.DefaultSecretKeepVersions,
 	}
 	opts := entities.SecretCreateOptions{}
 	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
@@ -42,6 +46,7 @@ func CreateSecret(w http.ResponseWriter, r *http.Request) {
 	opts.Labels = query.Labels
 	opts.Replace = query.Replace
 	opts.Ignore = query.Ignore
+	opts.KeepVersions = query.KeepVersions
 
 	ic := abi.ContainerEngine{Libpod: runtime}
 	report, err := ic.SecretCreate(r.Context(), query.Name, r.Body, opts)
@@ -68,3 +73,116 @@ func SecretExists(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusNoContent, "")
 }
+
+func UpdateSecret(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+		decoder = r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	)
+
+	query := struct {
+		Signal       string `schema:"signal"`
+		Exec         string `schema:"exec"`
+		KeepVersions int    `schema:"keepversions"`
+	}{
+		// override any golang type defaults
+		KeepVersions: entities.DefaultSecretKeepVersions,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Signal != "" {
+		if _, err := signal.ParseSignalNameOrNumber(query.Signal); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	name := utils.GetName(r)
+	opts := entities.SecretUpdateOptions{
+		ReloadSignal: query.Signal,
+		ReloadExec:   query.Exec,
+		KeepVersions: query.KeepVersions,
+	}
+	ic := abi.ContainerEngine{Libpod: runtime}
+	report, err := ic.SecretUpdate(r.Context(), name, r.Body, opts)
+	if err != nil {
+		if errors.Is(err, secrets.ErrNoSuchSecret) {
+			utils.SecretNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func RollbackSecret(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+		decoder = r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	)
+
+	query := struct {
+		Version      int    `schema:"version"`
+		Signal       string `schema:"signal"`
+		Exec         string `schema:"exec"`
+		KeepVersions int    `schema:"keepversions"`
+	}{
+		// override any golang type defaults
+		KeepVersions: entities.DefaultSecretKeepVersions,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Version < 1 {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("invalid secret version %d", query.Version))
+		return
+	}
+	if query.Signal != "" {
+		if _, err := signal.ParseSignalNameOrNumber(query.Signal); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	name := utils.GetName(r)
+	opts := entities.SecretRollbackOptions{
+		Version: query.Version,
+		SecretUpdateOptions: entities.SecretUpdateOptions{
+			ReloadSignal: query.Signal,
+			ReloadExec:   query.Exec,
+			KeepVersions: query.KeepVersions,
+		},
+	}
+	ic := abi.ContainerEngine{Libpod: runtime}
+	report, err := ic.SecretRollback(r.Context(), name, opts)
+	if err != nil {
+		if errors.Is(err, secrets.ErrNoSuchSecret) {
+			utils.SecretNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func SecretHistory(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	ic := abi.ContainerEngine{Libpod: runtime}
+
+	report, err := ic.SecretHistory(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, secrets.ErrNoSuchSecret) {
+			utils.SecretNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
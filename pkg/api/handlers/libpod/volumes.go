@@ -20,6 +20,7 @@ import (
 	"github.com/containers/podman/v6/pkg/domain/infra/abi"
 	"github.com/containers/podman/v6/pkg/domain/infra/abi/parse"
 	"github.com/containers/podman/v6/pkg/util"
+	"github.com/containers/podman/v6/pkg/where"
 	"github.com/gorilla/schema"
 )
 
@@ -124,8 +125,16 @@ func ListVolumes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	whereExpr := r.URL.Query().Get("where")
+	if whereExpr != "" {
+		if _, err := where.Compile(whereExpr); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
 	ic := abi.ContainerEngine{Libpod: runtime}
-	volumeConfigs, err := ic.VolumeList(r.Context(), entities.VolumeListOptions{Filter: *filterMap})
+	volumeConfigs, err := ic.VolumeList(r.Context(), entities.VolumeListOptions{Filter: *filterMap, Where: whereExpr})
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
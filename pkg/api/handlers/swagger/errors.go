@@ -51,6 +51,13 @@ type networkNotFound struct {
 	Body errorhandling.ErrorModel
 }
 
+// No such network policy
+// swagger:response
+type networkPolicyNotFound struct {
+	// in:body
+	Body errorhandling.ErrorModel
+}
+
 // Network is already connected and container is running or transitioning to the running state ('initialized')
 // swagger:response
 type networkConnectedError struct {
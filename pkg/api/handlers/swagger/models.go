@@ -52,6 +52,10 @@ type networkConnectRequestLibpod entities.NetworkConnectOptions
 // swagger:model
 type networkUpdateRequestLibpod entities.NetworkUpdateOptions
 
+// Network policy create
+// swagger:model
+type networkPolicyCreateRequestLibpod entities.NetworkPolicy
+
 // Container update
 // swagger:model
 type containerUpdateRequest struct {
@@ -80,6 +80,13 @@ type playKubeResponseLibpod struct {
 	Body entities.PlayKubeReport
 }
 
+// Kube rollback response
+// swagger:response
+type kubeRollbackResponseLibpod struct {
+	// in:body
+	Body entities.KubeRollbackReport
+}
+
 // Image Delete
 // swagger:response
 type imageDeleteResponse struct {
@@ -310,6 +317,13 @@ type containerStats struct {
 	Body define.ContainerStats
 }
 
+// Get the resource usage of the processes of a container
+// swagger:response
+type containerTopStats struct {
+	// in:body
+	Body entities.ContainerTopStatsReport
+}
+
 // Volume Prune
 // swagger:response
 type volumePruneLibpod struct {
@@ -479,6 +493,20 @@ type networkPruneResponse struct {
 	Body []entities.NetworkPruneReport
 }
 
+// Network policy
+// swagger:response
+type networkPolicyResponse struct {
+	// in:body
+	Body entities.NetworkPolicy
+}
+
+// Network policy list
+// swagger:response
+type networkPolicyListResponse struct {
+	// in:body
+	Body []entities.NetworkPolicy
+}
+
 // Inspect Artifact
 // swagger:response
 type inspectArtifactResponse struct {
@@ -507,6 +535,13 @@ type artifactRemoveResponse struct {
 	Body entities.ArtifactRemoveReport
 }
 
+// Artifact Refresh
+// swagger:response
+type artifactRefreshResponse struct {
+	// in:body
+	Body []entities.ArtifactRefreshReport
+}
+
 // Artifact Add
 // swagger:response
 type artifactAddResponse struct {
@@ -84,8 +84,9 @@ type UpdateEntities struct {
 	specs.LinuxResources
 	define.UpdateHealthCheckConfig
 	define.UpdateContainerDevicesLimits
-	Env      []string
-	UnsetEnv []string
+	Env               []string
+	UnsetEnv          []string
+	NetworkRateLimits map[string]define.NetworkRateLimit
 }
 
 type Info struct {
@@ -183,6 +184,7 @@ type ExecStartConfig struct {
 	Tty    bool   `json:"Tty"`
 	Height uint16 `json:"h"`
 	Width  uint16 `json:"w"`
+	Record bool   `json:"Record,omitempty"`
 }
 
 type ExecRemoveConfig struct {
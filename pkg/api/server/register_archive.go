@@ -37,6 +37,12 @@ func (s *APIServer) registerArchiveHandlers(r *mux.Router) error {
 	//     name: copyUIDGID
 	//     type: string
 	//     description: copy UID/GID maps to the dest file or di (1 or true)
+	//   - in: query
+	//     name: remove
+	//     type: array
+	//     items:
+	//       type: string
+	//     description: paths relative to path to remove before extracting, not supported by Docker
 	//   - in: body
 	//     name: request
 	//     description: tarfile of files to copy into the container
@@ -117,6 +123,12 @@ func (s *APIServer) registerArchiveHandlers(r *mux.Router) error {
 	//     type: boolean
 	//     description: pause the container while copying (defaults to true)
 	//     default: true
+	//   - in: query
+	//     name: remove
+	//     type: array
+	//     items:
+	//       type: string
+	//     description: paths relative to path to remove before extracting
 	//   - in: body
 	//     name: request
 	//     description: tarfile of files to copy into the container
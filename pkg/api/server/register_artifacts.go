@@ -128,6 +128,38 @@ func (s *APIServer) registerArtifactHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/artifacts/remove"), s.APIHandler(libpod.BatchRemoveArtifact)).Methods(http.MethodDelete)
+	// swagger:operation POST /libpod/artifacts/refresh libpod ArtifactRefreshLibpod
+	// ---
+	// tags:
+	//  - artifacts
+	// summary: Refresh artifact volumes
+	// description: |
+	//   Swap the content of the refreshing artifact volumes of containers whose
+	//   artifacts changed in local storage. Volumes mounted with refresh-pull pull
+	//   their artifacts first.
+	// produces:
+	//  - application/json
+	// parameters:
+	//  - name: containers
+	//    in: query
+	//    description: List of container names/IDs to refresh
+	//    type: array
+	//    items:
+	//        type: string
+	//  - name: all
+	//    in: query
+	//    description: Refresh all running containers
+	//    type: boolean
+	// responses:
+	//   200:
+	//     $ref: "#/responses/artifactRefreshResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/artifacts/refresh"), s.APIHandler(libpod.RefreshArtifacts)).Methods(http.MethodPost)
 	// swagger:operation DELETE /libpod/artifacts/{name} libpod ArtifactDeleteLibpod
 	// ---
 	// tags:
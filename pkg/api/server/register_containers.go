@@ -793,6 +793,10 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//        - `since`=(`<container id>` or `<container name>`)
 	//        - `status`=(`created`, `restarting`, `running`, `removing`, `paused`, `exited` or `dead`)
 	//        - `volume`=(`<volume name>` or `<mount point destination>`)
+	//  - in: query
+	//    name: where
+	//    type: string
+	//    description: Expression evaluated over the inspect data of each container, only containers it is true for are listed, e.g. `RestartCount > 3`. Not supported with `external`.
 	// produces:
 	// - application/json
 	// responses:
@@ -1227,6 +1231,47 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/top"), s.APIHandler(compat.TopContainer)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/top/stats libpod ContainerTopStatsLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Get the resource usage of processes
+	// description: Return a live stream of the CPU, memory, I/O and file descriptor usage of the processes running inside a container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: stream
+	//    type: boolean
+	//    default: true
+	//    description: Stream the output
+	//  - in: query
+	//    name: interval
+	//    type: integer
+	//    default: 5
+	//    description: Time in seconds between reports
+	//  - in: query
+	//    name: sort
+	//    type: string
+	//    default: pid
+	//    description: Sort the processes by pid, cpu, rss, io or fds
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/containerTopStats"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/top/stats"), s.APIHandler(libpod.TopStatsContainer)).Methods(http.MethodGet)
 	// swagger:operation POST /libpod/containers/{name}/unpause libpod ContainerUnpauseLibpod
 	// ---
 	// tags:
@@ -1484,6 +1529,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    required: false
 	//    type: boolean
 	//    description: Attach to container STDIN
+	//  - in: query
+	//    name: record
+	//    required: false
+	//    type: boolean
+	//    description: Record the attach session to an asciicast file on the server, see podman-session(1)
 	// produces:
 	// - application/json
 	// responses:
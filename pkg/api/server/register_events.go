@@ -52,6 +52,10 @@ func (s *APIServer) registerEventsHandlers(r *mux.Router) error {
 	//   type: string
 	//   in: query
 	//   description: start streaming events from this time
+	// - name: sinceCursor
+	//   type: string
+	//   in: query
+	//   description: start streaming the events after the event with this cursor, the opaque cursor of an event is returned with it
 	// - name: until
 	//   type: string
 	//   in: query
@@ -283,6 +283,9 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	//        w:
 	//          type: integer
 	//          description: Width of the TTY session in characters. Tty must be set to true to use it.
+	//        Record:
+	//          type: boolean
+	//          description: Record the exec session to an asciicast file on the server, see podman-session(1).
 	// produces:
 	// - application/json
 	// responses:
@@ -908,11 +908,17 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//        - `id`=(`<image-id>`)
 	//        - `since`=(`<image-name>[:<tag>]`,  `<image id>` or `<image@digest>`)
 	//     type: string
+	//   - name: where
+	//     in: query
+	//     description: Expression evaluated over the inspect data of each image, only images it is true for are listed, e.g. `Size > 100000000`.
+	//     type: string
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
 	//     $ref: "#/responses/imageListLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
 	//   500:
 	//     $ref: '#/responses/internalError'
 	r.Handle(VersionedPath("/libpod/images/json"), s.APIHandler(compat.GetImages)).Methods(http.MethodGet)
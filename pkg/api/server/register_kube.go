@@ -104,6 +104,11 @@ func (s *APIServer) registerKubeHandlers(r *mux.Router) error {
 	//    default: false
 	//    description: replace existing pods and containers
 	//  - in: query
+	//    name: rolling
+	//    type: boolean
+	//    default: false
+	//    description: update the pods of running Deployments one revision at a time, keeping the previous revision for rolling back
+	//  - in: query
 	//    name: serviceContainer
 	//    type: boolean
 	//    default: false
@@ -277,5 +282,33 @@ func (s *APIServer) registerKubeHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/kube/apply"), s.APIHandler(libpod.KubeApply)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/kube/rollback/{name} libpod KubeRollbackLibpod
+	// ---
+	// tags:
+	//  - pods
+	// summary: Roll back a Deployment
+	// description: |
+	//   Start the pod of a previous revision of a Deployment played with kube play
+	//   and stop the pod of the running revision once the previous one is ready.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name of the Deployment
+	//  - in: query
+	//    name: revision
+	//    type: integer
+	//    description: Revision to roll back to, the previously running one if unset
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/kubeRollbackResponseLibpod"
+	//   404:
+	//     $ref: "#/responses/podNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/kube/rollback/{name}"), s.APIHandler(libpod.KubeRollback)).Methods(http.MethodPost)
 	return nil
 }
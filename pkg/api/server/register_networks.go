@@ -439,5 +439,93 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/networks/prune"), s.APIHandler(libpod.Prune)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/networkpolicies/create libpod NetworkPolicyCreateLibpod
+	// ---
+	// tags:
+	//  - networks
+	// summary: Create network policy
+	// description: Create a network policy and enforce it on the running containers of its networks.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: body
+	//    name: create
+	//    description: the network policy
+	//    schema:
+	//      $ref: "#/definitions/networkPolicyCreateRequestLibpod"
+	//  - in: query
+	//    name: replace
+	//    type: boolean
+	//    description: replace an existing network policy of the same name
+	// responses:
+	//   200:
+	//     $ref: "#/responses/networkPolicyResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/networkNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/networkpolicies/create"), s.APIHandler(libpod.CreateNetworkPolicy)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/networkpolicies/json libpod NetworkPolicyListLibpod
+	// ---
+	// tags:
+	//  - networks
+	// summary: List network policies
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/networkPolicyListResponse"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/networkpolicies/json"), s.APIHandler(libpod.ListNetworkPolicies)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/networkpolicies/{name}/json libpod NetworkPolicyInspectLibpod
+	// ---
+	// tags:
+	//  - networks
+	// summary: Inspect a network policy
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name of the network policy
+	// responses:
+	//   200:
+	//     $ref: "#/responses/networkPolicyResponse"
+	//   404:
+	//     $ref: "#/responses/networkPolicyNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/networkpolicies/{name}/json"), s.APIHandler(libpod.InspectNetworkPolicy)).Methods(http.MethodGet)
+	// swagger:operation DELETE /libpod/networkpolicies/{name} libpod NetworkPolicyDeleteLibpod
+	// ---
+	// tags:
+	//  - networks
+	// summary: Remove a network policy
+	// description: Remove a network policy and lift the isolation of the containers it selected unless other policies select them.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name of the network policy
+	//  - in: query
+	//    name: ignore
+	//    type: boolean
+	//    description: do not fail if the network policy does not exist
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/networkPolicyNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/networkpolicies/{name}"), s.APIHandler(libpod.RemoveNetworkPolicy)).Methods(http.MethodDelete)
 	return nil
 }
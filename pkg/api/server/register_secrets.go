@@ -35,6 +35,16 @@ func (s *APIServer) registerSecretHandlers(r *mux.Router) error {
 	//     name: labels
 	//     type: string
 	//     description: Labels on the secret
+	//   - in: query
+	//     name: replace
+	//     type: boolean
+	//     description: Replace an existing secret with the same name
+	//     default: false
+	//   - in: query
+	//     name: keepversions
+	//     type: integer
+	//     description: Number of previous versions of the secret to keep when it is replaced
+	//     default: 5
 	//   - in: body
 	//     name: request
 	//     description: Secret
@@ -62,6 +72,11 @@ func (s *APIServer) registerSecretHandlers(r *mux.Router) error {
 	//      JSON encoded value of the filters (a `map[string][]string`) to process on the secrets list. Currently available filters:
 	//        - `name=[name]` Matches secrets name (accepts regex).
 	//        - `id=[id]` Matches for full or partial ID.
+	//  - in: query
+	//    name: all
+	//    type: boolean
+	//    description: List the previous versions of secrets too
+	//    default: false
 	// produces:
 	// - application/json
 	// responses:
@@ -117,6 +132,117 @@ func (s *APIServer) registerSecretHandlers(r *mux.Router) error {
 	//   '500':
 	//     "$ref": "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/secrets/{name}/exists"), s.APIHandler(libpod.SecretExists)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/secrets/{name}/update libpod SecretUpdateLibpod
+	// ---
+	// tags:
+	//  - secrets
+	// summary: Update a secret
+	// description: |
+	//   Replace the data of a secret and increment its version. The secret files
+	//   mounted into containers using the secret are rewritten, and running
+	//   containers are optionally reloaded.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the secret
+	//  - in: query
+	//    name: signal
+	//    type: string
+	//    description: Signal to send to running containers using the secret
+	//  - in: query
+	//    name: exec
+	//    type: string
+	//    description: Command to execute in running containers using the secret
+	//  - in: query
+	//    name: keepversions
+	//    type: integer
+	//    description: Number of previous versions of the secret to keep
+	//    default: 5
+	//  - in: body
+	//    name: request
+	//    description: Secret data
+	//    schema:
+	//      type: string
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     $ref: "#/responses/SecretUpdateResponse"
+	//   '400':
+	//     "$ref": "#/responses/badParamError"
+	//   '404':
+	//     "$ref": "#/responses/NoSuchSecret"
+	//   '500':
+	//     "$ref": "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/secrets/{name}/update"), s.APIHandler(libpod.UpdateSecret)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/secrets/{name}/rollback libpod SecretRollbackLibpod
+	// ---
+	// tags:
+	//  - secrets
+	// summary: Roll back a secret
+	// description: |
+	//   Restore the data of a previous version of a secret as its next version,
+	//   and update the secret in the containers using it.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the secret
+	//  - in: query
+	//    name: version
+	//    type: integer
+	//    required: true
+	//    description: Version to roll back to
+	//  - in: query
+	//    name: signal
+	//    type: string
+	//    description: Signal to send to running containers using the secret
+	//  - in: query
+	//    name: exec
+	//    type: string
+	//    description: Command to execute in running containers using the secret
+	//  - in: query
+	//    name: keepversions
+	//    type: integer
+	//    description: Number of previous versions of the secret to keep
+	//    default: 5
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     $ref: "#/responses/SecretUpdateResponse"
+	//   '400':
+	//     "$ref": "#/responses/badParamError"
+	//   '404':
+	//     "$ref": "#/responses/NoSuchSecret"
+	//   '500':
+	//     "$ref": "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/secrets/{name}/rollback"), s.APIHandler(libpod.RollbackSecret)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/secrets/{name}/history libpod SecretHistoryLibpod
+	// ---
+	// tags:
+	//  - secrets
+	// summary: Secret history
+	// description: List the current and the kept previous versions of a secret, newest first.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the secret
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     $ref: "#/responses/SecretHistoryResponse"
+	//   '404':
+	//     "$ref": "#/responses/NoSuchSecret"
+	//   '500':
+	//     "$ref": "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/secrets/{name}/history"), s.APIHandler(libpod.SecretHistory)).Methods(http.MethodGet)
 	// swagger:operation DELETE /libpod/secrets/{name} libpod SecretDeleteLibpod
 	// ---
 	// tags:
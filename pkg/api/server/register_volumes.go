@@ -71,9 +71,15 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	//        - name=<volume-name> Matches all of volume name.
 	//        - opt=<driver-option> Matches a storage driver options
 	//        - `until=<timestamp>` List volumes created before this timestamp. The `<timestamp>` can be Unix timestamps, date formatted timestamps, or Go duration strings (e.g. `10m`, `1h30m`) computed relative to the daemon machine’s time.
+	//  - in: query
+	//    name: where
+	//    type: string
+	//    description: Expression evaluated over the inspect data of each volume, only volumes it is true for are listed, e.g. `Labels["tier"] == "web"`.
 	// responses:
 	//   '200':
 	//     "$ref": "#/responses/volumeListLibpod"
+	//   '400':
+	//     "$ref": "#/responses/badParamError"
 	//   '500':
 	//      "$ref": "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/volumes/json"), s.APIHandler(libpod.ListVolumes)).Methods(http.MethodGet)
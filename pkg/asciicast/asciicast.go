@@ -0,0 +1,381 @@
+// Package asciicast reads, writes and replays terminal session recordings in
+// the asciicast v2 format, see https://docs.asciinema.org/manual/asciicast/v2/.
+package asciicast
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Version is the version of the asciicast format written by Writer.
+const Version = 2
+
+const (
+	// DefaultWidth is the terminal width used if the size is not known.
+	DefaultWidth = 80
+	// DefaultHeight is the terminal height used if the size is not known.
+	DefaultHeight = 24
+
+	// maxLineSize is the maximum size of a line of a recording.  Output is
+	// read in chunks of 8k, which are at most 6 times as large once
+	// encoded in JSON.
+	maxLineSize = 1024 * 1024
+)
+
+// EventType is the type of an event of a recording.
+type EventType string
+
+const (
+	// Output is data written to the terminal.
+	Output EventType = "o"
+	// Input is data read from the terminal.
+	Input EventType = "i"
+	// Resize is a change of the terminal size, the data is "WIDTHxHEIGHT".
+	Resize EventType = "r"
+	// Marker is a marker set during the recording.
+	Marker EventType = "m"
+)
+
+// Header is the first line of a recording.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Duration  float64           `json:"duration,omitempty"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	// Session describes the Podman session the recording was made of.
+	// Players ignore it.
+	Session *Session `json:"podman,omitempty"`
+}
+
+// Session describes a recorded exec or attach session.
+type Session struct {
+	// ID is the ID of the recording.
+	ID string `json:"id"`
+	// Type is the type of the session, "exec" or "attach".
+	Type string `json:"type"`
+	// ContainerID is the ID of the container of the session.
+	ContainerID string `json:"containerId"`
+	// ContainerName is the name of the container of the session.
+	ContainerName string `json:"containerName"`
+	// ExecID is the ID of the exec session, if Type is "exec".
+	ExecID string `json:"execId,omitempty"`
+	// User is the name of the user who started the session.
+	User string `json:"user,omitempty"`
+	// UID is the UID of the user who started the session.
+	UID int `json:"uid"`
+	// SudoUser is the name of the user who invoked sudo to start the
+	// session, if any.
+	SudoUser string `json:"sudoUser,omitempty"`
+}
+
+// Event is an event of a recording.
+type Event struct {
+	// Time is the time of the event since the start of the recording.
+	Time time.Duration
+	Type EventType
+	Data string
+}
+
+// MarshalJSON encodes the event as [time, type, data].
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{json.Number(strconv.FormatFloat(e.Time.Seconds(), 'f', 6, 64)), e.Type, e.Data})
+}
+
+// UnmarshalJSON decodes an event encoded as [time, type, data].
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 3 {
+		return fmt.Errorf("invalid event %s: expected 3 elements, got %d", data, len(raw))
+	}
+	var seconds float64
+	if err := json.Unmarshal(raw[0], &seconds); err != nil {
+		return fmt.Errorf("invalid event time %s: %w", raw[0], err)
+	}
+	if err := json.Unmarshal(raw[1], &e.Type); err != nil {
+		return fmt.Errorf("invalid event type %s: %w", raw[1], err)
+	}
+	if err := json.Unmarshal(raw[2], &e.Data); err != nil {
+		return fmt.Errorf("invalid event data: %w", err)
+	}
+	e.Time = time.Duration(seconds * float64(time.Second))
+	return nil
+}
+
+// Writer writes a recording.  It is safe for concurrent use.
+//
+// The header is written with the first event, so that a resize before any
+// output sets the initial size of the terminal instead of creating an event.
+type Writer struct {
+	mu            sync.Mutex
+	w             io.WriteCloser
+	header        Header
+	start         time.Time
+	headerWritten bool
+	// pending holds an incomplete UTF-8 sequence at the end of the
+	// output written last.
+	pending []byte
+	err     error
+}
+
+// NewWriter returns a Writer writing a recording with the given header to w.
+// The version and timestamp of the header are set, and the size is set to
+// the default size if it is not set.
+func NewWriter(w io.WriteCloser, header Header) *Writer {
+	start := time.Now()
+	header.Version = Version
+	header.Timestamp = start.Unix()
+	if header.Width <= 0 || header.Height <= 0 {
+		header.Width = DefaultWidth
+		header.Height = DefaultHeight
+	}
+	return &Writer{w: w, header: header, start: start}
+}
+
+// Resize records a change of the terminal size.
+func (w *Writer) Resize(width, height int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.headerWritten {
+		w.header.Width = width
+		w.header.Height = height
+		return nil
+	}
+	return w.writeEvent(Resize, fmt.Sprintf("%dx%d", width, height))
+}
+
+// Output returns a writer recording the data written to it as output.
+func (w *Writer) Output() io.Writer {
+	return writerFunc(func(p []byte) (int, error) {
+		if err := w.WriteOutput(p); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	})
+}
+
+// WriteOutput records data written to the terminal.  An incomplete UTF-8
+// sequence at the end of data is recorded with the next output.
+func (w *Writer) WriteOutput(data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.pending) > 0 {
+		data = append(w.pending, data...)
+		w.pending = nil
+	}
+	if n := incompleteSuffix(data); n > 0 {
+		w.pending = append([]byte(nil), data[len(data)-n:]...)
+		data = data[:len(data)-n]
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return w.writeEvent(Output, string(data))
+}
+
+// Close writes the remaining output and closes the underlying writer.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.pending) > 0 {
+		_ = w.writeEvent(Output, string(w.pending))
+		w.pending = nil
+	}
+	if !w.headerWritten {
+		_ = w.writeHeader()
+	}
+	err := w.w.Close()
+	if w.err != nil {
+		return w.err
+	}
+	return err
+}
+
+// writeHeader writes the header.  Must be called with the lock held.
+func (w *Writer) writeHeader() error {
+	w.headerWritten = true
+	return w.writeLine(w.header)
+}
+
+// writeEvent writes an event.  Must be called with the lock held.
+func (w *Writer) writeEvent(t EventType, data string) error {
+	if !w.headerWritten {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+	return w.writeLine(Event{Time: time.Since(w.start), Type: t, Data: data})
+}
+
+// writeLine writes v encoded as a line of JSON.  After the first error all
+// writes fail.  Must be called with the lock held.
+func (w *Writer) writeLine(v any) error {
+	if w.err != nil {
+		return w.err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		w.err = err
+		return err
+	}
+	if _, err := w.w.Write(append(b, '\n')); err != nil {
+		w.err = err
+		return err
+	}
+	return nil
+}
+
+// incompleteSuffix returns the length of an incomplete UTF-8 sequence at the
+// end of b.
+func incompleteSuffix(b []byte) int {
+	for i := 1; i < utf8.UTFMax && i <= len(b); i++ {
+		c := b[len(b)-i]
+		if utf8.RuneStart(c) {
+			if !utf8.FullRune(b[len(b)-i:]) {
+				return i
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+// Decoder reads the events of a recording.
+type Decoder struct {
+	scanner *bufio.Scanner
+	header  Header
+}
+
+// NewDecoder reads the header of the recording from r and returns a Decoder
+// for its events.
+func NewDecoder(r io.Reader) (*Decoder, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("empty recording")
+	}
+	d := &Decoder{scanner: scanner}
+	if err := json.Unmarshal(scanner.Bytes(), &d.header); err != nil {
+		return nil, fmt.Errorf("invalid recording header: %w", err)
+	}
+	if d.header.Version != Version {
+		return nil, fmt.Errorf("unsupported asciicast version %d", d.header.Version)
+	}
+	return d, nil
+}
+
+// Header returns the header of the recording.
+func (d *Decoder) Header() Header {
+	return d.header
+}
+
+// Next returns the next event of the recording, or io.EOF after the last
+// one.
+func (d *Decoder) Next() (*Event, error) {
+	for d.scanner.Scan() {
+		line := d.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		e := new(Event)
+		if err := json.Unmarshal(line, e); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// ReplayOptions are the options of Replay.
+type ReplayOptions struct {
+	// Speed is the factor the replay is sped up by.  Values <= 0 replay
+	// the recording without delays.
+	Speed float64
+	// IdleLimit limits pauses between events to the given duration, if
+	// set.
+	IdleLimit time.Duration
+}
+
+// Replay writes the output of the recording read from r to out, with the
+// recorded timing.  Replay stops early if stop is closed.
+func Replay(r io.Reader, out io.Writer, options ReplayOptions, stop <-chan struct{}) error {
+	d, err := NewDecoder(r)
+	if err != nil {
+		return err
+	}
+	var last time.Duration
+	for {
+		e, err := d.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if e.Type != Output {
+			continue
+		}
+		if options.Speed > 0 {
+			delay := e.Time - last
+			if options.IdleLimit > 0 && delay > options.IdleLimit {
+				delay = options.IdleLimit
+			}
+			delay = time.Duration(float64(delay) / options.Speed)
+			if delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-stop:
+					return nil
+				}
+			}
+		}
+		last = e.Time
+		if _, err := io.WriteString(out, e.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// Duration returns the time of the last event of the recording read from r.
+func Duration(r io.Reader) (Header, time.Duration, error) {
+	d, err := NewDecoder(r)
+	if err != nil {
+		return Header{}, 0, err
+	}
+	var duration time.Duration
+	for {
+		e, err := d.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return d.header, duration, nil
+			}
+			return d.header, duration, err
+		}
+		duration = e.Time
+	}
+}
@@ -0,0 +1,123 @@
+package asciicast
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestWriter(t *testing.T) {
+	buf := nopCloser{new(bytes.Buffer)}
+	w := NewWriter(buf, Header{Command: "sh", Session: &Session{ID: "abc", Type: "exec", UID: 1000}})
+
+	// A resize before the first event sets the size of the header.
+	require.NoError(t, w.Resize(120, 40))
+	_, err := w.Output().Write([]byte("hello "))
+	require.NoError(t, err)
+	// "é" split across two writes is recorded as one event.
+	require.NoError(t, w.WriteOutput([]byte{0xc3}))
+	require.NoError(t, w.WriteOutput([]byte{0xa9, '\n'}))
+	require.NoError(t, w.Resize(100, 30))
+	require.NoError(t, w.Close())
+
+	d, err := NewDecoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	header := d.Header()
+	assert.Equal(t, Version, header.Version)
+	assert.Equal(t, 120, header.Width)
+	assert.Equal(t, 40, header.Height)
+	assert.Equal(t, "sh", header.Command)
+	assert.NotZero(t, header.Timestamp)
+	require.NotNil(t, header.Session)
+	assert.Equal(t, "abc", header.Session.ID)
+
+	var got []Event
+	for {
+		e, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		got = append(got, *e)
+	}
+	require.Len(t, got, 3)
+	assert.Equal(t, Output, got[0].Type)
+	assert.Equal(t, "hello ", got[0].Data)
+	assert.Equal(t, "é\n", got[1].Data)
+	assert.Equal(t, Resize, got[2].Type)
+	assert.Equal(t, "100x30", got[2].Data)
+	assert.LessOrEqual(t, got[0].Time, got[2].Time)
+}
+
+func TestWriterDefaultSize(t *testing.T) {
+	buf := nopCloser{new(bytes.Buffer)}
+	require.NoError(t, NewWriter(buf, Header{}).Close())
+
+	header, duration, err := Duration(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultWidth, header.Width)
+	assert.Equal(t, DefaultHeight, header.Height)
+	assert.Zero(t, duration)
+}
+
+func TestDecoderErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		recording string
+		wantErr   string
+	}{
+		{name: "empty", recording: "", wantErr: "empty recording"},
+		{name: "version", recording: `{"version":1,"width":80,"height":24}`, wantErr: "unsupported asciicast version 1"},
+		{name: "header", recording: `[1]`, wantErr: "invalid recording header"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDecoder(strings.NewReader(tt.recording))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	d, err := NewDecoder(strings.NewReader("{\"version\":2,\"width\":80,\"height\":24}\n[0.5,\"o\"]\n"))
+	require.NoError(t, err)
+	_, err = d.Next()
+	assert.ErrorContains(t, err, "expected 3 elements, got 2")
+}
+
+func TestReplay(t *testing.T) {
+	recording := `{"version":2,"width":80,"height":24}
+[0.010000,"o","a"]
+[0.020000,"r","100x30"]
+[5.000000,"o","b"]
+`
+	var out bytes.Buffer
+	start := time.Now()
+	err := Replay(strings.NewReader(recording), &out, ReplayOptions{Speed: 1, IdleLimit: 50 * time.Millisecond}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ab", out.String())
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	// Without delays
+	out.Reset()
+	err = Replay(strings.NewReader(recording), &out, ReplayOptions{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ab", out.String())
+
+	// Stopped during a pause
+	out.Reset()
+	stop := make(chan struct{})
+	close(stop)
+	err = Replay(strings.NewReader(recording), &out, ReplayOptions{Speed: 1}, stop)
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,380 @@
+// Package audit matches the packages installed in images against an
+// offline vulnerability database imported from OSV data.
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v6/pkg/sbom"
+	"go.podman.io/storage/pkg/ioutils"
+)
+
+// ErrNoDatabase is returned by Load if no database has been imported yet.
+var ErrNoDatabase = errors.New("no vulnerability database has been imported")
+
+// dbVersion is increased on incompatible changes of the stored format.
+const dbVersion = 1
+
+// Advisory is the part of an OSV record stored in the database.
+type Advisory struct {
+	ID       string     `json:"id"`
+	Aliases  []string   `json:"aliases,omitempty"`
+	Summary  string     `json:"summary,omitempty"`
+	Severity Severity   `json:"severity,omitempty"`
+	Affected []Affected `json:"affected"`
+}
+
+// Affected describes the affected versions of one package.
+type Affected struct {
+	// Ecosystem is the OSV ecosystem including the release, e.g.
+	// "Alpine:v3.19".
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	// Severity overrides the severity of the advisory for this package.
+	Severity Severity `json:"severity,omitempty"`
+	Ranges   []Range  `json:"ranges,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// Range is an OSV version range of type ECOSYSTEM or SEMVER.
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event is a single OSV range event, only one of the fields is set.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Finding is a vulnerability affecting a package of an image.
+type Finding struct {
+	Package  sbom.Package
+	ID       string
+	Aliases  []string
+	Summary  string
+	Severity Severity
+	// Fixed is the first version fixing the vulnerability, if known.
+	Fixed string
+}
+
+// DB is the imported vulnerability database.
+type DB struct {
+	Version    int                  `json:"version"`
+	Updated    time.Time            `json:"updated"`
+	Advisories map[string]*Advisory `json:"advisories"`
+
+	index map[string][]indexEntry
+}
+
+type indexEntry struct {
+	advisory *Advisory
+	affected *Affected
+}
+
+// New returns an empty database.
+func New() *DB {
+	return &DB{Version: dbVersion, Advisories: make(map[string]*Advisory)}
+}
+
+// Load reads the database stored at path.
+func Load(path string) (*DB, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNoDatabase
+		}
+		return nil, err
+	}
+	db := New()
+	if err := json.Unmarshal(data, db); err != nil {
+		return nil, fmt.Errorf("reading vulnerability database %s: %w", path, err)
+	}
+	if db.Version != dbVersion {
+		return nil, fmt.Errorf("vulnerability database %s has unsupported version %d, import it again", path, db.Version)
+	}
+	return db, nil
+}
+
+// Save writes the database to path, creating the parent directory.
+func (db *DB) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(db)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(path, data, 0o600)
+}
+
+// Import adds the OSV records read from path to the database. Records
+// already present are replaced. It returns the number of records read.
+func (db *DB) Import(path string) (int, error) {
+	records, err := readOSV(path)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, r := range records {
+		if r.Withdrawn != "" {
+			delete(db.Advisories, r.ID)
+			continue
+		}
+		if adv := r.toAdvisory(); adv != nil {
+			db.Advisories[adv.ID] = adv
+			count++
+		}
+	}
+	db.Updated = time.Now().UTC()
+	db.index = nil
+	return count, nil
+}
+
+// splitEcosystem splits an OSV ecosystem like "Debian:12" into the
+// ecosystem and the release.
+func splitEcosystem(ecosystem string) (string, string) {
+	base, release, _ := strings.Cut(ecosystem, ":")
+	return base, release
+}
+
+func indexKey(ecosystem, name string) string {
+	if ecosystem == "PyPI" {
+		name = normalizePyPIName(name)
+	}
+	return ecosystem + "\x00" + name
+}
+
+// normalizePyPIName implements the name normalization of PEP 503.
+func normalizePyPIName(name string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+}
+
+func (db *DB) buildIndex() {
+	db.index = make(map[string][]indexEntry)
+	for _, adv := range db.Advisories {
+		for i := range adv.Affected {
+			a := &adv.Affected[i]
+			base, _ := splitEcosystem(a.Ecosystem)
+			key := indexKey(base, a.Name)
+			db.index[key] = append(db.index[key], indexEntry{advisory: adv, affected: a})
+		}
+	}
+}
+
+// osEcosystems maps os-release IDs to OSV ecosystems.
+var osEcosystems = map[string]string{
+	"alpine":        "Alpine",
+	"wolfi":         "Wolfi",
+	"chainguard":    "Chainguard",
+	"debian":        "Debian",
+	"ubuntu":        "Ubuntu",
+	"rhel":          "Red Hat",
+	"rocky":         "Rocky Linux",
+	"almalinux":     "AlmaLinux",
+	"opensuse-leap": "openSUSE",
+	"sles":          "SUSE",
+	"mageia":        "Mageia",
+	"openEuler":     "openEuler",
+}
+
+// ecosystemFor returns the OSV ecosystem of a package, empty if unknown.
+func ecosystemFor(p *sbom.Package, rel *sbom.OSRelease) string {
+	switch p.Type {
+	case sbom.TypePython:
+		return "PyPI"
+	case sbom.TypeNPM:
+		return "npm"
+	case sbom.TypeGo:
+		return "Go"
+	}
+	if rel != nil {
+		if eco, ok := osEcosystems[rel.ID]; ok {
+			return eco
+		}
+	}
+	switch p.Type {
+	case sbom.TypeAPK:
+		return "Alpine"
+	case sbom.TypeDeb:
+		return "Debian"
+	}
+	return ""
+}
+
+// releaseMatches reports whether the release part of an OSV ecosystem
+// applies to the distribution of the image.
+func releaseMatches(release string, rel *sbom.OSRelease) bool {
+	if release == "" || rel == nil || rel.VersionID == "" {
+		return true
+	}
+	r, _, _ := strings.Cut(strings.TrimPrefix(release, "v"), ":")
+	if r == "" || !isDigit(r[0]) {
+		// Not a plain release number, e.g. Red Hat CPE based
+		// ecosystems.
+		return true
+	}
+	return r == rel.VersionID || strings.HasPrefix(rel.VersionID, r+".")
+}
+
+// packageVersion returns the version of p as used by the advisories.
+func packageVersion(p *sbom.Package) string {
+	switch {
+	case p.Type == sbom.TypeRPM && p.Epoch != "" && p.Epoch != "0":
+		return p.Epoch + ":" + p.Version
+	case p.Type == sbom.TypeGo && p.Name == "stdlib":
+		return strings.TrimPrefix(p.Version, "go")
+	}
+	return p.Version
+}
+
+// Match returns the vulnerabilities affecting the given packages, sorted by
+// descending severity.
+func (db *DB) Match(rel *sbom.OSRelease, pkgs []sbom.Package) []Finding {
+	if db.index == nil {
+		db.buildIndex()
+	}
+	var findings []Finding
+	seen := make(map[string]bool)
+	for i := range pkgs {
+		p := &pkgs[i]
+		ecosystem := ecosystemFor(p, rel)
+		version := packageVersion(p)
+		if ecosystem == "" || version == "" {
+			continue
+		}
+		names := []string{p.Name}
+		if p.Source != "" {
+			names = append(names, p.Source)
+		}
+		for _, name := range names {
+			for _, e := range db.index[indexKey(ecosystem, name)] {
+				_, release := splitEcosystem(e.affected.Ecosystem)
+				if !releaseMatches(release, rel) {
+					continue
+				}
+				affected, fixed := e.affected.affects(ecosystem, version)
+				if !affected {
+					continue
+				}
+				key := e.advisory.ID + "\x00" + p.Location + "\x00" + p.Name + "\x00" + p.Version
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				severity := e.affected.Severity
+				if severity == SeverityUnknown {
+					severity = e.advisory.Severity
+				}
+				findings = append(findings, Finding{
+					Package:  *p,
+					ID:       e.advisory.ID,
+					Aliases:  e.advisory.Aliases,
+					Summary:  e.advisory.Summary,
+					Severity: severity,
+					Fixed:    fixed,
+				})
+			}
+		}
+	}
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		if c := cmpInt(int(b.Severity), int(a.Severity)); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Package.Name, b.Package.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return findings
+}
+
+// affects reports whether version is affected and returns the version
+// fixing it, if known.
+func (a *Affected) affects(ecosystem, version string) (bool, string) {
+	if slices.Contains(a.Versions, version) {
+		return true, ""
+	}
+	for _, r := range a.Ranges {
+		cmp := comparatorFor(ecosystem)
+		if r.Type == "SEMVER" {
+			cmp = compareSemver
+		}
+		if affected, fixed := r.affects(cmp, version); affected {
+			return true, fixed
+		}
+	}
+	return false, ""
+}
+
+// version returns the version of the event, "0" for the lowest
+// possible version.
+func (e *Event) version() string {
+	switch {
+	case e.Introduced != "":
+		return e.Introduced
+	case e.Fixed != "":
+		return e.Fixed
+	case e.LastAffected != "":
+		return e.LastAffected
+	}
+	return e.Limit
+}
+
+// affects evaluates the range as described in the OSV schema.
+func (r *Range) affects(cmp compareFunc, version string) (bool, string) {
+	events := slices.Clone(r.Events)
+	slices.SortStableFunc(events, func(a, b Event) int {
+		va, vb := a.version(), b.version()
+		switch {
+		case va == vb:
+			return 0
+		case va == "0":
+			return -1
+		case vb == "0":
+			return 1
+		}
+		return cmp(va, vb)
+	})
+
+	affected := false
+	fixed := ""
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			if e.Introduced == "0" || cmp(version, e.Introduced) >= 0 {
+				affected = true
+				fixed = ""
+			}
+		case e.Fixed != "":
+			if cmp(version, e.Fixed) >= 0 {
+				affected = false
+			} else if affected && fixed == "" {
+				fixed = e.Fixed
+			}
+		case e.LastAffected != "":
+			if cmp(version, e.LastAffected) > 0 {
+				affected = false
+			}
+		case e.Limit != "":
+			if cmp(version, e.Limit) >= 0 {
+				affected = false
+			}
+		}
+	}
+	if !affected {
+		return false, ""
+	}
+	return true, fixed
+}
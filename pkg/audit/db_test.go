@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/podman/v6/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOSV = `[
+  {
+    "id": "ALPINE-CVE-2025-0001",
+    "aliases": ["CVE-2025-0001"],
+    "summary": "musl overflow",
+    "affected": [{
+      "package": {"ecosystem": "Alpine:v3.19", "name": "musl"},
+      "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "1.2.4_git20230717-r5"}]}]
+    }]
+  },
+  {
+    "id": "ALPINE-CVE-2025-0002",
+    "affected": [{
+      "package": {"ecosystem": "Alpine:v3.18", "name": "musl"},
+      "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "1.2.5-r0"}]}]
+    }]
+  },
+  {
+    "id": "DEBIAN-CVE-2025-0003",
+    "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}],
+    "affected": [{
+      "package": {"ecosystem": "Debian:12", "name": "glibc"},
+      "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "2.36-9+deb12u7"}]}]
+    }]
+  },
+  {
+    "id": "GHSA-xxxx-yyyy-zzzz",
+    "database_specific": {"severity": "MODERATE"},
+    "affected": [{
+      "package": {"ecosystem": "PyPI", "name": "Requests"},
+      "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "2.0"}, {"last_affected": "2.31.0"}]}]
+    }]
+  },
+  {
+    "id": "GO-2025-0004",
+    "affected": [{
+      "package": {"ecosystem": "Go", "name": "stdlib"},
+      "ecosystem_specific": {"severity": "LOW"},
+      "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.21.9"}, {"introduced": "1.22.0"}, {"fixed": "1.22.2"}]}]
+    }]
+  },
+  {
+    "id": "GHSA-withdrawn",
+    "withdrawn": "2025-01-01T00:00:00Z",
+    "affected": [{"package": {"ecosystem": "npm", "name": "lodash"}, "versions": ["4.17.20"]}]
+  }
+]`
+
+func TestImportAndMatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "osv.json")
+	require.NoError(t, os.WriteFile(path, []byte(testOSV), 0o644))
+
+	db := New()
+	n, err := db.Import(path)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Len(t, db.Advisories, 5)
+
+	alpine := &sbom.OSRelease{ID: "alpine", VersionID: "3.19.1"}
+	findings := db.Match(alpine, []sbom.Package{
+		{Name: "musl-utils", Source: "musl", Version: "1.2.4_git20230717-r4", Type: sbom.TypeAPK, Location: "/lib/apk/db/installed"},
+		{Name: "busybox", Version: "1.36.1-r15", Type: sbom.TypeAPK, Location: "/lib/apk/db/installed"},
+		{Name: "requests", Version: "2.31.0", Type: sbom.TypePython, Location: "/usr/lib/python3/site-packages/requests-2.31.0.dist-info/METADATA"},
+		{Name: "stdlib", Version: "go1.22.1", Type: sbom.TypeGo, Location: "/usr/bin/app"},
+		{Name: "stdlib", Version: "go1.21.9", Type: sbom.TypeGo, Location: "/usr/bin/other"},
+		{Name: "lodash", Version: "4.17.20", Type: sbom.TypeNPM, Location: "/app/node_modules/lodash/package.json"},
+	})
+	require.Len(t, findings, 3)
+	assert.Equal(t, "GHSA-xxxx-yyyy-zzzz", findings[0].ID)
+	assert.Equal(t, SeverityMedium, findings[0].Severity)
+	assert.Equal(t, "", findings[0].Fixed)
+	assert.Equal(t, "GO-2025-0004", findings[1].ID)
+	assert.Equal(t, SeverityLow, findings[1].Severity)
+	assert.Equal(t, "1.22.2", findings[1].Fixed)
+	assert.Equal(t, "/usr/bin/app", findings[1].Package.Location)
+	assert.Equal(t, "ALPINE-CVE-2025-0001", findings[2].ID)
+	assert.Equal(t, "musl-utils", findings[2].Package.Name)
+	assert.Equal(t, []string{"CVE-2025-0001"}, findings[2].Aliases)
+	assert.Equal(t, "1.2.4_git20230717-r5", findings[2].Fixed)
+
+	debian := &sbom.OSRelease{ID: "debian", VersionID: "12"}
+	findings = db.Match(debian, []sbom.Package{
+		{Name: "libc6", Source: "glibc", Version: "2.36-9+deb12u4", Type: sbom.TypeDeb},
+		{Name: "libc-bin", Source: "glibc", Version: "2.36-9+deb12u7", Type: sbom.TypeDeb},
+	})
+	require.Len(t, findings, 1)
+	assert.Equal(t, "libc6", findings[0].Package.Name)
+	assert.Equal(t, SeverityCritical, findings[0].Severity)
+
+	// Import of a withdrawn record removes the advisory.
+	require.NoError(t, os.WriteFile(path, []byte(`{"id": "GO-2025-0004", "withdrawn": "2025-02-01T00:00:00Z"}`), 0o644))
+	_, err = db.Import(path)
+	require.NoError(t, err)
+	assert.NotContains(t, db.Advisories, "GO-2025-0004")
+}
+
+func TestImportZip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "all.zip")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("ALPINE-CVE-2025-0001.json")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(`{"id": "ALPINE-CVE-2025-0001", "affected": [{"package": {"ecosystem": "Alpine:v3.19", "name": "musl"}, "versions": ["1.2.4-r0"]}]}`))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+
+	db := New()
+	n, err := db.Import(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vulndb", "osv.json")
+
+	_, err := Load(path)
+	assert.ErrorIs(t, err, ErrNoDatabase)
+
+	src := filepath.Join(dir, "osv.json")
+	require.NoError(t, os.WriteFile(src, []byte(testOSV), 0o644))
+	db := New()
+	_, err = db.Import(src)
+	require.NoError(t, err)
+	require.NoError(t, db.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, len(db.Advisories), len(loaded.Advisories))
+	assert.Equal(t, SeverityCritical, loaded.Advisories["DEBIAN-CVE-2025-0003"].Severity)
+}
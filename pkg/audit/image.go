@@ -0,0 +1,103 @@
+//go:build !remote
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/pkg/sbom"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libimage"
+	"go.podman.io/storage/pkg/ioutils"
+)
+
+// inventoryVersion is increased whenever the scanners change in a way that
+// invalidates cached inventories.
+const inventoryVersion = 1
+
+// Inventory is the list of packages of an image, cached per image ID since
+// the layers of an image never change.
+type Inventory struct {
+	Version  int             `json:"version"`
+	OS       *sbom.OSRelease `json:"os,omitempty"`
+	Packages []sbom.Package  `json:"packages"`
+}
+
+// Dir returns the directory holding the database and the inventory cache.
+func Dir(r *libpod.Runtime) string {
+	return filepath.Join(r.GraphRoot(), "vulndb")
+}
+
+// DBPath returns the path of the imported database.
+func DBPath(r *libpod.Runtime) string {
+	return filepath.Join(Dir(r), "osv.json")
+}
+
+// ImageInventory returns the packages installed in img, scanning its layers
+// if no cached inventory exists.
+func ImageInventory(r *libpod.Runtime, img *libimage.Image) (*Inventory, error) {
+	cachePath := filepath.Join(Dir(r), "inventory", img.ID()+".json")
+	data, err := os.ReadFile(cachePath)
+	switch {
+	case err == nil:
+		var inv Inventory
+		if err := json.Unmarshal(data, &inv); err == nil && inv.Version == inventoryVersion {
+			return &inv, nil
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		logrus.Debugf("Reading cached inventory of image %s: %v", img.ID(), err)
+	}
+
+	catalog, err := sbom.ScanImage(r, img)
+	if err != nil {
+		return nil, err
+	}
+	inv := &Inventory{
+		Version:  inventoryVersion,
+		OS:       catalog.OS(),
+		Packages: catalog.Packages(),
+	}
+	if err := saveInventory(cachePath, inv); err != nil {
+		logrus.Warnf("Unable to cache the inventory of image %s: %v", img.ID(), err)
+	}
+	return inv, nil
+}
+
+func saveInventory(path string, inv *Inventory) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(path, data, 0o600)
+}
+
+// PruneInventories removes the cached inventories of images which no longer
+// exist.
+func PruneInventories(r *libpod.Runtime) error {
+	dir := filepath.Join(Dir(r), "inventory")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, e := range entries {
+		id := e.Name()[:len(e.Name())-len(filepath.Ext(e.Name()))]
+		if exists, err := r.LibimageRuntime().Exists(id); err != nil || exists {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package audit
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// osvRecord is the subset of the OSV schema used for matching, see
+// https://ossf.github.io/osv-schema/.
+type osvRecord struct {
+	ID               string         `json:"id"`
+	Aliases          []string       `json:"aliases"`
+	Summary          string         `json:"summary"`
+	Withdrawn        string         `json:"withdrawn"`
+	Severity         []osvSeverity  `json:"severity"`
+	Affected         []osvAffected  `json:"affected"`
+	DatabaseSpecific map[string]any `json:"database_specific"`
+}
+
+type osvSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+type osvAffected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Severity          []osvSeverity  `json:"severity"`
+	Ranges            []osvRange     `json:"ranges"`
+	Versions          []string       `json:"versions"`
+	EcosystemSpecific map[string]any `json:"ecosystem_specific"`
+	DatabaseSpecific  map[string]any `json:"database_specific"`
+}
+
+type osvRange struct {
+	Type   string              `json:"type"`
+	Events []map[string]string `json:"events"`
+}
+
+// readOSV reads OSV records from a JSON file holding a single record or an
+// array of records, a zip archive of such files as provided by the OSV
+// project, or a directory tree of such files.
+func readOSV(path string) ([]*osvRecord, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		var records []*osvRecord
+		err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(p) != ".json" {
+				return err
+			}
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			recs, err := decodeOSV(f)
+			if err != nil {
+				return fmt.Errorf("%s: %w", p, err)
+			}
+			records = append(records, recs...)
+			return nil
+		})
+		return records, err
+	}
+
+	if filepath.Ext(path) == ".zip" {
+		return readOSVZip(path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	records, err := decodeOSV(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return records, nil
+}
+
+func readOSVZip(path string) ([]*osvRecord, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var records []*osvRecord
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() || !strings.HasSuffix(zf.Name, ".json") {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+		recs, err := decodeOSV(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", path, zf.Name, err)
+		}
+		records = append(records, recs...)
+	}
+	return records, nil
+}
+
+func decodeOSV(r io.Reader) ([]*osvRecord, error) {
+	br := bufio.NewReader(r)
+	start, err := br.Peek(1)
+	for err == nil && len(bytes.TrimSpace(start)) == 0 {
+		if _, err = br.ReadByte(); err == nil {
+			start, err = br.Peek(1)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(br)
+	if start[0] == '[' {
+		var records []*osvRecord
+		if err := dec.Decode(&records); err != nil {
+			return nil, err
+		}
+		return records, nil
+	}
+	var record osvRecord
+	if err := dec.Decode(&record); err != nil {
+		return nil, err
+	}
+	return []*osvRecord{&record}, nil
+}
+
+// severityFromOSV returns the highest severity of the given OSV scores and
+// database specific ratings.
+func severityFromOSV(scores []osvSeverity, specific ...map[string]any) Severity {
+	severity := SeverityUnknown
+	for _, m := range specific {
+		if s, ok := m["severity"].(string); ok {
+			severity = max(severity, severityFromName(s))
+		}
+	}
+	for _, s := range scores {
+		switch s.Type {
+		case "CVSS_V3":
+			severity = max(severity, severityFromCVSS3(s.Score))
+		case "Ubuntu":
+			severity = max(severity, severityFromName(s.Score))
+		}
+	}
+	return severity
+}
+
+// toAdvisory converts a record to the stored form, nil for withdrawn
+// records.
+func (r *osvRecord) toAdvisory() *Advisory {
+	if r.ID == "" || r.Withdrawn != "" {
+		return nil
+	}
+	adv := &Advisory{
+		ID:       r.ID,
+		Aliases:  r.Aliases,
+		Summary:  r.Summary,
+		Severity: severityFromOSV(r.Severity, r.DatabaseSpecific),
+	}
+	for _, a := range r.Affected {
+		if a.Package.Name == "" || a.Package.Ecosystem == "" {
+			continue
+		}
+		affected := Affected{
+			Ecosystem: a.Package.Ecosystem,
+			Name:      a.Package.Name,
+			Severity:  severityFromOSV(a.Severity, a.EcosystemSpecific, a.DatabaseSpecific),
+			Versions:  a.Versions,
+		}
+		for _, rng := range a.Ranges {
+			if rng.Type == "GIT" {
+				continue
+			}
+			vr := Range{Type: rng.Type}
+			for _, e := range rng.Events {
+				vr.Events = append(vr.Events, Event{
+					Introduced:   e["introduced"],
+					Fixed:        e["fixed"],
+					LastAffected: e["last_affected"],
+					Limit:        e["limit"],
+				})
+			}
+			affected.Ranges = append(affected.Ranges, vr)
+		}
+		adv.Affected = append(adv.Affected, affected)
+	}
+	if len(adv.Affected) == 0 {
+		return nil
+	}
+	return adv
+}
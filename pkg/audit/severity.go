@@ -0,0 +1,176 @@
+package audit
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Severity of a vulnerability. The zero value is an unknown severity which
+// ranks below all others.
+type Severity int
+
+const (
+	SeverityUnknown Severity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+// Severities lists the names accepted by ParseSeverity.
+var Severities = []string{"low", "medium", "high", "critical"}
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	case SeverityCritical:
+		return "critical"
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Severity) UnmarshalText(text []byte) error {
+	*s = severityFromName(string(text))
+	return nil
+}
+
+// ParseSeverity parses a severity threshold given by the user.
+func ParseSeverity(s string) (Severity, error) {
+	for i, name := range Severities {
+		if strings.EqualFold(s, name) {
+			return Severity(i + 1), nil
+		}
+	}
+	return SeverityUnknown, fmt.Errorf("invalid severity %q, must be one of %s", s, strings.Join(Severities, ", "))
+}
+
+// severityFromName maps the ratings used by the different advisory sources
+// to a severity.
+func severityFromName(s string) Severity {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "critical":
+		return SeverityCritical
+	case "high", "important":
+		return SeverityHigh
+	case "medium", "moderate":
+		return SeverityMedium
+	case "low", "negligible", "unimportant":
+		return SeverityLow
+	}
+	return SeverityUnknown
+}
+
+// severityFromCVSS3 computes the base score of a CVSS v3.x vector and returns
+// its qualitative rating.
+func severityFromCVSS3(vector string) Severity {
+	score, ok := cvss3BaseScore(vector)
+	switch {
+	case !ok || score == 0:
+		return SeverityUnknown
+	case score >= 9:
+		return SeverityCritical
+	case score >= 7:
+		return SeverityHigh
+	case score >= 4:
+		return SeverityMedium
+	}
+	return SeverityLow
+}
+
+// cvss3BaseScore implements the base score equations of the CVSS v3.1
+// specification, section 7.1.
+func cvss3BaseScore(vector string) (float64, bool) {
+	parts := strings.Split(vector, "/")
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "CVSS:3") {
+		return 0, false
+	}
+	metrics := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, ok := strings.Cut(p, ":")
+		if !ok {
+			return 0, false
+		}
+		metrics[k] = v
+	}
+
+	weights := map[string]map[string]float64{
+		"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+		"AC": {"L": 0.77, "H": 0.44},
+		"UI": {"N": 0.85, "R": 0.62},
+		"C":  {"H": 0.56, "L": 0.22, "N": 0},
+		"I":  {"H": 0.56, "L": 0.22, "N": 0},
+		"A":  {"H": 0.56, "L": 0.22, "N": 0},
+	}
+	w := make(map[string]float64, len(weights))
+	for metric, values := range weights {
+		v, ok := values[metrics[metric]]
+		if !ok {
+			return 0, false
+		}
+		w[metric] = v
+	}
+
+	changed := false
+	switch metrics["S"] {
+	case "U":
+	case "C":
+		changed = true
+	default:
+		return 0, false
+	}
+	var pr float64
+	switch metrics["PR"] {
+	case "N":
+		pr = 0.85
+	case "L":
+		pr = 0.62
+		if changed {
+			pr = 0.68
+		}
+	case "H":
+		pr = 0.27
+		if changed {
+			pr = 0.5
+		}
+	default:
+		return 0, false
+	}
+
+	iss := 1 - (1-w["C"])*(1-w["I"])*(1-w["A"])
+	var impact float64
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	} else {
+		impact = 6.42 * iss
+	}
+	if impact <= 0 {
+		return 0, true
+	}
+	exploitability := 8.22 * w["AV"] * w["AC"] * pr * w["UI"]
+	if changed {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), true
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), true
+}
+
+// roundUp returns the smallest number with one decimal place that is equal
+// to or higher than x, see CVSS v3.1 Appendix A.
+func roundUp(x float64) float64 {
+	i := int(math.Round(x * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+	return float64(i/10000+1) / 10
+}
@@ -0,0 +1,368 @@
+package audit
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/mod/semver"
+)
+
+// compareFunc compares two versions and returns -1, 0 or +1.
+type compareFunc func(a, b string) int
+
+// comparatorFor returns the version comparison of the given OSV ecosystem.
+func comparatorFor(ecosystem string) compareFunc {
+	switch ecosystem {
+	case "Alpine", "Wolfi", "Chainguard":
+		return compareAPK
+	case "Debian", "Ubuntu":
+		return compareDeb
+	case "Red Hat", "Rocky Linux", "AlmaLinux", "openSUSE", "SUSE", "Mageia", "openEuler":
+		return compareRPM
+	case "npm", "Go":
+		return compareSemver
+	}
+	return compareGeneric
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareSemver compares semantic versions, with or without the leading v.
+// Invalid versions fall back to the generic comparison.
+func compareSemver(a, b string) int {
+	va, vb := "v"+strings.TrimPrefix(a, "v"), "v"+strings.TrimPrefix(b, "v")
+	if semver.IsValid(va) && semver.IsValid(vb) {
+		return semver.Compare(va, vb)
+	}
+	return compareGeneric(a, b)
+}
+
+// compareDeb implements the dpkg version comparison,
+// see deb-version(7).
+func compareDeb(a, b string) int {
+	ea, ua, ra := splitDeb(a)
+	eb, ub, rb := splitDeb(b)
+	if c := cmpInt(ea, eb); c != 0 {
+		return c
+	}
+	if c := compareDebPart(ua, ub); c != 0 {
+		return c
+	}
+	return compareDebPart(ra, rb)
+}
+
+func splitDeb(v string) (epoch int, upstream, revision string) {
+	if e, rest, ok := strings.Cut(v, ":"); ok {
+		if n, err := strconv.Atoi(e); err == nil {
+			epoch = n
+			v = rest
+		}
+	}
+	if i := strings.LastIndex(v, "-"); i >= 0 {
+		return epoch, v[:i], v[i+1:]
+	}
+	return epoch, v, ""
+}
+
+// debOrder ranks a character of the non-digit part of a version: ~ sorts
+// before everything, even the end of the part, letters before other
+// characters.
+func debOrder(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	c := s[i]
+	switch {
+	case c == '~':
+		return -1
+	case c >= '0' && c <= '9':
+		return 0
+	case unicode.IsLetter(rune(c)):
+		return int(c)
+	}
+	return int(c) + 256
+}
+
+func compareDebPart(a, b string) int {
+	for a != "" || b != "" {
+		i, j := 0, 0
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			if c := cmpInt(debOrder(a, i), debOrder(b, j)); c != 0 {
+				return c
+			}
+			i++
+			j++
+		}
+		a, b = a[min(i, len(a)):], b[min(j, len(b)):]
+		na, ra := leadingNumber(a)
+		nb, rb := leadingNumber(b)
+		if c := compareNumeric(na, nb); c != 0 {
+			return c
+		}
+		a, b = ra, rb
+	}
+	return 0
+}
+
+// compareRPM implements rpmvercmp on [EPOCH:]VERSION[-RELEASE] strings.
+func compareRPM(a, b string) int {
+	ea, va, ra := splitRPM(a)
+	eb, vb, rb := splitRPM(b)
+	if c := cmpInt(ea, eb); c != 0 {
+		return c
+	}
+	if c := rpmvercmp(va, vb); c != 0 {
+		return c
+	}
+	if ra == "" || rb == "" {
+		// A missing release matches any release.
+		return 0
+	}
+	return rpmvercmp(ra, rb)
+}
+
+func splitRPM(v string) (epoch int, version, release string) {
+	if e, rest, ok := strings.Cut(v, ":"); ok {
+		if n, err := strconv.Atoi(e); err == nil {
+			epoch = n
+			v = rest
+		}
+	}
+	version, release, _ = strings.Cut(v, "-")
+	return epoch, version, release
+}
+
+func isAlnum(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	for a != "" || b != "" {
+		for a != "" && !isAlnum(a[0]) && a[0] != '~' && a[0] != '^' {
+			a = a[1:]
+		}
+		for b != "" && !isAlnum(b[0]) && b[0] != '~' && b[0] != '^' {
+			b = b[1:]
+		}
+
+		// Tilde sorts before everything else.
+		if strings.HasPrefix(a, "~") || strings.HasPrefix(b, "~") {
+			if !strings.HasPrefix(a, "~") {
+				return 1
+			}
+			if !strings.HasPrefix(b, "~") {
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		// Caret sorts after the end of the version but before
+		// anything else.
+		if strings.HasPrefix(a, "^") || strings.HasPrefix(b, "^") {
+			switch {
+			case a == "":
+				return -1
+			case b == "":
+				return 1
+			case !strings.HasPrefix(a, "^"):
+				return 1
+			case !strings.HasPrefix(b, "^"):
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		if a == "" || b == "" {
+			break
+		}
+
+		var sa, sb string
+		numeric := isDigit(a[0])
+		if numeric {
+			sa, a = leadingNumber(a)
+			sb, b = leadingNumber(b)
+		} else {
+			sa, a = leadingAlpha(a)
+			sb, b = leadingAlpha(b)
+		}
+		if sb == "" {
+			// Numeric segments are newer than alpha segments.
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+		if numeric {
+			if c := compareNumeric(sa, sb); c != 0 {
+				return c
+			}
+		} else if c := strings.Compare(sa, sb); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	}
+	return 1
+}
+
+// apkSuffixes ranks the version suffixes of apk-tools, pre-release suffixes
+// sort before the plain version.
+var apkSuffixes = map[string]int{
+	"alpha": -4, "beta": -3, "pre": -2, "rc": -1,
+	"cvs": 1, "svn": 2, "git": 3, "hg": 4, "p": 5,
+}
+
+// compareAPK compares apk versions in the NUMBERS[LETTER][_SUFFIX...][-rREVISION]
+// form.
+func compareAPK(a, b string) int {
+	va, ra := splitAPKRevision(a)
+	vb, rb := splitAPKRevision(b)
+	baseA, sufA, _ := strings.Cut(va, "_")
+	baseB, sufB, _ := strings.Cut(vb, "_")
+	if c := compareGeneric(baseA, baseB); c != 0 {
+		return c
+	}
+	if c := compareAPKSuffixes(sufA, sufB); c != 0 {
+		return c
+	}
+	return cmpInt(ra, rb)
+}
+
+func splitAPKRevision(v string) (string, int) {
+	if i := strings.LastIndex(v, "-r"); i >= 0 {
+		if n, err := strconv.Atoi(v[i+2:]); err == nil {
+			return v[:i], n
+		}
+	}
+	return v, 0
+}
+
+func compareAPKSuffixes(a, b string) int {
+	for a != "" || b != "" {
+		var sa, sb string
+		sa, a, _ = strings.Cut(a, "_")
+		sb, b, _ = strings.Cut(b, "_")
+		nameA, numA := leadingAlpha(sa)
+		nameB, numB := leadingAlpha(sb)
+		if c := cmpInt(apkSuffixes[nameA], apkSuffixes[nameB]); c != 0 {
+			return c
+		}
+		if c := compareNumeric(numA, numB); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// preReleaseTags mark versions that sort before the version without them.
+var preReleaseTags = []string{"a", "alpha", "b", "beta", "c", "dev", "pre", "preview", "rc"}
+
+// compareGeneric compares versions by splitting them into numeric and
+// alphabetic segments. It follows the common conventions of PEP 440 and
+// similar schemes closely enough for matching advisories.
+func compareGeneric(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	for {
+		a = strings.TrimLeftFunc(a, isSeparator)
+		b = strings.TrimLeftFunc(b, isSeparator)
+		switch {
+		case a == "" && b == "":
+			return 0
+		case a == "":
+			return -trailingOrder(b)
+		case b == "":
+			return trailingOrder(a)
+		}
+		var sa, sb string
+		sa, a = nextSegment(a)
+		sb, b = nextSegment(b)
+		numA, numB := isDigit(sa[0]), isDigit(sb[0])
+		switch {
+		case numA && numB:
+			if c := compareNumeric(sa, sb); c != 0 {
+				return c
+			}
+		case numA:
+			// Numeric segments are newer than alphabetic ones.
+			return 1
+		case numB:
+			return -1
+		default:
+			if c := strings.Compare(sa, sb); c != 0 {
+				return c
+			}
+		}
+	}
+}
+
+// trailingOrder returns whether a version continuing with rest sorts before
+// (-1) or after (+1) a version ending here.
+func trailingOrder(rest string) int {
+	tag, _ := leadingAlpha(strings.TrimLeftFunc(rest, isSeparator))
+	if slices.Contains(preReleaseTags, tag) {
+		return -1
+	}
+	return 1
+}
+
+// nextSegment splits off the leading number, word or other single character
+// of s.
+func nextSegment(s string) (string, string) {
+	switch {
+	case isDigit(s[0]):
+		return leadingNumber(s)
+	case isAlnum(s[0]):
+		return leadingAlpha(s)
+	}
+	return s[:1], s[1:]
+}
+
+func isSeparator(r rune) bool {
+	return r == '.' || r == '-' || r == '_' || r == '+' || r == '~'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func leadingNumber(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func leadingAlpha(s string) (string, string) {
+	i := 0
+	for i < len(s) && isAlnum(s[i]) && !isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// compareNumeric compares two digit strings of arbitrary length.
+func compareNumeric(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if c := cmpInt(len(a), len(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		cmp  compareFunc
+		a, b string
+		want int
+	}{
+		// dpkg
+		{compareDeb, "1.0", "1.0", 0},
+		{compareDeb, "1.0-1", "1.0-2", -1},
+		{compareDeb, "1:1.0", "2.0", 1},
+		{compareDeb, "1.0~rc1", "1.0", -1},
+		{compareDeb, "1.0", "1.0+deb12u1", -1},
+		{compareDeb, "2.36-9+deb12u4", "2.36-9+deb12u10", -1},
+		{compareDeb, "1.0a", "1.0", 1},
+		// rpm
+		{compareRPM, "1.2.3-1.fc40", "1.2.3-1.fc40", 0},
+		{compareRPM, "1.2.3-1.fc40", "1.2.10-1.fc40", -1},
+		{compareRPM, "1:1.0-1", "2.0-1", 1},
+		{compareRPM, "1.0~rc1-1", "1.0-1", -1},
+		{compareRPM, "1.0^git1-1", "1.0-1", 1},
+		{compareRPM, "1.0^git1-1", "1.0.1-1", -1},
+		{compareRPM, "1.0a", "1.0.1", -1},
+		{compareRPM, "1.0-1", "1.0", 0},
+		// apk
+		{compareAPK, "1.2.4-r0", "1.2.4-r1", -1},
+		{compareAPK, "1.2.4_rc1-r0", "1.2.4-r0", -1},
+		{compareAPK, "1.2.4_p1-r0", "1.2.4-r0", 1},
+		{compareAPK, "3.1.4-r5", "3.1.10-r0", -1},
+		{compareAPK, "1.36.1-r15", "1.36.1-r15", 0},
+		// semver
+		{compareSemver, "1.2.3", "v1.2.3", 0},
+		{compareSemver, "1.2.3-beta.1", "1.2.3", -1},
+		{compareSemver, "1.21.5", "1.21.12", -1},
+		// generic
+		{compareGeneric, "2.31.0", "2.31.0", 0},
+		{compareGeneric, "2.31.0", "2.4.0", 1},
+		{compareGeneric, "1.0rc1", "1.0", -1},
+		{compareGeneric, "1.0.post1", "1.0", 1},
+		{compareGeneric, "1.0a1", "1.0b1", -1},
+		{compareGeneric, "1.0-1", "1.0+1", 0},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.cmp(tt.a, tt.b), "%q vs %q", tt.a, tt.b)
+		assert.Equal(t, -tt.want, tt.cmp(tt.b, tt.a), "%q vs %q", tt.b, tt.a)
+	}
+}
+
+func TestSeverityFromCVSS3(t *testing.T) {
+	tests := []struct {
+		vector string
+		score  float64
+		want   Severity
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8, SeverityCritical},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", 10, SeverityCritical},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H", 7.5, SeverityHigh},
+		{"CVSS:3.0/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:N/A:N", 5.5, SeverityMedium},
+		{"CVSS:3.1/AV:N/AC:H/PR:N/UI:R/S:U/C:L/I:N/A:N", 3.1, SeverityLow},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", 0, SeverityUnknown},
+	}
+	for _, tt := range tests {
+		score, ok := cvss3BaseScore(tt.vector)
+		assert.True(t, ok, tt.vector)
+		assert.Equal(t, tt.score, score, tt.vector)
+		assert.Equal(t, tt.want, severityFromCVSS3(tt.vector), tt.vector)
+	}
+
+	_, ok := cvss3BaseScore("CVSS:2.0/AV:N")
+	assert.False(t, ok)
+	_, ok = cvss3BaseScore("CVSS:3.1/AV:N/AC:L")
+	assert.False(t, ok)
+}
+
+func TestParseSeverity(t *testing.T) {
+	s, err := ParseSeverity("High")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityHigh, s)
+	assert.Equal(t, "high", s.String())
+
+	_, err = ParseSeverity("important")
+	assert.ErrorContains(t, err, "must be one of low, medium, high, critical")
+}
@@ -0,0 +1,39 @@
+package artifacts
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/containers/podman/v6/pkg/bindings"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+)
+
+// Refresh swaps the content of the refreshing artifact volumes of containers
+// whose artifacts changed in local storage.
+func Refresh(ctx context.Context, options *RefreshOptions) ([]*entities.ArtifactRefreshReport, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if options == nil {
+		options = new(RefreshOptions)
+	}
+
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/artifacts/refresh", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var reports []*entities.ArtifactRefreshReport
+	if err := response.Process(&reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
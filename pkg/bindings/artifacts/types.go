@@ -56,6 +56,16 @@ type RemoveOptions struct {
 	Ignore *bool
 }
 
+// RefreshOptions are optional options for refreshing artifact volumes
+//
+//go:generate go run ../generator/generator.go RefreshOptions
+type RefreshOptions struct {
+	// Refresh the artifact volumes of all running containers
+	All *bool
+	// Containers is a list of container IDs or names to refresh
+	Containers []string
+}
+
 // AddOptions are optional options for removing images
 //
 //go:generate go run ../generator/generator.go AddOptions
@@ -424,9 +424,11 @@ func ExecStartAndAttach(ctx context.Context, sessionID string, options *ExecStar
 		TTY    bool   `json:"Tty"`
 		Height uint16 `json:"h"`
 		Width  uint16 `json:"w"`
+		Record bool   `json:"Record,omitempty"`
 	}{
 		Detach: false,
 		TTY:    needTTY,
+		Record: options.GetRecord(),
 	}
 
 	if needTTY {
@@ -315,6 +315,57 @@ func Top(ctx context.Context, nameOrID string, options *TopOptions) ([]string, e
 	return topOutput, err
 }
 
+// TopStats gets the resource usage of the processes running in a container.  The
+// nameOrID can be a container name or a partial/full ID.  Unless streaming is
+// disabled in the options, a report is sent every interval until the container
+// stops or the context is cancelled.
+func TopStats(ctx context.Context, nameOrID string, options *TopStatsOptions) (chan types.ContainerTopStatsReport, error) {
+	if options == nil {
+		options = new(TopStatsOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/top/stats", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if !response.IsSuccess() {
+		defer response.Body.Close()
+		return nil, response.Process(nil)
+	}
+
+	statsChan := make(chan types.ContainerTopStatsReport)
+	go func() {
+		defer close(statsChan)
+		defer response.Body.Close()
+
+		dec := json.NewDecoder(response.Body)
+		for {
+			var report types.ContainerTopStatsReport
+			if err := dec.Decode(&report); err != nil {
+				// The server ends the stream when the container stops.
+				if !errors.Is(err, io.EOF) {
+					statsChan <- types.ContainerTopStatsReport{Error: err}
+				}
+				return
+			}
+			select {
+			case statsChan <- report:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return statsChan, nil
+}
+
 // Unpause resumes the given paused container.  The nameOrID can be a container name
 // or a partial/full ID.
 func Unpause(ctx context.Context, nameOrID string, options *UnpauseOptions) error {
@@ -46,6 +46,7 @@ type AttachOptions struct {
 	DetachKeys *string // Keys to detach from running container
 	Logs       *bool   // Flag to return all logs from container when true
 	Stream     *bool   // Flag only return container logs when false and Logs is true
+	Record     *bool   // Record the session to an asciicast file on the server
 }
 
 // CheckpointOptions are optional options for checkpointing containers
@@ -152,6 +153,7 @@ type ListOptions struct {
 	Namespace *bool
 	Size      *bool
 	Sync      *bool
+	Where     *string
 }
 
 // PruneOptions are optional options for pruning containers
@@ -223,6 +225,16 @@ type TopOptions struct {
 	Descriptors *[]string
 }
 
+// TopStatsOptions are optional options for getting the resource usage
+// of the processes in containers
+//
+//go:generate go run ../generator/generator.go TopStatsOptions
+type TopStatsOptions struct {
+	Stream   *bool
+	Interval *int
+	Sort     *string
+}
+
 // UnpauseOptions are optional options for unpausing containers
 //
 //go:generate go run ../generator/generator.go UnpauseOptions
@@ -313,6 +325,9 @@ type ExecStartAndAttachOptions struct {
 	// AttachInput is whether to attach to STDIN
 	// If false, stdout will not be attached
 	AttachInput *bool
+	// Record is whether to record the session to an asciicast file on
+	// the server
+	Record *bool
 }
 
 // ExistsOptions are optional options for checking if a container exists
@@ -332,6 +347,8 @@ type CopyOptions struct {
 	Chown *bool `schema:"copyUIDGID"`
 	// Map to translate path names.
 	Rename map[string]string
+	// Paths, relative to the destination, to remove before copying.
+	Remove []string
 	// NoOverwriteDirNonDir when true prevents an existing directory or file from being overwritten
 	// by the other type.
 	NoOverwriteDirNonDir *bool
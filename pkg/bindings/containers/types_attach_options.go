@@ -61,3 +61,18 @@ func (o *AttachOptions) GetStream() bool {
 	}
 	return *o.Stream
 }
+
+// WithRecord set record the session to an asciicast file on the server
+func (o *AttachOptions) WithRecord(value bool) *AttachOptions {
+	o.Record = &value
+	return o
+}
+
+// GetRecord returns value of record the session to an asciicast file on the server
+func (o *AttachOptions) GetRecord() bool {
+	if o.Record == nil {
+		var z bool
+		return z
+	}
+	return *o.Record
+}
@@ -47,6 +47,21 @@ func (o *CopyOptions) GetRename() map[string]string {
 	return o.Rename
 }
 
+// WithRemove set field Remove to given value
+func (o *CopyOptions) WithRemove(value []string) *CopyOptions {
+	o.Remove = value
+	return o
+}
+
+// GetRemove returns value of field Remove
+func (o *CopyOptions) GetRemove() []string {
+	if o.Remove == nil {
+		var z []string
+		return z
+	}
+	return o.Remove
+}
+
 // WithNoOverwriteDirNonDir set field NoOverwriteDirNonDir to given value
 func (o *CopyOptions) WithNoOverwriteDirNonDir(value bool) *CopyOptions {
 	o.NoOverwriteDirNonDir = &value
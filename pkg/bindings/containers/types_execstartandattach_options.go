@@ -108,3 +108,18 @@ func (o *ExecStartAndAttachOptions) GetAttachInput() bool {
 	}
 	return *o.AttachInput
 }
+
+// WithRecord set field Record to given value
+func (o *ExecStartAndAttachOptions) WithRecord(value bool) *ExecStartAndAttachOptions {
+	o.Record = &value
+	return o
+}
+
+// GetRecord returns value of field Record
+func (o *ExecStartAndAttachOptions) GetRecord() bool {
+	if o.Record == nil {
+		var z bool
+		return z
+	}
+	return *o.Record
+}
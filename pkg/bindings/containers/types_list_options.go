@@ -121,3 +121,18 @@ func (o *ListOptions) GetSync() bool {
 	}
 	return *o.Sync
 }
+
+// WithWhere set field Where to given value
+func (o *ListOptions) WithWhere(value string) *ListOptions {
+	o.Where = &value
+	return o
+}
+
+// GetWhere returns value of field Where
+func (o *ListOptions) GetWhere() string {
+	if o.Where == nil {
+		var z string
+		return z
+	}
+	return *o.Where
+}
@@ -40,6 +40,8 @@ type ListOptions struct {
 	All *bool
 	// filters that can be used to get a more specific list of images
 	Filters map[string][]string
+	// Where is an expression evaluated over the inspect data of each image
+	Where *string
 }
 
 // GetOptions are optional options for inspecting an image
@@ -124,6 +124,34 @@ func DownWithBody(ctx context.Context, body io.Reader, options DownOptions) (*en
 	return &report, nil
 }
 
+// Rollback rolls a Deployment played by kube play back to a previous revision
+func Rollback(ctx context.Context, name string, options *RollbackOptions) (*entitiesTypes.KubeRollbackReport, error) {
+	var report entitiesTypes.KubeRollbackReport
+	if options == nil {
+		options = new(RollbackOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/kube/rollback/%s", params, nil, name)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if err := response.Process(&report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
 // Kube generate Kubernetes YAML (v1 specification)
 func Generate(ctx context.Context, nameOrIDs []string, options generate.KubeOptions) (*entitiesTypes.GenerateKubeReport, error) {
 	return generate.Kube(ctx, nameOrIDs, &options)
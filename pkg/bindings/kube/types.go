@@ -46,6 +46,8 @@ type PlayOptions struct {
 	LogOptions *[]string
 	// Replace - replace existing pods and containers
 	Replace *bool
+	// Rolling - update the pods of running Deployments one revision at a time
+	Rolling *bool
 	// Start - don't start the pod if false
 	Start *bool
 	// NoTrunc - use annotations that were not truncated to the
@@ -82,6 +84,15 @@ type ApplyOptions struct {
 	Service *bool
 }
 
+// RollbackOptions are optional options for rolling back a Deployment played
+// by kube play
+//
+//go:generate go run ../generator/generator.go RollbackOptions
+type RollbackOptions struct {
+	// Revision to roll back to, the previously running one if unset
+	Revision *int
+}
+
 // DownOptions are optional options for tearing down kube YAML files to a k8s cluster
 //
 //go:generate go run ../generator/generator.go DownOptions
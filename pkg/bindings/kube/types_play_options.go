@@ -288,6 +288,21 @@ func (o *PlayOptions) GetReplace() bool {
 	return *o.Replace
 }
 
+// WithRolling set field Rolling to given value
+func (o *PlayOptions) WithRolling(value bool) *PlayOptions {
+	o.Rolling = &value
+	return o
+}
+
+// GetRolling returns value of field Rolling
+func (o *PlayOptions) GetRolling() bool {
+	if o.Rolling == nil {
+		var z bool
+		return z
+	}
+	return *o.Rolling
+}
+
 // WithStart set field Start to given value
 func (o *PlayOptions) WithStart(value bool) *PlayOptions {
 	o.Start = &value
@@ -0,0 +1,94 @@
+package network
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/bindings"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// PolicyCreate creates a network policy and enforces it on the running
+// containers of its networks.
+func PolicyCreate(ctx context.Context, policy *define.NetworkPolicy, options *PolicyCreateOptions) (*define.NetworkPolicy, error) {
+	if options == nil {
+		options = new(PolicyCreateOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	body, err := jsoniter.MarshalToString(policy)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, strings.NewReader(body), http.MethodPost, "/networkpolicies/create", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var report define.NetworkPolicy
+	return &report, response.Process(&report)
+}
+
+// PolicyInspect returns the network policy with the given name.
+func PolicyInspect(ctx context.Context, name string) (*define.NetworkPolicy, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/networkpolicies/%s/json", nil, nil, name)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var report define.NetworkPolicy
+	return &report, response.Process(&report)
+}
+
+// PolicyList returns all network policies.
+func PolicyList(ctx context.Context) ([]*define.NetworkPolicy, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/networkpolicies/json", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var reports []*define.NetworkPolicy
+	return reports, response.Process(&reports)
+}
+
+// PolicyRemove removes a network policy and lifts the isolation of the
+// containers it selected unless other policies select them.
+func PolicyRemove(ctx context.Context, name string, options *PolicyRemoveOptions) error {
+	if options == nil {
+		options = new(PolicyRemoveOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodDelete, "/networkpolicies/%s", params, nil, name)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return response.Process(nil)
+}
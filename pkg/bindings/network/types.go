@@ -99,3 +99,19 @@ type ExtraCreateOptions struct {
 	// IgnoreIfExists if true, do not fail if the network already exists
 	IgnoreIfExists *bool `schema:"ignoreIfExists"`
 }
+
+// PolicyCreateOptions are optional options for creating network policies
+//
+//go:generate go run ../generator/generator.go PolicyCreateOptions
+type PolicyCreateOptions struct {
+	// Replace an existing policy of the same name
+	Replace *bool
+}
+
+// PolicyRemoveOptions are optional options for removing network policies
+//
+//go:generate go run ../generator/generator.go PolicyRemoveOptions
+type PolicyRemoveOptions struct {
+	// Ignore a policy that does not exist
+	Ignore *bool
+}
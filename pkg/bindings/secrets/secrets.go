@@ -89,6 +89,73 @@ func Create(ctx context.Context, reader io.Reader, options *CreateOptions) (*ent
 	return create, response.Process(&create)
 }
 
+// Update replaces the data of a secret and updates it in the containers using it
+func Update(ctx context.Context, nameOrID string, reader io.Reader, options *UpdateOptions) (*entitiesTypes.SecretUpdateReport, error) {
+	var update *entitiesTypes.SecretUpdateReport
+	if options == nil {
+		options = new(UpdateOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(ctx, reader, http.MethodPost, "/secrets/%s/update", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return update, response.Process(&update)
+}
+
+// Rollback restores a previous version of a secret and updates it in the containers using it
+func Rollback(ctx context.Context, nameOrID string, options *RollbackOptions) (*entitiesTypes.SecretUpdateReport, error) {
+	var update *entitiesTypes.SecretUpdateReport
+	if options == nil {
+		options = new(RollbackOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/secrets/%s/rollback", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return update, response.Process(&update)
+}
+
+// History lists the current and previous versions of a secret
+func History(ctx context.Context, nameOrID string, _ *HistoryOptions) ([]*entitiesTypes.SecretHistoryReport, error) {
+	var history []*entitiesTypes.SecretHistoryReport
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/secrets/%s/history", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return history, response.Process(&history)
+}
+
 func Exists(ctx context.Context, nameOrID string) (bool, error) {
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
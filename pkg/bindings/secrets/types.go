@@ -5,6 +5,8 @@ package secrets
 //go:generate go run ../generator/generator.go ListOptions
 type ListOptions struct {
 	Filters map[string][]string
+	// All lists the previous versions of secrets too.
+	All *bool
 }
 
 // InspectOptions are optional options for inspecting secrets
@@ -23,10 +25,35 @@ type RemoveOptions struct{}
 //
 //go:generate go run ../generator/generator.go CreateOptions
 type CreateOptions struct {
-	Name       *string
-	Driver     *string
-	DriverOpts map[string]string
-	Labels     map[string]string
-	Replace    *bool
-	Ignore     *bool
+	Name         *string
+	Driver       *string
+	DriverOpts   map[string]string
+	Labels       map[string]string
+	Replace      *bool
+	Ignore       *bool
+	KeepVersions *int
 }
+
+// UpdateOptions are optional options for updating secrets
+//
+//go:generate go run ../generator/generator.go UpdateOptions
+type UpdateOptions struct {
+	Signal       *string
+	Exec         *string
+	KeepVersions *int
+}
+
+// RollbackOptions are optional options for rolling back secrets
+//
+//go:generate go run ../generator/generator.go RollbackOptions
+type RollbackOptions struct {
+	Version      *int
+	Signal       *string
+	Exec         *string
+	KeepVersions *int
+}
+
+// HistoryOptions are optional options for listing the versions of secrets
+//
+//go:generate go run ../generator/generator.go HistoryOptions
+type HistoryOptions struct{}
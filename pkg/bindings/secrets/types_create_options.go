@@ -106,3 +106,18 @@ func (o *CreateOptions) GetIgnore() bool {
 	}
 	return *o.Ignore
 }
+
+// WithKeepVersions set field KeepVersions to given value
+func (o *CreateOptions) WithKeepVersions(value int) *CreateOptions {
+	o.KeepVersions = &value
+	return o
+}
+
+// GetKeepVersions returns value of field KeepVersions
+func (o *CreateOptions) GetKeepVersions() int {
+	if o.KeepVersions == nil {
+		var z int
+		return z
+	}
+	return *o.KeepVersions
+}
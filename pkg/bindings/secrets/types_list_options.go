@@ -31,3 +31,18 @@ func (o *ListOptions) GetFilters() map[string][]string {
 	}
 	return o.Filters
 }
+
+// WithAll set field All to given value
+func (o *ListOptions) WithAll(value bool) *ListOptions {
+	o.All = &value
+	return o
+}
+
+// GetAll returns value of field All
+func (o *ListOptions) GetAll() bool {
+	if o.All == nil {
+		var z bool
+		return z
+	}
+	return *o.All
+}
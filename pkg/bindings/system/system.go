@@ -59,6 +59,98 @@ func Events(ctx context.Context, eventChan chan types.Event, cancelChan chan boo
 	return nil
 }
 
+// SubscribeEvents monitors events like Events, but survives restarts of the
+// service: when the connection is lost, it reconnects and resumes after the
+// cursor of the last received event, so that no event is lost or received
+// twice. Before the first event is received there is no cursor to resume
+// from, a reconnection then only passes new events. Events are passed to
+// eventChan until ctx is cancelled, then eventChan is closed. Only errors of
+// the first connection are returned.
+func SubscribeEvents(ctx context.Context, eventChan chan types.Event, options *EventsOptions) error {
+	if options == nil {
+		options = new(EventsOptions)
+	}
+	if options.Changed("Stream") && !options.GetStream() {
+		// there is nothing to resume once all events are read
+		return Events(ctx, eventChan, nil, options)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The options are updated with the cursor of every received event.
+	opts := *options
+	response, err := connectEvents(ctx, conn, &opts)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer close(eventChan)
+		delay := time.Second
+		for {
+			if receiveEvents(ctx, response, eventChan, &opts) {
+				delay = time.Second
+			}
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+				delay = min(delay*2, 30*time.Second)
+				response, err = connectEvents(ctx, conn, &opts)
+				if err == nil {
+					break
+				}
+				logrus.Debugf("Reconnecting to events: %v", err)
+			}
+		}
+	}()
+	return nil
+}
+
+func connectEvents(ctx context.Context, conn *bindings.Connection, options *EventsOptions) (*bindings.APIResponse, error) {
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/events", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, response.Process(nil)
+	}
+	return response, nil
+}
+
+// receiveEvents passes the events of response to eventChan until the
+// connection is closed and reports whether any were received.
+func receiveEvents(ctx context.Context, response *bindings.APIResponse, eventChan chan types.Event, options *EventsOptions) bool {
+	defer response.Body.Close()
+	received := false
+	dec := json.NewDecoder(response.Body)
+	for {
+		e := types.Event{}
+		if err := dec.Decode(&e); err != nil {
+			logrus.Debugf("Lost connection to events: %v", err)
+			return received
+		}
+		if e.Cursor != "" {
+			options.WithSinceCursor(e.Cursor)
+		}
+		received = true
+		select {
+		case eventChan <- e:
+		case <-ctx.Done():
+			return received
+		}
+	}
+}
+
 // Prune removes all unused system data.
 func Prune(ctx context.Context, options *PruneOptions) (*types.SystemPruneReport, error) {
 	var report types.SystemPruneReport
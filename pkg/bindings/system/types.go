@@ -6,8 +6,11 @@ package system
 type EventsOptions struct {
 	Filters map[string][]string
 	Since   *string
-	Stream  *bool
-	Until   *string
+	// SinceCursor only returns the events after the event with the given
+	// cursor.
+	SinceCursor *string `schema:"sinceCursor"`
+	Stream      *bool
+	Until       *string
 }
 
 // PruneOptions are optional options for pruning
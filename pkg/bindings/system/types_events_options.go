@@ -47,6 +47,21 @@ func (o *EventsOptions) GetSince() string {
 	return *o.Since
 }
 
+// WithSinceCursor set field SinceCursor to given value
+func (o *EventsOptions) WithSinceCursor(value string) *EventsOptions {
+	o.SinceCursor = &value
+	return o
+}
+
+// GetSinceCursor returns value of field SinceCursor
+func (o *EventsOptions) GetSinceCursor() string {
+	if o.SinceCursor == nil {
+		var z string
+		return z
+	}
+	return *o.SinceCursor
+}
+
 // WithStream set field Stream to given value
 func (o *EventsOptions) WithStream(value bool) *EventsOptions {
 	o.Stream = &value
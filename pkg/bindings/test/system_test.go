@@ -1,6 +1,7 @@
 package bindings_test
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -63,6 +64,50 @@ var _ = Describe("Podman system", func() {
 		Expect(eventCounter).To(BeNumerically(">", 0))
 	})
 
+	It("podman events subscription resumes after service restart", func() {
+		ctx, cancel := context.WithCancel(bt.conn)
+		defer cancel()
+
+		filters := map[string][]string{"type": {"volume"}}
+		eventChan := make(chan entities.Event)
+		err := system.SubscribeEvents(ctx, eventChan, new(system.EventsOptions).WithFilters(filters))
+		Expect(err).ToNot(HaveOccurred())
+
+		nextVolume := func() string {
+			select {
+			case e := <-eventChan:
+				Expect(e.Cursor).ToNot(BeEmpty())
+				return e.Actor.Attributes["name"]
+			case <-time.After(time.Minute):
+				Fail("timed out waiting for volume event")
+				return ""
+			}
+		}
+
+		createVolume := func(name string) {
+			session := bt.runPodman([]string{"volume", "create", name})
+			session.Wait(45)
+			Expect(session.ExitCode()).To(BeZero())
+		}
+
+		createVolume("vol1")
+		Expect(nextVolume()).To(Equal("vol1"))
+
+		// events while the service is down must not be lost
+		s.Kill()
+		s.Wait(45)
+		createVolume("vol2")
+		s = bt.startAPIService()
+		createVolume("vol3")
+
+		Expect(nextVolume()).To(Equal("vol2"))
+		Expect(nextVolume()).To(Equal("vol3"))
+		Consistently(eventChan, 3*time.Second).ShouldNot(Receive())
+
+		cancel()
+		Eventually(eventChan, 10*time.Second).Should(BeClosed())
+	})
+
 	It("podman system prune - pod,container stopped", func() {
 		// Start and stop a pod to enter in exited state.
 		_, err := pods.Start(bt.conn, newpod, nil)
@@ -16,6 +16,8 @@ type InspectOptions struct{}
 type ListOptions struct {
 	// Filters applied to the listing of volumes
 	Filters map[string][]string
+	// Where is an expression evaluated over the inspect data of each volume
+	Where *string
 }
 
 // PruneOptions are optional options for pruning volumes
@@ -31,3 +31,18 @@ func (o *ListOptions) GetFilters() map[string][]string {
 	}
 	return o.Filters
 }
+
+// WithWhere set field Where to given value
+func (o *ListOptions) WithWhere(value string) *ListOptions {
+	o.Where = &value
+	return o
+}
+
+// GetWhere returns value of field Where
+func (o *ListOptions) GetWhere() string {
+	if o.Where == nil {
+		var z string
+		return z
+	}
+	return *o.Where
+}
@@ -0,0 +1,467 @@
+package compose
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/signal"
+	"github.com/containers/podman/v6/pkg/specgen"
+	"github.com/containers/podman/v6/pkg/specgenutil"
+	"github.com/containers/podman/v6/pkg/util"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"go.podman.io/common/libnetwork/types"
+	"go.podman.io/image/v5/manifest"
+	"gopkg.in/yaml.v3"
+)
+
+// Labels set on the resources created for a project. They are the labels
+// used by Docker Compose, so that tools looking for them find the
+// containers.
+const (
+	ProjectLabel         = "com.docker.compose.project"
+	ServiceLabel         = "com.docker.compose.service"
+	ContainerNumberLabel = "com.docker.compose.container-number"
+	ConfigHashLabel      = "com.docker.compose.config-hash"
+	WorkingDirLabel      = "com.docker.compose.project.working_dir"
+	ConfigFilesLabel     = "com.docker.compose.project.config_files"
+	NetworkLabel         = "com.docker.compose.network"
+	VolumeLabel          = "com.docker.compose.volume"
+	SecretLabel          = "com.docker.compose.secret"
+	ConfigLabel          = "com.docker.compose.config"
+)
+
+// Healthcheck defaults of the compose specification.
+const (
+	defaultHealthInterval = 30 * time.Second
+	defaultHealthTimeout  = 30 * time.Second
+	defaultHealthRetries  = 3
+)
+
+// PodName returns the name of the pod grouping the containers of the
+// project.
+func (p *Project) PodName() string {
+	return "pod_" + p.Name
+}
+
+// ContainerName returns the name of a container of the service.
+func (p *Project) ContainerName(s *Service, number int) string {
+	if s.ContainerName != "" {
+		return s.ContainerName
+	}
+	return fmt.Sprintf("%s-%s-%d", p.Name, s.Name, number)
+}
+
+// NetworkName returns the name of the network created for a network of the
+// project.
+func (p *Project) NetworkName(name string) string {
+	if n := p.Networks[name]; n != nil && n.Name != "" {
+		return n.Name
+	}
+	if n := p.Networks[name]; n != nil && n.External {
+		return name
+	}
+	return p.Name + "_" + name
+}
+
+// VolumeName returns the name of the volume created for a volume of the
+// project.
+func (p *Project) VolumeName(name string) string {
+	if v := p.Volumes[name]; v != nil && v.Name != "" {
+		return v.Name
+	}
+	if v := p.Volumes[name]; v != nil && v.External {
+		return name
+	}
+	return p.Name + "_" + name
+}
+
+// SecretName returns the name of the Podman secret created for a secret of
+// the project.
+func (p *Project) SecretName(name string) string {
+	return fileObjectName(p.Secrets[name], name, p.Name+"_")
+}
+
+// ConfigName returns the name of the Podman secret created for a config of
+// the project. Configs are mounted as secrets.
+func (p *Project) ConfigName(name string) string {
+	return fileObjectName(p.Configs[name], name, p.Name+"_config_")
+}
+
+func fileObjectName(o *FileObject, name, prefix string) string {
+	switch {
+	case o != nil && o.Name != "":
+		return o.Name
+	case o != nil && bool(o.External):
+		return name
+	}
+	return prefix + name
+}
+
+// Labels returns the labels set on all resources of the project.
+func (p *Project) Labels() map[string]string {
+	return map[string]string{
+		ProjectLabel:     p.Name,
+		WorkingDirLabel:  p.WorkingDir,
+		ConfigFilesLabel: strings.Join(p.ComposeFiles, ","),
+	}
+}
+
+// ConfigHash returns a hash of the configuration of the service, to detect
+// whether its containers have to be recreated.
+func (s *Service) ConfigHash() (string, error) {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ServiceSpec returns the spec of container number of the service. The
+// container is created in pod if it is not empty.
+func (p *Project) ServiceSpec(svc *Service, number int, pod string) (*specgen.SpecGenerator, error) {
+	s := specgen.NewSpecGenerator(svc.Image, false)
+	s.Name = p.ContainerName(svc, number)
+	s.Pod = pod
+
+	hash, err := svc.ConfigHash()
+	if err != nil {
+		return nil, err
+	}
+	s.Labels = p.Labels()
+	maps.Copy(s.Labels, svc.Labels)
+	s.Labels[ServiceLabel] = svc.Name
+	s.Labels[ContainerNumberLabel] = strconv.Itoa(number)
+	s.Labels[ConfigHashLabel] = hash
+	if len(svc.Annotations) > 0 {
+		s.Annotations = maps.Clone(svc.Annotations)
+	}
+
+	s.Command = svc.Command
+	s.Entrypoint = svc.Entrypoint
+	s.Env = make(map[string]string, len(svc.Environment))
+	for k, v := range svc.Environment {
+		if v != nil {
+			s.Env[k] = *v
+		}
+	}
+	s.Terminal = &svc.Tty
+	s.Stdin = &svc.StdinOpen
+	s.Hostname = svc.Hostname
+	s.User = svc.User
+	s.WorkDir = svc.WorkingDir
+	s.Privileged = &svc.Privileged
+	s.ReadOnlyFilesystem = &svc.ReadOnly
+	s.CapAdd = svc.CapAdd
+	s.CapDrop = svc.CapDrop
+	s.Init = svc.Init
+	s.HostAdd = svc.ExtraHosts
+	s.DNSSearch = svc.DNSSearch
+	s.DNSOptions = svc.DNSOpt
+	for _, dns := range svc.DNS {
+		ip := net.ParseIP(dns)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid DNS server %q", dns)
+		}
+		s.DNSServers = append(s.DNSServers, ip)
+	}
+	if len(svc.Sysctls) > 0 {
+		s.Sysctl = maps.Clone(svc.Sysctls)
+	}
+	for _, dev := range svc.Devices {
+		s.Devices = append(s.Devices, specs.LinuxDevice{Path: dev})
+	}
+
+	if svc.StopSignal != "" {
+		sig, err := signal.ParseSignalNameOrNumber(svc.StopSignal)
+		if err != nil {
+			return nil, err
+		}
+		s.StopSignal = &sig
+	}
+	if svc.StopGracePeriod != "" {
+		d, err := time.ParseDuration(svc.StopGracePeriod)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stop_grace_period: %w", err)
+		}
+		timeout := uint(d.Round(time.Second).Seconds())
+		s.StopTimeout = &timeout
+	}
+	if err := setRestartPolicy(s, svc.Restart); err != nil {
+		return nil, err
+	}
+
+	if s.PortMappings, err = specgenutil.CreatePortBindings(svc.Ports); err != nil {
+		return nil, err
+	}
+	if len(svc.Expose) > 0 {
+		if s.Expose, err = specgenutil.CreateExpose(svc.Expose); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.setNetworks(s, svc); err != nil {
+		return nil, err
+	}
+	if err := p.setVolumes(s, svc); err != nil {
+		return nil, err
+	}
+	if s.HealthConfig, err = healthConfig(svc.Healthcheck); err != nil {
+		return nil, err
+	}
+
+	for _, ref := range svc.Secrets {
+		secret, err := fileObjectSecret(ref, p.SecretName(ref.Source), ref.Source)
+		if err != nil {
+			return nil, err
+		}
+		s.Secrets = append(s.Secrets, secret)
+	}
+	for _, ref := range svc.Configs {
+		target := ref.Target
+		if target == "" {
+			target = "/" + ref.Source
+		}
+		secret, err := fileObjectSecret(ref, p.ConfigName(ref.Source), target)
+		if err != nil {
+			return nil, err
+		}
+		s.Secrets = append(s.Secrets, secret)
+	}
+	return s, nil
+}
+
+func setRestartPolicy(s *specgen.SpecGenerator, restart string) error {
+	if restart == "" {
+		return nil
+	}
+	policy, retries, err := util.ParseRestartPolicy(restart)
+	if err != nil {
+		return err
+	}
+	s.RestartPolicy = policy
+	if policy == define.RestartPolicyOnFailure && retries > 0 {
+		s.RestartRetries = &retries
+	}
+	return nil
+}
+
+// setNetworks sets the network namespace and networks of the spec.
+func (p *Project) setNetworks(s *specgen.SpecGenerator, svc *Service) error {
+	if svc.NetworkMode != "" {
+		if service, ok := strings.CutPrefix(svc.NetworkMode, "service:"); ok {
+			s.NetNS = specgen.Namespace{NSMode: specgen.FromContainer, Value: p.ContainerName(p.Services[service], 1)}
+			return nil
+		}
+		ns, networks, opts, err := specgen.ParseNetworkFlag([]string{svc.NetworkMode})
+		if err != nil {
+			return err
+		}
+		s.NetNS, s.Networks, s.NetworkOptions = ns, networks, opts
+		return nil
+	}
+
+	s.NetNS = specgen.Namespace{NSMode: specgen.Bridge}
+	s.Networks = make(map[string]types.PerNetworkOptions, len(svc.Networks))
+	for name, cfg := range svc.Networks {
+		opts := types.PerNetworkOptions{Aliases: []string{svc.Name}}
+		if cfg != nil {
+			opts.Aliases = append(opts.Aliases, cfg.Aliases...)
+			for _, addr := range []string{cfg.IPv4Address, cfg.IPv6Address} {
+				if addr == "" {
+					continue
+				}
+				if svc.Replicas() > 1 {
+					return fmt.Errorf("static IP address %s cannot be set for a service with more than one replica", addr)
+				}
+				ip := net.ParseIP(addr)
+				if ip == nil {
+					return fmt.Errorf("invalid IP address %q", addr)
+				}
+				opts.StaticIPs = append(opts.StaticIPs, ip)
+			}
+			if cfg.MacAddress != "" {
+				mac, err := net.ParseMAC(cfg.MacAddress)
+				if err != nil {
+					return err
+				}
+				opts.StaticMAC = types.HardwareAddr(mac)
+			}
+		}
+		s.Networks[p.NetworkName(name)] = opts
+	}
+	return nil
+}
+
+// setVolumes sets the mounts of the spec.
+func (p *Project) setVolumes(s *specgen.SpecGenerator, svc *Service) error {
+	var volumes []string
+	for _, v := range svc.Volumes {
+		var opts []string
+		if v.ReadOnly {
+			opts = append(opts, "ro")
+		}
+		opts = append(opts, v.Options...)
+
+		switch v.Type {
+		case VolumeTypeTmpfs:
+			if v.Tmpfs != nil {
+				if v.Tmpfs.Size != "" {
+					opts = append(opts, "size="+v.Tmpfs.Size)
+				}
+				if v.Tmpfs.Mode != 0 {
+					opts = append(opts, fmt.Sprintf("mode=%o", v.Tmpfs.Mode))
+				}
+			}
+			s.Mounts = append(s.Mounts, tmpfsMount(v.Target, opts))
+			continue
+		case VolumeTypeBind:
+			if v.Bind != nil && v.Bind.SELinux != "" {
+				opts = append(opts, v.Bind.SELinux)
+			}
+			volumes = append(volumes, volumeString(v.Source, v.Target, opts))
+		default:
+			if v.Volume != nil && v.Volume.NoCopy {
+				opts = append(opts, "nocopy")
+			}
+			if v.Volume != nil && v.Volume.Subpath != "" {
+				return fmt.Errorf("volume %s: subpath is not supported", v.Target)
+			}
+			source := ""
+			if v.Source != "" {
+				source = p.VolumeName(v.Source)
+			}
+			volumes = append(volumes, volumeString(source, v.Target, opts))
+		}
+	}
+	for _, t := range svc.Tmpfs {
+		dest, opts, _ := strings.Cut(t, ":")
+		var options []string
+		if opts != "" {
+			options = strings.Split(opts, ",")
+		}
+		s.Mounts = append(s.Mounts, tmpfsMount(dest, options))
+	}
+
+	mounts, named, overlays, err := specgen.GenVolumeMounts(volumes)
+	if err != nil {
+		return err
+	}
+	for _, dest := range slices.Sorted(maps.Keys(mounts)) {
+		s.Mounts = append(s.Mounts, mounts[dest])
+	}
+	for _, dest := range slices.Sorted(maps.Keys(named)) {
+		s.Volumes = append(s.Volumes, named[dest])
+	}
+	for _, dest := range slices.Sorted(maps.Keys(overlays)) {
+		s.OverlayVolumes = append(s.OverlayVolumes, overlays[dest])
+	}
+	return nil
+}
+
+func volumeString(source, target string, opts []string) string {
+	v := target
+	if source != "" {
+		v = source + ":" + target
+	}
+	if len(opts) > 0 {
+		if source == "" {
+			// Options need a source, use an anonymous volume.
+			return v
+		}
+		v += ":" + strings.Join(opts, ",")
+	}
+	return v
+}
+
+func tmpfsMount(dest string, options []string) specs.Mount {
+	return specs.Mount{
+		Destination: path.Clean(dest),
+		Type:        define.TypeTmpfs,
+		Source:      define.TypeTmpfs,
+		Options:     options,
+	}
+}
+
+// healthConfig returns the healthcheck configuration of a service. A nil
+// configuration keeps the healthcheck of the image.
+func healthConfig(hc *Healthcheck) (*manifest.Schema2HealthConfig, error) {
+	if hc == nil {
+		return nil, nil
+	}
+	if hc.Disable || (len(hc.Test) > 0 && strings.ToUpper(hc.Test[0]) == define.HealthConfigTestNone) {
+		return &manifest.Schema2HealthConfig{Test: []string{define.HealthConfigTestNone}}, nil
+	}
+	if len(hc.Test) == 0 {
+		return nil, errors.New("healthcheck test must be set")
+	}
+	switch strings.ToUpper(hc.Test[0]) {
+	case define.HealthConfigTestCmd, define.HealthConfigTestCmdShell:
+	default:
+		return nil, fmt.Errorf("healthcheck test must start with CMD, CMD-SHELL or NONE, got %q", hc.Test[0])
+	}
+
+	config := &manifest.Schema2HealthConfig{
+		Test:     hc.Test,
+		Interval: defaultHealthInterval,
+		Timeout:  defaultHealthTimeout,
+		Retries:  defaultHealthRetries,
+	}
+	var err error
+	for _, d := range []struct {
+		name  string
+		value string
+		dest  *time.Duration
+	}{
+		{"interval", hc.Interval, &config.Interval},
+		{"timeout", hc.Timeout, &config.Timeout},
+		{"start_period", hc.StartPeriod, &config.StartPeriod},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if *d.dest, err = time.ParseDuration(d.value); err != nil {
+			return nil, fmt.Errorf("invalid healthcheck %s: %w", d.name, err)
+		}
+	}
+	if hc.Retries != nil {
+		config.Retries = int(*hc.Retries)
+	}
+	return config, nil
+}
+
+// fileObjectSecret returns the secret mounting a secret or config of the
+// project at target.
+func fileObjectSecret(ref FileObjectRef, name, target string) (specgen.Secret, error) {
+	if ref.Target != "" {
+		target = ref.Target
+	}
+	secret := specgen.Secret{Source: name, Target: target}
+	for _, id := range []struct {
+		value string
+		dest  *uint32
+	}{{ref.UID, &secret.UID}, {ref.GID, &secret.GID}} {
+		if id.value == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(id.value, 10, 32)
+		if err != nil {
+			return secret, fmt.Errorf("invalid uid or gid %q of %s", id.value, ref.Source)
+		}
+		*id.dest = uint32(n)
+	}
+	secret.Mode = 0o444
+	if ref.Mode != nil {
+		secret.Mode = *ref.Mode
+	}
+	return secret, nil
+}
@@ -0,0 +1,196 @@
+package compose
+
+import (
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v6/pkg/specgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/common/libnetwork/types"
+)
+
+func loadProject(t *testing.T, content string) *Project {
+	t.Helper()
+	dir := t.TempDir()
+	file := writeFile(t, dir, "compose.yaml", content)
+	p, err := Load(LoadOptions{Files: []string{file}, ProjectName: "test"})
+	require.NoError(t, err)
+	return p
+}
+
+func TestServiceSpec(t *testing.T) {
+	p := loadProject(t, `
+services:
+  web:
+    image: alpine
+    entrypoint: ["/bin/sh", "-c"]
+    command: echo hello
+    environment:
+      FOO: bar
+    labels:
+      app: web
+    hostname: web.local
+    user: "1000"
+    read_only: true
+    cap_add: [NET_ADMIN]
+    dns: [1.1.1.1]
+    extra_hosts:
+      db: 10.0.0.2
+    stop_signal: SIGINT
+    stop_grace_period: 1m30s
+    restart: on-failure:3
+    ports:
+      - "127.0.0.1:8080:80/tcp"
+    expose: ["9000"]
+    networks:
+      front:
+        aliases: [www]
+        ipv4_address: 10.89.0.10
+    volumes:
+      - data:/data:ro
+      - /tmp/html:/html
+      - type: tmpfs
+        target: /cache
+        tmpfs:
+          size: 64m
+    tmpfs: /run:size=1m
+    healthcheck:
+      test: ["CMD", "true"]
+      interval: 10s
+      retries: 5
+    secrets:
+      - source: token
+        target: api-token
+        uid: "1000"
+    configs:
+      - app_config
+networks:
+  front:
+volumes:
+  data:
+secrets:
+  token:
+    content: s3cr3t
+configs:
+  app_config:
+    content: "key: value"
+`)
+	svc := p.Services["web"]
+	s, err := p.ServiceSpec(svc, 1, p.PodName())
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-web-1", s.Name)
+	assert.Equal(t, "pod_test", s.Pod)
+	assert.Equal(t, "alpine", s.Image)
+	assert.Equal(t, []string{"/bin/sh", "-c"}, s.Entrypoint)
+	assert.Equal(t, []string{"echo", "hello"}, s.Command)
+	assert.Equal(t, map[string]string{"FOO": "bar"}, s.Env)
+	assert.Equal(t, "web", s.Labels["app"])
+	assert.Equal(t, "test", s.Labels[ProjectLabel])
+	assert.Equal(t, "web", s.Labels[ServiceLabel])
+	assert.Equal(t, "1", s.Labels[ContainerNumberLabel])
+	assert.NotEmpty(t, s.Labels[ConfigHashLabel])
+	assert.Equal(t, "web.local", s.Hostname)
+	assert.Equal(t, "1000", s.User)
+	assert.True(t, *s.ReadOnlyFilesystem)
+	assert.Equal(t, []string{"NET_ADMIN"}, s.CapAdd)
+	assert.Equal(t, []net.IP{net.ParseIP("1.1.1.1")}, s.DNSServers)
+	assert.Equal(t, []string{"db:10.0.0.2"}, s.HostAdd)
+	require.NotNil(t, s.StopTimeout)
+	assert.Equal(t, uint(90), *s.StopTimeout)
+	assert.Equal(t, "on-failure", s.RestartPolicy)
+	assert.Equal(t, uint(3), *s.RestartRetries)
+
+	require.Len(t, s.PortMappings, 1)
+	assert.Equal(t, types.PortMapping{HostIP: "127.0.0.1", HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 1}, s.PortMappings[0])
+	assert.Equal(t, map[uint16]string{9000: "tcp"}, s.Expose)
+
+	assert.Equal(t, specgen.Bridge, s.NetNS.NSMode)
+	require.Contains(t, s.Networks, "test_front")
+	assert.Equal(t, []string{"web", "www"}, s.Networks["test_front"].Aliases)
+	assert.Equal(t, []net.IP{net.ParseIP("10.89.0.10")}, s.Networks["test_front"].StaticIPs)
+
+	require.Len(t, s.Volumes, 1)
+	assert.Equal(t, "test_data", s.Volumes[0].Name)
+	assert.Equal(t, "/data", s.Volumes[0].Dest)
+	assert.Contains(t, s.Volumes[0].Options, "ro")
+	mounts := map[string][]string{}
+	for _, m := range s.Mounts {
+		mounts[m.Destination] = m.Options
+	}
+	assert.Contains(t, mounts, "/html")
+	assert.Equal(t, []string{"size=64m"}, mounts["/cache"])
+	assert.Equal(t, []string{"size=1m"}, mounts["/run"])
+
+	require.NotNil(t, s.HealthConfig)
+	assert.Equal(t, []string{"CMD", "true"}, s.HealthConfig.Test)
+	assert.Equal(t, 10*time.Second, s.HealthConfig.Interval)
+	assert.Equal(t, defaultHealthTimeout, s.HealthConfig.Timeout)
+	assert.Equal(t, 5, s.HealthConfig.Retries)
+
+	assert.Equal(t, []specgen.Secret{
+		{Source: "test_token", Target: "api-token", UID: 1000, Mode: 0o444},
+		{Source: "test_config_app_config", Target: "/app_config", Mode: 0o444},
+	}, s.Secrets)
+}
+
+func TestServiceSpecNetworkMode(t *testing.T) {
+	p := loadProject(t, `
+services:
+  app:
+    image: alpine
+  sidecar:
+    image: alpine
+    network_mode: service:app
+  host:
+    image: alpine
+    network_mode: host
+`)
+	s, err := p.ServiceSpec(p.Services["sidecar"], 1, "")
+	require.NoError(t, err)
+	assert.Equal(t, specgen.Namespace{NSMode: specgen.FromContainer, Value: "test-app-1"}, s.NetNS)
+	assert.Equal(t, ConditionServiceStarted, p.Services["sidecar"].DependsOn["app"].Condition)
+
+	s, err = p.ServiceSpec(p.Services["host"], 1, "")
+	require.NoError(t, err)
+	assert.Equal(t, specgen.Host, s.NetNS.NSMode)
+
+	order, err := p.ServiceOrder()
+	require.NoError(t, err)
+	assert.Less(t, slices.Index(order, "app"), slices.Index(order, "sidecar"))
+}
+
+func TestConfigHash(t *testing.T) {
+	p := loadProject(t, "services:\n  app:\n    image: alpine\n")
+	hash, err := p.Services["app"].ConfigHash()
+	require.NoError(t, err)
+
+	p2 := loadProject(t, "services:\n  app:\n    image: alpine\n")
+	hash2, err := p2.Services["app"].ConfigHash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, hash2)
+
+	p3 := loadProject(t, "services:\n  app:\n    image: alpine\n    command: sleep 1\n")
+	hash3, err := p3.Services["app"].ConfigHash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, hash3)
+}
+
+func TestHealthConfig(t *testing.T) {
+	hc, err := healthConfig(&Healthcheck{Disable: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"NONE"}, hc.Test)
+
+	_, err = healthConfig(&Healthcheck{Test: HealthcheckTest{"true"}})
+	assert.ErrorContains(t, err, "healthcheck test must start with CMD, CMD-SHELL or NONE")
+
+	_, err = healthConfig(&Healthcheck{Test: HealthcheckTest{"CMD", "true"}, Interval: "often"})
+	assert.ErrorContains(t, err, "invalid healthcheck interval")
+
+	hc, err = healthConfig(nil)
+	require.NoError(t, err)
+	assert.Nil(t, hc)
+}
@@ -0,0 +1,649 @@
+package compose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/specgen"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libnetwork/types"
+	"go.podman.io/common/pkg/config"
+)
+
+// Engine runs compose projects with the containers, pods, networks, volumes
+// and secrets of a Podman container engine.
+type Engine struct {
+	Containers entities.ContainerEngine
+	Images     entities.ImageEngine
+	// Out receives the progress messages, it defaults to os.Stderr.
+	Out io.Writer
+}
+
+// UpOptions are the options of Up.
+type UpOptions struct {
+	// Services restricts the services to start, their dependencies are
+	// started as well.
+	Services []string
+	// ForceRecreate recreates containers even if their configuration did
+	// not change.
+	ForceRecreate bool
+	// NoRecreate never recreates existing containers.
+	NoRecreate bool
+	// NoStart only creates the containers.
+	NoStart bool
+	// Pull overrides the pull policy of the services.
+	Pull string
+	// RemoveOrphans removes the containers of services which are not in
+	// the project anymore.
+	RemoveOrphans bool
+	// Timeout is the timeout to stop containers which are recreated.
+	Timeout *uint
+}
+
+// DownOptions are the options of Down.
+type DownOptions struct {
+	// Volumes removes the named volumes of the project and the anonymous
+	// volumes of its containers.
+	Volumes bool
+	// RemoveOrphans removes the containers of services which are not in
+	// the project anymore.
+	RemoveOrphans bool
+	// Timeout is the timeout to stop the containers.
+	Timeout *uint
+}
+
+func (e *Engine) progress(format string, args ...any) {
+	out := e.Out
+	if out == nil {
+		out = os.Stderr
+	}
+	fmt.Fprintf(out, format+"\n", args...)
+}
+
+// projectFilter returns the filters matching the resources of the project.
+func projectFilter(p *Project) map[string][]string {
+	return map[string][]string{"label": {ProjectLabel + "=" + p.Name}}
+}
+
+// ListContainers returns the containers of the project, including stopped ones.
+// If services is not empty only the containers of these services are
+// returned.
+func (e *Engine) ListContainers(ctx context.Context, p *Project, services []string) ([]entities.ListContainer, error) {
+	ctrs, err := e.Containers.ContainerList(ctx, entities.ContainerListOptions{All: true, Filters: projectFilter(p)})
+	if err != nil {
+		return nil, err
+	}
+	ctrs = slices.DeleteFunc(ctrs, func(c entities.ListContainer) bool {
+		return len(services) > 0 && !slices.Contains(services, c.Labels[ServiceLabel])
+	})
+	slices.SortFunc(ctrs, func(a, b entities.ListContainer) int {
+		return strings.Compare(a.Names[0], b.Names[0])
+	})
+	return ctrs, nil
+}
+
+// Up creates and starts the containers of the services and the networks,
+// volumes and secrets they use. It returns the names of the containers.
+func (e *Engine) Up(ctx context.Context, p *Project, opts UpOptions) ([]string, error) {
+	if opts.ForceRecreate && opts.NoRecreate {
+		return nil, errors.New("force-recreate and no-recreate cannot be combined")
+	}
+	order, err := p.ServiceOrder()
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Services) > 0 {
+		selected := make(map[string]bool)
+		var add func(name string) error
+		add = func(name string) error {
+			svc, ok := p.Services[name]
+			if !ok {
+				return fmt.Errorf("no such service: %s", name)
+			}
+			selected[name] = true
+			for dep := range svc.DependsOn {
+				if err := add(dep); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		for _, name := range opts.Services {
+			if err := add(name); err != nil {
+				return nil, err
+			}
+		}
+		order = slices.DeleteFunc(order, func(name string) bool { return !selected[name] })
+	}
+
+	if err := e.createResources(ctx, p, order); err != nil {
+		return nil, err
+	}
+
+	existing, err := e.ListContainers(ctx, p, nil)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]entities.ListContainer, len(existing))
+	for _, c := range existing {
+		byName[c.Names[0]] = c
+		service := c.Labels[ServiceLabel]
+		if _, ok := p.Services[service]; ok {
+			continue
+		}
+		if _, ok := p.DisabledServices[service]; ok {
+			continue
+		}
+		if !opts.RemoveOrphans {
+			logrus.Warnf("Found orphan container %s of service %q, remove it with --remove-orphans", c.Names[0], service)
+			continue
+		}
+		if err := e.removeContainer(ctx, c.Names[0], opts.Timeout, false); err != nil {
+			return nil, err
+		}
+	}
+
+	var names []string
+	pulled := make(map[string]bool)
+	for _, name := range order {
+		svc := p.Services[name]
+		if !opts.NoStart {
+			if err := e.waitForDependencies(ctx, p, svc); err != nil {
+				return nil, err
+			}
+		}
+		if !pulled[svc.Image] {
+			if err := e.pull(ctx, svc, opts.Pull); err != nil {
+				return nil, err
+			}
+			pulled[svc.Image] = true
+		}
+
+		hash, err := svc.ConfigHash()
+		if err != nil {
+			return nil, err
+		}
+		replicas := svc.Replicas()
+		for number := 1; number <= replicas; number++ {
+			ctrName := p.ContainerName(svc, number)
+			names = append(names, ctrName)
+			if err := e.upContainer(ctx, p, svc, number, byName, hash, opts); err != nil {
+				return nil, err
+			}
+		}
+		// Scale down
+		for _, c := range existing {
+			if c.Labels[ServiceLabel] != name {
+				continue
+			}
+			if number, err := strconv.Atoi(c.Labels[ContainerNumberLabel]); err == nil && number > replicas {
+				if err := e.removeContainer(ctx, c.Names[0], opts.Timeout, false); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+// upContainer creates and starts container number of the service unless it
+// exists with the same configuration.
+func (e *Engine) upContainer(ctx context.Context, p *Project, svc *Service, number int, existing map[string]entities.ListContainer, hash string, opts UpOptions) error {
+	name := p.ContainerName(svc, number)
+	c, exists := existing[name]
+	if exists && c.Labels[ProjectLabel] != p.Name {
+		return fmt.Errorf("container name %q is already in use by a container of another project", name)
+	}
+	recreate := exists && !opts.NoRecreate && (opts.ForceRecreate || c.Labels[ConfigHashLabel] != hash)
+	if recreate {
+		e.progress("Container %s  Recreate", name)
+		if err := e.removeContainer(ctx, name, opts.Timeout, false); err != nil {
+			return err
+		}
+	}
+	if !exists || recreate {
+		spec, err := p.ServiceSpec(svc, number, p.PodName())
+		if err != nil {
+			return fmt.Errorf("service %q: %w", svc.Name, err)
+		}
+		if _, err := e.Containers.ContainerCreate(ctx, spec); err != nil {
+			return fmt.Errorf("creating container %s: %w", name, err)
+		}
+		e.progress("Container %s  Created", name)
+	}
+	if opts.NoStart || (exists && !recreate && c.State == define.ContainerStateRunning.String()) {
+		return nil
+	}
+	reports, err := e.Containers.ContainerStart(ctx, []string{name}, entities.ContainerStartOptions{})
+	if err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if r.Err != nil {
+			return fmt.Errorf("starting container %s: %w", name, r.Err)
+		}
+	}
+	e.progress("Container %s  Started", name)
+	return nil
+}
+
+func (e *Engine) pull(ctx context.Context, svc *Service, override string) error {
+	policy := svc.PullPolicy
+	if override != "" {
+		policy = override
+	}
+	pullPolicy, err := config.ParsePullPolicy(strings.ReplaceAll(policy, "_", ""))
+	if err != nil {
+		return fmt.Errorf("service %q: %w", svc.Name, err)
+	}
+	if pullPolicy == config.PullPolicyNever {
+		return nil
+	}
+	exists, err := e.Images.Exists(ctx, svc.Image)
+	if err != nil {
+		return err
+	}
+	if exists.Value && pullPolicy == config.PullPolicyMissing {
+		return nil
+	}
+	out := e.Out
+	if out == nil {
+		out = os.Stderr
+	}
+	_, err = e.Images.Pull(ctx, svc.Image, entities.ImagePullOptions{PullPolicy: pullPolicy, Writer: out})
+	return err
+}
+
+// waitForDependencies waits until the conditions of the dependencies of the
+// service are met.
+func (e *Engine) waitForDependencies(ctx context.Context, p *Project, svc *Service) error {
+	for _, depName := range slices.Sorted(maps.Keys(svc.DependsOn)) {
+		dep, ok := p.Services[depName]
+		if !ok {
+			continue
+		}
+		condition := svc.DependsOn[depName].Condition
+		for number := 1; number <= dep.Replicas(); number++ {
+			name := p.ContainerName(dep, number)
+			switch condition {
+			case ConditionServiceHealthy:
+				e.progress("Container %s  Waiting", name)
+				if _, err := e.Containers.ContainerWait(ctx, []string{name}, entities.WaitOptions{
+					Conditions: []string{define.HealthCheckHealthy, define.HealthCheckUnhealthy, define.ContainerStateStopped.String(), define.ContainerStateExited.String()},
+				}); err != nil {
+					return err
+				}
+				inspect, errs, err := e.Containers.ContainerInspect(ctx, []string{name}, entities.InspectOptions{})
+				if err != nil {
+					return err
+				}
+				if len(errs) > 0 {
+					return errs[0]
+				}
+				if health := inspect[0].State.Health; health == nil || health.Status != define.HealthCheckHealthy {
+					return fmt.Errorf("dependency failed to start: container %s is not healthy", name)
+				}
+				e.progress("Container %s  Healthy", name)
+			case ConditionServiceCompletedSuccessfully:
+				e.progress("Container %s  Waiting", name)
+				reports, err := e.Containers.ContainerWait(ctx, []string{name}, entities.WaitOptions{})
+				if err != nil {
+					return err
+				}
+				for _, r := range reports {
+					if r.Error != nil {
+						return r.Error
+					}
+					if r.ExitCode != 0 {
+						return fmt.Errorf("service %q didn't complete successfully: exit %d", depName, r.ExitCode)
+					}
+				}
+				e.progress("Container %s  Exited", name)
+			}
+		}
+	}
+	return nil
+}
+
+// createResources creates the pod of the project and the networks, volumes,
+// secrets and configs used by the services if they do not exist.
+func (e *Engine) createResources(ctx context.Context, p *Project, services []string) error {
+	networks := make(map[string]bool)
+	volumes := make(map[string]bool)
+	secrets := make(map[string]bool)
+	configs := make(map[string]bool)
+	for _, name := range services {
+		svc := p.Services[name]
+		for n := range svc.Networks {
+			networks[n] = true
+		}
+		for _, v := range svc.Volumes {
+			if v.Type == VolumeTypeVolume && v.Source != "" {
+				volumes[v.Source] = true
+			}
+		}
+		for _, s := range svc.Secrets {
+			secrets[s.Source] = true
+		}
+		for _, c := range svc.Configs {
+			configs[c.Source] = true
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(networks)) {
+		if err := e.createNetwork(ctx, p, name); err != nil {
+			return err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(volumes)) {
+		if err := e.createVolume(ctx, p, name); err != nil {
+			return err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(secrets)) {
+		if err := e.createSecret(ctx, p, p.Secrets[name], p.SecretName(name), SecretLabel, name); err != nil {
+			return fmt.Errorf("secret %q: %w", name, err)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(configs)) {
+		if err := e.createSecret(ctx, p, p.Configs[name], p.ConfigName(name), ConfigLabel, name); err != nil {
+			return fmt.Errorf("config %q: %w", name, err)
+		}
+	}
+
+	exists, err := e.Containers.PodExists(ctx, p.PodName())
+	if err != nil {
+		return err
+	}
+	if !exists.Value {
+		spec := specgen.NewPodSpecGenerator()
+		spec.Name = p.PodName()
+		spec.NoInfra = true
+		spec.Labels = p.Labels()
+		if _, err := e.Containers.PodCreate(ctx, entities.PodSpec{PodSpecGen: *spec}); err != nil {
+			return fmt.Errorf("creating pod %s: %w", spec.Name, err)
+		}
+		e.progress("Pod %s  Created", spec.Name)
+	}
+	return nil
+}
+
+func (e *Engine) createNetwork(ctx context.Context, p *Project, key string) error {
+	cfg := p.Networks[key]
+	name := p.NetworkName(key)
+	exists, err := e.Containers.NetworkExists(ctx, name)
+	if err != nil {
+		return err
+	}
+	if exists.Value {
+		return nil
+	}
+	if bool(cfg.External) {
+		return fmt.Errorf("external network %q not found", name)
+	}
+
+	network := types.Network{
+		Name:        name,
+		Driver:      cfg.Driver,
+		Options:     cfg.DriverOpts,
+		Internal:    cfg.Internal,
+		IPv6Enabled: cfg.EnableIPv6,
+		DNSEnabled:  true,
+		Labels:      p.Labels(),
+	}
+	if network.Driver == "" {
+		network.Driver = types.DefaultNetworkDriver
+	}
+	maps.Copy(network.Labels, cfg.Labels)
+	network.Labels[NetworkLabel] = key
+	if cfg.Ipam != nil {
+		if cfg.Ipam.Driver != "" && cfg.Ipam.Driver != "default" {
+			network.IPAMOptions = map[string]string{types.Driver: cfg.Ipam.Driver}
+		}
+		for _, c := range cfg.Ipam.Config {
+			if c.Subnet == "" {
+				continue
+			}
+			subnet, err := types.ParseCIDR(c.Subnet)
+			if err != nil {
+				return fmt.Errorf("network %q: %w", key, err)
+			}
+			s := types.Subnet{Subnet: subnet}
+			if c.Gateway != "" {
+				if err := s.Gateway.UnmarshalText([]byte(c.Gateway)); err != nil {
+					return fmt.Errorf("network %q: invalid gateway %q", key, c.Gateway)
+				}
+			}
+			network.Subnets = append(network.Subnets, s)
+		}
+	}
+	if _, err := e.Containers.NetworkCreate(ctx, network, &types.NetworkCreateOptions{IgnoreIfExists: true}); err != nil {
+		return fmt.Errorf("creating network %s: %w", name, err)
+	}
+	e.progress("Network %s  Created", name)
+	return nil
+}
+
+func (e *Engine) createVolume(ctx context.Context, p *Project, key string) error {
+	cfg := p.Volumes[key]
+	name := p.VolumeName(key)
+	exists, err := e.Containers.VolumeExists(ctx, name)
+	if err != nil {
+		return err
+	}
+	if exists.Value {
+		return nil
+	}
+	if bool(cfg.External) {
+		return fmt.Errorf("external volume %q not found", name)
+	}
+	labels := p.Labels()
+	maps.Copy(labels, cfg.Labels)
+	labels[VolumeLabel] = key
+	if _, err := e.Containers.VolumeCreate(ctx, entities.VolumeCreateOptions{
+		Name:           name,
+		Driver:         cfg.Driver,
+		Options:        cfg.DriverOpts,
+		Labels:         labels,
+		IgnoreIfExists: true,
+	}); err != nil {
+		return fmt.Errorf("creating volume %s: %w", name, err)
+	}
+	e.progress("Volume %s  Created", name)
+	return nil
+}
+
+// createSecret creates or replaces the Podman secret of a secret or config
+// of the project. External secrets must exist.
+func (e *Engine) createSecret(ctx context.Context, p *Project, cfg *FileObject, name, label, key string) error {
+	if bool(cfg.External) {
+		exists, err := e.Containers.SecretExists(ctx, name)
+		if err != nil {
+			return err
+		}
+		if !exists.Value {
+			return fmt.Errorf("external secret %q not found", name)
+		}
+		return nil
+	}
+
+	var data io.Reader
+	switch {
+	case cfg.File != "":
+		f, err := os.Open(cfg.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		data = f
+	case cfg.Environment != "":
+		data = strings.NewReader(cfg.envValue)
+	default:
+		data = strings.NewReader(cfg.Content)
+	}
+	labels := p.Labels()
+	maps.Copy(labels, cfg.Labels)
+	labels[label] = key
+	if _, err := e.Containers.SecretCreate(ctx, name, data, entities.SecretCreateOptions{Labels: labels, Replace: true}); err != nil {
+		return fmt.Errorf("creating secret %s: %w", name, err)
+	}
+	return nil
+}
+
+func (e *Engine) removeContainer(ctx context.Context, name string, timeout *uint, volumes bool) error {
+	reports, err := e.Containers.ContainerRm(ctx, []string{name}, entities.RmOptions{Force: true, Ignore: true, Timeout: timeout, Volumes: volumes})
+	if err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if r.Err != nil {
+			return fmt.Errorf("removing container %s: %w", name, r.Err)
+		}
+	}
+	e.progress("Container %s  Removed", name)
+	return nil
+}
+
+// Stop stops the containers of the project.
+func (e *Engine) Stop(ctx context.Context, p *Project, timeout *uint) error {
+	ctrs, err := e.ListContainers(ctx, p, nil)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, c := range ctrs {
+		if c.State != define.ContainerStateRunning.String() {
+			continue
+		}
+		reports, err := e.Containers.ContainerStop(ctx, []string{c.ID}, entities.StopOptions{Ignore: true, Timeout: timeout})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, r := range reports {
+			if r.Err != nil {
+				errs = append(errs, r.Err)
+			}
+		}
+		e.progress("Container %s  Stopped", c.Names[0])
+	}
+	return errors.Join(errs...)
+}
+
+// Down removes the containers, pod, networks and secrets of the project,
+// and with Volumes its volumes.
+func (e *Engine) Down(ctx context.Context, p *Project, opts DownOptions) error {
+	ctrs, err := e.ListContainers(ctx, p, nil)
+	if err != nil {
+		return err
+	}
+	for _, c := range ctrs {
+		service := c.Labels[ServiceLabel]
+		_, enabled := p.Services[service]
+		_, disabled := p.DisabledServices[service]
+		if !enabled && !disabled && !opts.RemoveOrphans {
+			logrus.Warnf("Found orphan container %s of service %q, remove it with --remove-orphans", c.Names[0], service)
+			continue
+		}
+		if err := e.removeContainer(ctx, c.Names[0], opts.Timeout, opts.Volumes); err != nil {
+			return err
+		}
+	}
+
+	pods, err := e.Containers.PodRm(ctx, []string{p.PodName()}, entities.PodRmOptions{Ignore: true})
+	if err != nil {
+		return err
+	}
+	for _, r := range pods {
+		if r.Err != nil {
+			return fmt.Errorf("removing pod %s: %w", p.PodName(), r.Err)
+		}
+		if r.Id != "" {
+			e.progress("Pod %s  Removed", p.PodName())
+		}
+	}
+
+	networks, err := e.Containers.NetworkList(ctx, entities.NetworkListOptions{Filters: projectFilter(p)})
+	if err != nil {
+		return err
+	}
+	for _, n := range networks {
+		reports, err := e.Containers.NetworkRm(ctx, []string{n.Name}, entities.NetworkRmOptions{})
+		if err != nil {
+			return err
+		}
+		for _, r := range reports {
+			if r.Err != nil {
+				return fmt.Errorf("removing network %s: %w", n.Name, r.Err)
+			}
+		}
+		e.progress("Network %s  Removed", n.Name)
+	}
+
+	secrets, err := e.Containers.SecretList(ctx, entities.SecretListRequest{})
+	if err != nil {
+		return err
+	}
+	for _, s := range secrets {
+		if s.Spec.Labels[ProjectLabel] != p.Name {
+			continue
+		}
+		reports, err := e.Containers.SecretRm(ctx, []string{s.ID}, entities.SecretRmOptions{Ignore: true})
+		if err != nil {
+			return err
+		}
+		for _, r := range reports {
+			if r.Err != nil {
+				return fmt.Errorf("removing secret %s: %w", s.Spec.Name, r.Err)
+			}
+		}
+	}
+
+	if !opts.Volumes {
+		return nil
+	}
+	volumes, err := e.Containers.VolumeList(ctx, entities.VolumeListOptions{Filter: projectFilter(p)})
+	if err != nil {
+		return err
+	}
+	for _, v := range volumes {
+		reports, err := e.Containers.VolumeRm(ctx, []string{v.Name}, entities.VolumeRmOptions{})
+		if err != nil {
+			return err
+		}
+		for _, r := range reports {
+			if r.Err != nil {
+				return fmt.Errorf("removing volume %s: %w", v.Name, r.Err)
+			}
+		}
+		e.progress("Volume %s  Removed", v.Name)
+	}
+	return nil
+}
+
+// Logs writes the logs of the containers of the services, or all services
+// if services is empty.
+func (e *Engine) Logs(ctx context.Context, p *Project, services []string, opts entities.ContainerLogsOptions) error {
+	ctrs, err := e.ListContainers(ctx, p, services)
+	if err != nil {
+		return err
+	}
+	if len(ctrs) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(ctrs))
+	for _, c := range ctrs {
+		names = append(names, c.Names[0])
+	}
+	opts.Names = true
+	return e.Containers.ContainerLogs(ctx, names, opts)
+}
@@ -0,0 +1,162 @@
+package compose
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// interpolate substitutes the variables in s with their values in env.
+// Supported are $VAR, ${VAR} and the ${VAR:-default}, ${VAR-default},
+// ${VAR:?error}, ${VAR?error}, ${VAR:+replacement} and ${VAR+replacement}
+// forms, where the default, error and replacement may contain variables
+// again. $$ is a literal $.
+func interpolate(s string, env map[string]string) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i == len(s)-1 {
+			sb.WriteByte(s[i])
+			continue
+		}
+		switch next := s[i+1]; {
+		case next == '$':
+			sb.WriteByte('$')
+			i++
+		case next == '{':
+			end := matchingBrace(s, i+1)
+			if end < 0 {
+				return "", fmt.Errorf("invalid interpolation format for %q: missing closing brace", s)
+			}
+			value, err := expand(s[i+2:end], env)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(value)
+			i = end
+		case isNameStart(next):
+			j := i + 1
+			for j < len(s) && isNameChar(s[j]) {
+				j++
+			}
+			sb.WriteString(env[s[i+1:j]])
+			i = j - 1
+		default:
+			sb.WriteByte('$')
+		}
+	}
+	return sb.String(), nil
+}
+
+// matchingBrace returns the index of the brace closing the one at open.
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// expand returns the value of the expression between ${ and }.
+func expand(expr string, env map[string]string) (string, error) {
+	i := 0
+	for i < len(expr) && isNameChar(expr[i]) {
+		i++
+	}
+	name, op := expr[:i], expr[i:]
+	if name == "" || !isNameStart(name[0]) {
+		return "", fmt.Errorf("invalid interpolation format for ${%s}: invalid variable name", expr)
+	}
+	value, set := env[name]
+	if op == "" {
+		return value, nil
+	}
+
+	unsetOrEmpty := !set || value == ""
+	var arg string
+	switch {
+	case strings.HasPrefix(op, ":-"), strings.HasPrefix(op, ":?"), strings.HasPrefix(op, ":+"):
+		arg = op[2:]
+		op = op[:2]
+	case strings.HasPrefix(op, "-"), strings.HasPrefix(op, "?"), strings.HasPrefix(op, "+"):
+		arg = op[1:]
+		op = op[:1]
+	default:
+		return "", fmt.Errorf("invalid interpolation format for ${%s}", expr)
+	}
+	missing := !set
+	if op[0] == ':' {
+		missing = unsetOrEmpty
+	}
+
+	switch op[len(op)-1] {
+	case '-':
+		if missing {
+			return interpolate(arg, env)
+		}
+		return value, nil
+	case '?':
+		if missing {
+			msg, err := interpolate(arg, env)
+			if err != nil {
+				return "", err
+			}
+			return "", fmt.Errorf("required variable %s is missing a value: %s", name, msg)
+		}
+		return value, nil
+	default: // '+'
+		if missing {
+			return "", nil
+		}
+		return interpolate(arg, env)
+	}
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
+
+// interpolateNode interpolates all scalars of a YAML document. Plain
+// scalars are resolved again after interpolation so that e.g. a variable can
+// set a number.
+func interpolateNode(node *yaml.Node, env map[string]string) error {
+	if node.Kind == yaml.ScalarNode {
+		if !strings.Contains(node.Value, "$") {
+			return nil
+		}
+		value, err := interpolate(node.Value, env)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		node.Value = value
+		if node.Style == 0 {
+			node.Tag = ""
+		}
+		return nil
+	}
+	for i, child := range node.Content {
+		// Keys of mappings are not interpolated.
+		if node.Kind == yaml.MappingNode && i%2 == 0 {
+			continue
+		}
+		if err := interpolateNode(child, env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package compose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolate(t *testing.T) {
+	env := map[string]string{
+		"FOO":   "foo",
+		"EMPTY": "",
+		"TAG":   "1.2",
+	}
+	tests := []struct {
+		in      string
+		out     string
+		wantErr string
+	}{
+		{in: "plain", out: "plain"},
+		{in: "$FOO", out: "foo"},
+		{in: "${FOO}bar", out: "foobar"},
+		{in: "$FOO-bar", out: "foo-bar"},
+		{in: "$$FOO", out: "$FOO"},
+		{in: "cost $5", out: "cost $5"},
+		{in: "trailing $", out: "trailing $"},
+		{in: "$UNSET", out: ""},
+		{in: "${UNSET:-default}", out: "default"},
+		{in: "${EMPTY:-default}", out: "default"},
+		{in: "${EMPTY-default}", out: ""},
+		{in: "${UNSET-default}", out: "default"},
+		{in: "${UNSET:-${FOO}}", out: "foo"},
+		{in: "alpine:${TAG:-latest}", out: "alpine:1.2"},
+		{in: "${FOO:+set}", out: "set"},
+		{in: "${EMPTY:+set}", out: ""},
+		{in: "${EMPTY+set}", out: "set"},
+		{in: "${FOO:?must be set}", out: "foo"},
+		{in: "${EMPTY?must be set}", out: ""},
+		{in: "${EMPTY:?must be set}", wantErr: "required variable EMPTY is missing a value: must be set"},
+		{in: "${UNSET?must be set}", wantErr: "required variable UNSET is missing a value: must be set"},
+		{in: "${FOO", wantErr: "missing closing brace"},
+		{in: "${1FOO}", wantErr: "invalid variable name"},
+		{in: "${FOO/bar}", wantErr: "invalid interpolation format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			out, err := interpolate(tt.in, env)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.out, out)
+		})
+	}
+}
@@ -0,0 +1,631 @@
+package compose
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/containers/podman/v6/pkg/env"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFileNames are the names of the compose files looked up in the
+// working directory if no file is given, in order of preference.
+var DefaultFileNames = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// DefaultOverrideFileNames are the names of the override files loaded after
+// the default compose file, in order of preference.
+var DefaultOverrideFileNames = []string{"compose.override.yaml", "compose.override.yml", "docker-compose.override.yaml", "docker-compose.override.yml"}
+
+// LoadOptions are the options to load a project.
+type LoadOptions struct {
+	// Files are the compose files, merged in order. If empty the files in
+	// COMPOSE_FILE or the default files in the working directory are used.
+	Files []string
+	// ProjectName overrides the name of the project.
+	ProjectName string
+	// ProjectDir is the directory relative paths are resolved against. It
+	// defaults to the directory of the first compose file.
+	ProjectDir string
+	// EnvFiles are the files with the variables used for interpolation.
+	// They default to the .env file in the project directory.
+	EnvFiles []string
+	// Profiles are the active profiles. They default to the profiles in
+	// COMPOSE_PROFILES.
+	Profiles []string
+	// Services are services which are enabled regardless of their
+	// profiles.
+	Services []string
+	// Environ is the environment, in the format of os.Environ.
+	Environ []string
+}
+
+// serviceKeys are the supported keys of a service.
+var serviceKeys = map[string]bool{
+	"annotations": true, "build": true, "cap_add": true, "cap_drop": true, "command": true,
+	"configs": true, "container_name": true, "depends_on": true, "deploy": true, "devices": true,
+	"dns": true, "dns_opt": true, "dns_search": true, "entrypoint": true, "env_file": true,
+	"environment": true, "expose": true, "extra_hosts": true, "healthcheck": true, "hostname": true,
+	"image": true, "init": true, "labels": true, "network_mode": true, "networks": true,
+	"ports": true, "privileged": true, "profiles": true, "pull_policy": true, "read_only": true,
+	"restart": true, "scale": true, "secrets": true, "stdin_open": true, "stop_grace_period": true,
+	"stop_signal": true, "sysctls": true, "tmpfs": true, "tty": true, "user": true,
+	"volumes": true, "working_dir": true,
+}
+
+// appendKeys are the keys of sequences which are appended rather than
+// replaced when compose files are merged.
+var appendKeys = map[string]bool{
+	"cap_add": true, "cap_drop": true, "configs": true, "devices": true, "dns": true,
+	"dns_search": true, "env_file": true, "expose": true, "extra_hosts": true, "ports": true,
+	"secrets": true, "tmpfs": true, "volumes": true,
+}
+
+// mappingKeys are the keys which accept a list of KEY=VALUE strings or a
+// list of names as well as a mapping. They are merged as mappings.
+var mappingKeys = map[string]bool{
+	"annotations": true, "depends_on": true, "environment": true, "labels": true,
+	"networks": true, "sysctls": true,
+}
+
+var invalidProjectNameChars = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// Load loads the project defined by the compose files.
+func Load(opts LoadOptions) (*Project, error) {
+	environ := make(map[string]string, len(opts.Environ))
+	for _, e := range opts.Environ {
+		if k, v, ok := strings.Cut(e, "="); ok {
+			environ[k] = v
+		}
+	}
+
+	files, err := composeFiles(opts, environ)
+	if err != nil {
+		return nil, err
+	}
+
+	projectDir := opts.ProjectDir
+	if projectDir == "" {
+		projectDir = filepath.Dir(files[0])
+	}
+	if projectDir, err = filepath.Abs(projectDir); err != nil {
+		return nil, err
+	}
+
+	vars, err := projectEnv(projectDir, opts.EnvFiles, environ)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[string]any{}
+	for _, file := range files {
+		content, err := loadFile(file, vars)
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", file, err)
+		}
+		merged = merge(merged, content, "").(map[string]any)
+	}
+	for _, section := range []string{"services", "networks", "volumes", "secrets", "configs"} {
+		if v, ok := merged[section]; ok && v != nil {
+			if _, ok := v.(map[string]any); !ok {
+				return nil, fmt.Errorf("%s must be a mapping", section)
+			}
+		}
+	}
+	if services, ok := merged["services"].(map[string]any); ok {
+		for name, svc := range services {
+			svcMap, ok := svc.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("service %q must be a mapping", name)
+			}
+			for key := range svcMap {
+				if !serviceKeys[key] && !strings.HasPrefix(key, "x-") {
+					logrus.Warnf("Service %q: %s is not supported and ignored", name, key)
+				}
+			}
+		}
+	}
+
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	project := &Project{}
+	if err := yaml.Unmarshal(data, project); err != nil {
+		return nil, err
+	}
+
+	name := opts.ProjectName
+	if name == "" {
+		name = vars["COMPOSE_PROJECT_NAME"]
+	}
+	if name == "" {
+		name = project.Name
+	}
+	if name == "" {
+		name = filepath.Base(projectDir)
+	}
+	project.Name = NormalizeProjectName(name)
+	if project.Name == "" {
+		return nil, fmt.Errorf("invalid project name %q: it must contain at least one lowercase letter or digit", name)
+	}
+	project.WorkingDir = projectDir
+	project.ComposeFiles = files
+
+	profiles := opts.Profiles
+	if len(profiles) == 0 && vars["COMPOSE_PROFILES"] != "" {
+		profiles = strings.Split(vars["COMPOSE_PROFILES"], ",")
+	}
+	if err := project.normalize(vars, profiles, opts.Services); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// NormalizeProjectName returns the name in the format of project names,
+// lowercase letters, digits, dashes and underscores starting with a letter
+// or digit.
+func NormalizeProjectName(name string) string {
+	name = invalidProjectNameChars.ReplaceAllString(strings.ToLower(name), "")
+	return strings.TrimLeft(name, "_-")
+}
+
+// composeFiles returns the absolute paths of the compose files to load.
+func composeFiles(opts LoadOptions, environ map[string]string) ([]string, error) {
+	files := slices.Clone(opts.Files)
+	if len(files) == 0 && environ["COMPOSE_FILE"] != "" {
+		files = filepath.SplitList(environ["COMPOSE_FILE"])
+	}
+	if len(files) == 0 {
+		dir := opts.ProjectDir
+		if dir == "" {
+			dir = "."
+		}
+		for _, candidates := range [][]string{DefaultFileNames, DefaultOverrideFileNames} {
+			for _, name := range candidates {
+				path := filepath.Join(dir, name)
+				if _, err := os.Stat(path); err == nil {
+					files = append(files, path)
+					break
+				}
+			}
+			if len(files) == 0 {
+				return nil, fmt.Errorf("no compose file found in %s, expected one of %s", dir, strings.Join(DefaultFileNames, ", "))
+			}
+		}
+	}
+	for i, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return nil, err
+		}
+		files[i] = abs
+	}
+	return files, nil
+}
+
+// projectEnv returns the variables for interpolation, the variables in the
+// env files overridden by the environment.
+func projectEnv(projectDir string, envFiles []string, environ map[string]string) (map[string]string, error) {
+	if len(envFiles) == 0 {
+		path := filepath.Join(projectDir, ".env")
+		if _, err := os.Stat(path); err == nil {
+			envFiles = []string{path}
+		}
+	}
+	vars := make(map[string]string)
+	for _, file := range envFiles {
+		fileVars, err := parseEnvFile(file)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(vars, fileVars)
+	}
+	maps.Copy(vars, environ)
+	return vars, nil
+}
+
+// parseEnvFile parses an env file, removing the quotes around values.
+func parseEnvFile(path string) (map[string]string, error) {
+	vars, err := env.ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range vars {
+		vars[strings.TrimPrefix(k, "export ")] = unquote(v)
+		if strings.HasPrefix(k, "export ") {
+			delete(vars, k)
+		}
+	}
+	return vars, nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// loadFile reads and interpolates a compose file.
+func loadFile(path string, vars map[string]string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return map[string]any{}, nil
+		}
+		return nil, err
+	}
+	if err := interpolateNode(&doc, vars); err != nil {
+		return nil, err
+	}
+	content := map[string]any{}
+	if err := doc.Decode(&content); err != nil {
+		return nil, err
+	}
+	// Top-level extension fields are only used for YAML anchors.
+	for key := range content {
+		if strings.HasPrefix(key, "x-") {
+			delete(content, key)
+		}
+	}
+	return content, nil
+}
+
+// merge merges override into base. Mappings are merged recursively, the
+// sequences of appendKeys are appended and everything else is replaced.
+func merge(base, override any, key string) any {
+	if mappingKeys[key] {
+		base, override = toMapping(base), toMapping(override)
+	}
+	switch o := override.(type) {
+	case map[string]any:
+		b, ok := base.(map[string]any)
+		if !ok {
+			return o
+		}
+		for k, v := range o {
+			b[k] = merge(b[k], v, k)
+		}
+		return b
+	case []any:
+		if b, ok := base.([]any); ok && appendKeys[key] {
+			return append(b, o...)
+		}
+	}
+	return override
+}
+
+// toMapping converts a list of KEY=VALUE strings or names to a mapping.
+func toMapping(v any) any {
+	list, ok := v.([]any)
+	if !ok {
+		return v
+	}
+	m := make(map[string]any, len(list))
+	for _, item := range list {
+		s := fmt.Sprint(item)
+		if k, v, ok := strings.Cut(s, "="); ok {
+			m[k] = v
+		} else {
+			m[s] = nil
+		}
+	}
+	return m
+}
+
+// normalize resolves the services of the project, validates their
+// references and disables the services which are not enabled by the
+// profiles.
+func (p *Project) normalize(vars map[string]string, profiles, enabled []string) error {
+	if len(p.Services) == 0 {
+		return errors.New("no services defined")
+	}
+	if p.Networks == nil {
+		p.Networks = map[string]*Network{}
+	}
+	// Resources may be declared without any configuration.
+	for k, v := range p.Networks {
+		if v == nil {
+			p.Networks[k] = &Network{}
+		}
+	}
+	for k, v := range p.Volumes {
+		if v == nil {
+			p.Volumes[k] = &Volume{}
+		}
+	}
+	for _, objects := range []map[string]*FileObject{p.Secrets, p.Configs} {
+		for k, v := range objects {
+			if v == nil {
+				objects[k] = &FileObject{}
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(p.Services)) {
+		if err := p.normalizeService(name, vars); err != nil {
+			return fmt.Errorf("service %q: %w", name, err)
+		}
+	}
+	for name, secret := range p.Secrets {
+		if err := p.normalizeFileObject(secret, vars); err != nil {
+			return fmt.Errorf("secret %q: %w", name, err)
+		}
+	}
+	for name, config := range p.Configs {
+		if err := p.normalizeFileObject(config, vars); err != nil {
+			return fmt.Errorf("config %q: %w", name, err)
+		}
+	}
+
+	if err := p.applyProfiles(profiles, enabled); err != nil {
+		return err
+	}
+	_, err := p.ServiceOrder()
+	return err
+}
+
+func (p *Project) normalizeService(name string, vars map[string]string) error {
+	s := p.Services[name]
+	if s == nil {
+		s = &Service{}
+		p.Services[name] = s
+	}
+	s.Name = name
+	if s.Image == "" {
+		if s.Build != nil {
+			return errors.New("building images is not supported, build the image first and set image")
+		}
+		return errors.New("image must be set")
+	}
+	if s.ContainerName != "" && s.Replicas() > 1 {
+		return errors.New("container_name cannot be set for a service with more than one replica")
+	}
+
+	environment := make(Environment)
+	for _, file := range s.EnvFile {
+		fileVars, err := parseEnvFile(p.path(file))
+		if err != nil {
+			return err
+		}
+		for k, v := range fileVars {
+			environment[k] = &v
+		}
+	}
+	for k, v := range s.Environment {
+		if v == nil {
+			value, ok := vars[k]
+			if !ok {
+				delete(environment, k)
+				continue
+			}
+			v = &value
+		}
+		environment[k] = v
+	}
+	s.Environment = environment
+	s.EnvFile = nil
+
+	for i, v := range s.Volumes {
+		switch v.Type {
+		case VolumeTypeBind:
+			s.Volumes[i].Source = p.path(v.Source)
+		case VolumeTypeVolume:
+			if _, ok := p.Volumes[v.Source]; v.Source != "" && !ok {
+				return fmt.Errorf("undefined volume %q", v.Source)
+			}
+		case VolumeTypeTmpfs:
+		default:
+			return fmt.Errorf("unsupported volume type %q", v.Type)
+		}
+		if v.Target == "" {
+			return errors.New("volume target must be set")
+		}
+	}
+
+	switch {
+	case s.NetworkMode != "" && len(s.Networks) > 0:
+		return errors.New("network_mode and networks cannot be combined")
+	case s.NetworkMode == "":
+		if len(s.Networks) == 0 {
+			s.Networks = ServiceNetworks{"default": nil}
+		}
+		for network := range s.Networks {
+			if _, ok := p.Networks[network]; !ok {
+				if network != "default" {
+					return fmt.Errorf("undefined network %q", network)
+				}
+				p.Networks[network] = &Network{}
+			}
+		}
+	default:
+		if service, ok := strings.CutPrefix(s.NetworkMode, "service:"); ok {
+			if _, ok := p.Services[service]; !ok {
+				return fmt.Errorf("network_mode refers to undefined service %q", service)
+			}
+			if s.DependsOn == nil {
+				s.DependsOn = DependsOn{}
+			}
+			if _, ok := s.DependsOn[service]; !ok {
+				s.DependsOn[service] = ServiceDependency{Condition: ConditionServiceStarted}
+			}
+		}
+	}
+
+	for _, ref := range s.Secrets {
+		if _, ok := p.Secrets[ref.Source]; !ok {
+			return fmt.Errorf("undefined secret %q", ref.Source)
+		}
+	}
+	for _, ref := range s.Configs {
+		if _, ok := p.Configs[ref.Source]; !ok {
+			return fmt.Errorf("undefined config %q", ref.Source)
+		}
+	}
+	for dep, d := range s.DependsOn {
+		if _, ok := p.Services[dep]; !ok {
+			if d.Required != nil && !*d.Required {
+				delete(s.DependsOn, dep)
+				continue
+			}
+			return fmt.Errorf("depends on undefined service %q", dep)
+		}
+		switch d.Condition {
+		case ConditionServiceStarted, ConditionServiceHealthy, ConditionServiceCompletedSuccessfully:
+		default:
+			return fmt.Errorf("invalid condition %q for dependency %q", d.Condition, dep)
+		}
+	}
+	return nil
+}
+
+func (p *Project) normalizeFileObject(o *FileObject, vars map[string]string) error {
+	sources := 0
+	for _, set := range []bool{o.File != "", o.Environment != "", o.Content != "", bool(o.External)} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return errors.New("exactly one of file, environment, content and external must be set")
+	}
+	if o.File != "" {
+		o.File = p.path(o.File)
+	}
+	if o.Environment != "" {
+		value, ok := vars[o.Environment]
+		if !ok {
+			return fmt.Errorf("environment variable %s is not set", o.Environment)
+		}
+		o.envValue = value
+	}
+	return nil
+}
+
+// path returns the absolute path of a path relative to the project
+// directory.
+func (p *Project) path(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = home + rest
+		}
+	}
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(p.WorkingDir, path)
+}
+
+// applyProfiles moves the services not enabled by the profiles to
+// DisabledServices. Services without profiles, with an active profile, the
+// given services and their dependencies are enabled.
+func (p *Project) applyProfiles(profiles, services []string) error {
+	enabled := make(map[string]bool)
+	var enable func(name string) error
+	enable = func(name string) error {
+		if enabled[name] {
+			return nil
+		}
+		s, ok := p.Services[name]
+		if !ok {
+			return fmt.Errorf("no such service: %s", name)
+		}
+		enabled[name] = true
+		for dep := range s.DependsOn {
+			if err := enable(dep); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, name := range services {
+		if err := enable(name); err != nil {
+			return err
+		}
+	}
+	for name, s := range p.Services {
+		if len(s.Profiles) == 0 || slices.Contains(profiles, "*") || slices.ContainsFunc(s.Profiles, func(profile string) bool {
+			return slices.Contains(profiles, profile)
+		}) {
+			if err := enable(name); err != nil {
+				return err
+			}
+		}
+	}
+
+	p.DisabledServices = make(map[string]*Service)
+	for name, s := range p.Services {
+		if !enabled[name] {
+			p.DisabledServices[name] = s
+			delete(p.Services, name)
+		}
+	}
+	return nil
+}
+
+// ServiceOrder returns the names of the enabled services, ordered so that
+// every service comes after its dependencies.
+func (p *Project) ServiceOrder() ([]string, error) {
+	order := make([]string, 0, len(p.Services))
+	state := make(map[string]int) // 1 visiting, 2 done
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case 1:
+			return fmt.Errorf("dependency cycle detected: %s", strings.Join(append(path, name), " -> "))
+		case 2:
+			return nil
+		}
+		state[name] = 1
+		for _, dep := range slices.Sorted(maps.Keys(p.Services[name].DependsOn)) {
+			if _, ok := p.Services[dep]; !ok {
+				continue
+			}
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = 2
+		order = append(order, name)
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(p.Services)) {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// ServiceNames returns the sorted names of the enabled services.
+func (p *Project) ServiceNames() []string {
+	return slices.Sorted(maps.Keys(p.Services))
+}
+
+// YAML returns the resolved project in the compose file format.
+func (p *Project) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(p); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,237 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, ".env", "TAG=3.20\nexport DB_PASSWORD=\"secret\"\n")
+	writeFile(t, dir, "web.env", "MODE=production\n")
+	writeFile(t, dir, "compose.yaml", `
+name: My_App
+x-common: &common
+  restart: always
+services:
+  web:
+    <<: *common
+    image: alpine:${TAG}
+    command: sleep infinity
+    env_file: web.env
+    environment:
+      - DEBUG
+      - PORT=8080
+    ports:
+      - "8080:80"
+      - target: 443
+        published: 8443
+        protocol: tcp
+    volumes:
+      - data:/data
+      - ./html:/html:ro
+      - type: tmpfs
+        target: /tmp
+    depends_on:
+      db:
+        condition: service_healthy
+    secrets:
+      - db_password
+    deploy:
+      replicas: ${REPLICAS:-2}
+  db:
+    image: alpine
+    healthcheck:
+      test: pg_isready
+      interval: 5s
+volumes:
+  data:
+secrets:
+  db_password:
+    environment: DB_PASSWORD
+`)
+	writeFile(t, dir, "compose.override.yaml", `
+services:
+  web:
+    environment:
+      DEBUG: "1"
+    ports:
+      - "9090:90"
+`)
+
+	p, err := Load(LoadOptions{ProjectDir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, "my_app", p.Name)
+	assert.Equal(t, dir, p.WorkingDir)
+	assert.Equal(t, []string{filepath.Join(dir, "compose.yaml"), filepath.Join(dir, "compose.override.yaml")}, p.ComposeFiles)
+
+	web := p.Services["web"]
+	require.NotNil(t, web)
+	assert.Equal(t, "alpine:3.20", web.Image)
+	assert.Equal(t, "always", web.Restart)
+	assert.Equal(t, ShellCommand{"sleep", "infinity"}, web.Command)
+	assert.Equal(t, 2, web.Replicas())
+	env := map[string]string{}
+	for k, v := range web.Environment {
+		env[k] = *v
+	}
+	assert.Equal(t, map[string]string{"DEBUG": "1", "PORT": "8080", "MODE": "production"}, env)
+	assert.Equal(t, Ports{"8080:80", "8443:443/tcp", "9090:90"}, web.Ports)
+	assert.Equal(t, []ServiceVolume{
+		{Type: VolumeTypeVolume, Source: "data", Target: "/data"},
+		{Type: VolumeTypeBind, Source: filepath.Join(dir, "html"), Target: "/html", ReadOnly: true},
+		{Type: VolumeTypeTmpfs, Target: "/tmp"},
+	}, web.Volumes)
+	assert.Equal(t, ConditionServiceHealthy, web.DependsOn["db"].Condition)
+	assert.Contains(t, web.Networks, "default")
+	assert.Contains(t, p.Networks, "default")
+	assert.Equal(t, "secret", p.Secrets["db_password"].envValue)
+
+	db := p.Services["db"]
+	require.NotNil(t, db)
+	assert.Equal(t, HealthcheckTest{"CMD-SHELL", "pg_isready"}, db.Healthcheck.Test)
+
+	order, err := p.ServiceOrder()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db", "web"}, order)
+}
+
+func TestLoadProfiles(t *testing.T) {
+	dir := t.TempDir()
+	file := writeFile(t, dir, "compose.yaml", `
+services:
+  app:
+    image: alpine
+  debug:
+    image: alpine
+    profiles: [debug]
+    depends_on: [helper]
+  helper:
+    image: alpine
+    profiles: [tools]
+`)
+	tests := []struct {
+		name     string
+		profiles []string
+		services []string
+		enabled  []string
+	}{
+		{name: "default", enabled: []string{"app"}},
+		{name: "profile", profiles: []string{"debug"}, enabled: []string{"app", "debug", "helper"}},
+		{name: "all", profiles: []string{"*"}, enabled: []string{"app", "debug", "helper"}},
+		{name: "service", services: []string{"helper"}, enabled: []string{"app", "helper"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Load(LoadOptions{Files: []string{file}, Profiles: tt.profiles, Services: tt.services})
+			require.NoError(t, err)
+			assert.Equal(t, tt.enabled, p.ServiceNames())
+		})
+	}
+}
+
+func TestLoadProjectName(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Web.App")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	file := writeFile(t, dir, "compose.yaml", "services:\n  app:\n    image: alpine\n")
+
+	p, err := Load(LoadOptions{Files: []string{file}})
+	require.NoError(t, err)
+	assert.Equal(t, "webapp", p.Name)
+
+	p, err = Load(LoadOptions{Files: []string{file}, Environ: []string{"COMPOSE_PROJECT_NAME=fromenv"}})
+	require.NoError(t, err)
+	assert.Equal(t, "fromenv", p.Name)
+
+	p, err = Load(LoadOptions{Files: []string{file}, ProjectName: "option", Environ: []string{"COMPOSE_PROJECT_NAME=fromenv"}})
+	require.NoError(t, err)
+	assert.Equal(t, "option", p.Name)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		environ []string
+		wantErr string
+	}{
+		{
+			name:    "no services",
+			content: "networks:\n  net:\n",
+			wantErr: "no services defined",
+		},
+		{
+			name:    "build",
+			content: "services:\n  app:\n    build: .\n",
+			wantErr: "building images is not supported",
+		},
+		{
+			name:    "cycle",
+			content: "services:\n  a:\n    image: alpine\n    depends_on: [b]\n  b:\n    image: alpine\n    depends_on: [a]\n",
+			wantErr: "dependency cycle detected: a -> b -> a",
+		},
+		{
+			name:    "undefined volume",
+			content: "services:\n  app:\n    image: alpine\n    volumes: [data:/data]\n",
+			wantErr: `undefined volume "data"`,
+		},
+		{
+			name:    "undefined network",
+			content: "services:\n  app:\n    image: alpine\n    networks: [front]\n",
+			wantErr: `undefined network "front"`,
+		},
+		{
+			name:    "undefined dependency",
+			content: "services:\n  app:\n    image: alpine\n    depends_on: [db]\n",
+			wantErr: `depends on undefined service "db"`,
+		},
+		{
+			name:    "invalid condition",
+			content: "services:\n  app:\n    image: alpine\n    depends_on:\n      db:\n        condition: service_ready\n  db:\n    image: alpine\n",
+			wantErr: `invalid condition "service_ready"`,
+		},
+		{
+			name:    "required variable",
+			content: "services:\n  app:\n    image: ${IMAGE:?set the image}\n",
+			wantErr: "required variable IMAGE is missing a value: set the image",
+		},
+		{
+			name:    "network mode and networks",
+			content: "services:\n  app:\n    image: alpine\n    network_mode: host\n    networks: [default]\n",
+			wantErr: "network_mode and networks cannot be combined",
+		},
+		{
+			name:    "container name with replicas",
+			content: "services:\n  app:\n    image: alpine\n    container_name: app\n    scale: 2\n",
+			wantErr: "container_name cannot be set for a service with more than one replica",
+		},
+		{
+			name:    "secret sources",
+			content: "services:\n  app:\n    image: alpine\nsecrets:\n  s:\n    file: ./s\n    content: x\n",
+			wantErr: "exactly one of file, environment, content and external must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := writeFile(t, t.TempDir(), "compose.yaml", tt.content)
+			_, err := Load(LoadOptions{Files: []string{file}, Environ: tt.environ})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoadNoFile(t *testing.T) {
+	_, err := Load(LoadOptions{ProjectDir: t.TempDir()})
+	assert.ErrorContains(t, err, "no compose file found")
+}
@@ -0,0 +1,539 @@
+package compose
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mattn/go-shellwords"
+	"gopkg.in/yaml.v3"
+)
+
+// Project is a compose project, the resolved content of one or more compose
+// files.
+type Project struct {
+	// Name of the project, used to name and label the resources created
+	// for it.
+	Name string `yaml:"name"`
+	// WorkingDir is the project directory relative paths are resolved
+	// against.
+	WorkingDir string `yaml:"-"`
+	// ComposeFiles are the absolute paths of the files the project was
+	// loaded from.
+	ComposeFiles []string               `yaml:"-"`
+	Services     map[string]*Service    `yaml:"services"`
+	Networks     map[string]*Network    `yaml:"networks,omitempty"`
+	Volumes      map[string]*Volume     `yaml:"volumes,omitempty"`
+	Secrets      map[string]*FileObject `yaml:"secrets,omitempty"`
+	Configs      map[string]*FileObject `yaml:"configs,omitempty"`
+	// DisabledServices are the services which are not enabled by the
+	// active profiles.
+	DisabledServices map[string]*Service `yaml:"-"`
+}
+
+// Service is a service of a compose project.
+type Service struct {
+	Name            string          `yaml:"-"`
+	Profiles        []string        `yaml:"profiles,omitempty"`
+	Image           string          `yaml:"image,omitempty"`
+	Build           any             `yaml:"build,omitempty"`
+	PullPolicy      string          `yaml:"pull_policy,omitempty"`
+	ContainerName   string          `yaml:"container_name,omitempty"`
+	Hostname        string          `yaml:"hostname,omitempty"`
+	Command         ShellCommand    `yaml:"command,omitempty"`
+	Entrypoint      ShellCommand    `yaml:"entrypoint,omitempty"`
+	Environment     Environment     `yaml:"environment,omitempty"`
+	EnvFile         StringList      `yaml:"env_file,omitempty"`
+	Labels          Labels          `yaml:"labels,omitempty"`
+	Annotations     Labels          `yaml:"annotations,omitempty"`
+	Ports           Ports           `yaml:"ports,omitempty"`
+	Expose          []string        `yaml:"expose,omitempty"`
+	Volumes         []ServiceVolume `yaml:"volumes,omitempty"`
+	Tmpfs           StringList      `yaml:"tmpfs,omitempty"`
+	NetworkMode     string          `yaml:"network_mode,omitempty"`
+	Networks        ServiceNetworks `yaml:"networks,omitempty"`
+	DependsOn       DependsOn       `yaml:"depends_on,omitempty"`
+	Healthcheck     *Healthcheck    `yaml:"healthcheck,omitempty"`
+	Restart         string          `yaml:"restart,omitempty"`
+	User            string          `yaml:"user,omitempty"`
+	WorkingDir      string          `yaml:"working_dir,omitempty"`
+	Secrets         []FileObjectRef `yaml:"secrets,omitempty"`
+	Configs         []FileObjectRef `yaml:"configs,omitempty"`
+	CapAdd          []string        `yaml:"cap_add,omitempty"`
+	CapDrop         []string        `yaml:"cap_drop,omitempty"`
+	Privileged      bool            `yaml:"privileged,omitempty"`
+	ReadOnly        bool            `yaml:"read_only,omitempty"`
+	Tty             bool            `yaml:"tty,omitempty"`
+	StdinOpen       bool            `yaml:"stdin_open,omitempty"`
+	Init            *bool           `yaml:"init,omitempty"`
+	ExtraHosts      ExtraHosts      `yaml:"extra_hosts,omitempty"`
+	DNS             StringList      `yaml:"dns,omitempty"`
+	DNSSearch       StringList      `yaml:"dns_search,omitempty"`
+	DNSOpt          []string        `yaml:"dns_opt,omitempty"`
+	Devices         []string        `yaml:"devices,omitempty"`
+	Sysctls         Labels          `yaml:"sysctls,omitempty"`
+	StopSignal      string          `yaml:"stop_signal,omitempty"`
+	StopGracePeriod string          `yaml:"stop_grace_period,omitempty"`
+	Scale           *int            `yaml:"scale,omitempty"`
+	Deploy          *Deploy         `yaml:"deploy,omitempty"`
+	// Extensions holds the x- extension fields and the fields which are
+	// not supported.
+	Extensions map[string]any `yaml:",inline"`
+}
+
+// Deploy is the deployment configuration of a service. Only the number of
+// replicas is supported.
+type Deploy struct {
+	Replicas *int `yaml:"replicas,omitempty"`
+}
+
+// Replicas returns the number of containers to run for the service.
+func (s *Service) Replicas() int {
+	switch {
+	case s.Deploy != nil && s.Deploy.Replicas != nil:
+		return *s.Deploy.Replicas
+	case s.Scale != nil:
+		return *s.Scale
+	}
+	return 1
+}
+
+// Healthcheck is the healthcheck of a service.
+type Healthcheck struct {
+	Test        HealthcheckTest `yaml:"test,omitempty"`
+	Interval    string          `yaml:"interval,omitempty"`
+	Timeout     string          `yaml:"timeout,omitempty"`
+	StartPeriod string          `yaml:"start_period,omitempty"`
+	Retries     *uint           `yaml:"retries,omitempty"`
+	Disable     bool            `yaml:"disable,omitempty"`
+}
+
+// Conditions of a service dependency.
+const (
+	ConditionServiceStarted               = "service_started"
+	ConditionServiceHealthy               = "service_healthy"
+	ConditionServiceCompletedSuccessfully = "service_completed_successfully"
+)
+
+// ServiceDependency is a dependency of a service on another service.
+type ServiceDependency struct {
+	Condition string `yaml:"condition,omitempty"`
+	Required  *bool  `yaml:"required,omitempty"`
+}
+
+// DependsOn are the dependencies of a service, by service name. It accepts
+// the list and the mapping syntax.
+type DependsOn map[string]ServiceDependency
+
+func (d *DependsOn) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		var names []string
+		if err := node.Decode(&names); err != nil {
+			return err
+		}
+		*d = make(DependsOn, len(names))
+		for _, name := range names {
+			(*d)[name] = ServiceDependency{Condition: ConditionServiceStarted}
+		}
+		return nil
+	}
+	deps := map[string]ServiceDependency{}
+	if err := node.Decode(&deps); err != nil {
+		return err
+	}
+	for name, dep := range deps {
+		if dep.Condition == "" {
+			dep.Condition = ConditionServiceStarted
+			deps[name] = dep
+		}
+	}
+	*d = deps
+	return nil
+}
+
+// ServiceNetwork is the configuration of a service in a network.
+type ServiceNetwork struct {
+	Aliases     []string `yaml:"aliases,omitempty"`
+	IPv4Address string   `yaml:"ipv4_address,omitempty"`
+	IPv6Address string   `yaml:"ipv6_address,omitempty"`
+	MacAddress  string   `yaml:"mac_address,omitempty"`
+}
+
+// ServiceNetworks are the networks a service is connected to. It accepts the
+// list and the mapping syntax.
+type ServiceNetworks map[string]*ServiceNetwork
+
+func (n *ServiceNetworks) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		var names []string
+		if err := node.Decode(&names); err != nil {
+			return err
+		}
+		*n = make(ServiceNetworks, len(names))
+		for _, name := range names {
+			(*n)[name] = nil
+		}
+		return nil
+	}
+	networks := map[string]*ServiceNetwork{}
+	if err := node.Decode(&networks); err != nil {
+		return err
+	}
+	*n = networks
+	return nil
+}
+
+// Volume types of a service volume.
+const (
+	VolumeTypeVolume = "volume"
+	VolumeTypeBind   = "bind"
+	VolumeTypeTmpfs  = "tmpfs"
+)
+
+// ServiceVolume is a volume mounted into the containers of a service. It
+// accepts the short syntax SOURCE:TARGET:MODE and the long syntax.
+type ServiceVolume struct {
+	Type     string `yaml:"type"`
+	Source   string `yaml:"source,omitempty"`
+	Target   string `yaml:"target"`
+	ReadOnly bool   `yaml:"read_only,omitempty"`
+	// Options are the mount options of the short syntax besides ro and
+	// rw, e.g. z or Z.
+	Options []string `yaml:"options,omitempty"`
+	Bind    *struct {
+		SELinux string `yaml:"selinux,omitempty"`
+	} `yaml:"bind,omitempty"`
+	Volume *struct {
+		NoCopy  bool   `yaml:"nocopy,omitempty"`
+		Subpath string `yaml:"subpath,omitempty"`
+	} `yaml:"volume,omitempty"`
+	Tmpfs *struct {
+		Size string `yaml:"size,omitempty"`
+		Mode uint32 `yaml:"mode,omitempty"`
+	} `yaml:"tmpfs,omitempty"`
+}
+
+func (v *ServiceVolume) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		type plain ServiceVolume
+		return node.Decode((*plain)(v))
+	}
+	var short string
+	if err := node.Decode(&short); err != nil {
+		return err
+	}
+	vol, err := parseShortVolume(short)
+	if err != nil {
+		return err
+	}
+	*v = vol
+	return nil
+}
+
+// parseShortVolume parses the short syntax of a service volume.
+func parseShortVolume(short string) (ServiceVolume, error) {
+	parts := strings.Split(short, ":")
+	// A Windows drive letter is not a separator.
+	if len(parts) > 1 && len(parts[0]) == 1 && strings.HasPrefix(parts[1], `\`) {
+		parts = append([]string{parts[0] + ":" + parts[1]}, parts[2:]...)
+	}
+	v := ServiceVolume{Type: VolumeTypeVolume}
+	switch len(parts) {
+	case 1:
+		v.Target = parts[0]
+		return v, nil
+	case 2, 3:
+		v.Source, v.Target = parts[0], parts[1]
+	default:
+		return v, fmt.Errorf("invalid volume %q", short)
+	}
+	if v.Source == "" || v.Target == "" {
+		return v, fmt.Errorf("invalid volume %q", short)
+	}
+	if strings.HasPrefix(v.Source, ".") || strings.HasPrefix(v.Source, "/") || strings.HasPrefix(v.Source, "~") || strings.Contains(v.Source, `\`) {
+		v.Type = VolumeTypeBind
+	}
+	if len(parts) == 3 {
+		for opt := range strings.SplitSeq(parts[2], ",") {
+			switch opt {
+			case "ro":
+				v.ReadOnly = true
+			case "rw":
+			default:
+				v.Options = append(v.Options, opt)
+			}
+		}
+	}
+	return v, nil
+}
+
+// FileObject is a secret or config of a project.
+type FileObject struct {
+	Name        string   `yaml:"name,omitempty"`
+	File        string   `yaml:"file,omitempty"`
+	Environment string   `yaml:"environment,omitempty"`
+	Content     string   `yaml:"content,omitempty"`
+	External    External `yaml:"external,omitempty"`
+	Labels      Labels   `yaml:"labels,omitempty"`
+
+	// envValue is the value of Environment, resolved when loading the
+	// project so that the .env file is taken into account.
+	envValue string
+}
+
+// FileObjectRef is the reference of a service to a secret or config. It
+// accepts the short syntax, the name of the secret or config, and the long
+// syntax.
+type FileObjectRef struct {
+	Source string  `yaml:"source"`
+	Target string  `yaml:"target,omitempty"`
+	UID    string  `yaml:"uid,omitempty"`
+	GID    string  `yaml:"gid,omitempty"`
+	Mode   *uint32 `yaml:"mode,omitempty"`
+}
+
+func (r *FileObjectRef) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&r.Source)
+	}
+	type plain FileObjectRef
+	return node.Decode((*plain)(r))
+}
+
+// Network is a network of a project.
+type Network struct {
+	Name       string            `yaml:"name,omitempty"`
+	Driver     string            `yaml:"driver,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	External   External          `yaml:"external,omitempty"`
+	Internal   bool              `yaml:"internal,omitempty"`
+	EnableIPv6 bool              `yaml:"enable_ipv6,omitempty"`
+	Ipam       *struct {
+		Driver string `yaml:"driver,omitempty"`
+		Config []struct {
+			Subnet  string `yaml:"subnet,omitempty"`
+			Gateway string `yaml:"gateway,omitempty"`
+			IPRange string `yaml:"ip_range,omitempty"`
+		} `yaml:"config,omitempty"`
+	} `yaml:"ipam,omitempty"`
+	Labels Labels `yaml:"labels,omitempty"`
+}
+
+// Volume is a named volume of a project.
+type Volume struct {
+	Name       string            `yaml:"name,omitempty"`
+	Driver     string            `yaml:"driver,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	External   External          `yaml:"external,omitempty"`
+	Labels     Labels            `yaml:"labels,omitempty"`
+}
+
+// External marks a resource as created outside of the project. It accepts a
+// boolean and the deprecated mapping with the name of the resource.
+type External bool
+
+func (e *External) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		*e = true
+		return nil
+	}
+	var b bool
+	if err := node.Decode(&b); err != nil {
+		return err
+	}
+	*e = External(b)
+	return nil
+}
+
+// StringList is a list of strings which may be given as a single string.
+type StringList []string
+
+func (l *StringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var s string
+		if err := node.Decode(&s); err != nil {
+			return err
+		}
+		*l = StringList{s}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// ShellCommand is a command given as list or as string which is split like
+// a shell does.
+type ShellCommand []string
+
+func (c *ShellCommand) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var s string
+		if err := node.Decode(&s); err != nil {
+			return err
+		}
+		args, err := shellwords.Parse(s)
+		if err != nil {
+			return fmt.Errorf("parsing command %q: %w", s, err)
+		}
+		*c = args
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*c = list
+	return nil
+}
+
+// Labels is a mapping which may be given as list of KEY=VALUE strings.
+type Labels map[string]string
+
+func (l *Labels) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		var list []string
+		if err := node.Decode(&list); err != nil {
+			return err
+		}
+		*l = make(Labels, len(list))
+		for _, item := range list {
+			key, value, _ := strings.Cut(item, "=")
+			(*l)[key] = value
+		}
+		return nil
+	}
+	m := map[string]string{}
+	if err := node.Decode(&m); err != nil {
+		return err
+	}
+	*l = m
+	return nil
+}
+
+// ExtraHosts are host to IP mappings in the HOST:IP format. They may be
+// given as list of HOST:IP or HOST=IP strings or as mapping.
+type ExtraHosts []string
+
+func (h *ExtraHosts) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		m := map[string]string{}
+		if err := node.Decode(&m); err != nil {
+			return err
+		}
+		for _, host := range slices.Sorted(maps.Keys(m)) {
+			*h = append(*h, host+":"+m[host])
+		}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	for _, item := range list {
+		if host, ip, ok := strings.Cut(item, "="); ok {
+			item = host + ":" + ip
+		}
+		*h = append(*h, item)
+	}
+	return nil
+}
+
+// Environment are the environment variables of a service. A variable without
+// value is taken from the environment of the project. It accepts a list of
+// KEY=VALUE strings and a mapping.
+type Environment map[string]*string
+
+func (e *Environment) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		var list []string
+		if err := node.Decode(&list); err != nil {
+			return err
+		}
+		*e = make(Environment, len(list))
+		for _, item := range list {
+			key, value, hasValue := strings.Cut(item, "=")
+			if hasValue {
+				(*e)[key] = &value
+			} else {
+				(*e)[key] = nil
+			}
+		}
+		return nil
+	}
+	m := map[string]*string{}
+	if err := node.Decode(&m); err != nil {
+		return err
+	}
+	*e = m
+	return nil
+}
+
+// Ports are the published ports of a service in the format of --publish. The
+// long syntax is converted to it.
+type Ports []string
+
+func (p *Ports) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: ports must be a list", node.Line)
+	}
+	for _, item := range node.Content {
+		if item.Kind != yaml.MappingNode {
+			var short string
+			if err := item.Decode(&short); err != nil {
+				return err
+			}
+			*p = append(*p, short)
+			continue
+		}
+		var long struct {
+			Target    string `yaml:"target"`
+			Published string `yaml:"published"`
+			HostIP    string `yaml:"host_ip"`
+			Protocol  string `yaml:"protocol"`
+		}
+		if err := item.Decode(&long); err != nil {
+			return err
+		}
+		if long.Target == "" {
+			return fmt.Errorf("line %d: port target must be set", item.Line)
+		}
+		port := long.Target
+		if long.Published != "" {
+			port = long.Published + ":" + port
+			if long.HostIP != "" {
+				port = long.HostIP + ":" + port
+			}
+		}
+		if long.Protocol != "" {
+			port += "/" + long.Protocol
+		}
+		*p = append(*p, port)
+	}
+	return nil
+}
+
+// HealthcheckTest is the test of a healthcheck, starting with CMD, CMD-SHELL
+// or NONE. A string is run with the shell of the container.
+type HealthcheckTest []string
+
+func (t *HealthcheckTest) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var s string
+		if err := node.Decode(&s); err != nil {
+			return err
+		}
+		*t = HealthcheckTest{"CMD-SHELL", s}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
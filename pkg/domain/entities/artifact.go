@@ -104,4 +104,13 @@ type ArtifactRemoveOptions struct {
 
 type ArtifactRemoveReport = entitiesTypes.ArtifactRemoveReport
 
+type ArtifactRefreshOptions struct {
+	// Refresh the artifact volumes of all running containers
+	All bool
+	// Containers is a list of container IDs or names to refresh
+	Containers []string
+}
+
+type ArtifactRefreshReport = entitiesTypes.ArtifactRefreshReport
+
 type ArtifactInspectReport = entitiesTypes.ArtifactInspectReport
@@ -183,6 +183,8 @@ type CopyOptions struct {
 	Chown bool
 	// Map to translate path names.
 	Rename map[string]string
+	// Paths, relative to the destination, to remove before copying.
+	Remove []string
 	// NoOverwriteDirNonDir when true prevents an existing directory or file from being overwritten
 	// by the other type
 	NoOverwriteDirNonDir bool
@@ -247,6 +249,7 @@ type AttachOptions struct {
 	DetachKeys string
 	Latest     bool
 	NoStdin    bool
+	Record     bool
 	SigProxy   bool
 	Stdin      *os.File
 	Stdout     *os.File
@@ -340,6 +343,9 @@ type ContainerListOptions struct {
 	Sort      string
 	Sync      bool
 	Watch     uint
+	// Where is an expression evaluated over the inspect data of each
+	// container, only matching containers are listed.
+	Where string
 }
 
 // ContainerRunOptions describes the options needed
@@ -366,6 +372,23 @@ type ContainerRunReport struct {
 	Id       string
 }
 
+// ContainerDebugOptions describes the options to run a debug container
+// for a running container.
+type ContainerDebugOptions struct {
+	DetachKeys   string
+	ErrorStream  *os.File
+	InputStream  *os.File
+	OutputStream *os.File
+	// RootfsMount is the path the root file system of the target
+	// container is mounted at in the debug container. It is not mounted
+	// if empty.
+	RootfsMount string
+	SigProxy    bool
+	// Spec of the debug container. Its PID, network, IPC, UTS and user
+	// namespaces are set to the ones of the target container.
+	Spec *specgen.SpecGenerator
+}
+
 // ContainerCleanupOptions are the CLI values for the
 // cleanup command
 type ContainerCleanupOptions struct {
@@ -479,6 +502,23 @@ type ContainerStatsOptions struct {
 
 type ContainerStatsReport = types.ContainerStatsReport
 
+// ContainerTopStatsOptions describes input options for getting the resource
+// usage of the processes of a container.
+type ContainerTopStatsOptions struct {
+	// Operate on the latest known container.  Only supported for local
+	// clients.
+	Latest bool
+	// Stream stats.
+	Stream bool
+	// Interval in seconds
+	Interval int
+	// Sort the processes by pid, cpu, rss, io or fds.  All keys but pid
+	// sort in descending order.
+	Sort string
+}
+
+type ContainerTopStatsReport = types.ContainerTopStatsReport
+
 // ContainerRenameOptions describes input options for renaming a container.
 type ContainerRenameOptions struct {
 	// NewName is the new name that will be given to the container.
@@ -45,6 +45,7 @@ type PodmanConfig struct {
 	MaxWorks                 int      // maximum number of parallel threads
 	MemoryProfile            string   // Hidden: Should memory profile be taken
 	RegistriesConf           string   // allows for specifying a custom registries.conf
+	RecordSessions           bool     // record all exec and attach sessions attached to STDIN
 	Remote                   bool     // Connection to Podman API Service will use RESTful API
 	RuntimePath              string   // --runtime flag will set Engine.RuntimePath
 	RuntimeFlags             []string // global flags for the container runtime
@@ -17,6 +17,7 @@ type ContainerCopyFunc = types.ContainerCopyFunc
 type ContainerEngine interface { //nolint:interfacebloat
 	AutoUpdate(ctx context.Context, options AutoUpdateOptions) ([]*AutoUpdateReport, []error)
 	Config(ctx context.Context) (*config.Config, error)
+	ContainerApplyDiff(ctx context.Context, nameOrID string, reader io.Reader) error
 	ContainerAttach(ctx context.Context, nameOrID string, options AttachOptions) error
 	ContainerCheckpoint(ctx context.Context, namesOrIds []string, options CheckpointOptions) ([]*CheckpointReport, error)
 	ContainerCleanup(ctx context.Context, namesOrIds []string, options ContainerCleanupOptions) ([]*ContainerCleanupReport, error)
@@ -25,6 +26,7 @@ type ContainerEngine interface { //nolint:interfacebloat
 	ContainerCopyFromArchive(ctx context.Context, nameOrID, path string, reader io.Reader, options CopyOptions) (ContainerCopyFunc, error)
 	ContainerCopyToArchive(ctx context.Context, nameOrID string, path string, writer io.Writer) (ContainerCopyFunc, error)
 	ContainerCreate(ctx context.Context, s *specgen.SpecGenerator) (*ContainerCreateReport, error)
+	ContainerDebug(ctx context.Context, nameOrID string, options ContainerDebugOptions) (*ContainerRunReport, error)
 	ContainerExec(ctx context.Context, nameOrID string, options ExecOptions, streams define.AttachStreams) (int, error)
 	ContainerExecNoSession(ctx context.Context, nameOrID string, options ExecOptions, streams define.AttachStreams) (int, error)
 	ContainerExecDetached(ctx context.Context, nameOrID string, options ExecOptions) (string, error)
@@ -51,19 +53,26 @@ type ContainerEngine interface { //nolint:interfacebloat
 	ContainerStats(ctx context.Context, namesOrIds []string, options ContainerStatsOptions) (chan ContainerStatsReport, error)
 	ContainerStop(ctx context.Context, namesOrIds []string, options StopOptions) ([]*StopReport, error)
 	ContainerTop(ctx context.Context, options TopOptions) (*StringSliceReport, error)
+	ContainerTopStats(ctx context.Context, nameOrID string, options ContainerTopStatsOptions) (chan ContainerTopStatsReport, error)
+	ContainerTrace(ctx context.Context, nameOrID string, options ContainerTraceOptions) (*ContainerTraceReport, error)
 	ContainerUnmount(ctx context.Context, nameOrIDs []string, options ContainerUnmountOptions) ([]*ContainerUnmountReport, error)
 	ContainerUnpause(ctx context.Context, namesOrIds []string, options PauseUnPauseOptions) ([]*PauseUnpauseReport, error)
 	ContainerUpdate(ctx context.Context, options *ContainerUpdateOptions) (string, error)
 	ContainerWait(ctx context.Context, namesOrIds []string, options WaitOptions) ([]WaitReport, error)
 	Diff(ctx context.Context, namesOrIds []string, options DiffOptions) (*DiffReport, error)
+	DiffExport(ctx context.Context, namesOrIds []string, writer io.Writer, options DiffOptions) error
 	Events(ctx context.Context, opts EventsOptions) error
 	GenerateSpec(ctx context.Context, opts *GenerateSpecOptions) (*GenerateSpecReport, error)
 	GenerateSystemd(ctx context.Context, nameOrID string, opts GenerateSystemdOptions) (*GenerateSystemdReport, error)
 	GenerateKube(ctx context.Context, nameOrIDs []string, opts GenerateKubeOptions) (*GenerateKubeReport, error)
+	Graph(ctx context.Context, options GraphOptions) (*GraphReport, error)
+	SystemBackup(ctx context.Context, options SystemBackupOptions) (*SystemBackupReport, error)
 	SystemPrune(ctx context.Context, options SystemPruneOptions) (*SystemPruneReport, error)
+	SystemRestore(ctx context.Context, options SystemRestoreOptions) (*SystemRestoreReport, error)
 	HealthCheckRun(ctx context.Context, nameOrID string, options HealthCheckOptions) (*define.HealthCheckResults, error)
 	Info(ctx context.Context) (*define.Info, error)
 	KubeApply(ctx context.Context, body io.Reader, opts ApplyOptions) error
+	KubeRollback(ctx context.Context, deployment string, opts KubeRollbackOptions) (*KubeRollbackReport, error)
 	Locks(ctx context.Context) (*LocksReport, error)
 	Migrate(ctx context.Context, options SystemMigrateOptions) error
 	NetworkConnect(ctx context.Context, networkname string, options NetworkConnectOptions) error
@@ -73,6 +82,10 @@ type ContainerEngine interface { //nolint:interfacebloat
 	NetworkExists(ctx context.Context, networkname string) (*BoolReport, error)
 	NetworkInspect(ctx context.Context, namesOrIds []string, options InspectOptions) ([]NetworkInspectReport, []error, error)
 	NetworkList(ctx context.Context, options NetworkListOptions) ([]netTypes.Network, error)
+	NetworkPolicyCreate(ctx context.Context, policy NetworkPolicy, options NetworkPolicyCreateOptions) (*NetworkPolicy, error)
+	NetworkPolicyInspect(ctx context.Context, names []string) ([]*NetworkPolicy, []error, error)
+	NetworkPolicyList(ctx context.Context) ([]*NetworkPolicy, error)
+	NetworkPolicyRm(ctx context.Context, names []string, options NetworkPolicyRmOptions) ([]*NetworkPolicyRmReport, error)
 	NetworkPrune(ctx context.Context, options NetworkPruneOptions) ([]*NetworkPruneReport, error)
 	NetworkReload(ctx context.Context, names []string, options NetworkReloadOptions) ([]*NetworkReloadReport, error)
 	NetworkRm(ctx context.Context, namesOrIds []string, options NetworkRmOptions) ([]*NetworkRmReport, error)
@@ -106,6 +119,12 @@ type ContainerEngine interface { //nolint:interfacebloat
 	SecretList(ctx context.Context, opts SecretListRequest) ([]*SecretInfoReport, error)
 	SecretRm(ctx context.Context, nameOrID []string, opts SecretRmOptions) ([]*SecretRmReport, error)
 	SecretExists(ctx context.Context, nameOrID string) (*BoolReport, error)
+	SecretUpdate(ctx context.Context, nameOrID string, reader io.Reader, options SecretUpdateOptions) (*SecretUpdateReport, error)
+	SecretHistory(ctx context.Context, nameOrID string) ([]*SecretHistoryReport, error)
+	SecretRollback(ctx context.Context, nameOrID string, options SecretRollbackOptions) (*SecretUpdateReport, error)
+	SessionList(ctx context.Context, options SessionListOptions) ([]*SessionListReport, error)
+	SessionReplay(ctx context.Context, idOrPath string, options SessionReplayOptions) error
+	SessionRm(ctx context.Context, ids []string, options SessionRmOptions) ([]*SessionRmReport, error)
 	Shutdown(ctx context.Context)
 	SystemDf(ctx context.Context, options SystemDfOptions) (*SystemDfReport, error)
 	SystemCheck(ctx context.Context, options SystemCheckOptions) (*SystemCheckReport, error)
@@ -10,6 +10,7 @@ import (
 )
 
 type ImageEngine interface { //nolint:interfacebloat
+	Analyze(ctx context.Context, nameOrID string, opts ImageAnalyzeOptions) (*ImageAnalyzeReport, error)
 	ArtifactAdd(ctx context.Context, name string, artifactBlobs []ArtifactBlob, opts ArtifactAddOptions) (*ArtifactAddReport, error)
 	ArtifactExtract(ctx context.Context, name string, target string, opts ArtifactExtractOptions) error
 	ArtifactExtractTarStream(ctx context.Context, w io.Writer, name string, opts ArtifactExtractOptions) error
@@ -17,7 +18,10 @@ type ImageEngine interface { //nolint:interfacebloat
 	ArtifactList(ctx context.Context, opts ArtifactListOptions) ([]*ArtifactListReport, error)
 	ArtifactPull(ctx context.Context, name string, opts ArtifactPullOptions) (*ArtifactPullReport, error)
 	ArtifactPush(ctx context.Context, name string, opts ArtifactPushOptions) (*ArtifactPushReport, error)
+	ArtifactRefresh(ctx context.Context, opts ArtifactRefreshOptions) ([]*ArtifactRefreshReport, error)
 	ArtifactRm(ctx context.Context, opts ArtifactRemoveOptions) (*ArtifactRemoveReport, error)
+	Audit(ctx context.Context, namesOrIDs []string, opts ImageAuditOptions) ([]*ImageAuditReport, error)
+	AuditImport(ctx context.Context, paths []string) (*ImageAuditImportReport, error)
 	Build(ctx context.Context, containerFiles []string, opts BuildOptions) (*BuildReport, error)
 	Config(ctx context.Context) (*config.Config, error)
 	Exists(ctx context.Context, nameOrID string) (*BoolReport, error)
@@ -31,6 +35,7 @@ type ImageEngine interface { //nolint:interfacebloat
 	Pull(ctx context.Context, rawImage string, opts ImagePullOptions) (*ImagePullReport, error)
 	Push(ctx context.Context, source string, destination string, opts ImagePushOptions) (*ImagePushReport, error)
 	Remove(ctx context.Context, images []string, opts ImageRemoveOptions) (*ImageRemoveReport, []error)
+	SBOM(ctx context.Context, nameOrID string, opts ImageSBOMOptions) (*ImageSBOMReport, error)
 	Save(ctx context.Context, nameOrID string, tags []string, options ImageSaveOptions) error
 	Scp(ctx context.Context, src, dst string, opts ImageScpOptions) (*ImageScpReport, error)
 	Search(ctx context.Context, term string, opts ImageSearchOptions) ([]ImageSearchReport, error)
@@ -41,6 +46,7 @@ type ImageEngine interface { //nolint:interfacebloat
 	Tree(ctx context.Context, nameOrID string, options ImageTreeOptions) (*ImageTreeReport, error)
 	Unmount(ctx context.Context, images []string, options ImageUnmountOptions) ([]*ImageUnmountReport, error)
 	Untag(ctx context.Context, nameOrID string, tags []string, options ImageUntagOptions) error
+	Verify(ctx context.Context, nameOrID string, opts ImageVerifyOptions) (*ImageVerifyReport, error)
 	ManifestCreate(ctx context.Context, name string, images []string, opts ManifestCreateOptions) (string, error)
 	ManifestExists(ctx context.Context, name string) (*BoolReport, error)
 	ManifestInspect(ctx context.Context, name string, opts ManifestInspectOptions) (*define.ManifestListData, error)
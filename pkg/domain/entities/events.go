@@ -52,6 +52,7 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 		Type:              t,
 		HealthStatus:      e.HealthStatus,
 		Error:             errorString,
+		Cursor:            e.Cursor,
 		Details: libpodEvents.Details{
 			PodID:      podID,
 			Attributes: details,
@@ -95,5 +96,6 @@ func ConvertToEntitiesEvent(e libpodEvents.Event) *types.Event {
 	return &types.Event{
 		Message:      message,
 		HealthStatus: e.HealthStatus,
+		Cursor:       e.Cursor,
 	}
 }
@@ -0,0 +1,65 @@
+package entities
+
+// GraphOptions are the options of podman graph.
+type GraphOptions struct {
+	// Pod limits the graph to the containers of a pod and their
+	// dependencies.
+	Pod string
+}
+
+// Kinds of graph nodes.
+const (
+	GraphNodeContainer = "container"
+	GraphNodeInfra     = "infra"
+	GraphNodeNetwork   = "network"
+	GraphNodeVolume    = "volume"
+)
+
+// GraphNode is a container, network or volume in the dependency graph.
+type GraphNode struct {
+	// ID is the ID of containers and the name of networks and volumes,
+	// prefixed by "network:" and "volume:" respectively.
+	ID string
+	// Name is the name of the container, network or volume.
+	Name string
+	// Kind is one of container, infra, network or volume.
+	Kind string
+	// Pod is the name of the pod of the container.
+	Pod string `json:",omitempty"`
+	// State is the state of the container.
+	State string `json:",omitempty"`
+	// ExitCode is the exit code of exited containers.
+	ExitCode int32 `json:",omitempty"`
+	// Health is the health of containers with a healthcheck.
+	Health string `json:",omitempty"`
+}
+
+// GraphEdge is a dependency in the graph, From depends on To.
+type GraphEdge struct {
+	From string
+	To   string
+	// Kinds are the kinds of the dependency: the namespaces shared with
+	// the container, "requires" for --requires, and "network" and
+	// "volume" for the networks and volumes of a container.
+	Kinds []string
+}
+
+// GraphBlocker is a dependency which blocks a container from starting.
+type GraphBlocker struct {
+	// Container is the ID of the blocked container.
+	Container string
+	// Path is the chain of dependencies from the container to the
+	// blocking one, both excluded.
+	Path []string `json:",omitempty"`
+	// Dependency is the ID of the blocking container.
+	Dependency string
+	// Reason describes why the dependency is blocking.
+	Reason string
+}
+
+// GraphReport is the dependency graph of containers.
+type GraphReport struct {
+	Nodes    []GraphNode
+	Edges    []GraphEdge
+	Blockers []GraphBlocker
+}
@@ -209,6 +209,9 @@ type ImageListOptions struct {
 	// that the compat endpoint does not
 	ExtendedAttributes bool
 	Filter             []string
+	// Where is an expression evaluated over the inspect data of each
+	// image, only matching images are listed.
+	Where string
 }
 
 type ImagePruneOptions struct {
@@ -296,6 +299,70 @@ type ImageTreeOptions struct {
 // ImageTreeReport provides results from ImageEngine.Tree()
 type ImageTreeReport = entitiesTypes.ImageTreeReport
 
+// ImageSBOMOptions provides options for ImageEngine.SBOM()
+type ImageSBOMOptions struct {
+	// Format of the generated document, spdx or cyclonedx.
+	Format string
+	// Attach the document to the image as an artifact and push it to the
+	// registry of the image.
+	Attach bool
+	// Authfile to use when pushing the artifact.
+	Authfile string
+	// CertDir is the path to certificate directories when pushing.
+	CertDir string
+	// Username for authenticating against the registry.
+	Username string
+	// Password for authenticating against the registry.
+	Password string
+	// SkipTLSVerify to skip HTTPS and certificate verification.
+	SkipTLSVerify types.OptionalBool
+	// Writer is used to display copy information including progress bars.
+	Writer io.Writer
+}
+
+// ImageSBOMReport provides results from ImageEngine.SBOM()
+type ImageSBOMReport = entitiesTypes.ImageSBOMReport
+
+// ImageAuditOptions provides options for ImageEngine.Audit()
+type ImageAuditOptions struct{}
+
+// ImageAuditReport provides results from ImageEngine.Audit()
+type ImageAuditReport = entitiesTypes.ImageAuditReport
+
+// ImageVulnerability is a vulnerability found by ImageEngine.Audit()
+type ImageVulnerability = entitiesTypes.ImageVulnerability
+
+// ImageAuditImportReport provides results from ImageEngine.AuditImport()
+type ImageAuditImportReport = entitiesTypes.ImageAuditImportReport
+
+// ImageAnalyzeOptions provides options for ImageEngine.Analyze()
+type ImageAnalyzeOptions struct{}
+
+// ImageAnalyzeReport provides results from ImageEngine.Analyze()
+type ImageAnalyzeReport = entitiesTypes.ImageAnalyzeReport
+
+// ImageVerifyOptions provides options for ImageEngine.Verify()
+type ImageVerifyOptions struct {
+	// PolicyPath overrides the default policy.json.
+	PolicyPath string
+	// Attestations fetches the attestations attached to the image from
+	// its registry and verifies them.
+	Attestations bool
+	// Authfile to use when fetching attestations.
+	Authfile string
+	// CertDir is the path to certificate directories when fetching.
+	CertDir string
+	// Username for authenticating against the registry.
+	Username string
+	// Password for authenticating against the registry.
+	Password string
+	// SkipTLSVerify to skip HTTPS and certificate verification.
+	SkipTLSVerify types.OptionalBool
+}
+
+// ImageVerifyReport provides results from ImageEngine.Verify()
+type ImageVerifyReport = entitiesTypes.ImageVerifyReport
+
 // ShowTrustOptions are the cli options for showing trust
 type ShowTrustOptions struct {
 	JSON         bool
@@ -3,6 +3,7 @@ package entities
 import (
 	"net"
 
+	"github.com/containers/podman/v6/libpod/define"
 	entitiesTypes "github.com/containers/podman/v6/pkg/domain/entities/types"
 )
 
@@ -85,3 +86,22 @@ type (
 	NetworkInspectReport = entitiesTypes.NetworkInspectReport
 	NetworkContainerInfo = entitiesTypes.NetworkContainerInfo
 )
+
+// NetworkPolicy restricts the connections of the containers it selects on a
+// set of networks.
+type NetworkPolicy = define.NetworkPolicy
+
+// NetworkPolicyCreateOptions describes options for creating network policies
+type NetworkPolicyCreateOptions struct {
+	// Replace an existing policy of the same name.
+	Replace bool
+}
+
+// NetworkPolicyRmOptions describes options for removing network policies
+type NetworkPolicyRmOptions struct {
+	// Ignore policies that do not exist.
+	Ignore bool
+}
+
+// NetworkPolicyRmReport describes the results of network policy removal
+type NetworkPolicyRmReport = entitiesTypes.NetworkPolicyRmReport
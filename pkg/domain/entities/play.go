@@ -27,6 +27,9 @@ type PlayKubeOptions struct {
 	ExitCodePropagation string
 	// Replace indicates whether to delete and recreate a yaml file
 	Replace bool
+	// Rolling indicates whether to update the pods of Deployments that
+	// already run one revision at a time instead of failing
+	Rolling bool
 	// Do not create /etc/hostname within the pod's containers,
 	// instead use the version from the image
 	NoHostname bool
@@ -107,3 +110,16 @@ type PlayKubeDownOptions struct {
 type PlayKubeTeardown = entitiesTypes.PlayKubeTeardown
 
 type PlaySecret = entitiesTypes.PlaySecret
+
+// PlayKubeRollout describes the rollout of a Deployment by play kube.
+type PlayKubeRollout = entitiesTypes.PlayKubeRollout
+
+// KubeRollbackOptions controls rolling back a Deployment played by play kube.
+type KubeRollbackOptions struct {
+	// Revision to roll back to.  The previously running revision is
+	// used if 0.
+	Revision int
+}
+
+// KubeRollbackReport contains the results of rolling back a Deployment.
+type KubeRollbackReport = entitiesTypes.KubeRollbackReport
@@ -362,6 +362,7 @@ func ToPodSpecGen(s specgen.PodSpecGenerator, p *PodCreateOptions) (*specgen.Pod
 		s.PortMappings = p.Net.PublishPorts
 		s.Networks = p.Net.Networks
 		s.NetworkOptions = p.Net.NetworkOptions
+		s.NetworkRateLimits = p.Net.NetworkRateLimits
 		if p.Net.UseImageResolvConf {
 			s.NoManageResolvConf = true
 		}
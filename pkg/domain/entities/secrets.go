@@ -5,6 +5,10 @@ import (
 	"github.com/containers/podman/v6/pkg/errorhandling"
 )
 
+// DefaultSecretKeepVersions is the default number of previous versions of a
+// secret kept when it is replaced.
+const DefaultSecretKeepVersions = 5
+
 type SecretCreateReport = types.SecretCreateReport
 
 type SecretCreateOptions struct {
@@ -13,14 +17,44 @@ type SecretCreateOptions struct {
 	Labels     map[string]string
 	Replace    bool
 	Ignore     bool
+	// KeepVersions is the number of previous versions kept when the
+	// secret is replaced.
+	KeepVersions int
+}
+
+type SecretUpdateOptions struct {
+	// ReloadSignal is sent to running containers using the secret.
+	ReloadSignal string
+	// ReloadExec is a command executed in running containers using the
+	// secret.
+	ReloadExec string
+	// KeepVersions is the number of previous versions of the secret
+	// kept.
+	KeepVersions int
+}
+
+type SecretRollbackOptions struct {
+	// Version is the version the secret is rolled back to.
+	Version int
+	SecretUpdateOptions
 }
 
+type SecretHistoryReport = types.SecretHistoryReport
+
+type SecretUpdateReport = types.SecretUpdateReport
+
+type SecretUpdateContainerReport = types.SecretUpdateContainerReport
+
+type SecretContainer = types.SecretContainer
+
 type SecretInspectOptions struct {
 	ShowSecret bool
 }
 
 type SecretListRequest struct {
 	Filters map[string][]string
+	// All lists the previous versions of secrets too.
+	All bool
 }
 
 type SecretListReport = types.SecretListReport
@@ -77,6 +111,20 @@ type SwagSecretListCompatResponse struct {
 	Body []*SecretInfoReportCompat
 }
 
+// Secret update response
+// swagger:response SecretUpdateResponse
+type SwagSecretUpdateResponse struct {
+	// in:body
+	Body SecretUpdateReport
+}
+
+// Secret history response
+// swagger:response SecretHistoryResponse
+type SwagSecretHistoryResponse struct {
+	// in:body
+	Body []*SecretHistoryReport
+}
+
 // Secret inspect response
 // swagger:response SecretInspectResponse
 type SwagSecretInspectResponse struct {
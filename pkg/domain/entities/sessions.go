@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"os"
+	"time"
+)
+
+// SessionListOptions are the options of podman session ls.
+type SessionListOptions struct {
+	Filters map[string][]string
+}
+
+// SessionListReport describes a recording of an exec or attach session.
+type SessionListReport struct {
+	// ID is the ID of the recording.
+	ID string
+	// Type is the type of the session, exec or attach.
+	Type string
+	// ContainerID is the ID of the container of the session.
+	ContainerID string
+	// ContainerName is the name of the container of the session.
+	ContainerName string
+	// ExecID is the ID of the exec session of exec recordings.
+	ExecID string
+	// Command is the command of the session.
+	Command string
+	// User is the user who started the session.
+	User string
+	// UID is the UID of the user who started the session.
+	UID int
+	// SudoUser is the user who invoked sudo to start the session.
+	SudoUser string
+	// Created is the time the session started.
+	Created time.Time
+	// Duration is the duration of the recording.
+	Duration time.Duration
+	// Width is the initial width of the terminal.
+	Width int
+	// Height is the initial height of the terminal.
+	Height int
+	// Path is the path of the recording.
+	Path string
+}
+
+// SessionReplayOptions are the options of podman session show.
+type SessionReplayOptions struct {
+	// Speed is the factor the replay is sped up by.  Values <= 0 replay
+	// the recording without delays.
+	Speed float64
+	// IdleLimit limits pauses of the replay to the given duration, if set.
+	IdleLimit time.Duration
+	// OutputStream is written the output of the recording.
+	OutputStream *os.File
+}
+
+// SessionRmOptions are the options of podman session rm.
+type SessionRmOptions struct {
+	All bool
+}
+
+// SessionRmReport is the result of removing a session recording.
+type SessionRmReport struct {
+	ID  string
+	Err error
+}
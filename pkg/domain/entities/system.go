@@ -12,6 +12,11 @@ type (
 	SystemMigrateOptions    = types.SystemMigrateOptions
 	SystemCheckOptions      = types.SystemCheckOptions
 	SystemCheckReport       = types.SystemCheckReport
+	SystemBackupOptions     = types.SystemBackupOptions
+	SystemBackupReport      = types.SystemBackupReport
+	SystemRestoreOptions    = types.SystemRestoreOptions
+	SystemRestoreReport     = types.SystemRestoreReport
+	SystemRestoreEntry      = types.SystemRestoreEntry
 	SystemDfOptions         = types.SystemDfOptions
 	SystemDfReport          = types.SystemDfReport
 	SystemDfImageReport     = types.SystemDfImageReport
@@ -23,6 +28,13 @@ type (
 	ListRegistriesReport    = types.ListRegistriesReport
 )
 
+// Conflict policies for SystemRestoreOptions
+const (
+	RestoreConflictFail    = "fail"
+	RestoreConflictSkip    = "skip"
+	RestoreConflictReplace = "replace"
+)
+
 type (
 	AuthConfig  = types.AuthConfig
 	AuthReport  = types.AuthReport
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"github.com/containers/podman/v6/pkg/trace"
+)
+
+// ContainerTraceOptions are the options of podman trace.
+type ContainerTraceOptions struct {
+	// Latest traces the latest container.
+	Latest bool
+	// EventChan receives the traced events.  It is closed when the trace
+	// ends.
+	EventChan chan trace.Event
+}
+
+// ContainerTraceReport is the result of tracing a container.
+type ContainerTraceReport struct {
+	// Syscalls are the names of the system calls used by the processes
+	// of the container while they were traced.
+	Syscalls []string
+}
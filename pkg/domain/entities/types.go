@@ -54,6 +54,8 @@ type NetOptions struct {
 	PublishPorts       []types.PortMapping                `json:"portmappings,omitempty"`
 	// NetworkOptions are additional options for each network
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// NetworkRateLimits limits the bandwidth of each network
+	NetworkRateLimits map[string]define.NetworkRateLimit `json:"network_rate_limits,omitempty"`
 }
 
 // InspectOptions all CLI inspect commands and inspect sub-commands use the same options
@@ -73,6 +75,7 @@ type InspectOptions struct {
 // DiffOptions all API and CLI diff commands and diff sub-commands use the same options
 type DiffOptions struct {
 	Format string          `json:",omitempty"` // CLI only
+	Export string          `json:",omitempty"` // CLI only
 	Latest bool            `json:",omitempty"` // API and CLI, only supported by containers
 	Type   define.DiffType // Type which should be compared
 }
@@ -89,6 +92,9 @@ type EventsOptions struct {
 	Stream    bool
 	Since     string
 	Until     string
+	// SinceCursor only returns the events after the event with the
+	// given cursor.
+	SinceCursor string
 }
 
 // ContainerCreateResponse is the response struct for creating a container
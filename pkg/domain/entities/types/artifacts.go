@@ -29,3 +29,11 @@ type ArtifactPushReport struct {
 type ArtifactPullReport struct {
 	ArtifactDigest *digest.Digest
 }
+
+type ArtifactRefreshReport struct {
+	// Id is the ID of the container.
+	Id string
+	// Refreshed maps the destinations of the refreshed artifact volumes
+	// to the digests of the new artifacts.
+	Refreshed map[string]string
+}
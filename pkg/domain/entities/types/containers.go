@@ -36,6 +36,15 @@ type ContainerStatsReport struct {
 	Stats []define.ContainerStats
 }
 
+// ContainerTopStatsReport is used for streaming the resource usage of the
+// processes of a container.
+type ContainerTopStatsReport struct {
+	// Error from reading stats.
+	Error error
+	// Processes, set when there is no error.
+	Processes []define.ContainerProcessStats
+}
+
 type ContainerUpdateOptions struct {
 	NameOrID string
 	// This individual items of Specgen are used to update container configuration:
@@ -61,7 +70,10 @@ type ContainerUpdateOptions struct {
 	RestartRetries                  *uint
 	Env                             []string
 	UnsetEnv                        []string
-	Latest                          bool
+	// NetworkRateLimits replaces the rate limits of the given network
+	// attachments, a limit without rates removes it.
+	NetworkRateLimits map[string]define.NetworkRateLimit
+	Latest            bool
 }
 
 func (u *ContainerUpdateOptions) ProcessSpecgen() {
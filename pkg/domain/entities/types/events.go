@@ -11,4 +11,7 @@ type Event struct {
 	// point and fork such Docker types.
 	dockerEvents.Message
 	HealthStatus string `json:",omitempty"`
+	// Cursor is the position of the event in the event log, pass it as
+	// since cursor to resume reading after the event.
+	Cursor string `json:",omitempty"`
 }
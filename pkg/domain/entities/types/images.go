@@ -3,6 +3,7 @@ package types
 import (
 	"time"
 
+	"github.com/containers/podman/v6/pkg/analyze"
 	"github.com/containers/podman/v6/pkg/inspect"
 	"github.com/containers/podman/v6/pkg/trust"
 )
@@ -54,6 +55,89 @@ type ImageTreeReport struct {
 	Tree string // TODO: Refactor move presentation work out of server
 }
 
+type ImageSBOMReport struct {
+	// SBOM is the encoded document.
+	SBOM []byte
+	// MediaType of the document.
+	MediaType string
+	// Packages is the number of packages found in the image.
+	Packages int
+	// Artifact is the name of the attached artifact, if any.
+	Artifact string
+	// ArtifactDigest is the digest of the pushed artifact, if any.
+	ArtifactDigest string
+}
+
+type ImageAuditReport struct {
+	// ID of the image.
+	ID string
+	// Name of the image as given by the user.
+	Name string
+	// OS is the distribution of the image, if known.
+	OS string `json:",omitempty"`
+	// Packages is the number of packages found in the image.
+	Packages int
+	// Vulnerabilities affecting the packages, by descending severity.
+	Vulnerabilities []ImageVulnerability
+}
+
+type ImageVulnerability struct {
+	// ID of the advisory.
+	ID string
+	// Aliases of the advisory, usually CVE IDs.
+	Aliases []string `json:",omitempty"`
+	// Severity is one of unknown, low, medium, high or critical.
+	Severity string
+	// Package is the name of the affected package.
+	Package string
+	// Version is the installed version of the package.
+	Version string
+	// Type of the package, e.g. rpm or python.
+	Type string
+	// Location of the package database or manifest in the image.
+	Location string
+	// Fixed is the first version fixing the vulnerability, if known.
+	Fixed string `json:",omitempty"`
+	// Summary of the advisory.
+	Summary string `json:",omitempty"`
+}
+
+type ImageAuditImportReport struct {
+	// Imported is the number of advisories read from the given files.
+	Imported int
+	// Advisories is the number of advisories in the database.
+	Advisories int
+}
+
+type ImageAnalyzeReport struct {
+	// Name of the analyzed image.
+	Name string
+	// ID of the image.
+	ID string
+	analyze.Report
+}
+
+type ImageVerifyReport struct {
+	// Name of the image the signatures were verified for.
+	Name string
+	// ID of the image.
+	ID string
+	// Digest of the image manifest.
+	Digest string
+	// PolicyPath is the policy.json the image was verified against.
+	PolicyPath string
+	// Scope of the policy that applies to the image.
+	Scope string
+	// Allowed is true if the policy accepts the image.
+	Allowed bool
+	// Requirements of the scope and whether the image satisfies them.
+	Requirements []trust.VerifyRequirement
+	// Signatures stored with the image.
+	Signatures []trust.VerifySignature
+	// Attestations attached to the image in its registry, if requested.
+	Attestations []trust.VerifyAttestation `json:",omitempty"`
+}
+
 type ImageLoadReport struct {
 	Names []string
 }
@@ -109,6 +193,15 @@ type FarmInspectReport struct {
 	OS                string
 	Arch              string
 	Variant           string
+	// CPUs is the number of CPUs of the node.
+	CPUs int
+	// MemTotal is the total memory of the node in bytes.
+	MemTotal int64
+	// MemFree is the free memory of the node in bytes.
+	MemFree int64
+	// Builds is the number of builds running on the node, counted by
+	// their buildah working containers.
+	Builds int
 }
 
 // ImageRemoveReport is the response for removing one or more image(s) from storage
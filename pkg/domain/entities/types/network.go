@@ -31,6 +31,12 @@ type NetworkRmReport struct {
 	Err  error
 }
 
+// NetworkPolicyRmReport describes the results of network policy removal
+type NetworkPolicyRmReport struct {
+	Name string
+	Err  error
+}
+
 type NetworkCreateReport struct {
 	Name string
 }
@@ -29,6 +29,10 @@ type PlayKubeReport struct {
 	Secrets []PlaySecret
 	// ServiceContainerID - ID of the service container if one is created
 	ServiceContainerID string
+	// Rollouts - Deployments updated by a rolling play kube.
+	Rollouts []PlayKubeRollout
+	// NetworkPolicies - names of the network policies created by play kube.
+	NetworkPolicies []string
 	// If set, exit with the specified exit code.
 	ExitCode *int32
 }
@@ -41,8 +45,35 @@ type PlayKubeTeardown struct {
 	RmReport       []*PodRmReport
 	VolumeRmReport []*VolumeRmReport
 	SecretRmReport []*SecretRmReport
+	// NetworkPolicyRmReport - network policies removed by kube down.
+	NetworkPolicyRmReport []*NetworkPolicyRmReport
 }
 
 type PlaySecret struct {
 	CreateReport *SecretCreateReport
 }
+
+type PlayKubeRollout struct {
+	// Deployment - name of the Deployment.
+	Deployment string
+	// Revision - revision of the Deployment now running.
+	Revision int
+	// Pod - ID of the pod running the revision.
+	Pod string
+	// PreviousPod - ID of the pod of the previous revision, kept stopped
+	// for rolling back.  Empty if the Deployment was not running.
+	PreviousPod string `json:",omitempty"`
+	// Unchanged - the running revision already matched the YAML.
+	Unchanged bool
+}
+
+type KubeRollbackReport struct {
+	// Deployment - name of the Deployment.
+	Deployment string
+	// Revision - revision of the Deployment now running.
+	Revision int
+	// Pod - ID of the pod running the revision.
+	Pod string
+	// PreviousPod - ID of the pod of the revision rolled back from.
+	PreviousPod string
+}
@@ -42,6 +42,47 @@ type SecretInfoReport struct {
 	UpdatedAt  time.Time
 	Spec       SecretSpec
 	SecretData string `json:"SecretData,omitempty"`
+	// Version is incremented every time the secret is updated.
+	Version int `json:"Version,omitempty"`
+	// Containers are the containers using the secret.
+	Containers []SecretContainer `json:"Containers,omitempty"`
+}
+
+// SecretContainer is a container using a secret.
+type SecretContainer struct {
+	ID   string
+	Name string
+	// Stale is set if the container uses an older version of the secret.
+	Stale bool
+}
+
+type SecretUpdateReport struct {
+	ID      string
+	Version int
+	// Containers are the containers the secret was updated in.
+	Containers []SecretUpdateContainerReport
+}
+
+// SecretHistoryReport describes a version of a secret.
+type SecretHistoryReport struct {
+	ID      string
+	Name    string
+	Version int
+	// Current is set for the current version of the secret.
+	Current bool
+	// ChangedBy is the user who set the version.
+	ChangedBy string `json:"ChangedBy,omitempty"`
+	// ChangedAt is when the version was set.
+	ChangedAt time.Time
+	// RollbackTo is the version the secret was rolled back to, if the
+	// version was set by a rollback.
+	RollbackTo int `json:"RollbackTo,omitempty"`
+}
+
+type SecretUpdateContainerReport struct {
+	ID    string
+	Name  string
+	Error string `json:"Error,omitempty"`
 }
 
 type SecretInfoReportCompat struct {
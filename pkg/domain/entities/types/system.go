@@ -65,6 +65,46 @@ type SystemMigrateOptions struct {
 	NewRuntime string
 }
 
+// SystemBackupOptions describes the options for writing the configuration of
+// all containers, pods, networks, volumes and secrets to an archive
+type SystemBackupOptions struct {
+	Output     string // path of the archive to write
+	VolumeData bool   // include the contents of local volumes
+}
+
+// SystemBackupReport lists what was written to a backup archive
+type SystemBackupReport struct {
+	Containers []string
+	Pods       []string
+	Networks   []string
+	Volumes    []string
+	Secrets    []string
+	Images     []string
+}
+
+// SystemRestoreOptions describes the options for re-creating everything
+// stored in a backup archive
+type SystemRestoreOptions struct {
+	Input      string // path of the archive to read
+	KeepIDs    bool   // keep the container and pod IDs from the archive
+	KeepNames  bool   // keep the names from the archive
+	OnConflict string // one of fail, skip or replace
+}
+
+// SystemRestoreEntry describes the outcome of restoring a single object
+type SystemRestoreEntry struct {
+	Kind   string // container, pod, network, volume, secret or image
+	Name   string
+	ID     string
+	Status string // created, skipped, replaced, pulled or failed
+	Err    error
+}
+
+// SystemRestoreReport describes what was restored from a backup archive
+type SystemRestoreReport struct {
+	Entries []*SystemRestoreEntry
+}
+
 // SystemDfOptions describes the options for getting df information
 type SystemDfOptions struct {
 	Format  string
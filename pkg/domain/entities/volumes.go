@@ -31,6 +31,9 @@ type VolumePruneOptions struct {
 
 type VolumeListOptions struct {
 	Filter map[string][]string
+	// Where is an expression evaluated over the inspect data of each
+	// volume, only matching volumes are listed.
+	Where string
 }
 
 type VolumeListReport = types.VolumeListReport
@@ -12,7 +12,9 @@ import (
 
 	"github.com/containers/podman/v6/libpod"
 	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/audit"
 	"github.com/containers/podman/v6/pkg/domain/entities/types"
+	"github.com/sirupsen/logrus"
 	"go.podman.io/common/pkg/filters"
 	"go.podman.io/common/pkg/util"
 	"go.podman.io/storage"
@@ -299,6 +301,15 @@ func GenerateContainerFilterFuncs(filter string, filterValues []string, r *libpo
 		return func(c *libpod.Container) bool {
 			return c.ShouldStartOnBoot() == wantRestart
 		}, nil
+	case "vulnerable":
+		vulnerable, err := prepareVulnerableFilterFunc(filterValues, r)
+		if err != nil {
+			return nil, err
+		}
+		return func(c *libpod.Container) bool {
+			imageID, _ := c.Image()
+			return vulnerable(imageID)
+		}, nil
 	}
 	return nil, fmt.Errorf("%s is an invalid filter", filter)
 }
@@ -333,6 +344,62 @@ func prepareUntilFilterFunc(filterValues []string) (func(container *libpod.Conta
 	}, nil
 }
 
+// prepareVulnerableFilterFunc returns a function reporting whether an image
+// matches the vulnerable filter. The filter value is either a boolean or the
+// minimum severity of the vulnerabilities in the imported database.
+func prepareVulnerableFilterFunc(filterValues []string, r *libpod.Runtime) (func(imageID string) bool, error) {
+	want := true
+	threshold := audit.SeverityUnknown
+	for _, fv := range filterValues {
+		if b, err := strconv.ParseBool(fv); err == nil {
+			want, threshold = b, audit.SeverityUnknown
+			continue
+		}
+		severity, err := audit.ParseSeverity(fv)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vulnerable filter %q: must be a boolean or a severity (%s)", fv, strings.Join(audit.Severities, ", "))
+		}
+		want, threshold = true, severity
+	}
+	db, err := audit.Load(audit.DBPath(r))
+	if err != nil {
+		return nil, err
+	}
+
+	// Many containers usually share an image, only match it once.
+	results := make(map[string]bool)
+	return func(imageID string) bool {
+		vulnerable, ok := results[imageID]
+		if !ok {
+			vulnerable = imageIsVulnerable(r, db, imageID, threshold)
+			results[imageID] = vulnerable
+		}
+		return vulnerable == want
+	}, nil
+}
+
+func imageIsVulnerable(r *libpod.Runtime, db *audit.DB, imageID string, threshold audit.Severity) bool {
+	if imageID == "" {
+		// Containers created from a rootfs.
+		return false
+	}
+	img, _, err := r.LibimageRuntime().LookupImage(imageID, nil)
+	if err != nil {
+		return false
+	}
+	inv, err := audit.ImageInventory(r, img)
+	if err != nil {
+		logrus.Warnf("Unable to scan image %s: %v", imageID, err)
+		return false
+	}
+	for _, f := range db.Match(inv.OS, inv.Packages) {
+		if f.Severity >= threshold {
+			return true
+		}
+	}
+	return false
+}
+
 // GenerateContainerFilterFuncs return ContainerFilter functions based of filter.
 func GenerateExternalContainerFilterFuncs(filter string, filterValues []string, r *libpod.Runtime) (func(listContainer *types.ListContainer) bool, error) {
 	switch filter {
@@ -535,6 +602,14 @@ func GenerateExternalContainerFilterFuncs(filter string, filterValues []string,
 			}
 			return false
 		}, nil
+	case "vulnerable":
+		vulnerable, err := prepareVulnerableFilterFunc(filterValues, r)
+		if err != nil {
+			return nil, err
+		}
+		return func(listContainer *types.ListContainer) bool {
+			return vulnerable(listContainer.ImageID)
+		}, nil
 	case "restart-policy", "volume", "health":
 		return nil, fmt.Errorf("filter %s is not applicable for external containers", filter)
 	}
@@ -10,12 +10,18 @@ import (
 	"os"
 	"time"
 
+	"github.com/containers/podman/v6/libpod"
 	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/trust"
 	"github.com/opencontainers/go-digest"
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/libimage"
 	"go.podman.io/common/pkg/libartifact/store"
 	"go.podman.io/common/pkg/libartifact/types"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/image"
+	imageTypes "go.podman.io/image/v5/types"
 )
 
 func (ir *ImageEngine) ArtifactInspect(ctx context.Context, name string, _ entities.ArtifactInspectOptions) (*entities.ArtifactInspectReport, error) {
@@ -77,6 +83,9 @@ func (ir *ImageEngine) ArtifactPull(ctx context.Context, name string, opts entit
 	pullOptions.Writer = opts.Writer
 	pullOptions.OciDecryptConfig = opts.OciDecryptConfig
 	pullOptions.MaxRetries = opts.MaxRetries
+	// The signatures are verified against the policy by the copy but the
+	// artifact store can not hold them, they are stored separately below.
+	pullOptions.RemoveSignatures = true
 	if opts.RetryDelay != "" {
 		duration, err := time.ParseDuration(opts.RetryDelay)
 		if err != nil {
@@ -96,12 +105,98 @@ func (ir *ImageEngine) ArtifactPull(ctx context.Context, name string, opts entit
 	if err != nil {
 		return nil, err
 	}
+	if err := ir.storeArtifactSignatures(ctx, artRefToPull, artifactDigest, opts); err != nil {
+		return nil, fmt.Errorf("storing signatures of artifact %s: %w", artRefToPull, err)
+	}
 
 	return &entities.ArtifactPullReport{
 		ArtifactDigest: &artifactDigest,
 	}, nil
 }
 
+// storeArtifactSignatures fetches the signatures of the pulled artifact from
+// its registry and stores them for verifying the artifact against the
+// signature policy when it is mounted.
+func (ir *ImageEngine) storeArtifactSignatures(ctx context.Context, artRef store.ArtifactReference, artifactDigest digest.Digest, opts entities.ArtifactPullOptions) error {
+	named, err := reference.ParseNamed(artRef.String())
+	if err != nil {
+		return err
+	}
+	digested, err := reference.WithDigest(reference.TrimNamed(named), artifactDigest)
+	if err != nil {
+		return err
+	}
+	srcRef, err := docker.NewReference(digested)
+	if err != nil {
+		return err
+	}
+
+	sys := ir.Libpod.SystemContext()
+	if opts.AuthFilePath != "" {
+		sys.AuthFilePath = opts.AuthFilePath
+	}
+	if opts.CertDirPath != "" {
+		sys.DockerCertPath = opts.CertDirPath
+	}
+	if opts.Username != "" {
+		sys.DockerAuthConfig = &imageTypes.DockerAuthConfig{Username: opts.Username, Password: opts.Password}
+	}
+	sys.DockerInsecureSkipTLSVerify = opts.InsecureSkipTLSVerify
+
+	src, err := srcRef.NewImageSource(ctx, sys)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	unparsed := image.UnparsedInstance(src, nil)
+	rawManifest, _, err := unparsed.Manifest(ctx)
+	if err != nil {
+		return err
+	}
+	sigs, err := unparsed.UntrustedSignatures(ctx)
+	if err != nil {
+		return err
+	}
+	blobs := make([][]byte, 0, len(sigs))
+	for _, sig := range sigs {
+		blob, err := trust.SignatureBlob(sig)
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, blob)
+	}
+	return ir.Libpod.SetArtifactSignatures(ctx, rawManifest, blobs)
+}
+
+// removeArtifactSignatures removes the stored signatures of the removed
+// artifacts, unless the same artifact is still stored under another name.
+func (ir *ImageEngine) removeArtifactSignatures(ctx context.Context, artStore *store.ArtifactStore, removed []*digest.Digest) error {
+	if len(removed) == 0 {
+		return nil
+	}
+	remaining, err := artStore.List(ctx)
+	if err != nil {
+		return err
+	}
+	inUse := make(map[digest.Digest]bool, len(remaining))
+	for _, art := range remaining {
+		d, err := art.GetDigest()
+		if err != nil {
+			return err
+		}
+		inUse[*d] = true
+	}
+	for _, d := range removed {
+		if inUse[*d] {
+			continue
+		}
+		if err := ir.Libpod.RemoveArtifactSignatures(*d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (ir *ImageEngine) ArtifactRm(ctx context.Context, opts entities.ArtifactRemoveOptions) (*entities.ArtifactRemoveReport, error) {
 	var namesOrDigests []string
 	artStore, err := ir.Libpod.ArtifactStore()
@@ -147,6 +242,9 @@ func (ir *ImageEngine) ArtifactRm(ctx context.Context, opts entities.ArtifactRem
 		}
 		artifactDigests = append(artifactDigests, artifactDigest)
 	}
+	if err := ir.removeArtifactSignatures(ctx, artStore, artifactDigests); err != nil {
+		return nil, err
+	}
 	artifactRemoveReport := entities.ArtifactRemoveReport{
 		ArtifactDigests: artifactDigests,
 	}
@@ -292,3 +390,55 @@ func (ir *ImageEngine) ArtifactExtractTarStream(ctx context.Context, w io.Writer
 	}
 	return artStore.ExtractTarStream(ctx, w, asr, &extractOpt)
 }
+
+func (ir *ImageEngine) ArtifactRefresh(ctx context.Context, opts entities.ArtifactRefreshOptions) ([]*entities.ArtifactRefreshReport, error) {
+	var ctrs []*libpod.Container
+	if opts.All {
+		running, err := ir.Libpod.GetRunningContainers()
+		if err != nil {
+			return nil, err
+		}
+		ctrs = running
+	}
+	for _, nameOrID := range opts.Containers {
+		ctr, err := ir.Libpod.LookupContainer(nameOrID)
+		if err != nil {
+			return nil, err
+		}
+		ctrs = append(ctrs, ctr)
+	}
+
+	reports := make([]*entities.ArtifactRefreshReport, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		var refreshing bool
+		pulled := make(map[string]bool)
+		for _, volume := range ctr.Config().ArtifactVolumes {
+			if volume.RefreshInterval == 0 {
+				continue
+			}
+			refreshing = true
+			if !volume.RefreshPull || pulled[volume.Source] {
+				continue
+			}
+			if _, err := ir.ArtifactPull(ctx, volume.Source, entities.ArtifactPullOptions{Quiet: true}); err != nil {
+				return nil, fmt.Errorf("pulling artifact %q for container %s: %w", volume.Source, ctr.ID(), err)
+			}
+			pulled[volume.Source] = true
+		}
+		if !refreshing {
+			if opts.All {
+				continue
+			}
+			return nil, fmt.Errorf("container %s has no refreshing artifact volumes", ctr.ID())
+		}
+		refreshed, err := ctr.RefreshArtifacts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, &entities.ArtifactRefreshReport{
+			Id:        ctr.ID(),
+			Refreshed: refreshed,
+		})
+	}
+	return reports, nil
+}
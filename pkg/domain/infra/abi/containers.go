@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"os"
 	"reflect"
@@ -864,7 +865,7 @@ func (ic *ContainerEngine) ContainerAttach(ctx context.Context, nameOrID string,
 	ctr := containers[0]
 
 	// If the container is in a pod, also set to recursively start dependencies
-	err = terminal.StartAttachCtr(ctx, ctr.Container, options.Stdout, options.Stderr, options.Stdin, options.DetachKeys, options.SigProxy, false)
+	err = terminal.StartAttachCtr(ctx, ctr.Container, options.Stdout, options.Stderr, options.Stdin, options.DetachKeys, options.SigProxy, false, options.Record)
 	if err != nil && !errors.Is(err, define.ErrDetach) {
 		return fmt.Errorf("attaching to container %s: %w", ctr.ID(), err)
 	}
@@ -1040,7 +1041,7 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 		}
 
 		if options.Attach {
-			err = terminal.StartAttachCtr(ctx, ctr.Container, options.Stdout, options.Stderr, options.Stdin, options.DetachKeys, options.SigProxy, true)
+			err = terminal.StartAttachCtr(ctx, ctr.Container, options.Stdout, options.Stderr, options.Stdin, options.DetachKeys, options.SigProxy, true, false)
 			if errors.Is(err, define.ErrDetach) {
 				// User manually detached
 				// Exit cleanly immediately
@@ -1166,6 +1167,45 @@ func (ic *ContainerEngine) Diff(_ context.Context, namesOrIDs []string, opts ent
 	return &entities.DiffReport{Changes: changes}, err
 }
 
+// DiffExport writes the changes of a container or image as an uncompressed
+// layer tarball to writer.
+func (ic *ContainerEngine) DiffExport(_ context.Context, namesOrIDs []string, writer io.Writer, opts entities.DiffOptions) error {
+	var (
+		base   string
+		parent string
+	)
+	if opts.Latest {
+		ctnr, err := ic.Libpod.GetLatestContainer()
+		if err != nil {
+			return fmt.Errorf("unable to get latest container: %w", err)
+		}
+		base = ctnr.ID()
+	}
+	if len(namesOrIDs) > 0 {
+		base = namesOrIDs[0]
+		if len(namesOrIDs) > 1 {
+			parent = namesOrIDs[1]
+		}
+	}
+	diff, err := ic.Libpod.ExportDiff(parent, base, opts.Type)
+	if err != nil {
+		return err
+	}
+	defer diff.Close()
+	_, err = io.Copy(writer, diff)
+	return err
+}
+
+// ContainerApplyDiff applies a layer tarball to the root filesystem of a
+// container.
+func (ic *ContainerEngine) ContainerApplyDiff(ctx context.Context, nameOrID string, reader io.Reader) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ctr.ApplyDiff(ctx, reader)
+}
+
 func (ic *ContainerEngine) ContainerRun(ctx context.Context, opts entities.ContainerRunOptions) (*entities.ContainerRunReport, error) {
 	removeContainer := func(ctr *libpod.Container, force bool) error {
 		var timeout *uint
@@ -1265,7 +1305,7 @@ func (ic *ContainerEngine) ContainerRun(ctx context.Context, opts entities.Conta
 	}
 
 	// if the container was created as part of a pod, also start its dependencies, if any.
-	if err := terminal.StartAttachCtr(ctx, ctr, opts.OutputStream, opts.ErrorStream, opts.InputStream, opts.DetachKeys, opts.SigProxy, true); err != nil {
+	if err := terminal.StartAttachCtr(ctx, ctr, opts.OutputStream, opts.ErrorStream, opts.InputStream, opts.DetachKeys, opts.SigProxy, true, false); err != nil {
 		// We've manually detached from the container
 		// Do not perform cleanup, or wait for container exit code
 		// Just exit immediately
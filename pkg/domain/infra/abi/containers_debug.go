@@ -0,0 +1,108 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/domain/infra/abi/terminal"
+	"github.com/containers/podman/v6/pkg/specgen"
+	"github.com/containers/podman/v6/pkg/specgen/generate"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+)
+
+// ContainerDebug runs a debug container in the namespaces of a running
+// container, attaches to it and removes it once it exits.
+func (ic *ContainerEngine) ContainerDebug(ctx context.Context, nameOrID string, options entities.ContainerDebugOptions) (*entities.ContainerRunReport, error) {
+	target, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	state, err := target.State()
+	if err != nil {
+		return nil, err
+	}
+	if state != define.ContainerStateRunning {
+		return nil, fmt.Errorf("container %s is not running, can only debug running containers: %w", target.Name(), define.ErrCtrStateInvalid)
+	}
+
+	s := options.Spec
+	ns := specgen.Namespace{NSMode: specgen.FromContainer, Value: target.ID()}
+	s.PidNS = ns
+	s.NetNS = ns
+	s.UtsNS = ns
+	if target.ConfigNoCopy().NoShmShare {
+		logrus.Warnf("Container %s has a private IPC namespace, the debug container does not join it", target.Name())
+	} else {
+		s.IpcNS = ns
+	}
+	if len(target.IDMappings().UIDMap) > 0 {
+		s.UserNS = ns
+	}
+	if s.Labels == nil {
+		s.Labels = make(map[string]string)
+	}
+	s.Labels[define.DebugTargetLabel] = target.ID()
+	remove := true
+	s.Remove = &remove
+
+	if options.RootfsMount != "" {
+		mountPoint, err := target.Mount()
+		if err != nil {
+			return nil, fmt.Errorf("mounting root file system of container %s: %w", target.Name(), err)
+		}
+		defer func() {
+			if err := target.Unmount(false); err != nil {
+				logrus.Errorf("Unmounting root file system of container %s: %v", target.Name(), err)
+			}
+		}()
+		s.Mounts = append(s.Mounts, spec.Mount{
+			Destination: options.RootfsMount,
+			Type:        define.TypeBind,
+			Source:      mountPoint,
+			Options:     []string{"rbind"},
+		})
+	}
+
+	warn, err := generate.CompleteSpec(ctx, ic.Libpod, s)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range warn {
+		fmt.Fprintf(os.Stderr, "%s\n", w)
+	}
+	rtSpec, spec, optsN, err := generate.MakeContainer(ctx, ic.Libpod, s, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	ctr, err := generate.ExecuteCreate(ctx, ic.Libpod, rtSpec, spec, false, optsN...)
+	if err != nil {
+		return nil, err
+	}
+	target.NewDebugEvent(ctr)
+
+	report := entities.ContainerRunReport{Id: ctr.ID()}
+	if err := terminal.StartAttachCtr(ctx, ctr, options.OutputStream, options.ErrorStream, options.InputStream, options.DetachKeys, options.SigProxy, true, false); err != nil {
+		// The cleanup process removes the container once it exits.
+		if errors.Is(err, define.ErrDetach) {
+			return &report, nil
+		}
+		if rmErr := ic.Libpod.RemoveContainer(ctx, ctr, true, true, nil); rmErr != nil {
+			logrus.Debugf("Unable to remove debug container %s after failing to start and attach to it: %v", ctr.ID(), rmErr)
+		}
+		report.ExitCode = define.ExitCode(err)
+		return &report, err
+	}
+	report.ExitCode, _ = ic.ContainerWaitForExitCode(ctx, ctr)
+	if err := ic.Libpod.RemoveContainer(ctx, ctr, false, true, nil); err != nil &&
+		!errors.Is(err, define.ErrNoSuchCtr) && !errors.Is(err, define.ErrCtrRemoved) {
+		logrus.Errorf("Removing debug container %s: %v", ctr.ID(), err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,106 @@
+//go:build !remote
+
+package abi
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+)
+
+// processStatsSortKeys are the valid keys to sort the processes of a container
+// by.  All keys but pid sort in descending order.
+var processStatsSortKeys = map[string]func(a, b *define.ContainerProcessStats) int{
+	"pid": func(a, b *define.ContainerProcessStats) int {
+		return cmp.Compare(a.PID, b.PID)
+	},
+	"cpu": func(a, b *define.ContainerProcessStats) int {
+		return cmp.Compare(b.CPU, a.CPU)
+	},
+	"rss": func(a, b *define.ContainerProcessStats) int {
+		return cmp.Compare(b.RSS, a.RSS)
+	},
+	"io": func(a, b *define.ContainerProcessStats) int {
+		return cmp.Compare(b.ReadRate+b.WriteRate, a.ReadRate+a.WriteRate)
+	},
+	"fds": func(a, b *define.ContainerProcessStats) int {
+		return cmp.Compare(b.FDs, a.FDs)
+	},
+}
+
+// sortProcessStats sorts the processes by the key, processes with the same
+// value are sorted by PID.
+func sortProcessStats(processes []define.ContainerProcessStats, key string) error {
+	compare, ok := processStatsSortKeys[key]
+	if !ok {
+		return fmt.Errorf("invalid sort key %q, must be one of pid, cpu, rss, io or fds", key)
+	}
+	slices.SortStableFunc(processes, func(a, b define.ContainerProcessStats) int {
+		return cmp.Or(compare(&a, &b), cmp.Compare(a.PID, b.PID))
+	})
+	return nil
+}
+
+// ContainerTopStats returns the resource usage of the processes of a
+// container, repeatedly every interval if options.Stream is set.
+func (ic *ContainerEngine) ContainerTopStats(ctx context.Context, nameOrID string, options entities.ContainerTopStatsOptions) (chan entities.ContainerTopStatsReport, error) {
+	if options.Interval < 1 {
+		return nil, errors.New("invalid interval, must be a positive number greater zero")
+	}
+	if options.Sort == "" {
+		options.Sort = "pid"
+	}
+	if err := sortProcessStats(nil, options.Sort); err != nil {
+		return nil, err
+	}
+
+	var (
+		ctr *libpod.Container
+		err error
+	)
+	if options.Latest {
+		ctr, err = ic.Libpod.GetLatestContainer()
+	} else {
+		ctr, err = ic.Libpod.LookupContainer(nameOrID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up requested container: %w", err)
+	}
+
+	statsChan := make(chan entities.ContainerTopStatsReport, 1)
+	go func() {
+		defer close(statsChan)
+		var previous []define.ContainerProcessStats
+		for {
+			select {
+			case <-ctx.Done():
+				// client cancelled
+				logrus.Debugf("Container top stats stopped: context cancelled")
+				return
+			default:
+			}
+
+			report := entities.ContainerTopStatsReport{}
+			report.Processes, report.Error = ctr.GetProcessStats(previous)
+			if report.Error == nil {
+				previous = slices.Clone(report.Processes)
+				report.Error = sortProcessStats(report.Processes, options.Sort)
+			}
+			statsChan <- report
+
+			if report.Error != nil || !options.Stream {
+				return
+			}
+			time.Sleep(time.Second * time.Duration(options.Interval))
+		}
+	}()
+	return statsChan, nil
+}
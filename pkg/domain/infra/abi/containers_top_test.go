@@ -0,0 +1,43 @@
+//go:build !remote
+
+package abi
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sortProcessStats(t *testing.T) {
+	processes := []define.ContainerProcessStats{
+		{PID: 3, CPU: 5, RSS: 100, ReadRate: 10, FDs: 4},
+		{PID: 1, CPU: 50, RSS: 300, WriteRate: 5, FDs: -1},
+		{PID: 2, CPU: 5, RSS: 200, ReadRate: 1, WriteRate: 100, FDs: 10},
+	}
+	tests := []struct {
+		key  string
+		pids []int
+	}{
+		{key: "pid", pids: []int{1, 2, 3}},
+		{key: "cpu", pids: []int{1, 2, 3}},
+		{key: "rss", pids: []int{1, 2, 3}},
+		{key: "io", pids: []int{2, 3, 1}},
+		{key: "fds", pids: []int{2, 3, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			sorted := append([]define.ContainerProcessStats{}, processes...)
+			require.NoError(t, sortProcessStats(sorted, tt.key))
+			pids := make([]int, 0, len(sorted))
+			for _, p := range sorted {
+				pids = append(pids, p.PID)
+			}
+			assert.Equal(t, tt.pids, pids)
+		})
+	}
+
+	err := sortProcessStats(processes, "mem")
+	assert.ErrorContains(t, err, `invalid sort key "mem"`)
+}
@@ -5,12 +5,15 @@ package abi
 import (
 	"context"
 	"fmt"
+	"runtime"
 	"strings"
 
 	"github.com/containers/buildah/pkg/parse"
 	"github.com/containers/podman/v6/pkg/domain/entities"
 	"github.com/containers/podman/v6/pkg/emulation"
+	"github.com/sirupsen/logrus"
 	lplatform "go.podman.io/common/libimage/platform"
+	"go.podman.io/storage/pkg/system"
 )
 
 // FarmNodeName returns the local engine's name.
@@ -48,11 +51,48 @@ func (ir *ImageEngine) FarmNodeInspect(ctx context.Context) (*entities.FarmInspe
 	ir.platforms.Do(func() {
 		ir.os, ir.arch, ir.variant, ir.nativePlatforms, ir.emulatedPlatforms, ir.platformsErr = ir.fetchInfo(ctx)
 	})
+	if ir.platformsErr != nil {
+		return nil, ir.platformsErr
+	}
+	mi, err := system.ReadMemInfo()
+	if err != nil {
+		return nil, fmt.Errorf("reading memory info: %w", err)
+	}
+	builds, err := ir.runningBuilds()
+	if err != nil {
+		return nil, err
+	}
 	return &entities.FarmInspectReport{
 		NativePlatforms:   ir.nativePlatforms,
 		EmulatedPlatforms: ir.emulatedPlatforms,
 		OS:                ir.os,
 		Arch:              ir.arch,
 		Variant:           ir.variant,
-	}, ir.platformsErr
+		CPUs:              runtime.NumCPU(),
+		MemTotal:          mi.MemTotal,
+		MemFree:           mi.MemFree,
+		Builds:            builds,
+	}, nil
+}
+
+// runningBuilds counts the buildah working containers in the local storage,
+// every running build has at least one of them.
+func (ir *ImageEngine) runningBuilds() (int, error) {
+	ctrs, err := ir.Libpod.StorageContainers()
+	if err != nil {
+		return 0, err
+	}
+	builds := 0
+	for _, ctr := range ctrs {
+		isBuild, err := ir.Libpod.IsBuildahContainer(ctr.ID)
+		if err != nil {
+			// The build may have finished since listing.
+			logrus.Debugf("Checking whether container %s is a build container: %v", ctr.ID, err)
+			continue
+		}
+		if isBuild {
+			builds++
+		}
+	}
+	return builds, nil
 }
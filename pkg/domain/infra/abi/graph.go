@@ -0,0 +1,242 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+)
+
+// Graph returns the dependency graph of all containers or the containers of
+// a pod, including their dependencies outside of the pod.
+func (ic *ContainerEngine) Graph(_ context.Context, options entities.GraphOptions) (*entities.GraphReport, error) {
+	var (
+		ctrs []*libpod.Container
+		err  error
+	)
+	if options.Pod != "" {
+		pod, err := ic.Libpod.LookupPod(options.Pod)
+		if err != nil {
+			return nil, err
+		}
+		ctrs, err = pod.AllContainers()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		ctrs, err = ic.Libpod.GetAllContainers()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The graph must contain all dependencies.
+	seen := make(map[string]bool, len(ctrs))
+	for _, ctr := range ctrs {
+		seen[ctr.ID()] = true
+	}
+	for i := 0; i < len(ctrs); i++ {
+		for dep := range ctrs[i].DependencyKinds() {
+			if seen[dep] {
+				continue
+			}
+			depCtr, err := ic.Libpod.LookupContainer(dep)
+			if err != nil {
+				return nil, fmt.Errorf("looking up dependency %s of container %s: %w", dep, ctrs[i].ID(), err)
+			}
+			seen[dep] = true
+			ctrs = append(ctrs, depCtr)
+		}
+	}
+	slices.SortFunc(ctrs, func(a, b *libpod.Container) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	// Use the graph podman starts and stops containers with, it also
+	// detects cycles.
+	graph, err := libpod.BuildContainerGraph(ctrs)
+	if err != nil {
+		return nil, err
+	}
+	dependencies := graph.DependencyMap()
+
+	report := &entities.GraphReport{Nodes: []entities.GraphNode{}, Edges: []entities.GraphEdge{}}
+	podNames := make(map[string]string)
+	var networks, volumes []string
+	for _, ctr := range ctrs {
+		node, err := ic.graphNode(ctr, podNames)
+		if err != nil {
+			return nil, err
+		}
+		report.Nodes = append(report.Nodes, *node)
+
+		kinds := ctr.DependencyKinds()
+		deps := dependencies[ctr]
+		slices.SortFunc(deps, func(a, b *libpod.Container) int {
+			return strings.Compare(a.Name(), b.Name())
+		})
+		for _, dep := range deps {
+			report.Edges = append(report.Edges, entities.GraphEdge{From: ctr.ID(), To: dep.ID(), Kinds: kinds[dep.ID()]})
+		}
+
+		// Containers joining the network namespace of another
+		// container use its networks.
+		hasNetNsCtr := false
+		for _, k := range kinds {
+			if slices.Contains(k, "net") {
+				hasNetNsCtr = true
+			}
+		}
+		if !hasNetNsCtr {
+			ctrNetworks, err := ctr.Networks()
+			if err != nil {
+				return nil, fmt.Errorf("retrieving networks of container %s: %w", ctr.ID(), err)
+			}
+			slices.Sort(ctrNetworks)
+			for _, network := range ctrNetworks {
+				report.Edges = append(report.Edges, entities.GraphEdge{From: ctr.ID(), To: "network:" + network, Kinds: []string{entities.GraphNodeNetwork}})
+				if !slices.Contains(networks, network) {
+					networks = append(networks, network)
+				}
+			}
+		}
+
+		for _, vol := range ctr.NamedVolumes() {
+			report.Edges = append(report.Edges, entities.GraphEdge{From: ctr.ID(), To: "volume:" + vol.Name, Kinds: []string{entities.GraphNodeVolume}})
+			if !slices.Contains(volumes, vol.Name) {
+				volumes = append(volumes, vol.Name)
+			}
+		}
+	}
+
+	slices.Sort(networks)
+	for _, network := range networks {
+		report.Nodes = append(report.Nodes, entities.GraphNode{ID: "network:" + network, Name: network, Kind: entities.GraphNodeNetwork})
+	}
+	slices.Sort(volumes)
+	for _, vol := range volumes {
+		report.Nodes = append(report.Nodes, entities.GraphNode{ID: "volume:" + vol, Name: vol, Kind: entities.GraphNodeVolume})
+	}
+
+	report.Blockers = graphBlockers(report.Nodes, report.Edges)
+	return report, nil
+}
+
+func (ic *ContainerEngine) graphNode(ctr *libpod.Container, podNames map[string]string) (*entities.GraphNode, error) {
+	node := &entities.GraphNode{ID: ctr.ID(), Name: ctr.Name(), Kind: entities.GraphNodeContainer}
+	if ctr.IsInfra() {
+		node.Kind = entities.GraphNodeInfra
+	}
+	if podID := ctr.PodID(); podID != "" {
+		name, ok := podNames[podID]
+		if !ok {
+			pod, err := ic.Libpod.LookupPod(podID)
+			if err != nil {
+				return nil, fmt.Errorf("looking up pod of container %s: %w", ctr.ID(), err)
+			}
+			name = pod.Name()
+			podNames[podID] = name
+		}
+		node.Pod = name
+	}
+
+	state, err := ctr.State()
+	if err != nil {
+		return nil, fmt.Errorf("retrieving state of container %s: %w", ctr.ID(), err)
+	}
+	node.State = state.String()
+	if state == define.ContainerStateExited || state == define.ContainerStateStopped {
+		exitCode, _, err := ctr.ExitCode()
+		if err != nil {
+			return nil, fmt.Errorf("retrieving exit code of container %s: %w", ctr.ID(), err)
+		}
+		node.ExitCode = exitCode
+	}
+	if ctr.HasHealthCheck() {
+		health, err := ctr.HealthCheckStatus()
+		if err != nil {
+			return nil, fmt.Errorf("retrieving health of container %s: %w", ctr.ID(), err)
+		}
+		node.Health = health
+	}
+	return node, nil
+}
+
+// graphBlockers returns the dependencies which block the containers which
+// are not running from starting.  Starting a container starts its
+// dependencies first, so every dependency which is not running blocks it
+// until it is started; only the deepest dependency of each chain of such
+// dependencies is reported.  Infra containers are started by the pod and
+// never block.
+func graphBlockers(nodes []entities.GraphNode, edges []entities.GraphEdge) []entities.GraphBlocker {
+	byID := make(map[string]*entities.GraphNode, len(nodes))
+	for i := range nodes {
+		byID[nodes[i].ID] = &nodes[i]
+	}
+	deps := make(map[string][]string)
+	for _, edge := range edges {
+		if to, ok := byID[edge.To]; ok && (to.Kind == entities.GraphNodeContainer || to.Kind == entities.GraphNodeInfra) {
+			deps[edge.From] = append(deps[edge.From], edge.To)
+		}
+	}
+	blocking := func(id string) bool {
+		n := byID[id]
+		return n.Kind == entities.GraphNodeContainer && n.State != define.ContainerStateRunning.String()
+	}
+
+	hasBlockingDep := func(id string) bool {
+		return slices.ContainsFunc(deps[id], blocking)
+	}
+
+	blockers := []entities.GraphBlocker{}
+	for _, node := range nodes {
+		if (node.Kind != entities.GraphNodeContainer && node.Kind != entities.GraphNodeInfra) || node.State == define.ContainerStateRunning.String() {
+			continue
+		}
+		// Walk the blocking dependencies breadth first to find the
+		// shortest path to each.
+		paths := map[string][]string{node.ID: nil}
+		queue := []string{node.ID}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			for _, dep := range deps[id] {
+				if _, ok := paths[dep]; ok || !blocking(dep) {
+					continue
+				}
+				path := paths[id]
+				if id != node.ID {
+					path = append(slices.Clone(path), id)
+				}
+				paths[dep] = path
+				queue = append(queue, dep)
+				if !hasBlockingDep(dep) {
+					blockers = append(blockers, entities.GraphBlocker{
+						Container:  node.ID,
+						Path:       path,
+						Dependency: dep,
+						Reason:     blockReason(byID[dep]),
+					})
+				}
+			}
+		}
+	}
+	return blockers
+}
+
+func blockReason(n *entities.GraphNode) string {
+	switch {
+	case n.State == define.ContainerStateExited.String() && n.ExitCode != 0:
+		return fmt.Sprintf("exited with code %d", n.ExitCode)
+	case n.State == define.ContainerStatePaused.String():
+		return "is paused and must be unpaused"
+	default:
+		return fmt.Sprintf("is %s and must be started first", n.State)
+	}
+}
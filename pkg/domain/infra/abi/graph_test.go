@@ -0,0 +1,58 @@
+//go:build !remote
+
+package abi
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_graphBlockers(t *testing.T) {
+	ctr := func(id, state string, exitCode int32) entities.GraphNode {
+		return entities.GraphNode{ID: id, Name: id, Kind: entities.GraphNodeContainer, State: state, ExitCode: exitCode}
+	}
+	edge := func(from, to string) entities.GraphEdge {
+		return entities.GraphEdge{From: from, To: to, Kinds: []string{"requires"}}
+	}
+	nodes := []entities.GraphNode{
+		ctr("web", "created", 0),
+		ctr("api", "exited", 0),
+		ctr("db", "exited", 1),
+		ctr("cache", "running", 0),
+		ctr("queue", "paused", 0),
+		ctr("worker", "running", 0),
+		{ID: "infra", Name: "infra", Kind: entities.GraphNodeInfra, State: "created"},
+		{ID: "network:podman", Name: "podman", Kind: entities.GraphNodeNetwork},
+	}
+	edges := []entities.GraphEdge{
+		edge("web", "api"),
+		edge("web", "cache"),
+		edge("web", "infra"),
+		edge("api", "db"),
+		edge("api", "queue"),
+		edge("worker", "db"),
+		{From: "web", To: "network:podman", Kinds: []string{"network"}},
+	}
+
+	assert.Equal(t, []entities.GraphBlocker{
+		{Container: "web", Path: []string{"api"}, Dependency: "db", Reason: "exited with code 1"},
+		{Container: "web", Path: []string{"api"}, Dependency: "queue", Reason: "is paused and must be unpaused"},
+		{Container: "api", Dependency: "db", Reason: "exited with code 1"},
+		{Container: "api", Dependency: "queue", Reason: "is paused and must be unpaused"},
+	}, graphBlockers(nodes, edges))
+
+	// The dependencies of running containers are not blocking.
+	nodes[0].State = "running"
+	nodes[1].State = "running"
+	assert.Empty(t, graphBlockers(nodes, edges))
+
+	nodes[0].State = "exited"
+	nodes[1].State = "stopped"
+	nodes[2].State = "running"
+	nodes[4].State = "running"
+	assert.Equal(t, []entities.GraphBlocker{
+		{Container: "web", Dependency: "api", Reason: "is stopped and must be started first"},
+	}, graphBlockers(nodes, edges))
+}
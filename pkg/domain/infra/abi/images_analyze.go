@@ -0,0 +1,69 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/containers/podman/v6/pkg/analyze"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+)
+
+// Analyze reads the layers of an image from the local storage, from the
+// bottom up, and reports the changes of each layer and the space wasted by
+// files which later layers replace or delete.
+func (ir *ImageEngine) Analyze(ctx context.Context, nameOrID string, _ entities.ImageAnalyzeOptions) (*entities.ImageAnalyzeReport, error) {
+	img, resolvedName, err := ir.Libpod.LibimageRuntime().LookupImage(nameOrID, nil)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := ir.Libpod.ImageLayerIDs(img)
+	if err != nil {
+		return nil, err
+	}
+	data, err := img.Inspect(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty layers of the history have no storage layer, so the remaining
+	// entries map onto the layers unless the history is incomplete.
+	var createdBy []string
+	for _, h := range data.History {
+		if !h.EmptyLayer {
+			createdBy = append(createdBy, h.CreatedBy)
+		}
+	}
+	if len(createdBy) != len(layers) {
+		createdBy = nil
+	}
+
+	analyzer := analyze.NewAnalyzer()
+	for i, id := range layers {
+		var command string
+		if createdBy != nil {
+			command = createdBy[i]
+		}
+		rc, err := ir.Libpod.LayerDiff(id)
+		if err != nil {
+			return nil, fmt.Errorf("reading layer %s: %w", id, err)
+		}
+		err = analyzer.AddLayer(id, command, rc)
+		if closeErr := rc.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	name := img.ID()
+	if names := img.Names(); slices.Contains(names, resolvedName) {
+		name = resolvedName
+	} else if len(names) > 0 {
+		name = names[0]
+	}
+	return &entities.ImageAnalyzeReport{Name: name, ID: img.ID(), Report: *analyzer.Report()}, nil
+}
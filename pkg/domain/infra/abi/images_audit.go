@@ -0,0 +1,89 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/containers/podman/v6/pkg/audit"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+)
+
+// Audit matches the packages of the given images against the imported
+// vulnerability database.
+func (ir *ImageEngine) Audit(_ context.Context, namesOrIDs []string, _ entities.ImageAuditOptions) ([]*entities.ImageAuditReport, error) {
+	db, err := audit.Load(audit.DBPath(ir.Libpod))
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*entities.ImageAuditReport, 0, len(namesOrIDs))
+	for _, nameOrID := range namesOrIDs {
+		img, _, err := ir.Libpod.LibimageRuntime().LookupImage(nameOrID, nil)
+		if err != nil {
+			return nil, err
+		}
+		inv, err := audit.ImageInventory(ir.Libpod, img)
+		if err != nil {
+			return nil, fmt.Errorf("scanning image %s: %w", nameOrID, err)
+		}
+		report := &entities.ImageAuditReport{
+			ID:              img.ID(),
+			Name:            nameOrID,
+			Packages:        len(inv.Packages),
+			Vulnerabilities: []entities.ImageVulnerability{},
+		}
+		if inv.OS != nil {
+			report.OS = inv.OS.PrettyName
+		}
+		for _, f := range db.Match(inv.OS, inv.Packages) {
+			report.Vulnerabilities = append(report.Vulnerabilities, entities.ImageVulnerability{
+				ID:       f.ID,
+				Aliases:  f.Aliases,
+				Severity: f.Severity.String(),
+				Package:  f.Package.Name,
+				Version:  f.Package.Version,
+				Type:     f.Package.Type,
+				Location: f.Package.Location,
+				Fixed:    f.Fixed,
+				Summary:  f.Summary,
+			})
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// AuditImport adds the OSV data found at the given paths to the
+// vulnerability database.
+func (ir *ImageEngine) AuditImport(_ context.Context, paths []string) (*entities.ImageAuditImportReport, error) {
+	dbPath := audit.DBPath(ir.Libpod)
+	db, err := audit.Load(dbPath)
+	if err != nil {
+		if !errors.Is(err, audit.ErrNoDatabase) {
+			return nil, err
+		}
+		db = audit.New()
+	}
+
+	report := &entities.ImageAuditImportReport{}
+	for _, path := range paths {
+		n, err := db.Import(path)
+		if err != nil {
+			return nil, fmt.Errorf("importing %s: %w", path, err)
+		}
+		report.Imported += n
+	}
+	if err := db.Save(dbPath); err != nil {
+		return nil, err
+	}
+	report.Advisories = len(db.Advisories)
+
+	if err := audit.PruneInventories(ir.Libpod); err != nil {
+		logrus.Debugf("Pruning cached image inventories: %v", err)
+	}
+	return report, nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/where"
 	"go.podman.io/common/libimage"
 )
 
@@ -24,10 +25,23 @@ func (ir *ImageEngine) List(ctx context.Context, opts entities.ImageListOptions)
 		listImagesOptions.Filters = append(listImagesOptions.Filters, "intermediate=false")
 	}
 
+	var whereExpr *where.Expression
+	if opts.Where != "" {
+		var err error
+		if whereExpr, err = where.Compile(opts.Where); err != nil {
+			return nil, err
+		}
+	}
+
 	images, err := ir.Libpod.LibimageRuntime().ListImages(ctx, listImagesOptions)
 	if err != nil {
 		return nil, err
 	}
+	if whereExpr != nil {
+		if images, err = filterImagesWhere(ctx, images, whereExpr); err != nil {
+			return nil, err
+		}
+	}
 
 	summaries := []*entities.ImageSummary{}
 	for _, img := range images {
@@ -106,3 +120,27 @@ func (ir *ImageEngine) List(ctx context.Context, opts entities.ImageListOptions)
 	}
 	return summaries, nil
 }
+
+// filterImagesWhere returns the images whose inspect data matches expr.
+func filterImagesWhere(ctx context.Context, images []*libimage.Image, expr *where.Expression) ([]*libimage.Image, error) {
+	inspectOptions := &libimage.InspectOptions{WithParent: true, WithSize: true}
+	filtered := make([]*libimage.Image, 0, len(images))
+	for _, img := range images {
+		data, err := img.Inspect(ctx, inspectOptions)
+		if err != nil {
+			if libimage.ErrorIsImageUnknown(err) {
+				// The image may have been (partially) removed in the meantime
+				continue
+			}
+			return nil, err
+		}
+		match, err := expr.MatchValue(data)
+		if err != nil {
+			return nil, fmt.Errorf("image %s: %w", img.ID(), err)
+		}
+		if match {
+			filtered = append(filtered, img)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,297 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/pkg/docker/config"
+	"go.podman.io/image/v5/pkg/sysregistriesv2"
+	"go.podman.io/image/v5/pkg/tlsclientconfig"
+	"go.podman.io/image/v5/types"
+	"go.podman.io/storage/pkg/homedir"
+)
+
+// maxReferrersPages limits how many pages of a referrers list are followed.
+const maxReferrersPages = 32
+
+// referrersClient lists the referrers of manifests through the referrers API
+// of the OCI distribution spec, which c/image does not implement.
+type referrersClient struct {
+	sys      *types.SystemContext
+	repo     reference.Named
+	host     string
+	scheme   string
+	client   *http.Client
+	auth     types.DockerAuthConfig
+	token    string
+	insecure bool
+}
+
+func newReferrersClient(sys *types.SystemContext, repo reference.Named) (*referrersClient, error) {
+	host := reference.Domain(repo)
+	c := &referrersClient{sys: sys, repo: repo, host: host, scheme: "https"}
+
+	reg, err := sysregistriesv2.FindRegistry(sys, repo.Name())
+	if err != nil {
+		return nil, err
+	}
+	if reg != nil {
+		c.insecure = reg.Insecure
+	}
+	if sys != nil && sys.DockerInsecureSkipTLSVerify != types.OptionalBoolUndefined {
+		c.insecure = sys.DockerInsecureSkipTLSVerify == types.OptionalBoolTrue
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: c.insecure} //nolint:gosec // as configured for the registry
+	if err := tlsclientconfig.SetupCertificates(referrersCertDir(sys, host), tlsConfig); err != nil {
+		return nil, err
+	}
+	transport := tlsclientconfig.NewTransport()
+	transport.TLSClientConfig = tlsConfig
+	c.client = &http.Client{Transport: transport}
+
+	if sys != nil && sys.DockerAuthConfig != nil {
+		c.auth = *sys.DockerAuthConfig
+	} else {
+		c.auth, err = config.GetCredentials(sys, host)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// referrersCertDir returns the certificate directory for host the same way
+// the docker transport of c/image looks it up.
+func referrersCertDir(sys *types.SystemContext, host string) string {
+	if sys != nil && sys.DockerCertPath != "" {
+		return sys.DockerCertPath
+	}
+	if sys != nil && sys.DockerPerHostCertDirPath != "" {
+		return filepath.Join(sys.DockerPerHostCertDirPath, host)
+	}
+	dirs := []string{
+		filepath.Join(homedir.Get(), ".config/containers/certs.d"),
+		"/etc/containers/certs.d",
+		"/etc/docker/certs.d",
+	}
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, host)); err == nil {
+			return filepath.Join(dir, host)
+		}
+	}
+	return ""
+}
+
+// Referrers returns the descriptors of the manifests referring to the
+// manifest with digest d. It returns false if the registry does not support
+// the referrers API.
+func (c *referrersClient) Referrers(ctx context.Context, d digest.Digest) ([]imgspecv1.Descriptor, bool, error) {
+	registry := c.host
+	if registry == "docker.io" {
+		registry = "registry-1.docker.io"
+	}
+	next := fmt.Sprintf("/v2/%s/referrers/%s", reference.Path(c.repo), d)
+	var referrers []imgspecv1.Descriptor
+	for range maxReferrersPages {
+		res, err := c.get(ctx, registry, next)
+		if err != nil {
+			return nil, false, err
+		}
+		switch res.StatusCode {
+		case http.StatusOK:
+		case http.StatusNotFound:
+			res.Body.Close()
+			return nil, false, nil
+		default:
+			res.Body.Close()
+			return nil, false, fmt.Errorf("listing referrers of %s@%s: registry returned %s", c.repo.Name(), d, res.Status)
+		}
+		var index imgspecv1.Index
+		err = json.NewDecoder(io.LimitReader(res.Body, maxAttestationSize)).Decode(&index)
+		res.Body.Close()
+		if err != nil {
+			return nil, false, fmt.Errorf("parsing referrers of %s@%s: %w", c.repo.Name(), d, err)
+		}
+		referrers = append(referrers, index.Manifests...)
+
+		next = nextLink(res.Header.Get("Link"))
+		if next == "" {
+			return referrers, true, nil
+		}
+	}
+	return nil, false, fmt.Errorf("listing referrers of %s@%s: more than %d pages", c.repo.Name(), d, maxReferrersPages)
+}
+
+// get requests path from the registry, authenticating with a bearer token
+// or basic credentials when the registry asks for it. Insecure registries
+// which fail the TLS handshake are retried over plain HTTP.
+func (c *referrersClient) get(ctx context.Context, registry, path string) (*http.Response, error) {
+	res, err := c.request(ctx, registry, path)
+	if err != nil && c.insecure && c.scheme == "https" && isTLSHandshakeError(err) {
+		logrus.Debugf("Retrying referrers request to %s over http: %v", registry, err)
+		c.scheme = "http"
+		res, err = c.request(ctx, registry, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusUnauthorized || c.token != "" {
+		return res, nil
+	}
+	challenge := res.Header.Get("WWW-Authenticate")
+	res.Body.Close()
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "bearer":
+		if c.token, err = c.bearerToken(ctx, params); err != nil {
+			return nil, err
+		}
+	case "basic":
+		if c.auth.Username == "" {
+			return nil, fmt.Errorf("registry %s requires credentials", registry)
+		}
+		c.token = "basic"
+	default:
+		return nil, fmt.Errorf("unsupported authentication challenge %q from %s", challenge, registry)
+	}
+	return c.request(ctx, registry, path)
+}
+
+func (c *referrersClient) request(ctx context.Context, registry, path string) (*http.Response, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		u.Scheme = c.scheme
+		u.Host = registry
+	} else if u.Scheme != c.scheme || u.Host != registry {
+		// The credentials of the registry must not be sent elsewhere,
+		// e.g. by following a next link to another host.
+		return nil, fmt.Errorf("registry %s refers to %s on another host", registry, u.Redacted())
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", imgspecv1.MediaTypeImageIndex)
+	if c.sys != nil && c.sys.DockerRegistryUserAgent != "" {
+		req.Header.Set("User-Agent", c.sys.DockerRegistryUserAgent)
+	}
+	switch c.token {
+	case "":
+	case "basic":
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	default:
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return c.client.Do(req)
+}
+
+// bearerToken obtains a pull token for the repository from the realm of a
+// bearer challenge.
+func (c *referrersClient) bearerToken(ctx context.Context, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid bearer realm %q", params["realm"])
+	}
+	query := realm.Query()
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", reference.Path(c.repo)))
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case c.auth.IdentityToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.auth.IdentityToken)
+	case c.auth.Username != "":
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("requesting token from %s: %s", realm.Host, res.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&token); err != nil {
+		return "", fmt.Errorf("parsing token from %s: %w", realm.Host, err)
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", errors.New("registry returned an empty token")
+}
+
+// isTLSHandshakeError reports whether err is a failure of the TLS handshake,
+// e.g. with a registry which only speaks plain HTTP.
+func isTLSHandshakeError(err error) bool {
+	var recordHeaderErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &recordHeaderErr) || errors.As(err, &alertErr) || errors.As(err, &certErr) {
+		return true
+	}
+	// net/http replaces the record header error of a plain HTTP response.
+	return strings.Contains(err.Error(), "server gave HTTP response to HTTPS client")
+}
+
+// parseChallenge parses a WWW-Authenticate header into the lower case scheme
+// and its parameters.
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			params[key] = value
+		}
+	}
+	return strings.ToLower(scheme), params
+}
+
+// nextLink returns the target of the rel="next" link in a Link header.
+func nextLink(header string) string {
+	for link := range strings.SplitSeq(header, ",") {
+		target, params, ok := strings.Cut(link, ";")
+		if ok && strings.Contains(strings.ReplaceAll(params, " ", ""), `rel="next"`) {
+			return strings.Trim(strings.TrimSpace(target), "<>")
+		}
+	}
+	return ""
+}
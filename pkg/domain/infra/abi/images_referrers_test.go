@@ -0,0 +1,151 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/types"
+)
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:foo:pull"`)
+	assert.Equal(t, "bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:foo:pull",
+	}, params)
+
+	scheme, params = parseChallenge(`Basic realm=registry`)
+	assert.Equal(t, "basic", scheme)
+	assert.Equal(t, map[string]string{"realm": "registry"}, params)
+}
+
+func TestNextLink(t *testing.T) {
+	assert.Equal(t, "/v2/foo/referrers/sha256:abc?n=1", nextLink(`</v2/foo/referrers/sha256:abc?n=1>; rel="next"`))
+	assert.Empty(t, nextLink(`</v2/foo/referrers/sha256:abc?n=1>; rel="prev"`))
+	assert.Empty(t, nextLink(""))
+}
+
+func TestReferrers(t *testing.T) {
+	imageDigest := digest.FromString("image")
+	pages := [][]imgspecv1.Descriptor{
+		{{MediaType: imgspecv1.MediaTypeImageManifest, Digest: digest.FromString("att1"), ArtifactType: "application/vnd.dsse.envelope.v1+json"}},
+		{{MediaType: imgspecv1.MediaTypeImageManifest, Digest: digest.FromString("sbom"), ArtifactType: "application/spdx+json"}},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "repository:foo/bar:pull", r.URL.Query().Get("scope"))
+		user, pass, _ := r.BasicAuth()
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "pass", pass)
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "secret"})
+	})
+	mux.HandleFunc("/v2/foo/bar/referrers/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="http://`+r.Host+`/token",service="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if !strings.HasSuffix(r.URL.Path, imageDigest.String()) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		page := 0
+		if r.URL.Query().Get("last") != "" {
+			page = 1
+		} else {
+			w.Header().Set("Link", `<`+r.URL.Path+`?last=1>; rel="next"`)
+		}
+		w.Header().Set("Content-Type", imgspecv1.MediaTypeImageIndex)
+		_ = json.NewEncoder(w).Encode(imgspecv1.Index{MediaType: imgspecv1.MediaTypeImageIndex, Manifests: pages[page]})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dir := t.TempDir()
+	registriesConf := filepath.Join(dir, "registries.conf")
+	require.NoError(t, os.WriteFile(registriesConf, nil, 0o600))
+	sys := &types.SystemContext{
+		SystemRegistriesConfPath:    registriesConf,
+		SystemRegistriesConfDirPath: dir,
+		DockerCertPath:              dir,
+		DockerInsecureSkipTLSVerify: types.OptionalBoolTrue,
+		DockerAuthConfig:            &types.DockerAuthConfig{Username: "user", Password: "pass"},
+	}
+	repo, err := reference.ParseNormalizedNamed(strings.TrimPrefix(server.URL, "http://") + "/foo/bar")
+	require.NoError(t, err)
+	client, err := newReferrersClient(sys, repo)
+	require.NoError(t, err)
+
+	referrers, supported, err := client.Referrers(context.Background(), imageDigest)
+	require.NoError(t, err)
+	assert.True(t, supported)
+	assert.Equal(t, append(pages[0], pages[1]...), referrers)
+
+	referrers, supported, err = client.Referrers(context.Background(), digest.FromString("other"))
+	require.NoError(t, err)
+	assert.False(t, supported)
+	assert.Empty(t, referrers)
+}
+
+func TestReferrersNextLinkOtherHost(t *testing.T) {
+	imageDigest := digest.FromString("image")
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to other host with Authorization %q", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != "user" || pass != "pass" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Link", `<`+other.URL+r.URL.Path+`>; rel="next"`)
+		w.Header().Set("Content-Type", imgspecv1.MediaTypeImageIndex)
+		_ = json.NewEncoder(w).Encode(imgspecv1.Index{MediaType: imgspecv1.MediaTypeImageIndex})
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	registriesConf := filepath.Join(dir, "registries.conf")
+	require.NoError(t, os.WriteFile(registriesConf, nil, 0o600))
+	sys := &types.SystemContext{
+		SystemRegistriesConfPath:    registriesConf,
+		SystemRegistriesConfDirPath: dir,
+		DockerCertPath:              dir,
+		DockerInsecureSkipTLSVerify: types.OptionalBoolTrue,
+		DockerAuthConfig:            &types.DockerAuthConfig{Username: "user", Password: "pass"},
+	}
+	repo, err := reference.ParseNormalizedNamed(strings.TrimPrefix(server.URL, "http://") + "/foo/bar")
+	require.NoError(t, err)
+	client, err := newReferrersClient(sys, repo)
+	require.NoError(t, err)
+
+	_, _, err = client.Referrers(context.Background(), imageDigest)
+	assert.ErrorContains(t, err, "on another host")
+}
+
+func TestIsTLSHandshakeError(t *testing.T) {
+	assert.True(t, isTLSHandshakeError(fmt.Errorf("get: %w", tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"})))
+	assert.True(t, isTLSHandshakeError(errors.New("http: server gave HTTP response to HTTPS client")))
+	assert.False(t, isTLSHandshakeError(errors.New("connection refused")))
+}
@@ -0,0 +1,201 @@
+//go:build !remote
+
+package abi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/sbom"
+	"github.com/containers/podman/v6/version"
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libimage"
+	"go.podman.io/common/pkg/libartifact/store"
+	"go.podman.io/common/pkg/libartifact/types"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	imageTypes "go.podman.io/image/v5/types"
+)
+
+// SBOM scans the layers of an image for installed packages and encodes the
+// result in the requested format. With opts.Attach the document is stored as
+// an artifact referring to the image and pushed to its repository.
+func (ir *ImageEngine) SBOM(ctx context.Context, nameOrID string, opts entities.ImageSBOMOptions) (*entities.ImageSBOMReport, error) {
+	mediaType, err := sbom.MediaType(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	img, resolvedName, err := ir.Libpod.LibimageRuntime().LookupImage(nameOrID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := sbom.ScanImage(ir.Libpod, img)
+	if err != nil {
+		return nil, err
+	}
+
+	name := img.ID()
+	if names := img.Names(); slices.Contains(names, resolvedName) {
+		name = resolvedName
+	} else if len(names) > 0 {
+		name = names[0]
+	}
+	doc := &sbom.Document{
+		Name:        name,
+		Digest:      img.Digest().String(),
+		Created:     time.Now(),
+		ToolVersion: version.Version.String(),
+		OS:          catalog.OS(),
+		Packages:    catalog.Packages(),
+	}
+	var buf bytes.Buffer
+	if err := sbom.Encode(&buf, opts.Format, doc); err != nil {
+		return nil, err
+	}
+	report := &entities.ImageSBOMReport{
+		SBOM:      buf.Bytes(),
+		MediaType: mediaType,
+		Packages:  len(doc.Packages),
+	}
+	if !opts.Attach {
+		return report, nil
+	}
+
+	if name == img.ID() {
+		return nil, fmt.Errorf("image %s has no name, cannot attach the SBOM to it", nameOrID)
+	}
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	sys := ir.Libpod.SystemContext()
+	if opts.Authfile != "" {
+		sys.AuthFilePath = opts.Authfile
+	}
+	if opts.CertDir != "" {
+		sys.DockerCertPath = opts.CertDir
+	}
+	if opts.Username != "" {
+		sys.DockerAuthConfig = &imageTypes.DockerAuthConfig{Username: opts.Username, Password: opts.Password}
+	}
+	sys.DockerInsecureSkipTLSVerify = opts.SkipTLSVerify
+
+	repo := reference.TrimNamed(named)
+	subject, err := sbomSubject(ctx, sys, repo, img)
+	if err != nil {
+		return nil, err
+	}
+	// Registries without the referrers API, and tools like cosign,
+	// discover attachments by this tag schema.
+	report.Artifact = fmt.Sprintf("%s:%s-%s.sbom", repo, subject.Digest.Algorithm(), subject.Digest.Encoded())
+	artifactDigest, err := ir.attachSBOM(ctx, report.Artifact, subject, opts, report)
+	if err != nil {
+		return nil, err
+	}
+	report.ArtifactDigest = artifactDigest
+	return report, nil
+}
+
+// sbomSubject returns the descriptor of the image manifest in the
+// repository.  Images which were not pushed yet, like images created by
+// podman commit, are described by their manifest in local storage.
+func sbomSubject(ctx context.Context, sys *imageTypes.SystemContext, repo reference.Named, img *libimage.Image) (*imgspecv1.Descriptor, error) {
+	for _, d := range img.Digests() {
+		canonical, err := reference.WithDigest(repo, d)
+		if err != nil {
+			return nil, err
+		}
+		ref, err := docker.NewReference(canonical)
+		if err != nil {
+			return nil, err
+		}
+		src, err := ref.NewImageSource(ctx, sys)
+		if err != nil {
+			if isManifestUnknown(err) {
+				continue
+			}
+			return nil, err
+		}
+		data, mimeType, err := src.GetManifest(ctx, nil)
+		src.Close()
+		if err != nil {
+			if isManifestUnknown(err) {
+				continue
+			}
+			return nil, fmt.Errorf("fetching %s: %w", canonical, err)
+		}
+		return &imgspecv1.Descriptor{
+			MediaType: mimeType,
+			Digest:    digest.FromBytes(data),
+			Size:      int64(len(data)),
+		}, nil
+	}
+	logrus.Debugf("Image %s is not in repository %s, attaching the SBOM to its local manifest", img.ID(), repo)
+	data, mimeType, err := img.Manifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &imgspecv1.Descriptor{
+		MediaType: mimeType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}, nil
+}
+
+// attachSBOM adds the document to the local artifact store with the image
+// manifest as its subject, replacing an older document for the same image,
+// and pushes it.
+func (ir *ImageEngine) attachSBOM(ctx context.Context, name string, subject *imgspecv1.Descriptor, opts entities.ImageSBOMOptions, report *entities.ImageSBOMReport) (string, error) {
+	artStore, err := ir.Libpod.ArtifactStore()
+	if err != nil {
+		return "", err
+	}
+	artRef, err := store.NewArtifactReference(name)
+	if err != nil {
+		return "", err
+	}
+
+	fileName := "sbom.spdx.json"
+	if opts.Format == sbom.FormatCycloneDX {
+		fileName = "sbom.cdx.json"
+	}
+	blobs := []types.ArtifactBlob{{
+		BlobReader: bytes.NewReader(report.SBOM),
+		FileName:   fileName,
+	}}
+	addOptions := types.AddOptions{
+		ArtifactMIMEType: report.MediaType,
+		FileMIMEType:     report.MediaType,
+	}
+	if _, err := artStore.Remove(ctx, artRef.ToArtifactStoreReference()); err != nil && !errors.Is(err, types.ErrArtifactNotExist) {
+		return "", err
+	}
+	if _, err := artStore.Add(ctx, artRef, blobs, &addOptions); err != nil {
+		return "", err
+	}
+	if _, err := ir.Libpod.SetArtifactSubject(ctx, artRef.String(), subject); err != nil {
+		return "", err
+	}
+
+	copyOpts := libimage.CopyOptions{
+		AuthFilePath:          opts.Authfile,
+		CertDirPath:           opts.CertDir,
+		InsecureSkipTLSVerify: opts.SkipTLSVerify,
+		Username:              opts.Username,
+		Password:              opts.Password,
+		Writer:                opts.Writer,
+	}
+	artifactDigest, err := artStore.Push(ctx, artRef, artRef, copyOpts)
+	if err != nil {
+		return "", err
+	}
+	return artifactDigest.String(), nil
+}
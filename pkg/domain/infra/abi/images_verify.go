@@ -0,0 +1,281 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/trust"
+	"github.com/docker/distribution/registry/api/errcode"
+	errcodev2 "github.com/docker/distribution/registry/api/v2"
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libimage"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/image"
+	"go.podman.io/image/v5/manifest"
+	"go.podman.io/image/v5/pkg/blobinfocache/none"
+	"go.podman.io/image/v5/types"
+)
+
+// Verify evaluates the signature policy for pulling the image against the
+// image in local storage and describes its signatures and, if requested,
+// the attestations attached to it in its registry.
+func (ir *ImageEngine) Verify(ctx context.Context, nameOrID string, opts entities.ImageVerifyOptions) (*entities.ImageVerifyReport, error) {
+	img, resolvedName, err := ir.Libpod.LibimageRuntime().LookupImage(nameOrID, nil)
+	if err != nil {
+		return nil, err
+	}
+	names := img.Names()
+	name := resolvedName
+	if !slices.Contains(names, name) {
+		if len(names) == 0 {
+			return nil, fmt.Errorf("image %s has no name, signatures can only be verified for a name", nameOrID)
+		}
+		name = names[0]
+	}
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return nil, err
+	}
+
+	sys := ir.Libpod.SystemContext()
+	policyPath := opts.PolicyPath
+	if policyPath == "" {
+		policyPath = trust.DefaultPolicyPath(sys)
+	}
+	scope, reqs, err := trust.PolicyRequirements(policyPath, named)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := ir.Libpod.ImageReference(img, named)
+	if err != nil {
+		return nil, err
+	}
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	requirements, err := trust.EvaluateRequirements(ctx, reqs, image.UnparsedInstance(src, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	sigs, err := ir.Libpod.ImageSignatures(img)
+	if err != nil {
+		return nil, err
+	}
+	report := &entities.ImageVerifyReport{
+		Name:         named.String(),
+		ID:           img.ID(),
+		Digest:       img.Digest().String(),
+		PolicyPath:   policyPath,
+		Scope:        scope,
+		Allowed:      len(requirements) > 0,
+		Requirements: requirements,
+		Signatures:   trust.DescribeSignatures(sigs, reqs),
+	}
+	for _, r := range requirements {
+		report.Allowed = report.Allowed && r.Allowed
+	}
+
+	if opts.Attestations {
+		if opts.Authfile != "" {
+			sys.AuthFilePath = opts.Authfile
+		}
+		if opts.CertDir != "" {
+			sys.DockerCertPath = opts.CertDir
+		}
+		if opts.Username != "" {
+			sys.DockerAuthConfig = &types.DockerAuthConfig{Username: opts.Username, Password: opts.Password}
+		}
+		sys.DockerInsecureSkipTLSVerify = opts.SkipTLSVerify
+		report.Attestations, err = imageAttestations(ctx, sys, named, img, reqs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// imageAttestations fetches the attestations attached to the image in its
+// repository. They are listed through the referrers API of the OCI
+// distribution spec, or through its referrers tag schema on registries
+// without the API, and through the tag used by cosign.
+func imageAttestations(ctx context.Context, sys *types.SystemContext, named reference.Named, img *libimage.Image, reqs []trust.PolicyRequirement) ([]trust.VerifyAttestation, error) {
+	repo := reference.TrimNamed(named)
+	client, err := newReferrersClient(sys, repo)
+	if err != nil {
+		return nil, err
+	}
+	digests := img.Digests()
+	attestations := []trust.VerifyAttestation{}
+	seen := make(map[string]bool)
+	add := func(atts []trust.VerifyAttestation) {
+		for _, att := range atts {
+			if !seen[att.Reference] {
+				seen[att.Reference] = true
+				attestations = append(attestations, att)
+			}
+		}
+	}
+	for _, d := range digests {
+		tags := []string{fmt.Sprintf("%s-%s.att", d.Algorithm(), d.Encoded())}
+		referrers, supported, err := client.Referrers(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		if supported {
+			for _, desc := range referrers {
+				if !isAttestation(desc.ArtifactType) {
+					continue
+				}
+				canonical, err := reference.WithDigest(repo, desc.Digest)
+				if err != nil {
+					return nil, err
+				}
+				atts, err := attestationsFromTag(ctx, sys, canonical, digests, reqs)
+				if err != nil {
+					return nil, err
+				}
+				add(atts)
+			}
+		} else {
+			logrus.Debugf("Registry of %s does not support the referrers API, falling back to the tag schema", repo)
+			tags = append([]string{fmt.Sprintf("%s-%s", d.Algorithm(), d.Encoded())}, tags...)
+		}
+		for _, tag := range tags {
+			tagged, err := reference.WithTag(repo, tag)
+			if err != nil {
+				return nil, err
+			}
+			atts, err := attestationsFromTag(ctx, sys, tagged, digests, reqs)
+			if err != nil {
+				return nil, err
+			}
+			add(atts)
+		}
+	}
+	return attestations, nil
+}
+
+// attestationsFromTag verifies the attestations in the manifest at ref, or
+// in the manifests listed by the index at ref. ref is a tag or a digest.
+func attestationsFromTag(ctx context.Context, sys *types.SystemContext, ref reference.Named, digests []digest.Digest, reqs []trust.PolicyRequirement) ([]trust.VerifyAttestation, error) {
+	dockerRef, err := docker.NewReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	fetchError := func(err error) ([]trust.VerifyAttestation, error) {
+		if isManifestUnknown(err) {
+			logrus.Debugf("No attestations found at %s", ref)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fetching attestations from %s: %w", ref, err)
+	}
+	src, err := dockerRef.NewImageSource(ctx, sys)
+	if err != nil {
+		return fetchError(err)
+	}
+	defer src.Close()
+	data, mimeType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return fetchError(err)
+	}
+
+	repo := reference.TrimNamed(ref).String()
+	if !manifest.MIMETypeIsMultiImage(mimeType) {
+		return attestationsFromManifest(ctx, src, repo+"@"+digest.FromBytes(data).String(), data, digests, reqs)
+	}
+	index, err := manifest.OCI1IndexFromManifest(data)
+	if err != nil {
+		return nil, err
+	}
+	var attestations []trust.VerifyAttestation
+	for _, desc := range index.Manifests {
+		if !isAttestation(desc.ArtifactType) {
+			continue
+		}
+		data, _, err := src.GetManifest(ctx, &desc.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("fetching attestations from %s@%s: %w", repo, desc.Digest, err)
+		}
+		atts, err := attestationsFromManifest(ctx, src, repo+"@"+desc.Digest.String(), data, digests, reqs)
+		if err != nil {
+			return nil, err
+		}
+		attestations = append(attestations, atts...)
+	}
+	return attestations, nil
+}
+
+func isAttestation(mediaType string) bool {
+	return mediaType == trust.DSSEEnvelopeMediaType || strings.HasPrefix(mediaType, trust.SigstoreBundleMediaTypePrefix)
+}
+
+// attestationsFromManifest verifies the attestations stored as layers of
+// the manifest data, named by ref.
+func attestationsFromManifest(ctx context.Context, src types.ImageSource, ref string, data []byte, digests []digest.Digest, reqs []trust.PolicyRequirement) ([]trust.VerifyAttestation, error) {
+	m, err := manifest.OCI1FromManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing attestation manifest %s: %w", ref, err)
+	}
+	var attestations []trust.VerifyAttestation
+	for _, layer := range m.Layers {
+		if !isAttestation(layer.MediaType) {
+			continue
+		}
+		blob, err := fetchBlob(ctx, src, layer)
+		if err != nil {
+			return nil, fmt.Errorf("fetching attestation %s from %s: %w", layer.Digest, ref, err)
+		}
+		att := trust.VerifyAttestationLayer(layer.MediaType, blob, layer.Annotations, digests, reqs)
+		att.Reference = ref
+		attestations = append(attestations, att)
+	}
+	return attestations, nil
+}
+
+// maxAttestationSize limits the size of attestation blobs read into memory.
+const maxAttestationSize = 16 << 20
+
+func fetchBlob(ctx context.Context, src types.ImageSource, desc imgspecv1.Descriptor) ([]byte, error) {
+	rc, _, err := src.GetBlob(ctx, types.BlobInfo{Digest: desc.Digest, Size: desc.Size}, none.NoCache)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxAttestationSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxAttestationSize {
+		return nil, fmt.Errorf("attestation exceeds %d bytes", maxAttestationSize)
+	}
+	if desc.Digest.Validate() == nil && desc.Digest.Algorithm().FromBytes(data) != desc.Digest {
+		return nil, fmt.Errorf("digest mismatch, expected %s", desc.Digest)
+	}
+	return data, nil
+}
+
+// isManifestUnknown reports whether err is a registry response for a
+// missing tag.
+func isManifestUnknown(err error) bool {
+	var ec errcode.ErrorCoder
+	if errors.As(err, &ec) && ec.ErrorCode() == errcodev2.ErrorCodeManifestUnknown {
+		return true
+	}
+	var unexpected docker.UnexpectedHTTPStatusError
+	return errors.As(err, &unexpected) && unexpected.StatusCode == http.StatusNotFound
+}
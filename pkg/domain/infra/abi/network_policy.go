@@ -0,0 +1,57 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+)
+
+func (ic *ContainerEngine) NetworkPolicyCreate(_ context.Context, policy entities.NetworkPolicy, options entities.NetworkPolicyCreateOptions) (*entities.NetworkPolicy, error) {
+	return ic.Libpod.CreateNetworkPolicy(policy, options.Replace)
+}
+
+func (ic *ContainerEngine) NetworkPolicyInspect(_ context.Context, names []string) ([]*entities.NetworkPolicy, []error, error) {
+	var errs []error
+	policies := make([]*entities.NetworkPolicy, 0, len(names))
+	for _, name := range names {
+		policy, err := ic.Libpod.GetNetworkPolicy(name)
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchNetworkPolicy) {
+				errs = append(errs, err)
+				continue
+			}
+			return nil, nil, fmt.Errorf("inspecting network policy %s: %w", name, err)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, errs, nil
+}
+
+func (ic *ContainerEngine) NetworkPolicyList(_ context.Context) ([]*entities.NetworkPolicy, error) {
+	policies, err := ic.Libpod.GetNetworkPolicies()
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.NetworkPolicy, 0, len(policies))
+	for i := range policies {
+		reports = append(reports, &policies[i])
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) NetworkPolicyRm(_ context.Context, names []string, options entities.NetworkPolicyRmOptions) ([]*entities.NetworkPolicyRmReport, error) {
+	reports := make([]*entities.NetworkPolicyRmReport, 0, len(names))
+	for _, name := range names {
+		err := ic.Libpod.RemoveNetworkPolicy(name)
+		if err != nil && options.Ignore && errors.Is(err, define.ErrNoSuchNetworkPolicy) {
+			continue
+		}
+		reports = append(reports, &entities.NetworkPolicyRmReport{Name: name, Err: err})
+	}
+	return reports, nil
+}
@@ -11,6 +11,7 @@ import (
 	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,6 +27,7 @@ import (
 	"github.com/containers/podman/v6/pkg/domain/infra/abi/internal/expansion"
 	v1apps "github.com/containers/podman/v6/pkg/k8s.io/api/apps/v1"
 	v1 "github.com/containers/podman/v6/pkg/k8s.io/api/core/v1"
+	v1networking "github.com/containers/podman/v6/pkg/k8s.io/api/networking/v1"
 	metav1 "github.com/containers/podman/v6/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/containers/podman/v6/pkg/specgen"
 	"github.com/containers/podman/v6/pkg/specgen/generate"
@@ -234,6 +236,9 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 	if options.ServiceContainer && options.Start == types.OptionalBoolFalse { // Sanity check to be future proof
 		return nil, fmt.Errorf("running a service container requires starting the pod(s)")
 	}
+	if options.Rolling && options.ServiceContainer {
+		return nil, fmt.Errorf("rolling updates are not supported with a service container")
+	}
 
 	report := &entities.PlayKubeReport{}
 	validKinds := 0
@@ -411,6 +416,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 			notifyProxies = append(notifyProxies, proxies...)
 
 			report.Pods = append(report.Pods, r.Pods...)
+			report.Rollouts = append(report.Rollouts, r.Rollouts...)
 			validKinds++
 			setRanContainers(r)
 		case "Job":
@@ -476,6 +482,19 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 			}
 			report.Secrets = append(report.Secrets, entities.PlaySecret{CreateReport: r})
 			validKinds++
+		case "NetworkPolicy":
+			var networkPolicy v1networking.NetworkPolicy
+
+			if err := yaml.Unmarshal(document, &networkPolicy); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube NetworkPolicy: %w", err)
+			}
+
+			name, err := ic.playKubeNetworkPolicy(&networkPolicy, &options)
+			if err != nil {
+				return nil, err
+			}
+			report.NetworkPolicies = append(report.NetworkPolicies, name)
+			validKinds++
 		default:
 			logrus.Infof("Kube kind %s not supported", kind)
 			continue
@@ -584,7 +603,32 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 	}
 	podSpec = deploymentYAML.Spec.Template
 
+	// Label the pod with the revision of the Deployment it runs so that
+	// podman kube play --rolling can update it.
+	hash, err := podTemplateHash(&podSpec)
+	if err != nil {
+		return nil, nil, err
+	}
+	podSpec.Labels = maps.Clone(podSpec.Labels)
+	if podSpec.Labels == nil {
+		podSpec.Labels = make(map[string]string)
+	}
+	podSpec.Labels[kubeDeploymentLabel] = deploymentName
+	podSpec.Labels[kubeTemplateHashLabel] = hash
+	podSpec.Labels[kubeRevisionLabel] = "1"
+
+	if options.Rolling {
+		return ic.playKubeDeploymentRolling(ctx, deploymentYAML, &podSpec, hash, options, ipIndex, configMaps)
+	}
+
 	podName := fmt.Sprintf("%s-pod", deploymentName)
+	revisions, err := ic.deploymentRevisions(deploymentName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(revisions) > 0 && !slices.ContainsFunc(revisions, func(rev *deploymentRevision) bool { return rev.pod.Name() == podName }) {
+		return nil, nil, fmt.Errorf("deployment %s is in use: %w", deploymentName, define.ErrPodExists)
+	}
 	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, deploymentYAML.Annotations, configMaps, serviceContainer)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
@@ -675,6 +719,17 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		podOpt.Net.NetworkOptions = netOpts
 	}
 
+	networkOpt, ok := annotations[define.NetworkOptAnnotation]
+	if !ok {
+		networkOpt, ok = annotations[define.NetworkOptAnnotation+"/"+podName]
+	}
+	if ok {
+		podOpt.Net.NetworkRateLimits, err = specgen.ParseNetworkRateLimits(strings.Split(networkOpt, ";"))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if options.Userns == "" {
 		if v, ok := annotations[define.UserNsAnnotation]; ok {
 			options.Userns = v
@@ -1677,9 +1732,10 @@ func getBuildFile(imageName string, cwd string) (string, error) {
 
 func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, options entities.PlayKubeDownOptions) (*entities.PlayKubeReport, error) {
 	var (
-		podNames    []string
-		volumeNames []string
-		secretNames []string
+		podNames           []string
+		volumeNames        []string
+		secretNames        []string
+		networkPolicyNames []string
 	)
 	reports := new(entities.PlayKubeReport)
 
@@ -1750,6 +1806,16 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 			}
 			podName := fmt.Sprintf("%s-pod", deploymentName)
 			podNames = append(podNames, podName)
+			// Remove the pods of all revisions rolled out with --rolling.
+			revisions, err := ic.deploymentRevisions(deploymentName)
+			if err != nil {
+				return nil, err
+			}
+			for _, rev := range revisions {
+				if rev.pod.Name() != podName {
+					podNames = append(podNames, rev.pod.Name())
+				}
+			}
 		case "Job":
 			var jobYAML v1.Job
 
@@ -1771,6 +1837,12 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 				return nil, fmt.Errorf("unable to read YAML as Kube Secret: %w", err)
 			}
 			secretNames = append(secretNames, secret.Name)
+		case "NetworkPolicy":
+			var networkPolicy v1networking.NetworkPolicy
+			if err := yaml.Unmarshal(document, &networkPolicy); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube NetworkPolicy: %w", err)
+			}
+			networkPolicyNames = append(networkPolicyNames, networkPolicy.Name)
 		default:
 			continue
 		}
@@ -1815,6 +1887,13 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 		return nil, err
 	}
 
+	// Remove the network policies after the pods so that they are not
+	// briefly reachable.
+	reports.NetworkPolicyRmReport, err = ic.NetworkPolicyRm(ctx, networkPolicyNames, entities.NetworkPolicyRmOptions{Ignore: true})
+	if err != nil {
+		return nil, err
+	}
+
 	if options.Force {
 		reports.VolumeRmReport, err = ic.VolumeRm(ctx, volumeNames, entities.VolumeRmOptions{Ignore: true})
 		if err != nil {
@@ -1836,6 +1915,39 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 	return reports, nil
 }
 
+// playKubeNetworkPolicy creates or replaces the network policy for the
+// networks the pods of kube play are attached to and returns its name.
+func (ic *ContainerEngine) playKubeNetworkPolicy(np *v1networking.NetworkPolicy, options *entities.PlayKubeOptions) (string, error) {
+	networks := []string{kubeDefaultNetwork}
+	if len(options.Networks) > 0 {
+		ns, podmanNetworks, _, err := specgen.ParseNetworkFlag(options.Networks)
+		if err != nil {
+			return "", err
+		}
+		if !ns.IsBridge() {
+			return "", fmt.Errorf("network policy %s requires bridge networks, not %s", np.Name, ns.NSMode)
+		}
+		networks = make([]string, 0, len(podmanNetworks))
+		for name := range podmanNetworks {
+			if name == "default" {
+				name = ic.Libpod.GetDefaultNetworkName()
+			}
+			networks = append(networks, name)
+		}
+		slices.Sort(networks)
+	}
+
+	policy, err := kube.ToNetworkPolicy(np, networks)
+	if err != nil {
+		return "", err
+	}
+	created, err := ic.Libpod.CreateNetworkPolicy(policy, true)
+	if err != nil {
+		return "", err
+	}
+	return created.Name, nil
+}
+
 // playKubeSecret allows users to create and store a kubernetes secret as a podman secret
 func (ic *ContainerEngine) playKubeSecret(secret *v1.Secret) (*entities.SecretCreateReport, error) {
 	r := &entities.SecretCreateReport{}
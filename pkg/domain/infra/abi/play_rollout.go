@@ -0,0 +1,570 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	v1apps "github.com/containers/podman/v6/pkg/k8s.io/api/apps/v1"
+	v1 "github.com/containers/podman/v6/pkg/k8s.io/api/core/v1"
+	"github.com/containers/podman/v6/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"github.com/containers/podman/v6/pkg/systemd/notifyproxy"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+// Labels of the pods running the revisions of a Deployment.
+const (
+	// kubeDeploymentLabel is the name of the Deployment.
+	kubeDeploymentLabel = "io.podman.kube.deployment"
+	// kubeRevisionLabel is the revision of the Deployment run by the pod.
+	kubeRevisionLabel = "io.podman.kube.revision"
+	// kubeTemplateHashLabel is the hash of the pod template of the
+	// revision, like the pod-template-hash label of Kubernetes.
+	kubeTemplateHashLabel = "io.podman.kube.pod-template-hash"
+)
+
+const (
+	// defaultRevisionHistoryLimit is the number of stopped revisions kept
+	// for rolling back if the Deployment does not set it.
+	defaultRevisionHistoryLimit = 10
+	// defaultProgressDeadline is the time a new revision has to become
+	// ready if the Deployment does not set it.
+	defaultProgressDeadline = 600 * time.Second
+	// readinessPollInterval is the interval in which the containers of a
+	// new revision are checked for readiness.
+	readinessPollInterval = time.Second
+)
+
+// deploymentRevision is a pod running, or having run, a revision of a
+// Deployment.
+type deploymentRevision struct {
+	pod      *libpod.Pod
+	revision int
+	hash     string
+	// active is true if the pod is running.
+	active bool
+	// stopped is the time the pod was stopped at.
+	stopped time.Time
+}
+
+// rolloutStrategy controls how a revision replaces the active one.
+type rolloutStrategy struct {
+	// surge starts the new revision before stopping the active one.
+	surge bool
+	// minReady is the time the containers of the new revision must be
+	// ready for before the rollout continues.
+	minReady time.Duration
+	// deadline is the time the new revision has to become ready.
+	deadline time.Duration
+}
+
+// podTemplateHash returns the hash identifying the revision of a pod
+// template.
+func podTemplateHash(template *v1.PodTemplateSpec) (string, error) {
+	data, err := yaml.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	return digest.FromBytes(data).Encoded()[:10], nil
+}
+
+// scaledValue resolves maxSurge or maxUnavailable of a rolling update for
+// the single replica Podman runs.  Like Kubernetes, percentages of maxSurge
+// are rounded up and the ones of maxUnavailable down.
+func scaledValue(value *intstr.IntOrString, def string, roundUp bool) (int, error) {
+	v := intstr.FromString(def)
+	if value != nil {
+		v = *value
+	}
+	if v.Type == intstr.Int {
+		if v.IntVal < 0 {
+			return 0, fmt.Errorf("invalid value %d: must not be negative", v.IntVal)
+		}
+		return int(v.IntVal), nil
+	}
+	percent, ok := strings.CutSuffix(v.StrVal, "%")
+	if !ok {
+		return 0, fmt.Errorf("invalid value %q: must be an integer or a percentage", v.StrVal)
+	}
+	p, err := strconv.Atoi(percent)
+	if err != nil || p < 0 {
+		return 0, fmt.Errorf("invalid value %q: must be an integer or a percentage", v.StrVal)
+	}
+	if roundUp {
+		return (p + 99) / 100, nil
+	}
+	return p / 100, nil
+}
+
+// deploymentStrategy returns the rollout strategy of the Deployment.
+func deploymentStrategy(deployment *v1apps.Deployment) (rolloutStrategy, error) {
+	strategy := rolloutStrategy{
+		surge:    true,
+		minReady: time.Duration(deployment.Spec.MinReadySeconds) * time.Second,
+		deadline: defaultProgressDeadline,
+	}
+	if d := deployment.Spec.ProgressDeadlineSeconds; d != nil && *d > 0 {
+		strategy.deadline = time.Duration(*d) * time.Second
+	}
+
+	switch deployment.Spec.Strategy.Type {
+	case v1apps.RecreateDeploymentStrategyType:
+		strategy.surge = false
+		return strategy, nil
+	case "", v1apps.RollingUpdateDeploymentStrategyType:
+	default:
+		return strategy, fmt.Errorf("unsupported deployment strategy %q", deployment.Spec.Strategy.Type)
+	}
+
+	var maxSurge, maxUnavailable *intstr.IntOrString
+	if update := deployment.Spec.Strategy.RollingUpdate; update != nil {
+		maxSurge, maxUnavailable = update.MaxSurge, update.MaxUnavailable
+	}
+	surge, err := scaledValue(maxSurge, "25%", true)
+	if err != nil {
+		return strategy, fmt.Errorf("maxSurge: %w", err)
+	}
+	unavailable, err := scaledValue(maxUnavailable, "25%", false)
+	if err != nil {
+		return strategy, fmt.Errorf("maxUnavailable: %w", err)
+	}
+	if surge == 0 && unavailable == 0 {
+		return strategy, errors.New("maxSurge and maxUnavailable may not both be 0")
+	}
+	strategy.surge = surge > 0
+	return strategy, nil
+}
+
+// deploymentRevisions returns the pods of the revisions of a Deployment,
+// sorted by revision.  A pod created before revisions were tracked is
+// returned as revision 0.
+func (ic *ContainerEngine) deploymentRevisions(name string) ([]*deploymentRevision, error) {
+	pods, err := ic.Libpod.Pods(func(p *libpod.Pod) bool {
+		return p.Labels()[kubeDeploymentLabel] == name
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		pod, err := ic.Libpod.LookupPod(name + "-pod")
+		if err != nil && !errors.Is(err, define.ErrNoSuchPod) {
+			return nil, err
+		}
+		if pod != nil {
+			pods = append(pods, pod)
+		}
+	}
+
+	revisions := make([]*deploymentRevision, 0, len(pods))
+	for _, pod := range pods {
+		rev := &deploymentRevision{
+			pod:  pod,
+			hash: pod.Labels()[kubeTemplateHashLabel],
+		}
+		if r, ok := pod.Labels()[kubeRevisionLabel]; ok {
+			if rev.revision, err = strconv.Atoi(r); err != nil {
+				return nil, fmt.Errorf("invalid revision %q of pod %s: %w", r, pod.Name(), err)
+			}
+		}
+		status, err := pod.GetPodStatus()
+		if err != nil {
+			return nil, err
+		}
+		switch status {
+		case define.PodStateRunning, define.PodStateDegraded, define.PodStatePaused:
+			rev.active = true
+		default:
+			if infra, err := pod.InfraContainer(); err == nil {
+				rev.stopped, _ = infra.FinishedTime()
+			}
+		}
+		revisions = append(revisions, rev)
+	}
+	slices.SortFunc(revisions, func(a, b *deploymentRevision) int {
+		return a.revision - b.revision
+	})
+	return revisions, nil
+}
+
+// activeRevision returns the newest running revision.
+func activeRevision(revisions []*deploymentRevision) *deploymentRevision {
+	for _, rev := range slices.Backward(revisions) {
+		if rev.active {
+			return rev
+		}
+	}
+	return nil
+}
+
+// publishesPorts returns true if the pod publishes ports on the host, which
+// a pod of another revision can not publish at the same time.
+func publishesPorts(pod *libpod.Pod) bool {
+	data, err := pod.Inspect()
+	if err != nil || data.InfraConfig == nil {
+		return false
+	}
+	for _, bindings := range data.InfraConfig.PortBindings {
+		if len(bindings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// playKubeDeploymentRolling rolls out the Deployment if its pod template
+// differs from the one of the running revision.  The pod of a revision
+// whose template matches is started again, a new pod is played otherwise.
+func (ic *ContainerEngine) playKubeDeploymentRolling(ctx context.Context, deploymentYAML *v1apps.Deployment, podSpec *v1.PodTemplateSpec, hash string, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+	name := deploymentYAML.Name
+	strategy, err := deploymentStrategy(deploymentYAML)
+	if err != nil {
+		return nil, nil, fmt.Errorf("deployment %s: %w", name, err)
+	}
+	revisions, err := ic.deploymentRevisions(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	active := activeRevision(revisions)
+
+	var report entities.PlayKubeReport
+	if active != nil && active.hash == hash {
+		report.Rollouts = append(report.Rollouts, entities.PlayKubeRollout{
+			Deployment: name,
+			Revision:   active.revision,
+			Pod:        active.pod.ID(),
+			Unchanged:  true,
+		})
+		return &report, nil, nil
+	}
+
+	var (
+		target  *deploymentRevision
+		proxies []*notifyproxy.NotifyProxy
+	)
+	revision := 1
+	for _, rev := range revisions {
+		if rev.hash == hash {
+			target = rev
+		}
+		revision = max(revision, rev.revision+1)
+	}
+
+	var start func() (*libpod.Pod, func(), error)
+	if target != nil {
+		revision = target.revision
+		start = func() (*libpod.Pod, func(), error) {
+			return startStoppedRevision(ctx, target)
+		}
+	} else {
+		podName := name + "-pod"
+		if len(revisions) > 0 {
+			podName = fmt.Sprintf("%s-pod-%s", name, hash)
+		}
+		podSpec.Labels[kubeRevisionLabel] = strconv.Itoa(revision)
+		start = func() (*libpod.Pod, func(), error) {
+			r, p, err := ic.playKubePod(ctx, podName, podSpec, options, ipIndex, deploymentYAML.Annotations, configMaps, nil)
+			proxies = p
+			undo := func() {
+				if pod, err := ic.Libpod.LookupPod(podName); err == nil {
+					if _, err := ic.Libpod.RemovePod(ctx, pod, true, true, nil); err != nil {
+						logrus.Errorf("Removing pod %s of failed rollout: %v", podName, err)
+					}
+				}
+			}
+			if err == nil {
+				report.Pods = append(report.Pods, r.Pods...)
+				for _, p := range r.Pods {
+					if len(p.ContainerErrors) > 0 {
+						err = errors.New(strings.Join(p.ContainerErrors, "; "))
+					}
+				}
+			}
+			if err != nil {
+				return nil, undo, err
+			}
+			pod, err := ic.Libpod.LookupPod(podName)
+			return pod, undo, err
+		}
+	}
+
+	if active != nil && strategy.surge && publishesPorts(active.pod) {
+		logrus.Infof("Pod %s of deployment %s publishes ports, stopping it before starting revision %d", active.pod.Name(), name, revision)
+		strategy.surge = false
+	}
+	pod, err := ic.rollout(ctx, active, strategy, start)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rolling out revision %d of deployment %s: %w", revision, name, err)
+	}
+	if target != nil {
+		pods, err := ic.playKubePodReport(pod)
+		if err != nil {
+			return nil, nil, err
+		}
+		report.Pods = append(report.Pods, pods)
+	}
+
+	rollout := entities.PlayKubeRollout{
+		Deployment: name,
+		Revision:   revision,
+		Pod:        pod.ID(),
+	}
+	if active != nil {
+		rollout.PreviousPod = active.pod.ID()
+	}
+	report.Rollouts = append(report.Rollouts, rollout)
+
+	limit := defaultRevisionHistoryLimit
+	if l := deploymentYAML.Spec.RevisionHistoryLimit; l != nil {
+		limit = int(*l)
+	}
+	if err := ic.pruneRevisions(ctx, name, limit); err != nil {
+		logrus.Errorf("Removing old revisions of deployment %s: %v", name, err)
+	}
+	return &report, proxies, nil
+}
+
+// rollout replaces the active revision with the one started by start,
+// which also returns a function undoing the start.  The pod of the active
+// revision is stopped and kept for rolling back.  If the new revision does
+// not become ready, the active revision keeps or resumes running.
+func (ic *ContainerEngine) rollout(ctx context.Context, active *deploymentRevision, strategy rolloutStrategy, start func() (*libpod.Pod, func(), error)) (*libpod.Pod, error) {
+	if active != nil && !strategy.surge {
+		if err := stopRevisionPod(ctx, active.pod); err != nil {
+			return nil, err
+		}
+	}
+
+	pod, undo, err := start()
+	if err == nil {
+		err = ic.waitPodReady(ctx, pod, strategy)
+	}
+	if err != nil {
+		undo()
+		if active != nil && !strategy.surge {
+			if err := startRevisionPod(ctx, active.pod); err != nil {
+				logrus.Errorf("Restarting pod %s: %v", active.pod.Name(), err)
+			}
+		}
+		return nil, err
+	}
+
+	if active != nil && strategy.surge {
+		if err := stopRevisionPod(ctx, active.pod); err != nil {
+			return nil, err
+		}
+	}
+	return pod, nil
+}
+
+// startStoppedRevision starts the pod of a stopped revision again.
+func startStoppedRevision(ctx context.Context, rev *deploymentRevision) (*libpod.Pod, func(), error) {
+	undo := func() {
+		if err := stopRevisionPod(ctx, rev.pod); err != nil {
+			logrus.Errorf("Stopping pod %s: %v", rev.pod.Name(), err)
+		}
+	}
+	return rev.pod, undo, startRevisionPod(ctx, rev.pod)
+}
+
+func startRevisionPod(ctx context.Context, pod *libpod.Pod) error {
+	ctrErrs, err := pod.Start(ctx)
+	if err != nil && !errors.Is(err, define.ErrPodPartialFail) {
+		return err
+	}
+	return podContainerErrors(pod, ctrErrs)
+}
+
+func stopRevisionPod(ctx context.Context, pod *libpod.Pod) error {
+	ctrErrs, err := pod.Stop(ctx, true)
+	if err != nil && !errors.Is(err, define.ErrPodPartialFail) {
+		return err
+	}
+	return podContainerErrors(pod, ctrErrs)
+}
+
+func podContainerErrors(pod *libpod.Pod, ctrErrs map[string]error) error {
+	errs := make([]error, 0, len(ctrErrs))
+	for id, err := range ctrErrs {
+		errs = append(errs, fmt.Errorf("container %s of pod %s: %w", id, pod.Name(), err))
+	}
+	return errors.Join(errs...)
+}
+
+// waitPodReady waits for the containers of the pod to be running and, if
+// they have a health check, healthy for the minimum ready time.
+func (ic *ContainerEngine) waitPodReady(ctx context.Context, pod *libpod.Pod, strategy rolloutStrategy) error {
+	ctx, cancel := context.WithTimeout(ctx, strategy.deadline)
+	defer cancel()
+
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		return err
+	}
+	ready := func() error {
+		for _, ctr := range ctrs {
+			if ctr.IsInfra() || ctr.IsInitCtr() {
+				continue
+			}
+			if err := ic.waitContainerReady(ctx, ctr); err != nil {
+				if errors.Is(err, context.DeadlineExceeded) {
+					return fmt.Errorf("container %s not ready within %s", ctr.Name(), strategy.deadline)
+				}
+				return err
+			}
+		}
+		return nil
+	}
+	if err := ready(); err != nil || strategy.minReady == 0 {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("pod %s not ready for %s within %s", pod.Name(), strategy.minReady, strategy.deadline)
+	case <-time.After(strategy.minReady):
+	}
+	return ready()
+}
+
+func (ic *ContainerEngine) waitContainerReady(ctx context.Context, ctr *libpod.Container) error {
+	for {
+		state, err := ctr.State()
+		if err != nil {
+			return err
+		}
+		switch state {
+		case define.ContainerStateRunning:
+			if !ctr.HasHealthCheck() {
+				return nil
+			}
+			status, err := ic.Libpod.HealthCheck(ctx, ctr.ID())
+			if status == define.HealthCheckSuccess {
+				return nil
+			}
+			if err != nil {
+				logrus.Debugf("Health check of container %s: %v", ctr.Name(), err)
+			}
+		case define.ContainerStateExited, define.ContainerStateStopped:
+			return fmt.Errorf("container %s exited", ctr.Name())
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(readinessPollInterval):
+		}
+	}
+}
+
+// playKubePodReport returns the report of a pod of a revision started
+// again.
+func (ic *ContainerEngine) playKubePodReport(pod *libpod.Pod) (entities.PlayKubePod, error) {
+	report := entities.PlayKubePod{ID: pod.ID()}
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		return report, err
+	}
+	for _, ctr := range ctrs {
+		switch {
+		case ctr.IsInfra():
+		case ctr.IsInitCtr():
+			report.InitContainers = append(report.InitContainers, ctr.ID())
+		default:
+			report.Containers = append(report.Containers, ctr.ID())
+		}
+	}
+	return report, nil
+}
+
+// pruneRevisions removes the pods of the stopped revisions of a Deployment
+// beyond the history limit, the ones stopped longest ago first.
+func (ic *ContainerEngine) pruneRevisions(ctx context.Context, name string, limit int) error {
+	revisions, err := ic.deploymentRevisions(name)
+	if err != nil {
+		return err
+	}
+	revisions = slices.DeleteFunc(revisions, func(rev *deploymentRevision) bool {
+		return rev.active
+	})
+	if len(revisions) <= limit {
+		return nil
+	}
+	slices.SortFunc(revisions, func(a, b *deploymentRevision) int {
+		return b.stopped.Compare(a.stopped)
+	})
+	var errs []error
+	for _, rev := range revisions[limit:] {
+		if _, err := ic.Libpod.RemovePod(ctx, rev.pod, true, true, nil); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// KubeRollback rolls a Deployment played by kube play back to the pod of a
+// previous revision, by default the one running before the current one.
+func (ic *ContainerEngine) KubeRollback(ctx context.Context, name string, options entities.KubeRollbackOptions) (*entities.KubeRollbackReport, error) {
+	revisions, err := ic.deploymentRevisions(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("no pods of deployment %s found: %w", name, define.ErrNoSuchPod)
+	}
+	active := activeRevision(revisions)
+
+	var target *deploymentRevision
+	if options.Revision > 0 {
+		idx := slices.IndexFunc(revisions, func(rev *deploymentRevision) bool {
+			return rev.revision == options.Revision
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("deployment %s has no revision %d", name, options.Revision)
+		}
+		target = revisions[idx]
+		if target == active {
+			return nil, fmt.Errorf("revision %d of deployment %s is already running", options.Revision, name)
+		}
+	} else {
+		for _, rev := range revisions {
+			if rev != active && (target == nil || rev.stopped.After(target.stopped)) {
+				target = rev
+			}
+		}
+		if target == nil {
+			return nil, fmt.Errorf("deployment %s has no previous revision to roll back to", name)
+		}
+	}
+
+	strategy := rolloutStrategy{
+		surge:    active == nil || !publishesPorts(active.pod),
+		deadline: defaultProgressDeadline,
+	}
+	pod, err := ic.rollout(ctx, active, strategy, func() (*libpod.Pod, func(), error) {
+		return startStoppedRevision(ctx, target)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rolling back deployment %s to revision %d: %w", name, target.revision, err)
+	}
+
+	report := &entities.KubeRollbackReport{
+		Deployment: name,
+		Revision:   target.revision,
+		Pod:        pod.ID(),
+	}
+	if active != nil {
+		report.PreviousPod = active.pod.ID()
+	}
+	return report, nil
+}
@@ -0,0 +1,129 @@
+//go:build !remote
+
+package abi
+
+import (
+	"testing"
+	"time"
+
+	v1apps "github.com/containers/podman/v6/pkg/k8s.io/api/apps/v1"
+	v1 "github.com/containers/podman/v6/pkg/k8s.io/api/core/v1"
+	"github.com/containers/podman/v6/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentStrategy(t *testing.T) {
+	intOrString := func(v intstr.IntOrString) *intstr.IntOrString { return &v }
+	int32Ptr := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name        string
+		spec        v1apps.DeploymentSpec
+		expected    rolloutStrategy
+		expectedErr string
+	}{
+		{
+			name:     "defaults surge",
+			spec:     v1apps.DeploymentSpec{},
+			expected: rolloutStrategy{surge: true, deadline: defaultProgressDeadline},
+		},
+		{
+			name: "recreate",
+			spec: v1apps.DeploymentSpec{
+				Strategy: v1apps.DeploymentStrategy{Type: v1apps.RecreateDeploymentStrategyType},
+			},
+			expected: rolloutStrategy{deadline: defaultProgressDeadline},
+		},
+		{
+			name: "no surge",
+			spec: v1apps.DeploymentSpec{
+				MinReadySeconds:         5,
+				ProgressDeadlineSeconds: int32Ptr(30),
+				Strategy: v1apps.DeploymentStrategy{
+					RollingUpdate: &v1apps.RollingUpdateDeployment{
+						MaxSurge:       intOrString(intstr.FromInt(0)),
+						MaxUnavailable: intOrString(intstr.FromString("100%")),
+					},
+				},
+			},
+			expected: rolloutStrategy{minReady: 5 * time.Second, deadline: 30 * time.Second},
+		},
+		{
+			name: "small percentage surges",
+			spec: v1apps.DeploymentSpec{
+				Strategy: v1apps.DeploymentStrategy{
+					RollingUpdate: &v1apps.RollingUpdateDeployment{
+						MaxSurge:       intOrString(intstr.FromString("1%")),
+						MaxUnavailable: intOrString(intstr.FromString("99%")),
+					},
+				},
+			},
+			expected: rolloutStrategy{surge: true, deadline: defaultProgressDeadline},
+		},
+		{
+			name: "both zero",
+			spec: v1apps.DeploymentSpec{
+				Strategy: v1apps.DeploymentStrategy{
+					RollingUpdate: &v1apps.RollingUpdateDeployment{
+						MaxSurge: intOrString(intstr.FromInt(0)),
+					},
+				},
+			},
+			expectedErr: "maxSurge and maxUnavailable may not both be 0",
+		},
+		{
+			name: "invalid value",
+			spec: v1apps.DeploymentSpec{
+				Strategy: v1apps.DeploymentStrategy{
+					RollingUpdate: &v1apps.RollingUpdateDeployment{
+						MaxSurge: intOrString(intstr.FromString("half")),
+					},
+				},
+			},
+			expectedErr: `maxSurge: invalid value "half"`,
+		},
+		{
+			name: "unsupported type",
+			spec: v1apps.DeploymentSpec{
+				Strategy: v1apps.DeploymentStrategy{Type: "BlueGreen"},
+			},
+			expectedErr: `unsupported deployment strategy "BlueGreen"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			strategy, err := deploymentStrategy(&v1apps.Deployment{Spec: test.spec})
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, strategy)
+		})
+	}
+}
+
+func TestPodTemplateHash(t *testing.T) {
+	template := v1.PodTemplateSpec{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:  "web",
+				Image: "quay.io/libpod/alpine",
+				Env:   []v1.EnvVar{{Name: "LEVEL", Value: "1"}},
+			}},
+		},
+	}
+	hash, err := podTemplateHash(&template)
+	assert.NoError(t, err)
+	assert.Len(t, hash, 10)
+
+	same, err := podTemplateHash(&template)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, same)
+
+	template.Spec.Containers[0].Env[0].Value = "2"
+	changed, err := podTemplateHash(&template)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+}
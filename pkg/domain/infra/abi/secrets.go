@@ -8,11 +8,15 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/libpod/events"
 	"github.com/containers/podman/v6/pkg/domain/entities"
 	"github.com/containers/podman/v6/pkg/domain/utils"
+	"github.com/sirupsen/logrus"
 	"go.podman.io/common/pkg/secrets"
 )
 
@@ -49,14 +53,29 @@ func (ic *ContainerEngine) SecretCreate(_ context.Context, name string, reader i
 	storeOpts := secrets.StoreOptions{
 		DriverOpts:     options.DriverOpts,
 		Labels:         options.Labels,
+		Metadata:       map[string]string{secretChangedByKey: secretAuthor()},
 		Replace:        options.Replace,
 		IgnoreIfExists: options.Ignore,
 	}
+	if options.Replace {
+		// Replacing a secret is an update, keep its previous version.
+		if secret, err := manager.Lookup(name); err == nil && secret.Name == name {
+			if err := ic.archiveSecret(secret, options.KeepVersions); err != nil {
+				return nil, err
+			}
+			storeOpts.Metadata[define.SecretVersionKey] = strconv.Itoa(libpod.SecretVersion(*secret) + 1)
+		}
+	}
 
 	secretID, err := manager.Store(name, data, options.Driver, storeOpts)
 	if err != nil {
 		return nil, err
 	}
+	if options.Replace {
+		if err := ic.pruneSecretVersions(manager, name, options.KeepVersions); err != nil {
+			return nil, err
+		}
+	}
 
 	ic.Libpod.NewSecretEvent(events.Create, secretID)
 
@@ -96,12 +115,36 @@ func (ic *ContainerEngine) SecretInspect(_ context.Context, nameOrIDs []string,
 		if secret.UpdatedAt.IsZero() {
 			secret.UpdatedAt = secret.CreatedAt
 		}
-		reports = append(reports, secretToReportWithData(*secret, string(data)))
+		report := secretToReportWithData(*secret, string(data))
+		report.Containers, err = ic.secretContainers(secret)
+		if err != nil {
+			return nil, nil, fmt.Errorf("inspecting secret %s: %w", nameOrID, err)
+		}
+		reports = append(reports, report)
 	}
 
 	return reports, errs, nil
 }
 
+// secretContainers returns the containers using the secret.
+func (ic *ContainerEngine) secretContainers(secret *secrets.Secret) ([]entities.SecretContainer, error) {
+	ctrs, err := ic.Libpod.GetContainers(false, func(c *libpod.Container) bool {
+		return c.UsesSecret(secret.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	containers := make([]entities.SecretContainer, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		stale, err := ctr.SecretStale(secret)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, entities.SecretContainer{ID: ctr.ID(), Name: ctr.Name(), Stale: stale})
+	}
+	return containers, nil
+}
+
 func (ic *ContainerEngine) SecretList(_ context.Context, opts entities.SecretListRequest) ([]*entities.SecretInfoReport, error) {
 	manager, err := ic.Libpod.SecretsManager()
 	if err != nil {
@@ -113,6 +156,10 @@ func (ic *ContainerEngine) SecretList(_ context.Context, opts entities.SecretLis
 	}
 	report := make([]*entities.SecretInfoReport, 0, len(secretList))
 	for _, secret := range secretList {
+		// Previous versions are listed by podman secret history.
+		if _, ok := secret.Labels[define.SecretHistoryLabel]; ok && !opts.All {
+			continue
+		}
 		result, err := utils.IfPassesSecretsFilter(secret, opts.Filters)
 		if err != nil {
 			return nil, err
@@ -140,18 +187,34 @@ func (ic *ContainerEngine) SecretRm(_ context.Context, nameOrIDs []string, optio
 		if err != nil {
 			return nil, err
 		}
+		names := make(map[string]bool, len(allSecrs))
 		for _, secr := range allSecrs {
+			names[secr.Name] = true
+		}
+		for _, secr := range allSecrs {
+			// Previous versions are removed with their secret.
+			if base, ok := secr.Labels[define.SecretHistoryLabel]; ok && names[base] {
+				continue
+			}
 			toRemove = append(toRemove, secr.ID)
 		}
 	}
 	for _, nameOrID := range toRemove {
-		deletedID, err := manager.Delete(nameOrID)
+		secret, err := manager.Lookup(nameOrID)
+		if err == nil {
+			_, err = manager.Delete(secret.ID)
+		}
 		if options.Ignore && errors.Is(err, secrets.ErrNoSuchSecret) {
 			continue
 		}
-		reports = append(reports, &entities.SecretRmReport{Err: err, ID: deletedID})
-		if err == nil {
-			ic.Libpod.NewSecretEvent(events.Remove, deletedID)
+		if err != nil {
+			reports = append(reports, &entities.SecretRmReport{Err: err})
+			continue
+		}
+		reports = append(reports, &entities.SecretRmReport{ID: secret.ID})
+		ic.Libpod.NewSecretEvent(events.Remove, secret.ID)
+		if err := ic.removeSecretVersions(manager, secret.Name); err != nil {
+			logrus.Errorf("Removing previous versions of secret %s: %v", secret.Name, err)
 		}
 	}
 
@@ -190,5 +253,6 @@ func secretToReportWithData(secret secrets.Secret, data string) *entities.Secret
 			Labels: secret.Labels,
 		},
 		SecretData: data,
+		Version:    libpod.SecretVersion(secret),
 	}
 }
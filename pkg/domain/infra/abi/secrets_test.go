@@ -3,11 +3,16 @@
 package abi
 
 import (
+	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/pkg/domain/entities"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.podman.io/common/pkg/secrets"
 )
 
@@ -52,6 +57,28 @@ func Test_secretToReport(t *testing.T) {
 					Labels: map[string]string{"test-label": "test-value"},
 				},
 				SecretData: "test-secret-data",
+				Version:    1,
+			},
+		},
+		{
+			name: "test secretToReport with version",
+			args: args{
+				secret: secrets.Secret{
+					Name:     "test-name",
+					ID:       "test-id",
+					Metadata: map[string]string{"version": "3"},
+					Driver:   "test-driver",
+				},
+			},
+			want: &entities.SecretInfoReport{
+				ID: "test-id",
+				Spec: entities.SecretSpec{
+					Name: "test-name",
+					Driver: entities.SecretDriverSpec{
+						Name: "test-driver",
+					},
+				},
+				Version: 3,
 			},
 		},
 	}
@@ -61,3 +88,52 @@ func Test_secretToReport(t *testing.T) {
 		})
 	}
 }
+
+func TestSecretVersions(t *testing.T) {
+	dir := t.TempDir()
+	manager, err := secrets.NewManager(dir)
+	require.NoError(t, err)
+	driverOpts := map[string]string{"path": filepath.Join(dir, "filedriver")}
+
+	store := func(name string, labels, metadata map[string]string) {
+		_, err := manager.Store(name, []byte("data"), "file", secrets.StoreOptions{DriverOpts: driverOpts, Labels: labels, Metadata: metadata})
+		require.NoError(t, err)
+	}
+	store("s", nil, map[string]string{define.SecretVersionKey: "4"})
+	for _, version := range []int{2, 3, 1} {
+		store(libpod.SecretVersionName("s", version), map[string]string{define.SecretHistoryLabel: "s"}, map[string]string{define.SecretVersionKey: strconv.Itoa(version)})
+	}
+	// Versions of other secrets are ignored.
+	store("s2@v1", map[string]string{define.SecretHistoryLabel: "s2"}, nil)
+
+	versions, err := secretVersions(manager, "s")
+	require.NoError(t, err)
+	names := make([]string, 0, len(versions))
+	for _, version := range versions {
+		names = append(names, version.Name)
+	}
+	assert.Equal(t, []string{"s@v3", "s@v2", "s@v1"}, names)
+}
+
+func TestSecretHistoryReport(t *testing.T) {
+	changedAt := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+	report := secretHistoryReport(secrets.Secret{
+		ID:        "id",
+		Name:      "s@v3",
+		UpdatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Metadata: map[string]string{
+			define.SecretVersionKey:   "3",
+			secretChangedByKey:        "alice",
+			define.SecretChangedAtKey: changedAt.Format(time.RFC3339Nano),
+			secretRollbackKey:         "1",
+		},
+	})
+	assert.Equal(t, &entities.SecretHistoryReport{
+		ID:         "id",
+		Name:       "s@v3",
+		Version:    3,
+		ChangedBy:  "alice",
+		ChangedAt:  changedAt,
+		RollbackTo: 1,
+	}, report)
+}
@@ -0,0 +1,260 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/user"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/libpod/events"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/rootless"
+	"github.com/containers/podman/v6/pkg/signal"
+	"github.com/google/shlex"
+	"go.podman.io/common/pkg/secrets"
+)
+
+// Metadata keys of secret versions, in addition to the ones of define.
+const (
+	// secretChangedByKey is the user who set the version of a secret.
+	secretChangedByKey = "changedBy"
+	// secretRollbackKey is the version a secret was rolled back to.
+	secretRollbackKey = "rollbackTo"
+)
+
+func (ic *ContainerEngine) SecretUpdate(_ context.Context, nameOrID string, reader io.Reader, options entities.SecretUpdateOptions) (*entities.SecretUpdateReport, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := manager.Lookup(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if base, ok := secret.Labels[define.SecretHistoryLabel]; ok {
+		return nil, fmt.Errorf("%s is a previous version of secret %s, it cannot be updated", secret.Name, base)
+	}
+	return ic.updateSecret(manager, secret, data, options, nil)
+}
+
+func (ic *ContainerEngine) SecretRollback(_ context.Context, nameOrID string, options entities.SecretRollbackOptions) (*entities.SecretUpdateReport, error) {
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := manager.Lookup(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if base, ok := secret.Labels[define.SecretHistoryLabel]; ok {
+		return nil, fmt.Errorf("%s is a previous version of secret %s, it cannot be rolled back", secret.Name, base)
+	}
+	if options.Version == libpod.SecretVersion(*secret) {
+		return nil, fmt.Errorf("secret %s is at version %d already", secret.Name, options.Version)
+	}
+	_, data, err := manager.LookupSecretData(libpod.SecretVersionName(secret.Name, options.Version))
+	if err != nil {
+		return nil, fmt.Errorf("looking up version %d of secret %s: %w", options.Version, secret.Name, err)
+	}
+	metadata := map[string]string{secretRollbackKey: strconv.Itoa(options.Version)}
+	return ic.updateSecret(manager, secret, data, options.SecretUpdateOptions, metadata)
+}
+
+func (ic *ContainerEngine) SecretHistory(_ context.Context, nameOrID string) ([]*entities.SecretHistoryReport, error) {
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := manager.Lookup(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if base, ok := secret.Labels[define.SecretHistoryLabel]; ok {
+		if secret, err = manager.Lookup(base); err != nil {
+			return nil, err
+		}
+	}
+	versions, err := secretVersions(manager, secret.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	current := secretHistoryReport(*secret)
+	current.Current = true
+	current.ChangedAt = secret.UpdatedAt
+	reports := []*entities.SecretHistoryReport{current}
+	for _, version := range versions {
+		reports = append(reports, secretHistoryReport(version))
+	}
+	return reports, nil
+}
+
+// updateSecret stores the new data of the secret as its next version, keeping
+// the current version as a previous version, and updates the secret in the
+// containers using it.
+func (ic *ContainerEngine) updateSecret(manager *secrets.SecretsManager, secret *secrets.Secret, data []byte, options entities.SecretUpdateOptions, metadata map[string]string) (*entities.SecretUpdateReport, error) {
+	var reload libpod.SecretReload
+	if options.ReloadSignal != "" {
+		sig, err := signal.ParseSignalNameOrNumber(options.ReloadSignal)
+		if err != nil {
+			return nil, err
+		}
+		reload.Signal = uint(sig)
+	}
+	if options.ReloadExec != "" {
+		cmd, err := shlex.Split(options.ReloadExec)
+		if err != nil {
+			return nil, fmt.Errorf("parsing reload command %q: %w", options.ReloadExec, err)
+		}
+		reload.Command = cmd
+	}
+
+	if err := ic.archiveSecret(secret, options.KeepVersions); err != nil {
+		return nil, err
+	}
+	version := libpod.SecretVersion(*secret) + 1
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	metadata[define.SecretVersionKey] = strconv.Itoa(version)
+	metadata[secretChangedByKey] = secretAuthor()
+	storeOpts := secrets.StoreOptions{
+		DriverOpts: secret.DriverOptions,
+		Labels:     secret.Labels,
+		Metadata:   metadata,
+		Replace:    true,
+	}
+	if _, err := manager.Store(secret.Name, data, secret.Driver, storeOpts); err != nil {
+		return nil, err
+	}
+	secret, err := manager.Lookup(secret.Name)
+	if err != nil {
+		return nil, err
+	}
+	ic.Libpod.NewSecretEvent(events.Update, secret.ID)
+	if err := ic.pruneSecretVersions(manager, secret.Name, options.KeepVersions); err != nil {
+		return nil, err
+	}
+
+	report := &entities.SecretUpdateReport{ID: secret.ID, Version: version}
+	ctrs, err := ic.Libpod.GetContainers(false, func(c *libpod.Container) bool {
+		return c.UsesSecret(secret.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, ctr := range ctrs {
+		ctrReport := entities.SecretUpdateContainerReport{ID: ctr.ID(), Name: ctr.Name()}
+		if err := ctr.UpdateSecret(secret, data, reload); err != nil {
+			ctrReport.Error = err.Error()
+		}
+		report.Containers = append(report.Containers, ctrReport)
+	}
+	return report, nil
+}
+
+// archiveSecret stores the current version of the secret as a previous
+// version named NAME@vVERSION, unless no previous versions are kept.
+func (ic *ContainerEngine) archiveSecret(secret *secrets.Secret, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	return ic.Libpod.ArchiveSecret(secret)
+}
+
+// pruneSecretVersions removes the previous versions of the secret except for
+// the newest keep ones.  Versions used by containers are not removed.
+func (ic *ContainerEngine) pruneSecretVersions(manager *secrets.SecretsManager, name string, keep int) error {
+	versions, err := secretVersions(manager, name)
+	if err != nil {
+		return err
+	}
+	for _, version := range versions[min(max(keep, 0), len(versions)):] {
+		used, err := ic.Libpod.GetContainers(false, func(c *libpod.Container) bool {
+			return c.UsesSecret(version.Name)
+		})
+		if err != nil {
+			return err
+		}
+		if len(used) > 0 {
+			continue
+		}
+		if _, err := manager.Delete(version.ID); err != nil {
+			return fmt.Errorf("removing version %d of secret %s: %w", libpod.SecretVersion(version), name, err)
+		}
+		ic.Libpod.NewSecretEvent(events.Remove, version.ID)
+	}
+	return nil
+}
+
+// removeSecretVersions removes all previous versions of the secret.
+// Containers which pinned a version keep their copy of it.
+func (ic *ContainerEngine) removeSecretVersions(manager *secrets.SecretsManager, name string) error {
+	versions, err := secretVersions(manager, name)
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if _, err := manager.Delete(version.ID); err != nil {
+			return fmt.Errorf("removing version %d of secret %s: %w", libpod.SecretVersion(version), name, err)
+		}
+		ic.Libpod.NewSecretEvent(events.Remove, version.ID)
+	}
+	return nil
+}
+
+// secretVersions returns the previous versions of the secret, newest first.
+func secretVersions(manager *secrets.SecretsManager, name string) ([]secrets.Secret, error) {
+	all, err := manager.List()
+	if err != nil {
+		return nil, err
+	}
+	var versions []secrets.Secret
+	for _, secret := range all {
+		if secret.Labels[define.SecretHistoryLabel] == name {
+			versions = append(versions, secret)
+		}
+	}
+	slices.SortFunc(versions, func(a, b secrets.Secret) int {
+		return libpod.SecretVersion(b) - libpod.SecretVersion(a)
+	})
+	return versions, nil
+}
+
+// secretAuthor returns the name of the user changing a secret.
+func secretAuthor() string {
+	uid := strconv.Itoa(rootless.GetRootlessUID())
+	if u, err := user.LookupId(uid); err == nil {
+		return u.Username
+	}
+	return uid
+}
+
+func secretHistoryReport(secret secrets.Secret) *entities.SecretHistoryReport {
+	report := &entities.SecretHistoryReport{
+		ID:        secret.ID,
+		Name:      secret.Name,
+		Version:   libpod.SecretVersion(secret),
+		ChangedBy: secret.Metadata[secretChangedByKey],
+	}
+	if changedAt, err := time.Parse(time.RFC3339Nano, secret.Metadata[define.SecretChangedAtKey]); err == nil {
+		report.ChangedAt = changedAt
+	} else {
+		report.ChangedAt = secret.UpdatedAt
+	}
+	if to, err := strconv.Atoi(secret.Metadata[secretRollbackKey]); err == nil {
+		report.RollbackTo = to
+	}
+	return report
+}
@@ -0,0 +1,210 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/asciicast"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+)
+
+const recordingExt = ".cast"
+
+// SessionList lists the recordings of exec and attach sessions.
+func (ic *ContainerEngine) SessionList(_ context.Context, options entities.SessionListOptions) ([]*entities.SessionListReport, error) {
+	recordings, err := ic.sessionRecordings()
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.SessionListReport, 0, len(recordings))
+	for _, r := range recordings {
+		match, err := sessionMatchesFilters(r, options.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			reports = append(reports, r)
+		}
+	}
+	return reports, nil
+}
+
+// SessionReplay writes the output of a session recording with its timing.
+func (ic *ContainerEngine) SessionReplay(ctx context.Context, idOrPath string, options entities.SessionReplayOptions) error {
+	path, err := ic.lookupSessionRecording(idOrPath)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return asciicast.Replay(f, options.OutputStream, asciicast.ReplayOptions{Speed: options.Speed, IdleLimit: options.IdleLimit}, ctx.Done())
+}
+
+// SessionRm removes session recordings.
+func (ic *ContainerEngine) SessionRm(_ context.Context, ids []string, options entities.SessionRmOptions) ([]*entities.SessionRmReport, error) {
+	if options.All {
+		recordings, err := ic.sessionRecordings()
+		if err != nil {
+			return nil, err
+		}
+		ids = make([]string, 0, len(recordings))
+		for _, r := range recordings {
+			ids = append(ids, r.ID)
+		}
+	}
+	reports := make([]*entities.SessionRmReport, 0, len(ids))
+	for _, id := range ids {
+		report := &entities.SessionRmReport{ID: id}
+		path, err := ic.lookupSessionRecording(id)
+		if err == nil {
+			if filepath.Dir(path) != ic.Libpod.SessionRecordingsDir() {
+				err = fmt.Errorf("%s is not a session recording of Podman: %w", id, define.ErrInvalidArg)
+			} else {
+				report.ID = strings.TrimSuffix(filepath.Base(path), recordingExt)
+				err = os.Remove(path)
+			}
+		}
+		report.Err = err
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// sessionRecordings returns all session recordings sorted by their creation
+// time.  Unreadable recordings are skipped.
+func (ic *ContainerEngine) sessionRecordings() ([]*entities.SessionListReport, error) {
+	dir := ic.Libpod.SessionRecordingsDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading session recordings: %w", err)
+	}
+	recordings := make([]*entities.SessionListReport, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != recordingExt {
+			continue
+		}
+		r, err := readSessionRecording(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			logrus.Warnf("Skipping session recording %s: %v", entry.Name(), err)
+			continue
+		}
+		recordings = append(recordings, r)
+	}
+	sort.SliceStable(recordings, func(i, j int) bool {
+		return recordings[i].Created.Before(recordings[j].Created)
+	})
+	return recordings, nil
+}
+
+func readSessionRecording(path string) (*entities.SessionListReport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	header, duration, err := asciicast.Duration(f)
+	if err != nil {
+		return nil, err
+	}
+	r := &entities.SessionListReport{
+		ID:       strings.TrimSuffix(filepath.Base(path), recordingExt),
+		Command:  header.Command,
+		Created:  time.Unix(header.Timestamp, 0),
+		Duration: duration,
+		Width:    header.Width,
+		Height:   header.Height,
+		Path:     path,
+	}
+	if s := header.Session; s != nil {
+		r.Type = s.Type
+		r.ContainerID = s.ContainerID
+		r.ContainerName = s.ContainerName
+		r.ExecID = s.ExecID
+		r.User = s.User
+		r.UID = s.UID
+		r.SudoUser = s.SudoUser
+	}
+	return r, nil
+}
+
+// lookupSessionRecording returns the path of the recording with the given
+// ID or unique ID prefix.  idOrPath may also be the path of a recording.
+func (ic *ContainerEngine) lookupSessionRecording(idOrPath string) (string, error) {
+	if strings.ContainsRune(idOrPath, filepath.Separator) || strings.HasSuffix(idOrPath, recordingExt) {
+		if _, err := os.Stat(idOrPath); err != nil {
+			return "", err
+		}
+		return idOrPath, nil
+	}
+	dir := ic.Libpod.SessionRecordingsDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("reading session recordings: %w", err)
+	}
+	var matches []string
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), recordingExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if id == idOrPath {
+			return filepath.Join(dir, entry.Name()), nil
+		}
+		if idOrPath != "" && strings.HasPrefix(id, idOrPath) {
+			matches = append(matches, entry.Name())
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no session recording with ID %q found: %w", idOrPath, define.ErrNoSuchSessionRecording)
+	case 1:
+		return filepath.Join(dir, matches[0]), nil
+	default:
+		return "", fmt.Errorf("more than one session recording matches %q: %w", idOrPath, define.ErrInvalidArg)
+	}
+}
+
+// sessionMatchesFilters returns whether the recording matches the filters.
+// Values of the same filter are ORed, different filters are ANDed.
+func sessionMatchesFilters(r *entities.SessionListReport, filters map[string][]string) (bool, error) {
+	for key, values := range filters {
+		var match func(string) bool
+		switch key {
+		case "container":
+			match = func(v string) bool {
+				return v == r.ContainerName || (v != "" && strings.HasPrefix(r.ContainerID, v))
+			}
+		case "type":
+			match = func(v string) bool { return v == r.Type }
+		case "user":
+			match = func(v string) bool { return v == r.User || v == strconv.Itoa(r.UID) }
+		case "exec":
+			match = func(v string) bool { return v != "" && strings.HasPrefix(r.ExecID, v) }
+		default:
+			return false, fmt.Errorf("invalid session filter %q", key)
+		}
+		if !slices.ContainsFunc(values, match) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,929 @@
+//go:build !remote
+
+package abi
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/libpod/define"
+	ann "github.com/containers/podman/v6/pkg/annotations"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libimage"
+	nettypes "go.podman.io/common/libnetwork/types"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/common/pkg/secrets"
+	"go.podman.io/storage/pkg/stringid"
+)
+
+const (
+	// backupVersion is bumped whenever the archive layout changes in a
+	// way older versions of restore cannot handle.
+	backupVersion      = 1
+	backupManifestFile = "backup.json"
+	backupVolumesDir   = "volumes"
+
+	restoreStatusCreated  = "created"
+	restoreStatusSkipped  = "skipped"
+	restoreStatusReplaced = "replaced"
+	restoreStatusPulled   = "pulled"
+	restoreStatusFailed   = "failed"
+)
+
+// backupManifest is stored as backup.json at the root of a backup archive.
+type backupManifest struct {
+	Version    int                       `json:"version"`
+	Created    time.Time                 `json:"created"`
+	Containers []*libpod.ContainerConfig `json:"containers"`
+	Pods       []*backupPod              `json:"pods"`
+	Networks   []nettypes.Network        `json:"networks"`
+	Volumes    []*backupVolume           `json:"volumes"`
+	Secrets    []*secrets.Secret         `json:"secrets"`
+	Images     []*backupImage            `json:"images"`
+}
+
+type backupPod struct {
+	Config           *libpod.PodConfig `json:"config"`
+	InfraContainerID string            `json:"infraContainerID,omitempty"`
+}
+
+type backupVolume struct {
+	Config *libpod.VolumeConfig `json:"config"`
+	// HasData is set if the volume contents are stored in the archive as
+	// volumes/<name>.tar.
+	HasData bool `json:"hasData,omitempty"`
+}
+
+type backupImage struct {
+	Name string `json:"name,omitempty"`
+	ID   string `json:"id"`
+}
+
+// SystemBackup writes the configuration of all containers, pods, networks,
+// volumes and secrets, as well as the images they need, to an archive that
+// can be fed to SystemRestore on this or another host.
+func (ic *ContainerEngine) SystemBackup(_ context.Context, options entities.SystemBackupOptions) (_ *entities.SystemBackupReport, retErr error) {
+	report := new(entities.SystemBackupReport)
+	manifest := &backupManifest{
+		Version: backupVersion,
+		Created: time.Now(),
+	}
+
+	ctrs, err := ic.Libpod.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	images := make(map[string]*backupImage)
+	for _, ctr := range ctrs {
+		// Service containers belong to kube play and are recreated by it.
+		if ctr.IsService() {
+			continue
+		}
+		conf := ctr.Config()
+		if conf == nil {
+			return nil, fmt.Errorf("retrieving config of container %s: %w", ctr.ID(), define.ErrInternal)
+		}
+		manifest.Containers = append(manifest.Containers, conf)
+		report.Containers = append(report.Containers, conf.Name)
+		if conf.RootfsImageID != "" {
+			if _, ok := images[conf.RootfsImageID]; !ok {
+				images[conf.RootfsImageID] = &backupImage{Name: conf.RootfsImageName, ID: conf.RootfsImageID}
+			}
+		}
+	}
+	for _, img := range images {
+		manifest.Images = append(manifest.Images, img)
+		name := img.Name
+		if name == "" {
+			name = img.ID
+		}
+		report.Images = append(report.Images, name)
+	}
+
+	pods, err := ic.Libpod.GetAllPods()
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		conf, err := pod.Config()
+		if err != nil {
+			return nil, fmt.Errorf("retrieving config of pod %s: %w", pod.ID(), err)
+		}
+		bp := &backupPod{Config: conf}
+		if pod.HasInfraContainer() {
+			if bp.InfraContainerID, err = pod.InfraContainerID(); err != nil {
+				return nil, err
+			}
+		}
+		manifest.Pods = append(manifest.Pods, bp)
+		report.Pods = append(report.Pods, conf.Name)
+	}
+
+	networks, err := ic.Libpod.Network().NetworkList()
+	if err != nil {
+		return nil, err
+	}
+	for _, net := range networks {
+		// The default network is created automatically on every host.
+		if net.Name == ic.Libpod.GetDefaultNetworkName() {
+			continue
+		}
+		manifest.Networks = append(manifest.Networks, net)
+		report.Networks = append(report.Networks, net.Name)
+	}
+
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	secretList, err := manager.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range secretList {
+		manifest.Secrets = append(manifest.Secrets, &secretList[i])
+		report.Secrets = append(report.Secrets, secretList[i].Name)
+	}
+
+	vols, err := ic.Libpod.GetAllVolumes()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := os.Create(options.Output)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := out.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+		if retErr != nil {
+			if err := os.Remove(options.Output); err != nil {
+				logrus.Errorf("Removing incomplete backup archive %s: %v", options.Output, err)
+			}
+		}
+	}()
+	tw := tar.NewWriter(out)
+
+	var volumeData []*libpod.Volume
+	for _, vol := range vols {
+		conf, err := vol.Config()
+		if err != nil {
+			return nil, fmt.Errorf("retrieving config of volume %s: %w", vol.Name(), err)
+		}
+		bv := &backupVolume{Config: conf}
+		if options.VolumeData && conf.Driver == define.VolumeDriverLocal {
+			bv.HasData = true
+			volumeData = append(volumeData, vol)
+		}
+		manifest.Volumes = append(manifest.Volumes, bv)
+		report.Volumes = append(report.Volumes, conf.Name)
+	}
+
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeBackupEntry(tw, backupManifestFile, manifest.Created, int64(len(b)), strings.NewReader(string(b))); err != nil {
+		return nil, err
+	}
+
+	for _, vol := range volumeData {
+		if err := writeBackupVolumeData(tw, vol); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func writeBackupEntry(tw *tar.Writer, name string, modTime time.Time, size int64, content io.Reader) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    size,
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tw, content); err != nil {
+		return fmt.Errorf("writing %s to backup archive: %w", name, err)
+	}
+	return nil
+}
+
+// writeBackupVolumeData stores the contents of a volume as a nested tarball.
+// The size of a tar entry must be known up front, so the volume is exported
+// to a temporary file first.
+func writeBackupVolumeData(tw *tar.Writer, vol *libpod.Volume) error {
+	tmp, err := os.CreateTemp("", "podman-backup-volume")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	contents, err := vol.Export()
+	if err != nil {
+		return fmt.Errorf("exporting volume %s: %w", vol.Name(), err)
+	}
+	size, err := io.Copy(tmp, contents)
+	contents.Close()
+	if err != nil {
+		return fmt.Errorf("exporting volume %s: %w", vol.Name(), err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return writeBackupEntry(tw, filepath.Join(backupVolumesDir, vol.Name()+".tar"), time.Now(), size, tmp)
+}
+
+// readBackupArchive extracts a backup archive into dir and returns its
+// manifest. Only the manifest and volume tarballs are extracted, anything
+// else in the archive is ignored.
+func readBackupArchive(input, dir string) (*backupManifest, error) {
+	f, err := os.Open(input)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var manifest *backupManifest
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading backup archive %s: %w", input, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := filepath.Clean(hdr.Name)
+		switch {
+		case name == backupManifestFile:
+			manifest = new(backupManifest)
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", backupManifestFile, err)
+			}
+		case filepath.Dir(name) == backupVolumesDir && strings.HasSuffix(name, ".tar"):
+			out, err := os.Create(filepath.Join(dir, filepath.Base(name)))
+			if err != nil {
+				return nil, err
+			}
+			_, err = io.Copy(out, tr)
+			out.Close()
+			if err != nil {
+				return nil, fmt.Errorf("extracting %s: %w", name, err)
+			}
+		default:
+			logrus.Debugf("Ignoring unknown entry %s in backup archive", hdr.Name)
+		}
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("%s is not a podman backup archive: %s is missing", input, backupManifestFile)
+	}
+	if manifest.Version > backupVersion {
+		return nil, fmt.Errorf("backup archive version %d is not supported, this version of podman supports up to version %d", manifest.Version, backupVersion)
+	}
+	return manifest, nil
+}
+
+// containerConfigDependencies returns the IDs of all containers the given
+// container config depends on, including namespace containers.
+func containerConfigDependencies(conf *libpod.ContainerConfig) []string {
+	deps := []string{conf.IPCNsCtr, conf.MountNsCtr, conf.NetNsCtr, conf.PIDNsCtr, conf.UserNsCtr, conf.UTSNsCtr, conf.CgroupNsCtr}
+	deps = append(deps, conf.Dependencies...)
+	deps = slices.DeleteFunc(deps, func(id string) bool { return id == "" })
+	slices.Sort(deps)
+	return slices.Compact(deps)
+}
+
+// sortContainerConfigs orders the given container configs so that every
+// container comes after the containers it depends on. Dependencies on
+// containers that are not part of the list are ignored.
+func sortContainerConfigs(confs []*libpod.ContainerConfig) ([]*libpod.ContainerConfig, error) {
+	byID := make(map[string]*libpod.ContainerConfig, len(confs))
+	for _, conf := range confs {
+		byID[conf.ID] = conf
+	}
+
+	sorted := make([]*libpod.ContainerConfig, 0, len(confs))
+	// 0 = not visited, 1 = in progress, 2 = done
+	visited := make(map[string]int, len(confs))
+	var visit func(conf *libpod.ContainerConfig) error
+	visit = func(conf *libpod.ContainerConfig) error {
+		switch visited[conf.ID] {
+		case 1:
+			return fmt.Errorf("dependency cycle detected involving container %s", conf.Name)
+		case 2:
+			return nil
+		}
+		visited[conf.ID] = 1
+		for _, dep := range containerConfigDependencies(conf) {
+			if depConf, ok := byID[dep]; ok {
+				if err := visit(depConf); err != nil {
+					return err
+				}
+			}
+		}
+		visited[conf.ID] = 2
+		sorted = append(sorted, conf)
+		return nil
+	}
+
+	// Visit in name order so the result does not depend on the order of
+	// the input.
+	ordered := slices.Clone(confs)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Name < ordered[j].Name })
+	for _, conf := range ordered {
+		if err := visit(conf); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// remapContainerConfig rewrites all container and pod IDs referenced by the
+// given container config according to the given maps.
+func remapContainerConfig(conf *libpod.ContainerConfig, ctrIDs, podIDs map[string]string) {
+	remap := func(id string) string {
+		if newID, ok := ctrIDs[id]; ok {
+			return newID
+		}
+		return id
+	}
+	conf.ID = remap(conf.ID)
+	conf.IPCNsCtr = remap(conf.IPCNsCtr)
+	conf.MountNsCtr = remap(conf.MountNsCtr)
+	conf.NetNsCtr = remap(conf.NetNsCtr)
+	conf.PIDNsCtr = remap(conf.PIDNsCtr)
+	conf.UserNsCtr = remap(conf.UserNsCtr)
+	conf.UTSNsCtr = remap(conf.UTSNsCtr)
+	conf.CgroupNsCtr = remap(conf.CgroupNsCtr)
+	for i, dep := range conf.Dependencies {
+		conf.Dependencies[i] = remap(dep)
+	}
+	if newID, ok := podIDs[conf.Pod]; ok && conf.Pod != "" {
+		conf.Pod = newID
+		if conf.Spec != nil && conf.Spec.Annotations != nil {
+			if _, ok := conf.Spec.Annotations[ann.SandboxID]; ok {
+				conf.Spec.Annotations[ann.SandboxID] = newID
+			}
+		}
+	}
+}
+
+// backupRestorer keeps the state of a single SystemRestore call.
+type backupRestorer struct {
+	ic       *ContainerEngine
+	options  entities.SystemRestoreOptions
+	manifest *backupManifest
+	dataDir  string
+	report   *entities.SystemRestoreReport
+
+	// ctrIDs and podIDs map the IDs in the archive to the IDs used on this
+	// host.
+	ctrIDs map[string]string
+	podIDs map[string]string
+	// failedCtrs and failedPods hold the archive IDs of objects that could
+	// not be restored, so that everything depending on them is skipped.
+	failedCtrs map[string]error
+	failedPods map[string]error
+	// skippedCtrs and skippedPods hold the archive IDs of objects that were
+	// skipped because they already exist.
+	skippedCtrs map[string]bool
+	skippedPods map[string]bool
+	// imageIDs maps image names from the archive to the local image ID.
+	imageIDs map[string]string
+}
+
+func (r *backupRestorer) addEntry(kind, name, id, status string, err error) {
+	if err != nil {
+		status = restoreStatusFailed
+	}
+	r.report.Entries = append(r.report.Entries, &entities.SystemRestoreEntry{
+		Kind:   kind,
+		Name:   name,
+		ID:     id,
+		Status: status,
+		Err:    err,
+	})
+}
+
+// SystemRestore re-creates the networks, volumes, pods and containers stored
+// in a backup archive written by SystemBackup. Missing images are pulled.
+func (ic *ContainerEngine) SystemRestore(ctx context.Context, options entities.SystemRestoreOptions) (*entities.SystemRestoreReport, error) {
+	switch options.OnConflict {
+	case "":
+		options.OnConflict = entities.RestoreConflictFail
+	case entities.RestoreConflictFail, entities.RestoreConflictSkip, entities.RestoreConflictReplace:
+	default:
+		return nil, fmt.Errorf("invalid conflict policy %q, must be one of %s, %s or %s: %w", options.OnConflict,
+			entities.RestoreConflictFail, entities.RestoreConflictSkip, entities.RestoreConflictReplace, define.ErrInvalidArg)
+	}
+
+	dir, err := os.MkdirTemp("", "podman-restore")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logrus.Errorf("Could not recursively remove %s: %q", dir, err)
+		}
+	}()
+
+	manifest, err := readBackupArchive(options.Input, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &backupRestorer{
+		ic:          ic,
+		options:     options,
+		manifest:    manifest,
+		dataDir:     dir,
+		report:      new(entities.SystemRestoreReport),
+		ctrIDs:      make(map[string]string),
+		podIDs:      make(map[string]string),
+		failedCtrs:  make(map[string]error),
+		failedPods:  make(map[string]error),
+		skippedCtrs: make(map[string]bool),
+		skippedPods: make(map[string]bool),
+		imageIDs:    make(map[string]string),
+	}
+	if err := r.prepare(ctx); err != nil {
+		return nil, err
+	}
+	r.restoreNetworks(ctx)
+	r.restoreVolumes(ctx)
+	r.restoreSecrets()
+	r.pullImages(ctx)
+	r.restorePods(ctx)
+	if err := r.restoreContainers(ctx); err != nil {
+		return r.report, err
+	}
+	r.restoreVolumeData()
+	return r.report, nil
+}
+
+// prepare assigns the IDs and names to use on this host and deals with
+// objects that already exist according to the conflict policy.
+func (r *backupRestorer) prepare(ctx context.Context) error {
+	rt := r.ic.Libpod
+	for _, pod := range r.manifest.Pods {
+		newID := pod.Config.ID
+		if !r.options.KeepIDs {
+			newID = stringid.GenerateRandomID()
+		}
+		r.podIDs[pod.Config.ID] = newID
+	}
+	for _, conf := range r.manifest.Containers {
+		newID := conf.ID
+		if !r.options.KeepIDs {
+			newID = stringid.GenerateRandomID()
+		}
+		r.ctrIDs[conf.ID] = newID
+	}
+
+	var conflicts []string
+	for _, conf := range r.manifest.Containers {
+		ctr, err := r.lookupExistingContainer(conf)
+		if err != nil {
+			return err
+		}
+		if ctr == nil {
+			continue
+		}
+		// Infra containers are handled together with their pod.
+		if conf.IsInfra {
+			continue
+		}
+		switch r.options.OnConflict {
+		case entities.RestoreConflictFail:
+			conflicts = append(conflicts, fmt.Sprintf("container %s", conf.Name))
+		case entities.RestoreConflictSkip:
+			r.ctrIDs[conf.ID] = ctr.ID()
+			r.skippedCtrs[conf.ID] = true
+			r.addEntry("container", conf.Name, ctr.ID(), restoreStatusSkipped, nil)
+		case entities.RestoreConflictReplace:
+			if err := rt.RemoveContainer(ctx, ctr, true, false, nil); err != nil && !errors.Is(err, define.ErrNoSuchCtr) {
+				return fmt.Errorf("replacing container %s: %w", conf.Name, err)
+			}
+		}
+	}
+	for _, bp := range r.manifest.Pods {
+		pod, err := r.lookupExistingPod(bp.Config)
+		if err != nil {
+			return err
+		}
+		if pod == nil {
+			continue
+		}
+		switch r.options.OnConflict {
+		case entities.RestoreConflictFail:
+			conflicts = append(conflicts, fmt.Sprintf("pod %s", bp.Config.Name))
+		case entities.RestoreConflictSkip:
+			r.podIDs[bp.Config.ID] = pod.ID()
+			r.skippedPods[bp.Config.ID] = true
+			if bp.InfraContainerID != "" {
+				infraID, err := pod.InfraContainerID()
+				if err != nil {
+					return err
+				}
+				r.ctrIDs[bp.InfraContainerID] = infraID
+				r.skippedCtrs[bp.InfraContainerID] = true
+			}
+			r.addEntry("pod", bp.Config.Name, pod.ID(), restoreStatusSkipped, nil)
+		case entities.RestoreConflictReplace:
+			if _, err := rt.RemovePod(ctx, pod, true, true, nil); err != nil && !errors.Is(err, define.ErrNoSuchPod) {
+				return fmt.Errorf("replacing pod %s: %w", bp.Config.Name, err)
+			}
+		}
+	}
+	if r.options.OnConflict == entities.RestoreConflictFail {
+		for _, bv := range r.manifest.Volumes {
+			if ok, err := rt.HasVolume(bv.Config.Name); err != nil {
+				return err
+			} else if ok {
+				conflicts = append(conflicts, fmt.Sprintf("volume %s", bv.Config.Name))
+			}
+		}
+		for _, net := range r.manifest.Networks {
+			if _, err := rt.Network().NetworkInspect(net.Name); err == nil {
+				conflicts = append(conflicts, fmt.Sprintf("network %s", net.Name))
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("the following objects already exist: %s; use --on-conflict=skip or --on-conflict=replace: %w",
+			strings.Join(conflicts, ", "), define.ErrInvalidArg)
+	}
+	return nil
+}
+
+// lookupExistingContainer returns the local container that conflicts with
+// the given config, or nil if there is none.
+func (r *backupRestorer) lookupExistingContainer(conf *libpod.ContainerConfig) (*libpod.Container, error) {
+	if r.options.KeepIDs {
+		ctr, err := r.ic.Libpod.GetContainer(conf.ID)
+		if err == nil {
+			return ctr, nil
+		}
+		if !errors.Is(err, define.ErrNoSuchCtr) {
+			return nil, err
+		}
+	}
+	if r.options.KeepNames {
+		ctr, err := r.ic.Libpod.LookupContainer(conf.Name)
+		if err == nil {
+			return ctr, nil
+		}
+		if !errors.Is(err, define.ErrNoSuchCtr) {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// lookupExistingPod returns the local pod that conflicts with the given
+// config, or nil if there is none.
+func (r *backupRestorer) lookupExistingPod(conf *libpod.PodConfig) (*libpod.Pod, error) {
+	if r.options.KeepIDs {
+		pod, err := r.ic.Libpod.GetPod(conf.ID)
+		if err == nil {
+			return pod, nil
+		}
+		if !errors.Is(err, define.ErrNoSuchPod) {
+			return nil, err
+		}
+	}
+	if r.options.KeepNames {
+		pod, err := r.ic.Libpod.LookupPod(conf.Name)
+		if err == nil {
+			return pod, nil
+		}
+		if !errors.Is(err, define.ErrNoSuchPod) {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (r *backupRestorer) restoreNetworks(ctx context.Context) {
+	for _, net := range r.manifest.Networks {
+		status := restoreStatusCreated
+		if _, err := r.ic.Libpod.Network().NetworkInspect(net.Name); err == nil {
+			if r.options.OnConflict == entities.RestoreConflictSkip {
+				r.addEntry("network", net.Name, "", restoreStatusSkipped, nil)
+				continue
+			}
+			// Networks in use are not removed, we do not want to
+			// take down unrelated containers.
+			if _, err := r.ic.NetworkRm(ctx, []string{net.Name}, entities.NetworkRmOptions{}); err != nil {
+				r.addEntry("network", net.Name, "", "", fmt.Errorf("replacing network %s: %w", net.Name, err))
+				continue
+			}
+			status = restoreStatusReplaced
+		}
+		net.ID = ""
+		created, err := r.ic.NetworkCreate(ctx, net, &nettypes.NetworkCreateOptions{})
+		if err != nil {
+			r.addEntry("network", net.Name, "", "", fmt.Errorf("creating network %s: %w", net.Name, err))
+			continue
+		}
+		r.addEntry("network", created.Name, created.ID, status, nil)
+	}
+}
+
+// restoreVolumes creates all named volumes. Anonymous volumes are created
+// together with the container that uses them.
+func (r *backupRestorer) restoreVolumes(ctx context.Context) {
+	for _, bv := range r.manifest.Volumes {
+		conf := bv.Config
+		if conf.IsAnon {
+			continue
+		}
+		status := restoreStatusCreated
+		if vol, err := r.ic.Libpod.LookupVolume(conf.Name); err == nil {
+			if r.options.OnConflict == entities.RestoreConflictSkip {
+				bv.HasData = false
+				r.addEntry("volume", conf.Name, "", restoreStatusSkipped, nil)
+				continue
+			}
+			if err := r.ic.Libpod.RemoveVolume(ctx, vol, false, nil); err != nil {
+				bv.HasData = false
+				r.addEntry("volume", conf.Name, "", "", fmt.Errorf("replacing volume %s: %w", conf.Name, err))
+				continue
+			}
+			status = restoreStatusReplaced
+		}
+		opts := entities.VolumeCreateOptions{
+			Name:    conf.Name,
+			Driver:  conf.Driver,
+			Label:   conf.Labels,
+			Options: conf.Options,
+		}
+		if _, err := r.ic.VolumeCreate(ctx, opts); err != nil {
+			bv.HasData = false
+			r.addEntry("volume", conf.Name, "", "", fmt.Errorf("creating volume %s: %w", conf.Name, err))
+			continue
+		}
+		r.addEntry("volume", conf.Name, "", status, nil)
+	}
+}
+
+// restoreSecrets checks that all secrets in the archive are available. The
+// archive only holds secret metadata, the secret data must be created on this
+// host before restoring.
+func (r *backupRestorer) restoreSecrets() {
+	manager, err := r.ic.Libpod.SecretsManager()
+	if err != nil {
+		r.addEntry("secret", "", "", "", err)
+		return
+	}
+	for _, secret := range r.manifest.Secrets {
+		local, err := manager.Lookup(secret.Name)
+		if err != nil {
+			r.addEntry("secret", secret.Name, "", "", fmt.Errorf("secret %s does not exist, the backup does not contain secret data: create it before restoring: %w", secret.Name, err))
+			continue
+		}
+		r.addEntry("secret", secret.Name, local.ID, restoreStatusSkipped, nil)
+	}
+}
+
+// resolveSecrets points the secrets of a container config to the local
+// secrets of the same name.
+func (r *backupRestorer) resolveSecrets(conf *libpod.ContainerConfig) error {
+	if len(conf.Secrets) == 0 && len(conf.EnvSecrets) == 0 {
+		return nil
+	}
+	manager, err := r.ic.Libpod.SecretsManager()
+	if err != nil {
+		return err
+	}
+	lookup := func(name string) (*secrets.Secret, error) {
+		secret, err := manager.Lookup(name)
+		if err != nil {
+			return nil, fmt.Errorf("looking up secret %s: %w", name, err)
+		}
+		return secret, nil
+	}
+	for _, ctrSecret := range conf.Secrets {
+		if ctrSecret.Secret == nil {
+			continue
+		}
+		if ctrSecret.Secret, err = lookup(ctrSecret.Name); err != nil {
+			return err
+		}
+	}
+	for key, secret := range conf.EnvSecrets {
+		if conf.EnvSecrets[key], err = lookup(secret.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *backupRestorer) pullImages(ctx context.Context) {
+	libimageRuntime := r.ic.Libpod.LibimageRuntime()
+	for _, img := range r.manifest.Images {
+		if img.Name == "" {
+			// Without a name the image cannot be pulled, it
+			// must be present already.
+			if _, _, err := libimageRuntime.LookupImage(img.ID, nil); err != nil {
+				r.addEntry("image", img.ID, img.ID, "", fmt.Errorf("image %s is not present and has no name to pull it by: %w", img.ID, err))
+				continue
+			}
+			r.imageIDs[img.ID] = img.ID
+			r.addEntry("image", img.ID, img.ID, restoreStatusSkipped, nil)
+			continue
+		}
+		if local, _, err := libimageRuntime.LookupImage(img.Name, nil); err == nil {
+			r.imageIDs[img.ID] = local.ID()
+			r.addEntry("image", img.Name, local.ID(), restoreStatusSkipped, nil)
+			continue
+		}
+		pullOptions := &libimage.PullOptions{}
+		pullOptions.Writer = os.Stderr
+		pulled, err := libimageRuntime.Pull(ctx, img.Name, config.PullPolicyMissing, pullOptions)
+		if err != nil {
+			r.addEntry("image", img.Name, img.ID, "", fmt.Errorf("pulling image %s: %w", img.Name, err))
+			continue
+		}
+		r.imageIDs[img.ID] = pulled[0].ID()
+		r.addEntry("image", img.Name, pulled[0].ID(), restoreStatusPulled, nil)
+	}
+}
+
+// prepareContainerConfig applies the new IDs, names, images and secrets to
+// a container config from the archive.
+func (r *backupRestorer) prepareContainerConfig(conf *libpod.ContainerConfig) error {
+	for _, dep := range containerConfigDependencies(conf) {
+		if err, ok := r.failedCtrs[dep]; ok {
+			return fmt.Errorf("dependency %s was not restored: %w", dep, err)
+		}
+	}
+	if err, ok := r.failedPods[conf.Pod]; ok && conf.Pod != "" {
+		return fmt.Errorf("pod %s was not restored: %w", conf.Pod, err)
+	}
+	if conf.RootfsImageID != "" {
+		newID, ok := r.imageIDs[conf.RootfsImageID]
+		if !ok {
+			return fmt.Errorf("image %s is not available", conf.RootfsImageName)
+		}
+		conf.RootfsImageID = newID
+	}
+	if err := r.resolveSecrets(conf); err != nil {
+		return err
+	}
+	remapContainerConfig(conf, r.ctrIDs, r.podIDs)
+	if !r.options.KeepNames {
+		conf.Name = ""
+	}
+	return nil
+}
+
+func (r *backupRestorer) restorePods(ctx context.Context) {
+	infraConfs := make(map[string]*libpod.ContainerConfig)
+	for _, conf := range r.manifest.Containers {
+		if conf.IsInfra {
+			infraConfs[conf.ID] = conf
+		}
+	}
+	for _, bp := range r.manifest.Pods {
+		oldID := bp.Config.ID
+		name := bp.Config.Name
+		if r.skippedPods[oldID] {
+			continue
+		}
+		if err := r.restorePod(ctx, bp, infraConfs[bp.InfraContainerID]); err != nil {
+			r.failedPods[oldID] = err
+			if bp.InfraContainerID != "" {
+				r.failedCtrs[bp.InfraContainerID] = err
+			}
+			r.addEntry("pod", name, "", "", fmt.Errorf("restoring pod %s: %w", name, err))
+			continue
+		}
+		r.addEntry("pod", name, r.podIDs[oldID], restoreStatusCreated, nil)
+	}
+}
+
+func (r *backupRestorer) restorePod(ctx context.Context, bp *backupPod, infraConf *libpod.ContainerConfig) (retErr error) {
+	podConf := bp.Config
+	podConf.ID = r.podIDs[podConf.ID]
+	if !r.options.KeepNames {
+		podConf.Name = ""
+	}
+	pod, err := r.ic.Libpod.RestorePod(ctx, podConf)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			if _, err := r.ic.Libpod.RemovePod(ctx, pod, true, true, nil); err != nil {
+				logrus.Errorf("Removing partially restored pod %s: %v", pod.Name(), err)
+			}
+		}
+	}()
+	if bp.InfraContainerID == "" {
+		return r.ic.Libpod.SavePod(pod)
+	}
+	if infraConf == nil {
+		return fmt.Errorf("infra container %s is missing from the backup", bp.InfraContainerID)
+	}
+	if err := r.prepareContainerConfig(infraConf); err != nil {
+		return err
+	}
+	infra, err := r.ic.Libpod.RecreateContainer(ctx, infraConf)
+	if err != nil {
+		return fmt.Errorf("recreating infra container: %w", err)
+	}
+	_, err = r.ic.Libpod.AddInfra(ctx, pod, infra)
+	return err
+}
+
+func (r *backupRestorer) restoreContainers(ctx context.Context) error {
+	var confs []*libpod.ContainerConfig
+	for _, conf := range r.manifest.Containers {
+		if conf.IsInfra || r.skippedCtrs[conf.ID] {
+			continue
+		}
+		confs = append(confs, conf)
+	}
+	sorted, err := sortContainerConfigs(confs)
+	if err != nil {
+		return err
+	}
+	for _, conf := range sorted {
+		oldID := conf.ID
+		name := conf.Name
+		if err := r.prepareContainerConfig(conf); err != nil {
+			r.failedCtrs[oldID] = err
+			r.addEntry("container", name, "", "", fmt.Errorf("restoring container %s: %w", name, err))
+			continue
+		}
+		ctr, err := r.ic.Libpod.RecreateContainer(ctx, conf)
+		if err != nil {
+			r.failedCtrs[oldID] = err
+			r.addEntry("container", name, "", "", fmt.Errorf("restoring container %s: %w", name, err))
+			continue
+		}
+		r.addEntry("container", ctr.Name(), ctr.ID(), restoreStatusCreated, nil)
+	}
+	return nil
+}
+
+// restoreVolumeData imports the volume contents stored in the archive. This
+// runs after the containers have been created, as those create the anonymous
+// volumes.
+func (r *backupRestorer) restoreVolumeData() {
+	for _, bv := range r.manifest.Volumes {
+		if !bv.HasData {
+			continue
+		}
+		name := bv.Config.Name
+		vol, err := r.ic.Libpod.LookupVolume(name)
+		if err != nil {
+			if bv.Config.IsAnon && errors.Is(err, define.ErrNoSuchVolume) {
+				// The container using it was not restored.
+				continue
+			}
+			r.addEntry("volume", name, "", "", fmt.Errorf("importing volume %s data: %w", name, err))
+			continue
+		}
+		if err := r.importVolumeData(vol); err != nil {
+			r.addEntry("volume", name, "", "", fmt.Errorf("importing volume %s data: %w", name, err))
+		}
+	}
+}
+
+func (r *backupRestorer) importVolumeData(vol *libpod.Volume) error {
+	f, err := os.Open(filepath.Join(r.dataDir, vol.Name()+".tar"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return vol.Import(f)
+}
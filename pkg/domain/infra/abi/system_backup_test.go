@@ -0,0 +1,69 @@
+//go:build !remote
+
+package abi
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v6/libpod"
+	ann "github.com/containers/podman/v6/pkg/annotations"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBackupTestConfig(id, name string) *libpod.ContainerConfig {
+	conf := new(libpod.ContainerConfig)
+	conf.ID = id
+	conf.Name = name
+	return conf
+}
+
+func Test_sortContainerConfigs(t *testing.T) {
+	infra := newBackupTestConfig("infra", "z-infra")
+	app := newBackupTestConfig("app", "a-app")
+	app.NetNsCtr = "infra"
+	app.PIDNsCtr = "infra"
+	db := newBackupTestConfig("db", "b-db")
+	web := newBackupTestConfig("web", "c-web")
+	web.Dependencies = []string{"db", "app", "not-in-backup"}
+
+	sorted, err := sortContainerConfigs([]*libpod.ContainerConfig{web, app, db, infra})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(sorted))
+	for _, conf := range sorted {
+		names = append(names, conf.Name)
+	}
+	assert.Equal(t, []string{"z-infra", "a-app", "b-db", "c-web"}, names)
+}
+
+func Test_sortContainerConfigsCycle(t *testing.T) {
+	a := newBackupTestConfig("a", "a")
+	a.Dependencies = []string{"b"}
+	b := newBackupTestConfig("b", "b")
+	b.IPCNsCtr = "a"
+
+	_, err := sortContainerConfigs([]*libpod.ContainerConfig{a, b})
+	assert.ErrorContains(t, err, "dependency cycle")
+}
+
+func Test_remapContainerConfig(t *testing.T) {
+	conf := newBackupTestConfig("ctr", "ctr")
+	conf.Pod = "pod"
+	conf.NetNsCtr = "infra"
+	conf.UTSNsCtr = "other"
+	conf.Dependencies = []string{"infra", "other"}
+	conf.Spec = &spec.Spec{Annotations: map[string]string{ann.SandboxID: "pod"}}
+
+	remapContainerConfig(conf,
+		map[string]string{"ctr": "new-ctr", "infra": "new-infra"},
+		map[string]string{"pod": "new-pod"})
+
+	assert.Equal(t, "new-ctr", conf.ID)
+	assert.Equal(t, "new-pod", conf.Pod)
+	assert.Equal(t, "new-infra", conf.NetNsCtr)
+	assert.Equal(t, "other", conf.UTSNsCtr)
+	assert.Equal(t, []string{"new-infra", "other"}, conf.Dependencies)
+	assert.Equal(t, "new-pod", conf.Spec.Annotations[ann.SandboxID])
+}
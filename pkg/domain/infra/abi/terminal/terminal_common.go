@@ -38,10 +38,11 @@ func ExecAttachCtr(ctx context.Context, ctr *libpod.Container, execConfig *libpo
 	return ctr.Exec(execConfig, streams, resizechan)
 }
 
-// StartAttachCtr starts and (if required) attaches to a container
+// StartAttachCtr starts and (if required) attaches to a container, record is
+// whether to record the session.
 // if you change the signature of this function from os.File to io.Writer, it will trigger a downstream
 // error. we may need to just lint disable this one.
-func StartAttachCtr(ctx context.Context, ctr *libpod.Container, stdout, stderr, stdin *os.File, detachKeys string, sigProxy bool, startContainer bool) error { //nolint: interfacer
+func StartAttachCtr(ctx context.Context, ctr *libpod.Container, stdout, stderr, stdin *os.File, detachKeys string, sigProxy bool, startContainer bool, record bool) error { //nolint: interfacer
 	resize := make(chan resize.TerminalSize)
 
 	haveTerminal := term.IsTerminal(int(os.Stdin.Fd()))
@@ -69,6 +70,7 @@ func StartAttachCtr(ctx context.Context, ctr *libpod.Container, stdout, stderr,
 	streams.AttachOutput = true
 	streams.AttachError = true
 	streams.AttachInput = true
+	streams.Record = record
 
 	if stdout == nil {
 		logrus.Debugf("Not attaching to stdout")
@@ -16,9 +16,10 @@ func ExecAttachCtr(ctx context.Context, ctr *libpod.Container, execConfig *libpo
 	return -1, errors.New("not implemented ExecAttachCtr")
 }
 
-// StartAttachCtr starts and (if required) attaches to a container
+// StartAttachCtr starts and (if required) attaches to a container, record is
+// whether to record the session.
 // if you change the signature of this function from os.File to io.Writer, it will trigger a downstream
 // error. we may need to just lint disable this one.
-func StartAttachCtr(ctx context.Context, ctr *libpod.Container, stdout, stderr, stdin *os.File, detachKeys string, sigProxy bool, startContainer bool) error { //nolint: interfacer
+func StartAttachCtr(ctx context.Context, ctr *libpod.Container, stdout, stderr, stdin *os.File, detachKeys string, sigProxy bool, startContainer bool, record bool) error { //nolint: interfacer
 	return errors.New("not implemented StartAttachCtr")
 }
@@ -0,0 +1,36 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v6/libpod"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+)
+
+// ContainerTrace traces a running container until it stops or the context is
+// cancelled.
+func (ic *ContainerEngine) ContainerTrace(ctx context.Context, nameOrID string, options entities.ContainerTraceOptions) (*entities.ContainerTraceReport, error) {
+	defer close(options.EventChan)
+
+	var (
+		ctr *libpod.Container
+		err error
+	)
+	if options.Latest {
+		ctr, err = ic.Libpod.GetLatestContainer()
+	} else {
+		ctr, err = ic.Libpod.LookupContainer(nameOrID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up requested container: %w", err)
+	}
+
+	syscalls, err := ctr.Trace(ctx, options.EventChan)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ContainerTraceReport{Syscalls: syscalls}, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/containers/podman/v6/pkg/domain/entities/reports"
 	"github.com/containers/podman/v6/pkg/domain/filters"
 	"github.com/containers/podman/v6/pkg/domain/infra/abi/parse"
+	"github.com/containers/podman/v6/pkg/where"
 )
 
 func (ic *ContainerEngine) VolumeCreate(ctx context.Context, opts entities.VolumeCreateOptions) (*entities.IDOrNameResponse, error) {
@@ -161,6 +162,13 @@ func (ic *ContainerEngine) VolumeList(_ context.Context, opts entities.VolumeLis
 		}
 		volumeFilters = append(volumeFilters, filterFunc)
 	}
+	var whereExpr *where.Expression
+	if opts.Where != "" {
+		var err error
+		if whereExpr, err = where.Compile(opts.Where); err != nil {
+			return nil, err
+		}
+	}
 
 	vols, err := ic.Libpod.Volumes(volumeFilters...)
 	if err != nil {
@@ -175,6 +183,15 @@ func (ic *ContainerEngine) VolumeList(_ context.Context, opts entities.VolumeLis
 			}
 			return nil, err
 		}
+		if whereExpr != nil {
+			match, err := whereExpr.MatchValue(inspectOut)
+			if err != nil {
+				return nil, fmt.Errorf("volume %s: %w", v.Name(), err)
+			}
+			if !match {
+				continue
+			}
+		}
 		config := entities.VolumeConfigResponse{
 			InspectVolumeData: *inspectOut,
 		}
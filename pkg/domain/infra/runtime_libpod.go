@@ -204,6 +204,10 @@ func getRuntime(ctx context.Context, fs *flag.FlagSet, opts *engineOpts) (*libpo
 		options = append(options, libpod.WithSyslog())
 	}
 
+	if cfg.RecordSessions {
+		options = append(options, libpod.WithRecordSessions())
+	}
+
 	if opts.config.ContainersConfDefaultsRO.Engine.StaticDir != "" {
 		options = append(options, libpod.WithStaticDir(opts.config.ContainersConfDefaultsRO.Engine.StaticDir))
 	}
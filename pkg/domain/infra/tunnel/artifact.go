@@ -166,3 +166,12 @@ func addArtifact(ctx context.Context, name string, index int, blob entities.Arti
 	}
 	return artifactAddReport, nil
 }
+
+func (ir *ImageEngine) ArtifactRefresh(_ context.Context, opts entities.ArtifactRefreshOptions) ([]*entities.ArtifactRefreshReport, error) {
+	options := artifacts.RefreshOptions{
+		All:        &opts.All,
+		Containers: opts.Containers,
+	}
+
+	return artifacts.Refresh(ir.ClientCtx, &options)
+}
@@ -357,6 +357,13 @@ func (ic *ContainerEngine) ContainerTop(_ context.Context, opts entities.TopOpti
 	return &entities.StringSliceReport{Value: topOutput}, nil
 }
 
+func (ic *ContainerEngine) ContainerTopStats(_ context.Context, nameOrID string, options entities.ContainerTopStatsOptions) (chan entities.ContainerTopStatsReport, error) {
+	if options.Latest {
+		return nil, errors.New("latest is not supported for the remote client")
+	}
+	return containers.TopStats(ic.ClientCtx, nameOrID, new(containers.TopStatsOptions).WithStream(options.Stream).WithInterval(options.Interval).WithSort(options.Sort))
+}
+
 func (ic *ContainerEngine) ContainerCommit(_ context.Context, nameOrID string, opts entities.CommitOptions) (*entities.CommitReport, error) {
 	var (
 		repo string
@@ -532,6 +539,10 @@ func (ic *ContainerEngine) ContainerRestore(_ context.Context, namesOrIds []stri
 	return reports, nil
 }
 
+func (ic *ContainerEngine) ContainerDebug(_ context.Context, _ string, _ entities.ContainerDebugOptions) (*entities.ContainerRunReport, error) {
+	return nil, errors.New("debug containers are not supported for remote clients")
+}
+
 func (ic *ContainerEngine) ContainerCreate(_ context.Context, s *specgen.SpecGenerator) (*entities.ContainerCreateReport, error) {
 	response, err := containers.CreateWithSpec(ic.ClientCtx, s, nil)
 	if err != nil {
@@ -587,6 +598,9 @@ func (ic *ContainerEngine) ContainerAttach(ctx context.Context, nameOrID string,
 		return fmt.Errorf("you can only attach to running containers")
 	}
 	options := new(containers.AttachOptions).WithStream(true).WithDetachKeys(opts.DetachKeys)
+	if opts.Record {
+		options.WithRecord(true)
+	}
 	if opts.SigProxy {
 		remoteProxySignals(ctr.ID, func(signal string) error {
 			killOpts := entities.KillOptions{All: false, Latest: false, Signal: signal}
@@ -644,6 +658,9 @@ func (ic *ContainerEngine) ContainerExec(_ context.Context, nameOrID string, opt
 		startAndAttachOptions.WithInputStream(*streams.InputStream)
 	}
 	startAndAttachOptions.WithAttachError(streams.AttachError).WithAttachOutput(streams.AttachOutput).WithAttachInput(streams.AttachInput)
+	if streams.Record {
+		startAndAttachOptions.WithRecord(true)
+	}
 	if err := containers.ExecStartAndAttach(ic.ClientCtx, sessionID, startAndAttachOptions); err != nil {
 		return 125, err
 	}
@@ -866,6 +883,9 @@ func (ic *ContainerEngine) ContainerStart(_ context.Context, namesOrIds []string
 func (ic *ContainerEngine) ContainerList(_ context.Context, opts entities.ContainerListOptions) ([]entities.ListContainer, error) {
 	options := new(containers.ListOptions).WithFilters(opts.Filters).WithAll(opts.All).WithLast(opts.Last)
 	options.WithNamespace(opts.Namespace).WithSize(opts.Size).WithSync(opts.Sync).WithExternal(opts.External)
+	if opts.Where != "" {
+		options.WithWhere(opts.Where)
+	}
 	return containers.List(ic.ClientCtx, options)
 }
 
@@ -986,6 +1006,14 @@ func (ic *ContainerEngine) Diff(_ context.Context, namesOrIDs []string, opts ent
 	return &entities.DiffReport{Changes: changes}, err
 }
 
+func (ic *ContainerEngine) DiffExport(_ context.Context, _ []string, _ io.Writer, _ entities.DiffOptions) error {
+	return errors.New("exporting diffs is not supported for remote clients")
+}
+
+func (ic *ContainerEngine) ContainerApplyDiff(_ context.Context, _ string, _ io.Reader) error {
+	return errors.New("applying diffs is not supported for remote clients")
+}
+
 func (ic *ContainerEngine) ContainerCleanup(_ context.Context, _ []string, _ entities.ContainerCleanupOptions) ([]*entities.ContainerCleanupReport, error) {
 	return nil, errors.New("not implemented")
 }
@@ -1057,7 +1085,7 @@ func (ic *ContainerEngine) ContainerPort(_ context.Context, nameOrID string, opt
 }
 
 func (ic *ContainerEngine) ContainerCopyFromArchive(_ context.Context, nameOrID, path string, reader io.Reader, options entities.CopyOptions) (entities.ContainerCopyFunc, error) {
-	copyOptions := new(containers.CopyOptions).WithChown(options.Chown).WithRename(options.Rename).WithNoOverwriteDirNonDir(options.NoOverwriteDirNonDir)
+	copyOptions := new(containers.CopyOptions).WithChown(options.Chown).WithRename(options.Rename).WithRemove(options.Remove).WithNoOverwriteDirNonDir(options.NoOverwriteDirNonDir)
 	return containers.CopyFromArchiveWithOptions(ic.ClientCtx, nameOrID, path, reader, copyOptions)
 }
 
@@ -1094,3 +1122,12 @@ func (ic *ContainerEngine) ContainerUpdate(_ context.Context, updateOptions *ent
 	updateOptions.ProcessSpecgen()
 	return containers.Update(ic.ClientCtx, updateOptions)
 }
+
+func (ic *ContainerEngine) ContainerTrace(_ context.Context, _ string, options entities.ContainerTraceOptions) (*entities.ContainerTraceReport, error) {
+	close(options.EventChan)
+	return nil, errors.New("tracing containers is not supported for remote clients")
+}
+
+func (ic *ContainerEngine) Graph(_ context.Context, _ entities.GraphOptions) (*entities.GraphReport, error) {
+	return nil, errors.New("dependency graphs are not supported for remote clients")
+}
@@ -29,5 +29,8 @@ func (ic *ContainerEngine) Events(_ context.Context, opts entities.EventsOptions
 		close(opts.EventChan)
 	}()
 	options := new(system.EventsOptions).WithFilters(filters).WithSince(opts.Since).WithStream(opts.Stream).WithUntil(opts.Until)
+	if len(opts.SinceCursor) > 0 {
+		options.WithSinceCursor(opts.SinceCursor)
+	}
 	return system.Events(ic.ClientCtx, binChan, nil, options)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/containers/podman/v6/pkg/bindings/containers"
 	"github.com/containers/podman/v6/pkg/bindings/system"
 	"github.com/containers/podman/v6/pkg/domain/entities"
 )
@@ -39,10 +40,42 @@ func (ir *ImageEngine) FarmNodeInspect(ctx context.Context) (*entities.FarmInspe
 	ir.platforms.Do(func() {
 		ir.os, ir.arch, ir.variant, ir.nativePlatforms, ir.platformsErr = ir.fetchInfo(ctx)
 	})
+	if ir.platformsErr != nil {
+		return nil, ir.platformsErr
+	}
+	// The load changes all the time, unlike the platforms it is not cached.
+	engineInfo, err := system.Info(ir.ClientCtx, &system.InfoOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving host info from %q: %w", ir.NodeName, err)
+	}
+	builds, err := ir.runningBuilds()
+	if err != nil {
+		return nil, err
+	}
 	return &entities.FarmInspectReport{
 		NativePlatforms: ir.nativePlatforms,
 		OS:              ir.os,
 		Arch:            ir.arch,
 		Variant:         ir.variant,
-	}, ir.platformsErr
+		CPUs:            engineInfo.Host.CPUs,
+		MemTotal:        engineInfo.Host.MemTotal,
+		MemFree:         engineInfo.Host.MemFree,
+		Builds:          builds,
+	}, nil
+}
+
+// runningBuilds counts the buildah working containers on the remote engine,
+// every running build has at least one of them.
+func (ir *ImageEngine) runningBuilds() (int, error) {
+	ctrs, err := containers.List(ir.ClientCtx, new(containers.ListOptions).WithAll(true).WithExternal(true))
+	if err != nil {
+		return 0, fmt.Errorf("listing containers of %q: %w", ir.NodeName, err)
+	}
+	builds := 0
+	for _, ctr := range ctrs {
+		if ctr.State == "storage" && len(ctr.Command) > 0 && ctr.Command[0] == "buildah" {
+			builds++
+		}
+	}
+	return builds, nil
 }
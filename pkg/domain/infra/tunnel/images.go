@@ -47,6 +47,9 @@ func (ir *ImageEngine) List(_ context.Context, opts entities.ImageListOptions) (
 		}
 	}
 	options := new(images.ListOptions).WithAll(opts.All).WithFilters(filters)
+	if opts.Where != "" {
+		options.WithWhere(opts.Where)
+	}
 	psImages, err := images.List(ir.ClientCtx, options)
 	if err != nil {
 		return nil, err
@@ -71,6 +74,26 @@ func (ir *ImageEngine) Unmount(_ context.Context, _ []string, _ entities.ImageUn
 	return nil, errors.New("unmounting images is not supported for remote clients")
 }
 
+func (ir *ImageEngine) Audit(_ context.Context, _ []string, _ entities.ImageAuditOptions) ([]*entities.ImageAuditReport, error) {
+	return nil, errors.New("auditing images is not supported for remote clients")
+}
+
+func (ir *ImageEngine) AuditImport(_ context.Context, _ []string) (*entities.ImageAuditImportReport, error) {
+	return nil, errors.New("auditing images is not supported for remote clients")
+}
+
+func (ir *ImageEngine) SBOM(_ context.Context, _ string, _ entities.ImageSBOMOptions) (*entities.ImageSBOMReport, error) {
+	return nil, errors.New("generating SBOMs is not supported for remote clients")
+}
+
+func (ir *ImageEngine) Analyze(_ context.Context, _ string, _ entities.ImageAnalyzeOptions) (*entities.ImageAnalyzeReport, error) {
+	return nil, errors.New("analyzing images is not supported for remote clients")
+}
+
+func (ir *ImageEngine) Verify(_ context.Context, _ string, _ entities.ImageVerifyOptions) (*entities.ImageVerifyReport, error) {
+	return nil, errors.New("verifying images is not supported for remote clients")
+}
+
 func (ir *ImageEngine) History(_ context.Context, nameOrID string, _ entities.ImageHistoryOptions) (*entities.ImageHistoryReport, error) {
 	options := new(images.HistoryOptions)
 	results, err := images.History(ir.ClientCtx, nameOrID, options)
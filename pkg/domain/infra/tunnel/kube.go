@@ -59,6 +59,7 @@ func (ic *ContainerEngine) PlayKube(_ context.Context, body io.Reader, opts enti
 	options.WithCertDir(opts.CertDir).WithQuiet(opts.Quiet).WithSignaturePolicy(opts.SignaturePolicy).WithConfigMaps(opts.ConfigMaps)
 	options.WithLogDriver(opts.LogDriver).WithNetwork(opts.Networks).WithSeccompProfileRoot(opts.SeccompProfileRoot)
 	options.WithStaticIPs(opts.StaticIPs).WithStaticMACs(opts.StaticMACs).WithWait(opts.Wait).WithServiceContainer(opts.ServiceContainer).WithReplace(opts.Replace)
+	options.WithRolling(opts.Rolling)
 	if len(opts.LogOptions) > 0 {
 		options.WithLogOptions(opts.LogOptions)
 	}
@@ -83,6 +84,14 @@ func (ic *ContainerEngine) PlayKubeDown(_ context.Context, body io.Reader, optio
 	return play.DownWithBody(ic.ClientCtx, body, kube.DownOptions{Force: &options.Force})
 }
 
+func (ic *ContainerEngine) KubeRollback(_ context.Context, name string, opts entities.KubeRollbackOptions) (*entities.KubeRollbackReport, error) {
+	options := new(kube.RollbackOptions)
+	if opts.Revision > 0 {
+		options.WithRevision(opts.Revision)
+	}
+	return kube.Rollback(ic.ClientCtx, name, options)
+}
+
 func (ic *ContainerEngine) KubeApply(_ context.Context, body io.Reader, opts entities.ApplyOptions) error {
 	options := new(kube.ApplyOptions).WithKubeconfig(opts.Kubeconfig).WithCACertFile(opts.CACertFile).WithNamespace(opts.Namespace)
 	return kube.ApplyWithBody(ic.ClientCtx, body, options)
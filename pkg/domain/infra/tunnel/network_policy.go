@@ -0,0 +1,51 @@
+package tunnel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v6/libpod/define"
+	"github.com/containers/podman/v6/pkg/bindings/network"
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/errorhandling"
+)
+
+func (ic *ContainerEngine) NetworkPolicyCreate(_ context.Context, policy entities.NetworkPolicy, opts entities.NetworkPolicyCreateOptions) (*entities.NetworkPolicy, error) {
+	options := new(network.PolicyCreateOptions).WithReplace(opts.Replace)
+	return network.PolicyCreate(ic.ClientCtx, &policy, options)
+}
+
+func (ic *ContainerEngine) NetworkPolicyInspect(_ context.Context, names []string) ([]*entities.NetworkPolicy, []error, error) {
+	var errs []error
+	policies := make([]*entities.NetworkPolicy, 0, len(names))
+	for _, name := range names {
+		policy, err := network.PolicyInspect(ic.ClientCtx, name)
+		if err != nil {
+			errModel, ok := err.(*errorhandling.ErrorModel)
+			if !ok {
+				return nil, nil, err
+			}
+			if errModel.ResponseCode == 404 {
+				errs = append(errs, fmt.Errorf("network policy %s: %w", name, define.ErrNoSuchNetworkPolicy))
+				continue
+			}
+			return nil, nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, errs, nil
+}
+
+func (ic *ContainerEngine) NetworkPolicyList(_ context.Context) ([]*entities.NetworkPolicy, error) {
+	return network.PolicyList(ic.ClientCtx)
+}
+
+func (ic *ContainerEngine) NetworkPolicyRm(_ context.Context, names []string, opts entities.NetworkPolicyRmOptions) ([]*entities.NetworkPolicyRmReport, error) {
+	reports := make([]*entities.NetworkPolicyRmReport, 0, len(names))
+	options := new(network.PolicyRemoveOptions).WithIgnore(opts.Ignore)
+	for _, name := range names {
+		err := network.PolicyRemove(ic.ClientCtx, name, options)
+		reports = append(reports, &entities.NetworkPolicyRmReport{Name: name, Err: err})
+	}
+	return reports, nil
+}
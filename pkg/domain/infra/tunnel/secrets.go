@@ -17,7 +17,8 @@ func (ic *ContainerEngine) SecretCreate(_ context.Context, name string, reader i
 		WithName(name).
 		WithLabels(options.Labels).
 		WithReplace(options.Replace).
-		WithIgnore(options.Ignore)
+		WithIgnore(options.Ignore).
+		WithKeepVersions(options.KeepVersions)
 	created, err := secrets.Create(ic.ClientCtx, reader, opts)
 	if err != nil {
 		return nil, err
@@ -50,7 +51,7 @@ func (ic *ContainerEngine) SecretInspect(_ context.Context, nameOrIDs []string,
 }
 
 func (ic *ContainerEngine) SecretList(_ context.Context, opts entities.SecretListRequest) ([]*entities.SecretInfoReport, error) {
-	options := new(secrets.ListOptions).WithFilters(opts.Filters)
+	options := new(secrets.ListOptions).WithFilters(opts.Filters).WithAll(opts.All)
 	secrs, _ := secrets.List(ic.ClientCtx, options)
 	return secrs, nil
 }
@@ -102,3 +103,32 @@ func (ic *ContainerEngine) SecretExists(_ context.Context, nameOrID string) (*en
 	}
 	return &entities.BoolReport{Value: exists}, nil
 }
+
+func (ic *ContainerEngine) SecretUpdate(_ context.Context, nameOrID string, reader io.Reader, options entities.SecretUpdateOptions) (*entities.SecretUpdateReport, error) {
+	opts := new(secrets.UpdateOptions).
+		WithKeepVersions(options.KeepVersions)
+	if options.ReloadSignal != "" {
+		opts.WithSignal(options.ReloadSignal)
+	}
+	if options.ReloadExec != "" {
+		opts.WithExec(options.ReloadExec)
+	}
+	return secrets.Update(ic.ClientCtx, nameOrID, reader, opts)
+}
+
+func (ic *ContainerEngine) SecretHistory(_ context.Context, nameOrID string) ([]*entities.SecretHistoryReport, error) {
+	return secrets.History(ic.ClientCtx, nameOrID, nil)
+}
+
+func (ic *ContainerEngine) SecretRollback(_ context.Context, nameOrID string, options entities.SecretRollbackOptions) (*entities.SecretUpdateReport, error) {
+	opts := new(secrets.RollbackOptions).
+		WithVersion(options.Version).
+		WithKeepVersions(options.KeepVersions)
+	if options.ReloadSignal != "" {
+		opts.WithSignal(options.ReloadSignal)
+	}
+	if options.ReloadExec != "" {
+		opts.WithExec(options.ReloadExec)
+	}
+	return secrets.Rollback(ic.ClientCtx, nameOrID, opts)
+}
@@ -0,0 +1,22 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+
+	"github.com/containers/podman/v6/pkg/domain/entities"
+)
+
+var errSessionsRemote = errors.New("session recordings are not supported for remote clients")
+
+func (ic *ContainerEngine) SessionList(_ context.Context, _ entities.SessionListOptions) ([]*entities.SessionListReport, error) {
+	return nil, errSessionsRemote
+}
+
+func (ic *ContainerEngine) SessionReplay(_ context.Context, _ string, _ entities.SessionReplayOptions) error {
+	return errSessionsRemote
+}
+
+func (ic *ContainerEngine) SessionRm(_ context.Context, _ []string, _ entities.SessionRmOptions) ([]*entities.SessionRmReport, error) {
+	return nil, errSessionsRemote
+}
@@ -59,3 +59,11 @@ func (ic *ContainerEngine) Version(_ context.Context) (*entities.SystemVersionRe
 func (ic *ContainerEngine) Locks(_ context.Context) (*entities.LocksReport, error) {
 	return nil, errors.New("locks is not supported on remote clients")
 }
+
+func (ic *ContainerEngine) SystemBackup(_ context.Context, _ entities.SystemBackupOptions) (*entities.SystemBackupReport, error) {
+	return nil, errors.New("system backup is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemRestore(_ context.Context, _ entities.SystemRestoreOptions) (*entities.SystemRestoreReport, error) {
+	return nil, errors.New("system restore is not supported on remote clients")
+}
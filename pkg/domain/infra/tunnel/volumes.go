@@ -82,6 +82,9 @@ func (ic *ContainerEngine) VolumePrune(_ context.Context, opts entities.VolumePr
 
 func (ic *ContainerEngine) VolumeList(_ context.Context, opts entities.VolumeListOptions) ([]*entities.VolumeListReport, error) {
 	options := new(volumes.ListOptions).WithFilters(opts.Filter)
+	if opts.Where != "" {
+		options.WithWhere(opts.Where)
+	}
 	return volumes.List(ic.ClientCtx, options)
 }
 
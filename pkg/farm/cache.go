@@ -0,0 +1,389 @@
+package farm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/containers/podman/v6/pkg/domain/infra"
+	"github.com/containers/podman/v6/pkg/specgen"
+	nettypes "go.podman.io/common/libnetwork/types"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/storage/pkg/homedir"
+	"go.podman.io/storage/pkg/ioutils"
+	"go.podman.io/storage/pkg/lockfile"
+)
+
+const (
+	// farmsFile stores the settings of the farms which are not part of the
+	// connections config, next to the configs of podman machine.
+	farmsFile = "containers/podman/farms.json"
+
+	// DefaultCachePort is the port the registry on a cache node listens on
+	// if no port is configured.
+	DefaultCachePort = 5000
+	// CacheRegistryImage is the image of the registry serving the layer
+	// cache on a cache node.
+	CacheRegistryImage = "docker.io/library/registry:2"
+	// cacheContainerName is the name of the registry container on a cache
+	// node.
+	cacheContainerName = "podman-farm-cache"
+	// cacheNodeRepository is the repository of the layer cache in the
+	// registry on a cache node.  Cache entries are keyed by the content of
+	// the build steps, so farms can share it.
+	cacheNodeRepository = "podman-farm-cache"
+	// cacheCALabel labels the registry container on a cache node with the
+	// fingerprint of the authority of its certificates.
+	cacheCALabel = "io.podman.farm.cache.ca"
+)
+
+// CacheConfig configures the layer cache shared by the nodes of a farm.  The
+// intermediate images of builds are pushed to and pulled from either a
+// registry repository or a registry run on a farm-designated cache node.
+type CacheConfig struct {
+	// Repository is the registry repository of the cache.
+	Repository string `json:",omitempty"`
+	// Node is the system connection of the node serving the cache.
+	Node string `json:",omitempty"`
+	// Port is the port of the registry on the cache node.
+	Port uint16 `json:",omitempty"`
+}
+
+type farmsConfig struct {
+	// Cache maps the names of the farms to their cache configs.
+	Cache map[string]CacheConfig `json:",omitempty"`
+}
+
+// Validate checks that the config is usable.
+func (c *CacheConfig) Validate() error {
+	switch {
+	case c.Repository != "" && c.Node != "":
+		return errors.New("the layer cache can be shared either through a repository or a cache node, not both")
+	case c.Node == "" && c.Port != 0:
+		return errors.New("the cache port can only be set for a cache node")
+	case c.Repository != "":
+		named, err := reference.ParseNormalizedNamed(c.Repository)
+		if err != nil {
+			return fmt.Errorf("invalid cache repository %q: %w", c.Repository, err)
+		}
+		if !reference.IsNameOnly(named) {
+			return fmt.Errorf("invalid cache repository %q: must not have a tag or digest", c.Repository)
+		}
+	}
+	return nil
+}
+
+// String describes where the cache is shared.
+func (c *CacheConfig) String() string {
+	switch {
+	case c.Repository != "":
+		return c.Repository
+	case c.Node != "":
+		return fmt.Sprintf("node %s:%d", c.Node, c.port())
+	}
+	return ""
+}
+
+func (c *CacheConfig) port() uint16 {
+	if c.Port == 0 {
+		return DefaultCachePort
+	}
+	return c.Port
+}
+
+func farmsConfigFile() (string, error) {
+	configHome, err := homedir.GetConfigHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configHome, farmsFile), nil
+}
+
+func readFarmsConfig(path string) (*farmsConfig, error) {
+	conf := new(farmsConfig)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return conf, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, conf); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return conf, nil
+}
+
+// GetCacheConfig returns the layer cache config of the farm, or nil if the farm
+// does not share its layer cache.
+func GetCacheConfig(farm string) (*CacheConfig, error) {
+	path, err := farmsConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	conf, err := readFarmsConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	cache, ok := conf.Cache[farm]
+	if !ok {
+		return nil, nil
+	}
+	return &cache, nil
+}
+
+// SetCacheConfig sets the layer cache config of the farm.  A nil or empty
+// config stops sharing the layer cache.
+func SetCacheConfig(farm string, cache *CacheConfig) error {
+	if cache != nil {
+		if err := cache.Validate(); err != nil {
+			return err
+		}
+	}
+	path, err := farmsConfigFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	lock, err := lockfile.GetLockFile(path + ".lock")
+	if err != nil {
+		return fmt.Errorf("obtain lock file: %w", err)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	conf, err := readFarmsConfig(path)
+	if err != nil {
+		return err
+	}
+	if cache == nil || *cache == (CacheConfig{}) {
+		if _, ok := conf.Cache[farm]; !ok {
+			return nil
+		}
+		delete(conf.Cache, farm)
+	} else {
+		if conf.Cache == nil {
+			conf.Cache = make(map[string]CacheConfig)
+		}
+		conf.Cache[farm] = *cache
+	}
+	data, err := json.MarshalIndent(conf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(path, data, 0o600)
+}
+
+// shareCache makes the builds share the layer cache of the farm, unless the
+// cache repositories were chosen for the build.
+func (f *Farm) shareCache(ctx context.Context, options *entities.BuildOptions) error {
+	if len(options.CacheFrom) > 0 || len(options.CacheTo) > 0 {
+		return nil
+	}
+	repository, err := f.cacheRepository(ctx)
+	if err != nil || repository == nil {
+		return err
+	}
+	fmt.Printf("Sharing the layer cache through %q\n", repository.String())
+	options.CacheFrom = []reference.Named{repository}
+	options.CacheTo = []reference.Named{repository}
+	return nil
+}
+
+// cacheRepository returns the repository the nodes of the farm share their
+// layer cache through.  If the cache is served by a cache node, the registry
+// container is started on it first.
+func (f *Farm) cacheRepository(ctx context.Context) (reference.Named, error) {
+	if f.cache == nil {
+		return nil, nil
+	}
+	repository := f.cache.Repository
+	if f.cache.Node != "" {
+		cfg, err := config.Default()
+		if err != nil {
+			return nil, err
+		}
+		con, err := cfg.GetConnection(f.cache.Node, false)
+		if err != nil {
+			return nil, fmt.Errorf("looking up cache node: %w", err)
+		}
+		host, err := CacheNodeHost(con)
+		if err != nil {
+			return nil, err
+		}
+		certsDir, err := cacheCertsDir(con.Name)
+		if err != nil {
+			return nil, err
+		}
+		certs, err := loadCacheCerts(certsDir)
+		if err != nil {
+			return nil, fmt.Errorf("loading certificates of cache node %q: %w", con.Name, err)
+		}
+		if err := startCacheRegistry(ctx, con, host, f.cache.port(), certs); err != nil {
+			return nil, fmt.Errorf("starting cache registry on %q: %w", con.Name, err)
+		}
+		repository = net.JoinHostPort(host, strconv.Itoa(int(f.cache.port()))) + "/" + cacheNodeRepository
+	}
+	named, err := reference.ParseNormalizedNamed(repository)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache repository %q: %w", repository, err)
+	}
+	return named, nil
+}
+
+// CacheNodeHost returns the host name the nodes of the farm reach the cache
+// node of the connection by.  Connections to the local host, including those
+// of podman machines, are rejected as every farm node would reach itself.
+func CacheNodeHost(con *config.Connection) (string, error) {
+	if con.IsMachine {
+		return "", fmt.Errorf("connection %q is a podman machine, which the farm nodes cannot reach", con.Name)
+	}
+	uri, err := url.Parse(con.URI)
+	if err != nil {
+		return "", fmt.Errorf("parsing URI of connection %q: %w", con.Name, err)
+	}
+	host := uri.Hostname()
+	if host == "" {
+		return "", fmt.Errorf("connection %q has no host name the farm nodes can reach", con.Name)
+	}
+	if ip := net.ParseIP(host); (ip != nil && ip.IsLoopback()) || strings.EqualFold(host, "localhost") {
+		return "", fmt.Errorf("connection %q is to the local host %s, which the farm nodes cannot reach", con.Name, host)
+	}
+	return host, nil
+}
+
+// startCacheRegistry makes sure the registry container serving the cache is
+// running on the node of the connection.  The registry serves a certificate
+// for host and only accepts clients presenting the client certificate, both
+// issued by the authority of certs.
+func startCacheRegistry(ctx context.Context, con *config.Connection, host string, port uint16, certs *cacheCerts) error {
+	podmanConfig := &entities.PodmanConfig{
+		EngineMode:   entities.TunnelMode,
+		URI:          con.URI,
+		Identity:     con.Identity,
+		MachineMode:  con.IsMachine,
+		FarmNodeName: con.Name,
+	}
+	containerEngine, err := infra.NewContainerEngine(podmanConfig)
+	if err != nil {
+		return err
+	}
+	exists, err := containerEngine.ContainerExists(ctx, cacheContainerName, entities.ContainerExistsOptions{})
+	if err != nil {
+		return err
+	}
+	if exists.Value {
+		inspect, errs, err := containerEngine.ContainerInspect(ctx, []string{cacheContainerName}, entities.InspectOptions{})
+		if err != nil {
+			return err
+		}
+		if len(errs) > 0 {
+			return errs[0]
+		}
+		if inspect[0].Config == nil || inspect[0].Config.Labels[cacheCALabel] != certs.fingerprint() {
+			return fmt.Errorf("container %s was not set up with the certificates in %s, remove it to set it up again", cacheContainerName, certs.dir)
+		}
+	} else {
+		if err := createCacheRegistry(ctx, podmanConfig, containerEngine, host, port, certs); err != nil {
+			return err
+		}
+		fmt.Printf("Created cache registry on %q, the farm nodes need the files in %s in their certs.d/%s directory\n",
+			con.Name, certs.certsDir(), net.JoinHostPort(host, strconv.Itoa(int(port))))
+	}
+	reports, err := containerEngine.ContainerStart(ctx, []string{cacheContainerName}, entities.ContainerStartOptions{})
+	if err != nil {
+		return err
+	}
+	for _, report := range reports {
+		if report.Err != nil {
+			return report.Err
+		}
+	}
+	return nil
+}
+
+// createCacheRegistry creates the registry container on the node, passing it
+// its certificates as secrets.
+func createCacheRegistry(ctx context.Context, podmanConfig *entities.PodmanConfig, containerEngine entities.ContainerEngine, host string, port uint16, certs *cacheCerts) error {
+	imageEngine, err := infra.NewImageEngine(podmanConfig)
+	if err != nil {
+		return err
+	}
+	if _, err := imageEngine.Pull(ctx, CacheRegistryImage, entities.ImagePullOptions{Quiet: true}); err != nil {
+		return err
+	}
+	serverCert, serverKey, err := certs.serverCert(host)
+	if err != nil {
+		return err
+	}
+	s := specgen.NewSpecGenerator(CacheRegistryImage, false)
+	s.Name = cacheContainerName
+	s.Labels = map[string]string{cacheCALabel: certs.fingerprint()}
+	s.PortMappings = []nettypes.PortMapping{{ContainerPort: 5000, HostPort: port, Protocol: "tcp"}}
+	s.Env = map[string]string{
+		"REGISTRY_HTTP_TLS_CERTIFICATE": "/run/secrets/" + cacheContainerName + ".crt",
+		"REGISTRY_HTTP_TLS_KEY":         "/run/secrets/" + cacheContainerName + ".key",
+		"REGISTRY_HTTP_TLS_CLIENTCAS":   "[/run/secrets/" + cacheContainerName + "-ca.crt]",
+	}
+	for _, secret := range []struct {
+		name string
+		data []byte
+	}{
+		{cacheContainerName + ".crt", serverCert},
+		{cacheContainerName + ".key", serverKey},
+		{cacheContainerName + "-ca.crt", certs.caPEM()},
+	} {
+		if _, err := containerEngine.SecretCreate(ctx, secret.name, bytes.NewReader(secret.data), entities.SecretCreateOptions{Replace: true}); err != nil {
+			return fmt.Errorf("creating secret %s: %w", secret.name, err)
+		}
+		s.Secrets = append(s.Secrets, specgen.Secret{Source: secret.name})
+	}
+	_, err = containerEngine.ContainerCreate(ctx, s)
+	return err
+}
+
+// buildStats counts the cache hits of a build.
+type buildStats struct {
+	// steps is the number of steps of the build.
+	steps int
+	// cached is the number of steps served from a cache.
+	cached int
+	// pulled is the number of cached steps pulled from the shared cache.
+	pulled int
+	// pushed is the number of steps pushed to the shared cache.
+	pushed int
+}
+
+var buildStepRegexp = regexp.MustCompile(`^(\[[0-9]+/[0-9]+\] )?STEP [0-9]+`)
+
+// count updates the statistics with a line of the build output.
+func (s *buildStats) count(line string) {
+	switch {
+	case buildStepRegexp.MatchString(line):
+		s.steps++
+	case strings.HasPrefix(line, "--> Using cache "):
+		s.cached++
+	case strings.HasPrefix(line, "--> Cache pulled from remote "):
+		s.pulled++
+	case strings.HasPrefix(line, "--> Pushing cache "):
+		s.pushed++
+	}
+}
+
+func (s buildStats) String() string {
+	return fmt.Sprintf("%d steps, %d cached, %d pulled from and %d pushed to the shared cache", s.steps, s.cached, s.pulled, s.pushed)
+}
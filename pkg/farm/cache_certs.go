@@ -0,0 +1,228 @@
+package farm
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.podman.io/storage/pkg/homedir"
+)
+
+const (
+	// cacheCertsPath is the directory of the certificates of the cache
+	// registries, one subdirectory per cache node.
+	cacheCertsPath = "containers/podman/farm-cache"
+	// cacheCertValidity is how long the certificates of a cache registry
+	// are valid.
+	cacheCertValidity = 10 * 365 * 24 * time.Hour
+)
+
+// cacheCerts is the certificate authority of the registry on a cache node.
+// It signs the certificate the registry serves and the client certificate
+// the farm nodes authenticate with.  Its key never leaves the client.
+type cacheCerts struct {
+	// dir holds ca.key and the certs directory with ca.crt, client.cert
+	// and client.key, which are installed on the farm nodes.
+	dir    string
+	caCert *x509.Certificate
+	caKey  crypto.Signer
+}
+
+// cacheCertsDir returns the directory of the certificates of the registry on
+// the cache node of the connection.
+func cacheCertsDir(node string) (string, error) {
+	configHome, err := homedir.GetConfigHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configHome, cacheCertsPath, node), nil
+}
+
+// loadCacheCerts loads the certificate authority in dir, creating it and the
+// client certificate on first use.
+func loadCacheCerts(dir string) (*cacheCerts, error) {
+	c := &cacheCerts{dir: dir}
+	keyPEM, err := os.ReadFile(filepath.Join(dir, "ca.key"))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		return c, c.create()
+	}
+	certPEM, err := os.ReadFile(c.certsFile("ca.crt"))
+	if err != nil {
+		return nil, err
+	}
+	if c.caCert, err = parseCertPEM(certPEM); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", c.certsFile("ca.crt"), err)
+	}
+	if c.caKey, err = parseKeyPEM(keyPEM); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Join(dir, "ca.key"), err)
+	}
+	return c, nil
+}
+
+// certsDir returns the directory with the certificate authority and the client
+// certificate the farm nodes need to use the cache registry.
+func (c *cacheCerts) certsDir() string {
+	return filepath.Join(c.dir, "certs")
+}
+
+func (c *cacheCerts) certsFile(name string) string {
+	return filepath.Join(c.certsDir(), name)
+}
+
+func (c *cacheCerts) create() error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	template, err := certTemplate("podman farm cache CA")
+	if err != nil {
+		return err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return err
+	}
+	if c.caCert, err = x509.ParseCertificate(der); err != nil {
+		return err
+	}
+	c.caKey = key
+
+	clientCert, clientKey, err := c.issue("podman farm node", x509.ExtKeyUsageClientAuth, "")
+	if err != nil {
+		return err
+	}
+	caKey, err := marshalKeyPEM(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.certsDir(), 0o700); err != nil {
+		return err
+	}
+	// The key of the authority is written last, files left over by an
+	// interrupted run are replaced the next time.
+	for _, file := range []struct {
+		path string
+		data []byte
+	}{
+		{c.certsFile("ca.crt"), c.caPEM()},
+		{c.certsFile("client.cert"), clientCert},
+		{c.certsFile("client.key"), clientKey},
+		{filepath.Join(c.dir, "ca.key"), caKey},
+	} {
+		if err := os.WriteFile(file.path, file.data, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// caPEM returns the PEM encoded certificate of the authority.
+func (c *cacheCerts) caPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.caCert.Raw})
+}
+
+// fingerprint returns the SHA-256 fingerprint of the certificate of the
+// authority.
+func (c *cacheCerts) fingerprint() string {
+	sum := sha256.Sum256(c.caCert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// serverCert issues the PEM encoded certificate and key the registry serves
+// when reached by host.
+func (c *cacheCerts) serverCert(host string) ([]byte, []byte, error) {
+	return c.issue(host, x509.ExtKeyUsageServerAuth, host)
+}
+
+func (c *cacheCerts) issue(commonName string, usage x509.ExtKeyUsage, host string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	template, err := certTemplate(commonName)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{usage}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else if host != "" {
+		template.DNSNames = []string{host}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, c.caCert, key.Public(), c.caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := marshalKeyPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), keyPEM, nil
+}
+
+func certTemplate(commonName string) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(cacheCertValidity),
+	}, nil
+}
+
+func marshalKeyPEM(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func parseCertPEM(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parseKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("no PEM encoded private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
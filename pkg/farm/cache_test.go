@@ -0,0 +1,146 @@
+//go:build !windows
+
+package farm
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/common/pkg/config"
+)
+
+func TestCacheConfigValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		cache CacheConfig
+		err   string
+	}{
+		{name: "repository", cache: CacheConfig{Repository: "registry.example.com/cache"}},
+		{name: "node", cache: CacheConfig{Node: "node1", Port: 5001}},
+		{name: "both", cache: CacheConfig{Repository: "registry.example.com/cache", Node: "node1"}, err: "either through a repository or a cache node"},
+		{name: "tagged repository", cache: CacheConfig{Repository: "registry.example.com/cache:latest"}, err: "must not have a tag or digest"},
+		{name: "invalid repository", cache: CacheConfig{Repository: "Registry/Cache"}, err: "invalid cache repository"},
+		{name: "port without node", cache: CacheConfig{Port: 5001}, err: "only be set for a cache node"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cache.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCacheConfigStore(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cache, err := GetCacheConfig("farm1")
+	require.NoError(t, err)
+	assert.Nil(t, cache)
+
+	require.NoError(t, SetCacheConfig("farm1", &CacheConfig{Repository: "registry.example.com/cache"}))
+	require.NoError(t, SetCacheConfig("farm2", &CacheConfig{Node: "node1"}))
+	cache, err = GetCacheConfig("farm2")
+	require.NoError(t, err)
+	assert.Equal(t, &CacheConfig{Node: "node1"}, cache)
+	assert.Equal(t, "node node1:5000", cache.String())
+
+	require.NoError(t, SetCacheConfig("farm2", nil))
+	cache, err = GetCacheConfig("farm2")
+	require.NoError(t, err)
+	assert.Nil(t, cache)
+	cache, err = GetCacheConfig("farm1")
+	require.NoError(t, err)
+	assert.Equal(t, "registry.example.com/cache", cache.String())
+
+	assert.Error(t, SetCacheConfig("farm1", &CacheConfig{Port: 1}))
+}
+
+func TestCacheNodeHost(t *testing.T) {
+	tests := []struct {
+		name string
+		con  config.Connection
+		host string
+		err  string
+	}{
+		{name: "ssh", con: config.Connection{Destination: config.Destination{URI: "ssh://user@node1.example.com:2222/run/podman/podman.sock"}}, host: "node1.example.com"},
+		{name: "tcp", con: config.Connection{Destination: config.Destination{URI: "tcp://192.0.2.1:8080"}}, host: "192.0.2.1"},
+		{name: "localhost", con: config.Connection{Destination: config.Destination{URI: "ssh://user@localhost/run/podman/podman.sock"}}, err: "is to the local host"},
+		{name: "loopback", con: config.Connection{Destination: config.Destination{URI: "ssh://user@127.0.0.1:42/run/podman/podman.sock"}}, err: "is to the local host"},
+		{name: "loopback v6", con: config.Connection{Destination: config.Destination{URI: "ssh://user@[::1]:42/run/podman/podman.sock"}}, err: "is to the local host"},
+		{name: "machine", con: config.Connection{Destination: config.Destination{URI: "ssh://core@192.0.2.1:42/run/podman/podman.sock", IsMachine: true}}, err: "is a podman machine"},
+		{name: "unix", con: config.Connection{Destination: config.Destination{URI: "unix:///run/podman/podman.sock"}}, err: "has no host name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, err := CacheNodeHost(&tt.con)
+			if tt.err == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.host, host)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCacheCerts(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "node1")
+	certs, err := loadCacheCerts(dir)
+	require.NoError(t, err)
+	for _, name := range []string{"ca.key", "certs/ca.crt", "certs/client.cert", "certs/client.key"} {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+
+	loaded, err := loadCacheCerts(dir)
+	require.NoError(t, err)
+	assert.Equal(t, certs.fingerprint(), loaded.fingerprint())
+
+	roots := x509.NewCertPool()
+	roots.AddCert(certs.caCert)
+	for _, host := range []string{"node1.example.com", "192.0.2.1"} {
+		certPEM, keyPEM, err := loaded.serverCert(host)
+		require.NoError(t, err)
+		_, err = parseKeyPEM(keyPEM)
+		require.NoError(t, err)
+		cert, err := parseCertPEM(certPEM)
+		require.NoError(t, err)
+		_, err = cert.Verify(x509.VerifyOptions{DNSName: host, Roots: roots})
+		assert.NoError(t, err)
+		_, err = cert.Verify(x509.VerifyOptions{DNSName: "other.example.com", Roots: roots})
+		assert.Error(t, err)
+	}
+
+	clientPEM, err := os.ReadFile(filepath.Join(dir, "certs/client.cert"))
+	require.NoError(t, err)
+	client, err := parseCertPEM(clientPEM)
+	require.NoError(t, err)
+	_, err = client.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	assert.NoError(t, err)
+}
+
+func TestBuildStats(t *testing.T) {
+	var stats buildStats
+	for _, line := range []string{
+		"[1/2] STEP 1/3: FROM alpine AS builder",
+		"[1/2] STEP 2/3: RUN make",
+		"--> Cache pulled from remote registry.example.com/cache:abc",
+		"--> Using cache 0123456789abcdef",
+		"[2/2] STEP 1/2: FROM alpine",
+		"STEP 2/2: COPY --from=builder /out /",
+		"--> Pushing cache registry.example.com/cache:def",
+		"--> 0123456789ab",
+		"COMMIT",
+	} {
+		stats.count(line)
+	}
+	assert.Equal(t, buildStats{steps: 4, cached: 1, pulled: 1, pushed: 1}, stats)
+	assert.Equal(t, "4 steps, 1 cached, 1 pulled from and 1 pushed to the shared cache", stats.String())
+}
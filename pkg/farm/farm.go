@@ -2,11 +2,13 @@ package farm
 
 import (
 	"bufio"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -25,11 +27,101 @@ type Farm struct {
 	name        string
 	localEngine entities.ImageEngine            // not nil -> use local engine, too
 	builders    map[string]entities.ImageEngine // name -> builder
+	cache       *CacheConfig                    // not nil -> share the layer cache
 }
 
 // Schedule is a description of where and how we'll do builds.
 type Schedule struct {
-	platformBuilders map[string]string // target->connection
+	builds []ScheduledBuild
+}
+
+// ScheduledBuild describes on which node the image for a platform is built.
+type ScheduledBuild struct {
+	// Platform is the platform the image is built for.
+	Platform string
+	// Node is the name of the node the image is built on.
+	Node string
+	// Native is true if the node runs on the platform, and false if it
+	// builds the image using emulation.
+	Native bool
+	// CPUs is the number of CPUs of the node.
+	CPUs int
+	// MemFree is the free memory of the node in bytes.
+	MemFree int64
+	// Builds is the number of builds which were running on the node when
+	// the build was scheduled.
+	Builds int
+	// Fallbacks are the names of the other nodes capable of building the
+	// image, in the order the build is retried on them if it fails.
+	Fallbacks []string
+}
+
+// Builds returns the scheduled builds, sorted by platform.
+func (s Schedule) Builds() []ScheduledBuild {
+	return s.builds
+}
+
+// farmNode is a builder and its load while scheduling builds.
+type farmNode struct {
+	name    string
+	inspect *entities.FarmInspectReport
+	// assigned is the number of builds assigned to the node by the
+	// schedule so far.
+	assigned int
+}
+
+// spare returns the number of CPUs of the node which are not busy with
+// builds.
+func (n *farmNode) spare() int {
+	return n.inspect.CPUs - n.inspect.Builds - n.assigned
+}
+
+// candidate is a node capable of building for a platform.
+type candidate struct {
+	*farmNode
+	native bool
+}
+
+// rankCandidates returns the nodes capable of building for the platform, best
+// first: nodes building natively before nodes using emulation, nodes with
+// spare CPUs before busy ones, the local node before remote ones as it saves
+// copying the image, then nodes with more spare CPUs and more free memory.
+func rankCandidates(platform string, nodes []*farmNode) []candidate {
+	var candidates []candidate
+	for _, node := range nodes {
+		switch {
+		case slices.Contains(node.inspect.NativePlatforms, platform):
+			candidates = append(candidates, candidate{farmNode: node, native: true})
+		case slices.Contains(node.inspect.EmulatedPlatforms, platform):
+			candidates = append(candidates, candidate{farmNode: node})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if a.native != b.native {
+			if a.native {
+				return -1
+			}
+			return 1
+		}
+		if aSpare, bSpare := a.spare() > 0, b.spare() > 0; aSpare != bSpare {
+			if aSpare {
+				return -1
+			}
+			return 1
+		}
+		if aLocal, bLocal := a.name == entities.LocalFarmImageBuilderName, b.name == entities.LocalFarmImageBuilderName; aLocal != bLocal {
+			if aLocal {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(
+			cmp.Compare(b.spare(), a.spare()),
+			cmp.Compare(b.inspect.MemFree, a.inspect.MemFree),
+			strings.Compare(a.name, b.name),
+		)
+	})
+	return candidates
 }
 
 func newFarmWithBuilders(_ context.Context, name string, cons []config.Connection, localEngine entities.ImageEngine, buildLocal bool) (*Farm, error) {
@@ -94,7 +186,16 @@ func NewFarm(ctx context.Context, name string, localEngine entities.ImageEngine,
 		return nil, err
 	}
 
-	return newFarmWithBuilders(ctx, name, destinations, localEngine, buildLocal)
+	farm, err := newFarmWithBuilders(ctx, name, destinations, localEngine, buildLocal)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		if farm.cache, err = GetCacheConfig(name); err != nil {
+			return nil, fmt.Errorf("reading layer cache config of farm %q: %w", name, err)
+		}
+	}
+	return farm, nil
 }
 
 // NativePlatforms returns a list of the set of platforms for which the farm
@@ -136,16 +237,16 @@ func (f *Farm) NativePlatforms(ctx context.Context) ([]string, error) {
 	return platforms, nil
 }
 
-// Schedule takes a list of platforms and returns a list of connections which
-// can be used to build for those platforms.  It always prefers native builders
-// over emulated builders, but will assign a builder which can use emulation
-// for a platform if no suitable native builder is available.
+// Schedule takes a list of platforms and assigns each of them to a node
+// which can build for it.  It prefers nodes building natively over nodes using
+// emulation, and among them the ones with the most spare capacity, based on
+// the number of CPUs, free memory and running builds reported by each node.
+// Builds assigned by the schedule count towards the load of the node, so the
+// builds are spread over nodes of similar capacity.  The other capable nodes
+// are kept as fallbacks for retrying failed builds.
 //
 // If platforms is an empty list, all available native platforms will be
 // scheduled.
-//
-// TODO: add (Priority,Weight *int) a la RFC 2782 to destinations that we know
-// of, and factor those in when assigning builds to nodes in here.
 func (f *Farm) Schedule(ctx context.Context, platforms []string) (Schedule, error) {
 	var (
 		err       error
@@ -160,33 +261,17 @@ func (f *Farm) Schedule(ctx context.Context, platforms []string) (Schedule, erro
 		}
 	}
 
-	platformBuilders := make(map[string]string)
-	native := make(map[string]string)
-	emulated := make(map[string]string)
-	var localPlatform string
-	// Make notes of which platforms we can build for natively, and which
-	// ones we can build for using emulation.
+	nodes := make([]*farmNode, 0, len(f.builders))
 	for name, engine := range f.builders {
 		infoGroup.Go(func() error {
 			inspect, err := engine.FarmNodeInspect(ctx)
 			if err != nil {
 				return err
 			}
+			logrus.Debugf("Node %q: %d CPUs, %d bytes of memory free, %d builds running", name, inspect.CPUs, inspect.MemFree, inspect.Builds)
 			infoMutex.Lock()
 			defer infoMutex.Unlock()
-			for _, n := range inspect.NativePlatforms {
-				if _, assigned := native[n]; !assigned {
-					native[n] = name
-				}
-				if name == entities.LocalFarmImageBuilderName {
-					localPlatform = n
-				}
-			}
-			for _, e := range inspect.EmulatedPlatforms {
-				if _, assigned := emulated[e]; !assigned {
-					emulated[e] = name
-				}
-			}
+			nodes = append(nodes, &farmNode{name: name, inspect: inspect})
 			return nil
 		})
 	}
@@ -196,30 +281,40 @@ func (f *Farm) Schedule(ctx context.Context, platforms []string) (Schedule, erro
 			return Schedule{}, err
 		}
 	}
-	// Assign a build to the first node that could build it natively, and
-	// if there isn't one, the first one that can build it with the help of
-	// emulation, and if there aren't any, error out.
-	for _, platform := range platforms {
-		if builder, ok := native[platform]; ok {
-			platformBuilders[platform] = builder
-		} else if builder, ok := emulated[platform]; ok {
-			platformBuilders[platform] = builder
-		} else {
+	// Sort the nodes, so that nodes which are equally suitable are picked
+	// in a stable order.
+	slices.SortFunc(nodes, func(a, b *farmNode) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	platforms = slices.Clone(platforms)
+	slices.Sort(platforms)
+	builds := make([]ScheduledBuild, 0, len(platforms))
+	for _, platform := range slices.Compact(platforms) {
+		candidates := rankCandidates(platform, nodes)
+		if len(candidates) == 0 {
 			return Schedule{}, fmt.Errorf("no builder capable of building for platform %q available", platform)
 		}
+		chosen := candidates[0]
+		build := ScheduledBuild{
+			Platform: platform,
+			Node:     chosen.name,
+			Native:   chosen.native,
+			CPUs:     chosen.inspect.CPUs,
+			MemFree:  chosen.inspect.MemFree,
+			Builds:   chosen.inspect.Builds,
+		}
+		for _, fallback := range candidates[1:] {
+			build.Fallbacks = append(build.Fallbacks, fallback.name)
+		}
+		chosen.assigned++
+		builds = append(builds, build)
 	}
-	// If local is set, prioritize building on local
-	if localPlatform != "" {
-		platformBuilders[localPlatform] = entities.LocalFarmImageBuilderName
-	}
-	schedule := Schedule{
-		platformBuilders: platformBuilders,
-	}
-	return schedule, nil
+	return Schedule{builds: builds}, nil
 }
 
-// Build runs a build using the specified targetplatform:service map.  If all
-// builds succeed, it copies the resulting images from the remote hosts to the
+// Build runs the builds of the schedule.  A failed build is retried on the
+// fallback nodes of its platform.  If all builds succeed, it copies the resulting images from the remote hosts to the
 // local service and builds a manifest list with the specified reference name.
 func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.BuildOptions, reference string, _ entities.ImageEngine) error {
 	switch options.OutputFormat {
@@ -230,39 +325,8 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 	case define.Dockerv2ImageManifest:
 	}
 
-	// Build the list of jobs.
-	var jobs sync.Map
-	type job struct {
-		platform string
-		os       string
-		arch     string
-		variant  string
-		builder  entities.ImageEngine
-	}
-	for platform, builderName := range schedule.platformBuilders { // prepare to build
-		builder, ok := f.builders[builderName]
-		if !ok {
-			return fmt.Errorf("unknown builder %q", builderName)
-		}
-		var rawOS, rawArch, rawVariant string
-		p := strings.Split(platform, "/")
-		if len(p) > 0 && p[0] != "" {
-			rawOS = p[0]
-		}
-		if len(p) > 1 {
-			rawArch = p[1]
-		}
-		if len(p) > 2 {
-			rawVariant = p[2]
-		}
-		os, arch, variant := lplatform.Normalize(rawOS, rawArch, rawVariant)
-		jobs.Store(builderName, job{
-			platform: platform,
-			os:       os,
-			arch:     arch,
-			variant:  variant,
-			builder:  builder,
-		})
+	if err := f.shareCache(ctx, &options); err != nil {
+		return fmt.Errorf("setting up the layer cache: %w", err)
 	}
 
 	listBuilderOptions := listBuilderOptions{
@@ -281,64 +345,53 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 	type buildResult struct {
 		report  entities.BuildReport
 		builder entities.ImageEngine
+		node    string
+		stats   buildStats
 	}
-	for platform, builder := range schedule.platformBuilders {
-		outReader, outWriter := io.Pipe()
-		errReader, errWriter := io.Pipe()
-		go func() {
-			defer outReader.Close()
-			reader := bufio.NewReader(outReader)
-			writer := options.Out
-			if writer == nil {
-				writer = os.Stdout
-			}
-			line, err := reader.ReadString('\n')
-			for err == nil {
-				line = strings.TrimSuffix(line, "\n")
-				fmt.Fprintf(writer, "[%s@%s] %s\n", platform, builder, line)
-				line, err = reader.ReadString('\n')
-			}
-		}()
-		go func() {
-			defer errReader.Close()
-			reader := bufio.NewReader(errReader)
-			writer := options.Err
-			if writer == nil {
-				writer = os.Stderr
-			}
-			line, err := reader.ReadString('\n')
-			for err == nil {
-				line = strings.TrimSuffix(line, "\n")
-				fmt.Fprintf(writer, "[%s@%s] %s\n", platform, builder, line)
-				line, err = reader.ReadString('\n')
-			}
-		}()
+	for _, scheduled := range schedule.builds {
 		buildGroup.Go(func() error {
-			var j job
-			defer outWriter.Close()
-			defer errWriter.Close()
-			c, ok := jobs.Load(builder)
-			if !ok {
-				return fmt.Errorf("unknown connection for %q (shouldn't happen)", builder)
+			var rawOS, rawArch, rawVariant string
+			p := strings.Split(scheduled.Platform, "/")
+			if len(p) > 0 && p[0] != "" {
+				rawOS = p[0]
 			}
-			if j, ok = c.(job); !ok {
-				return fmt.Errorf("unexpected connection type for %q (shouldn't happen)", builder)
+			if len(p) > 1 {
+				rawArch = p[1]
 			}
+			if len(p) > 2 {
+				rawVariant = p[2]
+			}
+			platformOS, arch, variant := lplatform.Normalize(rawOS, rawArch, rawVariant)
 			buildOptions := options
-			buildOptions.Platforms = []struct{ OS, Arch, Variant string }{{j.os, j.arch, j.variant}}
-			buildOptions.Out = outWriter
-			buildOptions.Err = errWriter
-			fmt.Printf("Starting build for %v at %q\n", buildOptions.Platforms, builder)
-			buildReport, err := j.builder.Build(ctx, options.ContainerFiles, buildOptions)
-			if err != nil {
-				return fmt.Errorf("building for %q on %q: %w", j.platform, builder, err)
+			buildOptions.Platforms = []struct{ OS, Arch, Variant string }{{platformOS, arch, variant}}
+
+			// Try the scheduled node first, then the fallbacks in order.
+			var attemptErrors *multierror.Error
+			for i, builderName := range append([]string{scheduled.Node}, scheduled.Fallbacks...) {
+				builder, ok := f.builders[builderName]
+				if !ok {
+					return fmt.Errorf("unknown builder %q", builderName)
+				}
+				if i > 0 {
+					fmt.Printf("Retrying build for %v at %q\n", buildOptions.Platforms, builderName)
+				}
+				buildReport, stats, err := buildOn(ctx, scheduled.Platform, builderName, builder, buildOptions)
+				if err != nil {
+					err = fmt.Errorf("building for %q on %q: %w", scheduled.Platform, builderName, err)
+					fmt.Fprintln(os.Stderr, err)
+					attemptErrors = multierror.Append(attemptErrors, err)
+					continue
+				}
+				fmt.Printf("finished build for %v at %q: built %s\n", buildOptions.Platforms, builderName, buildReport.ID)
+				buildResults.Store(scheduled.Platform, buildResult{
+					report:  *buildReport,
+					builder: builder,
+					node:    builderName,
+					stats:   stats,
+				})
+				return nil
 			}
-			fmt.Printf("finished build for %v at %q: built %s\n", buildOptions.Platforms, builder, buildReport.ID)
-			buildResults.Store(platform, buildResult{
-				report:  *buildReport,
-				builder: j.builder,
-			})
-			return nil
+			return attemptErrors.ErrorOrNil()
 		})
 	}
 	buildErrors := buildGroup.Wait()
@@ -348,15 +401,22 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 
 	// Assemble the final result.
 	perArchBuilds := make(map[entities.BuildReport]entities.ImageEngine)
-	buildResults.Range(func(_, v any) bool {
+	var summary []string
+	buildResults.Range(func(k, v any) bool {
 		result, ok := v.(buildResult)
 		if !ok {
 			fmt.Fprintf(os.Stderr, "report %v not a build result?\n", v)
 			return false
 		}
 		perArchBuilds[result.report] = result.builder
+		summary = append(summary, fmt.Sprintf("  %s on %q: %s", k, result.node, result.stats))
 		return true
 	})
+	sort.Strings(summary)
+	fmt.Println("Layer cache:")
+	for _, line := range summary {
+		fmt.Println(line)
+	}
 	location, err := manifestListBuilder.build(ctx, perArchBuilds)
 	if err != nil {
 		return err
@@ -365,6 +425,61 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 	return nil
 }
 
+// buildOn runs the build for the platform on the builder, prefixing every line
+// of its output with the platform and the name of the builder.  It returns
+// the cache statistics of the build, collected from its output.
+func buildOn(ctx context.Context, platform, builderName string, builder entities.ImageEngine, options entities.BuildOptions) (*entities.BuildReport, buildStats, error) {
+	var (
+		stats  buildStats
+		output sync.WaitGroup
+	)
+	outReader, outWriter := io.Pipe()
+	errReader, errWriter := io.Pipe()
+	output.Add(2)
+	go func() {
+		defer output.Done()
+		defer outReader.Close()
+		reader := bufio.NewReader(outReader)
+		writer := options.Out
+		if writer == nil {
+			writer = os.Stdout
+		}
+		line, err := reader.ReadString('\n')
+		for err == nil {
+			line = strings.TrimSuffix(line, "\n")
+			stats.count(line)
+			fmt.Fprintf(writer, "[%s@%s] %s\n", platform, builderName, line)
+			line, err = reader.ReadString('\n')
+		}
+	}()
+	go func() {
+		defer output.Done()
+		defer errReader.Close()
+		reader := bufio.NewReader(errReader)
+		writer := options.Err
+		if writer == nil {
+			writer = os.Stderr
+		}
+		line, err := reader.ReadString('\n')
+		for err == nil {
+			line = strings.TrimSuffix(line, "\n")
+			fmt.Fprintf(writer, "[%s@%s] %s\n", platform, builderName, line)
+			line, err = reader.ReadString('\n')
+		}
+	}()
+
+	buildOptions := options
+	buildOptions.Out = outWriter
+	buildOptions.Err = errWriter
+	fmt.Printf("Starting build for %v at %q\n", buildOptions.Platforms, builderName)
+	report, err := builder.Build(ctx, options.ContainerFiles, buildOptions)
+	outWriter.Close()
+	errWriter.Close()
+	// Wait for the output, it is counted in the statistics.
+	output.Wait()
+	return report, stats, err
+}
+
 func getFarmDestinations(name string) (string, []config.Connection, error) {
 	cfg, err := config.Default()
 	if err != nil {
@@ -0,0 +1,123 @@
+package farm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNode struct {
+	entities.ImageEngine
+	inspect entities.FarmInspectReport
+}
+
+func (n *fakeNode) FarmNodeInspect(_ context.Context) (*entities.FarmInspectReport, error) {
+	inspect := n.inspect
+	return &inspect, nil
+}
+
+func testFarm(nodes map[string]entities.FarmInspectReport) *Farm {
+	f := &Farm{name: "test", builders: make(map[string]entities.ImageEngine)}
+	for name, inspect := range nodes {
+		f.builders[name] = &fakeNode{inspect: inspect}
+	}
+	return f
+}
+
+func TestSchedule(t *testing.T) {
+	amd64 := []string{"linux/amd64"}
+	arm64 := []string{"linux/arm64"}
+	both := []string{"linux/amd64", "linux/arm64"}
+	tests := []struct {
+		name      string
+		nodes     map[string]entities.FarmInspectReport
+		platforms []string
+		expected  []ScheduledBuild
+	}{
+		{
+			name: "native over emulated",
+			nodes: map[string]entities.FarmInspectReport{
+				"emulating": {NativePlatforms: amd64, EmulatedPlatforms: arm64, CPUs: 64},
+				"native":    {NativePlatforms: arm64, CPUs: 2, Builds: 4},
+			},
+			platforms: arm64,
+			expected: []ScheduledBuild{
+				{Platform: "linux/arm64", Node: "native", Native: true, CPUs: 2, Builds: 4, Fallbacks: []string{"emulating"}},
+			},
+		},
+		{
+			name: "emulated if no native node",
+			nodes: map[string]entities.FarmInspectReport{
+				"emulating": {NativePlatforms: amd64, EmulatedPlatforms: arm64, CPUs: 4},
+			},
+			platforms: arm64,
+			expected: []ScheduledBuild{
+				{Platform: "linux/arm64", Node: "emulating", CPUs: 4},
+			},
+		},
+		{
+			name: "spare capacity over busy node",
+			nodes: map[string]entities.FarmInspectReport{
+				entities.LocalFarmImageBuilderName: {NativePlatforms: amd64, CPUs: 4, Builds: 4},
+				"idle":                             {NativePlatforms: amd64, CPUs: 2},
+			},
+			platforms: amd64,
+			expected: []ScheduledBuild{
+				{Platform: "linux/amd64", Node: "idle", Native: true, CPUs: 2, Fallbacks: []string{entities.LocalFarmImageBuilderName}},
+			},
+		},
+		{
+			name: "local over remote with spare capacity",
+			nodes: map[string]entities.FarmInspectReport{
+				entities.LocalFarmImageBuilderName: {NativePlatforms: amd64, CPUs: 2, Builds: 1},
+				"big":                              {NativePlatforms: amd64, CPUs: 64},
+			},
+			platforms: amd64,
+			expected: []ScheduledBuild{
+				{Platform: "linux/amd64", Node: entities.LocalFarmImageBuilderName, Native: true, CPUs: 2, Builds: 1, Fallbacks: []string{"big"}},
+			},
+		},
+		{
+			name: "more spare CPUs, then more free memory",
+			nodes: map[string]entities.FarmInspectReport{
+				"a": {NativePlatforms: amd64, CPUs: 8, Builds: 6, MemFree: 4096},
+				"b": {NativePlatforms: amd64, CPUs: 4, MemFree: 1024},
+				"c": {NativePlatforms: amd64, CPUs: 4, MemFree: 2048},
+			},
+			platforms: amd64,
+			expected: []ScheduledBuild{
+				{Platform: "linux/amd64", Node: "c", Native: true, CPUs: 4, MemFree: 2048, Fallbacks: []string{"b", "a"}},
+			},
+		},
+		{
+			name: "builds are spread",
+			nodes: map[string]entities.FarmInspectReport{
+				"a": {NativePlatforms: both, CPUs: 1},
+				"b": {NativePlatforms: both, CPUs: 1},
+			},
+			platforms: []string{"linux/arm64", "linux/amd64", "linux/arm64"},
+			expected: []ScheduledBuild{
+				{Platform: "linux/amd64", Node: "a", Native: true, CPUs: 1, Fallbacks: []string{"b"}},
+				{Platform: "linux/arm64", Node: "b", Native: true, CPUs: 1, Fallbacks: []string{"a"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := testFarm(tt.nodes).Schedule(context.Background(), tt.platforms)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.Builds())
+		})
+	}
+}
+
+func TestScheduleNoBuilder(t *testing.T) {
+	f := testFarm(map[string]entities.FarmInspectReport{
+		"node": {NativePlatforms: []string{"linux/amd64"}, CPUs: 4},
+	})
+	_, err := f.Schedule(context.Background(), []string{"linux/s390x"})
+	assert.EqualError(t, err, `no builder capable of building for platform "linux/s390x" available`)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/containers/podman/v6/pkg/domain/entities"
 	"github.com/containers/podman/v6/pkg/domain/filters"
 	psdefine "github.com/containers/podman/v6/pkg/ps/define"
+	"github.com/containers/podman/v6/pkg/where"
 	"github.com/sirupsen/logrus"
 	libnetworkTypes "go.podman.io/common/libnetwork/types"
 	"go.podman.io/storage"
@@ -34,6 +35,17 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 	filterFuncs := make([]libpod.ContainerFilter, 0, len(options.Filters))
 	filterExtFuncs := make([]entities.ExternalContainerFilter, 0, len(options.Filters))
 	all := options.All || options.Last > 0
+	var whereExpr *where.Expression
+	if options.Where != "" {
+		if options.External {
+			return nil, errors.New("--where cannot be used with external containers")
+		}
+		var err error
+		whereExpr, err = where.Compile(options.Where)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if len(options.Filters) > 0 {
 		for k, v := range options.Filters {
 			generatedFunc, err := filters.GenerateContainerFilterFuncs(k, v, runtime)
@@ -76,6 +88,11 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 	if err != nil {
 		return nil, err
 	}
+	if whereExpr != nil {
+		if cons, err = filterWhere(cons, whereExpr); err != nil {
+			return nil, err
+		}
+	}
 	if options.Last > 0 {
 		// Sort the libpod containers
 		sort.Sort(SortCreateTime{SortContainers: cons})
@@ -118,6 +135,29 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 	return pss, nil
 }
 
+// filterWhere returns the containers whose inspect data matches expr.
+func filterWhere(cons []*libpod.Container, expr *where.Expression) ([]*libpod.Container, error) {
+	filtered := make([]*libpod.Container, 0, len(cons))
+	for _, con := range cons {
+		data, err := con.Inspect(false)
+		switch {
+		// ignore both no ctr and no such pod errors as it means the ctr is gone now
+		case errors.Is(err, define.ErrNoSuchCtr), errors.Is(err, define.ErrNoSuchPod):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		match, err := expr.MatchValue(data)
+		if err != nil {
+			return nil, fmt.Errorf("container %s: %w", con.ID(), err)
+		}
+		if match {
+			filtered = append(filtered, con)
+		}
+	}
+	return filtered, nil
+}
+
 // GetExternalContainerLists returns list of external containers for e.g. created by buildah
 func GetExternalContainerLists(runtime *libpod.Runtime, filterExtFuncs ...entities.ExternalContainerFilter) ([]entities.ListContainer, error) {
 	pss := []*entities.ListContainer{}
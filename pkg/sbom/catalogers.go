@@ -0,0 +1,330 @@
+package sbom
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"debug/buildinfo"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/textproto"
+	"path"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxBinarySize is the largest executable inspected for Go build information.
+const maxBinarySize = 256 << 20
+
+var (
+	osReleasePaths = []string{"/etc/os-release", "/usr/lib/os-release"}
+
+	dpkgStatusPath = "/var/lib/dpkg/status"
+	// dpkgStatusDir is used by distroless images, one file per package.
+	dpkgStatusDir  = "/var/lib/dpkg/status.d"
+	apkDBPath      = "/lib/apk/db/installed"
+	rpmSQLitePaths = []string{
+		"/usr/lib/sysimage/rpm/rpmdb.sqlite",
+		"/var/lib/rpm/rpmdb.sqlite",
+	}
+	// rpmLegacyPaths are the Berkeley DB and ndb backends which are not
+	// supported.
+	rpmLegacyPaths = []string{
+		"/var/lib/rpm/Packages",
+		"/usr/lib/sysimage/rpm/Packages.db",
+		"/var/lib/rpm/Packages.db",
+	}
+)
+
+// cataloger extracts packages from a single file of a layer.
+type cataloger func(name string, hdr *tar.Header, r io.Reader) ([]Package, error)
+
+func isOSRelease(name string) bool {
+	for _, p := range osReleasePaths {
+		if name == p {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupCataloger returns the cataloger for the file or nil if the file is
+// not of interest.
+func lookupCataloger(name string, hdr *tar.Header) cataloger {
+	dir, base := path.Split(name)
+	dir = path.Clean(dir)
+	switch {
+	case name == dpkgStatusPath:
+		return parseDpkgStatus
+	case dir == dpkgStatusDir && !strings.HasSuffix(base, ".md5sums"):
+		return parseDpkgStatus
+	case name == apkDBPath:
+		return parseApkDB
+	case base == "METADATA" && strings.HasSuffix(dir, ".dist-info"),
+		base == "PKG-INFO" && strings.HasSuffix(dir, ".egg-info"):
+		return parsePythonMetadata
+	case base == "package.json" && isNodeModule(dir):
+		return parsePackageJSON
+	}
+	for _, p := range rpmSQLitePaths {
+		if name == p {
+			return parseRpmDB
+		}
+	}
+	for _, p := range rpmLegacyPaths {
+		if name == p {
+			logrus.Warnf("Skipping %s: only the sqlite rpm database format is supported", name)
+			return nil
+		}
+	}
+	if hdr.Mode&0o111 != 0 && hdr.Size > 4 && hdr.Size <= maxBinarySize {
+		return parseGoBinary
+	}
+	return nil
+}
+
+// isNodeModule reports whether dir is a package directory directly below a
+// node_modules directory, taking scoped packages into account.
+func isNodeModule(dir string) bool {
+	parent, _ := path.Split(dir)
+	parent = path.Clean(parent)
+	if path.Base(parent) == "node_modules" {
+		return true
+	}
+	grandParent := path.Dir(parent)
+	return strings.HasPrefix(path.Base(parent), "@") && path.Base(grandParent) == "node_modules"
+}
+
+func parseOSRelease(r io.Reader) (*OSRelease, error) {
+	rel := &OSRelease{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		switch key {
+		case "ID":
+			rel.ID = value
+		case "VERSION_ID":
+			rel.VersionID = value
+		case "NAME":
+			rel.Name = value
+		case "PRETTY_NAME":
+			rel.PrettyName = value
+		}
+	}
+	return rel, scanner.Err()
+}
+
+// readStanzas splits r into RFC 822 style paragraphs separated by empty lines.
+func readStanzas(r io.Reader) ([]textproto.MIMEHeader, error) {
+	var stanzas []textproto.MIMEHeader
+	tp := textproto.NewReader(bufio.NewReader(r))
+	for {
+		hdr, err := tp.ReadMIMEHeader()
+		if len(hdr) > 0 {
+			stanzas = append(stanzas, hdr)
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return stanzas, nil
+			}
+			return stanzas, err
+		}
+	}
+}
+
+func parseDpkgStatus(_ string, _ *tar.Header, r io.Reader) ([]Package, error) {
+	stanzas, err := readStanzas(r)
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []Package
+	for _, s := range stanzas {
+		// Only report packages that are actually installed, the status
+		// file also keeps removed packages with their config files.
+		if status := s.Get("Status"); status != "" && !strings.HasSuffix(status, " installed") {
+			continue
+		}
+		if s.Get("Package") == "" {
+			continue
+		}
+		// The Source field may carry the source version in
+		// parentheses, e.g. "glibc (2.36-9)".
+		source, _, _ := strings.Cut(s.Get("Source"), " ")
+		pkgs = append(pkgs, Package{
+			Name:    s.Get("Package"),
+			Version: s.Get("Version"),
+			Source:  source,
+			Arch:    s.Get("Architecture"),
+			Type:    TypeDeb,
+		})
+	}
+	return pkgs, nil
+}
+
+func parseApkDB(_ string, _ *tar.Header, r io.Reader) ([]Package, error) {
+	var (
+		pkgs []Package
+		cur  Package
+	)
+	flush := func() {
+		if cur.Name != "" {
+			cur.Type = TypeAPK
+			pkgs = append(pkgs, cur)
+		}
+		cur = Package{}
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "P":
+			cur.Name = value
+		case "V":
+			cur.Version = value
+		case "A":
+			cur.Arch = value
+		case "L":
+			cur.License = value
+		case "o":
+			if value != cur.Name {
+				cur.Source = value
+			}
+		}
+	}
+	flush()
+	return pkgs, scanner.Err()
+}
+
+func parsePythonMetadata(_ string, _ *tar.Header, r io.Reader) ([]Package, error) {
+	// Only the header is of interest, the description body follows the
+	// first empty line.
+	hdr, err := textproto.NewReader(bufio.NewReader(r)).ReadMIMEHeader()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if hdr.Get("Name") == "" {
+		return nil, nil
+	}
+	license := hdr.Get("License-Expression")
+	if license == "" {
+		license = hdr.Get("License")
+	}
+	return []Package{{
+		Name:    hdr.Get("Name"),
+		Version: hdr.Get("Version"),
+		License: license,
+		Type:    TypePython,
+	}}, nil
+}
+
+func parsePackageJSON(_ string, _ *tar.Header, r io.Reader) ([]Package, error) {
+	var manifest struct {
+		Name    string          `json:"name"`
+		Version string          `json:"version"`
+		License json.RawMessage `json:"license"`
+	}
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Name == "" {
+		return nil, nil
+	}
+	return []Package{{
+		Name:    manifest.Name,
+		Version: manifest.Version,
+		License: npmLicense(manifest.License),
+		Type:    TypeNPM,
+	}}, nil
+}
+
+// npmLicense handles both the current string form and the deprecated
+// {"type": "..."} object form of the license field.
+func npmLicense(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var obj struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		return obj.Type
+	}
+	return ""
+}
+
+func parseGoBinary(_ string, hdr *tar.Header, r io.Reader) ([]Package, error) {
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(magic, []byte("\x7fELF")) {
+		return nil, nil
+	}
+	data := make([]byte, hdr.Size)
+	copy(data, magic)
+	if _, err := io.ReadFull(r, data[len(magic):]); err != nil {
+		return nil, err
+	}
+	info, err := buildinfo.Read(bytes.NewReader(data))
+	if err != nil {
+		// Not a Go binary or stripped of its build information.
+		return nil, nil //nolint:nilerr
+	}
+
+	pkgs := []Package{{
+		Name:    "stdlib",
+		Version: info.GoVersion,
+		License: "BSD-3-Clause",
+		Type:    TypeGo,
+	}}
+	if info.Main.Path != "" {
+		pkgs = append(pkgs, Package{
+			Name:    info.Main.Path,
+			Version: goModuleVersion(info.Main.Version),
+			Type:    TypeGo,
+		})
+	}
+	for _, dep := range info.Deps {
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		pkgs = append(pkgs, Package{
+			Name:    dep.Path,
+			Version: goModuleVersion(dep.Version),
+			Type:    TypeGo,
+		})
+	}
+	return pkgs, nil
+}
+
+// goModuleVersion drops the placeholder used for modules built from a local
+// checkout.
+func goModuleVersion(v string) string {
+	if v == "(devel)" {
+		return ""
+	}
+	return v
+}
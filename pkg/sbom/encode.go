@@ -0,0 +1,267 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Supported output formats.
+const (
+	FormatSPDX      = "spdx"
+	FormatCycloneDX = "cyclonedx"
+)
+
+// Media types of the encoded documents.
+const (
+	MediaTypeSPDX      = "application/spdx+json"
+	MediaTypeCycloneDX = "application/vnd.cyclonedx+json"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{FormatSPDX, FormatCycloneDX}
+
+// Document is the format independent content of an SBOM.
+type Document struct {
+	// Name of the image, or its ID if it has no name.
+	Name string
+	// Digest of the image manifest.
+	Digest string
+	// Created is the time the document was generated.
+	Created time.Time
+	// ToolVersion is the podman version generating the document.
+	ToolVersion string
+	// OS of the image, may be nil.
+	OS *OSRelease
+	// Packages found in the image.
+	Packages []Package
+}
+
+// MediaType returns the media type of documents in the given format.
+func MediaType(format string) (string, error) {
+	switch format {
+	case FormatSPDX:
+		return MediaTypeSPDX, nil
+	case FormatCycloneDX:
+		return MediaTypeCycloneDX, nil
+	}
+	return "", fmt.Errorf("unsupported SBOM format %q, must be one of %v", format, Formats)
+}
+
+// Encode writes doc in the given format to w.
+func Encode(w io.Writer, format string, doc *Document) error {
+	var v any
+	switch format {
+	case FormatSPDX:
+		v = toSPDX(doc)
+	case FormatCycloneDX:
+		v = toCycloneDX(doc)
+	default:
+		_, err := MediaType(format)
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name                  string            `json:"name"`
+	SPDXID                string            `json:"SPDXID"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	LicenseConcluded      string            `json:"licenseConcluded"`
+	LicenseDeclared       string            `json:"licenseDeclared"`
+	LicenseComments       string            `json:"licenseComments,omitempty"`
+	SourceInfo            string            `json:"sourceInfo,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+const spdxNoAssertion = "NOASSERTION"
+
+func toSPDX(doc *Document) *spdxDocument {
+	const imageID = "SPDXRef-Image"
+	out := &spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              doc.Name,
+		DocumentNamespace: "https://podman.io/spdx/" + url.PathEscape(doc.Name) + "-" + uuid.NewString(),
+		CreationInfo: spdxCreationInfo{
+			Created:  doc.Created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: podman-" + doc.ToolVersion},
+		},
+		Packages: []spdxPackage{{
+			Name:                  doc.Name,
+			SPDXID:                imageID,
+			VersionInfo:           doc.Digest,
+			DownloadLocation:      spdxNoAssertion,
+			LicenseConcluded:      spdxNoAssertion,
+			LicenseDeclared:       spdxNoAssertion,
+			PrimaryPackagePurpose: "CONTAINER",
+		}},
+		Relationships: []spdxRelationship{{
+			SPDXElementID:      "SPDXRef-DOCUMENT",
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: imageID,
+		}},
+	}
+	for i := range doc.Packages {
+		p := &doc.Packages[i]
+		id := "SPDXRef-Package-" + strconv.Itoa(i+1)
+		pkg := spdxPackage{
+			Name:             p.Name,
+			SPDXID:           id,
+			VersionInfo:      p.Version,
+			DownloadLocation: spdxNoAssertion,
+			// Package metadata rarely carries valid SPDX license
+			// expressions, keep the declared value as a comment.
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  spdxNoAssertion,
+			SourceInfo:       "found in " + p.Location,
+			ExternalRefs: []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  p.PURL(doc.OS),
+			}},
+		}
+		if p.License != "" {
+			pkg.LicenseComments = "declared license: " + p.License
+		}
+		out.Packages = append(out.Packages, pkg)
+		out.Relationships = append(out.Relationships, spdxRelationship{
+			SPDXElementID:      imageID,
+			RelationshipType:   "CONTAINS",
+			RelatedSPDXElement: id,
+		})
+	}
+	return out
+}
+
+type cdxDocument struct {
+	BOMFormat    string         `json:"bomFormat"`
+	SpecVersion  string         `json:"specVersion"`
+	SerialNumber string         `json:"serialNumber"`
+	Version      int            `json:"version"`
+	Metadata     cdxMetadata    `json:"metadata"`
+	Components   []cdxComponent `json:"components"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type       string        `json:"type"`
+	BOMRef     string        `json:"bom-ref,omitempty"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version,omitempty"`
+	PURL       string        `json:"purl,omitempty"`
+	Licenses   []cdxLicense  `json:"licenses,omitempty"`
+	Properties []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxLicense struct {
+	License cdxLicenseName `json:"license"`
+}
+
+type cdxLicenseName struct {
+	Name string `json:"name"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func toCycloneDX(doc *Document) *cdxDocument {
+	out := &cdxDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + uuid.NewString(),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: doc.Created.UTC().Format(time.RFC3339),
+			Tools: cdxTools{Components: []cdxComponent{{
+				Type:    "application",
+				Name:    "podman",
+				Version: doc.ToolVersion,
+			}}},
+			Component: cdxComponent{
+				Type:    "container",
+				BOMRef:  "image",
+				Name:    doc.Name,
+				Version: doc.Digest,
+			},
+		},
+		Components: []cdxComponent{},
+	}
+	if doc.OS != nil && doc.OS.ID != "" {
+		out.Components = append(out.Components, cdxComponent{
+			Type:    "operating-system",
+			BOMRef:  "os",
+			Name:    doc.OS.ID,
+			Version: doc.OS.VersionID,
+		})
+	}
+	for i := range doc.Packages {
+		p := &doc.Packages[i]
+		c := cdxComponent{
+			Type:    "library",
+			BOMRef:  "pkg-" + strconv.Itoa(i+1),
+			Name:    p.Name,
+			Version: p.Version,
+			PURL:    p.PURL(doc.OS),
+			Properties: []cdxProperty{
+				{Name: "podman:package:type", Value: p.Type},
+				{Name: "podman:package:location", Value: p.Location},
+			},
+		}
+		if p.License != "" {
+			c.Licenses = []cdxLicense{{License: cdxLicenseName{Name: p.License}}}
+		}
+		out.Components = append(out.Components, c)
+	}
+	return out
+}
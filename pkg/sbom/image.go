@@ -0,0 +1,34 @@
+//go:build !remote
+
+package sbom
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v6/libpod"
+	"go.podman.io/common/libimage"
+)
+
+// ScanImage applies the layers of img from the bottom up to a new catalog.
+func ScanImage(r *libpod.Runtime, img *libimage.Image) (*Catalog, error) {
+	layers, err := r.ImageLayerIDs(img)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := NewCatalog()
+	for _, id := range layers {
+		rc, err := r.LayerDiff(id)
+		if err != nil {
+			return nil, fmt.Errorf("reading layer %s: %w", id, err)
+		}
+		err = catalog.AddLayer(rc)
+		if closeErr := rc.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("scanning layer %s: %w", id, err)
+		}
+	}
+	return catalog, nil
+}
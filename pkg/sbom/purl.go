@@ -0,0 +1,85 @@
+package sbom
+
+import (
+	"net/url"
+	"strings"
+)
+
+// PURL returns the package URL of p as defined by
+// https://github.com/package-url/purl-spec. rel is used for the namespace
+// and distro qualifier of distribution packages and may be nil.
+func (p *Package) PURL(rel *OSRelease) string {
+	var (
+		purlType  string
+		namespace string
+		name      = p.Name
+		version   = p.Version
+		quals     [][2]string
+	)
+
+	switch p.Type {
+	case TypeDeb, TypeRPM, TypeAPK:
+		purlType = p.Type
+		if rel != nil {
+			namespace = rel.ID
+		}
+		if p.Arch != "" {
+			quals = append(quals, [2]string{"arch", p.Arch})
+		}
+		if rel != nil && rel.ID != "" && rel.VersionID != "" {
+			quals = append(quals, [2]string{"distro", rel.ID + "-" + rel.VersionID})
+		}
+		if p.Epoch != "" && p.Epoch != "0" {
+			quals = append(quals, [2]string{"epoch", p.Epoch})
+		}
+	case TypePython:
+		purlType = "pypi"
+		// PyPI names are case insensitive and treat _ and - alike.
+		name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	case TypeNPM:
+		purlType = "npm"
+		if scope, rest, ok := strings.Cut(name, "/"); ok && strings.HasPrefix(scope, "@") {
+			namespace, name = scope, rest
+		}
+	case TypeGo:
+		purlType = "golang"
+		if i := strings.LastIndex(name, "/"); i > 0 {
+			namespace, name = name[:i], name[i+1:]
+		}
+	default:
+		purlType = "generic"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("pkg:")
+	sb.WriteString(purlType)
+	sb.WriteString("/")
+	if namespace != "" {
+		for _, segment := range strings.Split(namespace, "/") {
+			sb.WriteString(purlEscape(segment))
+			sb.WriteString("/")
+		}
+	}
+	sb.WriteString(purlEscape(name))
+	if version != "" {
+		sb.WriteString("@")
+		sb.WriteString(purlEscape(version))
+	}
+	for i, q := range quals {
+		if i == 0 {
+			sb.WriteString("?")
+		} else {
+			sb.WriteString("&")
+		}
+		sb.WriteString(q[0])
+		sb.WriteString("=")
+		sb.WriteString(url.QueryEscape(q[1]))
+	}
+	return sb.String()
+}
+
+// purlEscape percent-encodes a namespace segment, name or version. Unlike
+// url.PathEscape it also encodes the characters with a meaning in purls.
+func purlEscape(s string) string {
+	return strings.NewReplacer("@", "%40", "+", "%2B").Replace(url.PathEscape(s))
+}
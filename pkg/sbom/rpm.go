@@ -0,0 +1,183 @@
+package sbom
+
+import (
+	"archive/tar"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	// Register the sqlite3 driver for reading rpmdb.sqlite.
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// rpm header tags, see rpmtag.h.
+const (
+	rpmTagName    = 1000
+	rpmTagVersion = 1001
+	rpmTagRelease = 1002
+	rpmTagEpoch   = 1003
+	rpmTagLicense = 1014
+	rpmTagArch    = 1022
+	rpmTagSource  = 1044
+)
+
+// rpm header data types.
+const (
+	rpmTypeInt32       = 4
+	rpmTypeString      = 6
+	rpmTypeI18NString  = 9
+	rpmHeaderEntrySize = 16
+)
+
+// parseRpmDB reads the sqlite backend of the rpm database. The database has
+// to be copied to a temporary file first as sqlite cannot operate on a
+// stream.
+func parseRpmDB(_ string, _ *tar.Header, r io.Reader) (_ []Package, retErr error) {
+	dir, err := os.MkdirTemp("", "podman-sbom-rpmdb")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	dbPath := filepath.Join(dir, "rpmdb.sqlite")
+	f, err := os.Create(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+dbPath+"?mode=ro&immutable=1")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := db.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+
+	rows, err := db.Query("SELECT blob FROM Packages")
+	if err != nil {
+		return nil, fmt.Errorf("querying rpm database: %w", err)
+	}
+	defer rows.Close()
+
+	var pkgs []Package
+	for rows.Next() {
+		var blob []byte
+		if err := rows.Scan(&blob); err != nil {
+			return nil, err
+		}
+		pkg, err := parseRpmHeader(blob)
+		if err != nil {
+			return nil, err
+		}
+		// The gpg-pubkey pseudo packages are imported keys.
+		if pkg.Name == "" || pkg.Name == "gpg-pubkey" {
+			continue
+		}
+		pkgs = append(pkgs, *pkg)
+	}
+	return pkgs, rows.Err()
+}
+
+// parseRpmHeader decodes the subset of tags needed from an rpm header blob as
+// stored in the database: an index length and data length, followed by the
+// index entries and the data store.
+func parseRpmHeader(blob []byte) (*Package, error) {
+	if len(blob) < 8 {
+		return nil, errors.New("rpm header too short")
+	}
+	indexCount := int(binary.BigEndian.Uint32(blob[0:4]))
+	dataLength := int(binary.BigEndian.Uint32(blob[4:8]))
+	dataStart := 8 + indexCount*rpmHeaderEntrySize
+	if indexCount < 0 || dataLength < 0 || dataStart < 8 || dataStart+dataLength > len(blob) {
+		return nil, errors.New("invalid rpm header")
+	}
+	data := blob[dataStart : dataStart+dataLength]
+
+	var (
+		pkg     = &Package{Type: TypeRPM}
+		version string
+		release string
+	)
+	for i := range indexCount {
+		entry := blob[8+i*rpmHeaderEntrySize : 8+(i+1)*rpmHeaderEntrySize]
+		tag := binary.BigEndian.Uint32(entry[0:4])
+		typ := binary.BigEndian.Uint32(entry[4:8])
+		offset := int(int32(binary.BigEndian.Uint32(entry[8:12])))
+		if offset < 0 || offset >= len(data) {
+			continue
+		}
+		switch tag {
+		case rpmTagName, rpmTagVersion, rpmTagRelease, rpmTagLicense, rpmTagArch, rpmTagSource:
+			if typ != rpmTypeString && typ != rpmTypeI18NString {
+				continue
+			}
+			value := rpmString(data[offset:])
+			switch tag {
+			case rpmTagName:
+				pkg.Name = value
+			case rpmTagVersion:
+				version = value
+			case rpmTagRelease:
+				release = value
+			case rpmTagLicense:
+				pkg.License = value
+			case rpmTagArch:
+				pkg.Arch = value
+			case rpmTagSource:
+				pkg.Source = rpmSourceName(value)
+			}
+		case rpmTagEpoch:
+			if typ != rpmTypeInt32 || offset+4 > len(data) {
+				continue
+			}
+			pkg.Epoch = strconv.FormatUint(uint64(binary.BigEndian.Uint32(data[offset:offset+4])), 10)
+		}
+	}
+	pkg.Version = version
+	if release != "" {
+		pkg.Version += "-" + release
+	}
+	if pkg.Source == pkg.Name {
+		pkg.Source = ""
+	}
+	return pkg, nil
+}
+
+// rpmSourceName returns the package name of a source rpm file name in the
+// NAME-VERSION-RELEASE.src.rpm form.
+func rpmSourceName(srpm string) string {
+	name := strings.TrimSuffix(strings.TrimSuffix(srpm, ".rpm"), ".src")
+	for range 2 {
+		i := strings.LastIndex(name, "-")
+		if i <= 0 {
+			return ""
+		}
+		name = name[:i]
+	}
+	return name
+}
+
+// rpmString returns the NUL terminated string at the start of b.
+func rpmString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
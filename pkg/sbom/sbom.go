@@ -0,0 +1,203 @@
+// Package sbom builds a software bill of materials for container images by
+// scanning their layers for package databases and language manifests.
+package sbom
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Package types reported by the catalogers.
+const (
+	TypeAPK    = "apk"
+	TypeDeb    = "deb"
+	TypeRPM    = "rpm"
+	TypePython = "python"
+	TypeNPM    = "npm"
+	TypeGo     = "golang"
+)
+
+const (
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+// Package describes a single software package found in an image.
+type Package struct {
+	// Name of the package.
+	Name string `json:"name"`
+	// Version of the package. For rpm packages this is VERSION-RELEASE.
+	Version string `json:"version,omitempty"`
+	// Source is the name of the source package of distribution packages,
+	// if it differs from Name.
+	Source string `json:"source,omitempty"`
+	// Epoch of rpm packages, empty if unset.
+	Epoch string `json:"epoch,omitempty"`
+	// Arch is the architecture the package was built for, if known.
+	Arch string `json:"arch,omitempty"`
+	// License as declared by the package metadata, verbatim.
+	License string `json:"license,omitempty"`
+	// Type is the ecosystem of the package, see the Type* constants.
+	Type string `json:"type"`
+	// Location is the path inside the image the package was found at.
+	Location string `json:"location"`
+}
+
+// OSRelease holds the distribution information read from os-release(5).
+type OSRelease struct {
+	ID         string `json:"id,omitempty"`
+	VersionID  string `json:"versionID,omitempty"`
+	Name       string `json:"name,omitempty"`
+	PrettyName string `json:"prettyName,omitempty"`
+}
+
+// Catalog accumulates the packages found while applying image layers from
+// the bottom to the top. Entries are tracked per file, so that files removed
+// or replaced by an upper layer no longer contribute to the result.
+type Catalog struct {
+	packages  map[string][]Package
+	osRelease map[string]*OSRelease
+}
+
+// NewCatalog returns an empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{
+		packages:  make(map[string][]Package),
+		osRelease: make(map[string]*OSRelease),
+	}
+}
+
+// AddLayer scans the uncompressed layer tarball read from r and applies it
+// on top of the layers added before.
+func (c *Catalog) AddLayer(r io.Reader) error {
+	var (
+		removed  []string
+		replaced []string
+		opaque   []string
+		packages = make(map[string][]Package)
+		releases = make(map[string]*OSRelease)
+	)
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("reading layer: %w", err)
+		}
+		name := path.Clean("/" + hdr.Name)
+		dir, base := path.Split(name)
+		switch {
+		case base == whiteoutOpaque:
+			opaque = append(opaque, path.Clean(dir))
+			continue
+		case strings.HasPrefix(base, whiteoutPrefix):
+			removed = append(removed, path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix)))
+			continue
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+		// A file replaced in this layer hides whatever lower layers had
+		// at the same path, even if it is no longer a package database.
+		replaced = append(replaced, name)
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if isOSRelease(name) {
+			rel, err := parseOSRelease(tr)
+			if err != nil {
+				logrus.Debugf("Parsing %s: %v", name, err)
+				continue
+			}
+			releases[name] = rel
+			continue
+		}
+		cataloger := lookupCataloger(name, hdr)
+		if cataloger == nil {
+			continue
+		}
+		pkgs, err := cataloger(name, hdr, tr)
+		if err != nil {
+			logrus.Warnf("Unable to read packages from %s: %v", name, err)
+			continue
+		}
+		for i := range pkgs {
+			pkgs[i].Location = name
+		}
+		packages[name] = pkgs
+	}
+
+	for _, dir := range opaque {
+		c.removeTree(dir, false)
+	}
+	for _, p := range removed {
+		c.removeTree(p, true)
+	}
+	for _, p := range replaced {
+		delete(c.packages, p)
+		delete(c.osRelease, p)
+	}
+	maps.Copy(c.packages, packages)
+	maps.Copy(c.osRelease, releases)
+	return nil
+}
+
+// removeTree drops every entry below p, and p itself if self is set.
+func (c *Catalog) removeTree(p string, self bool) {
+	prefix := strings.TrimSuffix(p, "/") + "/"
+	for name := range c.packages {
+		if (self && name == p) || strings.HasPrefix(name, prefix) {
+			delete(c.packages, name)
+		}
+	}
+	for name := range c.osRelease {
+		if (self && name == p) || strings.HasPrefix(name, prefix) {
+			delete(c.osRelease, name)
+		}
+	}
+}
+
+// Packages returns all packages of the catalog, sorted by type, name and
+// location.
+func (c *Catalog) Packages() []Package {
+	var pkgs []Package
+	for _, p := range c.packages {
+		pkgs = append(pkgs, p...)
+	}
+	slices.SortFunc(pkgs, func(a, b Package) int {
+		if n := strings.Compare(a.Type, b.Type); n != 0 {
+			return n
+		}
+		if n := strings.Compare(a.Name, b.Name); n != 0 {
+			return n
+		}
+		if n := strings.Compare(a.Version, b.Version); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Location, b.Location)
+	})
+	return pkgs
+}
+
+// OS returns the distribution of the image or nil if no os-release file was
+// found. /etc/os-release takes precedence over /usr/lib/os-release.
+func (c *Catalog) OS() *OSRelease {
+	for _, p := range osReleasePaths {
+		if rel, ok := c.osRelease[p]; ok {
+			return rel
+		}
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package sbom
+
+import (
+	"archive/tar"
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type layerFile struct {
+	name    string
+	content string
+	dir     bool
+}
+
+func makeLayer(t *testing.T, files ...layerFile) *bytes.Buffer {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for _, f := range files {
+		hdr := &tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.content)), Typeflag: tar.TypeReg}
+		if f.dir {
+			hdr = &tar.Header{Name: f.name, Mode: 0o755, Typeflag: tar.TypeDir}
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		if !f.dir {
+			_, err := tw.Write([]byte(f.content))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	return buf
+}
+
+const dpkgStatus = `Package: base-files
+Status: install ok installed
+Architecture: amd64
+Version: 12.4+deb12u5
+Description: Debian base system miscellaneous files
+ This package contains the basic filesystem hierarchy.
+ .
+ More text.
+
+Package: removed-pkg
+Status: deinstall ok config-files
+Version: 1.0
+
+Package: libc6
+Status: install ok installed
+Architecture: amd64
+Version: 2.36-9+deb12u4
+`
+
+const apkInstalled = `C:Q1abc=
+P:musl
+V:1.2.4-r2
+A:x86_64
+L:MIT
+
+P:busybox
+V:1.36.1-r15
+A:x86_64
+L:GPL-2.0-only
+`
+
+func names(pkgs []Package) []string {
+	var out []string
+	for _, p := range pkgs {
+		out = append(out, p.Type+":"+p.Name+"@"+p.Version)
+	}
+	return out
+}
+
+func TestCatalogLayers(t *testing.T) {
+	c := NewCatalog()
+	require.NoError(t, c.AddLayer(makeLayer(t,
+		layerFile{name: "etc/", dir: true},
+		layerFile{name: "etc/os-release", content: "ID=debian\nVERSION_ID=\"12\"\nPRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\n"},
+		layerFile{name: "var/lib/dpkg/status", content: dpkgStatus},
+		layerFile{name: "usr/lib/python3/dist-packages/requests-2.31.0.dist-info/METADATA", content: "Metadata-Version: 2.1\nName: requests\nVersion: 2.31.0\nLicense: Apache 2.0\n\nLong description\n"},
+		layerFile{name: "app/node_modules/left-pad/package.json", content: `{"name": "left-pad", "version": "1.3.0", "license": {"type": "WTFPL"}}`},
+		layerFile{name: "app/node_modules/@scope/pkg/package.json", content: `{"name": "@scope/pkg", "version": "0.1.0", "license": "MIT"}`},
+		layerFile{name: "app/package.json", content: `{"name": "not-a-dependency", "version": "1.0.0"}`},
+	)))
+
+	assert.Equal(t, []string{
+		"deb:base-files@12.4+deb12u5",
+		"deb:libc6@2.36-9+deb12u4",
+		"npm:@scope/pkg@0.1.0",
+		"npm:left-pad@1.3.0",
+		"python:requests@2.31.0",
+	}, names(c.Packages()))
+	require.NotNil(t, c.OS())
+	assert.Equal(t, "debian", c.OS().ID)
+	assert.Equal(t, "12", c.OS().VersionID)
+
+	// Upper layer: whiteout a python package, make node_modules opaque and
+	// directory entries must not hide anything.
+	require.NoError(t, c.AddLayer(makeLayer(t,
+		layerFile{name: "var/lib/dpkg/", dir: true},
+		layerFile{name: "usr/lib/python3/dist-packages/.wh.requests-2.31.0.dist-info", content: ""},
+		layerFile{name: "app/node_modules/.wh..wh..opq", content: ""},
+		layerFile{name: "app/node_modules/is-odd/package.json", content: `{"name": "is-odd", "version": "3.0.1"}`},
+	)))
+	assert.Equal(t, []string{
+		"deb:base-files@12.4+deb12u5",
+		"deb:libc6@2.36-9+deb12u4",
+		"npm:is-odd@3.0.1",
+	}, names(c.Packages()))
+
+	// Replacing the status file replaces the package list.
+	require.NoError(t, c.AddLayer(makeLayer(t,
+		layerFile{name: "./var/lib/dpkg/status", content: "Package: libc6\nStatus: install ok installed\nVersion: 2.36-9+deb12u7\n"},
+	)))
+	pkgs := c.Packages()
+	assert.Equal(t, []string{
+		"deb:libc6@2.36-9+deb12u7",
+		"npm:is-odd@3.0.1",
+	}, names(pkgs))
+	assert.Equal(t, "/var/lib/dpkg/status", pkgs[0].Location)
+}
+
+func TestParseApkDB(t *testing.T) {
+	pkgs, err := parseApkDB("", nil, bytes.NewBufferString(apkInstalled))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Name: "musl", Version: "1.2.4-r2", Arch: "x86_64", License: "MIT", Type: TypeAPK},
+		{Name: "busybox", Version: "1.36.1-r15", Arch: "x86_64", License: "GPL-2.0-only", Type: TypeAPK},
+	}, pkgs)
+}
+
+// rpmHeaderBlob builds a header blob with string entries and an optional
+// epoch.
+func rpmHeaderBlob(strs map[uint32]string, epoch *uint32) []byte {
+	var (
+		index []byte
+		data  []byte
+	)
+	addEntry := func(tag, typ uint32, offset int) {
+		entry := make([]byte, rpmHeaderEntrySize)
+		binary.BigEndian.PutUint32(entry[0:4], tag)
+		binary.BigEndian.PutUint32(entry[4:8], typ)
+		binary.BigEndian.PutUint32(entry[8:12], uint32(offset))
+		binary.BigEndian.PutUint32(entry[12:16], 1)
+		index = append(index, entry...)
+	}
+	if epoch != nil {
+		addEntry(rpmTagEpoch, rpmTypeInt32, len(data))
+		data = binary.BigEndian.AppendUint32(data, *epoch)
+	}
+	for _, tag := range []uint32{rpmTagName, rpmTagVersion, rpmTagRelease, rpmTagLicense, rpmTagArch} {
+		if s, ok := strs[tag]; ok {
+			addEntry(tag, rpmTypeString, len(data))
+			data = append(data, append([]byte(s), 0)...)
+		}
+	}
+	blob := binary.BigEndian.AppendUint32(nil, uint32(len(index)/rpmHeaderEntrySize))
+	blob = binary.BigEndian.AppendUint32(blob, uint32(len(data)))
+	blob = append(blob, index...)
+	return append(blob, data...)
+}
+
+func TestParseRpmHeader(t *testing.T) {
+	epoch := uint32(2)
+	pkg, err := parseRpmHeader(rpmHeaderBlob(map[uint32]string{
+		rpmTagName:    "vim-minimal",
+		rpmTagVersion: "9.1.083",
+		rpmTagRelease: "1.fc40",
+		rpmTagLicense: "Vim AND MIT",
+		rpmTagArch:    "x86_64",
+	}, &epoch))
+	require.NoError(t, err)
+	assert.Equal(t, &Package{
+		Name:    "vim-minimal",
+		Version: "9.1.083-1.fc40",
+		Epoch:   "2",
+		Arch:    "x86_64",
+		License: "Vim AND MIT",
+		Type:    TypeRPM,
+	}, pkg)
+
+	_, err = parseRpmHeader([]byte{0, 0, 0, 9, 0, 0, 0, 1})
+	assert.Error(t, err)
+}
+
+func TestParseRpmDB(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "rpmdb.sqlite")
+	db, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE Packages (hnum INTEGER PRIMARY KEY AUTOINCREMENT, blob BLOB NOT NULL)")
+	require.NoError(t, err)
+	for _, name := range []string{"bash", "gpg-pubkey"} {
+		_, err = db.Exec("INSERT INTO Packages (blob) VALUES (?)", rpmHeaderBlob(map[uint32]string{
+			rpmTagName:    name,
+			rpmTagVersion: "5.2.26",
+			rpmTagRelease: "3.fc40",
+		}, nil))
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.Close())
+
+	f, err := os.Open(dbPath)
+	require.NoError(t, err)
+	defer f.Close()
+	pkgs, err := parseRpmDB("", nil, f)
+	require.NoError(t, err)
+	assert.Equal(t, []Package{{Name: "bash", Version: "5.2.26-3.fc40", Type: TypeRPM}}, pkgs)
+}
+
+func TestPURL(t *testing.T) {
+	fedora := &OSRelease{ID: "fedora", VersionID: "40"}
+	tests := []struct {
+		pkg  Package
+		rel  *OSRelease
+		want string
+	}{
+		{Package{Name: "vim-minimal", Version: "9.1.083-1.fc40", Epoch: "2", Arch: "x86_64", Type: TypeRPM}, fedora, "pkg:rpm/fedora/vim-minimal@9.1.083-1.fc40?arch=x86_64&distro=fedora-40&epoch=2"},
+		{Package{Name: "libc6", Version: "2.36-9+deb12u4", Arch: "amd64", Type: TypeDeb}, nil, "pkg:deb/libc6@2.36-9%2Bdeb12u4?arch=amd64"},
+		{Package{Name: "Flask_Login", Version: "0.6.3", Type: TypePython}, nil, "pkg:pypi/flask-login@0.6.3"},
+		{Package{Name: "@scope/pkg", Version: "0.1.0", Type: TypeNPM}, nil, "pkg:npm/%40scope/pkg@0.1.0"},
+		{Package{Name: "github.com/spf13/cobra", Version: "v1.8.0", Type: TypeGo}, nil, "pkg:golang/github.com/spf13/cobra@v1.8.0"},
+		{Package{Name: "stdlib", Version: "go1.22.1", Type: TypeGo}, nil, "pkg:golang/stdlib@go1.22.1"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.pkg.PURL(tt.rel))
+	}
+}
+
+func TestEncode(t *testing.T) {
+	doc := &Document{
+		Name:        "quay.io/libpod/alpine:latest",
+		Digest:      "sha256:1234",
+		Created:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ToolVersion: "6.0.0",
+		OS:          &OSRelease{ID: "alpine", VersionID: "3.19.1"},
+		Packages: []Package{
+			{Name: "musl", Version: "1.2.4-r2", Arch: "x86_64", License: "MIT", Type: TypeAPK, Location: apkDBPath},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, FormatSPDX, doc))
+	var spdx spdxDocument
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &spdx))
+	assert.Equal(t, "SPDX-2.3", spdx.SPDXVersion)
+	assert.Equal(t, "2024-01-02T03:04:05Z", spdx.CreationInfo.Created)
+	require.Len(t, spdx.Packages, 2)
+	assert.Equal(t, "CONTAINER", spdx.Packages[0].PrimaryPackagePurpose)
+	assert.Equal(t, "musl", spdx.Packages[1].Name)
+	assert.Equal(t, "pkg:apk/alpine/musl@1.2.4-r2?arch=x86_64&distro=alpine-3.19.1", spdx.Packages[1].ExternalRefs[0].ReferenceLocator)
+	assert.Len(t, spdx.Relationships, 2)
+
+	buf.Reset()
+	require.NoError(t, Encode(&buf, FormatCycloneDX, doc))
+	var cdx cdxDocument
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &cdx))
+	assert.Equal(t, "1.5", cdx.SpecVersion)
+	assert.Equal(t, "container", cdx.Metadata.Component.Type)
+	require.Len(t, cdx.Components, 2)
+	assert.Equal(t, "operating-system", cdx.Components[0].Type)
+	assert.Equal(t, "MIT", cdx.Components[1].Licenses[0].License.Name)
+
+	assert.Error(t, Encode(&buf, "xml", doc))
+}
@@ -517,6 +517,10 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 				Digest: v.Digest,
 				Title:  v.Title,
 				Name:   v.Name,
+
+				RefreshInterval: v.RefreshInterval,
+				RefreshPull:     v.RefreshPull,
+				RefreshSignal:   uint(v.RefreshSignal),
 			})
 		}
 		options = append(options, libpod.WithArtifactVolumes(vols))
@@ -666,22 +670,24 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	}
 
 	if len(s.Secrets) != 0 {
-		manager, err := rt.SecretsManager()
-		if err != nil {
-			return nil, err
-		}
 		var secrs []*libpod.ContainerSecret
 		for _, s := range s.Secrets {
-			secr, err := manager.Lookup(s.Source)
+			secr, err := rt.LookupSecret(s.Source)
 			if err != nil {
 				return nil, err
 			}
+			target := s.Target
+			if base, ok := secr.Labels[define.SecretHistoryLabel]; ok && target == "" {
+				// A pinned version is mounted at the path of
+				// its secret.
+				target = base
+			}
 			secrs = append(secrs, &libpod.ContainerSecret{
 				Secret: secr,
 				UID:    s.UID,
 				GID:    s.GID,
 				Mode:   s.Mode,
-				Target: s.Target,
+				Target: target,
 			})
 		}
 		options = append(options, libpod.WithSecrets(secrs))
@@ -286,6 +286,10 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure livenessProbe: %w", err)
 	}
+	err = setupReadinessProbe(s, opts.Container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure readinessProbe: %w", err)
+	}
 	err = setupStartupProbe(s, opts.Container, opts.RestartPolicy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure startupProbe: %w", err)
@@ -794,6 +798,22 @@ func setupLivenessProbe(s *specgen.SpecGenerator, containerYAML v1.Container, re
 	return nil
 }
 
+// setupReadinessProbe uses the readiness probe as health check of a
+// container without a liveness probe.  The health check only reports the
+// readiness and never restarts the container.
+func setupReadinessProbe(s *specgen.SpecGenerator, containerYAML v1.Container) error {
+	if containerYAML.ReadinessProbe == nil || s.HealthConfig != nil {
+		return nil
+	}
+	emptyHandler := v1.Handler{}
+	if containerYAML.ReadinessProbe.Handler == emptyHandler {
+		return nil
+	}
+	var err error
+	s.HealthConfig, err = probeToHealthConfig(containerYAML.ReadinessProbe, containerYAML.Ports)
+	return err
+}
+
 func setupStartupProbe(s *specgen.SpecGenerator, containerYAML v1.Container, restartPolicy string) error {
 	if containerYAML.StartupProbe == nil {
 		return nil
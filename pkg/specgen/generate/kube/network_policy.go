@@ -0,0 +1,115 @@
+//go:build !remote
+
+package kube
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v6/libpod/define"
+	networkingv1 "github.com/containers/podman/v6/pkg/k8s.io/api/networking/v1"
+	v12 "github.com/containers/podman/v6/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/containers/podman/v6/pkg/k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ToNetworkPolicy converts a Kubernetes NetworkPolicy to a network policy
+// applying to the given networks, the equivalent of its namespace.
+func ToNetworkPolicy(np *networkingv1.NetworkPolicy, networks []string) (define.NetworkPolicy, error) {
+	policy := define.NetworkPolicy{
+		Name:     np.Name,
+		Networks: networks,
+		Labels:   np.Labels,
+	}
+
+	var err error
+	policy.PodSelector, err = networkPolicySelector(&np.Spec.PodSelector)
+	if err != nil {
+		return policy, err
+	}
+
+	for _, t := range np.Spec.PolicyTypes {
+		policy.PolicyTypes = append(policy.PolicyTypes, string(t))
+	}
+	if len(policy.PolicyTypes) == 0 {
+		policy.PolicyTypes = []string{define.NetworkPolicyTypeIngress}
+		if len(np.Spec.Egress) > 0 {
+			policy.PolicyTypes = append(policy.PolicyTypes, define.NetworkPolicyTypeEgress)
+		}
+	}
+
+	for _, rule := range np.Spec.Ingress {
+		r, err := networkPolicyRule(rule.From, rule.Ports)
+		if err != nil {
+			return policy, fmt.Errorf("ingress rule of network policy %s: %w", np.Name, err)
+		}
+		policy.Ingress = append(policy.Ingress, r)
+	}
+	for _, rule := range np.Spec.Egress {
+		r, err := networkPolicyRule(rule.To, rule.Ports)
+		if err != nil {
+			return policy, fmt.Errorf("egress rule of network policy %s: %w", np.Name, err)
+		}
+		policy.Egress = append(policy.Egress, r)
+	}
+	return policy, nil
+}
+
+// networkPolicySelector returns the labels matched by the selector, an empty
+// map if it matches all pods.
+func networkPolicySelector(selector *v12.LabelSelector) (map[string]string, error) {
+	if len(selector.MatchExpressions) > 0 {
+		return nil, errors.New("matchExpressions are not supported in network policy selectors, use matchLabels")
+	}
+	labels := make(map[string]string, len(selector.MatchLabels))
+	for k, v := range selector.MatchLabels {
+		labels[k] = v
+	}
+	return labels, nil
+}
+
+func networkPolicyRule(peers []networkingv1.NetworkPolicyPeer, ports []networkingv1.NetworkPolicyPort) (define.NetworkPolicyRule, error) {
+	var rule define.NetworkPolicyRule
+	for _, peer := range peers {
+		if peer.NamespaceSelector != nil {
+			return rule, errors.New("namespaceSelector is not supported, network policies apply to the networks of kube play")
+		}
+		switch {
+		case peer.IPBlock != nil && peer.PodSelector != nil:
+			return rule, errors.New("peer may not have both an ipBlock and a podSelector")
+		case peer.IPBlock != nil:
+			rule.Peers = append(rule.Peers, define.NetworkPolicyPeer{CIDR: peer.IPBlock.CIDR, Except: peer.IPBlock.Except})
+		case peer.PodSelector != nil:
+			selector, err := networkPolicySelector(peer.PodSelector)
+			if err != nil {
+				return rule, err
+			}
+			rule.Peers = append(rule.Peers, define.NetworkPolicyPeer{PodSelector: selector})
+		default:
+			return rule, errors.New("peer needs an ipBlock or a podSelector")
+		}
+	}
+	for _, port := range ports {
+		var p define.NetworkPolicyPort
+		if port.Protocol != nil {
+			p.Protocol = strings.ToLower(string(*port.Protocol))
+		}
+		if port.Port != nil {
+			if port.Port.Type != intstr.Int {
+				return rule, fmt.Errorf("named port %q is not supported", port.Port.StrVal)
+			}
+			if port.Port.IntVal < 1 || port.Port.IntVal > 65535 {
+				return rule, fmt.Errorf("invalid port %d", port.Port.IntVal)
+			}
+			p.Port = uint16(port.Port.IntVal)
+		}
+		if port.EndPort != nil {
+			if port.Port == nil || *port.EndPort < port.Port.IntVal || *port.EndPort > 65535 {
+				return rule, fmt.Errorf("invalid endPort %d", *port.EndPort)
+			}
+			p.EndPort = uint16(*port.EndPort)
+		}
+		rule.Ports = append(rule.Ports, p)
+	}
+	return rule, nil
+}
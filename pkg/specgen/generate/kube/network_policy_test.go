@@ -0,0 +1,119 @@
+//go:build !remote
+
+package kube
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v6/libpod/define"
+	v1 "github.com/containers/podman/v6/pkg/k8s.io/api/core/v1"
+	networkingv1 "github.com/containers/podman/v6/pkg/k8s.io/api/networking/v1"
+	v12 "github.com/containers/podman/v6/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/containers/podman/v6/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToNetworkPolicy(t *testing.T) {
+	udp := v1.ProtocolUDP
+	port := func(v intstr.IntOrString) *intstr.IntOrString { return &v }
+	endPort := int32(8080)
+
+	tests := []struct {
+		name        string
+		spec        networkingv1.NetworkPolicySpec
+		expected    define.NetworkPolicy
+		expectedErr string
+	}{
+		{
+			name: "deny all ingress",
+			spec: networkingv1.NetworkPolicySpec{},
+			expected: define.NetworkPolicy{
+				PodSelector: map[string]string{},
+				PolicyTypes: []string{define.NetworkPolicyTypeIngress},
+			},
+		},
+		{
+			name: "ingress and egress",
+			spec: networkingv1.NetworkPolicySpec{
+				PodSelector: v12.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{
+					From: []networkingv1.NetworkPolicyPeer{
+						{PodSelector: &v12.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{{Port: port(intstr.FromInt(8000)), EndPort: &endPort}},
+				}},
+				Egress: []networkingv1.NetworkPolicyEgressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: port(intstr.FromInt(53))}},
+				}},
+			},
+			expected: define.NetworkPolicy{
+				PodSelector: map[string]string{"app": "db"},
+				PolicyTypes: []string{define.NetworkPolicyTypeIngress, define.NetworkPolicyTypeEgress},
+				Ingress: []define.NetworkPolicyRule{{
+					Peers: []define.NetworkPolicyPeer{
+						{PodSelector: map[string]string{"app": "web"}},
+						{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}},
+					},
+					Ports: []define.NetworkPolicyPort{{Port: 8000, EndPort: 8080}},
+				}},
+				Egress: []define.NetworkPolicyRule{{
+					Ports: []define.NetworkPolicyPort{{Protocol: "udp", Port: 53}},
+				}},
+			},
+		},
+		{
+			name: "egress only",
+			spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			},
+			expected: define.NetworkPolicy{
+				PodSelector: map[string]string{},
+				PolicyTypes: []string{define.NetworkPolicyTypeEgress},
+			},
+		},
+		{
+			name: "named port",
+			spec: networkingv1.NetworkPolicySpec{
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{{Port: port(intstr.FromString("http"))}},
+				}},
+			},
+			expectedErr: `named port "http" is not supported`,
+		},
+		{
+			name: "namespace selector",
+			spec: networkingv1.NetworkPolicySpec{
+				Egress: []networkingv1.NetworkPolicyEgressRule{{
+					To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &v12.LabelSelector{}}},
+				}},
+			},
+			expectedErr: "namespaceSelector is not supported",
+		},
+		{
+			name: "match expressions",
+			spec: networkingv1.NetworkPolicySpec{
+				PodSelector: v12.LabelSelector{MatchExpressions: []v12.LabelSelectorRequirement{{Key: "app", Operator: v12.LabelSelectorOpExists}}},
+			},
+			expectedErr: "matchExpressions are not supported",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			np := networkingv1.NetworkPolicy{
+				ObjectMeta: v12.ObjectMeta{Name: "policy"},
+				Spec:       test.spec,
+			}
+			policy, err := ToNetworkPolicy(&np, []string{"kube"})
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			test.expected.Name = "policy"
+			test.expected.Networks = []string{"kube"}
+			assert.Equal(t, test.expected, policy)
+		})
+	}
+}
@@ -1402,6 +1402,38 @@ func TestTCPLivenessProbe(t *testing.T) {
 	}
 }
 
+func TestReadinessProbe(t *testing.T) {
+	readinessProbe := &v1.Probe{
+		Handler: v1.Handler{
+			TCPSocket: &v1.TCPSocketAction{
+				Port: intstr.FromInt(8080),
+			},
+		},
+	}
+	livenessProbe := &v1.Probe{
+		Handler: v1.Handler{
+			TCPSocket: &v1.TCPSocketAction{
+				Port: intstr.FromInt(9090),
+			},
+		},
+	}
+
+	s := specgen.SpecGenerator{}
+	err := setupReadinessProbe(&s, v1.Container{ReadinessProbe: readinessProbe})
+	assert.NoError(t, err)
+	assert.Contains(t, s.HealthConfig.Test, "8080")
+	assert.Equal(t, define.HealthCheckOnFailureActionNone, int(s.HealthCheckOnFailureAction))
+
+	// A liveness probe takes precedence.
+	s = specgen.SpecGenerator{}
+	container := v1.Container{ReadinessProbe: readinessProbe, LivenessProbe: livenessProbe}
+	err = setupLivenessProbe(&s, container, "always")
+	assert.NoError(t, err)
+	err = setupReadinessProbe(&s, container)
+	assert.NoError(t, err)
+	assert.Contains(t, s.HealthConfig.Test, "9090")
+}
+
 func TestDeviceResource(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -371,6 +371,10 @@ func namespaceOptions(s *specgen.SpecGenerator, rt *libpod.Runtime, pod *libpod.
 			s.Networks[rtConfig.Network.DefaultNetwork] = opts
 			delete(s.Networks, "default")
 		}
+		if limit, ok := s.NetworkRateLimits["default"]; ok {
+			s.NetworkRateLimits[rtConfig.Network.DefaultNetwork] = limit
+			delete(s.NetworkRateLimits, "default")
+		}
 		toReturn = append(toReturn, libpod.WithNetNS(portMappings, postConfigureNetNS, "bridge", s.Networks))
 	}
 
@@ -400,6 +404,9 @@ func namespaceOptions(s *specgen.SpecGenerator, rt *libpod.Runtime, pod *libpod.
 	if s.NetworkOptions != nil {
 		toReturn = append(toReturn, libpod.WithNetworkOptions(s.NetworkOptions))
 	}
+	if len(s.NetworkRateLimits) > 0 {
+		toReturn = append(toReturn, libpod.WithNetworkRateLimits(s.NetworkRateLimits))
+	}
 
 	return toReturn, nil
 }
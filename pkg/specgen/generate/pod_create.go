@@ -260,6 +260,9 @@ func MapSpec(p *specgen.PodSpecGenerator) (*specgen.SpecGenerator, error) {
 	if len(p.Networks) > 0 {
 		spec.Networks = p.Networks
 	}
+	if len(p.NetworkRateLimits) > 0 {
+		spec.NetworkRateLimits = p.NetworkRateLimits
+	}
 	if p.NoManageHosts {
 		spec.UseImageHosts = &p.NoManageHosts
 	}
@@ -3,8 +3,10 @@ package specgen
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/containers/podman/v6/libpod/define"
@@ -481,6 +483,98 @@ func parseBridgeNetworkOptions(opts string) (types.PerNetworkOptions, error) {
 	return netOpts, nil
 }
 
+// networkRateUnits are the units of rates accepted by tc(8), in bits per
+// second.
+var networkRateUnits = map[string]float64{
+	"bit":   1,
+	"kbit":  1e3,
+	"mbit":  1e6,
+	"gbit":  1e9,
+	"tbit":  1e12,
+	"kibit": 1 << 10,
+	"mibit": 1 << 20,
+	"gibit": 1 << 30,
+	"tibit": 1 << 40,
+	"bps":   8,
+	"kbps":  8e3,
+	"mbps":  8e6,
+	"gbps":  8e9,
+	"tbps":  8e12,
+	"kibps": 8 << 10,
+	"mibps": 8 << 20,
+	"gibps": 8 << 30,
+	"tibps": 8 << 40,
+}
+
+// ParseNetworkRate parses a rate in the format of tc(8), e.g. 10mbit, and
+// returns it in bits per second. 0 is accepted without unit.
+func ParseNetworkRate(value string) (uint64, error) {
+	if value == "0" {
+		return 0, nil
+	}
+	lower := strings.ToLower(value)
+	i := strings.IndexFunc(lower, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i <= 0 {
+		return 0, fmt.Errorf("invalid rate %q, expected a number with a unit such as 10mbit: %w", value, define.ErrInvalidArg)
+	}
+	unit, ok := networkRateUnits[lower[i:]]
+	if !ok {
+		return 0, fmt.Errorf("invalid unit in rate %q: %w", value, define.ErrInvalidArg)
+	}
+	num, err := strconv.ParseFloat(lower[:i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rate %q: %w", value, define.ErrInvalidArg)
+	}
+	rate := num * unit
+	if rate >= math.MaxUint64 {
+		return 0, fmt.Errorf("rate %q is too large: %w", value, define.ErrInvalidArg)
+	}
+	return uint64(rate), nil
+}
+
+// ParseNetworkRateLimits parses --network-opt values of the form
+// [NETWORK:]ingress-rate=RATE,egress-rate=RATE. Limits given without a
+// network name apply to all network attachments of the container and are
+// returned with the empty string as key. A rate which is not given is not
+// limited.
+func ParseNetworkRateLimits(opts []string) (map[string]define.NetworkRateLimit, error) {
+	if len(opts) == 0 {
+		return nil, nil
+	}
+	limits := make(map[string]define.NetworkRateLimit, len(opts))
+	for _, opt := range opts {
+		network, options, hasNetwork := strings.Cut(opt, ":")
+		if !hasNetwork || strings.Contains(network, "=") {
+			network, options = "", opt
+		} else if network == "" {
+			return nil, fmt.Errorf("network name cannot be empty in network option %q: %w", opt, define.ErrInvalidArg)
+		}
+		limit := define.NetworkRateLimit{}
+		for o := range strings.SplitSeq(options, ",") {
+			key, value, _ := strings.Cut(o, "=")
+			var err error
+			switch key {
+			case "ingress-rate":
+				limit.IngressRate, err = ParseNetworkRate(value)
+			case "egress-rate":
+				limit.EgressRate, err = ParseNetworkRate(value)
+			default:
+				err = fmt.Errorf("unknown network option %q: %w", key, define.ErrInvalidArg)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := define.ValidateNetworkRateLimit(limit); err != nil {
+			return nil, fmt.Errorf("network option %q: %w", opt, err)
+		}
+		limits[network] = limit
+	}
+	return limits, nil
+}
+
 func SetupUserNS(idmappings *storageTypes.IDMappingOptions, userns Namespace, g *generate.Generator) (string, error) {
 	// User
 	var user string
@@ -4,6 +4,7 @@ import (
 	"net"
 	"testing"
 
+	"github.com/containers/podman/v6/libpod/define"
 	"github.com/stretchr/testify/assert"
 	"go.podman.io/common/libnetwork/types"
 )
@@ -288,3 +289,92 @@ func TestParseNetworkFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNetworkRate(t *testing.T) {
+	tests := []struct {
+		value string
+		rate  uint64
+		err   string
+	}{
+		{value: "0", rate: 0},
+		{value: "100bit", rate: 100},
+		{value: "10mbit", rate: 10_000_000},
+		{value: "1.5Gbit", rate: 1_500_000_000},
+		{value: "2kibit", rate: 2048},
+		{value: "1mbps", rate: 8_000_000},
+		{value: "10", err: `invalid rate "10", expected a number with a unit such as 10mbit: invalid argument`},
+		{value: "mbit", err: `invalid rate "mbit", expected a number with a unit such as 10mbit: invalid argument`},
+		{value: "10mb", err: `invalid unit in rate "10mb": invalid argument`},
+		{value: "1.2.3mbit", err: `invalid rate "1.2.3mbit": invalid argument`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			rate, err := ParseNetworkRate(tt.value)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.rate, rate)
+		})
+	}
+}
+
+func TestParseNetworkRateLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		limits map[string]define.NetworkRateLimit
+		err    string
+	}{
+		{
+			name: "no options",
+		},
+		{
+			name:   "all networks",
+			args:   []string{"ingress-rate=10mbit,egress-rate=5mbit"},
+			limits: map[string]define.NetworkRateLimit{"": {IngressRate: 10_000_000, EgressRate: 5_000_000}},
+		},
+		{
+			name: "per network",
+			args: []string{"egress-rate=1mbit", "net1:ingress-rate=2mbit"},
+			limits: map[string]define.NetworkRateLimit{
+				"":     {EgressRate: 1_000_000},
+				"net1": {IngressRate: 2_000_000},
+			},
+		},
+		{
+			name: "empty network name",
+			args: []string{":ingress-rate=2mbit"},
+			err:  `network name cannot be empty in network option ":ingress-rate=2mbit": invalid argument`,
+		},
+		{
+			name: "unknown option",
+			args: []string{"net1:mtu=1500"},
+			err:  `unknown network option "mtu": invalid argument`,
+		},
+		{
+			name: "ingress rate too large",
+			args: []string{"ingress-rate=1tbit"},
+			err:  `network option "ingress-rate=1tbit": ingress rate 1000000000000bit exceeds the maximum of 34359738360bit: invalid argument`,
+		},
+		{
+			name: "egress rate too small",
+			args: []string{"net1:egress-rate=100bit"},
+			err:  `network option "net1:egress-rate=100bit": egress rate 100bit is below the minimum of 8000bit: invalid argument`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := ParseNetworkRateLimits(tt.args)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.limits, limits)
+		})
+	}
+}
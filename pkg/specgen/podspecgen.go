@@ -3,6 +3,7 @@ package specgen
 import (
 	"net"
 
+	"github.com/containers/podman/v6/libpod/define"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"go.podman.io/common/libnetwork/types"
 	storageTypes "go.podman.io/storage/types"
@@ -176,6 +177,11 @@ type PodNetworkConfig struct {
 	// NetworkOptions are additional options for each network
 	// Optional.
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// NetworkRateLimits limits the bandwidth of the network attachments
+	// of the pod, keyed by network name. The limit with the empty name
+	// applies to all attachments without a limit of their own.
+	// Optional.
+	NetworkRateLimits map[string]define.NetworkRateLimit `json:"network_rate_limits,omitempty"`
 }
 
 // PodStorageConfig contains all of the storage related options for the pod and its infra container.
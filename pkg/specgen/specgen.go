@@ -551,6 +551,11 @@ type ContainerNetworkConfig struct {
 	// NetworkOptions are additional options for each network
 	// Optional.
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// NetworkRateLimits limits the bandwidth of the network attachments
+	// of the container, keyed by network name. The limit with the empty name
+	// applies to all attachments without a limit of their own.
+	// Optional.
+	NetworkRateLimits map[string]define.NetworkRateLimit `json:"network_rate_limits,omitempty"`
 }
 
 // ContainerResourceConfig contains information on container resource limits.
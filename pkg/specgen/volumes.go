@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/containers/podman/v6/libpod/define"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -83,6 +85,17 @@ type ArtifactVolume struct {
 	// "<name>-x" where x is a 0 indexed integer based on the layer order.
 	// Optional.
 	Name string `json:"name,omitempty"`
+	// RefreshInterval is the interval in which the artifact is checked for a
+	// new version.  When set, the blobs are mounted through a directory whose
+	// content is atomically swapped when the artifact changes.
+	// Optional.
+	RefreshInterval time.Duration `json:"refresh_interval,omitempty"`
+	// RefreshPull pulls the artifact from its registry before each check.
+	// Optional. Requires RefreshInterval.
+	RefreshPull bool `json:"refresh_pull,omitempty"`
+	// RefreshSignal is sent to the container after the artifact was refreshed.
+	// Optional. Requires RefreshInterval.
+	RefreshSignal syscall.Signal `json:"refresh_signal,omitempty"`
 }
 
 // GenVolumeMounts parses user input into mounts, volumes and overlay volumes
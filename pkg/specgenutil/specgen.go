@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -598,6 +599,7 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		s.DNSSearch = c.Net.DNSSearch
 		s.DNSOptions = c.Net.DNSOptions
 		s.NetworkOptions = c.Net.NetworkOptions
+		s.NetworkRateLimits = c.Net.NetworkRateLimits
 		s.UseImageHostname = &c.Net.NoHostname
 		s.UseImageHosts = &c.Net.NoHosts
 	}
@@ -1110,6 +1112,9 @@ func parseThrottleIOPsDevices(iopsDevices []string) (map[string]specs.LinuxThrot
 	return td, nil
 }
 
+// secretVersionSuffix matches the version of a pinned secret version.
+var secretVersionSuffix = regexp.MustCompile(`@v[0-9]+$`)
+
 func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error) {
 	secretParseError := errors.New("parsing secret")
 	var mount []specgen.Secret
@@ -1209,7 +1214,9 @@ func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error)
 				return nil, nil, fmt.Errorf("UID, GID, Mode options cannot be set with secret type env: %w", secretParseError)
 			}
 			if target == "" {
-				target = source
+				// The variable of a pinned version NAME@vVERSION
+				// is named after its secret.
+				target = secretVersionSuffix.ReplaceAllString(source, "")
 			}
 			envs[target] = source
 		}
@@ -219,6 +219,13 @@ func TestGenRlimits(t *testing.T) {
 	assert.Error(t, err, "err is not nil")
 }
 
+func TestParseSecretsPinnedVersion(t *testing.T) {
+	mounts, envs, err := parseSecrets([]string{"db@v3", "token@v12,type=env", "key@v2,type=env,target=KEY", "odd@vx,type=env"})
+	assert.NoError(t, err)
+	assert.Equal(t, []specgen.Secret{{Source: "db@v3", Mode: 0o444}}, mounts)
+	assert.Equal(t, map[string]string{"token": "token@v12", "KEY": "key@v2", "odd@vx": "odd@vx"}, envs)
+}
+
 func TestFillOutSpecGenRecorsUserNs(t *testing.T) {
 	sg := specgen.NewSpecGenerator("nothing", false)
 	err := FillOutSpecGen(sg, &entities.ContainerCreateOptions{
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/pkg/rootless"
@@ -752,6 +754,37 @@ func getArtifactVolume(args []string) (*specgen.ArtifactVolume, error) {
 				return nil, fmt.Errorf("%v: %w", name, errOptionArg)
 			}
 			newVolume.Name = value
+		case "refresh":
+			if !hasValue {
+				return nil, fmt.Errorf("%v: %w", name, errOptionArg)
+			}
+			interval, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid refresh interval %q: %w", value, err)
+			}
+			if interval < time.Second {
+				return nil, fmt.Errorf("refresh interval %q must be at least 1s", value)
+			}
+			newVolume.RefreshInterval = interval
+		case "refresh-pull":
+			if !hasValue {
+				newVolume.RefreshPull = true
+				continue
+			}
+			pull, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s %q: %w", name, value, err)
+			}
+			newVolume.RefreshPull = pull
+		case "refresh-signal":
+			if !hasValue {
+				return nil, fmt.Errorf("%v: %w", name, errOptionArg)
+			}
+			sig, err := util.ParseSignal(value)
+			if err != nil {
+				return nil, err
+			}
+			newVolume.RefreshSignal = sig
 		default:
 			return nil, fmt.Errorf("%s: %w", name, util.ErrBadMntOption)
 		}
@@ -760,6 +793,9 @@ func getArtifactVolume(args []string) (*specgen.ArtifactVolume, error) {
 	if len(newVolume.Source)*len(newVolume.Destination) == 0 {
 		return nil, errors.New("must set source and destination for artifact volume")
 	}
+	if newVolume.RefreshInterval == 0 && (newVolume.RefreshPull || newVolume.RefreshSignal != 0) {
+		return nil, errors.New("refresh-pull and refresh-signal require a refresh interval")
+	}
 
 	return newVolume, nil
 }
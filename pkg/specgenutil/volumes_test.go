@@ -1,6 +1,13 @@
 package specgenutil
 
-import "testing"
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v6/pkg/specgen"
+	"github.com/stretchr/testify/assert"
+)
 
 func Test_validChownFlag(t *testing.T) {
 	type args struct {
@@ -66,3 +73,55 @@ func Test_validChownFlag(t *testing.T) {
 		})
 	}
 }
+
+func Test_getArtifactVolume(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    *specgen.ArtifactVolume
+		wantErr string
+	}{
+		{
+			name: "refresh",
+			args: []string{"src=quay.io/models/m:latest", "dst=/models", "refresh=30s", "refresh-pull", "refresh-signal=HUP"},
+			want: &specgen.ArtifactVolume{
+				Source:          "quay.io/models/m:latest",
+				Destination:     "/models",
+				RefreshInterval: 30 * time.Second,
+				RefreshPull:     true,
+				RefreshSignal:   syscall.SIGHUP,
+			},
+		},
+		{
+			name: "refresh-pull=false",
+			args: []string{"src=a", "dst=/a", "refresh=1m", "refresh-pull=false"},
+			want: &specgen.ArtifactVolume{Source: "a", Destination: "/a", RefreshInterval: time.Minute},
+		},
+		{
+			name:    "invalid interval",
+			args:    []string{"src=a", "dst=/a", "refresh=often"},
+			wantErr: `invalid refresh interval "often"`,
+		},
+		{
+			name:    "interval too short",
+			args:    []string{"src=a", "dst=/a", "refresh=10ms"},
+			wantErr: "must be at least 1s",
+		},
+		{
+			name:    "signal without refresh",
+			args:    []string{"src=a", "dst=/a", "refresh-signal=HUP"},
+			wantErr: "require a refresh interval",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getArtifactVolume(tt.args)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -136,6 +136,7 @@ const (
 	KeyNetworkAlias          = "NetworkAlias"
 	KeyNetworkDeleteOnStop   = "NetworkDeleteOnStop"
 	KeyNetworkName           = "NetworkName"
+	KeyNetworkOpt            = "NetworkOpt"
 	KeyNoNewPrivileges       = "NoNewPrivileges"
 	KeyNotify                = "Notify"
 	KeyOptions               = "Options"
@@ -187,6 +188,7 @@ const (
 	KeyUnmask                = "Unmask"
 	KeyUser                  = "User"
 	KeyUserNS                = "UserNS"
+	KeyValues                = "Values"
 	KeyVariant               = "Variant"
 	KeyVolatileTmp           = "VolatileTmp" // deprecated
 	KeyVolume                = "Volume"
@@ -295,6 +297,7 @@ var (
 				KeyMount:                 true,
 				KeyNetwork:               true,
 				KeyNetworkAlias:          true,
+				KeyNetworkOpt:            true,
 				KeyNoNewPrivileges:       true,
 				KeyNotify:                true,
 				KeyPidsLimit:             true,
@@ -407,6 +410,7 @@ var (
 				KeyServiceName:          true,
 				KeySetWorkingDirectory:  true,
 				KeyUserNS:               true,
+				KeyValues:               true,
 				KeyYaml:                 true,
 			},
 		},
@@ -505,6 +509,7 @@ var (
 				KeyLabel:                true,
 				KeyNetwork:              true,
 				KeyNetworkAlias:         true,
+				KeyNetworkOpt:           true,
 				KeyPodName:              true,
 				KeyPodmanArgs:           true,
 				KeyPublishPort:          true,
@@ -695,6 +700,7 @@ func ConvertContainer(container *parser.UnitFile, unitsInfoMap map[string]*UnitI
 
 	allStringsKeys := map[string]string{
 		KeyNetworkAlias: "--network-alias",
+		KeyNetworkOpt:   "--network-opt",
 		KeyUlimit:       "--ulimit",
 		KeyDNS:          "--dns",
 		KeyDNSOption:    "--dns-option",
@@ -1301,6 +1307,17 @@ func ConvertKube(kube *parser.UnitFile, unitsInfoMap map[string]*UnitInfo, isUse
 		execStart.add("--configmap", configMapPath)
 	}
 
+	// The same values render the YAML for both kube play and kube down.
+	var valuesArgs []string
+	for _, values := range kube.LookupAllStrv(KubeGroup, KeyValues) {
+		valuesPath, err := getAbsolutePath(kube, values)
+		if err != nil {
+			return nil, err
+		}
+		valuesArgs = append(valuesArgs, "--values", valuesPath)
+	}
+	execStart.add(valuesArgs...)
+
 	handlePublishPorts(kube, KubeGroup, execStart)
 
 	handlePodmanArgs(kube, KubeGroup, execStart)
@@ -1320,6 +1337,8 @@ func ConvertKube(kube *parser.UnitFile, unitsInfoMap map[string]*UnitInfo, isUse
 		execStop.addBool("--force", kubeDownForce)
 	}
 
+	execStop.add(valuesArgs...)
+
 	// Add all YAML file paths to the stop command
 	execStop.add(absoluteYamlPaths...)
 	service.AddCmdline(ServiceGroup, "ExecStopPost", execStop.Args)
@@ -1656,6 +1675,7 @@ func ConvertPod(podUnit *parser.UnitFile, unitsInfoMap map[string]*UnitInfo, isU
 
 	allStringsKeys := map[string]string{
 		KeyNetworkAlias: "--network-alias",
+		KeyNetworkOpt:   "--network-opt",
 		KeyDNS:          "--dns",
 		KeyDNSOption:    "--dns-option",
 		KeyDNSSearch:    "--dns-search",
@@ -0,0 +1,235 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// maxArgs is the maximum number of exec arguments read.
+	maxArgs = 256
+	// maxSockaddr is the size of struct sockaddr_storage.
+	maxSockaddr = 128
+)
+
+// decode returns the event of the system call the thread enters, or nil if
+// the system call is not traced.
+func (t *tracer) decode(tid int, nr uint64, args *[6]uint64) *Event {
+	switch nr {
+	case unix.SYS_OPENAT:
+		return t.openEvent(tid, int32(args[0]), args[1], args[2])
+	case unix.SYS_OPENAT2:
+		// The flags are the first field of struct open_how.
+		how := make([]byte, 8)
+		if err := readMemory(tid, args[2], how); err != nil {
+			return nil
+		}
+		return t.openEvent(tid, int32(args[0]), args[1], binary.NativeEndian.Uint64(how))
+	case unix.SYS_EXECVE:
+		return t.execEvent(tid, unix.AT_FDCWD, args[0], args[1])
+	case unix.SYS_EXECVEAT:
+		return t.execEvent(tid, int32(args[0]), args[1], args[2])
+	case unix.SYS_CONNECT:
+		size := min(args[2], maxSockaddr)
+		addr := make([]byte, size)
+		if err := readMemory(tid, args[1], addr); err != nil {
+			return nil
+		}
+		family, address, ok := parseSockaddr(addr)
+		if !ok {
+			return nil
+		}
+		return &Event{Time: time.Now(), Type: EventConnect, Family: family, Address: address}
+	}
+	return t.legacyOpenEvent(tid, nr, args)
+}
+
+func (t *tracer) openEvent(tid int, dirfd int32, pathAddr, flags uint64) *Event {
+	p, err := readString(tid, pathAddr)
+	if err != nil || p == "" {
+		return nil
+	}
+	return &Event{Time: time.Now(), Type: EventOpen, Path: resolvePath(tid, dirfd, p), Flags: openFlags(flags)}
+}
+
+func (t *tracer) execEvent(tid int, dirfd int32, pathAddr, argvAddr uint64) *Event {
+	p, err := readString(tid, pathAddr)
+	if err != nil {
+		return nil
+	}
+	ev := &Event{Time: time.Now(), Type: EventExec, Path: resolvePath(tid, dirfd, p)}
+	ptr := make([]byte, 8)
+	for i := range uint64(maxArgs) {
+		if argvAddr == 0 || readMemory(tid, argvAddr+i*8, ptr) != nil {
+			break
+		}
+		addr := binary.NativeEndian.Uint64(ptr)
+		if addr == 0 {
+			break
+		}
+		arg, err := readString(tid, addr)
+		if err != nil {
+			break
+		}
+		ev.Args = append(ev.Args, arg)
+	}
+	return ev
+}
+
+// resolvePath makes a path relative to a directory file descriptor absolute.
+func resolvePath(tid int, dirfd int32, p string) string {
+	if path.IsAbs(p) {
+		return p
+	}
+	link := fmt.Sprintf("/proc/%d/cwd", tid)
+	if dirfd != unix.AT_FDCWD {
+		link = fmt.Sprintf("/proc/%d/fd/%d", tid, dirfd)
+	}
+	dir, err := os.Readlink(link)
+	if err != nil {
+		return p
+	}
+	return path.Join(dir, p)
+}
+
+// readMemory reads the memory of the thread at addr into buf.
+func readMemory(tid int, addr uint64, buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	local := []unix.Iovec{{Base: &buf[0]}}
+	local[0].SetLen(len(buf))
+	remote := []unix.RemoteIovec{{Base: uintptr(addr), Len: len(buf)}}
+	n, err := unix.ProcessVMReadv(tid, local, remote, 0)
+	if err != nil {
+		return err
+	}
+	if n != len(buf) {
+		return fmt.Errorf("short read of %d bytes", n)
+	}
+	return nil
+}
+
+// readString reads a NUL terminated string of at most PATH_MAX bytes.
+func readString(tid int, addr uint64) (string, error) {
+	var sb strings.Builder
+	pageSize := uint64(os.Getpagesize())
+	buf := make([]byte, 256)
+	for sb.Len() < unix.PathMax {
+		// Do not read across pages, the next one may not be mapped.
+		chunk := buf[:min(uint64(len(buf)), pageSize-addr%pageSize)]
+		if err := readMemory(tid, addr, chunk); err != nil {
+			return "", err
+		}
+		if i := bytes.IndexByte(chunk, 0); i >= 0 {
+			sb.Write(chunk[:i])
+			return sb.String(), nil
+		}
+		sb.Write(chunk)
+		addr += uint64(len(chunk))
+	}
+	return sb.String(), nil
+}
+
+// readComm returns the name of the executable of the process.
+func readComm(pid int) string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+// openFlagNames are the names of the open(2) flags besides the access mode.
+var openFlagNames = []struct {
+	flag int
+	name string
+}{
+	{unix.O_CREAT, "O_CREAT"},
+	{unix.O_EXCL, "O_EXCL"},
+	{unix.O_NOCTTY, "O_NOCTTY"},
+	{unix.O_TRUNC, "O_TRUNC"},
+	{unix.O_APPEND, "O_APPEND"},
+	{unix.O_NONBLOCK, "O_NONBLOCK"},
+	{unix.O_DSYNC, "O_DSYNC"},
+	{unix.O_DIRECT, "O_DIRECT"},
+	{unix.O_DIRECTORY, "O_DIRECTORY"},
+	{unix.O_NOFOLLOW, "O_NOFOLLOW"},
+	{unix.O_NOATIME, "O_NOATIME"},
+	{unix.O_CLOEXEC, "O_CLOEXEC"},
+	{unix.O_PATH, "O_PATH"},
+}
+
+// openFlags formats open(2) flags, e.g. O_WRONLY|O_CREAT|O_TRUNC.
+func openFlags(flags uint64) string {
+	f := int(flags)
+	names := []string{}
+	switch f & unix.O_ACCMODE {
+	case unix.O_WRONLY:
+		names = append(names, "O_WRONLY")
+	case unix.O_RDWR:
+		names = append(names, "O_RDWR")
+	default:
+		names = append(names, "O_RDONLY")
+	}
+	// O_TMPFILE includes O_DIRECTORY.
+	if f&unix.O_TMPFILE == unix.O_TMPFILE {
+		names = append(names, "O_TMPFILE")
+		f &^= unix.O_TMPFILE
+	}
+	// O_SYNC includes O_DSYNC.
+	if f&unix.O_SYNC == unix.O_SYNC {
+		names = append(names, "O_SYNC")
+		f &^= unix.O_SYNC
+	}
+	for _, n := range openFlagNames {
+		if f&n.flag != 0 {
+			names = append(names, n.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// parseSockaddr returns the family and address of inet, inet6 and unix socket
+// addresses.
+func parseSockaddr(b []byte) (family, address string, ok bool) {
+	if len(b) < 2 {
+		return "", "", false
+	}
+	switch binary.NativeEndian.Uint16(b) {
+	case unix.AF_INET:
+		if len(b) < int(unsafe.Sizeof(unix.RawSockaddrInet4{})) {
+			return "", "", false
+		}
+		port := binary.BigEndian.Uint16(b[2:4])
+		return "inet", net.JoinHostPort(net.IP(b[4:8]).String(), strconv.Itoa(int(port))), true
+	case unix.AF_INET6:
+		if len(b) < int(unsafe.Sizeof(unix.RawSockaddrInet6{})) {
+			return "", "", false
+		}
+		port := binary.BigEndian.Uint16(b[2:4])
+		return "inet6", net.JoinHostPort(net.IP(b[8:24]).String(), strconv.Itoa(int(port))), true
+	case unix.AF_UNIX:
+		name := b[2:]
+		// Abstract sockets start with a NUL byte and are not NUL
+		// terminated.
+		if len(name) > 0 && name[0] == 0 {
+			return "unix", "@" + string(name[1:]), true
+		}
+		if i := bytes.IndexByte(name, 0); i >= 0 {
+			name = name[:i]
+		}
+		return "unix", string(name), true
+	}
+	return "", "", false
+}
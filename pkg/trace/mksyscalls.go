@@ -0,0 +1,55 @@
+//go:build ignore
+
+// mksyscalls generates the table of syscall names of an architecture from the
+// syscall numbers of golang.org/x/sys/unix.
+//
+//	go run mksyscalls.go amd64
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var sysnum = regexp.MustCompile(`(?m)^\s+SYS_(\w+)\s+=\s+(\d+)$`)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: go run mksyscalls.go ARCH")
+		os.Exit(1)
+	}
+	arch := os.Args[1]
+	data, err := os.ReadFile(fmt.Sprintf("../../vendor/golang.org/x/sys/unix/zsysnum_linux_%s.go", arch))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by \"go run mksyscalls.go %s\"; DO NOT EDIT.\n\n", arch)
+	fmt.Fprintf(&buf, "package trace\n\n// syscallNames maps the syscall numbers of %s to their names.\nvar syscallNames = map[uint64]string{\n", arch)
+	for _, m := range sysnum.FindAllStringSubmatch(string(data), -1) {
+		nr, err := strconv.ParseUint(m[2], 10, 64)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(&buf, "\t%d: %q,\n", nr, strings.ToLower(m[1]))
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(fmt.Sprintf("zsyscalls_linux_%s.go", arch), src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,82 @@
+package trace
+
+import (
+	"runtime"
+	"slices"
+
+	"go.podman.io/common/pkg/seccomp"
+	"golang.org/x/sys/unix"
+)
+
+// startupSyscalls are used by the OCI runtime to execute the process of the
+// container after loading the seccomp filter, and by the dynamic loader and
+// libc to start it.  They are missed when attaching to a running container.
+var startupSyscalls = []string{
+	"arch_prctl",
+	"brk",
+	"capget",
+	"capset",
+	"close",
+	"execve",
+	"exit",
+	"exit_group",
+	"fcntl",
+	"fstat",
+	"futex",
+	"getpid",
+	"getppid",
+	"mmap",
+	"mprotect",
+	"munmap",
+	"newfstatat",
+	"openat",
+	"prctl",
+	"pread64",
+	"prlimit64",
+	"read",
+	"rseq",
+	"rt_sigaction",
+	"rt_sigprocmask",
+	"rt_sigreturn",
+	"set_robust_list",
+	"set_tid_address",
+	"write",
+}
+
+// GenerateSeccompProfile returns a seccomp profile allowing only the system
+// calls and the ones needed to start the container, and failing all others
+// with EPERM.
+func GenerateSeccompProfile(syscalls []string) (*seccomp.Seccomp, error) {
+	arch, err := seccomp.GoArchToSeccompArch(runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(syscallNames))
+	for _, name := range syscallNames {
+		known[name] = true
+	}
+	names := slices.Clone(syscalls)
+	for _, name := range startupSyscalls {
+		// Skip the system calls which do not exist on this
+		// architecture, like arch_prctl on arm64.
+		if known[name] {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	errno := uint(unix.EPERM)
+	return &seccomp.Seccomp{
+		DefaultAction:   seccomp.ActErrno,
+		DefaultErrnoRet: &errno,
+		Architectures:   []seccomp.Arch{arch},
+		Syscalls: []*seccomp.Syscall{
+			{
+				Names:  names,
+				Action: seccomp.ActAllow,
+				Args:   []*seccomp.Arg{},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,20 @@
+package trace
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// auditArch identifies the system calls of the native architecture.
+const auditArch = unix.AUDIT_ARCH_X86_64
+
+// legacyOpenEvent decodes the open(2) and creat(2) system calls which only
+// exist on some architectures.
+func (t *tracer) legacyOpenEvent(tid int, nr uint64, args *[6]uint64) *Event {
+	switch nr {
+	case unix.SYS_OPEN:
+		return t.openEvent(tid, unix.AT_FDCWD, args[0], args[1])
+	case unix.SYS_CREAT:
+		return t.openEvent(tid, unix.AT_FDCWD, args[0], unix.O_CREAT|unix.O_WRONLY|unix.O_TRUNC)
+	}
+	return nil
+}
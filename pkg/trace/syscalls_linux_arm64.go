@@ -0,0 +1,13 @@
+package trace
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// auditArch identifies the system calls of the native architecture.
+const auditArch = unix.AUDIT_ARCH_AARCH64
+
+// legacyOpenEvent returns nil, arm64 only has openat(2) and openat2(2).
+func (t *tracer) legacyOpenEvent(_ int, _ uint64, _ *[6]uint64) *Event {
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build linux && !amd64 && !arm64
+
+package trace
+
+// auditArch is unknown, tracing is not supported.
+const auditArch = 0
+
+// syscallNames is empty, tracing is not supported.
+var syscallNames map[uint64]string
+
+func (t *tracer) legacyOpenEvent(_ int, _ uint64, _ *[6]uint64) *Event {
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package trace traces the file, exec and network activity of the processes
+// of a container from the host with ptrace(2), and generates seccomp profiles
+// from the system calls they use.
+package trace
+
+//go:generate go run mksyscalls.go amd64
+//go:generate go run mksyscalls.go arm64
+
+import (
+	"time"
+)
+
+// Types of events.
+const (
+	// EventOpen is a file opened with open(2), openat(2), openat2(2) or
+	// creat(2).
+	EventOpen = "open"
+	// EventExec is a binary executed with execve(2) or execveat(2).
+	EventExec = "exec"
+	// EventConnect is a connection made with connect(2).
+	EventConnect = "connect"
+)
+
+// Event is a file opened, a binary executed or a network connection made by a
+// traced process.
+type Event struct {
+	Time time.Time `json:"time"`
+	// Type is one of open, exec or connect.
+	Type string `json:"type"`
+	// PID is the host PID of the process.
+	PID int `json:"pid"`
+	// Command is the name of the executable of the process.
+	Command string `json:"command"`
+	// Path is the path of the opened file or executed binary, in the
+	// container.
+	Path string `json:"path,omitempty"`
+	// Flags are the open(2) flags of the file, e.g. O_RDONLY|O_CLOEXEC.
+	Flags string `json:"flags,omitempty"`
+	// Args are the arguments of the executed binary.
+	Args []string `json:"args,omitempty"`
+	// Family is the address family of the connection: inet, inet6 or
+	// unix.
+	Family string `json:"family,omitempty"`
+	// Address is the address connected to, e.g. 10.88.0.1:80 or the path
+	// of a unix socket.  Abstract unix sockets start with @.
+	Address string `json:"address,omitempty"`
+	// Error is the error returned by the system call, empty on success.
+	Error string `json:"error,omitempty"`
+}
+
+// Options are the options of Trace.
+type Options struct {
+	// Pids returns the PIDs of the processes to trace.  It is called
+	// periodically to also trace new processes, like exec sessions.
+	Pids func() ([]int, error)
+	// Events receives the traced events.  It is not closed by Trace.
+	Events chan<- Event
+}
@@ -0,0 +1,233 @@
+package trace
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/common/pkg/seccomp"
+	"golang.org/x/sys/unix"
+)
+
+func TestOpenFlags(t *testing.T) {
+	tests := []struct {
+		flags uint64
+		want  string
+	}{
+		{flags: unix.O_RDONLY, want: "O_RDONLY"},
+		{flags: unix.O_RDONLY | unix.O_CLOEXEC | unix.O_DIRECTORY, want: "O_RDONLY|O_DIRECTORY|O_CLOEXEC"},
+		{flags: unix.O_WRONLY | unix.O_CREAT | unix.O_TRUNC, want: "O_WRONLY|O_CREAT|O_TRUNC"},
+		{flags: unix.O_RDWR | unix.O_TMPFILE, want: "O_RDWR|O_TMPFILE"},
+		{flags: unix.O_WRONLY | unix.O_SYNC, want: "O_WRONLY|O_SYNC"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, openFlags(tt.flags))
+	}
+}
+
+func TestParseSockaddr(t *testing.T) {
+	inet := make([]byte, 16)
+	binary.NativeEndian.PutUint16(inet, unix.AF_INET)
+	binary.BigEndian.PutUint16(inet[2:], 8080)
+	copy(inet[4:], []byte{10, 88, 0, 1})
+
+	inet6 := make([]byte, 28)
+	binary.NativeEndian.PutUint16(inet6, unix.AF_INET6)
+	binary.BigEndian.PutUint16(inet6[2:], 443)
+	inet6[23] = 1
+
+	unixPath := make([]byte, 2, 110)
+	binary.NativeEndian.PutUint16(unixPath, unix.AF_UNIX)
+	unixPath = append(unixPath, "/run/app.sock\x00\x00\x00"...)
+
+	abstract := make([]byte, 2, 10)
+	binary.NativeEndian.PutUint16(abstract, unix.AF_UNIX)
+	abstract = append(abstract, "\x00app"...)
+
+	netlink := make([]byte, 12)
+	binary.NativeEndian.PutUint16(netlink, unix.AF_NETLINK)
+
+	tests := []struct {
+		name    string
+		addr    []byte
+		family  string
+		address string
+		ok      bool
+	}{
+		{name: "inet", addr: inet, family: "inet", address: "10.88.0.1:8080", ok: true},
+		{name: "inet6", addr: inet6, family: "inet6", address: "[::1]:443", ok: true},
+		{name: "unix", addr: unixPath, family: "unix", address: "/run/app.sock", ok: true},
+		{name: "abstract unix", addr: abstract, family: "unix", address: "@app", ok: true},
+		{name: "netlink", addr: netlink},
+		{name: "short inet", addr: inet[:8]},
+		{name: "empty", addr: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			family, address, ok := parseSockaddr(tt.addr)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.family, family)
+			assert.Equal(t, tt.address, address)
+		})
+	}
+}
+
+func TestGenerateSeccompProfile(t *testing.T) {
+	if len(syscallNames) == 0 {
+		t.Skip("tracing is not supported on this architecture")
+	}
+	profile, err := GenerateSeccompProfile([]string{"socket", "connect", "read"})
+	require.NoError(t, err)
+	assert.Equal(t, seccomp.ActErrno, profile.DefaultAction)
+	require.NotNil(t, profile.DefaultErrnoRet)
+	assert.Equal(t, uint(unix.EPERM), *profile.DefaultErrnoRet)
+	require.Len(t, profile.Syscalls, 1)
+	names := profile.Syscalls[0].Names
+	assert.True(t, slices.IsSorted(names))
+	assert.Subset(t, names, []string{"socket", "connect", "read", "execve", "exit_group"})
+	assert.Len(t, slices.Compact(slices.Clone(names)), len(names))
+}
+
+func TestTrace(t *testing.T) {
+	if len(syscallNames) == 0 {
+		t.Skip("tracing is not supported on this architecture")
+	}
+	cmd := exec.Command("sh", "-c", "read x; cat /etc/hostname >/dev/null; exec true")
+	stdin, err := cmd.StdinPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	defer func() {
+		_ = cmd.Process.Kill()
+		// Trace reaps the process.
+		_ = cmd.Wait()
+	}()
+
+	events := make(chan Event, 100)
+	done := make(chan error, 1)
+	var syscalls []string
+	go func() {
+		var err error
+		syscalls, err = Trace(context.Background(), Options{
+			Pids:   func() ([]int, error) { return []int{cmd.Process.Pid}, nil },
+			Events: events,
+		})
+		done <- err
+	}()
+	// Let the tracer attach before the shell continues.
+	time.Sleep(200 * time.Millisecond)
+	_, err = stdin.Write([]byte("\n"))
+	require.NoError(t, err)
+
+	select {
+	case err := <-done:
+		if errors.Is(err, unix.EPERM) {
+			t.Skipf("ptrace is not permitted: %v", err)
+		}
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for the trace to end")
+	}
+	close(events)
+
+	var opened, executed []string
+	for ev := range events {
+		switch ev.Type {
+		case EventOpen:
+			opened = append(opened, ev.Path)
+		case EventExec:
+			executed = append(executed, ev.Path)
+		}
+	}
+	assert.Contains(t, opened, "/etc/hostname")
+	assert.NotEmpty(t, executed)
+	assert.Contains(t, syscalls, "execve")
+}
+
+func TestTraceDetach(t *testing.T) {
+	if len(syscallNames) == 0 {
+		t.Skip("tracing is not supported on this architecture")
+	}
+	cmd := exec.Command("sh", "-c", "while :; do sleep 0.1; done")
+	require.NoError(t, cmd.Start())
+	pid := cmd.Process.Pid
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan Event, 100)
+	done := make(chan error, 1)
+	go func() {
+		_, err := Trace(ctx, Options{
+			Pids:   func() ([]int, error) { return []int{pid}, nil },
+			Events: events,
+		})
+		done <- err
+	}()
+	time.Sleep(500 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if errors.Is(err, unix.EPERM) {
+			t.Skipf("ptrace is not permitted: %v", err)
+		}
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for the trace to end")
+	}
+
+	// The process keeps running and is not traced anymore.
+	require.NoError(t, unix.Kill(pid, 0))
+	status, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	require.NoError(t, err)
+	assert.Contains(t, string(status), "TracerPid:\t0\n")
+}
+
+func TestTraceOtherChildren(t *testing.T) {
+	if len(syscallNames) == 0 {
+		t.Skip("tracing is not supported on this architecture")
+	}
+	// A child which is not traced and exits while the trace runs.
+	other := exec.Command("true")
+	require.NoError(t, other.Start())
+
+	cmd := exec.Command("sh", "-c", "sleep 0.5; exec true")
+	require.NoError(t, cmd.Start())
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	events := make(chan Event, 100)
+	done := make(chan error, 1)
+	go func() {
+		_, err := Trace(context.Background(), Options{
+			Pids:   func() ([]int, error) { return []int{cmd.Process.Pid}, nil },
+			Events: events,
+		})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if errors.Is(err, unix.EPERM) {
+			t.Skipf("ptrace is not permitted: %v", err)
+		}
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for the trace to end")
+	}
+
+	// The exit status of the other child was not reaped by the tracer.
+	require.NoError(t, other.Wait())
+}
@@ -0,0 +1,20 @@
+//go:build !linux
+
+package trace
+
+import (
+	"context"
+	"errors"
+
+	"go.podman.io/common/pkg/seccomp"
+)
+
+// Trace is only supported on Linux.
+func Trace(_ context.Context, _ Options) ([]string, error) {
+	return nil, errors.New("tracing is only supported on Linux")
+}
+
+// GenerateSeccompProfile is only supported on Linux.
+func GenerateSeccompProfile(_ []string) (*seccomp.Seccomp, error) {
+	return nil, errors.New("generating seccomp profiles is only supported on Linux")
+}
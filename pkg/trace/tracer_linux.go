@@ -0,0 +1,375 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// ptraceOptions stop the tracees at system calls and attach to their new
+// threads and child processes.  PTRACE_O_EXITKILL is not set on purpose, the
+// processes of the container must survive the tracer.
+const ptraceOptions = unix.PTRACE_O_TRACESYSGOOD | unix.PTRACE_O_TRACECLONE |
+	unix.PTRACE_O_TRACEFORK | unix.PTRACE_O_TRACEVFORK | unix.PTRACE_O_TRACEEXEC
+
+// rescanInterval is the interval to look for new processes to trace.
+const rescanInterval = time.Second
+
+// syscallInfo is struct ptrace_syscall_info of PTRACE_GET_SYSCALL_INFO.
+type syscallInfo struct {
+	Op   uint8
+	_    [3]uint8
+	Arch uint32
+	IP   uint64
+	SP   uint64
+	// Data is nr and args for entries, and rval and is_error for exits.
+	Data [8]uint64
+}
+
+type tracee struct {
+	// tgid is the PID of the process of the thread.
+	tgid int
+	// pending is the event of the system call the thread is in, it is
+	// sent with the error of the system call when it returns.
+	pending *Event
+}
+
+type tracer struct {
+	ctx      context.Context
+	events   chan<- Event
+	tracees  map[int]*tracee
+	syscalls map[uint64]bool
+}
+
+// Trace traces the processes until they all exit or the context is cancelled,
+// and then detaches from them.  It returns the names of the system calls the
+// processes used while being traced.
+//
+// All ptrace(2) requests must be made by the thread which attached to the
+// tracees, so Trace locks the calling goroutine to its thread.  Trace waits
+// for each tracee, other children of the current process are not reaped.
+func Trace(ctx context.Context, options Options) ([]string, error) {
+	if len(syscallNames) == 0 {
+		return nil, fmt.Errorf("tracing is not supported on %s", runtime.GOARCH)
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	t := &tracer{
+		ctx:      ctx,
+		events:   options.Events,
+		tracees:  make(map[int]*tracee),
+		syscalls: make(map[uint64]bool),
+	}
+	pids, err := options.Pids()
+	if err != nil {
+		return nil, err
+	}
+	if err := t.attach(pids, true); err != nil {
+		t.detach()
+		return nil, err
+	}
+	if len(t.tracees) == 0 {
+		return nil, errors.New("no processes to trace")
+	}
+
+	lastScan := time.Now()
+	backoff := time.Millisecond
+	for len(t.tracees) > 0 {
+		if ctx.Err() != nil {
+			t.detach()
+			break
+		}
+		if time.Since(lastScan) > rescanInterval {
+			// The cgroup is gone when the container stops, the
+			// processes are removed by their exit status.
+			if pids, err := options.Pids(); err == nil {
+				if err := t.attach(pids, false); err != nil {
+					t.detach()
+					return nil, err
+				}
+			}
+			lastScan = time.Now()
+		}
+
+		var status unix.WaitStatus
+		tid, err := t.wait(&status)
+		if err != nil {
+			if errors.Is(err, unix.EINTR) {
+				continue
+			}
+			t.detach()
+			return nil, fmt.Errorf("waiting for traced processes: %w", err)
+		}
+		if tid == 0 {
+			// Nothing to do, poll with an increasing interval to
+			// not burn cpu while the processes are idle.
+			time.Sleep(backoff)
+			backoff = min(backoff*2, 50*time.Millisecond)
+			continue
+		}
+		backoff = time.Millisecond
+		t.handle(tid, status)
+	}
+	return t.syscallNames(), nil
+}
+
+// attach seizes all threads of the processes which are not traced yet.  When
+// rescanning, threads which are being traced already but were not reported
+// by wait yet are skipped.
+func (t *tracer) attach(pids []int, initial bool) error {
+	for _, pid := range pids {
+		tasks, err := os.ReadDir(fmt.Sprintf("/proc/%d/task", pid))
+		if err != nil {
+			// The process exited.
+			continue
+		}
+		for _, task := range tasks {
+			tid, err := strconv.Atoi(task.Name())
+			if err != nil {
+				continue
+			}
+			if _, ok := t.tracees[tid]; ok {
+				continue
+			}
+			if _, _, errno := unix.Syscall6(unix.SYS_PTRACE, unix.PTRACE_SEIZE, uintptr(tid), 0, ptraceOptions, 0, 0); errno != 0 {
+				if errno == unix.ESRCH || (errno == unix.EPERM && !initial) {
+					continue
+				}
+				return fmt.Errorf("attaching to process %d: %w", tid, errno)
+			}
+			if err := unix.PtraceInterrupt(tid); err != nil && !errors.Is(err, unix.ESRCH) {
+				return fmt.Errorf("interrupting process %d: %w", tid, err)
+			}
+			t.tracees[tid] = &tracee{tgid: pid}
+		}
+	}
+	return nil
+}
+
+// wait polls each tracee for a state change without blocking, waiting for
+// any child would reap the other children of the current process.  It
+// returns 0 if no tracee changed its state.
+func (t *tracer) wait(status *unix.WaitStatus) (int, error) {
+	for tid := range t.tracees {
+		wtid, err := unix.Wait4(tid, status, unix.WALL|unix.WNOHANG, nil)
+		switch {
+		case errors.Is(err, unix.ECHILD):
+			// The thread is gone without a report, such as a
+			// thread executing a binary which took over the
+			// thread group leader.
+			delete(t.tracees, tid)
+		case err != nil:
+			return 0, err
+		case wtid > 0:
+			return wtid, nil
+		}
+	}
+	return 0, nil
+}
+
+// handle handles a state change of a tracee reported by wait and resumes it.
+func (t *tracer) handle(tid int, status unix.WaitStatus) {
+	if status.Exited() || status.Signaled() {
+		delete(t.tracees, tid)
+		return
+	}
+	if !status.Stopped() {
+		return
+	}
+	tr := t.tracee(tid)
+
+	sig := status.StopSignal()
+	event := uint32(status) >> 16
+	switch {
+	case sig == unix.SIGTRAP|0x80:
+		t.syscallStop(tid, tr)
+		t.resume(tid, 0)
+	case event == unix.PTRACE_EVENT_STOP:
+		if sig == unix.SIGSTOP || sig == unix.SIGTSTP || sig == unix.SIGTTIN || sig == unix.SIGTTOU {
+			// Group-stop, keep the thread stopped until it is
+			// continued.
+			if _, _, errno := unix.Syscall6(unix.SYS_PTRACE, unix.PTRACE_LISTEN, uintptr(tid), 0, 0, 0, 0); errno != 0 && errno != unix.ESRCH {
+				logrus.Debugf("Listening on traced process %d: %v", tid, errno)
+			}
+			return
+		}
+		// Stopped by PTRACE_INTERRUPT after attaching, or a new child.
+		t.resume(tid, 0)
+	case event == unix.PTRACE_EVENT_EXEC:
+		// A thread executing a binary takes over the thread group
+		// leader, move its pending exec event.
+		if msg, err := unix.PtraceGetEventMsg(tid); err == nil && int(msg) != tid {
+			if former, ok := t.tracees[int(msg)]; ok {
+				tr.pending = former.pending
+				delete(t.tracees, int(msg))
+			}
+		}
+		tr.tgid = tid
+		t.resume(tid, 0)
+	case event == unix.PTRACE_EVENT_CLONE || event == unix.PTRACE_EVENT_FORK || event == unix.PTRACE_EVENT_VFORK:
+		// New children are attached automatically and reported with
+		// a PTRACE_EVENT_STOP.  The process of cloned threads is looked
+		// up when they make their first traced system call.
+		if msg, err := unix.PtraceGetEventMsg(tid); err == nil {
+			child := t.tracee(int(msg))
+			if event != unix.PTRACE_EVENT_CLONE {
+				child.tgid = int(msg)
+			}
+		}
+		t.resume(tid, 0)
+	default:
+		// Signal-delivery stop, deliver the signal.
+		t.resume(tid, int(sig))
+	}
+}
+
+// tracee returns the tracee of the thread, adding it if it is new.
+func (t *tracer) tracee(tid int) *tracee {
+	tr, ok := t.tracees[tid]
+	if !ok {
+		tr = &tracee{}
+		t.tracees[tid] = tr
+	}
+	return tr
+}
+
+func (t *tracer) resume(tid, sig int) {
+	if err := unix.PtraceSyscall(tid, sig); err != nil && !errors.Is(err, unix.ESRCH) {
+		logrus.Debugf("Resuming traced process %d: %v", tid, err)
+	}
+}
+
+// syscallStop records the system call the thread enters, and sends the event
+// of the system call it returns from.
+func (t *tracer) syscallStop(tid int, tr *tracee) {
+	var info syscallInfo
+	if _, _, errno := unix.Syscall6(unix.SYS_PTRACE, unix.PTRACE_GET_SYSCALL_INFO, uintptr(tid), unsafe.Sizeof(info), uintptr(unsafe.Pointer(&info)), 0, 0); errno != 0 {
+		if errno != unix.ESRCH {
+			logrus.Debugf("Getting system call of traced process %d: %v", tid, errno)
+		}
+		return
+	}
+	// System calls of compat architectures, e.g. i386 binaries on x86_64,
+	// have different numbers.
+	if info.Arch != auditArch {
+		return
+	}
+
+	switch info.Op {
+	case unix.PTRACE_SYSCALL_INFO_ENTRY:
+		nr := info.Data[0]
+		t.syscalls[nr] = true
+		args := [6]uint64(info.Data[1:7])
+		tr.pending = t.decode(tid, nr, &args)
+		if tr.pending != nil {
+			tr.pending.PID = t.tgid(tid, tr)
+			tr.pending.Command = readComm(tr.pending.PID)
+		}
+	case unix.PTRACE_SYSCALL_INFO_EXIT:
+		ev := tr.pending
+		tr.pending = nil
+		if ev == nil {
+			return
+		}
+		if rval := int64(info.Data[0]); info.Data[1]&0xff != 0 {
+			errno := unix.Errno(-rval)
+			// Non-blocking connections are still made.
+			if ev.Type == EventConnect && errno == unix.EINPROGRESS {
+				errno = 0
+			}
+			if errno != 0 {
+				ev.Error = errno.Error()
+			}
+		}
+		select {
+		case t.events <- *ev:
+		case <-t.ctx.Done():
+		}
+	}
+}
+
+// tgid returns the PID of the process of the thread.
+func (t *tracer) tgid(tid int, tr *tracee) int {
+	if tr.tgid != 0 {
+		return tr.tgid
+	}
+	tr.tgid = tid
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", tid))
+	if err != nil {
+		return tid
+	}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if value, ok := strings.CutPrefix(line, "Tgid:"); ok {
+			if tgid, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				tr.tgid = tgid
+			}
+			break
+		}
+	}
+	return tr.tgid
+}
+
+// detach interrupts all tracees and detaches from them once they stopped,
+// delivering the signals they were stopped for.
+func (t *tracer) detach() {
+	for tid := range t.tracees {
+		_ = unix.PtraceInterrupt(tid)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(t.tracees) > 0 && time.Now().Before(deadline) {
+		var status unix.WaitStatus
+		tid, err := t.wait(&status)
+		if err != nil {
+			if errors.Is(err, unix.EINTR) {
+				continue
+			}
+			break
+		}
+		if tid == 0 {
+			time.Sleep(time.Millisecond)
+			continue
+		}
+		if status.Exited() || status.Signaled() {
+			delete(t.tracees, tid)
+			continue
+		}
+		if !status.Stopped() {
+			continue
+		}
+		sig := 0
+		if s := status.StopSignal(); s != unix.SIGTRAP|0x80 && uint32(status)>>16 == 0 {
+			sig = int(s)
+		}
+		if _, _, errno := unix.Syscall6(unix.SYS_PTRACE, unix.PTRACE_DETACH, uintptr(tid), 0, uintptr(sig), 0, 0); errno != 0 && errno != unix.ESRCH {
+			logrus.Debugf("Detaching from traced process %d: %v", tid, errno)
+		}
+		delete(t.tracees, tid)
+	}
+	if len(t.tracees) > 0 {
+		logrus.Warnf("Could not detach from %d traced threads, they are detached when podman exits", len(t.tracees))
+	}
+}
+
+// syscallNames returns the sorted names of the system calls used.
+func (t *tracer) syscallNames() []string {
+	names := make([]string, 0, len(t.syscalls))
+	for nr := range t.syscalls {
+		if name, ok := syscallNames[nr]; ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,168 @@
+package trust
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// Media types of attestations attached to images.
+const (
+	// DSSEEnvelopeMediaType is used by cosign for layers of attestation
+	// manifests.
+	DSSEEnvelopeMediaType = "application/vnd.dsse.envelope.v1+json"
+	// SigstoreBundleMediaTypePrefix prefixes the versioned media types of
+	// sigstore bundles, attached as OCI referrers by newer cosign versions.
+	SigstoreBundleMediaTypePrefix = "application/vnd.dev.sigstore.bundle"
+
+	inTotoPayloadType          = "application/vnd.in-toto+json"
+	inTotoStatementTypePrefix  = "https://in-toto.io/Statement/"
+	predicateTypeAnnotationKey = "predicateType"
+)
+
+// VerifyAttestation describes an in-toto attestation attached to an image.
+type VerifyAttestation struct {
+	// Reference is the manifest holding the attestation.
+	Reference string
+	// PredicateType is the type of the attestation, e.g.
+	// https://slsa.dev/provenance/v1.
+	PredicateType string `json:",omitempty"`
+	// Key, Identity and Issuer describe the signer as for signatures.
+	Key      string `json:",omitempty"`
+	Identity string `json:",omitempty"`
+	Issuer   string `json:",omitempty"`
+	// Verified is true if the attestation is signed by a key or Fulcio
+	// identity accepted by the policy and is about the image.
+	Verified bool
+	// Error explains why the attestation could not be verified.
+	Error string `json:",omitempty"`
+}
+
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     []byte `json:"payload"`
+	Signatures  []struct {
+		KeyID string `json:"keyid"`
+		Sig   []byte `json:"sig"`
+	} `json:"signatures"`
+}
+
+// pae returns the DSSE pre-authentication encoding of the envelope, the
+// data that is signed.
+func (e *dsseEnvelope) pae() []byte {
+	return fmt.Appendf(nil, "DSSEv1 %d %s %d %s", len(e.PayloadType), e.PayloadType, len(e.Payload), e.Payload)
+}
+
+type inTotoStatement struct {
+	Type          string `json:"_type"`
+	PredicateType string `json:"predicateType"`
+	Subject       []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+type sigstoreBundle struct {
+	VerificationMaterial struct {
+		Certificate *struct {
+			RawBytes []byte `json:"rawBytes"`
+		} `json:"certificate"`
+		X509CertificateChain *struct {
+			Certificates []struct {
+				RawBytes []byte `json:"rawBytes"`
+			} `json:"certificates"`
+		} `json:"x509CertificateChain"`
+	} `json:"verificationMaterial"`
+	DSSEEnvelope *dsseEnvelope `json:"dsseEnvelope"`
+}
+
+func derToPEM(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// VerifyAttestationLayer verifies an attestation stored as a layer of an
+// attached manifest, either a DSSE envelope with the certificate in the
+// layer annotations or a sigstore bundle. The attestation must be about
+// one of the given image digests and be signed by a key or Fulcio identity
+// of the sigstoreSigned requirements.
+func VerifyAttestationLayer(mediaType string, data []byte, annotations map[string]string, digests []digest.Digest, reqs []PolicyRequirement) VerifyAttestation {
+	att := VerifyAttestation{PredicateType: annotations[predicateTypeAnnotationKey]}
+	var (
+		envelope dsseEnvelope
+		v        verification
+	)
+	switch {
+	case mediaType == DSSEEnvelopeMediaType:
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			att.Error = fmt.Sprintf("parsing DSSE envelope: %v", err)
+			return att
+		}
+		v.certificate = []byte(annotations[sigstoreCertificateAnnotationKey])
+		v.chain = []byte(annotations[sigstoreChainAnnotationKey])
+	case strings.HasPrefix(mediaType, SigstoreBundleMediaTypePrefix):
+		var bundle sigstoreBundle
+		if err := json.Unmarshal(data, &bundle); err != nil {
+			att.Error = fmt.Sprintf("parsing sigstore bundle: %v", err)
+			return att
+		}
+		if bundle.DSSEEnvelope == nil {
+			att.Error = "sigstore bundle does not contain an attestation"
+			return att
+		}
+		envelope = *bundle.DSSEEnvelope
+		material := bundle.VerificationMaterial
+		switch {
+		case material.Certificate != nil:
+			v.certificate = derToPEM(material.Certificate.RawBytes)
+		case material.X509CertificateChain != nil && len(material.X509CertificateChain.Certificates) > 0:
+			certs := material.X509CertificateChain.Certificates
+			v.certificate = derToPEM(certs[0].RawBytes)
+			for _, c := range certs[1:] {
+				v.chain = append(v.chain, derToPEM(c.RawBytes)...)
+			}
+		}
+	default:
+		att.Error = fmt.Sprintf("unsupported attestation media type %q", mediaType)
+		return att
+	}
+
+	if envelope.PayloadType != inTotoPayloadType {
+		att.Error = fmt.Sprintf("unsupported payload type %q", envelope.PayloadType)
+		return att
+	}
+	var statement inTotoStatement
+	if err := json.Unmarshal(envelope.Payload, &statement); err != nil || !strings.HasPrefix(statement.Type, inTotoStatementTypePrefix) {
+		att.Error = "payload is not an in-toto statement"
+		return att
+	}
+	att.PredicateType = statement.PredicateType
+
+	v.data = envelope.pae()
+	res := verificationResult{err: errors.New("attestation is not signed")}
+	for _, s := range envelope.Signatures {
+		v.signature = s.Sig
+		if res = v.verify(reqs); res.verified {
+			break
+		}
+	}
+	att.Key, att.Identity, att.Issuer = res.key, res.identity, res.issuer
+	if !res.verified {
+		att.Error = res.err.Error()
+		return att
+	}
+
+	for _, s := range statement.Subject {
+		for _, d := range digests {
+			if s.Digest[d.Algorithm().String()] == d.Encoded() {
+				att.Verified = true
+				return att
+			}
+		}
+	}
+	att.Error = "attestation is not about this image"
+	return att
+}
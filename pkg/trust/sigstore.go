@@ -0,0 +1,348 @@
+package trust
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	sigstoreSignature "github.com/sigstore/sigstore/pkg/signature"
+	"go.podman.io/image/v5/signature"
+)
+
+// Annotations of sigstore signatures, from sigstore/cosign/pkg/oci/static.
+const (
+	sigstoreSignatureAnnotationKey    = "dev.cosignproject.cosign/signature"
+	sigstoreSETAnnotationKey          = "dev.sigstore.cosign/bundle"
+	sigstoreCertificateAnnotationKey  = "dev.sigstore.cosign/certificate"
+	sigstoreChainAnnotationKey        = "dev.sigstore.cosign/chain"
+	sigstoreSignatureFormat           = "sigstore-json"
+	simpleSigningSignatureFormat      = "simple-signing"
+	fulcioIssuerV1OID                 = "1.3.6.1.4.1.57264.1.1"
+	fulcioIssuerV2OID                 = "1.3.6.1.4.1.57264.1.8"
+	sigstoreFulcioCertificateKeyLabel = "Fulcio"
+)
+
+// VerifySignature describes a signature stored with an image.
+type VerifySignature struct {
+	// Format is "sigstore" or "simple-signing".
+	Format string
+	// DockerReference and ManifestDigest are the image identity claimed
+	// by the signature.
+	DockerReference string `json:",omitempty"`
+	ManifestDigest  string `json:",omitempty"`
+	// KeyID is the GPG key ID of a simple signing signature.
+	KeyID string `json:",omitempty"`
+	// Identity and Issuer are the subject and OIDC issuer of the Fulcio
+	// certificate of a keyless sigstore signature.
+	Identity string `json:",omitempty"`
+	Issuer   string `json:",omitempty"`
+	// Rekor is true if the signature carries a Rekor inclusion proof.
+	Rekor bool
+	// Key is the policy key, or Fulcio, that verified the signature.
+	Key string `json:",omitempty"`
+	// Verified is true if a key or the Fulcio CA of the policy verified
+	// the signature.
+	Verified bool
+	// Error explains why the signature could not be parsed or verified.
+	Error string `json:",omitempty"`
+}
+
+// sigstoreJSON is the stored form of a sigstore signature
+// (= c/image/v5/internal/signature.sigstoreJSONRepresentation).
+type sigstoreJSON struct {
+	MIMEType    string            `json:"mimeType"`
+	Payload     []byte            `json:"payload"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// sigstorePayload is the cosign simple signing payload, only the fields
+// shown to the user.
+type sigstorePayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// splitSignatureBlob returns the format and data of a signature in the
+// format stored by containers-storage (= c/image/v5/internal/signature.Blob).
+func splitSignatureBlob(blob []byte) (string, []byte, error) {
+	if len(blob) == 0 {
+		return "", nil, errors.New("empty signature blob")
+	}
+	if blob[0] != 0 {
+		// Signatures without a format prefix are GPG signatures.
+		return simpleSigningSignatureFormat, blob, nil
+	}
+	format, data, ok := bytes.Cut(blob[1:], []byte{'\n'})
+	if !ok {
+		return "", nil, errors.New("invalid signature format, missing newline")
+	}
+	return string(format), data, nil
+}
+
+// SignatureBlob returns a signature, as returned by the
+// UntrustedSignatures method of c/image/v5/image.UnparsedImage, in the
+// format stored by containers-storage and the dir transport.
+func SignatureBlob(sig any) ([]byte, error) {
+	switch s := sig.(type) {
+	case interface{ UntrustedSignature() []byte }:
+		return s.UntrustedSignature(), nil
+	case interface {
+		UntrustedMIMEType() string
+		UntrustedPayload() []byte
+		UntrustedAnnotations() map[string]string
+	}:
+		data, err := json.Marshal(sigstoreJSON{
+			MIMEType:    s.UntrustedMIMEType(),
+			Payload:     s.UntrustedPayload(),
+			Annotations: s.UntrustedAnnotations(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		blob := append([]byte{0}, sigstoreSignatureFormat+"\n"...)
+		return append(blob, data...), nil
+	}
+	return nil, fmt.Errorf("unsupported signature type %T", sig)
+}
+
+// DescribeSignatures parses signatures in the format stored by
+// containers-storage and checks which key or Fulcio CA of the requirements
+// verifies each of them. The result is informational, whether the image is
+// accepted is decided by EvaluateRequirements.
+func DescribeSignatures(blobs [][]byte, reqs []PolicyRequirement) []VerifySignature {
+	res := make([]VerifySignature, 0, len(blobs))
+	for _, blob := range blobs {
+		format, data, err := splitSignatureBlob(blob)
+		if err != nil {
+			res = append(res, VerifySignature{Error: err.Error()})
+			continue
+		}
+		var sig VerifySignature
+		switch format {
+		case simpleSigningSignatureFormat:
+			sig = describeSimpleSigning(data, reqs)
+		case sigstoreSignatureFormat:
+			sig = describeSigstore(data, reqs)
+		default:
+			sig = VerifySignature{Format: format, Error: "unsupported signature format"}
+		}
+		res = append(res, sig)
+	}
+	return res
+}
+
+func describeSimpleSigning(data []byte, reqs []PolicyRequirement) VerifySignature {
+	sig := VerifySignature{Format: "simple-signing"}
+	info, err := signature.GetUntrustedSignatureInformationWithoutVerifying(data)
+	if err != nil {
+		sig.Error = err.Error()
+		return sig
+	}
+	sig.DockerReference = info.UntrustedDockerReference
+	sig.ManifestDigest = info.UntrustedDockerManifestDigest.String()
+	sig.KeyID = info.UntrustedShortKeyIdentifier
+
+	for i := range reqs {
+		if reqs[i].content.Type != "signedBy" {
+			continue
+		}
+		for _, k := range reqs[i].keys() {
+			keyring := k.data
+			if k.path != "" {
+				if keyring, err = os.ReadFile(k.path); err != nil {
+					continue
+				}
+			}
+			mech, _, err := signature.NewEphemeralGPGSigningMechanism(keyring)
+			if err != nil {
+				continue
+			}
+			_, _, err = mech.Verify(data)
+			mech.Close()
+			if err == nil {
+				sig.Key = k.label
+				sig.Verified = true
+				return sig
+			}
+		}
+	}
+	sig.Error = "not signed by any key of the policy"
+	return sig
+}
+
+func describeSigstore(data []byte, reqs []PolicyRequirement) VerifySignature {
+	sig := VerifySignature{Format: "sigstore"}
+	var stored sigstoreJSON
+	if err := json.Unmarshal(data, &stored); err != nil {
+		sig.Error = err.Error()
+		return sig
+	}
+	var payload sigstorePayload
+	if err := json.Unmarshal(stored.Payload, &payload); err != nil {
+		sig.Error = fmt.Sprintf("parsing signature payload: %v", err)
+		return sig
+	}
+	sig.DockerReference = payload.Critical.Identity.DockerReference
+	sig.ManifestDigest = payload.Critical.Image.DockerManifestDigest
+	sig.Rekor = stored.Annotations[sigstoreSETAnnotationKey] != ""
+
+	rawSig, err := base64.StdEncoding.DecodeString(stored.Annotations[sigstoreSignatureAnnotationKey])
+	if err != nil {
+		sig.Error = fmt.Sprintf("decoding signature: %v", err)
+		return sig
+	}
+	v := verification{
+		data:        stored.Payload,
+		signature:   rawSig,
+		certificate: []byte(stored.Annotations[sigstoreCertificateAnnotationKey]),
+		chain:       []byte(stored.Annotations[sigstoreChainAnnotationKey]),
+	}
+	res := v.verify(reqs)
+	sig.Key, sig.Identity, sig.Issuer, sig.Verified = res.key, res.identity, res.issuer, res.verified
+	if res.err != nil {
+		sig.Error = res.err.Error()
+	}
+	return sig
+}
+
+// verification is a sigstore signature over data, made either with a
+// public key or with the key of a Fulcio certificate.
+type verification struct {
+	data        []byte
+	signature   []byte
+	certificate []byte // PEM
+	chain       []byte // PEM
+}
+
+type verificationResult struct {
+	key      string
+	identity string
+	issuer   string
+	verified bool
+	err      error
+}
+
+// verify checks the signature against the public keys and Fulcio CAs of
+// the sigstoreSigned requirements.
+func (v *verification) verify(reqs []PolicyRequirement) verificationResult {
+	if len(v.certificate) > 0 {
+		return v.verifyCertificate(reqs)
+	}
+	for i := range reqs {
+		keys, err := reqs[i].publicKeys()
+		if err != nil {
+			return verificationResult{err: err}
+		}
+		for _, k := range keys {
+			if verifyWithKey(k.key, v.data, v.signature) == nil {
+				return verificationResult{key: k.label, verified: true}
+			}
+		}
+	}
+	return verificationResult{err: errors.New("not signed by any key of the policy")}
+}
+
+func (v *verification) verifyCertificate(reqs []PolicyRequirement) verificationResult {
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(v.certificate)
+	if err != nil || len(certs) != 1 {
+		return verificationResult{err: fmt.Errorf("parsing the signing certificate: %w", err)}
+	}
+	cert := certs[0]
+	res := verificationResult{
+		identity: strings.Join(cryptoutils.GetSubjectAlternateNames(cert), ", "),
+		issuer:   fulcioIssuer(cert),
+	}
+	if err := verifyWithKey(cert.PublicKey, v.data, v.signature); err != nil {
+		res.err = fmt.Errorf("signature does not match its certificate: %w", err)
+		return res
+	}
+
+	intermediates := x509.NewCertPool()
+	if len(v.chain) > 0 {
+		chain, err := cryptoutils.UnmarshalCertificatesFromPEM(v.chain)
+		if err != nil {
+			res.err = fmt.Errorf("parsing the certificate chain: %w", err)
+			return res
+		}
+		for _, c := range chain {
+			intermediates.AddCert(c)
+		}
+	}
+	res.err = errors.New("certificate not issued by a Fulcio CA of the policy")
+	for i := range reqs {
+		roots, err := reqs[i].fulcioRoots()
+		if err != nil {
+			res.err = err
+			return res
+		}
+		if roots == nil {
+			continue
+		}
+		// Fulcio certificates are short-lived, the signature is valid
+		// if it was made while the certificate was valid.
+		if _, err := cert.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   cert.NotBefore,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		}); err != nil {
+			res.err = fmt.Errorf("verifying the certificate: %w", err)
+			continue
+		}
+		f := reqs[i].content.Fulcio
+		if f.OIDCIssuer != "" && f.OIDCIssuer != res.issuer {
+			res.err = fmt.Errorf("certificate issued for OIDC issuer %q, policy requires %q", res.issuer, f.OIDCIssuer)
+			continue
+		}
+		if f.SubjectEmail != "" && !slices.Contains(cert.EmailAddresses, f.SubjectEmail) {
+			res.err = fmt.Errorf("certificate issued for %q, policy requires %q", res.identity, f.SubjectEmail)
+			continue
+		}
+		res.key = sigstoreFulcioCertificateKeyLabel
+		res.verified = true
+		res.err = nil
+		return res
+	}
+	return res
+}
+
+// verifyWithKey verifies a signature over data the way cosign creates
+// them, using SHA-256 for ECDSA and RSA keys.
+func verifyWithKey(key crypto.PublicKey, data, sig []byte) error {
+	verifier, err := sigstoreSignature.LoadVerifier(key, crypto.SHA256)
+	if err != nil {
+		return err
+	}
+	return verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(data))
+}
+
+// fulcioIssuer returns the OIDC issuer recorded in a Fulcio certificate.
+func fulcioIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		switch ext.Id.String() {
+		case fulcioIssuerV2OID:
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		case fulcioIssuerV1OID:
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
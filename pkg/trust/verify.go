@@ -0,0 +1,283 @@
+package trust
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/signature"
+	"go.podman.io/image/v5/types"
+)
+
+// VerifyRequirement is the result of evaluating a single requirement of the
+// policy against an image.
+type VerifyRequirement struct {
+	// Type of the requirement, e.g. sigstoreSigned.
+	Type string
+	// Keys lists the public keys accepted by the requirement, by path or
+	// as keyData for inline keys.
+	Keys []string `json:",omitempty"`
+	// MatchedKeys lists the keys which on their own satisfy the
+	// requirement.
+	MatchedKeys []string `json:",omitempty"`
+	// OIDCIssuer and SubjectEmail are the identity required of Fulcio
+	// certificates.
+	OIDCIssuer   string `json:",omitempty"`
+	SubjectEmail string `json:",omitempty"`
+	// Allowed is true if the requirement is satisfied.
+	Allowed bool
+	// Error explains why the requirement is not satisfied.
+	Error string `json:",omitempty"`
+}
+
+// PolicyRequirement is a single requirement of a policy.json scope.
+type PolicyRequirement struct {
+	raw     json.RawMessage
+	content requirementContent
+}
+
+// requirementContent holds the fields of a requirement needed to describe it
+// and to verify attestations (= c/image/v5/signature.{prSignedBy,prSigstoreSigned}).
+type requirementContent struct {
+	Type     string   `json:"type"`
+	KeyPath  string   `json:"keyPath,omitempty"`
+	KeyPaths []string `json:"keyPaths,omitempty"`
+	KeyData  []byte   `json:"keyData,omitempty"`
+	KeyDatas [][]byte `json:"keyDatas,omitempty"`
+	Fulcio   *struct {
+		CAPath       string `json:"caPath,omitempty"`
+		CAData       []byte `json:"caData,omitempty"`
+		OIDCIssuer   string `json:"oidcIssuer,omitempty"`
+		SubjectEmail string `json:"subjectEmail,omitempty"`
+	} `json:"fulcio,omitempty"`
+}
+
+// Type returns the type of the requirement.
+func (r *PolicyRequirement) Type() string {
+	return r.content.Type
+}
+
+// keySource is a public key of a requirement, given by path or inline.
+type keySource struct {
+	label string
+	path  string
+	data  []byte
+}
+
+// keys returns the public keys of the requirement.
+func (r *PolicyRequirement) keys() []keySource {
+	var keys []keySource
+	if r.content.KeyPath != "" {
+		keys = append(keys, keySource{label: r.content.KeyPath, path: r.content.KeyPath})
+	}
+	for _, p := range r.content.KeyPaths {
+		keys = append(keys, keySource{label: p, path: p})
+	}
+	if len(r.content.KeyData) > 0 {
+		keys = append(keys, keySource{label: "keyData", data: r.content.KeyData})
+	}
+	for i, data := range r.content.KeyDatas {
+		keys = append(keys, keySource{label: fmt.Sprintf("keyDatas[%d]", i), data: data})
+	}
+	return keys
+}
+
+// singleKeyRequirement returns a copy of the requirement accepting only the
+// given key.
+func (r *PolicyRequirement) singleKeyRequirement(key keySource) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(r.raw, &fields); err != nil {
+		return nil, err
+	}
+	for _, k := range []string{"keyPath", "keyPaths", "keyData", "keyDatas"} {
+		delete(fields, k)
+	}
+	var err error
+	if key.path != "" {
+		fields["keyPath"], err = json.Marshal(key.path)
+	} else {
+		fields["keyData"], err = json.Marshal(key.data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// publicKey is a sigstore public key accepted by the policy.
+type publicKey struct {
+	label string
+	key   crypto.PublicKey
+}
+
+// publicKeys returns the sigstore public keys of the requirement.
+func (r *PolicyRequirement) publicKeys() ([]publicKey, error) {
+	if r.content.Type != "sigstoreSigned" {
+		return nil, nil
+	}
+	var keys []publicKey
+	for _, k := range r.keys() {
+		data := k.data
+		if k.path != "" {
+			var err error
+			if data, err = os.ReadFile(k.path); err != nil {
+				return nil, err
+			}
+		}
+		key, err := cryptoutils.UnmarshalPEMToPublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key %s: %w", k.label, err)
+		}
+		keys = append(keys, publicKey{label: k.label, key: key})
+	}
+	return keys, nil
+}
+
+// fulcioRoots returns the Fulcio CA certificates of the requirement, nil if
+// it does not accept Fulcio certificates.
+func (r *PolicyRequirement) fulcioRoots() (*x509.CertPool, error) {
+	f := r.content.Fulcio
+	if r.content.Type != "sigstoreSigned" || f == nil {
+		return nil, nil
+	}
+	data := f.CAData
+	if f.CAPath != "" {
+		var err error
+		if data, err = os.ReadFile(f.CAPath); err != nil {
+			return nil, err
+		}
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Fulcio CA certificates: %w", err)
+	}
+	pool := x509.NewCertPool()
+	for _, c := range certs {
+		pool.AddCert(c)
+	}
+	return pool, nil
+}
+
+// PolicyRequirements returns the requirements of the policy at policyPath
+// which apply to pulling named from a registry, and the scope they were
+// found in.
+func PolicyRequirements(policyPath string, named reference.Named) (string, []PolicyRequirement, error) {
+	data, err := os.ReadFile(policyPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to read policy file: %w", err)
+	}
+	// Make sure the policy is valid as a whole, like it would be for a pull.
+	if _, err := signature.NewPolicyFromBytes(data); err != nil {
+		return "", nil, fmt.Errorf("invalid policy in %q: %w", policyPath, err)
+	}
+	var policy genericPolicyContent
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return "", nil, fmt.Errorf("could not parse trust policies from %s: %w", policyPath, err)
+	}
+
+	scope, raw := "default", policy.Default
+	if scopes, ok := policy.Transports["docker"]; ok {
+		ref, err := docker.NewReference(reference.TagNameOnly(named))
+		if err != nil {
+			return "", nil, err
+		}
+		candidates := append([]string{ref.PolicyConfigurationIdentity()}, ref.PolicyConfigurationNamespaces()...)
+		for _, c := range append(candidates, "") {
+			if reqs, ok := scopes[c]; ok {
+				scope, raw = "docker:"+c, reqs
+				if c == "" {
+					scope = "docker"
+				}
+				break
+			}
+		}
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(raw, &raws); err != nil {
+		return "", nil, fmt.Errorf("could not parse trust policies from %s: %w", policyPath, err)
+	}
+	reqs := make([]PolicyRequirement, 0, len(raws))
+	for _, r := range raws {
+		req := PolicyRequirement{raw: r}
+		if err := json.Unmarshal(r, &req.content); err != nil {
+			return "", nil, fmt.Errorf("could not parse trust policies from %s: %w", policyPath, err)
+		}
+		reqs = append(reqs, req)
+	}
+	return scope, reqs, nil
+}
+
+// evaluateRequirement evaluates a single requirement, given as JSON, with
+// the policy code used for pulling images.
+func evaluateRequirement(ctx context.Context, raw json.RawMessage, img types.UnparsedImage) error {
+	var buf bytes.Buffer
+	buf.WriteString(`{"default":[`)
+	buf.Write(raw)
+	buf.WriteString(`]}`)
+	policy, err := signature.NewPolicyFromBytes(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	pc, err := signature.NewPolicyContext(policy)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = pc.Destroy() }()
+
+	allowed, err := pc.IsRunningImageAllowed(ctx, img)
+	if allowed {
+		return nil
+	}
+	if err == nil {
+		err = errors.New("rejected by policy")
+	}
+	return err
+}
+
+// EvaluateRequirements evaluates each requirement against img. The docker
+// reference of img is the identity checked against signatures.
+func EvaluateRequirements(ctx context.Context, reqs []PolicyRequirement, img types.UnparsedImage) ([]VerifyRequirement, error) {
+	results := make([]VerifyRequirement, 0, len(reqs))
+	for i := range reqs {
+		req := &reqs[i]
+		keys := req.keys()
+		res := VerifyRequirement{Type: req.content.Type}
+		for _, k := range keys {
+			res.Keys = append(res.Keys, k.label)
+		}
+		if f := req.content.Fulcio; f != nil {
+			res.OIDCIssuer = f.OIDCIssuer
+			res.SubjectEmail = f.SubjectEmail
+		}
+		if err := evaluateRequirement(ctx, req.raw, img); err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Allowed = true
+		}
+
+		if res.Allowed && len(keys) > 1 {
+			for _, k := range keys {
+				single, err := req.singleKeyRequirement(k)
+				if err != nil {
+					return nil, err
+				}
+				if evaluateRequirement(ctx, single, img) == nil {
+					res.MatchedKeys = append(res.MatchedKeys, k.label)
+				}
+			}
+		} else if res.Allowed {
+			res.MatchedKeys = res.Keys
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
@@ -0,0 +1,246 @@
+package trust
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/directory"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/image"
+	"go.podman.io/image/v5/types"
+)
+
+func writePolicy(t *testing.T, policy string) string {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(policy), 0o600))
+	return path
+}
+
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pub, err := cryptoutils.MarshalPublicKeyToPEM(key.Public())
+	require.NoError(t, err)
+	return key, pub
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, data []byte) []byte {
+	h := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, h[:])
+	require.NoError(t, err)
+	return sig
+}
+
+func sigstoreRequirementPolicy(t *testing.T, pub []byte) string {
+	keyData, err := json.Marshal(pub)
+	require.NoError(t, err)
+	return `{"default":[{"type":"sigstoreSigned","keyData":` + string(keyData) + `,"signedIdentity":{"type":"matchRepoDigestOrExact"}}]}`
+}
+
+func TestPolicyRequirementsScope(t *testing.T) {
+	policyPath := writePolicy(t, `{
+		"default": [{"type": "reject"}],
+		"transports": {
+			"docker": {
+				"quay.io/podman/stable": [{"type": "insecureAcceptAnything"}],
+				"quay.io/podman": [{"type": "insecureAcceptAnything"}, {"type": "insecureAcceptAnything"}],
+				"docker.io": [{"type": "reject"}, {"type": "reject"}, {"type": "reject"}]
+			}
+		}
+	}`)
+
+	for _, c := range []struct {
+		image string
+		scope string
+		count int
+	}{
+		{"quay.io/podman/stable:latest", "docker:quay.io/podman/stable", 1},
+		{"quay.io/podman/hello", "docker:quay.io/podman", 2},
+		{"alpine", "docker:docker.io", 3},
+		{"registry.example.com/foo", "default", 1},
+	} {
+		named, err := reference.ParseNormalizedNamed(c.image)
+		require.NoError(t, err)
+		scope, reqs, err := PolicyRequirements(policyPath, named)
+		require.NoError(t, err, c.image)
+		assert.Equal(t, c.scope, scope, c.image)
+		assert.Len(t, reqs, c.count, c.image)
+	}
+
+	named, err := reference.ParseNormalizedNamed("alpine")
+	require.NoError(t, err)
+	_, _, err = PolicyRequirements(writePolicy(t, `{"default": [{"type": "unknown"}]}`), named)
+	assert.Error(t, err)
+}
+
+// unparsedImage is an image without manifest or signatures, sufficient for
+// requirements which do not look at either.
+type unparsedImage struct {
+	ref types.ImageReference
+}
+
+func (u unparsedImage) Reference() types.ImageReference { return u.ref }
+
+func (u unparsedImage) Manifest(context.Context) ([]byte, string, error) {
+	return []byte("{}"), "application/vnd.oci.image.manifest.v1+json", nil
+}
+
+func (u unparsedImage) Signatures(context.Context) ([][]byte, error) { return nil, nil }
+
+func TestEvaluateRequirements(t *testing.T) {
+	named, err := reference.ParseNormalizedNamed("quay.io/podman/stable:latest")
+	require.NoError(t, err)
+	ref, err := docker.NewReference(named)
+	require.NoError(t, err)
+
+	policyPath := writePolicy(t, `{"default": [{"type": "insecureAcceptAnything"}, {"type": "reject"}]}`)
+	_, reqs, err := PolicyRequirements(policyPath, named)
+	require.NoError(t, err)
+	res, err := EvaluateRequirements(context.Background(), reqs, unparsedImage{ref: ref})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, "insecureAcceptAnything", res[0].Type)
+	assert.True(t, res[0].Allowed)
+	assert.Empty(t, res[0].Error)
+	assert.Equal(t, "reject", res[1].Type)
+	assert.False(t, res[1].Allowed)
+	assert.NotEmpty(t, res[1].Error)
+}
+
+func TestDescribeSigstoreSignature(t *testing.T) {
+	key, pub := newTestKey(t)
+	_, otherPub := newTestKey(t)
+	named, err := reference.ParseNormalizedNamed("quay.io/podman/stable")
+	require.NoError(t, err)
+
+	payload := []byte(`{"critical":{"identity":{"docker-reference":"quay.io/podman/stable"},"image":{"docker-manifest-digest":"sha256:0123"},"type":"cosign container image signature"},"optional":null}`)
+	stored, err := json.Marshal(sigstoreJSON{
+		MIMEType: "application/vnd.dev.cosign.simplesigning.v1+json",
+		Payload:  payload,
+		Annotations: map[string]string{
+			sigstoreSignatureAnnotationKey: base64.StdEncoding.EncodeToString(sign(t, key, payload)),
+		},
+	})
+	require.NoError(t, err)
+	blob := append([]byte("\x00"+sigstoreSignatureFormat+"\n"), stored...)
+
+	_, reqs, err := PolicyRequirements(writePolicy(t, sigstoreRequirementPolicy(t, pub)), named)
+	require.NoError(t, err)
+	sigs := DescribeSignatures([][]byte{blob, []byte("\x00unknown\n")}, reqs)
+	require.Len(t, sigs, 2)
+	assert.Equal(t, VerifySignature{
+		Format:          "sigstore",
+		DockerReference: "quay.io/podman/stable",
+		ManifestDigest:  "sha256:0123",
+		Key:             "keyData",
+		Verified:        true,
+	}, sigs[0])
+	assert.Equal(t, "unknown", sigs[1].Format)
+	assert.False(t, sigs[1].Verified)
+
+	_, reqs, err = PolicyRequirements(writePolicy(t, sigstoreRequirementPolicy(t, otherPub)), named)
+	require.NoError(t, err)
+	sigs = DescribeSignatures([][]byte{blob}, reqs)
+	require.Len(t, sigs, 1)
+	assert.False(t, sigs[0].Verified)
+	assert.Equal(t, "not signed by any key of the policy", sigs[0].Error)
+}
+
+func TestVerifyAttestationLayer(t *testing.T) {
+	key, pub := newTestKey(t)
+	named, err := reference.ParseNormalizedNamed("quay.io/podman/stable")
+	require.NoError(t, err)
+	_, reqs, err := PolicyRequirements(writePolicy(t, sigstoreRequirementPolicy(t, pub)), named)
+	require.NoError(t, err)
+
+	imageDigest := digest.FromString("manifest")
+	envelope := func(subject digest.Digest) []byte {
+		statement, err := json.Marshal(map[string]any{
+			"_type":         "https://in-toto.io/Statement/v1",
+			"predicateType": "https://slsa.dev/provenance/v1",
+			"subject": []map[string]any{{
+				"name":   "quay.io/podman/stable",
+				"digest": map[string]string{subject.Algorithm().String(): subject.Encoded()},
+			}},
+			"predicate": map[string]any{},
+		})
+		require.NoError(t, err)
+		e := dsseEnvelope{PayloadType: inTotoPayloadType, Payload: statement}
+		e.Signatures = append(e.Signatures, struct {
+			KeyID string `json:"keyid"`
+			Sig   []byte `json:"sig"`
+		}{Sig: sign(t, key, e.pae())})
+		data, err := json.Marshal(e)
+		require.NoError(t, err)
+		return data
+	}
+
+	att := VerifyAttestationLayer(DSSEEnvelopeMediaType, envelope(imageDigest), nil, []digest.Digest{imageDigest}, reqs)
+	assert.Equal(t, VerifyAttestation{
+		PredicateType: "https://slsa.dev/provenance/v1",
+		Key:           "keyData",
+		Verified:      true,
+	}, att)
+
+	att = VerifyAttestationLayer(DSSEEnvelopeMediaType, envelope(digest.FromString("other")), nil, []digest.Digest{imageDigest}, reqs)
+	assert.False(t, att.Verified)
+	assert.Equal(t, "attestation is not about this image", att.Error)
+
+	att = VerifyAttestationLayer("application/octet-stream", nil, nil, []digest.Digest{imageDigest}, reqs)
+	assert.False(t, att.Verified)
+	assert.Contains(t, att.Error, "unsupported attestation media type")
+}
+
+func TestSignatureBlob(t *testing.T) {
+	stored, err := json.Marshal(sigstoreJSON{
+		MIMEType:    "application/vnd.dev.cosign.simplesigning.v1+json",
+		Payload:     []byte(`{"critical":{}}`),
+		Annotations: map[string]string{sigstoreSignatureAnnotationKey: "c2lnbmF0dXJl"},
+	})
+	require.NoError(t, err)
+	blobs := [][]byte{
+		append([]byte("\x00"+sigstoreSignatureFormat+"\n"), stored...),
+		// An OpenPGP signature packet, stored without a format prefix.
+		{0x89, 0x01, 0x02},
+	}
+
+	// Round trip the blobs through the dir transport, which keeps them
+	// as they are.
+	ref, err := directory.NewReference(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+	dest, err := ref.NewImageDestination(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, dest.PutManifest(ctx, []byte(`{"schemaVersion":2}`), nil))
+	require.NoError(t, dest.PutSignatures(ctx, blobs, nil))
+	require.NoError(t, dest.Commit(ctx, nil))
+	require.NoError(t, dest.Close())
+
+	src, err := ref.NewImageSource(ctx, nil)
+	require.NoError(t, err)
+	defer src.Close()
+	sigs, err := image.UnparsedInstance(src, nil).UntrustedSignatures(ctx)
+	require.NoError(t, err)
+	require.Len(t, sigs, len(blobs))
+	for i, sig := range sigs {
+		blob, err := SignatureBlob(sig)
+		require.NoError(t, err)
+		assert.Equal(t, blobs[i], blob)
+	}
+
+	_, err = SignatureBlob("signature")
+	assert.Error(t, err)
+}
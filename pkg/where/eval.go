@@ -0,0 +1,441 @@
+package where
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Expression is a compiled --where expression.
+type Expression struct {
+	source string
+	root   node
+	// regexps caches the compiled patterns of matches().
+	regexps map[string]*regexp.Regexp
+	// now is the time now() returns, fixed for all evaluations.
+	now time.Time
+}
+
+// Compile parses expr.
+func Compile(expr string) (*Expression, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, errors.New("empty expression")
+	}
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, p.errorf("expected end of expression")
+	}
+	return &Expression{source: expr, root: root, regexps: make(map[string]*regexp.Regexp), now: time.Now()}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Data converts v to the data an expression is evaluated against, the
+// generic representation of its JSON encoding.  Field names are therefore
+// the ones shown by the inspect commands.
+func Data(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var data any
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Match evaluates the expression against data as returned by Data.  The
+// expression must evaluate to a boolean or null, which does not match.
+func (e *Expression) Match(data any) (bool, error) {
+	v, err := e.root.eval(&env{expr: e, data: data})
+	if err != nil {
+		return false, fmt.Errorf("evaluating %q: %w", e.source, err)
+	}
+	switch v := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	default:
+		return false, fmt.Errorf("evaluating %q: result is a %s, not a boolean", e.source, typeName(v))
+	}
+}
+
+// MatchValue converts v with Data and evaluates the expression against it.
+func (e *Expression) MatchValue(v any) (bool, error) {
+	data, err := Data(v)
+	if err != nil {
+		return false, err
+	}
+	return e.Match(data)
+}
+
+type env struct {
+	expr *Expression
+	data any
+}
+
+// EvalError is the error of evaluating an expression.
+type EvalError struct {
+	// Pos is the byte offset of the failing part of the expression.
+	Pos int
+	Msg string
+}
+
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("at position %d: %s", e.Pos+1, e.Msg)
+}
+
+func evalErrorf(pos int, format string, args ...any) error {
+	return &EvalError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+type node interface {
+	eval(*env) (any, error)
+}
+
+type literal struct {
+	value any
+}
+
+func (n *literal) eval(*env) (any, error) {
+	return n.value, nil
+}
+
+type list struct {
+	elems []node
+}
+
+func (n *list) eval(e *env) (any, error) {
+	values := make([]any, 0, len(n.elems))
+	for _, elem := range n.elems {
+		v, err := elem.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// field is a top-level field of the data.
+type field struct {
+	name string
+	pos  int
+}
+
+func (n *field) eval(e *env) (any, error) {
+	return selectFrom(e.data, n.name, n.pos)
+}
+
+type selectField struct {
+	operand node
+	field   string
+	pos     int
+}
+
+func (n *selectField) eval(e *env) (any, error) {
+	v, err := n.operand.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	return selectFrom(v, n.field, n.pos)
+}
+
+// selectFrom returns the field name of v.  Missing fields are null, so
+// that optional fields can be compared without checking for them first.
+// If no field matches exactly, the field is matched case-insensitively.
+func selectFrom(v any, name string, pos int) (any, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		if value, ok := v[name]; ok {
+			return value, nil
+		}
+		for key, value := range v {
+			if strings.EqualFold(key, name) {
+				return value, nil
+			}
+		}
+		return nil, nil
+	default:
+		return nil, evalErrorf(pos, "cannot select field %q of a %s", name, typeName(v))
+	}
+}
+
+type indexExpr struct {
+	operand node
+	index   node
+	pos     int
+}
+
+func (n *indexExpr) eval(e *env) (any, error) {
+	v, err := n.operand.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	index, err := n.index.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		key, ok := index.(string)
+		if !ok {
+			return nil, evalErrorf(n.pos, "map index must be a string, not a %s", typeName(index))
+		}
+		return v[key], nil
+	case []any:
+		i, ok := index.(float64)
+		if !ok || i != math.Trunc(i) {
+			return nil, evalErrorf(n.pos, "list index must be an integer, not %v", index)
+		}
+		if i < 0 || int(i) >= len(v) {
+			return nil, nil
+		}
+		return v[int(i)], nil
+	default:
+		return nil, evalErrorf(n.pos, "cannot index a %s", typeName(v))
+	}
+}
+
+type unary struct {
+	op      string
+	operand node
+	pos     int
+}
+
+func (n *unary) eval(e *env) (any, error) {
+	v, err := n.operand.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "!" {
+		b, err := toBool(v, n.pos)
+		if err != nil {
+			return nil, err
+		}
+		return !b, nil
+	}
+	f, ok := v.(float64)
+	if !ok {
+		return nil, evalErrorf(n.pos, "cannot negate a %s", typeName(v))
+	}
+	return -f, nil
+}
+
+type conditional struct {
+	cond, then, els node
+}
+
+func (n *conditional) eval(e *env) (any, error) {
+	c, err := n.cond.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	b, err := toBool(c, 0)
+	if err != nil {
+		return nil, err
+	}
+	if b {
+		return n.then.eval(e)
+	}
+	return n.els.eval(e)
+}
+
+type binary struct {
+	op          string
+	left, right node
+	pos         int
+}
+
+func (n *binary) eval(e *env) (any, error) {
+	left, err := n.left.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	// Logical operators short-circuit.
+	switch n.op {
+	case "&&", "||":
+		l, err := toBool(left, n.pos)
+		if err != nil {
+			return nil, err
+		}
+		if l == (n.op == "||") {
+			return l, nil
+		}
+		right, err := n.right.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		return toBool(right, n.pos)
+	}
+
+	right, err := n.right.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "<", "<=", ">", ">=":
+		return n.compare(left, right)
+	case "in":
+		switch r := right.(type) {
+		case nil:
+			return false, nil
+		case []any:
+			for _, elem := range r {
+				if equal(left, elem) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case map[string]any:
+			key, ok := left.(string)
+			if !ok {
+				return false, nil
+			}
+			_, found := r[key]
+			return found, nil
+		default:
+			return nil, evalErrorf(n.pos, "right operand of in must be a list or map, not a %s", typeName(right))
+		}
+	}
+	return n.arithmetic(left, right)
+}
+
+// compare evaluates the ordering operators.  Null is not ordered, comparing
+// it is false.
+func (n *binary) compare(left, right any) (any, error) {
+	if left == nil || right == nil {
+		return false, nil
+	}
+	var c int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return nil, evalErrorf(n.pos, "cannot compare a number to a %s", typeName(right))
+		}
+		switch {
+		case l < r:
+			c = -1
+		case l > r:
+			c = 1
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return nil, evalErrorf(n.pos, "cannot compare a string to a %s", typeName(right))
+		}
+		c = strings.Compare(l, r)
+	default:
+		return nil, evalErrorf(n.pos, "cannot order a %s", typeName(left))
+	}
+	switch n.op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default:
+		return c >= 0, nil
+	}
+}
+
+func (n *binary) arithmetic(left, right any) (any, error) {
+	if n.op == "+" {
+		switch l := left.(type) {
+		case string:
+			if r, ok := right.(string); ok {
+				return l + r, nil
+			}
+		case []any:
+			if r, ok := right.([]any); ok {
+				return append(append([]any{}, l...), r...), nil
+			}
+		}
+	}
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		return nil, evalErrorf(n.pos, "invalid operands of %s: %s and %s", n.op, typeName(left), typeName(right))
+	}
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, evalErrorf(n.pos, "division by zero")
+		}
+		return l / r, nil
+	default:
+		if r == 0 {
+			return nil, evalErrorf(n.pos, "division by zero")
+		}
+		return math.Mod(l, r), nil
+	}
+}
+
+// toBool converts a condition to a boolean, null is false.
+func toBool(v any, pos int) (bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	default:
+		return false, evalErrorf(pos, "expected a boolean, not a %s", typeName(v))
+	}
+}
+
+func equal(a, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "map"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,225 @@
+package where
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// call is a function call, or a method call if target is set.
+type call struct {
+	name   string
+	target node
+	args   []node
+	pos    int
+}
+
+func (n *call) eval(e *env) (any, error) {
+	// has() takes a field path rather than a value, so a missing field is
+	// not an error even in the middle of the path.
+	if n.name == "has" && n.target == nil {
+		if len(n.args) != 1 {
+			return nil, evalErrorf(n.pos, "has() takes exactly one argument")
+		}
+		v, err := n.args[0].eval(e)
+		if err != nil {
+			return nil, err
+		}
+		return v != nil, nil
+	}
+
+	args := make([]any, 0, len(n.args)+1)
+	if n.target != nil {
+		v, err := n.target.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+	for _, arg := range n.args {
+		v, err := arg.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+
+	fn, ok := functions[n.name]
+	if !ok {
+		return nil, evalErrorf(n.pos, "unknown function %s()", n.name)
+	}
+	if len(args) != fn.args {
+		return nil, evalErrorf(n.pos, "%s() takes %d argument(s), got %d", n.name, fn.args, len(args))
+	}
+	v, err := fn.eval(e, args)
+	if err != nil {
+		return nil, evalErrorf(n.pos, "%s(): %v", n.name, err)
+	}
+	return v, nil
+}
+
+type function struct {
+	// args is the number of arguments, including the target of method
+	// calls.
+	args int
+	eval func(e *env, args []any) (any, error)
+}
+
+// functions are the functions and methods of the language.  A method call
+// x.f(y) is the same as f(x, y).
+var functions = map[string]function{
+	"contains":   {args: 2, eval: stringPredicate(strings.Contains)},
+	"startsWith": {args: 2, eval: stringPredicate(strings.HasPrefix)},
+	"endsWith":   {args: 2, eval: stringPredicate(strings.HasSuffix)},
+	"matches":    {args: 2, eval: matches},
+	"size":       {args: 1, eval: size},
+	"lower":      {args: 1, eval: stringFunction(strings.ToLower)},
+	"upper":      {args: 1, eval: stringFunction(strings.ToUpper)},
+	"timestamp":  {args: 1, eval: timestamp},
+	"duration":   {args: 1, eval: duration},
+	"now": {args: 0, eval: func(e *env, _ []any) (any, error) {
+		return unixSeconds(e.expr.now), nil
+	}},
+	"int": {args: 1, eval: toInt},
+	"string": {args: 1, eval: func(_ *env, args []any) (any, error) {
+		switch v := args[0].(type) {
+		case string:
+			return v, nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		default:
+			return nil, fmt.Errorf("cannot convert a %s to a string", typeName(v))
+		}
+	}},
+}
+
+// stringArgs returns the arguments as strings.  A null argument is
+// returned as ok == false, so that predicates on missing fields are false.
+func stringArgs(args []any) (strs []string, ok bool, err error) {
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case nil:
+			return nil, false, nil
+		case string:
+			strs = append(strs, v)
+		default:
+			return nil, false, fmt.Errorf("expected a string, not a %s", typeName(arg))
+		}
+	}
+	return strs, true, nil
+}
+
+func stringPredicate(f func(s, substr string) bool) func(*env, []any) (any, error) {
+	return func(_ *env, args []any) (any, error) {
+		// contains() also tests list membership.
+		if list, ok := args[0].([]any); ok {
+			for _, elem := range list {
+				if equal(elem, args[1]) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+		strs, ok, err := stringArgs(args)
+		if err != nil || !ok {
+			return false, err
+		}
+		return f(strs[0], strs[1]), nil
+	}
+}
+
+func stringFunction(f func(string) string) func(*env, []any) (any, error) {
+	return func(_ *env, args []any) (any, error) {
+		strs, ok, err := stringArgs(args)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return f(strs[0]), nil
+	}
+}
+
+func matches(e *env, args []any) (any, error) {
+	strs, ok, err := stringArgs(args)
+	if err != nil || !ok {
+		return false, err
+	}
+	re, ok := e.expr.regexps[strs[1]]
+	if !ok {
+		re, err = regexp.Compile(strs[1])
+		if err != nil {
+			return nil, err
+		}
+		e.expr.regexps[strs[1]] = re
+	}
+	return re.MatchString(strs[0]), nil
+}
+
+func size(_ *env, args []any) (any, error) {
+	switch v := args[0].(type) {
+	case nil:
+		return float64(0), nil
+	case string:
+		return float64(len([]rune(v))), nil
+	case []any:
+		return float64(len(v)), nil
+	case map[string]any:
+		return float64(len(v)), nil
+	default:
+		return nil, fmt.Errorf("cannot take the size of a %s", typeName(v))
+	}
+}
+
+// timestamp converts an RFC 3339 time to seconds since the epoch, so that
+// it can be compared with now() and durations.
+func timestamp(_ *env, args []any) (any, error) {
+	strs, ok, err := stringArgs(args)
+	if err != nil || !ok {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339Nano, strs[0])
+	if err != nil {
+		return nil, err
+	}
+	return unixSeconds(t), nil
+}
+
+// duration converts a duration like "1h30m" to seconds.
+func duration(_ *env, args []any) (any, error) {
+	strs, ok, err := stringArgs(args)
+	if err != nil || !ok {
+		return nil, err
+	}
+	d, err := time.ParseDuration(strs[0])
+	if err != nil {
+		return nil, err
+	}
+	return d.Seconds(), nil
+}
+
+func toInt(_ *env, args []any) (any, error) {
+	switch v := args[0].(type) {
+	case float64:
+		return float64(int64(v)), nil
+	case string:
+		i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return float64(i), nil
+	case bool:
+		if v {
+			return float64(1), nil
+		}
+		return float64(0), nil
+	default:
+		return nil, fmt.Errorf("cannot convert a %s to an integer", typeName(v))
+	}
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
@@ -0,0 +1,359 @@
+// Package where implements the expression language of the --where option,
+// a small subset of the Common Expression Language (CEL) evaluated over the
+// inspect data of containers, images and volumes.
+package where
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+	// num is the value of number tokens, str of string tokens.
+	num float64
+	str string
+}
+
+// operators are the operator tokens, longer ones first.
+var operators = []string{
+	"||", "&&", "==", "!=", "<=", ">=",
+	"!", "<", ">", "+", "-", "*", "/", "%", "(", ")", "[", "]", ".", ",", "?", ":",
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(input) {
+		c := rune(input[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '_' || unicode.IsLetter(c):
+			start := i
+			for i < len(input) && (input[i] == '_' || unicode.IsLetter(rune(input[i])) || unicode.IsDigit(rune(input[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: input[start:i], pos: start})
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(input) && (unicode.IsDigit(rune(input[i])) || input[i] == '.' || input[i] == 'e' || input[i] == 'E' ||
+				((input[i] == '+' || input[i] == '-') && (input[i-1] == 'e' || input[i-1] == 'E'))) {
+				i++
+			}
+			num, err := strconv.ParseFloat(input[start:i], 64)
+			if err != nil {
+				return nil, &SyntaxError{Pos: start, Msg: fmt.Sprintf("invalid number %q", input[start:i])}
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: input[start:i], pos: start, num: num})
+		case c == '"' || c == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(input) {
+					return nil, &SyntaxError{Pos: start, Msg: "unterminated string"}
+				}
+				if rune(input[i]) == c {
+					i++
+					break
+				}
+				if input[i] == '\\' && i+1 < len(input) {
+					i++
+					switch input[i] {
+					case 'n':
+						sb.WriteByte('\n')
+					case 't':
+						sb.WriteByte('\t')
+					default:
+						sb.WriteByte(input[i])
+					}
+					i++
+					continue
+				}
+				sb.WriteByte(input[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: tokString, text: input[start:i], pos: start, str: sb.String()})
+		default:
+			found := false
+			for _, op := range operators {
+				if strings.HasPrefix(input[i:], op) {
+					tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+					i += len(op)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, &SyntaxError{Pos: i, Msg: fmt.Sprintf("unexpected character %q", c)}
+			}
+		}
+	}
+	tokens = append(tokens, token{kind: tokEOF, pos: len(input)})
+	return tokens, nil
+}
+
+// SyntaxError is the error of parsing an invalid expression.
+type SyntaxError struct {
+	// Pos is the byte offset of the error in the expression.
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid expression at position %d: %s", e.Pos+1, e.Msg)
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the operator or keyword s.
+func (p *parser) accept(s string) bool {
+	t := p.peek()
+	if (t.kind == tokOp || t.kind == tokIdent) && t.text == s {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(s string) error {
+	if !p.accept(s) {
+		return p.errorf("expected %q", s)
+	}
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	t := p.peek()
+	msg := fmt.Sprintf(format, args...)
+	if t.kind == tokEOF {
+		msg += ", found end of expression"
+	} else {
+		msg += fmt.Sprintf(", found %q", t.text)
+	}
+	return &SyntaxError{Pos: t.pos, Msg: msg}
+}
+
+// parseExpr parses a conditional expression, the lowest precedence.
+func (p *parser) parseExpr() (node, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("?") {
+		return cond, nil
+	}
+	then, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	els, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return &conditional{cond: cond, then: then, els: els}, nil
+}
+
+// binaryLevels are the binary operators by increasing precedence.
+var binaryLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<", "<=", ">", ">=", "in"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		op := ""
+		for _, candidate := range binaryLevels[level] {
+			if (t.kind == tokOp || t.kind == tokIdent) && t.text == candidate {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binary{op: op, left: left, right: right, pos: t.pos}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	if p.accept("!") || p.accept("-") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unary{op: t.text, operand: operand, pos: t.pos}, nil
+	}
+	return p.parseMember()
+}
+
+func (p *parser) parseMember() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case p.accept("."):
+			if p.peek().kind != tokIdent {
+				return nil, p.errorf("expected field name")
+			}
+			name := p.next()
+			if p.accept("(") {
+				args, err := p.parseArgs()
+				if err != nil {
+					return nil, err
+				}
+				n = &call{name: name.text, target: n, args: args, pos: name.pos}
+				continue
+			}
+			n = &selectField{operand: n, field: name.text, pos: name.pos}
+		case p.accept("["):
+			index, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			n = &indexExpr{operand: n, index: index, pos: t.pos}
+		default:
+			return n, nil
+		}
+	}
+}
+
+// parseArgs parses the arguments of a call after the opening parenthesis.
+func (p *parser) parseArgs() ([]node, error) {
+	var args []node
+	if p.accept(")") {
+		return args, nil
+	}
+	for {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.accept(")") {
+			return args, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	start := p.pos
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return &literal{value: t.num}, nil
+	case tokString:
+		return &literal{value: t.str}, nil
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return &literal{value: true}, nil
+		case "false":
+			return &literal{value: false}, nil
+		case "null":
+			return &literal{value: nil}, nil
+		case "in":
+			p.pos = start
+			return nil, p.errorf("expected operand")
+		}
+		if p.accept("(") {
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			return &call{name: t.text, args: args, pos: t.pos}, nil
+		}
+		return &field{name: t.text, pos: t.pos}, nil
+	case tokOp:
+		switch t.text {
+		case "(":
+			n, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "[":
+			var elems []node
+			if p.accept("]") {
+				return &list{}, nil
+			}
+			for {
+				elem, err := p.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				elems = append(elems, elem)
+				if p.accept("]") {
+					return &list{elems: elems}, nil
+				}
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	p.pos = start
+	return nil, p.errorf("expected operand")
+}
@@ -0,0 +1,143 @@
+package where
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testState struct {
+	Running   bool
+	Restarts  int
+	StartedAt time.Time
+}
+
+type testContainer struct {
+	Name   string
+	State  testState
+	Config struct {
+		Labels map[string]string
+	}
+	Mounts []string
+}
+
+func testData(t *testing.T) any {
+	t.Helper()
+	ctr := testContainer{
+		Name:   "web-1",
+		State:  testState{Running: true, Restarts: 4, StartedAt: time.Now().Add(-2 * time.Hour)},
+		Mounts: []string{"/data", "/logs"},
+	}
+	ctr.Config.Labels = map[string]string{"tier": "web", "app.version": "1.2"}
+	data, err := Data(ctr)
+	require.NoError(t, err)
+	return data
+}
+
+func TestMatch(t *testing.T) {
+	data := testData(t)
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`State.Restarts > 3 && Config.Labels["tier"] == "web"`, true},
+		{`State.Restarts > 4`, false},
+		{`State.Restarts >= 4 && State.Running`, true},
+		{`!State.Running || Name == "db"`, false},
+		{`state.restarts == 4`, true},
+		{`Config.Labels["app.version"] == "1.2"`, true},
+		{`Config.Labels.missing == null`, true},
+		{`Config.Labels["missing"] != "x"`, true},
+		{`Missing.Deeply.Nested == "x"`, false},
+		{`has(Config.Labels.tier) && !has(Config.Labels.owner)`, true},
+		{`"tier" in Config.Labels`, true},
+		{`"/data" in Mounts && size(Mounts) == 2`, true},
+		{`Mounts[1] == "/logs" && Mounts[5] == null`, true},
+		{`Mounts.contains("/logs")`, true},
+		{`Name.startsWith("web") && Name.endsWith("-1") && Name.contains("b-")`, true},
+		{`Name.matches("^web-[0-9]+$")`, true},
+		{`Name.upper() == "WEB-1"`, true},
+		{`Name in ["db-1", "web-1"]`, true},
+		{`(State.Restarts + 1) * 2 - 10 % 3 == 9`, true},
+		{`-State.Restarts < 0`, true},
+		{`State.Restarts > 3 ? Name == "web-1" : false`, true},
+		{`timestamp(State.StartedAt) < now() - duration("1h")`, true},
+		{`timestamp(State.StartedAt) > now() - duration("1h")`, false},
+		{`int(Config.Labels["app.version"].size()) == 3`, true},
+		{`string(State.Restarts) == "4"`, true},
+		{`Config.Labels.owner`, false},
+		{`'single' + "double" == "singledouble"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Compile(tt.expr)
+			require.NoError(t, err)
+			got, err := expr.Match(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{``, "empty expression"},
+		{`State.Restarts >`, "invalid expression at position 17: expected operand, found end of expression"},
+		{`Name == "web`, "invalid expression at position 9: unterminated string"},
+		{`Name = "web"`, "invalid expression at position 6: unexpected character '='"},
+		{`(Name == "web"`, `invalid expression at position 15: expected ")", found end of expression`},
+		{`Name "web"`, `invalid expression at position 6: expected end of expression, found "\"web\""`},
+		{`Labels.`, "invalid expression at position 8: expected field name, found end of expression"},
+		{`a ? b`, `invalid expression at position 6: expected ":", found end of expression`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Compile(tt.expr)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestMatchError(t *testing.T) {
+	data := testData(t)
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{`Name`, "result is a string, not a boolean"},
+		{`State.Restarts > "3"`, "at position 16: cannot compare a number to a string"},
+		{`Name && true`, "at position 6: expected a boolean, not a string"},
+		{`Name.Length == 3`, `at position 6: cannot select field "Length" of a string`},
+		{`unknown(Name)`, "at position 1: unknown function unknown()"},
+		{`Name.matches("[")`, "at position 6: matches(): error parsing regexp"},
+		{`State.Restarts / 0 == 1`, "at position 16: division by zero"},
+		{`size(Name, Name) == 1`, "size() takes 1 argument(s), got 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Compile(tt.expr)
+			require.NoError(t, err)
+			_, err = expr.Match(data)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestShortCircuit(t *testing.T) {
+	expr, err := Compile(`has(State) && State.Restarts > "x"`)
+	require.NoError(t, err)
+	match, err := expr.Match(map[string]any{})
+	require.NoError(t, err)
+	assert.False(t, match)
+
+	expr, err = Compile(`Name == "x" || Name > 1`)
+	require.NoError(t, err)
+	match, err = expr.Match(map[string]any{"Name": "x"})
+	require.NoError(t, err)
+	assert.True(t, match)
+}
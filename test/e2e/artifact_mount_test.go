@@ -203,6 +203,61 @@ var _ = Describe("Podman artifact mount", func() {
 		Expect(session.OutputToString()).To(Equal("hello world second file"))
 	})
 
+	It("podman artifact mount refresh", func() {
+		ctrName := "ctr1"
+		artifactName := "localhost/test"
+		artifactFileName := "somefile"
+
+		artifactFile := filepath.Join(podmanTest.TempDir, artifactFileName)
+		err := os.WriteFile(artifactFile, []byte("version one\n"), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+
+		podmanTest.PodmanExitCleanly("artifact", "add", artifactName, artifactFile)
+
+		// FIXME: we need https://github.com/containers/container-selinux/pull/360 to fix the selinux access problem, until then disable it.
+		ctr := podmanTest.PodmanExitCleanly("run", "--security-opt=label=disable", "--name", ctrName, "-d", "--mount", "type=artifact,src="+artifactName+",dst=/tmp,refresh=1h", ALPINE, "sleep", "100")
+		ctrID := ctr.OutputToString()
+
+		session := podmanTest.PodmanExitCleanly("exec", ctrName, "cat", "/tmp/"+artifactFileName)
+		Expect(session.OutputToString()).To(Equal("version one"))
+
+		// nothing changed yet
+		session = podmanTest.PodmanExitCleanly("artifact", "refresh", ctrName)
+		Expect(session.OutputToString()).To(BeEmpty())
+
+		err = os.WriteFile(artifactFile, []byte("version two\n"), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("artifact", "add", "--replace", artifactName, artifactFile)
+
+		session = podmanTest.PodmanExitCleanly("artifact", "refresh", "--all")
+		Expect(session.OutputToString()).To(Equal(ctrID))
+
+		session = podmanTest.PodmanExitCleanly("exec", ctrName, "cat", "/tmp/"+artifactFileName)
+		Expect(session.OutputToString()).To(Equal("version two"))
+
+		session = podmanTest.PodmanExitCleanly("events", "--stream=false", "--filter", "container="+ctrName, "--filter", "event=artifact-refresh")
+		Expect(session.OutputToStringArray()).To(HaveLen(1))
+
+		session = podmanTest.Podman([]string{"artifact", "refresh", "--all", ctrName})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "when using the --all switch, you may not pass any container names or IDs"))
+
+		podmanTest.PodmanExitCleanly("run", "--name", "norefresh", "-d", "--mount", "type=artifact,src="+artifactName+",dst=/tmp", ALPINE, "sleep", "100")
+		session = podmanTest.Podman([]string{"artifact", "refresh", "norefresh"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "has no refreshing artifact volumes"))
+	})
+
+	It("podman artifact mount refresh options", func() {
+		session := podmanTest.Podman([]string{"create", "--mount", "type=artifact,src=someartifact,dst=/test,refresh-pull", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "refresh-pull and refresh-signal require a refresh interval"))
+
+		session = podmanTest.Podman([]string{"create", "--mount", "type=artifact,src=someartifact,dst=/test,refresh=1ms", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `refresh interval "1ms" must be at least 1s`))
+	})
+
 	It("podman artifact mount dest conflict", func() {
 		tests := []struct {
 			name  string
@@ -303,6 +303,71 @@ var _ = Describe("Podman artifact", func() {
 		Expect(inspectedArtifact.Name).To(Equal(artifact1Name))
 	})
 
+	It("podman artifact push with sigstore signature and verification", func() {
+		SkipIfRemote("podman-remote does not support signing")
+		lock, port, err := setupRegistry(nil)
+		if err == nil {
+			defer lock.Unlock()
+		}
+		Expect(err).ToNot(HaveOccurred())
+
+		// As in push_test.go, sigstore attachments can only be enabled in
+		// /etc/containers/registries.d.
+		repo := fmt.Sprintf("localhost:%s/sigstore-signed-artifact", port)
+		systemRegistriesDAddition := "/etc/containers/registries.d/podman-test-only-temporary-artifact-addition.yaml"
+		err = os.WriteFile(systemRegistriesDAddition, []byte(fmt.Sprintf("docker:\n  %s:\n    use-sigstore-attachments: true\n", repo)), 0o644)
+		if err != nil {
+			Skip(fmt.Sprintf("/etc/containers/registries.d isn’t writable: %s", err))
+		}
+		defer os.Remove(systemRegistriesDAddition)
+
+		keyPath, err := filepath.Abs("testdata/sigstore-key.pub")
+		Expect(err).ToNot(HaveOccurred())
+		policy := fmt.Sprintf(`{"default":[{"type":"insecureAcceptAnything"}],"transports":{"docker":{%q:[{"type":"sigstoreSigned","keyPath":%q}]}}}`, repo, keyPath)
+		policyPath := filepath.Join(podmanTest.TempDir, "artifact-policy.json")
+		err = os.WriteFile(policyPath, []byte(policy), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+
+		artifactFile := filepath.Join(podmanTest.TempDir, "signed-artifact")
+		err = os.WriteFile(artifactFile, []byte("signed content"), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+
+		unsigned := repo + ":unsigned"
+		podmanTest.PodmanExitCleanly("artifact", "add", unsigned, artifactFile)
+		podmanTest.PodmanExitCleanly("artifact", "push", "-q", "--tls-verify=false", unsigned)
+		podmanTest.PodmanExitCleanly("artifact", "rm", unsigned)
+		pull := podmanTest.Podman([]string{"artifact", "pull", "-q", "--tls-verify=false", "--signature-policy", policyPath, unsigned})
+		pull.WaitWithDefaultTimeout()
+		Expect(pull).To(ExitWithError(125, "Source image rejected: A signature was required, but no signature exists"))
+
+		signed := repo + ":signed"
+		podmanTest.PodmanExitCleanly("artifact", "add", signed, artifactFile)
+		podmanTest.PodmanExitCleanly("artifact", "push", "-q", "--tls-verify=false", "--sign-by-sigstore-private-key", "testdata/sigstore-key.key", "--sign-passphrase-file", "testdata/sigstore-key.key.pass", signed)
+		podmanTest.PodmanExitCleanly("artifact", "rm", signed)
+		podmanTest.PodmanExitCleanly("artifact", "pull", "-q", "--tls-verify=false", "--signature-policy", policyPath, signed)
+
+		// Mounts are verified with the default policy, use the one of the
+		// user configuration.
+		home := filepath.Join(podmanTest.TempDir, "artifact-home")
+		err = os.MkdirAll(filepath.Join(home, ".config", "containers"), 0o755)
+		Expect(err).ToNot(HaveOccurred())
+		err = os.WriteFile(filepath.Join(home, ".config", "containers", "policy.json"), []byte(policy), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+		options := PodmanExecOptions{Env: append(os.Environ(), "HOME="+home)}
+
+		run := podmanTest.PodmanWithOptions(options, "run", "--rm", "--security-opt=label=disable", "--mount", "type=artifact,src="+signed+",dst=/data", ALPINE, "cat", "/data")
+		run.WaitWithDefaultTimeout()
+		Expect(run).Should(ExitCleanly())
+		Expect(run.OutputToString()).To(Equal("signed content"))
+
+		// A local artifact with the name of the signed scope has no signatures.
+		local := repo + ":local"
+		podmanTest.PodmanExitCleanly("artifact", "add", local, artifactFile)
+		run = podmanTest.PodmanWithOptions(options, "run", "--rm", "--security-opt=label=disable", "--mount", "type=artifact,src="+local+",dst=/data", ALPINE, "cat", "/data")
+		run.WaitWithDefaultTimeout()
+		Expect(run).To(ExitWithError(125, "Source image rejected: A signature was required, but no signature exists"))
+	})
+
 	It("podman artifact remove", func() {
 		// Trying to remove an image that does not exist should fail
 		rmFail := podmanTest.Podman([]string{"artifact", "rm", "foobar"})
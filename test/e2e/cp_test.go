@@ -12,6 +12,7 @@ import (
 	. "github.com/containers/podman/v6/test/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
 )
 
 // NOTE: Only smoke tests.  The system tests (i.e., "./test/system/*") take
@@ -292,4 +293,59 @@ RUN chown 9999:9999 %s`, ALPINE, ctrVolPath, ctrVolPath)
 		_ = podmanTest.PodmanExitCleanly("create", "--name", ctrName, "-v", fmt.Sprintf("%s:%s", volName, ctrVolPath), imgName, "sh")
 		_ = podmanTest.PodmanExitCleanly("cp", srcFile.Name(), fmt.Sprintf("%s:%sfile2", ctrName, ctrVolPath))
 	})
+
+	It("podman cp --exclude", func() {
+		srcDir := filepath.Join(podmanTest.TempDir, "src")
+		Expect(os.MkdirAll(filepath.Join(srcDir, ".git"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, ".git", "HEAD"), []byte("ref"), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "main.go"), []byte("main"), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "main.swp"), []byte("swap"), 0o644)).To(Succeed())
+		ignoreFile := filepath.Join(podmanTest.TempDir, "ignore")
+		Expect(os.WriteFile(ignoreFile, []byte("# editor files\n*.swp\n"), 0o644)).To(Succeed())
+
+		_ = podmanTest.PodmanExitCleanly("create", "--name", "testctr", ALPINE, "top")
+		_ = podmanTest.PodmanExitCleanly("cp", "--exclude", "/.git", "--ignorefile", ignoreFile, srcDir, "testctr:/app")
+		_ = podmanTest.PodmanExitCleanly("start", "testctr")
+		ls := podmanTest.PodmanExitCleanly("exec", "testctr", "ls", "-a", "/app")
+		Expect(ls.OutputToStringArray()).To(ContainElement("main.go"))
+		Expect(ls.OutputToStringArray()).ToNot(ContainElements(".git", "main.swp"))
+
+		session := podmanTest.Podman([]string{"cp", "--exclude", "*.swp", "testctr:/app", podmanTest.TempDir})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "--exclude and --ignorefile are only supported when copying from the host"))
+
+		session = podmanTest.Podman([]string{"cp", "--delete", srcDir, "testctr:/app"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "--delete requires --watch"))
+	})
+
+	It("podman cp --watch", func() {
+		srcDir := filepath.Join(podmanTest.TempDir, "src")
+		Expect(os.MkdirAll(srcDir, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "old"), []byte("old"), 0o644)).To(Succeed())
+
+		_ = podmanTest.PodmanExitCleanly("run", "-d", "--name", "testctr", ALPINE, "top")
+		watch := podmanTest.Podman([]string{"cp", "--watch", "--delete", "--exclude", "*.tmp", "--reload", "touch /reloaded", srcDir + "/.", "testctr:/app"})
+		defer watch.Signal(os.Interrupt)
+
+		Eventually(func(g Gomega) {
+			cat := podmanTest.Podman([]string{"exec", "testctr", "cat", "/app/old"})
+			cat.WaitWithDefaultTimeout()
+			g.Expect(cat).To(ExitCleanly())
+		}, DefaultWaitTimeout).Should(Succeed())
+		// Give the watcher time to start watching the directory.
+		Eventually(watch.Err, DefaultWaitTimeout).Should(Say("Watching"))
+
+		Expect(os.WriteFile(filepath.Join(srcDir, "new"), []byte("new"), 0o644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "ignored.tmp"), []byte("tmp"), 0o644)).To(Succeed())
+		Expect(os.Remove(filepath.Join(srcDir, "old"))).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			ls := podmanTest.Podman([]string{"exec", "testctr", "ls", "/app", "/reloaded"})
+			ls.WaitWithDefaultTimeout()
+			g.Expect(ls).To(ExitCleanly())
+			g.Expect(ls.OutputToStringArray()).To(ContainElement("new"))
+			g.Expect(ls.OutputToStringArray()).ToNot(ContainElements("old", "ignored.tmp"))
+		}, DefaultWaitTimeout).Should(Succeed())
+	})
 })
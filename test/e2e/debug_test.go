@@ -0,0 +1,84 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	. "github.com/containers/podman/v6/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman debug", func() {
+	BeforeEach(func() {
+		SkipIfRemote("debug containers are not supported for remote clients")
+	})
+
+	It("podman debug bogus container", func() {
+		session := podmanTest.Podman([]string{"debug", "--image", ALPINE, "foobar", "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `no container with name or ID "foobar" found: no such container`))
+	})
+
+	It("podman debug stopped container", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test1", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"debug", "--image", ALPINE, "test1", "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "container test1 is not running, can only debug running containers"))
+	})
+
+	It("podman debug joins the namespaces of the container", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup).Should(ExitCleanly())
+		cid := setup.OutputToString()
+
+		session := podmanTest.Podman([]string{"debug", "--image", ALPINE, "--interactive=false", "test1", "sh", "-c", "ps -o pid,comm; hostname; cat /proc/1/root/etc/alpine-release"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(MatchRegexp(`\s1 top`))
+		Expect(session.OutputToStringArray()).To(ContainElement(cid[:12]))
+
+		// The debug container is removed
+		session = podmanTest.Podman([]string{"ps", "-aq", "--filter", "label=io.podman.debug.target=" + cid})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(BeEmpty())
+
+		session = podmanTest.Podman([]string{"events", "--stream=false", "--filter", "event=debug", "--filter", "container=" + cid, "--format", "{{.Status}} {{.Name}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("debug test1"))
+	})
+
+	It("podman debug exit code and name", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup).Should(ExitCleanly())
+
+		session := podmanTest.Podman([]string{"container", "debug", "--image", ALPINE, "--interactive=false", "--name", "dbg", "test1", "sh", "-c", "exit 3"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(3, ""))
+
+		session = podmanTest.Podman([]string{"container", "exists", "dbg"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(1, ""))
+	})
+
+	It("podman debug --mount-root", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup).Should(ExitCleanly())
+
+		session := podmanTest.Podman([]string{"debug", "--image", ALPINE, "--interactive=false", "--mount-root", "/target", "-e", "MSG=hello", "test1", "sh", "-c", "echo $MSG > /target/debug.txt"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"exec", "test1", "cat", "/debug.txt"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("hello"))
+	})
+})
@@ -4,6 +4,7 @@ package integration
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
 
 	. "github.com/containers/podman/v6/test/utils"
@@ -175,4 +176,33 @@ RUN touch %s`, ALPINE, imagefile)
 			Expect(session).Should(ExitWithError(125, " requires a name, id, or the \"--latest\" flag"))
 		}
 	})
+
+	It("podman diff --export and container apply-diff", func() {
+		SkipIfRemote("exporting and applying diffs is not supported for remote clients")
+		_ = podmanTest.PodmanExitCleanly("run", "--name", "source", ALPINE, "sh", "-c", "echo fix > /fix.txt && mkdir /opt/data && rm /etc/motd")
+		_ = podmanTest.PodmanExitCleanly("create", "--name", "target", ALPINE, "top")
+
+		layer := filepath.Join(podmanTest.TempDir, "diff.tar")
+		_ = podmanTest.PodmanExitCleanly("diff", "--export", layer, "source")
+		list := podmanTest.PodmanExitCleanly("run", "--rm", "-v", podmanTest.TempDir+":/in:z", ALPINE, "tar", "tf", "/in/diff.tar")
+		Expect(list.OutputToStringArray()).To(ContainElements("fix.txt", "opt/data/", "etc/.wh.motd"))
+		Expect(list.OutputToString()).ToNot(ContainSubstring("hosts"))
+
+		_ = podmanTest.PodmanExitCleanly("container", "apply-diff", "target", layer)
+		_ = podmanTest.PodmanExitCleanly("start", "target")
+		session := podmanTest.PodmanExitCleanly("exec", "target", "cat", "/fix.txt")
+		Expect(session.OutputToString()).To(Equal("fix"))
+		_ = podmanTest.PodmanExitCleanly("exec", "target", "test", "-d", "/opt/data")
+		session = podmanTest.Podman([]string{"exec", "target", "test", "-e", "/etc/motd"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(1, ""))
+
+		diff := podmanTest.PodmanExitCleanly("container", "diff", "target")
+		Expect(diff.OutputToString()).To(ContainSubstring("A /fix.txt"))
+		Expect(diff.OutputToString()).To(ContainSubstring("D /etc/motd"))
+
+		session = podmanTest.Podman([]string{"diff", "--export", layer, "--format", "json", "source"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "--format and --export cannot be used together"))
+	})
 })
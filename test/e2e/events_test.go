@@ -119,6 +119,29 @@ var _ = Describe("Podman events", func() {
 		Expect(result).Should(ExitCleanly())
 	})
 
+	It("podman events --since-cursor", func() {
+		name1 := stringid.GenerateRandomID()
+		name2 := stringid.GenerateRandomID()
+		podmanTest.PodmanExitCleanly("create", "--name", name1, ALPINE)
+
+		result := podmanTest.PodmanExitCleanly("events", "--stream=false", "--since", "1m", "--filter", "container="+name1, "--format", "{{.Cursor}}")
+		cursors := result.OutputToStringArray()
+		Expect(cursors).ToNot(BeEmpty())
+		cursor := cursors[len(cursors)-1]
+		Expect(cursor).ToNot(BeEmpty())
+
+		podmanTest.PodmanExitCleanly("create", "--name", name2, ALPINE)
+
+		result = podmanTest.PodmanExitCleanly("events", "--stream=false", "--since-cursor", cursor, "--format", "{{.Cursor}} {{.Name}}")
+		Expect(result.OutputToString()).ToNot(ContainSubstring(name1))
+		Expect(result.OutputToString()).To(ContainSubstring(name2))
+		Expect(result.OutputToString()).ToNot(ContainSubstring(cursor + " "))
+
+		session := podmanTest.Podman([]string{"events", "--stream=false", "--since-cursor", "invalid"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "invalid"))
+	})
+
 	It("podman events format", func() {
 		start := time.Now()
 		ctrName := "testCtr"
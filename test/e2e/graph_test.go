@@ -0,0 +1,50 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"encoding/json"
+
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	. "github.com/containers/podman/v6/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("podman graph", func() {
+	BeforeEach(func() {
+		SkipIfRemote("graph is not supported on podman --remote")
+	})
+
+	It("shows dependencies and the blocking dependency", func() {
+		podmanTest.PodmanExitCleanly("pod", "create", "--name", "graphpod")
+
+		session := podmanTest.Podman([]string{"run", "--name", "graphdb", ALPINE, "sh", "-c", "exit 3"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(3, ""))
+
+		podmanTest.PodmanExitCleanly("create", "--pod", "graphpod", "--name", "graphapi", "--requires", "graphdb", ALPINE, "top")
+
+		session = podmanTest.PodmanExitCleanly("graph", "--pod", "graphpod")
+		output := session.OutputToString()
+		Expect(output).To(ContainSubstring("graphapi [created] in pod graphpod"))
+		Expect(output).To(ContainSubstring("graphdb [exited (3)] (requires)"))
+		Expect(output).To(ContainSubstring("-infra [infra, created] ("))
+		Expect(output).To(ContainSubstring("graphapi cannot start: graphdb exited with code 3"))
+
+		session = podmanTest.PodmanExitCleanly("graph", "--pod", "graphpod", "--format", "json")
+		var report entities.GraphReport
+		Expect(json.Unmarshal(session.Out.Contents(), &report)).To(Succeed())
+		Expect(report.Nodes).To(ContainElement(HaveField("Name", "graphdb")))
+		Expect(report.Blockers).To(HaveLen(1))
+		Expect(report.Blockers[0].Reason).To(Equal("exited with code 3"))
+
+		session = podmanTest.PodmanExitCleanly("graph", "--format", "dot")
+		Expect(session.OutputToString()).To(HavePrefix("digraph containers {"))
+		Expect(session.OutputToString()).To(ContainSubstring(`label="pod graphpod"`))
+
+		session = podmanTest.Podman([]string{"graph", "--format", "svg"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `"svg" is not a valid value`))
+	})
+})
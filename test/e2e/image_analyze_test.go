@@ -0,0 +1,74 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/containers/podman/v6/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("podman image analyze", func() {
+	BeforeEach(func() {
+		SkipIfRemote("image analyze is not supported on podman --remote")
+	})
+
+	It("reports the changes of each layer and wasted space", func() {
+		dockerfile := fmt.Sprintf(`FROM %s
+RUN head -c 100000 /dev/zero > /big && echo keep > /keep
+RUN rm /big && echo changed > /keep`, ALPINE)
+		podmanTest.BuildImage(dockerfile, "analyze-test", "true")
+
+		session := podmanTest.PodmanExitCleanly("image", "analyze", "--format", "json", "analyze-test")
+		var result struct {
+			Layers []struct {
+				Added, Modified, Deleted int
+				Changes                  []struct {
+					Path string
+					Kind string
+				}
+			}
+			WastedBytes int64
+			Efficiency  float64
+			Wasted      []struct {
+				Path        string
+				WastedBytes int64
+			}
+		}
+		Expect(json.Unmarshal(session.Out.Contents(), &result)).To(Succeed())
+		Expect(len(result.Layers)).To(BeNumerically(">=", 3))
+		top := result.Layers[len(result.Layers)-1]
+		Expect(top.Deleted).To(BeNumerically(">=", 1))
+		Expect(top.Modified).To(BeNumerically(">=", 1))
+		Expect(result.WastedBytes).To(BeNumerically(">=", 100000))
+		Expect(result.Efficiency).To(BeNumerically("<", 100))
+		Expect(result.Wasted).To(ContainElement(HaveField("Path", "/big")))
+
+		session = podmanTest.PodmanExitCleanly("image", "analyze", "--files", "analyze-test")
+		Expect(session.OutputToString()).To(ContainSubstring("Efficiency:"))
+		Expect(session.OutputToString()).To(ContainSubstring("D /big"))
+
+		session = podmanTest.PodmanExitCleanly("image", "analyze", "--format", "{{.Name}}", "analyze-test")
+		Expect(session.OutputToString()).To(Equal("localhost/analyze-test:latest"))
+	})
+
+	It("fails if the efficiency is below the threshold", func() {
+		dockerfile := fmt.Sprintf(`FROM %s
+RUN head -c 1000000 /dev/zero > /big
+RUN rm /big`, ALPINE)
+		podmanTest.BuildImage(dockerfile, "analyze-wasteful", "true")
+
+		session := podmanTest.Podman([]string{"image", "analyze", "--fail-under", "99", "analyze-wasteful"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(1, "below the threshold of 99%"))
+
+		session = podmanTest.Podman([]string{"image", "analyze", "--fail-under", "101", "analyze-wasteful"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "--fail-under must be a percentage between 0 and 100"))
+
+		_ = podmanTest.PodmanExitCleanly("image", "analyze", "--fail-under", "0", "analyze-wasteful")
+	})
+})
@@ -0,0 +1,86 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/containers/podman/v6/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const auditMuslAdvisory = `{
+  "id": "TEST-2025-0001",
+  "aliases": ["CVE-2025-0001"],
+  "summary": "test advisory for musl",
+  "database_specific": {"severity": "HIGH"},
+  "affected": [{
+    "package": {"ecosystem": "Alpine", "name": "musl"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}]}]
+  }]
+}`
+
+var _ = Describe("podman image audit", func() {
+	var advisory string
+
+	BeforeEach(func() {
+		SkipIfRemote("image audit is not supported on podman --remote")
+		advisory = filepath.Join(podmanTest.TempDir, "musl.json")
+		err := os.WriteFile(advisory, []byte(auditMuslAdvisory), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("fails without a database", func() {
+		session := podmanTest.Podman([]string{"image", "audit", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no vulnerability database has been imported"))
+	})
+
+	It("reports vulnerable packages", func() {
+		session := podmanTest.PodmanExitCleanly("image", "audit", "--import-db", advisory)
+		Expect(session.OutputToString()).To(Equal("Imported 1 advisories, the database holds 1 advisories"))
+
+		session = podmanTest.PodmanExitCleanly("image", "audit", "--format", "json", ALPINE)
+		var reports []struct {
+			Name            string
+			Vulnerabilities []struct {
+				ID       string
+				Package  string
+				Severity string
+			}
+		}
+		Expect(json.Unmarshal(session.Out.Contents(), &reports)).To(Succeed())
+		Expect(reports).To(HaveLen(1))
+		Expect(reports[0].Name).To(Equal(ALPINE))
+		Expect(reports[0].Vulnerabilities).ToNot(BeEmpty())
+		Expect(reports[0].Vulnerabilities[0].ID).To(Equal("TEST-2025-0001"))
+		Expect(reports[0].Vulnerabilities[0].Severity).To(Equal("high"))
+
+		session = podmanTest.PodmanExitCleanly("image", "audit", "--format", "{{.Package}} {{.ID}}", ALPINE)
+		Expect(session.OutputToStringArray()).To(ContainElement("musl TEST-2025-0001"))
+
+		podmanTest.PodmanExitCleanly("image", "audit", "--fail-on", "critical", ALPINE)
+
+		session = podmanTest.Podman([]string{"image", "audit", "--fail-on", "high", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(1, "vulnerabilities with severity high or higher"))
+	})
+
+	It("filters containers of vulnerable images", func() {
+		podmanTest.PodmanExitCleanly("image", "audit", "--import-db", advisory)
+		podmanTest.PodmanExitCleanly("create", "--name", "vulnctr", ALPINE, "true")
+		podmanTest.PodmanExitCleanly("create", "--name", "otherctr", BB, "true")
+
+		session := podmanTest.PodmanExitCleanly("ps", "-a", "--filter", "vulnerable=true", "--format", "{{.Names}}")
+		Expect(session.OutputToStringArray()).To(Equal([]string{"vulnctr"}))
+
+		session = podmanTest.PodmanExitCleanly("ps", "-a", "--filter", "vulnerable=false", "--format", "{{.Names}}")
+		Expect(session.OutputToStringArray()).To(Equal([]string{"otherctr"}))
+
+		session = podmanTest.PodmanExitCleanly("ps", "-a", "--filter", "vulnerable=critical", "--format", "{{.Names}}")
+		Expect(session.OutputToString()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,105 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/containers/podman/v6/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("podman image sbom", func() {
+	BeforeEach(func() {
+		SkipIfRemote("image sbom is not supported on podman --remote")
+	})
+
+	It("lists apk packages as SPDX", func() {
+		session := podmanTest.PodmanExitCleanly("image", "sbom", ALPINE)
+
+		var doc struct {
+			SPDXVersion string `json:"spdxVersion"`
+			Packages    []struct {
+				Name         string `json:"name"`
+				ExternalRefs []struct {
+					ReferenceLocator string `json:"referenceLocator"`
+				} `json:"externalRefs"`
+			} `json:"packages"`
+		}
+		Expect(json.Unmarshal(session.Out.Contents(), &doc)).To(Succeed())
+		Expect(doc.SPDXVersion).To(Equal("SPDX-2.3"))
+
+		var purls []string
+		for _, p := range doc.Packages {
+			for _, ref := range p.ExternalRefs {
+				purls = append(purls, ref.ReferenceLocator)
+			}
+		}
+		Expect(purls).To(ContainElement(HavePrefix("pkg:apk/alpine/musl@")))
+	})
+
+	It("writes CycloneDX for a committed container", func() {
+		output := filepath.Join(podmanTest.TempDir, "sbom.json")
+		podmanTest.PodmanExitCleanly("run", "--name", "sbomctr", ALPINE, "sh", "-c", "rm /lib/apk/db/installed")
+		podmanTest.PodmanExitCleanly("commit", "-q", "sbomctr", "sbomimage")
+
+		session := podmanTest.PodmanExitCleanly("image", "sbom", "--format", "cyclonedx", "--output", output, "sbomimage")
+		Expect(session.OutputToString()).To(BeEmpty())
+
+		data, err := os.ReadFile(output)
+		Expect(err).ToNot(HaveOccurred())
+		var doc struct {
+			BOMFormat  string `json:"bomFormat"`
+			Components []struct {
+				Type string `json:"type"`
+				Name string `json:"name"`
+			} `json:"components"`
+		}
+		Expect(json.Unmarshal(data, &doc)).To(Succeed())
+		Expect(doc.BOMFormat).To(Equal("CycloneDX"))
+		// The package database was removed in the committed layer.
+		for _, c := range doc.Components {
+			Expect(c.Type).ToNot(Equal("library"), "unexpected component %s", c.Name)
+		}
+	})
+
+	It("attaches the SBOM of a committed image", func() {
+		lock, port, err := setupRegistry(nil)
+		if err == nil {
+			defer lock.Unlock()
+		}
+		Expect(err).ToNot(HaveOccurred())
+
+		imageName := fmt.Sprintf("localhost:%s/sbom/app:1.0", port)
+		podmanTest.PodmanExitCleanly("run", "--name", "attachctr", ALPINE, "true")
+		podmanTest.PodmanExitCleanly("commit", "-q", "attachctr", imageName)
+		imageDigest := podmanTest.PodmanExitCleanly("image", "inspect", "--format", "{{.Digest}}", imageName).OutputToString()
+
+		// The image was not pushed, the SBOM refers to its local manifest.
+		session := podmanTest.PodmanExitCleanly("image", "sbom", "--attach", "-q", "--tls-verify=false", imageName)
+		artifactName, _, _ := strings.Cut(session.OutputToString(), "@")
+		Expect(artifactName).To(Equal(fmt.Sprintf("localhost:%s/sbom/app:%s.sbom", port, strings.Replace(imageDigest, ":", "-", 1))))
+
+		artifact := podmanTest.InspectArtifact(artifactName)
+		Expect(artifact.Manifest.Subject).ToNot(BeNil())
+		Expect(artifact.Manifest.Subject.Digest.String()).To(Equal(imageDigest))
+		Expect(artifact.Manifest.ArtifactType).To(Equal("application/spdx+json"))
+	})
+
+	It("rejects invalid input", func() {
+		session := podmanTest.Podman([]string{"image", "sbom", "--format", "xml", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `unsupported SBOM format "xml"`))
+
+		podmanTest.PodmanExitCleanly("run", "--name", "nonamectr", ALPINE, "true")
+		imageID := podmanTest.PodmanExitCleanly("commit", "-q", "nonamectr").OutputToString()
+		session = podmanTest.Podman([]string{"image", "sbom", "--attach", imageID})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "has no name, cannot attach the SBOM to it"))
+	})
+})
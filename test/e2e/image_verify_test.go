@@ -0,0 +1,76 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/containers/podman/v6/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("podman image verify", func() {
+	BeforeEach(func() {
+		SkipIfRemote("image verify is not supported on podman --remote")
+	})
+
+	writePolicy := func(policy string) string {
+		path := filepath.Join(podmanTest.TempDir, "policy.json")
+		err := os.WriteFile(path, []byte(policy), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+		return path
+	}
+
+	It("accepts an image allowed by the policy", func() {
+		policy := writePolicy(`{"default": [{"type": "insecureAcceptAnything"}]}`)
+
+		session := podmanTest.PodmanExitCleanly("image", "verify", "--policypath", policy, ALPINE)
+		Expect(session.OutputToString()).To(ContainSubstring("Result:  accepted"))
+		Expect(session.OutputToString()).To(ContainSubstring("insecureAcceptAnything: satisfied"))
+
+		session = podmanTest.PodmanExitCleanly("image", "verify", "--policypath", policy, "--format", "{{.Scope}} {{.Allowed}}", ALPINE)
+		Expect(session.OutputToString()).To(Equal("default true"))
+	})
+
+	It("reports the failed requirement of a rejected image", func() {
+		policy := writePolicy(`{
+			"default": [{"type": "insecureAcceptAnything"}],
+			"transports": {"docker": {"quay.io/libpod": [{"type": "insecureAcceptAnything"}, {"type": "reject"}]}}
+		}`)
+
+		session := podmanTest.Podman([]string{"image", "verify", "--policypath", policy, "--format", "json", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(1, "is rejected by the policy in "+policy))
+
+		var report struct {
+			Scope        string
+			Allowed      bool
+			Requirements []struct {
+				Type    string
+				Allowed bool
+				Error   string
+			}
+			Signatures []any
+		}
+		err := json.Unmarshal(session.Out.Contents(), &report)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Scope).To(Equal("docker:quay.io/libpod"))
+		Expect(report.Allowed).To(BeFalse())
+		Expect(report.Requirements).To(HaveLen(2))
+		Expect(report.Requirements[0].Allowed).To(BeTrue())
+		Expect(report.Requirements[1].Type).To(Equal("reject"))
+		Expect(report.Requirements[1].Allowed).To(BeFalse())
+		Expect(report.Requirements[1].Error).ToNot(BeEmpty())
+		Expect(report.Signatures).To(BeEmpty())
+	})
+
+	It("fails for an invalid policy", func() {
+		policy := writePolicy(`{"default": [{"type": "unknown"}]}`)
+		session := podmanTest.Podman([]string{"image", "verify", "--policypath", policy, ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "invalid policy in"))
+	})
+})
@@ -557,4 +557,16 @@ LABEL xyz="bar"
 		Expect(session.OutputToStringArray()).To(HaveLen(2))
 		Expect(session.OutputToString()).To(ContainSubstring("test-abc-xyz"))
 	})
+
+	It("podman images --where", func() {
+		result := podmanTest.PodmanExitCleanly("images", "--where", fmt.Sprintf("%q in RepoTags && Size > 0", ALPINE), "--format", "{{.Repository}}:{{.Tag}}")
+		Expect(result.OutputToStringArray()).To(Equal([]string{ALPINE}))
+
+		result = podmanTest.PodmanExitCleanly("images", "-q", "--where", "Size < 0")
+		Expect(result.OutputToString()).To(BeEmpty())
+
+		result = podmanTest.Podman([]string{"images", "--where", "RepoTags ==="})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError(125, "invalid expression at position 12: unexpected character '='"))
+	})
 })
@@ -0,0 +1,108 @@
+//go:build linux
+
+package integration
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/containers/podman/v6/pkg/domain/entities"
+	. "github.com/containers/podman/v6/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.podman.io/storage/pkg/stringid"
+)
+
+var _ = Describe("Podman network policy", func() {
+	It("podman network policy create, ls, inspect and rm", func() {
+		net := "net" + stringid.GenerateRandomID()[:10]
+		podmanTest.PodmanExitCleanly("network", "create", net)
+		defer podmanTest.removeNetwork(net)
+
+		name := "policy" + stringid.GenerateRandomID()[:10]
+		session := podmanTest.PodmanExitCleanly("network", "policy", "create", "--network", net, "--selector", "app=db",
+			"--ingress", "selector=app=web,port=5432", "--egress", "cidr=10.0.0.0/8,except=10.1.0.0/16", "--egress", "port=53/udp", name)
+		Expect(session.OutputToString()).To(Equal(name))
+
+		session = podmanTest.Podman([]string{"network", "policy", "create", "--network", net, name})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "network policy "+name+": network policy already exists"))
+
+		session = podmanTest.PodmanExitCleanly("network", "policy", "ls", "--noheading")
+		Expect(session.OutputToString()).To(ContainSubstring(name))
+		Expect(session.OutputToString()).To(ContainSubstring("app=db"))
+		Expect(session.OutputToString()).To(ContainSubstring("Ingress,Egress"))
+
+		session = podmanTest.PodmanExitCleanly("network", "policy", "inspect", name)
+		var policies []entities.NetworkPolicy
+		err := json.Unmarshal([]byte(session.OutputToString()), &policies)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policies).To(HaveLen(1))
+		Expect(policies[0].Networks).To(Equal([]string{net}))
+		Expect(policies[0].PodSelector).To(HaveKeyWithValue("app", "db"))
+		Expect(policies[0].Ingress).To(HaveLen(1))
+		Expect(policies[0].Ingress[0].Peers[0].PodSelector).To(HaveKeyWithValue("app", "web"))
+		Expect(policies[0].Ingress[0].Ports[0].Port).To(Equal(uint16(5432)))
+		Expect(policies[0].Egress).To(HaveLen(2))
+		Expect(policies[0].Egress[0].Peers[0].Except).To(Equal([]string{"10.1.0.0/16"}))
+
+		podmanTest.PodmanExitCleanly("network", "policy", "create", "--replace", "--network", net, name)
+		session = podmanTest.PodmanExitCleanly("network", "policy", "inspect", "--format", "{{.PolicyTypes}} {{len .Ingress}}", name)
+		Expect(session.OutputToString()).To(Equal("[Ingress] 0"))
+
+		session = podmanTest.PodmanExitCleanly("network", "policy", "rm", name)
+		Expect(session.OutputToString()).To(Equal(name))
+
+		session = podmanTest.Podman([]string{"network", "policy", "rm", name})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "network policy "+name+": no such network policy"))
+
+		podmanTest.PodmanExitCleanly("network", "policy", "rm", "--ignore", name)
+	})
+
+	It("podman network policy create rejects invalid policies", func() {
+		session := podmanTest.Podman([]string{"network", "policy", "create", "--network", "nonexistent", "policy1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "unable to find network with name or ID nonexistent: network not found"))
+
+		session = podmanTest.Podman([]string{"network", "policy", "create", "--network", "podman", "--ingress", "port=100-10", "policy1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "invalid network policy port range 100-10"))
+
+		session = podmanTest.Podman([]string{"network", "policy", "create", "--network", "podman", "--ingress", "cidr=10.0.0.0/8,except=192.168.0.0/16", "policy1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "except 192.168.0.0/16 is not within 10.0.0.0/8"))
+	})
+
+	It("podman kube play and down NetworkPolicy", func() {
+		kubeYaml := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		err := writeYaml(`apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: db-access
+spec:
+  podSelector:
+    matchLabels:
+      app: db
+  ingress:
+  - from:
+    - podSelector:
+        matchLabels:
+          app: web
+    ports:
+    - port: 5432
+`, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.PodmanExitCleanly("kube", "play", kubeYaml)
+		Expect(session.OutputToString()).To(ContainSubstring("db-access"))
+
+		session = podmanTest.PodmanExitCleanly("network", "policy", "inspect", "--format", "{{.Networks}} {{.PolicyTypes}}", "db-access")
+		Expect(session.OutputToString()).To(Equal("[podman-default-kube-network] [Ingress]"))
+
+		podmanTest.PodmanExitCleanly("kube", "down", kubeYaml)
+
+		session = podmanTest.PodmanExitCleanly("network", "policy", "ls", "--quiet")
+		Expect(session.OutputToString()).ToNot(ContainSubstring("db-access"))
+	})
+})
@@ -6493,4 +6493,113 @@ spec:
 		inspect := podmanTest.PodmanExitCleanly("inspect", "simpleWithoutPodPrefix")
 		Expect(inspect.InspectContainerToJSON()[0].Name).Should(Equal("simpleWithoutPodPrefix"))
 	})
+
+	It("render with values", func() {
+		podTemplate := `apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ .Values.name | default "values-pod" }}
+spec:
+  restartPolicy: Never
+  containers:
+  - name: ctr
+    image: {{ required "image is required" .Values.image }}
+    command: ["echo", {{ .Values.greeting | quote }}]
+`
+		err := writeYaml(podTemplate, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		valuesFile := filepath.Join(podmanTest.TempDir, "values.yaml")
+		err = os.WriteFile(valuesFile, []byte("image: "+CITEST_IMAGE+"\ngreeting: hello\n"), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+
+		render := podmanTest.PodmanExitCleanly("kube", "play", "--render", "--values", valuesFile, "--set", "greeting=world", kubeYaml)
+		Expect(render.OutputToString()).To(ContainSubstring("name: values-pod"))
+		Expect(render.OutputToString()).To(ContainSubstring(`command: ["echo", "world"]`))
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml, "--set", "greeting=hi"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).To(ExitWithError(125, "image is required"))
+
+		podmanTest.PodmanExitCleanly("kube", "play", "--values", valuesFile, "--set", "name=rendered", kubeYaml)
+		podmanTest.PodmanExitCleanly("wait", "rendered-ctr")
+		logs := podmanTest.PodmanExitCleanly("logs", "rendered-ctr")
+		Expect(logs.OutputToString()).To(Equal("hello"))
+
+		podmanTest.PodmanExitCleanly("kube", "down", "--values", valuesFile, "--set", "name=rendered", kubeYaml)
+		exists := podmanTest.Podman([]string{"pod", "exists", "rendered"})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).Should(ExitWithError(1, ""))
+	})
+
+	It("rolling update and rollback of a deployment", func() {
+		deploymentTemplate := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: rolling
+spec:
+  selector:
+    matchLabels:
+      app: rolling
+  template:
+    metadata:
+      labels:
+        app: rolling
+    spec:
+      containers:
+      - name: ctr
+        image: ` + CITEST_IMAGE + `
+        command: ["top"]
+        env:
+        - name: LEVEL
+          value: "%s"
+`
+		podLabels := func(name string) map[string]string {
+			inspect := podmanTest.PodmanExitCleanly("pod", "inspect", name)
+			return inspect.InspectPodToJSON().Labels
+		}
+		podState := func(name string) string {
+			return podmanTest.PodmanExitCleanly("pod", "inspect", "--format", "{{.State}}", name).OutputToString()
+		}
+
+		err := writeYaml(fmt.Sprintf(deploymentTemplate, "1"), kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("kube", "play", kubeYaml)
+		Expect(podLabels("rolling-pod")).To(HaveKeyWithValue("io.podman.kube.revision", "1"))
+
+		// Without a change, nothing is rolled out.
+		session := podmanTest.PodmanExitCleanly("kube", "play", "--rolling", kubeYaml)
+		Expect(session.OutputToString()).To(ContainSubstring("rolling: revision 1 is up to date"))
+
+		err = writeYaml(fmt.Sprintf(deploymentTemplate, "2"), kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+		session = podmanTest.PodmanExitCleanly("kube", "play", "--rolling", kubeYaml)
+		Expect(session.OutputToString()).To(ContainSubstring("rolling: rolled out revision 2"))
+
+		pods := podmanTest.PodmanExitCleanly("pod", "ps", "--filter", "label=io.podman.kube.deployment=rolling", "--filter", "label=io.podman.kube.revision=2", "--format", "{{.Name}}")
+		newPod := pods.OutputToString()
+		Expect(newPod).To(HavePrefix("rolling-pod-"))
+		Expect(podState(newPod)).To(Equal("Running"))
+		Expect(podState("rolling-pod")).To(Equal("Exited"))
+		env := podmanTest.PodmanExitCleanly("exec", newPod+"-ctr", "printenv", "LEVEL")
+		Expect(env.OutputToString()).To(Equal("2"))
+
+		session = podmanTest.PodmanExitCleanly("kube", "rollback", "rolling")
+		Expect(session.OutputToString()).To(Equal("rolling: rolled back to revision 1"))
+		Expect(podState("rolling-pod")).To(Equal("Running"))
+		Expect(podState(newPod)).To(Equal("Exited"))
+
+		session = podmanTest.Podman([]string{"kube", "rollback", "--to-revision", "1", "rolling"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "revision 1 of deployment rolling is already running"))
+
+		// Playing the YAML of revision 2 again starts its kept pod.
+		session = podmanTest.PodmanExitCleanly("kube", "play", "--rolling", kubeYaml)
+		Expect(session.OutputToString()).To(ContainSubstring("rolling: rolled out revision 2"))
+		Expect(podState(newPod)).To(Equal("Running"))
+
+		podmanTest.PodmanExitCleanly("kube", "down", kubeYaml)
+		pods = podmanTest.PodmanExitCleanly("pod", "ps", "-q", "--filter", "label=io.podman.kube.deployment=rolling")
+		Expect(pods.OutputToString()).To(BeEmpty())
+	})
 })
@@ -1117,4 +1117,26 @@ var _ = Describe("Podman ps", func() {
 		Expect(output).ToNot(ContainSubstring("test-unless-stopped-exit-bad"))
 		Expect(output).ToNot(ContainSubstring("test-always-exit-bad"))
 	})
+
+	It("podman ps --where", func() {
+		podmanTest.PodmanExitCleanly("create", "--name", "where-web", "--label", "tier=web", ALPINE, "top")
+		podmanTest.PodmanExitCleanly("create", "--name", "where-db", "--label", "tier=db", ALPINE, "top")
+
+		result := podmanTest.PodmanExitCleanly("ps", "-a", "--where", `Config.Labels["tier"] == "web"`, "--format", "{{.Names}}")
+		Expect(result.OutputToStringArray()).To(Equal([]string{"where-web"}))
+
+		result = podmanTest.PodmanExitCleanly("ps", "-a", "--where", `Name.startsWith("where-") && RestartCount == 0`, "--format", "{{.Names}}")
+		Expect(result.OutputToStringArray()).To(ConsistOf("where-web", "where-db"))
+
+		result = podmanTest.PodmanExitCleanly("ps", "--where", `Name.startsWith("where-")`, "--format", "{{.Names}}")
+		Expect(result.OutputToString()).To(BeEmpty())
+
+		result = podmanTest.Podman([]string{"ps", "-a", "--where", "RestartCount >"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError(125, "invalid expression at position 15: expected operand, found end of expression"))
+
+		result = podmanTest.Podman([]string{"ps", "-a", "--where", "Name"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError(125, "result is a string, not a boolean"))
+	})
 })
@@ -976,6 +976,7 @@ BOGUS=foo
 		Entry("template@instance.container", "template@instance.container"),
 		Entry("Unit After Override", "unit-after-override.container"),
 		Entry("NetworkAlias", "network-alias.container"),
+		Entry("NetworkOpt", "network-opt.container"),
 		Entry("CgroupMode", "cgroups-mode.container"),
 		Entry("Container - No Default Dependencies", "no_deps.container"),
 		Entry("retry.container", "retry.container"),
@@ -1016,6 +1017,7 @@ BOGUS=foo
 		Entry("Kube - Containers Conf Modules", "containersconfmodule.kube"),
 		Entry("Kube - Service Type=oneshot", "oneshot.kube"),
 		Entry("Kube - Down force", "downforce.kube"),
+		Entry("Kube - Values", "values.kube"),
 
 		Entry("Network - Basic", "basic.network"),
 		Entry("Network - Disable DNS", "disable-dns.network"),
@@ -1110,6 +1112,7 @@ BOGUS=foo
 		Entry("Pod - Network", "network.pod"),
 		Entry("Pod - PodmanArgs", "podmanargs.pod"),
 		Entry("Pod - NetworkAlias", "network-alias.pod"),
+		Entry("Pod - NetworkOpt", "network-opt.pod"),
 		Entry("Pod - Remap auto", "remap-auto.pod"),
 		Entry("Pod - Remap auto2", "remap-auto2.pod"),
 		Entry("Pod - Remap keep-id", "remap-keep-id.pod"),
@@ -14,6 +14,7 @@ import (
 	"syscall"
 
 	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/podman/v6/libpod/define"
 	"github.com/containers/podman/v6/pkg/domain/entities"
 	. "github.com/containers/podman/v6/test/utils"
 	. "github.com/onsi/ginkgo/v2"
@@ -1206,6 +1207,51 @@ EXPOSE 2004-2005/tcp`, ALPINE)
 		Expect(session).Should(ExitCleanly())
 	})
 
+	It("podman run with network-opt rate limits", func() {
+		net := "rate" + stringid.GenerateRandomID()
+		session := podmanTest.Podman([]string{"network", "create", net})
+		session.WaitWithDefaultTimeout()
+		defer podmanTest.removeNetwork(net)
+		Expect(session).Should(ExitCleanly())
+
+		ctrName := "ratelimit"
+		session = podmanTest.Podman([]string{"run", "-d", "--name", ctrName, "--network", net, "--network-opt", "ingress-rate=10mbit,egress-rate=5mbit", "--network-opt", net + ":egress-rate=1mbit", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		inspect := podmanTest.InspectContainer(ctrName)
+		Expect(inspect).To(HaveLen(1))
+		Expect(inspect[0].HostConfig.NetworkRateLimits).To(Equal(map[string]define.NetworkRateLimit{
+			"":  {IngressRate: 10000000, EgressRate: 5000000},
+			net: {EgressRate: 1000000},
+		}))
+
+		if !isRootless() {
+			qdisc := SystemExec("nsenter", []string{"--net=" + inspect[0].NetworkSettings.SandboxKey, "tc", "qdisc", "show", "dev", "eth0"})
+			Expect(qdisc).Should(ExitCleanly())
+			Expect(qdisc.OutputToString()).To(ContainSubstring("qdisc tbf 1: root"))
+			Expect(qdisc.OutputToString()).To(ContainSubstring("qdisc ingress ffff:"))
+		}
+
+		session = podmanTest.Podman([]string{"update", "--network-opt", net + ":ingress-rate=2mbit", "--network-opt", "ingress-rate=0", ctrName})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		inspect = podmanTest.InspectContainer(ctrName)
+		Expect(inspect).To(HaveLen(1))
+		Expect(inspect[0].HostConfig.NetworkRateLimits).To(Equal(map[string]define.NetworkRateLimit{
+			net: {IngressRate: 2000000},
+		}))
+
+		session = podmanTest.Podman([]string{"run", "--network-opt", "ingress-rate=10", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `invalid rate "10", expected a number with a unit such as 10mbit: invalid argument`))
+
+		session = podmanTest.Podman([]string{"run", "--network", "host", "--network-opt", "ingress-rate=10mbit", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "network rate limits can only be set for containers with their own network namespace"))
+	})
+
 	It("podman run with ipam none driver", func() {
 		net := "ipam" + stringid.GenerateRandomID()
 		session := podmanTest.Podman([]string{"network", "create", "--ipam-driver=none", net})
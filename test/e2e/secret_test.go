@@ -500,4 +500,157 @@ var _ = Describe("Podman secret", func() {
 		exists.WaitWithDefaultTimeout()
 		Expect(exists).Should(ExitWithError(1, ""))
 	})
+
+	It("podman secret update propagates into containers", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("mysecret"), 0o755)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("secret", "create", "updsecret", secretFilePath)
+
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", "mounted", "--secret", "updsecret", ALPINE, "top")
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", "env", "--secret", "updsecret,type=env,target=MYSECRET", ALPINE, "top")
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", "outside", "--secret", "updsecret,target=/etc/mysecret", ALPINE, "top")
+		podmanTest.PodmanExitCleanly("create", "--name", "stopped", "--secret", "updsecret,target=/etc/mysecret", ALPINE, "cat", "/etc/mysecret")
+
+		err = os.WriteFile(secretFilePath, []byte("newsecret"), 0o755)
+		Expect(err).ToNot(HaveOccurred())
+		session := podmanTest.PodmanExitCleanly("secret", "update", "--exec", "sh -c 'cat /run/secrets/updsecret > /reloaded'", "updsecret", secretFilePath)
+		secretID := session.OutputToString()
+
+		session = podmanTest.PodmanExitCleanly("exec", "mounted", "cat", "/run/secrets/updsecret")
+		Expect(session.OutputToString()).To(Equal("newsecret"))
+		session = podmanTest.PodmanExitCleanly("exec", "mounted", "cat", "/reloaded")
+		Expect(session.OutputToString()).To(Equal("newsecret"))
+		session = podmanTest.PodmanExitCleanly("start", "--attach", "stopped")
+		Expect(session.OutputToString()).To(Equal("newsecret"))
+
+		session = podmanTest.PodmanExitCleanly("secret", "inspect", "--format", "{{.ID}} {{.Version}}", "updsecret")
+		Expect(session.OutputToString()).To(Equal(secretID + " 2"))
+		session = podmanTest.PodmanExitCleanly("secret", "inspect", "--format", "{{range .Containers}}{{.Name}}={{.Stale}} {{end}}", "updsecret")
+		Expect(session.OutputToString()).To(ContainSubstring("mounted=false"))
+		Expect(session.OutputToString()).To(ContainSubstring("stopped=false"))
+		Expect(session.OutputToString()).To(ContainSubstring("env=true"))
+		// Secrets mounted outside of /run/secrets are updated when the
+		// container restarts.
+		Expect(session.OutputToString()).To(ContainSubstring("outside=true"))
+		session = podmanTest.PodmanExitCleanly("exec", "outside", "cat", "/etc/mysecret")
+		Expect(session.OutputToString()).To(Equal("mysecret"))
+		podmanTest.PodmanExitCleanly("restart", "-t0", "outside")
+		session = podmanTest.PodmanExitCleanly("exec", "outside", "cat", "/etc/mysecret")
+		Expect(session.OutputToString()).To(Equal("newsecret"))
+
+		// The environment is updated when the container restarts.
+		podmanTest.PodmanExitCleanly("restart", "-t0", "env")
+		session = podmanTest.PodmanExitCleanly("exec", "env", "printenv", "MYSECRET")
+		Expect(session.OutputToString()).To(Equal("newsecret"))
+
+		// Replacing a secret makes containers stale.
+		podmanTest.PodmanExitCleanly("secret", "create", "--replace", "updsecret", secretFilePath)
+		session = podmanTest.PodmanExitCleanly("secret", "inspect", "--format", "{{.Version}} {{range .Containers}}{{.Name}}={{.Stale}} {{end}}", "updsecret")
+		Expect(session.OutputToString()).To(HavePrefix("3 "))
+		Expect(session.OutputToString()).To(ContainSubstring("mounted=true"))
+
+		result := podmanTest.PodmanExitCleanly("events", "--stream=false", "--filter", "type=secret")
+		Expect(result.OutputToStringArray()).To(ContainElement(ContainSubstring(" secret update %s", secretID)))
+	})
+
+	It("podman secret update reports failing reloads", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("mysecret"), 0o755)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("secret", "create", "updsecret", secretFilePath)
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", "reloadfail", "--secret", "updsecret", ALPINE, "top")
+
+		session := podmanTest.Podman([]string{"secret", "update", "--exec", "false", "updsecret", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "updating secret in container reloadfail: reloading container"))
+
+		session = podmanTest.Podman([]string{"secret", "update", "--signal", "NOSIG", "updsecret", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "invalid signal: NOSIG"))
+
+		session = podmanTest.Podman([]string{"secret", "update", "nosuchsecret", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no such secret"))
+	})
+
+	It("podman secret history and rollback", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		for i, data := range []string{"one", "two", "three"} {
+			err := os.WriteFile(secretFilePath, []byte(data), 0o755)
+			Expect(err).ToNot(HaveOccurred())
+			if i == 0 {
+				podmanTest.PodmanExitCleanly("secret", "create", "versecret", secretFilePath)
+			} else {
+				podmanTest.PodmanExitCleanly("secret", "update", "versecret", secretFilePath)
+			}
+		}
+
+		session := podmanTest.PodmanExitCleanly("secret", "history", "--quiet", "versecret")
+		Expect(session.OutputToStringArray()).To(Equal([]string{"3", "2", "1"}))
+		session = podmanTest.PodmanExitCleanly("secret", "history", "--format", "{{.Version}} {{.Comment}}", "versecret")
+		Expect(session.OutputToStringArray()[0]).To(Equal("3 current"))
+
+		// Previous versions are hidden from the list but can be pinned.
+		session = podmanTest.PodmanExitCleanly("secret", "ls", "--format", "{{.Name}}")
+		Expect(session.OutputToStringArray()).To(Equal([]string{"versecret"}))
+		session = podmanTest.PodmanExitCleanly("secret", "ls", "--all", "--format", "{{.Name}}")
+		Expect(session.OutputToStringArray()).To(ConsistOf("versecret", "versecret@v1", "versecret@v2"))
+		podmanTest.PodmanExitCleanly("create", "--name", "pinned", "--secret", "versecret@v1", ALPINE, "cat", "/run/secrets/versecret")
+		session = podmanTest.PodmanExitCleanly("start", "--attach", "pinned")
+		Expect(session.OutputToString()).To(Equal("one"))
+		// The current version can be pinned too.
+		podmanTest.PodmanExitCleanly("create", "--name", "current", "--secret", "versecret@v3", ALPINE, "cat", "/run/secrets/versecret")
+		session = podmanTest.Podman([]string{"create", "--secret", "versecret@v4", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no such secret"))
+
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", "follower", "--secret", "versecret", ALPINE, "top")
+		podmanTest.PodmanExitCleanly("secret", "rollback", "--to", "v2", "versecret")
+		session = podmanTest.PodmanExitCleanly("exec", "follower", "cat", "/run/secrets/versecret")
+		Expect(session.OutputToString()).To(Equal("two"))
+		session = podmanTest.PodmanExitCleanly("start", "--attach", "pinned")
+		Expect(session.OutputToString()).To(Equal("one"))
+		session = podmanTest.PodmanExitCleanly("start", "--attach", "current")
+		Expect(session.OutputToString()).To(Equal("three"))
+		session = podmanTest.PodmanExitCleanly("secret", "history", "--format", "{{.Version}} {{.Comment}}", "versecret")
+		Expect(session.OutputToStringArray()[0]).To(Equal("4 current, rollback to v2"))
+
+		session = podmanTest.Podman([]string{"secret", "rollback", "--to", "4", "versecret"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "secret versecret is at version 4 already"))
+		session = podmanTest.Podman([]string{"secret", "update", "versecret@v1", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "versecret@v1 is a previous version of secret versecret, it cannot be updated"))
+
+		// Removing the secret removes all its versions, containers keep
+		// their copy of pinned versions.
+		podmanTest.PodmanExitCleanly("rm", "-f", "-t0", "follower")
+		podmanTest.PodmanExitCleanly("secret", "rm", "versecret")
+		for _, version := range []string{"versecret@v1", "versecret@v2"} {
+			session = podmanTest.Podman([]string{"secret", "inspect", version})
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(ExitWithError(125, "no such secret"))
+		}
+		session = podmanTest.PodmanExitCleanly("secret", "ls", "--all", "--quiet")
+		Expect(session.OutputToString()).To(BeEmpty())
+		session = podmanTest.PodmanExitCleanly("start", "--attach", "pinned")
+		Expect(session.OutputToString()).To(Equal("one"))
+	})
+
+	It("podman secret keeps the configured number of versions", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("mysecret"), 0o755)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("secret", "create", "versecret", secretFilePath)
+		for range 3 {
+			podmanTest.PodmanExitCleanly("secret", "update", "--keep-versions", "2", "versecret", secretFilePath)
+		}
+		session := podmanTest.PodmanExitCleanly("secret", "history", "--quiet", "versecret")
+		Expect(session.OutputToStringArray()).To(Equal([]string{"4", "3", "2"}))
+
+		podmanTest.PodmanExitCleanly("secret", "create", "--replace", "--keep-versions", "0", "versecret", secretFilePath)
+		session = podmanTest.PodmanExitCleanly("secret", "history", "--quiet", "versecret")
+		Expect(session.OutputToStringArray()).To(Equal([]string{"5"}))
+	})
 })
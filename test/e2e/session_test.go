@@ -0,0 +1,115 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	. "github.com/containers/podman/v6/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Podman session", func() {
+	BeforeEach(func() {
+		SkipIfRemote("session recordings are not supported for remote clients")
+	})
+
+	It("podman exec --record", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup).Should(ExitCleanly())
+		cid := setup.OutputToString()
+
+		session := podmanTest.Podman([]string{"exec", "--record", "test1", "sh", "-c", "echo hello; echo world >&2"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToString()).To(Equal("hello"))
+
+		session = podmanTest.Podman([]string{"session", "ls", "--filter", "container=test1", "--format", "{{.ID}} {{.Type}} {{.ContainerID}} {{.Command}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		lines := session.OutputToStringArray()
+		Expect(lines).To(HaveLen(1))
+		fields := lines[0]
+		Expect(fields).To(HaveSuffix(" exec " + cid + " sh -c echo hello; echo world >&2"))
+		id := fields[:12]
+
+		session = podmanTest.Podman([]string{"session", "show", "--speed", "0", id})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(ContainSubstring("hello"))
+		Expect(session.OutputToString()).To(ContainSubstring("world"))
+
+		session = podmanTest.Podman([]string{"session", "ls", "--format", "{{.Path}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		path := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"events", "--stream=false", "--filter", "event=exec", "--filter", "container=" + cid, "--format", "{{index .Attributes \"recording\"}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal(path))
+
+		session = podmanTest.Podman([]string{"session", "rm", id})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"session", "ls", "-q"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(BeEmpty())
+
+		session = podmanTest.Podman([]string{"session", "show", id})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `no session recording with ID "`+id+`" found: no such session recording`))
+	})
+
+	It("podman exec without --record is not recorded", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup).Should(ExitCleanly())
+
+		session := podmanTest.Podman([]string{"exec", "test1", "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"session", "ls", "-q"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(BeEmpty())
+	})
+
+	It("podman --record-sessions records interactive sessions", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup).Should(ExitCleanly())
+
+		session := podmanTest.Podman([]string{"--record-sessions", "exec", "test1", "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"session", "ls", "-q"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(BeEmpty())
+
+		session = podmanTest.Podman([]string{"--record-sessions", "exec", "-i", "test1", "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"session", "ls", "--format", "{{.Type}} {{.Command}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToStringArray()).To(Equal([]string{"exec true"}))
+	})
+
+	It("podman exec --record with --detach", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup).Should(ExitCleanly())
+
+		session := podmanTest.Podman([]string{"exec", "--record", "-d", "test1", "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "--record cannot be used with --detach or --no-session"))
+	})
+})
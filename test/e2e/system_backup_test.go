@@ -0,0 +1,83 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"path/filepath"
+
+	. "github.com/containers/podman/v6/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("podman system backup and restore", func() {
+	BeforeEach(func() {
+		SkipIfRemote("system backup and restore are not supported on podman --remote")
+	})
+
+	It("restores containers, pods, networks and volumes", func() {
+		archive := filepath.Join(podmanTest.TempDir, "backup.tar")
+
+		podmanTest.PodmanExitCleanly("network", "create", "backupnet")
+		podmanTest.PodmanExitCleanly("volume", "create", "backupvol")
+		podmanTest.PodmanExitCleanly("run", "-v", "backupvol:/data", ALPINE, "sh", "-c", "echo hello > /data/file")
+		podmanTest.PodmanExitCleanly("pod", "create", "--name", "backuppod")
+		podmanTest.PodmanExitCleanly("create", "--pod", "backuppod", "--name", "podctr", ALPINE, "top")
+		podmanTest.PodmanExitCleanly("create", "--network", "backupnet", "-v", "backupvol:/data", "--name", "netctr", ALPINE, "cat", "/data/file")
+		podmanTest.PodmanExitCleanly("create", "--requires", "netctr", "--name", "depctr", ALPINE, "true")
+		ctrID := podmanTest.PodmanExitCleanly("inspect", "--format", "{{.Id}}", "netctr").OutputToString()
+
+		session := podmanTest.PodmanExitCleanly("system", "backup", "--volume-data", archive)
+		Expect(session.OutputToString()).To(ContainSubstring("to " + archive))
+
+		podmanTest.PodmanExitCleanly("pod", "rm", "-f", "backuppod")
+		podmanTest.PodmanExitCleanly("rm", "-fa")
+		podmanTest.PodmanExitCleanly("volume", "rm", "-f", "backupvol")
+		podmanTest.PodmanExitCleanly("network", "rm", "backupnet")
+
+		session = podmanTest.PodmanExitCleanly("system", "restore", archive)
+		Expect(session.OutputToString()).To(ContainSubstring("container netctr: created"))
+		Expect(session.OutputToString()).To(ContainSubstring("pod backuppod: created"))
+
+		Expect(podmanTest.PodmanExitCleanly("inspect", "--format", "{{.Id}}", "netctr").OutputToString()).To(Equal(ctrID))
+		podmanTest.PodmanExitCleanly("network", "exists", "backupnet")
+		session = podmanTest.PodmanExitCleanly("pod", "inspect", "--format", "{{.NumContainers}}", "backuppod")
+		Expect(session.OutputToString()).To(Equal("2"))
+
+		session = podmanTest.PodmanExitCleanly("start", "--attach", "netctr")
+		Expect(session.OutputToString()).To(Equal("hello"))
+		podmanTest.PodmanExitCleanly("start", "depctr")
+	})
+
+	It("handles conflicts", func() {
+		archive := filepath.Join(podmanTest.TempDir, "backup.tar")
+
+		podmanTest.PodmanExitCleanly("create", "--name", "conflict", ALPINE, "true")
+		oldID := podmanTest.PodmanExitCleanly("inspect", "--format", "{{.Id}}", "conflict").OutputToString()
+		podmanTest.PodmanExitCleanly("system", "backup", archive)
+
+		session := podmanTest.Podman([]string{"system", "restore", archive})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "the following objects already exist: container conflict"))
+
+		session = podmanTest.PodmanExitCleanly("system", "restore", "--on-conflict=skip", archive)
+		Expect(session.OutputToString()).To(ContainSubstring("container conflict: skipped"))
+
+		podmanTest.PodmanExitCleanly("system", "restore", "--on-conflict=replace", archive)
+		Expect(podmanTest.PodmanExitCleanly("inspect", "--format", "{{.Id}}", "conflict").OutputToString()).To(Equal(oldID))
+
+		session = podmanTest.PodmanExitCleanly("system", "restore", "--keep-ids=false", "--keep-names=false", archive)
+		Expect(session.OutputToString()).To(ContainSubstring(": created"))
+		session = podmanTest.PodmanExitCleanly("ps", "-aq")
+		Expect(session.OutputToStringArray()).To(HaveLen(2))
+	})
+
+	It("rejects an invalid conflict policy", func() {
+		archive := filepath.Join(podmanTest.TempDir, "backup.tar")
+		podmanTest.PodmanExitCleanly("system", "backup", archive)
+
+		session := podmanTest.Podman([]string{"system", "restore", "--on-conflict=bogus", archive})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `invalid conflict policy "bogus"`))
+	})
+})
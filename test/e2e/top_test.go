@@ -3,9 +3,11 @@
 package integration
 
 import (
+	"encoding/json"
 	"os"
 	"os/user"
 
+	"github.com/containers/podman/v6/libpod/define"
 	. "github.com/containers/podman/v6/test/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -171,4 +173,51 @@ var _ = Describe("Podman top", func() {
 		Expect(result).Should(ExitCleanly())
 		Expect(result.OutputToStringArray()).To(Equal([]string{"EFFECTIVE CAPS", "full"}))
 	})
+
+	It("podman top resource usage", func() {
+		session := podmanTest.PodmanExitCleanly("run", "-d", "--name", "test", ALPINE, "sh", "-c", "top -d 2 & sleep inf")
+		cid := session.OutputToString()
+
+		result := podmanTest.PodmanExitCleanly("top", "--sort", "rss", "test")
+		lines := result.OutputToStringArray()
+		Expect(lines).To(HaveLen(4))
+		Expect(lines[0]).To(MatchRegexp(`^PID\s+PPID\s+STATE\s+%CPU\s+RSS\s+READ/S\s+WRITE/S\s+FDS\s+COMMAND$`))
+		Expect(result.OutputToString()).To(And(ContainSubstring("top"), ContainSubstring("sleep")))
+
+		result = podmanTest.PodmanExitCleanly("container", "top", "--format", "json", cid)
+		var processes []define.ContainerProcessStats
+		Expect(json.Unmarshal(result.Out.Contents(), &processes)).To(Succeed())
+		Expect(processes).To(HaveLen(3))
+		commands := []string{}
+		for _, p := range processes {
+			Expect(p.PID).To(BeNumerically(">", 1))
+			Expect(p.RSS).To(BeNumerically(">", 0))
+			commands = append(commands, p.Command)
+		}
+		Expect(commands).To(ContainElements("sh", "top", "sleep"))
+
+		result = podmanTest.Podman([]string{"top", "--sort", "mem", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitWithError(125, `invalid argument "mem" for "--sort" flag: "mem" is not a valid value`))
+
+		result = podmanTest.Podman([]string{"top", "--format", "json", "test", "pid"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitWithError(125, "format descriptors and ps(1) arguments cannot be used with --watch, --sort or --format"))
+	})
+
+	It("podman top --watch ends when the container stops", func() {
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", "test", ALPINE, "sleep", "3")
+
+		result := podmanTest.PodmanExitCleanly("top", "--watch", "1", "--format", "json", "test")
+		lines := result.OutputToStringArray()
+		Expect(len(lines)).To(BeNumerically(">=", 2))
+		for _, line := range lines {
+			var processes []define.ContainerProcessStats
+			Expect(json.Unmarshal([]byte(line), &processes)).To(Succeed())
+		}
+
+		result = podmanTest.Podman([]string{"top", "--watch", "1", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitWithError(125, "unless it is running"))
+	})
 })
@@ -0,0 +1,53 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v6/pkg/trace"
+	. "github.com/containers/podman/v6/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.podman.io/common/pkg/seccomp"
+)
+
+var _ = Describe("podman trace", func() {
+	BeforeEach(func() {
+		SkipIfRemote("trace is not supported on podman --remote")
+	})
+
+	It("podman trace on a stopped container", func() {
+		podmanTest.PodmanExitCleanly("create", "--name", "tracectr", ALPINE, "top")
+
+		session := podmanTest.Podman([]string{"trace", "tracectr"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "can only trace running containers"))
+	})
+
+	It("podman trace reports events and generates a seccomp profile", func() {
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", "tracectr", ALPINE, "sh", "-c", "sleep 5; cat /etc/hostname; sleep 1")
+
+		profilePath := filepath.Join(podmanTest.TempDir, "seccomp.json")
+		session := podmanTest.PodmanExitCleanly("trace", "--generate-seccomp", profilePath, "tracectr")
+
+		var events []trace.Event
+		for _, line := range session.OutputToStringArray() {
+			var ev trace.Event
+			Expect(json.Unmarshal([]byte(line), &ev)).To(Succeed())
+			events = append(events, ev)
+		}
+		Expect(events).To(ContainElement(And(HaveField("Type", trace.EventExec), HaveField("Path", "/bin/cat"))))
+		Expect(events).To(ContainElement(And(HaveField("Type", trace.EventOpen), HaveField("Path", "/etc/hostname"))))
+
+		data, err := os.ReadFile(profilePath)
+		Expect(err).ToNot(HaveOccurred())
+		var profile seccomp.Seccomp
+		Expect(json.Unmarshal(data, &profile)).To(Succeed())
+		Expect(profile.DefaultAction).To(Equal(seccomp.ActErrno))
+		Expect(profile.Syscalls).To(HaveLen(1))
+		Expect(profile.Syscalls[0].Names).To(ContainElements("execve", "openat"))
+	})
+})
@@ -244,4 +244,19 @@ var _ = Describe("Podman volume ls", func() {
 		Expect(session.OutputToStringArray()[0]).To(Equal(vol2))
 		Expect(session.OutputToStringArray()[1]).To(Equal(vol3))
 	})
+
+	It("podman ls volume with --where", func() {
+		podmanTest.PodmanExitCleanly("volume", "create", "--label", "tier=web", "vol1")
+		podmanTest.PodmanExitCleanly("volume", "create", "vol2")
+
+		session := podmanTest.PodmanExitCleanly("volume", "ls", "-q", "--where", `Labels["tier"] == "web"`)
+		Expect(session.OutputToStringArray()).To(Equal([]string{"vol1"}))
+
+		session = podmanTest.PodmanExitCleanly("volume", "ls", "-q", "--where", `Driver == "local" && !has(Labels.tier)`)
+		Expect(session.OutputToStringArray()).To(Equal([]string{"vol2"}))
+
+		session = podmanTest.Podman([]string{"volume", "ls", "--where", `Labels.tier > 1`})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "cannot compare a string to a number"))
+	})
 })
@@ -112,6 +112,75 @@ EOF
     run_podman image prune -f
 }
 
+@test "farm - build --dry-run prints the schedule" {
+    run_podman info --format '{{.Host.Arch}}'
+    ARCH=$output
+
+    # The local machine is preferred, the farm node is the fallback
+    run_podman farm build --dry-run $FARM_TMPDIR
+    assert "$output" =~ "Farm \"$FARMNAME\" ready"
+    assert "$output" =~ "PLATFORM +NODE +NATIVE +CPUS +FREE MEMORY +RUNNING BUILDS +FALLBACKS"
+    assert "$output" =~ "linux/$ARCH +local +true +[0-9]+ +.* +[0-9]+ +test-node"
+    assert "$output" !~ "Starting build" "no build is started"
+
+    run_podman farm build --dry-run --local=false $FARM_TMPDIR
+    assert "$output" =~ "linux/$ARCH +test-node +true +[0-9]+ +.* +[0-9]+ +-"
+
+    run_podman 125 farm build --dry-run --local=false --platforms linux/bogus $FARM_TMPDIR
+    assert "$output" =~ "no builder capable of building for platform \"linux/bogus\" available"
+}
+
+@test "farm - layer cache config" {
+    run_podman farm create --cache-repo $REGISTRY/cache cache-farm test-node
+    run_podman farm ls --format '{{.Name}} {{.Cache}}'
+    assert "$output" =~ "cache-farm $REGISTRY/cache"
+
+    run_podman system connection add cache-node tcp://cache.example.com:8080
+    run_podman farm update --cache-node cache-node --cache-port 5001 cache-farm
+    run_podman farm ls --format '{{.Name}} {{.Cache}}'
+    assert "$output" =~ "cache-farm node cache-node:5001"
+
+    run_podman 125 farm update --cache-repo $REGISTRY/cache:tag cache-farm
+    assert "$output" =~ "must not have a tag or digest"
+    run_podman 125 farm update --cache-node bogus cache-farm
+    assert "$output" =~ "invalid cache node"
+    run_podman 125 farm update --cache-node test-node cache-farm
+    assert "$output" =~ "connection \"test-node\" is to the local host localhost"
+
+    run_podman farm update --cache-node "" cache-farm
+    run_podman farm ls --format '{{.Name}}:{{.Cache}}'
+    assert "$output" =~ "cache-farm:$"
+
+    run_podman farm rm cache-farm
+    run_podman system connection rm cache-node
+}
+
+@test "farm - build shares the layer cache" {
+    iname="test-image-cache"
+    # Layers which were never built before, so the only cache is the shared one
+    context=$PODMAN_TMPDIR/cache-context
+    mkdir -p $context
+    cat >$context/Containerfile <<EOF
+FROM $IMAGE
+RUN echo $(random_string) > /random.txt
+RUN arch | tee /arch.txt
+EOF
+    run_podman farm create --cache-repo $REGISTRY/cache cache-farm test-node
+    run_podman farm build --farm cache-farm --local=false --authfile $AUTHFILE --tls-verify=false -t $REGISTRY/$iname $context
+    assert "$output" =~ "Sharing the layer cache through \"$REGISTRY/cache\""
+    assert "$output" =~ "Layer cache:"
+    assert "$output" =~ "on \"test-node\": 3 steps, 0 cached, 0 pulled from and 2 pushed to the shared cache"
+
+    # The local build finds the layers pushed by the farm node
+    run_podman farm update --remove test-node cache-farm
+    run_podman farm build --farm cache-farm --authfile $AUTHFILE --tls-verify=false -t $REGISTRY/$iname $context
+    assert "$output" =~ "on \"local\": 3 steps, 2 cached, 2 pulled from and 0 pushed to the shared cache"
+
+    run_podman farm rm cache-farm
+    run_podman manifest rm $iname
+    run_podman image prune -f
+}
+
 # Test out podman-remote
 
 @test "farm - build on farm node only (podman-remote)" {
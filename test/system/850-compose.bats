@@ -35,18 +35,30 @@ EOF
     # Make the fake one executable and check the --help output
     chmod +x $fake_compose_bin
     PODMAN_COMPOSE_PROVIDER=$fake_compose_bin run_podman compose --help
-    is "$output" "Run compose workloads via an external provider .*arguments: --help"
+    is "$output" "Run compose workloads.*arguments: --help"
 
     # No argument yields the help message as well
     PODMAN_COMPOSE_PROVIDER=$fake_compose_bin run_podman compose
-    is "$output" "Run compose workloads via an external provider .*arguments: "
+    is "$output" "Run compose workloads.*arguments: "
+
+    # PODMAN_COMPOSE_PROVIDER passes natively implemented commands as well
+    PODMAN_COMPOSE_PROVIDER=$fake_compose_bin run_podman compose up -d
+    is "$output" "arguments: up -d"
 
     # Make sure that the provider can be specified via containers.conf and that
     # the warning logs can be turned off
-    CONTAINERS_CONF_OVERRIDE=$compose_conf run_podman compose --help
-    is "$output" "Run compose workloads via an external provider .*arguments: --help"
+    CONTAINERS_CONF_OVERRIDE=$compose_conf run_podman compose pull
+    is "$output" "arguments: pull"
     assert "$output" !~ ".*Executing external compose provider.*"
 
+    # Flags of the native compose engine and unknown ones are passed as given
+    CONTAINERS_CONF_OVERRIDE=$compose_conf run_podman compose -f /path/to/file --verbose up
+    is "$output" "arguments: -f /path/to/file --verbose up"
+
+    # The help of commands of the provider is the one of the provider
+    CONTAINERS_CONF_OVERRIDE=$compose_conf run_podman compose build --help
+    is "$output" "arguments: build --help"
+
     # Run with bogus arguments and make sure they're being returned
     CONTAINERS_CONF_OVERRIDE=$compose_conf run_podman compose $random_data
     is "$output" "arguments: $random_data"
@@ -81,3 +93,94 @@ EOF
     is "${lines[1]}" "0"
     is "${lines[2]}" "$random_data"
 }
+
+@test "podman compose - native engine" {
+    project=p-$(safename)
+    compose_dir="$PODMAN_TMPDIR/$project"
+    mkdir -p $compose_dir
+    echo "MESSAGE=hello from $project" > $compose_dir/.env
+    cat >$compose_dir/compose.yaml <<EOF
+services:
+  db:
+    image: $IMAGE
+    command: ["sh", "-c", "touch /tmp/ready; sleep inf"]
+    healthcheck:
+      test: ["CMD", "test", "-e", "/tmp/ready"]
+      interval: 1s
+      retries: 30
+  web:
+    image: $IMAGE
+    command: ["sh", "-c", "echo \$\$MESSAGE; cat /run/secrets/token; echo; sleep inf"]
+    environment:
+      MESSAGE: \${MESSAGE}
+    depends_on:
+      db:
+        condition: service_healthy
+    volumes:
+      - data:/data
+    secrets:
+      - token
+  debug:
+    image: $IMAGE
+    profiles: [debug]
+volumes:
+  data:
+secrets:
+  token:
+    content: s3cr3t
+EOF
+
+    # Without PODMAN_COMPOSE_PROVIDER the help of the native engine is shown
+    run_podman compose --help
+    is "$output" "Run compose workloads.*Commands:.*config.*up .*"
+    run_podman compose up --help
+    is "$output" "Create and start the containers of the project.*--detach.*"
+
+    run_podman 125 compose --project-directory $compose_dir down extra
+    is "$output" "Error: .*compose down. takes no arguments"
+
+    run_podman compose --project-directory $compose_dir config --services
+    is "$output" "db
+web" "services without inactive profiles"
+
+    run_podman compose --project-directory $compose_dir --profile debug config --services
+    is "$output" "db
+debug
+web" "services with the debug profile"
+
+    run_podman compose --project-directory $compose_dir config
+    assert "$output" =~ "MESSAGE: hello from $project" "environment is interpolated"
+
+    run_podman compose --project-directory $compose_dir up -d
+    assert "$output" =~ "Container $project-db-1  Healthy" "web waits for db to become healthy"
+    assert "$output" =~ "Container $project-web-1  Started"
+
+    run_podman compose --project-directory $compose_dir ps --format '{{.Name}} {{.Service}}'
+    is "$output" "$project-db-1 db
+$project-web-1 web"
+
+    run_podman pod ps --filter name=pod_$project --format '{{.Name}} {{.NumberOfContainers}}'
+    is "$output" "pod_$project 2"
+    run_podman volume exists ${project}_data
+    run_podman network exists ${project}_default
+
+    wait_for_output "s3cr3t" $project-web-1
+    run_podman compose --project-directory $compose_dir logs web
+    assert "$output" =~ "$project-web-1 hello from $project"
+    assert "$output" =~ "$project-web-1 s3cr3t"
+
+    # A second up keeps unchanged containers
+    run_podman inspect --format '{{.Id}}' $project-web-1
+    cid="$output"
+    run_podman compose --project-directory $compose_dir up -d
+    run_podman inspect --format '{{.Id}}' $project-web-1
+    is "$output" "$cid" "unchanged container is not recreated"
+
+    run_podman compose --project-directory $compose_dir down -v -t 0
+    run_podman ps -a --filter label=com.docker.compose.project=$project --format '{{.Names}}'
+    is "$output" "" "no containers left"
+    run_podman 1 pod exists pod_$project
+    run_podman 1 volume exists ${project}_data
+    run_podman 1 network exists ${project}_default
+    run_podman 1 secret exists ${project}_token
+}